@@ -337,6 +337,16 @@ describe('getCompletionItems()', () => {
                 insertText: 'has.contributor(${1}) ',
                 label: 'has.contributor(...)',
             },
+            {
+                // eslint-disable-next-line no-template-curly-in-string
+                insertText: 'has.symbol(kind:${1:function} name:${2}) ',
+                label: 'has.symbol(...)',
+            },
+            {
+                // eslint-disable-next-line no-template-curly-in-string
+                insertText: 'has.codeowners.issue(${1:unowned}) ',
+                label: 'has.codeowners.issue(...)',
+            },
             {
                 insertText: '^connect\\.go$ ',
                 label: 'connect.go',
@@ -360,7 +370,14 @@ describe('getCompletionItems()', () => {
                     {}
                 )
             )?.suggestions.map(({ filterText }) => filterText)
-        ).toStrictEqual(['has.content(...)', 'has.owner(...)', 'has.contributor(...)', '^jsonrpc'])
+        ).toStrictEqual([
+            'has.content(...)',
+            'has.owner(...)',
+            'has.contributor(...)',
+            'has.symbol(...)',
+            'has.codeowners.issue(...)',
+            '^jsonrpc',
+        ])
     })
 
     test('includes file path in insertText when completing filter value', async () => {
@@ -386,6 +403,10 @@ describe('getCompletionItems()', () => {
             'has.owner(${1}) ',
             // eslint-disable-next-line no-template-curly-in-string
             'has.contributor(${1}) ',
+            // eslint-disable-next-line no-template-curly-in-string
+            'has.symbol(kind:${1:function} name:${2}) ',
+            // eslint-disable-next-line no-template-curly-in-string
+            'has.codeowners.issue(${1:unowned}) ',
             '^some/path/main\\.go$ ',
         ])
     })
@@ -415,6 +436,7 @@ describe('getCompletionItems()', () => {
               "has.commit.after(\${1:1 month ago}) ",
               "has.description(\${1}) ",
               "has.meta(\${1:key}:\${2:value}) ",
+              "has.symbol(kind:\${1:function} name:\${2}) ",
               "^repo/with\\\\ a\\\\ space$ "
             ]
         `)
@@ -438,7 +460,8 @@ describe('getCompletionItems()', () => {
               "has.topic(\${1}) ",
               "has.commit.after(\${1:1 month ago}) ",
               "has.description(\${1}) ",
-              "has.meta(\${1:key}:\${2:value}) "
+              "has.meta(\${1:key}:\${2:value}) ",
+              "has.symbol(kind:\${1:function} name:\${2}) "
             ]
         `)
     })
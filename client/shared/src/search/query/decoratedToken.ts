@@ -1015,6 +1015,7 @@ const decoratePredicateBody = (path: string[], body: string, offset: number): De
         }
         case 'has.tag':
         case 'has.owner':
+        case 'has.codeowners.issue':
         case 'has.key':
         case 'has.topic':
             return [
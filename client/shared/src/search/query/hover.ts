@@ -186,6 +186,10 @@ const toPredicateHover = (token: MetaPredicate): string => {
             return '**Built-in predicate**. DEPRECATED: Use "has.meta({key})" instead. Search only inside repositories that are associated with the given key, regardless of its value'
         case 'has.owner':
             return '**Built-in predicate**. Search only inside files that are owned by the given person or team'
+        case 'has.codeowners.issue':
+            return '**Built-in predicate**. Search only inside files whose ownership per the CODEOWNERS file has an issue: `unowned` for files without owners, `unresolved-owner` for files with owners that match no user, team or code host account, or any of these if empty'
+        case 'has.symbol':
+            return '**Built-in predicate**. Search only inside repositories or files that define a symbol matching the given `name:` regular expression and `kind:`'
     }
     return ''
 }
@@ -42,6 +42,7 @@ export const PREDICATES: Access[] = [
                     { name: 'key' },
                     { name: 'meta' },
                     { name: 'topic' },
+                    { name: 'symbol' },
                 ],
             },
         ],
@@ -55,7 +56,15 @@ export const PREDICATES: Access[] = [
             },
             {
                 name: 'has',
-                fields: [{ name: 'content' }, { name: 'owner' }],
+                fields: [
+                    { name: 'content' },
+                    { name: 'owner' },
+                    { name: 'symbol' },
+                    {
+                        name: 'codeowners',
+                        fields: [{ name: 'issue' }],
+                    },
+                ],
             },
         ],
     },
@@ -219,6 +228,12 @@ export const predicateCompletion = (field: string): Completion[] => {
                     'Search only inside repositories having ({key}:{value}) pair, or ({key}) with any value or ({key}:) with no value metadata',
                 asSnippet: true,
             },
+            {
+                label: 'has.symbol(...)',
+                insertText: 'has.symbol(kind:${1:function} name:${2})',
+                asSnippet: true,
+                description: 'Search only inside repositories that define a matching symbol',
+            },
         ]
     }
     if (field === 'file') {
@@ -241,6 +256,18 @@ export const predicateCompletion = (field: string): Completion[] => {
                 asSnippet: true,
                 description: 'Search only inside files that have a contributor that matches a pattern',
             },
+            {
+                label: 'has.symbol(...)',
+                insertText: 'has.symbol(kind:${1:function} name:${2})',
+                asSnippet: true,
+                description: 'Search only inside files that define a matching symbol',
+            },
+            {
+                label: 'has.codeowners.issue(...)',
+                insertText: 'has.codeowners.issue(${1:unowned})',
+                asSnippet: true,
+                description: 'Search only inside files without owners or with unresolved owners in CODEOWNERS',
+            },
         ]
     }
     return []
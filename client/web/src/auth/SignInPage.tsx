@@ -88,6 +88,7 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
         !showMoreProviders && (moreProviders.length > 0 || (primaryProviders.length > 0 && builtInAuthProvider))
 
     const providers = showMoreProviders ? moreProviders : primaryProviders
+    const showBuiltinForm = builtInAuthProvider && (showMoreProviders || thirdPartyAuthProviders.length === 0)
 
     const body = !hasProviders ? (
         <Alert className="mt-3" variant="info">
@@ -115,7 +116,7 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
                         </Button>
                     </div>
                 )}
-                {builtInAuthProvider && (showMoreProviders || thirdPartyAuthProviders.length === 0) && (
+                {showBuiltinForm && (
                     <UsernamePasswordSignInForm
                         {...props}
                         onAuthError={setError}
@@ -125,29 +126,42 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
                 {builtInAuthProvider && showMoreProviders && providers.length > 0 && (
                     <OrDivider className="mb-3 py-1" />
                 )}
-                {providers.map((provider, index) => (
+                {providers.map((provider, index) =>
                     // Use index as key because display name may not be unique. This is OK
                     // here because this list will not be updated during this component's lifetime.
                     /* eslint-disable react/no-array-index-key */
-                    <div className="mb-2" key={index}>
-                        <Button
-                            to={provider.authenticationURL}
-                            display="block"
-                            variant={showMoreProviders ? 'secondary' : 'primary'}
-                            as={AnchorLink}
-                        >
-                            {provider.serviceType === 'github' && <Icon aria-hidden={true} svgPath={mdiGithub} />}
-                            {provider.serviceType === 'gitlab' && <Icon aria-hidden={true} svgPath={mdiGitlab} />}
-                            {provider.serviceType === 'bitbucketCloud' && (
-                                <Icon aria-hidden={true} svgPath={mdiBitbucket} />
-                            )}
-                            {provider.serviceType === 'azuredevops' && (
-                                <Icon aria-hidden={true} svgPath={mdiMicrosoftAzureDevops} />
-                            )}{' '}
-                            {provider.displayPrefix ?? 'Continue with'} {provider.displayName}
-                        </Button>
-                    </div>
-                ))}
+                    provider.serviceType === 'ldap' ? (
+                        // LDAP providers check the username and password of users, so they have
+                        // their own sign-in form instead of a link.
+                        <UsernamePasswordSignInForm
+                            {...props}
+                            key={index}
+                            provider={provider}
+                            onAuthError={setError}
+                            autoFocus={index === 0 && !showBuiltinForm}
+                            className="mb-3"
+                        />
+                    ) : (
+                        <div className="mb-2" key={index}>
+                            <Button
+                                to={provider.authenticationURL}
+                                display="block"
+                                variant={showMoreProviders ? 'secondary' : 'primary'}
+                                as={AnchorLink}
+                            >
+                                {provider.serviceType === 'github' && <Icon aria-hidden={true} svgPath={mdiGithub} />}
+                                {provider.serviceType === 'gitlab' && <Icon aria-hidden={true} svgPath={mdiGitlab} />}
+                                {provider.serviceType === 'bitbucketCloud' && (
+                                    <Icon aria-hidden={true} svgPath={mdiBitbucket} />
+                                )}
+                                {provider.serviceType === 'azuredevops' && (
+                                    <Icon aria-hidden={true} svgPath={mdiMicrosoftAzureDevops} />
+                                )}{' '}
+                                {provider.displayPrefix ?? 'Continue with'} {provider.displayName}
+                            </Button>
+                        </div>
+                    )
+                )}
                 {showMoreWaysToLogin && (
                     <div className="mb-2">
                         <Button display="block" variant="secondary" onClick={() => toggleMoreProviders(true)}>
@@ -122,7 +122,10 @@ export const SignUpForm: React.FunctionComponent<React.PropsWithChildren<SignUpF
         [onSignUp, disabled, emailState, usernameState, passwordState]
     )
 
-    const externalAuthProviders = context.authProviders.filter(provider => !provider.isBuiltin)
+    // LDAP users sign up by signing in with the form of the sign-in page.
+    const externalAuthProviders = context.authProviders.filter(
+        provider => !provider.isBuiltin && provider.serviceType !== 'ldap'
+    )
 
     const onClickExternalAuthSignup = useCallback(
         (type: AuthProvider['serviceType']) => () => {
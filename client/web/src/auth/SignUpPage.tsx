@@ -78,6 +78,11 @@ export const SignUpPage: React.FunctionComponent<React.PropsWithChildren<SignUpP
             },
             body: JSON.stringify(args),
         }).then(response => {
+            if (response.status === 428) {
+                // The account was created, but a second factor must be enrolled when signing in.
+                window.location.replace(`/sign-in?returnTo=${encodeURIComponent(returnTo)}`)
+                return Promise.resolve()
+            }
             if (response.status !== 200) {
                 return response.text().then(text => Promise.reject(new Error(text)))
             }
@@ -4,9 +4,9 @@ import classNames from 'classnames'
 import { useLocation } from 'react-router-dom'
 
 import { asError, logger } from '@sourcegraph/common'
-import { Label, Button, LoadingSpinner, Link, Text, Input, Form } from '@sourcegraph/wildcard'
+import { Label, Button, LoadingSpinner, Link, Text, Input, Form, Alert, Code } from '@sourcegraph/wildcard'
 
-import { SourcegraphContext } from '../jscontext'
+import { AuthProvider, SourcegraphContext } from '../jscontext'
 import { eventLogger } from '../tracking/eventLogger'
 
 import { getReturnTo, PasswordInput } from './SignInSignUpCommon'
@@ -18,20 +18,45 @@ interface Props {
         'allowSignup' | 'authProviders' | 'sourcegraphDotComMode' | 'xhrHeaders' | 'resetPasswordEnabled'
     >
     className?: string
+    /**
+     * The LDAP auth provider to sign in with. When unset, the form signs in with the builtin
+     * auth provider.
+     */
+    provider?: AuthProvider
+    autoFocus?: boolean
 }
 
 /**
- * The form for signing in with a username and password.
+ * The response of the builtin auth provider to a sign-in request with a correct password, when the user
+ * needs to provide or enroll a second factor (HTTP 428), or just enrolled one (HTTP 200).
+ */
+interface SecondFactorResponse {
+    totpRequired?: boolean
+    totpEnrollment?: { secret: string; provisioningURI: string }
+    recoveryCodes?: string[]
+}
+
+/**
+ * The form for signing in with a username and password, checked by the builtin auth provider
+ * or an LDAP auth provider.
  */
 export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWithChildren<Props>> = ({
     onAuthError,
     className,
     context,
+    provider,
+    autoFocus = true,
 }) => {
     const location = useLocation()
+    // Make IDs unique when there are several forms on the page.
+    const passwordID = provider ? `password-${provider.serviceID}` : 'password'
     const [usernameOrEmail, setUsernameOrEmail] = useState('')
     const [password, setPassword] = useState('')
     const [loading, setLoading] = useState(false)
+    const [secondFactor, setSecondFactor] = useState<SecondFactorResponse>()
+    const [totpCode, setTOTPCode] = useState('')
+    const [useRecoveryCode, setUseRecoveryCode] = useState(false)
+    const [recoveryCodes, setRecoveryCodes] = useState<string[]>()
 
     const onUsernameOrEmailFieldChange = useCallback((event: React.ChangeEvent<HTMLInputElement>): void => {
         setUsernameOrEmail(event.target.value)
@@ -41,6 +66,19 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
         setPassword(event.target.value)
     }, [])
 
+    const onTOTPCodeFieldChange = useCallback((event: React.ChangeEvent<HTMLInputElement>): void => {
+        setTOTPCode(event.target.value)
+    }, [])
+
+    const redirectAfterSignIn = useCallback((): void => {
+        if (new URLSearchParams(location.search).get('close') === 'true') {
+            window.close()
+        } else {
+            const returnTo = getReturnTo(location)
+            window.location.replace(returnTo)
+        }
+    }, [location])
+
     const handleSubmit = useCallback(
         (event: React.FormEvent<HTMLFormElement>): void => {
             event.preventDefault()
@@ -50,7 +88,7 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
 
             setLoading(true)
             eventLogger.log('InitiateSignIn')
-            fetch('/-/sign-in', {
+            fetch(provider ? provider.authenticationURL : '/-/sign-in', {
                 credentials: 'same-origin',
                 method: 'POST',
                 headers: {
@@ -58,21 +96,39 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                     Accept: 'application/json',
                     'Content-Type': 'application/json',
                 },
-                body: JSON.stringify({
-                    email: usernameOrEmail,
-                    password,
-                }),
+                body: JSON.stringify(
+                    provider
+                        ? { username: usernameOrEmail, password }
+                        : {
+                              email: usernameOrEmail,
+                              password,
+                              totpCode: secondFactor && !useRecoveryCode ? totpCode : undefined,
+                              recoveryCode: secondFactor && useRecoveryCode ? totpCode : undefined,
+                          }
+                ),
             })
-                .then(response => {
+                .then(async response => {
                     if (response.status === 200) {
-                        if (new URLSearchParams(location.search).get('close') === 'true') {
-                            window.close()
-                        } else {
-                            const returnTo = getReturnTo(location)
-                            window.location.replace(returnTo)
+                        const body = (await response.text()) || '{}'
+                        const { recoveryCodes } = JSON.parse(body) as SecondFactorResponse
+                        if (recoveryCodes) {
+                            // The user enrolled an authenticator app while signing in, show the recovery
+                            // codes before continuing.
+                            setRecoveryCodes(recoveryCodes)
+                            setLoading(false)
+                            return
                         }
+                        redirectAfterSignIn()
+                    } else if (response.status === 428) {
+                        setSecondFactor((await response.json()) as SecondFactorResponse)
+                        setTOTPCode('')
+                        setLoading(false)
                     } else if (response.status === 401) {
-                        throw new Error('User or password was incorrect')
+                        throw new Error(
+                            secondFactor
+                                ? 'The two-factor authentication code was incorrect'
+                                : 'User or password was incorrect'
+                        )
                     } else if (response.status === 422) {
                         throw new Error('The account has been locked out')
                     } else {
@@ -85,21 +141,57 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                     onAuthError(asError(error))
                 })
         },
-        [usernameOrEmail, loading, location, password, onAuthError, context]
+        [
+            usernameOrEmail,
+            loading,
+            password,
+            onAuthError,
+            context,
+            provider,
+            secondFactor,
+            totpCode,
+            useRecoveryCode,
+            redirectAfterSignIn,
+        ]
     )
 
+    if (recoveryCodes) {
+        return (
+            <div className={className}>
+                <Alert variant="success">Two-factor authentication is now enabled for your account.</Alert>
+                <Text alignment="left">
+                    Store these recovery codes in a safe place. Each code can be used once to sign in if you lose
+                    access to your authenticator app. They will not be shown again.
+                </Text>
+                <Code className="d-block mb-3 text-left">
+                    {recoveryCodes.map(code => (
+                        <div key={code}>{code}</div>
+                    ))}
+                </Code>
+                <Button display="block" variant="primary" onClick={redirectAfterSignIn}>
+                    Continue
+                </Button>
+            </div>
+        )
+    }
+
     return (
         <>
             <Form onSubmit={handleSubmit} className={className}>
+                {provider && (
+                    <Text alignment="left" weight="medium">
+                        {provider.displayPrefix ?? 'Sign in with'} {provider.displayName}
+                    </Text>
+                )}
                 <Input
-                    id="username-or-email"
-                    label={<Text alignment="left">Username or email</Text>}
+                    id={provider ? `username-${provider.serviceID}` : 'username-or-email'}
+                    label={<Text alignment="left">{provider ? 'Username' : 'Username or email'}</Text>}
                     onChange={onUsernameOrEmailFieldChange}
                     required={true}
                     value={usernameOrEmail}
                     disabled={loading}
                     autoCapitalize="off"
-                    autoFocus={true}
+                    autoFocus={autoFocus}
                     className="form-group"
                     // There is no well supported way to declare username OR email here.
                     // Using username seems to be the best approach and should still support this behaviour.
@@ -108,10 +200,11 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                 />
 
                 <div className="form-group d-flex flex-column align-content-start position-relative">
-                    <Label htmlFor="password" className="align-self-start">
+                    <Label htmlFor={passwordID} className="align-self-start">
                         Password
                     </Label>
                     <PasswordInput
+                        id={passwordID}
                         onChange={onPasswordFieldChange}
                         value={password}
                         required={true}
@@ -119,16 +212,60 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                         autoComplete="current-password"
                         placeholder=" "
                     />
-                    {context.resetPasswordEnabled && (
+                    {!provider && context.resetPasswordEnabled && (
                         <small className="form-text text-muted align-self-end position-absolute">
                             <Link to="/password-reset">Forgot password?</Link>
                         </small>
                     )}
                 </div>
 
+                {secondFactor?.totpEnrollment && (
+                    <div className="form-group text-left">
+                        <Text>
+                            Two-factor authentication is required. Add this account to your authenticator app by
+                            opening the <Link to={secondFactor.totpEnrollment.provisioningURI}>setup link</Link> on
+                            your device or by entering this key:
+                        </Text>
+                        <Code className="d-block mb-2">{secondFactor.totpEnrollment.secret}</Code>
+                        <Text className="mb-0">Then enter the code shown by the app to finish signing in.</Text>
+                    </div>
+                )}
+                {secondFactor && (
+                    <div className="form-group d-flex flex-column align-content-start">
+                        <Input
+                            id="totp-code"
+                            label={
+                                <Text alignment="left">
+                                    {useRecoveryCode ? 'Recovery code' : 'Authentication code'}
+                                </Text>
+                            }
+                            onChange={onTOTPCodeFieldChange}
+                            required={true}
+                            value={totpCode}
+                            disabled={loading}
+                            autoFocus={true}
+                            autoComplete="one-time-code"
+                            inputMode={useRecoveryCode ? 'text' : 'numeric'}
+                        />
+                        {secondFactor.totpRequired && (
+                            <Button
+                                variant="link"
+                                size="sm"
+                                className="align-self-end p-0"
+                                onClick={() => {
+                                    setUseRecoveryCode(!useRecoveryCode)
+                                    setTOTPCode('')
+                                }}
+                            >
+                                {useRecoveryCode ? 'Use your authenticator app' : 'Use a recovery code'}
+                            </Button>
+                        )}
+                    </div>
+                )}
+
                 <div className={classNames('form-group', 'mb-0')}>
                     <Button display="block" type="submit" disabled={loading} variant="primary">
-                        {loading ? <LoadingSpinner /> : 'Sign in'}
+                        {loading ? <LoadingSpinner /> : secondFactor ? 'Verify' : 'Sign in'}
                     </Button>
                 </div>
             </Form>
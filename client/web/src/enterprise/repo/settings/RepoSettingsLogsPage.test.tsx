@@ -29,6 +29,7 @@ const mockRepo: SettingsAreaRepositoryFields = {
         updatedAt: '2023-07-31T10:24:00Z',
         isCorrupted: false,
         corruptionLogs: [],
+        integrityCheck: null,
         lastError: '',
         lastSyncOutput: '',
         updateSchedule: {
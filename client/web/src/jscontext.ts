@@ -22,6 +22,7 @@ export interface AuthProvider {
         | 'builtin'
         | 'gerrit'
         | 'azuredevops'
+        | 'ldap'
     displayName: string
     displayPrefix?: string
     isBuiltin: boolean
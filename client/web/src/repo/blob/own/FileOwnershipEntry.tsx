@@ -9,6 +9,7 @@ import { Button, ButtonLink, Icon, LinkOrSpan, Tooltip } from '@sourcegraph/wild
 
 import {
     AssignedOwnerFields,
+    BlameOwnershipSignalFields,
     CodeownersFileEntryFields,
     OwnerFields,
     RecentContributorOwnershipSignalFields,
@@ -37,6 +38,7 @@ type OwnershipReason =
     | CodeownersFileEntryFields
     | RecentContributorOwnershipSignalFields
     | RecentViewOwnershipSignalFields
+    | BlameOwnershipSignalFields
     | AssignedOwnerFields
 
 export const FileOwnershipEntry: React.FunctionComponent<Props> = ({
@@ -151,8 +153,10 @@ export const FileOwnershipEntry: React.FunctionComponent<Props> = ({
 const getOwnershipReasonPriority = (reason: OwnershipReason): number => {
     switch (reason.__typename ?? '') {
         case 'CodeownersFileEntry':
-            return 4
+            return 5
         case 'AssignedOwner':
+            return 4
+        case 'BlameOwnershipSignal':
             return 3
         case 'RecentContributorOwnershipSignal':
             return 2
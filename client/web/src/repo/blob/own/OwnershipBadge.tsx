@@ -4,6 +4,7 @@ import { Badge, BadgeProps, BadgeVariantType, Link } from '@sourcegraph/wildcard
 
 import {
     AssignedOwnerFields,
+    BlameOwnershipSignalFields,
     CodeownersFileEntryFields,
     RecentContributorOwnershipSignalFields,
     RecentViewOwnershipSignalFields,
@@ -16,6 +17,7 @@ interface Props {
         | CodeownersFileEntryFields
         | RecentContributorOwnershipSignalFields
         | RecentViewOwnershipSignalFields
+        | BlameOwnershipSignalFields
         | AssignedOwnerFields
 }
 
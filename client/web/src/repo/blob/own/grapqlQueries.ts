@@ -44,6 +44,14 @@ export const RECENT_VIEW_FIELDS = gql`
     }
 `
 
+export const BLAME_OWNERSHIP_FIELDS = gql`
+    fragment BlameOwnershipSignalFields on BlameOwnershipSignal {
+        title
+        description
+        linesCount
+    }
+`
+
 export const ASSIGNED_OWNER_FIELDS = gql`
     fragment AssignedOwnerFields on AssignedOwner {
         title
@@ -56,6 +64,7 @@ export const FETCH_OWNERS = gql`
     ${OWNER_FIELDS}
     ${RECENT_CONTRIBUTOR_FIELDS}
     ${RECENT_VIEW_FIELDS}
+    ${BLAME_OWNERSHIP_FIELDS}
     ${ASSIGNED_OWNER_FIELDS}
 
     fragment CodeownersFileEntryFields on CodeownersFileEntry {
@@ -79,6 +88,7 @@ export const FETCH_OWNERS = gql`
                         ...CodeownersFileEntryFields
                         ...RecentContributorOwnershipSignalFields
                         ...RecentViewOwnershipSignalFields
+                        ...BlameOwnershipSignalFields
                         ...AssignedOwnerFields
                     }
                 }
@@ -113,6 +123,7 @@ export const FETCH_TREE_OWNERS = gql`
     ${OWNER_FIELDS}
     ${RECENT_CONTRIBUTOR_FIELDS}
     ${RECENT_VIEW_FIELDS}
+    ${BLAME_OWNERSHIP_FIELDS}
     ${ASSIGNED_OWNER_FIELDS}
 
     fragment CodeownersFileEntryFields on CodeownersFileEntry {
@@ -134,6 +145,7 @@ export const FETCH_TREE_OWNERS = gql`
                 ...CodeownersFileEntryFields
                 ...RecentContributorOwnershipSignalFields
                 ...RecentViewOwnershipSignalFields
+                ...BlameOwnershipSignalFields
                 ...AssignedOwnerFields
             }
         }
@@ -14,6 +14,8 @@ export function isPackageServiceType(serviceType?: string): boolean {
         case 'rubyPackages':
         case 'goModules':
         case 'rustPackages':
+        case 'nugetPackages':
+        case 'phpPackages':
             return true
         default:
             return false
@@ -225,6 +225,16 @@ const CorruptionLogsContainer: FC<CorruptionLogProps> = props => {
         </>
     ) : null
 
+    const { integrityCheck } = props.repo.mirrorInfo
+    const lastIntegrityCheck = integrityCheck ? (
+        <Text className="mb-0">
+            The last integrity check <Timestamp date={integrityCheck.checkedAt} />{' '}
+            {integrityCheck.passed ? 'passed.' : 'failed, the repository will be recloned.'}
+        </Text>
+    ) : (
+        <Text className="mb-0 text-muted">The integrity of this repository was not checked yet.</Text>
+    )
+
     const logEvents: JSX.Element[] = props.repo.mirrorInfo.corruptionLogs.map(log => (
         <li key={`${props.repo.name}#${log.timestamp}`} className="list-group-item px-2 py-1">
             <div className="d-flex flex-column align-items-center justify-content-between">
@@ -247,6 +257,7 @@ const CorruptionLogsContainer: FC<CorruptionLogProps> = props => {
             details={
                 <div className="flex-1">
                     {health}
+                    {lastIntegrityCheck}
                     <Collapse isOpen={isOpened} onOpenChange={setIsOpened}>
                         <CollapseHeader
                             as={Button}
@@ -36,6 +36,10 @@ export const settingsAreaRepositoryFragment = gql`
                 timestamp
                 reason
             }
+            integrityCheck {
+                checkedAt
+                passed
+            }
             lastError
             lastSyncOutput
             updateSchedule {
@@ -1,6 +1,6 @@
 import React, { useEffect, useMemo, useState } from 'react'
 
-import { mdiCog, mdiFileOutline, mdiGlasses, mdiInformationOutline } from '@mdi/js'
+import { mdiCog, mdiFileDocumentOutline, mdiFileOutline, mdiGlasses, mdiInformationOutline } from '@mdi/js'
 import classNames from 'classnames'
 import { formatISO, subYears } from 'date-fns'
 import { capitalize, escapeRegExp } from 'lodash'
@@ -51,7 +51,12 @@ import { PersonLink } from '../../person/PersonLink'
 import { quoteIfNeeded, searchQueryForRepoRevision } from '../../search'
 import { buildSearchURLQueryFromQueryState, useNavbarQueryState } from '../../stores'
 import { canWriteRepoMetadata } from '../../util/rbac'
-import { OWNER_FIELDS, RECENT_CONTRIBUTOR_FIELDS, RECENT_VIEW_FIELDS } from '../blob/own/grapqlQueries'
+import {
+    BLAME_OWNERSHIP_FIELDS,
+    OWNER_FIELDS,
+    RECENT_CONTRIBUTOR_FIELDS,
+    RECENT_VIEW_FIELDS,
+} from '../blob/own/grapqlQueries'
 import { GitCommitNodeTableRow } from '../commits/GitCommitNodeTableRow'
 import { gitCommitFragment } from '../commits/RepositoryCommitsPage'
 import { getRefType, isPerforceChangelistMappingEnabled } from '../utils'
@@ -500,6 +505,7 @@ const OWNERS_QUERY = gql`
     ${OWNER_FIELDS}
     ${RECENT_CONTRIBUTOR_FIELDS}
     ${RECENT_VIEW_FIELDS}
+    ${BLAME_OWNERSHIP_FIELDS}
 
     query TreePageOwnership($repo: ID!, $first: Int, $revision: String!, $filePath: String!) {
         node(id: $repo) {
@@ -534,6 +540,7 @@ const OWNERS_QUERY = gql`
         reasons {
             ...RecentContributorOwnershipSignalFields
             ...RecentViewOwnershipSignalFields
+            ...BlameOwnershipSignalFields
         }
     }
 `
@@ -608,6 +615,7 @@ const OwnerNode: React.FC<OwnerNodeProps> = ({ node }) => {
     const owner = node?.owner
     const primaryReason =
         node.reasons.find(reason => reason.__typename === 'AssignedOwner') ||
+        node.reasons.find(reason => reason.__typename === 'BlameOwnershipSignal') ||
         node.reasons.find(reason => reason.__typename === 'RecentContributorOwnershipSignal') ||
         node.reasons[0]
     return (
@@ -637,6 +645,12 @@ const OwnerNode: React.FC<OwnerNodeProps> = ({ node }) => {
                         owner
                     </Badge>
                 )}
+                {primaryReason?.__typename === 'BlameOwnershipSignal' && (
+                    <Badge tooltip={primaryReason.description} className={styles.badge} variant="secondary">
+                        <Icon aria-label={primaryReason.title} svgPath={mdiFileDocumentOutline} />{' '}
+                        {primaryReason.linesCount} lines
+                    </Badge>
+                )}
                 {primaryReason?.__typename === 'RecentContributorOwnershipSignal' && (
                     <Badge tooltip={primaryReason.description} className={styles.badge} variant="secondary">
                         <Icon aria-label={primaryReason.title} svgPath={mdiFileOutline} /> changes
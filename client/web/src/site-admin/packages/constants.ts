@@ -33,6 +33,14 @@ export const ExternalServicePackageMap: Partial<
         label: 'Rust',
         value: PackageRepoReferenceKind.RUSTPACKAGES,
     },
+    [ExternalServiceKind.NUGETPACKAGES]: {
+        label: 'NuGet',
+        value: PackageRepoReferenceKind.NUGETPACKAGES,
+    },
+    [ExternalServiceKind.PHPPACKAGES]: {
+        label: 'PHP',
+        value: PackageRepoReferenceKind.PHPPACKAGES,
+    },
 }
 
 export const PackageExternalServiceMap: Partial<
@@ -68,4 +76,12 @@ export const PackageExternalServiceMap: Partial<
         label: 'Rust',
         value: ExternalServiceKind.RUSTPACKAGES,
     },
+    [PackageRepoReferenceKind.NUGETPACKAGES]: {
+        label: 'NuGet',
+        value: ExternalServiceKind.NUGETPACKAGES,
+    },
+    [PackageRepoReferenceKind.PHPPACKAGES]: {
+        label: 'PHP',
+        value: ExternalServiceKind.PHPPACKAGES,
+    },
 }
@@ -35,6 +35,7 @@ import { getPasswordRequirements } from '../../../util/security'
 import { CREATE_PASSWORD, USER_EXTERNAL_ACCOUNTS, UPDATE_PASSWORD } from '../backend'
 
 import { ExternalAccountsSignIn } from './ExternalAccountsSignIn'
+import { UserSettingsTOTPSection } from './UserSettingsTOTPSection'
 
 // pick only the fields we need
 export type UserExternalAccount = Pick<
@@ -306,6 +307,11 @@ export const UserSettingsSecurityPage: React.FunctionComponent<React.PropsWithCh
                     </Container>
                 </>
             )}
+
+            {/* Only users who sign in with a password can use a second factor */}
+            {props.user.builtinAuth && props.authenticatedUser.id === props.user.id && (
+                <UserSettingsTOTPSection user={props.user} />
+            )}
         </>
     )
 }
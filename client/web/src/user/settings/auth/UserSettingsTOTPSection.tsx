@@ -0,0 +1,182 @@
+import React, { useState } from 'react'
+
+import { ErrorLike } from '@sourcegraph/common'
+import { useMutation, useQuery } from '@sourcegraph/http-client'
+import {
+    Alert,
+    Button,
+    Code,
+    Container,
+    ErrorAlert,
+    Form,
+    H3,
+    Input,
+    Link,
+    LoadingSpinner,
+    Text,
+} from '@sourcegraph/wildcard'
+
+import {
+    BeginTOTPEnrollmentResult,
+    BeginTOTPEnrollmentVariables,
+    ConfirmTOTPEnrollmentResult,
+    ConfirmTOTPEnrollmentVariables,
+    DisableTOTPResult,
+    DisableTOTPVariables,
+    RegenerateTOTPRecoveryCodesResult,
+    RegenerateTOTPRecoveryCodesVariables,
+    UserAreaUserFields,
+    UserTOTPEnabledResult,
+    UserTOTPEnabledVariables,
+} from '../../../graphql-operations'
+import {
+    BEGIN_TOTP_ENROLLMENT,
+    CONFIRM_TOTP_ENROLLMENT,
+    DISABLE_TOTP,
+    REGENERATE_TOTP_RECOVERY_CODES,
+    USER_TOTP_ENABLED,
+} from '../backend'
+
+interface Props {
+    user: Pick<UserAreaUserFields, 'id' | 'username'>
+}
+
+/**
+ * Lets users who sign in with a password enroll an authenticator app as a second factor, regenerate
+ * their recovery codes, and remove the second factor.
+ */
+export const UserSettingsTOTPSection: React.FunctionComponent<React.PropsWithChildren<Props>> = ({ user }) => {
+    const [code, setCode] = useState('')
+    const [recoveryCodes, setRecoveryCodes] = useState<string[]>()
+    const [error, setError] = useState<ErrorLike>()
+
+    const { data, loading, refetch } = useQuery<UserTOTPEnabledResult, UserTOTPEnabledVariables>(USER_TOTP_ENABLED, {
+        variables: { username: user.username },
+        onError: setError,
+    })
+    const enabled = data?.user?.totpEnabled ?? false
+
+    const [beginEnrollment, { data: enrollment, loading: beginning, reset: resetEnrollment }] = useMutation<
+        BeginTOTPEnrollmentResult,
+        BeginTOTPEnrollmentVariables
+    >(BEGIN_TOTP_ENROLLMENT, { onError: setError })
+
+    const onCompleted = (codes: string[]): void => {
+        setRecoveryCodes(codes)
+        setCode('')
+        setError(undefined)
+        resetEnrollment()
+        refetch().catch(setError)
+    }
+    const [confirmEnrollment, { loading: confirming }] = useMutation<
+        ConfirmTOTPEnrollmentResult,
+        ConfirmTOTPEnrollmentVariables
+    >(CONFIRM_TOTP_ENROLLMENT, {
+        onCompleted: result => onCompleted(result.confirmTOTPEnrollment),
+        onError: setError,
+    })
+    const [regenerateRecoveryCodes, { loading: regenerating }] = useMutation<
+        RegenerateTOTPRecoveryCodesResult,
+        RegenerateTOTPRecoveryCodesVariables
+    >(REGENERATE_TOTP_RECOVERY_CODES, {
+        onCompleted: result => onCompleted(result.regenerateTOTPRecoveryCodes),
+        onError: setError,
+    })
+    const [disable, { loading: disabling }] = useMutation<DisableTOTPResult, DisableTOTPVariables>(DISABLE_TOTP, {
+        onCompleted: () => {
+            setCode('')
+            setError(undefined)
+            setRecoveryCodes(undefined)
+            refetch().catch(setError)
+        },
+        onError: setError,
+    })
+
+    const busy = loading || beginning || confirming || regenerating || disabling
+    const pending = enrollment?.beginTOTPEnrollment
+
+    const onConfirm = (event: React.FormEvent<HTMLFormElement>): void => {
+        event.preventDefault()
+        confirmEnrollment({ variables: { code } }).catch(setError)
+    }
+
+    const codeInput = (
+        <Input
+            id="totp-code"
+            label={enabled ? 'Authentication code or recovery code' : 'Authentication code'}
+            value={code}
+            onChange={event => setCode(event.target.value)}
+            disabled={busy}
+            autoComplete="one-time-code"
+            className="form-group"
+        />
+    )
+
+    return (
+        <>
+            <hr className="my-4" />
+            <H3 className="mb-3">Two-factor authentication</H3>
+            <Text>
+                Require a code from an authenticator app on your phone or computer in addition to your password when
+                you sign in.
+            </Text>
+            {error && <ErrorAlert className="mb-3" error={error} />}
+            <Container>
+                {recoveryCodes && (
+                    <Alert variant="success">
+                        <Text>
+                            Store these recovery codes in a safe place. Each code can be used once to sign in if you
+                            lose access to your authenticator app. They will not be shown again.
+                        </Text>
+                        <Code className="d-block">
+                            {recoveryCodes.map(recoveryCode => (
+                                <div key={recoveryCode}>{recoveryCode}</div>
+                            ))}
+                        </Code>
+                    </Alert>
+                )}
+
+                {loading ? (
+                    <LoadingSpinner />
+                ) : enabled ? (
+                    <Form onSubmit={event => event.preventDefault()}>
+                        <Text>Two-factor authentication is enabled.</Text>
+                        {codeInput}
+                        <Button
+                            className="mr-2"
+                            variant="secondary"
+                            disabled={busy || code === ''}
+                            onClick={() => regenerateRecoveryCodes({ variables: { code } }).catch(setError)}
+                        >
+                            Regenerate recovery codes
+                        </Button>
+                        <Button
+                            variant="danger"
+                            disabled={busy || code === ''}
+                            onClick={() => disable({ variables: { user: user.id, code } }).catch(setError)}
+                        >
+                            Disable two-factor authentication
+                        </Button>
+                    </Form>
+                ) : pending ? (
+                    <Form onSubmit={onConfirm}>
+                        <Text>
+                            Add your account to your authenticator app by opening the{' '}
+                            <Link to={pending.provisioningURI}>setup link</Link> on your device or by entering this
+                            key:
+                        </Text>
+                        <Code className="d-block mb-3">{pending.secret}</Code>
+                        {codeInput}
+                        <Button type="submit" variant="primary" disabled={busy || code === ''}>
+                            Enable two-factor authentication
+                        </Button>
+                    </Form>
+                ) : (
+                    <Button variant="primary" disabled={busy} onClick={() => beginEnrollment().catch(setError)}>
+                        Set up an authenticator app
+                    </Button>
+                )}
+            </Container>
+        </>
+    )
+}
@@ -33,6 +33,44 @@ export const CREATE_PASSWORD = gql`
     }
 `
 
+export const USER_TOTP_ENABLED = gql`
+    query UserTOTPEnabled($username: String!) {
+        user(username: $username) {
+            id
+            totpEnabled
+        }
+    }
+`
+
+export const BEGIN_TOTP_ENROLLMENT = gql`
+    mutation BeginTOTPEnrollment {
+        beginTOTPEnrollment {
+            secret
+            provisioningURI
+        }
+    }
+`
+
+export const CONFIRM_TOTP_ENROLLMENT = gql`
+    mutation ConfirmTOTPEnrollment($code: String!) {
+        confirmTOTPEnrollment(code: $code)
+    }
+`
+
+export const REGENERATE_TOTP_RECOVERY_CODES = gql`
+    mutation RegenerateTOTPRecoveryCodes($code: String!) {
+        regenerateTOTPRecoveryCodes(code: $code)
+    }
+`
+
+export const DISABLE_TOTP = gql`
+    mutation DisableTOTP($user: ID!, $code: String) {
+        disableTOTP(user: $user, code: $code) {
+            alwaysNil
+        }
+    }
+`
+
 export const userExternalAccountFragment = gql`
     fragment UserExternalAccountFields on ExternalAccount {
         id
@@ -210,6 +210,11 @@ type ListBatchChangeBulkOperationArgs struct {
 	CreatedAfter *gqlutil.DateTime
 }
 
+type ListBatchChangeScheduledRunsArgs struct {
+	First int32
+	After *string
+}
+
 type CreateChangesetCommentsArgs struct {
 	BulkOperationBaseArgs
 	Body string
@@ -325,6 +330,22 @@ type BulkOperationResolver interface {
 	FinishedAt() *gqlutil.DateTime
 }
 
+type BatchChangeScheduledRunConnectionResolver interface {
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Nodes(ctx context.Context) ([]BatchChangeScheduledRunResolver, error)
+}
+
+type BatchChangeScheduledRunResolver interface {
+	ID() graphql.ID
+	State() string
+	BatchSpec(ctx context.Context) (BatchSpecResolver, error)
+	NewChangesets() int32
+	FailureMessage() *string
+	ScheduledAt() gqlutil.DateTime
+	FinishedAt() *gqlutil.DateTime
+}
+
 type ChangesetJobErrorResolver interface {
 	Changeset() ChangesetResolver
 	Error() *string
@@ -669,6 +690,7 @@ type BatchChangeResolver interface {
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
 	BatchSpecs(ctx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
+	ScheduledRuns(ctx context.Context, args *ListBatchChangeScheduledRunsArgs) (BatchChangeScheduledRunConnectionResolver, error)
 }
 
 type BatchChangesConnectionResolver interface {
@@ -32,6 +32,8 @@ type CodeMonitorsResolver interface {
 	TriggerTestEmailAction(ctx context.Context, args *TriggerTestEmailActionArgs) (*EmptyResponse, error)
 	TriggerTestWebhookAction(ctx context.Context, args *TriggerTestWebhookActionArgs) (*EmptyResponse, error)
 	TriggerTestSlackWebhookAction(ctx context.Context, args *TriggerTestSlackWebhookActionArgs) (*EmptyResponse, error)
+	TriggerTestTeamsWebhookAction(ctx context.Context, args *TriggerTestTeamsWebhookActionArgs) (*EmptyResponse, error)
+	TriggerTestMattermostWebhookAction(ctx context.Context, args *TriggerTestMattermostWebhookActionArgs) (*EmptyResponse, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -90,6 +92,8 @@ type MonitorAction interface {
 	ToMonitorEmail() (MonitorEmailResolver, bool)
 	ToMonitorWebhook() (MonitorWebhookResolver, bool)
 	ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool)
+	ToMonitorTeamsWebhook() (MonitorTeamsWebhookResolver, bool)
+	ToMonitorMattermostWebhook() (MonitorMattermostWebhookResolver, bool)
 }
 
 type MonitorEmailResolver interface {
@@ -118,6 +122,22 @@ type MonitorSlackWebhookResolver interface {
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
+type MonitorTeamsWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	URL() string
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorMattermostWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	URL() string
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
 type MonitorEmailRecipient interface {
 	ToUser() (*UserResolver, bool)
 }
@@ -172,9 +192,11 @@ type CreateTriggerArgs struct {
 }
 
 type CreateActionArgs struct {
-	Email        *CreateActionEmailArgs
-	Webhook      *CreateActionWebhookArgs
-	SlackWebhook *CreateActionSlackWebhookArgs
+	Email             *CreateActionEmailArgs
+	Webhook           *CreateActionWebhookArgs
+	SlackWebhook      *CreateActionSlackWebhookArgs
+	TeamsWebhook      *CreateActionTeamsWebhookArgs
+	MattermostWebhook *CreateActionMattermostWebhookArgs
 }
 
 type CreateActionEmailArgs struct {
@@ -197,6 +219,18 @@ type CreateActionSlackWebhookArgs struct {
 	URL            string
 }
 
+type CreateActionTeamsWebhookArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	URL            string
+}
+
+type CreateActionMattermostWebhookArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	URL            string
+}
+
 type ToggleCodeMonitorArgs struct {
 	Id      graphql.ID
 	Enabled bool
@@ -228,6 +262,18 @@ type TriggerTestSlackWebhookActionArgs struct {
 	SlackWebhook *CreateActionSlackWebhookArgs
 }
 
+type TriggerTestTeamsWebhookActionArgs struct {
+	Namespace    graphql.ID
+	Description  string
+	TeamsWebhook *CreateActionTeamsWebhookArgs
+}
+
+type TriggerTestMattermostWebhookActionArgs struct {
+	Namespace         graphql.ID
+	Description       string
+	MattermostWebhook *CreateActionMattermostWebhookArgs
+}
+
 type CreateMonitorArgs struct {
 	Namespace   graphql.ID
 	Description string
@@ -249,10 +295,22 @@ type EditActionSlackWebhookArgs struct {
 	Update *CreateActionSlackWebhookArgs
 }
 
+type EditActionTeamsWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionTeamsWebhookArgs
+}
+
+type EditActionMattermostWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionMattermostWebhookArgs
+}
+
 type EditActionArgs struct {
-	Email        *EditActionEmailArgs
-	Webhook      *EditActionWebhookArgs
-	SlackWebhook *EditActionSlackWebhookArgs
+	Email             *EditActionEmailArgs
+	Webhook           *EditActionWebhookArgs
+	SlackWebhook      *EditActionSlackWebhookArgs
+	TeamsWebhook      *EditActionTeamsWebhookArgs
+	MattermostWebhook *EditActionMattermostWebhookArgs
 }
 
 type EditTriggerArgs struct {
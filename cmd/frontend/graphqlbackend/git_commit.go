@@ -439,3 +439,7 @@ func (r *GitCommitResolver) canonicalRepoRevURL() *url.URL {
 func (r *GitCommitResolver) Ownership(ctx context.Context, args ListOwnershipArgs) (OwnershipConnectionResolver, error) {
 	return EnterpriseResolvers.ownResolver.GitCommitOwnership(ctx, r, args)
 }
+
+func (r *GitCommitResolver) CodeownersValidation(ctx context.Context, args CodeownersValidationArgs) (CodeownersValidationResolver, error) {
+	return EnterpriseResolvers.ownResolver.GitCommitCodeownersValidation(ctx, r, args)
+}
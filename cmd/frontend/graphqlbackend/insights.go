@@ -41,6 +41,9 @@ type InsightsResolver interface {
 	DeleteInsightView(ctx context.Context, args *DeleteInsightViewArgs) (*EmptyResponse, error)
 	SaveInsightAsNewView(ctx context.Context, args SaveInsightAsNewViewArgs) (InsightViewPayloadResolver, error)
 
+	CreateInsightAlertRule(ctx context.Context, args *CreateInsightAlertRuleArgs) (InsightAlertRuleResolver, error)
+	DeleteInsightAlertRule(ctx context.Context, args *DeleteInsightAlertRuleArgs) (*EmptyResponse, error)
+
 	// Admin Management
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
 	InsightViewDebug(ctx context.Context, args InsightViewDebugArgs) (InsightViewDebugResolver, error)
@@ -208,6 +211,7 @@ type InsightViewResolver interface {
 	SeriesCount(ctx context.Context) (*int32, error)
 	RepositoryDefinition(ctx context.Context) (InsightRepositoryDefinition, error)
 	TimeScope(ctx context.Context) (InsightTimeScope, error)
+	AlertRules(ctx context.Context) ([]InsightAlertRuleResolver, error)
 }
 
 type InsightDataSeriesDefinition interface {
@@ -484,6 +488,46 @@ type DeleteInsightViewArgs struct {
 	Id graphql.ID
 }
 
+type CreateInsightAlertRuleArgs struct {
+	Input CreateInsightAlertRuleInput
+}
+
+type CreateInsightAlertRuleInput struct {
+	InsightViewId   graphql.ID
+	SeriesId        string
+	Capture         *string
+	Kind            string
+	Comparison      string
+	Threshold       float64
+	Intervals       *int32
+	Description     string
+	Email           *bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+}
+
+type DeleteInsightAlertRuleArgs struct {
+	Id graphql.ID
+}
+
+type InsightAlertRuleResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Capture() *string
+	Kind() string
+	Comparison() string
+	Threshold() float64
+	Intervals() int32
+	Description() string
+	Email() bool
+	SlackWebhookURL() *string
+	WebhookURL() *string
+	Firing() bool
+	LastValue() *float64
+	LastEvaluatedAt() *gqlutil.DateTime
+	LastFiredAt() *gqlutil.DateTime
+}
+
 type SearchInsightLivePreviewSeriesResolver interface {
 	Points(ctx context.Context) ([]InsightsDataPointResolver, error)
 	Label(ctx context.Context) (string, error)
@@ -79,6 +79,16 @@ func (r *NodeResolver) ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, boo
 	return n, ok
 }
 
+func (r *NodeResolver) ToMonitorTeamsWebhook() (MonitorTeamsWebhookResolver, bool) {
+	n, ok := r.Node.(MonitorTeamsWebhookResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToMonitorMattermostWebhook() (MonitorMattermostWebhookResolver, bool) {
+	n, ok := r.Node.(MonitorMattermostWebhookResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToMonitorActionEvent() (MonitorActionEventResolver, bool) {
 	n, ok := r.Node.(MonitorActionEventResolver)
 	return n, ok
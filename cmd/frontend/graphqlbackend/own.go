@@ -23,6 +23,7 @@ const (
 	AssignedOwner                    OwnershipReasonType = "ASSIGNED_OWNER"
 	RecentContributorOwnershipSignal OwnershipReasonType = "RECENT_CONTRIBUTOR_OWNERSHIP_SIGNAL"
 	RecentViewOwnershipSignal        OwnershipReasonType = "RECENT_VIEW_OWNERSHIP_SIGNAL"
+	BlameOwnershipSignal             OwnershipReasonType = "BLAME_OWNERSHIP_SIGNAL"
 )
 
 func (args *ListOwnershipArgs) IncludeReason(reason OwnershipReasonType) bool {
@@ -45,6 +46,8 @@ type OwnResolver interface {
 	GitCommitOwnership(ctx context.Context, commit *GitCommitResolver, args ListOwnershipArgs) (OwnershipConnectionResolver, error)
 	GitTreeOwnership(ctx context.Context, tree *GitTreeEntryResolver, args ListOwnershipArgs) (OwnershipConnectionResolver, error)
 
+	GitCommitCodeownersValidation(ctx context.Context, commit *GitCommitResolver, args CodeownersValidationArgs) (CodeownersValidationResolver, error)
+
 	GitTreeOwnershipStats(ctx context.Context, tree *GitTreeEntryResolver) (OwnershipStatsResolver, error)
 	InstanceOwnershipStats(ctx context.Context) (OwnershipStatsResolver, error)
 
@@ -89,6 +92,28 @@ type OwnershipStatsResolver interface {
 	UpdatedAt(ctx context.Context) (*gqlutil.DateTime, error)
 }
 
+type CodeownersValidationArgs struct {
+	Kinds *[]string
+}
+
+type CodeownersValidationResolver interface {
+	CodeownersFile(context.Context) (FileResolver, error)
+	TotalFiles() int32
+	UnownedFilesCount() int32
+	TotalCount() int32
+	Issues() []CodeownersIssueResolver
+}
+
+type CodeownersIssueResolver interface {
+	Kind() string
+	Message() string
+	LineNumber() *int32
+	Pattern() *string
+	Owner() *string
+	Path() *string
+	ShadowedBy() *[]int32
+}
+
 type Ownable interface {
 	ToGitBlob(context.Context) (*GitTreeEntryResolver, bool)
 }
@@ -110,6 +135,7 @@ type OwnershipReasonResolver interface {
 	ToCodeownersFileEntry() (CodeownersFileEntryResolver, bool)
 	ToRecentContributorOwnershipSignal() (RecentContributorOwnershipSignalResolver, bool)
 	ToRecentViewOwnershipSignal() (RecentViewOwnershipSignalResolver, bool)
+	ToBlameOwnershipSignal() (BlameOwnershipSignalResolver, bool)
 	ToAssignedOwner() (AssignedOwnerResolver, bool)
 }
 
@@ -135,6 +161,13 @@ type RecentViewOwnershipSignalResolver interface {
 	Description() (string, error)
 }
 
+type BlameOwnershipSignalResolver interface {
+	Title() (string, error)
+	Description() (string, error)
+	LinesCount() int32
+	Score() float64
+}
+
 type AssignedOwnerResolver interface {
 	Title() (string, error)
 	Description() (string, error)
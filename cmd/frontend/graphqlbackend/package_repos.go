@@ -33,6 +33,8 @@ var externalServiceToPackageSchemeMap = map[string]string{
 	extsvc.KindPythonPackages: dependencies.PythonPackagesScheme,
 	extsvc.KindRustPackages:   dependencies.RustPackagesScheme,
 	extsvc.KindRubyPackages:   dependencies.RubyPackagesScheme,
+	extsvc.KindNuGetPackages:  dependencies.NuGetPackagesScheme,
+	extsvc.KindPHPPackages:    dependencies.PHPPackagesScheme,
 }
 
 var packageSchemeToExternalServiceMap = map[string]string{
@@ -42,6 +44,8 @@ var packageSchemeToExternalServiceMap = map[string]string{
 	dependencies.PythonPackagesScheme: extsvc.KindPythonPackages,
 	dependencies.RustPackagesScheme:   extsvc.KindRustPackages,
 	dependencies.RubyPackagesScheme:   extsvc.KindRubyPackages,
+	dependencies.NuGetPackagesScheme:  extsvc.KindNuGetPackages,
+	dependencies.PHPPackagesScheme:    extsvc.KindPHPPackages,
 }
 
 func (r *schemaResolver) PackageRepoReferences(ctx context.Context, args *PackageRepoReferenceConnectionArgs) (_ *packageRepoReferenceConnectionResolver, err error) {
@@ -232,6 +236,18 @@ func dependencyRepoToRepoName(dep dependencies.PackageRepoReference) (repoName a
 		repoName = reposource.ParsePythonPackageFromName(dep.Name).RepoName()
 	case "scip-ruby":
 		repoName = reposource.ParseRubyPackageFromName(dep.Name).RepoName()
+	case "scip-dotnet":
+		pkg, err := reposource.ParseNuGetPackageFromName(dep.Name)
+		if err != nil {
+			return "", err
+		}
+		repoName = pkg.RepoName()
+	case "scip-php":
+		pkg, err := reposource.ParsePHPPackageFromName(dep.Name)
+		if err != nil {
+			return "", err
+		}
+		repoName = pkg.RepoName()
 	case "semanticdb":
 		pkg, err := reposource.ParseMavenPackageFromName(dep.Name)
 		if err != nil {
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 
@@ -231,6 +232,32 @@ func (r *corruptionLogResolver) Reason() (string, error) {
 	return r.log.Reason, nil
 }
 
+func (r *repositoryMirrorInfoResolver) IntegrityCheck(ctx context.Context) (*integrityCheckResolver, error) {
+	info, err := r.computeGitserverRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IntegrityCheckedAt.IsZero() {
+		return nil, nil
+	}
+
+	return &integrityCheckResolver{checkedAt: info.IntegrityCheckedAt, passed: info.IntegrityCheckPassed}, nil
+}
+
+type integrityCheckResolver struct {
+	checkedAt time.Time
+	passed    bool
+}
+
+func (r *integrityCheckResolver) CheckedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.checkedAt}
+}
+
+func (r *integrityCheckResolver) Passed() bool {
+	return r.passed
+}
+
 func (r *repositoryMirrorInfoResolver) ByteSize(ctx context.Context) (BigInt, error) {
 	info, err := r.computeGitserverRepo(ctx)
 	if err != nil {
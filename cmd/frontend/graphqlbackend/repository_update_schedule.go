@@ -0,0 +1,178 @@
+package graphqlbackend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type repositoryUpdateScheduleArgs struct {
+	First int32
+	After *string
+}
+
+func (r *schemaResolver) RepositoryUpdateSchedule(ctx context.Context, args *repositoryUpdateScheduleArgs) (*repositoryUpdateScheduleConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins may view the update schedule, which lists all repositories.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if args.First < 0 {
+		return nil, errors.Newf("first must not be negative, got %d", args.First)
+	}
+	after, err := unmarshalRepositoryUpdateScheduleCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositoryUpdateScheduleConnectionResolver{
+		db:    r.db,
+		first: int(args.First),
+		after: after,
+	}, nil
+}
+
+// This constant defines the cursor prefix, which disambiguates an update
+// schedule cursor from other types of cursors in the system.
+const repositoryUpdateScheduleCursorKind = "RepositoryUpdateScheduleCursor"
+
+func marshalRepositoryUpdateScheduleCursor(cursor database.RepoUpdateScheduleCursor) string {
+	return string(relay.MarshalID(repositoryUpdateScheduleCursorKind, cursor))
+}
+
+func unmarshalRepositoryUpdateScheduleCursor(cursor *string) (*database.RepoUpdateScheduleCursor, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+	if kind := relay.UnmarshalKind(graphql.ID(*cursor)); kind != repositoryUpdateScheduleCursorKind {
+		return nil, errors.Errorf("cannot unmarshal repository update schedule cursor type: %q", kind)
+	}
+	var spec database.RepoUpdateScheduleCursor
+	if err := relay.UnmarshalSpec(graphql.ID(*cursor), &spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// repositoryUpdateScheduleConnectionResolver resolves the persisted update schedule of
+// repo-updater.
+//
+// 🚨 SECURITY: When instantiating a repositoryUpdateScheduleConnectionResolver value, the caller
+// MUST check permissions.
+type repositoryUpdateScheduleConnectionResolver struct {
+	db    database.DB
+	first int
+	after *database.RepoUpdateScheduleCursor
+
+	// cache results because they are used by multiple fields
+	once      sync.Once
+	schedules []*database.RepoUpdateSchedule
+	err       error
+}
+
+func (r *repositoryUpdateScheduleConnectionResolver) compute(ctx context.Context) ([]*database.RepoUpdateSchedule, error) {
+	r.once.Do(func() {
+		// Fetch one more schedule than requested to know whether there is a next page.
+		r.schedules, r.err = r.db.RepoUpdateSchedules().List(ctx, database.RepoUpdateScheduleListOptions{
+			LimitOffset: &database.LimitOffset{Limit: r.first + 1},
+			After:       r.after,
+		})
+	})
+	return r.schedules, r.err
+}
+
+func (r *repositoryUpdateScheduleConnectionResolver) Nodes(ctx context.Context) ([]*repositoryUpdateScheduleEntryResolver, error) {
+	schedules, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) > r.first {
+		schedules = schedules[:r.first]
+	}
+
+	ids := make([]api.RepoID, len(schedules))
+	for i, schedule := range schedules {
+		ids[i] = schedule.RepoID
+	}
+	repos, err := r.db.Repos().GetReposSetByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	gsClient := gitserver.NewClient(r.db)
+	resolvers := make([]*repositoryUpdateScheduleEntryResolver, 0, len(schedules))
+	for _, schedule := range schedules {
+		repo, ok := repos[schedule.RepoID]
+		if !ok {
+			// The repository was deleted after the schedule was listed.
+			continue
+		}
+		resolvers = append(resolvers, &repositoryUpdateScheduleEntryResolver{
+			schedule:   schedule,
+			repository: NewRepositoryResolver(r.db, gsClient, repo),
+		})
+	}
+	return resolvers, nil
+}
+
+func (r *repositoryUpdateScheduleConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.db.RepoUpdateSchedules().Count(ctx)
+	return int32(count), err
+}
+
+func (r *repositoryUpdateScheduleConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	schedules, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) <= r.first {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	if r.first == 0 {
+		// There is no node to continue after, the next page starts where this one does.
+		if r.after == nil {
+			return graphqlutil.HasNextPage(true), nil
+		}
+		return graphqlutil.NextPageCursor(marshalRepositoryUpdateScheduleCursor(*r.after)), nil
+	}
+	last := schedules[r.first-1]
+	return graphqlutil.NextPageCursor(marshalRepositoryUpdateScheduleCursor(database.RepoUpdateScheduleCursor{
+		DueAt:  last.DueAt,
+		RepoID: last.RepoID,
+	})), nil
+}
+
+type repositoryUpdateScheduleEntryResolver struct {
+	schedule   *database.RepoUpdateSchedule
+	repository *RepositoryResolver
+}
+
+func (r *repositoryUpdateScheduleEntryResolver) Repository() *RepositoryResolver {
+	return r.repository
+}
+
+func (r *repositoryUpdateScheduleEntryResolver) IntervalSeconds() int32 {
+	return int32(r.schedule.Interval.Seconds())
+}
+
+func (r *repositoryUpdateScheduleEntryResolver) Due() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.schedule.DueAt}
+}
+
+func (r *repositoryUpdateScheduleEntryResolver) LastFetched() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.schedule.LastFetchedAt)
+}
+
+func (r *repositoryUpdateScheduleEntryResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.schedule.UpdatedAt}
+}
@@ -0,0 +1,165 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepositoryUpdateSchedule(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2023, 8, 11, 12, 0, 0, 0, time.UTC)
+	lastFetched := base.Add(-time.Hour)
+
+	schedules := []*database.RepoUpdateSchedule{
+		{RepoID: 1, Interval: time.Hour, DueAt: base, LastFetchedAt: &lastFetched, UpdatedAt: base.Add(-time.Minute)},
+		{RepoID: 2, Interval: 8 * time.Hour, DueAt: base.Add(time.Minute), UpdatedAt: base.Add(-time.Minute)},
+		// 3 is deleted after the schedules are listed.
+		{RepoID: 3, Interval: 45 * time.Second, DueAt: base.Add(2 * time.Minute), UpdatedAt: base.Add(-time.Minute)},
+		{RepoID: 4, Interval: 45 * time.Second, DueAt: base.Add(3 * time.Minute), UpdatedAt: base.Add(-time.Minute)},
+	}
+
+	cursorOf := func(s *database.RepoUpdateSchedule) database.RepoUpdateScheduleCursor {
+		return database.RepoUpdateScheduleCursor{DueAt: s.DueAt, RepoID: s.RepoID}
+	}
+	after := marshalRepositoryUpdateScheduleCursor(cursorOf(schedules[0]))
+
+	newDB := func(t *testing.T) *database.MockDB {
+		scheduleStore := database.NewMockRepoUpdateScheduleStore()
+		scheduleStore.ListFunc.SetDefaultHook(func(ctx context.Context, opts database.RepoUpdateScheduleListOptions) ([]*database.RepoUpdateSchedule, error) {
+			assert.Equal(t, &database.LimitOffset{Limit: 3 + 1}, opts.LimitOffset)
+			require.NotNil(t, opts.After)
+			assert.Equal(t, cursorOf(schedules[0]), *opts.After)
+			return schedules[1:], nil
+		})
+		scheduleStore.CountFunc.SetDefaultReturn(len(schedules), nil)
+
+		repoStore := database.NewMockRepoStore()
+		repoStore.GetReposSetByIDsFunc.SetDefaultHook(func(ctx context.Context, ids ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+			assert.Equal(t, []api.RepoID{2, 3, 4}, ids)
+			return map[api.RepoID]*types.Repo{
+				2: {ID: 2, Name: "github.com/sourcegraph/b"},
+				4: {ID: 4, Name: "github.com/sourcegraph/d"},
+			}, nil
+		})
+
+		db := database.NewMockDB()
+		db.RepoUpdateSchedulesFunc.SetDefaultReturn(scheduleStore)
+		db.ReposFunc.SetDefaultReturn(repoStore)
+		return db
+	}
+
+	query := fmt.Sprintf(`
+		{
+			repositoryUpdateSchedule(first: 3, after: %q) {
+				nodes {
+					repository {
+						name
+					}
+					intervalSeconds
+					due
+					lastFetched
+				}
+				totalCount
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`, after)
+
+	t.Run("as non site admin", func(t *testing.T) {
+		db := newDB(t)
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"repositoryUpdateSchedule"}, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query:   query,
+		})
+	})
+
+	t.Run("as site admin", func(t *testing.T) {
+		db := newDB(t)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query:   query,
+			ExpectedResult: `
+				{
+					"repositoryUpdateSchedule": {
+						"nodes": [
+							{
+								"repository": {
+									"name": "github.com/sourcegraph/b"
+								},
+								"intervalSeconds": 28800,
+								"due": "2023-08-11T12:01:00Z",
+								"lastFetched": null
+							},
+							{
+								"repository": {
+									"name": "github.com/sourcegraph/d"
+								},
+								"intervalSeconds": 45,
+								"due": "2023-08-11T12:03:00Z",
+								"lastFetched": null
+							}
+						],
+						"totalCount": 4,
+						"pageInfo": {
+							"hasNextPage": false,
+							"endCursor": null
+						}
+					}
+				}
+			`,
+		})
+	})
+	t.Run("next page", func(t *testing.T) {
+		scheduleStore := database.NewMockRepoUpdateScheduleStore()
+		scheduleStore.ListFunc.SetDefaultHook(func(ctx context.Context, opts database.RepoUpdateScheduleListOptions) ([]*database.RepoUpdateSchedule, error) {
+			return schedules[:opts.Limit], nil
+		})
+		db := newDB(t)
+		db.RepoUpdateSchedulesFunc.SetDefaultReturn(scheduleStore)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		r, err := newSchemaResolver(db, gitserver.NewClient(db)).RepositoryUpdateSchedule(ctx, &repositoryUpdateScheduleArgs{First: 2})
+		require.NoError(t, err)
+		pageInfo, err := r.PageInfo(ctx)
+		require.NoError(t, err)
+		assert.True(t, pageInfo.HasNextPage())
+		require.NotNil(t, pageInfo.EndCursor())
+		cursor, err := unmarshalRepositoryUpdateScheduleCursor(pageInfo.EndCursor())
+		require.NoError(t, err)
+		assert.Equal(t, cursorOf(schedules[1]), *cursor)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		db := newDB(t)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+		resolver := newSchemaResolver(db, gitserver.NewClient(db))
+
+		_, err := resolver.RepositoryUpdateSchedule(ctx, &repositoryUpdateScheduleArgs{First: -1})
+		assert.ErrorContains(t, err, "first must not be negative")
+
+		invalid := "1"
+		_, err = resolver.RepositoryUpdateSchedule(ctx, &repositoryUpdateScheduleArgs{First: 1, After: &invalid})
+		assert.Error(t, err)
+		assert.Empty(t, db.RepoUpdateSchedules().(*database.MockRepoUpdateScheduleStore).ListFunc.History())
+	})
+}
@@ -0,0 +1,135 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/auth/totp"
+	"github.com/sourcegraph/sourcegraph/internal/auth/userpasswd"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (r *UserResolver) TOTPEnabled(ctx context.Context) (bool, error) {
+	// 🚨 SECURITY: Only the user and site admins can see whether the user has a second factor.
+	if err := auth.CheckSiteAdminOrSameUserFromActor(r.actor, r.db, r.user.ID); err != nil {
+		return false, err
+	}
+	return userpasswd.TOTPEnabled(ctx, r.db, r.user.ID)
+}
+
+type totpEnrollmentResolver struct {
+	enrollment *userpasswd.TOTPEnrollment
+}
+
+func (r *totpEnrollmentResolver) Secret() string { return r.enrollment.Secret }
+
+func (r *totpEnrollmentResolver) ProvisioningURI() string { return r.enrollment.ProvisioningURI }
+
+// currentPasswordUser returns the current user, if they sign in with a password. A second factor
+// is only checked when signing in with the builtin auth provider.
+func (r *schemaResolver) currentPasswordUser(ctx context.Context) (*types.User, error) {
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("no authenticated user")
+	}
+	if !user.BuiltinAuth || !providers.BuiltinAuthEnabled() {
+		return nil, errors.New("two-factor authentication is only available to users who sign in with a password")
+	}
+	return user, nil
+}
+
+// newTOTPLockoutStore returns the account lockout store that the checks of codes count towards.
+// It is mocked in tests.
+var newTOTPLockoutStore = func() userpasswd.LockoutStore {
+	return userpasswd.NewLockoutStoreFromConf(conf.AuthLockout())
+}
+
+func (r *schemaResolver) BeginTOTPEnrollment(ctx context.Context) (*totpEnrollmentResolver, error) {
+	// 🚨 SECURITY: Only the authenticated user can enroll their second factor.
+	user, err := r.currentPasswordUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := userpasswd.BeginTOTPEnrollment(ctx, r.db, user.ID, user.Username)
+	if err != nil {
+		return nil, err
+	}
+	return &totpEnrollmentResolver{enrollment: enrollment}, nil
+}
+
+func (r *schemaResolver) ConfirmTOTPEnrollment(ctx context.Context, args *struct{ Code string }) ([]string, error) {
+	// 🚨 SECURITY: Only the authenticated user can enroll their second factor.
+	user, err := r.currentPasswordUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var recoveryCodes []string
+	err = userpasswd.CheckTOTPCodeWithLockout(newTOTPLockoutStore(), user.ID, func() (err error) {
+		recoveryCodes, err = userpasswd.ConfirmTOTPEnrollment(ctx, r.db, user.ID, args.Code)
+		return err
+	})
+	return recoveryCodes, err
+}
+
+func (r *schemaResolver) RegenerateTOTPRecoveryCodes(ctx context.Context, args *struct{ Code string }) ([]string, error) {
+	// 🚨 SECURITY: Only the authenticated user can regenerate their recovery codes, with a code
+	// from their authenticator app.
+	user, err := r.currentPasswordUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var recoveryCodes []string
+	err = userpasswd.CheckTOTPCodeWithLockout(newTOTPLockoutStore(), user.ID, func() (err error) {
+		recoveryCodes, err = userpasswd.RegenerateTOTPRecoveryCodes(ctx, r.db, user.ID, args.Code)
+		return err
+	})
+	return recoveryCodes, err
+}
+
+func (r *schemaResolver) DisableTOTP(ctx context.Context, args *struct {
+	User graphql.ID
+	Code *string
+},
+) (*EmptyResponse, error) {
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+
+	a := actor.FromContext(ctx)
+	if a.UID == userID {
+		// 🚨 SECURITY: Users must prove that they still have their second factor, so that a
+		// stolen session can't be used to remove it.
+		if args.Code == nil || *args.Code == "" {
+			return nil, errors.New("a code from your authenticator app or a recovery code is required")
+		}
+		code := strings.ReplaceAll(*args.Code, " ", "")
+		err = userpasswd.CheckTOTPCodeWithLockout(newTOTPLockoutStore(), userID, func() error {
+			if len(code) == totp.Digits {
+				return userpasswd.VerifyTOTP(ctx, r.db, userID, code)
+			}
+			return userpasswd.VerifyTOTPRecoveryCode(ctx, r.db, userID, code)
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		// 🚨 SECURITY: Only site admins can remove the second factor of other users.
+		return nil, err
+	}
+
+	if err := userpasswd.DisableTOTP(ctx, r.db, userID, a.UID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
@@ -0,0 +1,115 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/auth/totp"
+	"github.com/sourcegraph/sourcegraph/internal/auth/userpasswd"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// fakeLockoutStore locks out users after the given number of failed attempts.
+type fakeLockoutStore struct {
+	userpasswd.LockoutStore
+	threshold int
+	failed    map[int32]int
+}
+
+func (s *fakeLockoutStore) IsLockedOut(userID int32) (string, bool) {
+	return "", s.failed[userID] >= s.threshold
+}
+
+func (s *fakeLockoutStore) IncreaseFailedAttempt(userID int32) { s.failed[userID]++ }
+
+func mockTOTPLockoutStore(t *testing.T, threshold int) {
+	store := &fakeLockoutStore{threshold: threshold, failed: map[int32]int{}}
+	old := newTOTPLockoutStore
+	newTOTPLockoutStore = func() userpasswd.LockoutStore { return store }
+	t.Cleanup(func() { newTOTPLockoutStore = old })
+}
+
+func TestDisableTOTP(t *testing.T) {
+	const userID, adminID = 1, 2
+
+	newDB := func() (*database.MockDB, *database.MockUserTOTPStore) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+			uid := actor.FromContext(ctx).UID
+			return &types.User{ID: uid, SiteAdmin: uid == adminID}, nil
+		})
+		totpStore := database.NewMockUserTOTPStore()
+		totpStore.GetByUserIDFunc.SetDefaultReturn(nil, database.UserTOTPNotFoundErr{})
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.UserTOTPFunc.SetDefaultReturn(totpStore)
+		db.SecurityEventLogsFunc.SetDefaultReturn(database.NewMockSecurityEventLogsStore())
+		return db, totpStore
+	}
+	disable := func(ctx context.Context, db database.DB, code *string) error {
+		_, err := newSchemaResolver(db, gitserver.NewClient(db)).DisableTOTP(ctx, &struct {
+			User graphql.ID
+			Code *string
+		}{User: MarshalUserID(userID), Code: code})
+		return err
+	}
+
+	t.Run("users must provide a code", func(t *testing.T) {
+		mockTOTPLockoutStore(t, 3)
+		db, totpStore := newDB()
+		ctx := actor.WithActor(context.Background(), actor.FromUser(userID))
+
+		assert.ErrorContains(t, disable(ctx, db, nil), "code")
+		code := "123456"
+		assert.ErrorIs(t, disable(ctx, db, &code), userpasswd.ErrTOTPNotEnabled)
+		assert.Empty(t, totpStore.DeleteFunc.History())
+	})
+
+	t.Run("codes are not checked after too many failed attempts", func(t *testing.T) {
+		mockTOTPLockoutStore(t, 3)
+		db, totpStore := newDB()
+		const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+		enabledAt := time.Now()
+		totpStore.GetByUserIDFunc.SetDefaultReturn(&database.UserTOTP{UserID: userID, Secret: secret, EnabledAt: &enabledAt}, nil)
+		totpStore.UseStepFunc.SetDefaultReturn(true, nil)
+		ctx := actor.WithActor(context.Background(), actor.FromUser(userID))
+
+		wrong := "aaaaa-aaaaa"
+		for i := 0; i < 3; i++ {
+			assert.ErrorIs(t, disable(ctx, db, &wrong), userpasswd.ErrInvalidTOTPCode)
+		}
+
+		// The correct code is refused as well once the user is locked out.
+		code, err := totp.Code(secret, totp.Step(time.Now()))
+		require.NoError(t, err)
+		assert.ErrorIs(t, disable(ctx, db, &code), userpasswd.ErrTOTPLockedOut)
+		assert.Empty(t, totpStore.DeleteFunc.History())
+	})
+
+	t.Run("other users can't disable it", func(t *testing.T) {
+		db, totpStore := newDB()
+		ctx := actor.WithActor(context.Background(), actor.FromUser(3))
+
+		assert.ErrorIs(t, disable(ctx, db, nil), auth.ErrMustBeSiteAdmin)
+		assert.Empty(t, totpStore.DeleteFunc.History())
+	})
+
+	t.Run("site admins can disable it without a code", func(t *testing.T) {
+		db, totpStore := newDB()
+		ctx := actor.WithActor(context.Background(), actor.FromUser(adminID))
+
+		require.NoError(t, disable(ctx, db, nil))
+		require.Len(t, totpStore.DeleteFunc.History(), 1)
+		assert.Equal(t, int32(userID), totpStore.DeleteFunc.History()[0].Arg1)
+	})
+}
@@ -72,6 +72,7 @@ type Handlers struct {
 
 	// Code Insights
 	CodeInsightsDataExportHandler http.Handler
+	CodeInsightsDataImportHandler http.Handler
 
 	// Dotcom license check
 	NewDotcomLicenseCheckHandler enterprise.NewDotcomLicenseCheckHandler
@@ -157,6 +158,7 @@ func NewHandler(
 	m.Get(apirouter.CodeCompletions).Handler(trace.Route(handlers.NewCodeCompletionsHandler()))
 
 	m.Get(apirouter.CodeInsightsDataExport).Handler(trace.Route(handlers.CodeInsightsDataExportHandler))
+	m.Get(apirouter.CodeInsightsDataImport).Handler(trace.Route(handlers.CodeInsightsDataImportHandler))
 
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/app/check/update").Name(codyapp.RouteAppUpdateCheck).Handler(trace.Route(codyapp.AppUpdateHandler(logger)))
@@ -41,6 +41,7 @@ const (
 	BatchesFileUpload = "batches.file.upload"
 
 	CodeInsightsDataExport = "insights.data.export"
+	CodeInsightsDataImport = "insights.data.import"
 
 	GitInfoRefs         = "internal.git.info-refs"
 	GitUploadPack       = "internal.git.upload-pack"
@@ -82,6 +83,7 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
 	base.Path("/insights/export/{id}").Methods("GET").Name(CodeInsightsDataExport)
+	base.Path("/insights/import/{id}").Methods("POST").Name(CodeInsightsDataImport)
 	base.Path("/completions/stream").Methods("POST").Name(ChatCompletionsStream)
 	base.Path("/completions/code").Methods("POST").Name(CodeCompletions)
 
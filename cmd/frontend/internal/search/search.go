@@ -260,6 +260,8 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 	switch v := match.(type) {
 	case *result.FileMatch:
 		return fromFileMatch(v, repoCache, enableChunkMatches)
+	case *result.FileDiffMatch:
+		return fromFileDiffMatch(v, repoCache)
 	case *result.RepoMatch:
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
@@ -389,6 +391,31 @@ func fromContentMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Sear
 	return contentEvent
 }
 
+func fromFileDiffMatch(fd *result.FileDiffMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventContentDiffMatch {
+	diffEvent := &streamhttp.EventContentDiffMatch{
+		Type:         streamhttp.ContentDiffMatchType,
+		Path:         fd.Path,
+		RepositoryID: int32(fd.Repo.ID),
+		Repository:   string(fd.Repo.Name),
+		Commit:       string(fd.CommitID),
+		BaseRevision: fd.BaseRev,
+		BaseCommit:   string(fd.BaseCommitID),
+		Added:        fromChunkMatches(fd.Added),
+		Removed:      fromChunkMatches(fd.Removed),
+	}
+
+	if fd.InputRev != nil {
+		diffEvent.Branches = []string{*fd.InputRev}
+	}
+
+	if r, ok := repoCache[fd.Repo.ID]; ok {
+		diffEvent.RepoStars = r.Stars
+		diffEvent.RepoLastFetched = r.LastFetched
+	}
+
+	return diffEvent
+}
+
 func fromSymbolMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventSymbolMatch {
 	symbols := make([]streamhttp.Symbol, 0, len(fm.Symbols))
 	for _, sym := range fm.Symbols {
@@ -22,6 +22,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/ricochet2200/go-disk-usage/du"
 	"github.com/sourcegraph/log"
+	"golang.org/x/time/rate"
 
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -49,6 +50,9 @@ const (
 	// gitConfigMaybeCorrupt is a key we add to git config to signal that a repo may be
 	// corrupt on disk.
 	gitConfigMaybeCorrupt = "sourcegraph.maybeCorruptRepo"
+	// gitConfigIntegrityCheck is the git config key we store the time of the
+	// last integrity check of a repo under.
+	gitConfigIntegrityCheck = "sourcegraph.integrityCheckTimestamp"
 	// The name of the log file placed by sg maintenance in case it encountered an
 	// error.
 	sgmLog = "sgm.log"
@@ -115,6 +119,14 @@ var sgmRetries, _ = strconv.Atoi(env.Get("SRC_SGM_RETRIES", "3", "the maximum nu
 // The limit of repos cloned on the wrong shard to delete in one janitor run - value <=0 disables delete.
 var wrongShardReposDeleteLimit, _ = strconv.Atoi(env.Get("SRC_WRONG_SHARD_DELETE_LIMIT", "10", "the maximum number of repos not assigned to this shard we delete in one run"))
 
+// How often gitserver cleanup verifies the integrity of each repo on disk with git fsck. A value <=0 disables the checks.
+var integrityCheckInterval = env.MustGetDuration("SRC_REPO_INTEGRITY_CHECK_INTERVAL", 7*24*time.Hour, "how often the integrity of each repo on disk is verified with git fsck. Set to 0 to disable.")
+
+// The maximum number of repo integrity checks gitserver cleanup runs per hour, so that verifying all repos does not starve other work.
+var integrityChecksPerHour, _ = strconv.Atoi(env.Get("SRC_REPO_INTEGRITY_CHECKS_PER_HOUR", "60", "the maximum number of repo integrity checks per hour"))
+
+var integrityCheckLimiter = rate.NewLimiter(rate.Limit(float64(integrityChecksPerHour)/time.Hour.Seconds()), 1)
+
 // Controls if gitserver cleanup tries to remove repos from disk which are not defined in the DB. Defaults to false.
 var removeNonExistingRepos, _ = strconv.ParseBool(env.Get("SRC_REMOVE_NON_EXISTING_REPOS", "false", "controls if gitserver cleanup tries to remove repos from disk which are not defined in the DB"))
 
@@ -156,6 +168,19 @@ var (
 		Name: "src_gitserver_non_existing_repos_removed",
 		Help: "number of non existing repos removed during cleanup",
 	})
+	integrityChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_repo_integrity_checks",
+		Help: "number of repo integrity checks run during cleanup, by whether the repo passed (true/false)",
+	}, []string{"passed"})
+	integrityCheckDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "src_gitserver_repo_integrity_check_duration_seconds",
+		Help:    "Duration of repo integrity checks run during cleanup",
+		Buckets: []float64{0.1, 1, 10, 60, 300, 1800},
+	})
+	lfsObjectsRemoved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_lfs_objects_removed",
+		Help: "number of repos whose Git LFS objects were removed for exceeding the LFS size budget",
+	})
 )
 
 const reposStatsName = "repos-stats.json"
@@ -167,12 +192,14 @@ const reposStatsName = "repos-stats.json"
 // 3. Remove stale lock files.
 // 4. Ensure correct git attributes
 // 5. Ensure gc.auto=0 or unset depending on gitGCMode
-// 6. Perform garbage collection
-// 7. Re-clone repos after a while. (simulate git gc)
-// 8. Remove repos based on disk pressure.
-// 9. Perform sg-maintenance
-// 10. Git prune
-// 11. Set sizes of repos
+// 6. Remove Git LFS objects exceeding their size budget
+// 7. Perform garbage collection
+// 8. Verify the integrity of repos periodically
+// 9. Re-clone repos after a while. (simulate git gc)
+// 10. Remove repos based on disk pressure.
+// 11. Perform sg-maintenance
+// 12. Git prune
+// 13. Set sizes of repos
 func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitserverAddresses) {
 	janitorRunning.Set(1)
 	janitorStart := time.Now()
@@ -302,6 +329,41 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 		return false, gitSetAutoGC(dir, s)
 	}
 
+	ensureGitLFSBudget := func(dir common.GitDir, s *Server) (done bool, err error) {
+		_, err = enforceGitLFSBudget(logger, dir, s)
+		return false, err
+	}
+
+	// reclone re-clones the repo at dir, which was last cloned at recloneTime, for the
+	// given reason.
+	reclone := func(dir common.GitDir, s *Server, recloneTime time.Time, reason string) (done bool, err error) {
+		ctx, cancel := context.WithTimeout(bCtx, conf.GitLongCommandTimeout())
+		defer cancel()
+
+		// name is the relative path to ReposDir, but without the .git suffix.
+		repo := s.name(dir)
+		recloneLogger := logger.With(
+			log.String("repo", string(repo)),
+			log.Time("cloned", recloneTime),
+			log.String("reason", reason),
+		)
+
+		recloneLogger.Info("re-cloning expired repo")
+
+		// update the re-clone time so that we don't constantly re-clone if cloning fails.
+		// For example if a repo fails to clone due to being large, we will constantly be
+		// doing a clone which uses up lots of resources.
+		if err := setRecloneTime(dir, s, recloneTime.Add(time.Since(recloneTime)/2)); err != nil {
+			recloneLogger.Warn("setting backed off re-clone time failed", log.Error(err))
+		}
+
+		if _, err := s.cloneRepo(ctx, repo, &cloneOptions{Block: true, Overwrite: true}); err != nil {
+			return true, err
+		}
+		reposRecloned.Inc()
+		return true, nil
+	}
+
 	maybeReclone := func(dir common.GitDir, s *Server) (done bool, err error) {
 		repoType, err := getRepositoryType(dir, s)
 		if err != nil {
@@ -353,31 +415,7 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 			return false, nil
 		}
 
-		ctx, cancel := context.WithTimeout(bCtx, conf.GitLongCommandTimeout())
-		defer cancel()
-
-		// name is the relative path to ReposDir, but without the .git suffix.
-		repo := s.name(dir)
-		recloneLogger := logger.With(
-			log.String("repo", string(repo)),
-			log.Time("cloned", recloneTime),
-			log.String("reason", reason),
-		)
-
-		recloneLogger.Info("re-cloning expired repo")
-
-		// update the re-clone time so that we don't constantly re-clone if cloning fails.
-		// For example if a repo fails to clone due to being large, we will constantly be
-		// doing a clone which uses up lots of resources.
-		if err := setRecloneTime(dir, s, recloneTime.Add(time.Since(recloneTime)/2)); err != nil {
-			recloneLogger.Warn("setting backed off re-clone time failed", log.Error(err))
-		}
-
-		if _, err := s.cloneRepo(ctx, repo, &cloneOptions{Block: true, Overwrite: true}); err != nil {
-			return true, err
-		}
-		reposRecloned.Inc()
-		return true, nil
+		return reclone(dir, s, recloneTime, reason)
 	}
 
 	removeStaleLocks := func(gitDir common.GitDir, _ *Server) (done bool, err error) {
@@ -432,6 +470,23 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 		return false, multi
 	}
 
+	maybeVerifyIntegrity := func(dir common.GitDir, s *Server) (done bool, err error) {
+		failed, err := s.maybeVerifyRepoIntegrity(bCtx, logger, dir)
+		if err != nil || !failed || !conf.Get().DisableAutoGitUpdates {
+			return false, err
+		}
+
+		// The repo is marked for a re-clone, but "maybe re-clone" does not run if
+		// DisableAutoGitUpdates is set. A corrupt repo stays broken until it is
+		// re-cloned, so we re-clone it right away.
+		_ = gitConfigUnset(dir, s, gitConfigMaybeCorrupt)
+		recloneTime, err := getRecloneTime(dir, s)
+		if err != nil {
+			return false, err
+		}
+		return reclone(dir, s, recloneTime, "failed integrity check")
+	}
+
 	performGC := func(dir common.GitDir, s *Server) (done bool, err error) {
 		return false, gitGC(dir, s)
 	}
@@ -465,6 +520,9 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 		// happen if several git-gc operations are running at the same time.
 		// We only disable if sg is managing gc.
 		{"auto gc config", ensureAutoGC},
+		// Git LFS objects are fetched outside of git's object storage, so we
+		// enforce their size budget separately.
+		{"ensure git lfs budget", ensureGitLFSBudget},
 	}
 
 	if gitGCMode == gitGCModeJanitorAutoGC {
@@ -484,6 +542,17 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 		cleanups = append(cleanups, cleanupFn{"git prune", performGitPrune})
 	}
 
+	if integrityCheckInterval > 0 {
+		// Corruption is otherwise only detected once a command happens to
+		// fail. We periodically verify the integrity of every repo and mark
+		// those failing for a re-clone, which happens in the next step, or
+		// right away if that step is disabled by DisableAutoGitUpdates.
+		cleanups = append(cleanups, cleanupFn{
+			Name: "maybe verify integrity",
+			Do:   maybeVerifyIntegrity,
+		})
+	}
+
 	if !conf.Get().DisableAutoGitUpdates {
 		// Old git clones accumulate loose git objects that waste space and slow down git
 		// operations. Periodically do a fresh clone to avoid these problems. git gc is
@@ -934,6 +1003,48 @@ func (s *Server) SetupAndClearTmp() (string, error) {
 	return dir, nil
 }
 
+// enforceGitLFSBudget removes the Git LFS objects of the repository at dir if
+// they use more disk space than the budget configured for it. It also removes
+// them if LFS support was disabled for the repository. It reports whether
+// objects were removed.
+func enforceGitLFSBudget(logger log.Logger, dir common.GitDir, s *Server) (bool, error) {
+	lfsDir := dir.Path("lfs")
+	if _, err := os.Stat(lfsDir); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	maxSize, err := gitConfigGet(dir, s, gitConfigLFSMaxSize)
+	if err != nil {
+		return false, err
+	}
+	if maxSize == "" {
+		return true, os.RemoveAll(lfsDir)
+	}
+	limit, err := strconv.ParseInt(maxSize, 10, 64)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid git config %s", gitConfigLFSMaxSize)
+	}
+
+	size := dirSize(lfsDir)
+	if size <= limit {
+		return false, nil
+	}
+
+	logger.Warn("removing Git LFS objects exceeding the LFS size budget",
+		log.String("repo", string(dir)),
+		log.Int64("size-bytes", size),
+		log.Int64("budget-bytes", limit),
+	)
+	if err := os.RemoveAll(lfsDir); err != nil {
+		return false, err
+	}
+	lfsObjectsRemoved.Inc()
+
+	// Remember that the objects don't fit into the budget, so that we don't
+	// fetch them again on the next fetch.
+	return true, gitConfigSet(dir, s, gitConfigLFSExceeded, maxSize)
+}
+
 // setRepositoryType sets the type of the repository.
 func setRepositoryType(dir common.GitDir, s *Server, typ string) error {
 	return gitConfigSet(dir, s, "sourcegraph.type", typ)
@@ -991,6 +1102,105 @@ func getRecloneTime(dir common.GitDir, s *Server) (time.Time, error) {
 	return time.Unix(sec, 0), nil
 }
 
+// maybeVerifyRepoIntegrity verifies the integrity of the repo at dir if it
+// was not verified within integrityCheckInterval and integrityCheckLimiter
+// allows it. The result is recorded in the DB, and repos failing the check are
+// logged as corrupt and marked for a re-clone. It returns true if the repo
+// failed the check.
+func (s *Server) maybeVerifyRepoIntegrity(ctx context.Context, logger log.Logger, dir common.GitDir) (failed bool, err error) {
+	checkedAt, err := getIntegrityCheckTime(dir, s)
+	if err != nil {
+		return false, err
+	}
+	// Add a jitter to spread out checks of repos cloned at the same time.
+	if time.Since(checkedAt) < integrityCheckInterval+jitterDuration(string(dir), integrityCheckInterval/4) {
+		return false, nil
+	}
+	if !integrityCheckLimiter.Allow() {
+		// We check the repo in a later run.
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, conf.GitLongCommandTimeout())
+	defer cancel()
+
+	start := time.Now()
+	passed, output, err := verifyRepoIntegrity(ctx, dir, s)
+	if err != nil {
+		return false, err
+	}
+	integrityCheckDuration.Observe(time.Since(start).Seconds())
+	integrityChecks.WithLabelValues(strconv.FormatBool(passed)).Inc()
+
+	if err := gitConfigSet(dir, s, gitConfigIntegrityCheck, strconv.FormatInt(time.Now().Unix(), 10)); err != nil {
+		return false, errors.Wrap(err, "failed to update integrity check timestamp")
+	}
+
+	repo := s.name(dir)
+	if err := s.DB.GitserverRepos().SetIntegrityCheck(ctx, repo, passed, s.Hostname); err != nil {
+		logger.Warn("failed to record repo integrity check", log.String("repo", string(repo)), log.Error(err))
+	}
+	if passed {
+		return false, nil
+	}
+
+	logger.Warn("marking repo for re-cloning due to failed integrity check",
+		log.String("repo", string(repo)),
+		log.String("output", output))
+	if err := s.DB.GitserverRepos().LogCorruption(ctx, repo, fmt.Sprintf("git fsck failed: %s", output), s.Hostname); err != nil {
+		logger.Warn("failed to log repo corruption", log.String("repo", string(repo)), log.Error(err))
+	}
+	return true, gitConfigSet(dir, s, gitConfigMaybeCorrupt, strconv.FormatInt(time.Now().Unix(), 10))
+}
+
+// getIntegrityCheckTime returns the time the integrity of the repo at dir was
+// last verified, or the zero time if it was never verified.
+func getIntegrityCheckTime(dir common.GitDir, s *Server) (time.Time, error) {
+	value, err := gitConfigGet(dir, s, gitConfigIntegrityCheck)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to determine integrity check timestamp")
+	}
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	sec, err := strconv.ParseInt(value, 10, 0)
+	if err != nil {
+		// Treat a bad value like a missing one, so that we check again.
+		return time.Time{}, nil
+	}
+	return time.Unix(sec, 0), nil
+}
+
+// verifyRepoIntegrity checks that all objects reachable from the refs of the
+// repo at dir are present and readable. It does not verify the content of
+// blobs, which would be too expensive to do periodically for every repo. It
+// returns the output of git fsck if the repo failed the check.
+func verifyRepoIntegrity(ctx context.Context, dir common.GitDir, s *Server) (passed bool, output string, _ error) {
+	cmd := exec.CommandContext(ctx, "git", "fsck", "--connectivity-only", "--no-dangling", "--no-progress")
+	dir.Set(cmd)
+	wrappedCmd := s.RecordingCommandFactory.WrapWithRepoName(ctx, log.NoOp(), s.name(dir), cmd)
+	out, err := wrappedCmd.CombinedOutput()
+	if err == nil {
+		return true, "", nil
+	}
+	// A timeout or shutdown says nothing about the integrity of the repo.
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return false, "", ctxErr
+	}
+	var e *exec.ExitError
+	if !errors.As(err, &e) {
+		return false, "", errors.Wrap(wrapCmdError(cmd, err), "failed to run git fsck")
+	}
+
+	output = string(bytes.TrimSpace(out))
+	// Don't store huge outputs of repos with many missing objects.
+	if len(output) > 4096 {
+		output = output[:4096]
+	}
+	return false, output, nil
+}
+
 func checkMaybeCorruptRepo(logger log.Logger, s *Server, repo api.RepoName, dir common.GitDir, stderr string) bool {
 	if !stdErrIndicatesCorruption(stderr) {
 		return false
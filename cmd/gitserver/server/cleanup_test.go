@@ -18,6 +18,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -1477,6 +1478,88 @@ git commit-graph write --reachable --changed-paths
 	})
 }
 
+func TestMaybeVerifyRepoIntegrity(t *testing.T) {
+	limiter := integrityCheckLimiter
+	integrityCheckLimiter = rate.NewLimiter(rate.Inf, 1)
+	t.Cleanup(func() { integrityCheckLimiter = limiter })
+
+	dir := t.TempDir()
+	gitDir := prepareEmptyGitRepo(t, dir)
+	cmd := exec.Command("/bin/sh", "-euxc", "echo acont > afile && git add afile && git commit -am amsg")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("out=%s, err=%s", out, err)
+	}
+
+	type integrityCheck struct {
+		passed bool
+		shard  string
+	}
+	var checks []integrityCheck
+	var corruptionLogs []string
+	gsr := database.NewMockGitserverRepoStore()
+	gsr.SetIntegrityCheckFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, passed bool, shardID string) error {
+		checks = append(checks, integrityCheck{passed: passed, shard: shardID})
+		return nil
+	})
+	gsr.LogCorruptionFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, reason string, _ string) error {
+		corruptionLogs = append(corruptionLogs, reason)
+		return nil
+	})
+	db := database.NewMockDB()
+	db.GitserverReposFunc.SetDefaultReturn(gsr)
+
+	s := &Server{
+		Logger:                  logtest.Scoped(t),
+		ReposDir:                dir,
+		DB:                      db,
+		Hostname:                "gitserver-0",
+		RecordingCommandFactory: wrexec.NewNoOpRecordingCommandFactory(),
+	}
+	ctx := context.Background()
+
+	if failed, err := s.maybeVerifyRepoIntegrity(ctx, s.Logger, gitDir); err != nil || failed {
+		t.Fatalf("expected repo to pass the check, got failed=%v err=%v", failed, err)
+	}
+	if diff := cmp.Diff([]integrityCheck{{passed: true, shard: "gitserver-0"}}, checks, cmp.AllowUnexported(integrityCheck{})); diff != "" {
+		t.Fatalf("unexpected integrity checks (-want +got):\n%s", diff)
+	}
+
+	// The repo was checked recently, so we don't check it again.
+	if _, err := s.maybeVerifyRepoIntegrity(ctx, s.Logger, gitDir); err != nil {
+		t.Fatal(err)
+	}
+	if len(checks) != 1 {
+		t.Fatalf("expected repo not to be checked again, got %d checks", len(checks))
+	}
+
+	// Remove the object of the committed file to corrupt the repo.
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD:afile").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oid := strings.TrimSpace(string(out))
+	if err := os.Remove(gitDir.Path("objects", oid[:2], oid[2:])); err != nil {
+		t.Fatal(err)
+	}
+	if err := gitConfigUnset(gitDir, s, gitConfigIntegrityCheck); err != nil {
+		t.Fatal(err)
+	}
+
+	if failed, err := s.maybeVerifyRepoIntegrity(ctx, s.Logger, gitDir); err != nil || !failed {
+		t.Fatalf("expected repo to fail the check, got failed=%v err=%v", failed, err)
+	}
+	if len(checks) != 2 || checks[1].passed {
+		t.Fatalf("expected corrupt repo to fail the check, got %+v", checks)
+	}
+	if len(corruptionLogs) != 1 || !strings.HasPrefix(corruptionLogs[0], "git fsck failed: ") || !strings.Contains(corruptionLogs[0], oid) {
+		t.Fatalf("expected corruption to be logged with the missing object, got %q", corruptionLogs)
+	}
+	if maybeCorrupt, _ := gitConfigGet(gitDir, s, gitConfigMaybeCorrupt); maybeCorrupt == "" {
+		t.Fatal("expected repo to be marked for re-cloning")
+	}
+}
+
 func TestNeedsMaintenance(t *testing.T) {
 	dir := t.TempDir()
 	gitDir := prepareEmptyGitRepo(t, dir)
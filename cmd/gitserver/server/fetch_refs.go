@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+// refsFetcher is implemented by VCSSyncers that can fetch just the refs that were
+// pushed to a repository.
+type refsFetcher interface {
+	// FetchRefs fetches the given refs from the remote to the given directory.
+	FetchRefs(ctx context.Context, remoteURL *vcs.URL, repoName api.RepoName, dir common.GitDir, refs []protocol.RefUpdate) ([]byte, error)
+}
+
+var (
+	fetchBytes = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_gitserver_fetch_bytes",
+		Help:    "Size of the objects added to repositories by fetches, by whether only pushed refs or all refs were fetched.",
+		Buckets: prometheus.ExponentialBuckets(1024, 4, 12), // 1 KiB to 4 GiB
+	}, []string{"type"})
+	fetchRefsFallbackCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_fetch_refs_fallback_total",
+		Help: "Number of updates of pushed refs that fell back to fetching all refs, by reason.",
+	}, []string{"reason"})
+)
+
+// fetchedRefPrefixes are the namespaces of the refs that a full fetch of a Git
+// repository fetches, see gitRepoSyncer.fetchCommand.
+var fetchedRefPrefixes = []string{
+	"refs/heads/",
+	"refs/tags/",
+	"refs/pull/",
+	"refs/merge-requests/",
+	"refs/pull-requests/",
+	"refs/changes/",
+	"refs/sourcegraph/",
+}
+
+// fetch fetches updates of the repository. If refs is not empty, it only fetches
+// the given refs if it can, and falls back to fetching all refs otherwise.
+func (s *Server) fetch(ctx context.Context, logger log.Logger, syncer VCSSyncer, remoteURL *vcs.URL, repo api.RepoName, dir common.GitDir, revspec string, refs []protocol.RefUpdate) ([]byte, error) {
+	sizeBefore := objectsSize(ctx, dir)
+
+	if len(refs) > 0 {
+		reason := fetchRefsFallbackReason(ctx, syncer, remoteURL, refs)
+		if reason == "" {
+			output, err := syncer.(refsFetcher).FetchRefs(ctx, remoteURL, repo, dir, refs)
+			if err == nil {
+				sizeAfter := objectsSize(ctx, dir)
+				observeFetchBytes("refs", sizeBefore, sizeAfter)
+				if !isForcePush(ctx, dir, refs) {
+					return output, nil
+				}
+				sizeBefore = sizeAfter
+				reason = "forced"
+			} else {
+				logger.Warn("failed to fetch pushed refs, fetching all refs", log.Error(err))
+				reason = "error"
+			}
+		}
+		fetchRefsFallbackCounter.WithLabelValues(reason).Inc()
+	}
+
+	output, err := syncer.Fetch(ctx, remoteURL, repo, dir, revspec)
+	if err == nil {
+		observeFetchBytes("full", sizeBefore, objectsSize(ctx, dir))
+	}
+	return output, err
+}
+
+// fetchRefsFallbackReason returns why all refs have to be fetched instead of just
+// the given refs, or the empty string if they can be fetched on their own.
+func fetchRefsFallbackReason(ctx context.Context, syncer VCSSyncer, remoteURL *vcs.URL, refs []protocol.RefUpdate) string {
+	if _, ok := syncer.(refsFetcher); !ok {
+		return "unsupported"
+	}
+	// The admin has configured how to fetch this repository.
+	if customFetchCmd(ctx, remoteURL) != nil || useRefspecOverrides() {
+		return "custom_fetch"
+	}
+
+	for _, ref := range refs {
+		switch {
+		// The ref names and commits come from the code host, so we check them before
+		// passing them to git.
+		case !isFetchedRef(ref.Name), ref.Before != "" && !gitdomain.IsAbsoluteRevision(string(ref.Before)):
+			return "invalid"
+		// Only a full fetch prunes deleted refs.
+		case ref.Deleted():
+			return "deleted"
+		case !gitdomain.IsAbsoluteRevision(string(ref.After)):
+			return "invalid"
+		case ref.Forced:
+			return "forced"
+		}
+	}
+	return ""
+}
+
+// isFetchedRef returns whether name is a valid name of a ref that a full fetch
+// fetches.
+func isFetchedRef(name string) bool {
+	for _, prefix := range fetchedRefPrefixes {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			return gitdomain.ValidateBranchName(name)
+		}
+	}
+	return false
+}
+
+// isForcePush returns whether any of the fetched refs was force-pushed, which not
+// all code hosts report in their push webhooks. It also returns true if the commit
+// a ref pointed to before the push is not in the repository, because we missed an
+// earlier push.
+func isForcePush(ctx context.Context, dir common.GitDir, refs []protocol.RefUpdate) bool {
+	for _, ref := range refs {
+		if ref.Before == "" {
+			continue
+		}
+		cmd := exec.CommandContext(ctx, "git", "merge-base", "--is-ancestor", string(ref.Before), string(ref.After))
+		dir.Set(cmd)
+		if err := cmd.Run(); err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// objectsSize returns the size of the objects of the repository at dir in
+// bytes as reported by git count-objects, or -1 if it fails. Unlike walking the
+// objects directory, git only has to stat the loose objects and packs.
+func objectsSize(ctx context.Context, dir common.GitDir) int64 {
+	cmd := exec.CommandContext(ctx, "git", "count-objects", "-v")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return -1
+	}
+
+	var kib int64
+	for _, line := range strings.Split(string(out), "\n") {
+		key, value, _ := strings.Cut(line, ": ")
+		if key == "size" || key == "size-pack" {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return -1
+			}
+			kib += n
+		}
+	}
+	return kib * 1024
+}
+
+func observeFetchBytes(fetchType string, before, after int64) {
+	if before < 0 || after < 0 {
+		return
+	}
+	size := after - before
+	// Objects may be removed concurrently, e.g. by garbage collection.
+	if size < 0 {
+		size = 0
+	}
+	fetchBytes.WithLabelValues(fetchType).Observe(float64(size))
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+)
+
+func TestFetchRefsFallbackReason(t *testing.T) {
+	customGitFetch = func() map[string][]string { return nil }
+
+	const (
+		before = "e6dcba6b52fd349279a38d9fa25db894467de609"
+		after  = "4cd5ee68a039032fe8a613879fd73b242592ea6a"
+	)
+	remoteURL, _ := vcs.ParseURL("https://github.com/sourcegraph/sourcegraph")
+	gitSyncer := NewGitRepoSyncer(wrexec.NewNoOpRecordingCommandFactory())
+
+	tests := []struct {
+		name   string
+		syncer VCSSyncer
+		ref    protocol.RefUpdate
+		want   string
+	}{
+		{name: "update", syncer: gitSyncer, ref: protocol.RefUpdate{Name: "refs/heads/main", Before: before, After: after}},
+		{name: "created", syncer: gitSyncer, ref: protocol.RefUpdate{Name: "refs/tags/v1.0.0", After: after}},
+		{name: "deleted", syncer: gitSyncer, ref: protocol.RefUpdate{Name: "refs/heads/main", Before: before}, want: "deleted"},
+		{name: "forced", syncer: gitSyncer, ref: protocol.RefUpdate{Name: "refs/heads/main", Before: before, After: after, Forced: true}, want: "forced"},
+		{name: "not fetched", syncer: gitSyncer, ref: protocol.RefUpdate{Name: "refs/notes/commits", After: after}, want: "invalid"},
+		{name: "refspec", syncer: gitSyncer, ref: protocol.RefUpdate{Name: "refs/heads/a:refs/remotes/b", After: after}, want: "invalid"},
+		{name: "invalid name", syncer: gitSyncer, ref: protocol.RefUpdate{Name: "refs/heads/a..b", After: after}, want: "invalid"},
+		{name: "abbreviated commit", syncer: gitSyncer, ref: protocol.RefUpdate{Name: "refs/heads/main", After: "4cd5ee6"}, want: "invalid"},
+		{name: "unsupported", syncer: NewMockVCSSyncer(), ref: protocol.RefUpdate{Name: "refs/heads/main", After: after}, want: "unsupported"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := fetchRefsFallbackReason(context.Background(), test.syncer, remoteURL, []protocol.RefUpdate{test.ref})
+			if got != test.want {
+				t.Errorf("got reason %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestServer_fetch(t *testing.T) {
+	customGitFetch = func() map[string][]string { return nil }
+
+	ctx := context.Background()
+
+	remote := t.TempDir()
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return strings.TrimSpace(runCmd(t, remote, name, arg...))
+	}
+	makeSingleCommitRepo(cmd)
+	cmd("git", "branch", "-M", "main")
+	cmd("git", "branch", "other")
+
+	root := t.TempDir()
+	dir := common.GitDir(filepath.Join(root, "repo", ".git"))
+	runCmd(t, root, "git", "clone", "--bare", remote, string(dir))
+	remoteURL, err := vcs.ParseURL(remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		Logger:                  logtest.Scoped(t),
+		ReposDir:                root,
+		RecordingCommandFactory: wrexec.NewNoOpRecordingCommandFactory(),
+	}
+	syncer := NewGitRepoSyncer(s.RecordingCommandFactory)
+	revParse := func(ref string) string {
+		t.Helper()
+		return strings.TrimSpace(runCmd(t, string(dir), "git", "rev-parse", ref))
+	}
+
+	t.Run("pushed refs", func(t *testing.T) {
+		before := revParse("main")
+		cmd("git", "checkout", "-q", "other")
+		cmd("sh", "-c", "echo other >> hello.txt")
+		otherAfter := addCommitToRepo(cmd)
+		cmd("git", "checkout", "-q", "main")
+		cmd("sh", "-c", "echo main >> hello.txt")
+		after := addCommitToRepo(cmd)
+
+		refs := []protocol.RefUpdate{{Name: "refs/heads/main", Before: api.CommitID(before), After: api.CommitID(after)}}
+		if _, err := s.fetch(ctx, s.Logger, syncer, remoteURL, "repo", dir, "", refs); err != nil {
+			t.Fatal(err)
+		}
+		if got := revParse("main"); got != after {
+			t.Errorf("main is %s, want %s", got, after)
+		}
+		// Only the pushed ref is fetched.
+		if got := revParse("other"); got == otherAfter {
+			t.Errorf("other was fetched")
+		}
+	})
+
+	t.Run("force push", func(t *testing.T) {
+		before := revParse("main")
+		cmd("git", "commit", "-q", "--amend", "-m", "amended")
+		after := cmd("git", "rev-parse", "HEAD")
+		otherAfter := cmd("git", "rev-parse", "other")
+
+		// The code host doesn't report that main was force-pushed.
+		refs := []protocol.RefUpdate{{Name: "refs/heads/main", Before: api.CommitID(before), After: api.CommitID(after)}}
+		if _, err := s.fetch(ctx, s.Logger, syncer, remoteURL, "repo", dir, "", refs); err != nil {
+			t.Fatal(err)
+		}
+		if got := revParse("main"); got != after {
+			t.Errorf("main is %s, want %s", got, after)
+		}
+		// Falling back to a full fetch fetches all refs.
+		if got := revParse("other"); got != otherAfter {
+			t.Errorf("other is %s, want %s", got, otherAfter)
+		}
+	})
+}
+
+func TestObjectsSize(t *testing.T) {
+	remote := t.TempDir()
+	makeSingleCommitRepo(func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	})
+
+	if size := objectsSize(context.Background(), common.GitDir(filepath.Join(remote, ".git"))); size <= 0 {
+		t.Errorf("expected objects of a repository with a commit, got size %d", size)
+	}
+	if size := objectsSize(context.Background(), common.GitDir(filepath.Join(remote, "missing"))); size != -1 {
+		t.Errorf("expected -1 for a missing repository, got size %d", size)
+	}
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// GitLFSOptions configures fetching Git LFS objects for the repositories of a
+// code host connection.
+type GitLFSOptions struct {
+	// MaxSizeBytes is the maximum amount of disk space the LFS objects of a
+	// single repository may use.
+	MaxSizeBytes int64
+}
+
+const (
+	// gitConfigLFSMaxSize is the git config key we store the LFS budget of a
+	// repository under. It is only set for repositories with LFS enabled.
+	gitConfigLFSMaxSize = "sourcegraph.lfsMaxSize"
+	// gitConfigLFSExceeded is the git config key we store the LFS budget under
+	// once the LFS objects of a repository exceeded it.
+	gitConfigLFSExceeded = "sourcegraph.lfsExceeded"
+	// lfsAttributesFile is the name of the file in $GIT_DIR/info containing the
+	// LFS patterns of the repository, see setGitAttributes.
+	lfsAttributesFile = "lfs-attributes"
+)
+
+// lfsFilterConfig is the git config that makes git resolve LFS pointers from
+// the local LFS object store. We use --skip so that git-lfs never tries to
+// download objects on demand, and only configure the smudge command since it
+// is only run for files tracked by LFS. Unlike filter-process, git falls back
+// to the pointer if it fails, e.g. because git-lfs is not installed.
+var lfsFilterConfig = [][2]string{
+	{"filter.lfs.smudge", "git-lfs smudge --skip -- %f"},
+	{"filter.lfs.required", "false"},
+}
+
+// syncGitLFS fetches the LFS objects of the default branch of the repository
+// at dir if its code host connection enabled Git LFS support. Otherwise, it
+// removes what is left over from when it was enabled.
+func (s *Server) syncGitLFS(ctx context.Context, repo api.RepoName, dir common.GitDir, syncer VCSSyncer, remoteURL *vcs.URL) error {
+	gs, ok := syncer.(*gitRepoSyncer)
+	if !ok || gs.lfs == nil {
+		return s.disableGitLFS(dir)
+	}
+
+	maxSize := strconv.FormatInt(gs.lfs.MaxSizeBytes, 10)
+	if exceeded, _ := gitConfigGet(dir, s, gitConfigLFSExceeded); exceeded == maxSize {
+		// The LFS objects did not fit into the budget before, we only try
+		// again once the budget changes or the repository is recloned.
+		return nil
+	}
+
+	for _, kv := range lfsFilterConfig {
+		if err := gitConfigSet(dir, s, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	if err := gitConfigSet(dir, s, gitConfigLFSMaxSize, maxSize); err != nil {
+		return err
+	}
+	if err := setLFSAttributes(ctx, dir); err != nil {
+		return err
+	}
+
+	// Check the budget against the sizes recorded in the pointers before
+	// downloading anything, so that objects exceeding it never hit the disk.
+	size, err := lfsPointersSize(ctx, dir)
+	if err != nil {
+		return err
+	}
+	if size > gs.lfs.MaxSizeBytes {
+		s.Logger.Warn("not fetching Git LFS objects exceeding the LFS size budget",
+			log.String("repo", string(repo)),
+			log.Int64("size-bytes", size),
+			log.Int64("budget-bytes", gs.lfs.MaxSizeBytes),
+		)
+		if err := os.RemoveAll(dir.Path("lfs")); err != nil {
+			return err
+		}
+		return gitConfigSet(dir, s, gitConfigLFSExceeded, maxSize)
+	}
+
+	if err := gs.fetchLFS(ctx, repo, dir, remoteURL); err != nil {
+		return err
+	}
+
+	// Objects of earlier fetches are still around, so the store can exceed
+	// the budget even if the objects of the default branch don't.
+	_, err = enforceGitLFSBudget(s.Logger, dir, s)
+	return err
+}
+
+// disableGitLFS removes the LFS configuration and objects of the repository at
+// dir, if there are any.
+func (s *Server) disableGitLFS(dir common.GitDir) error {
+	if maxSize, err := gitConfigGet(dir, s, gitConfigLFSMaxSize); err != nil || maxSize == "" {
+		return err
+	}
+
+	for _, key := range []string{gitConfigLFSMaxSize, gitConfigLFSExceeded, lfsFilterConfig[0][0], lfsFilterConfig[1][0]} {
+		if err := gitConfigUnset(dir, s, key); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(dir.Path("info", lfsAttributesFile)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove LFS attributes")
+	}
+	if err := setGitAttributes(dir); err != nil {
+		return err
+	}
+	return os.RemoveAll(dir.Path("lfs"))
+}
+
+// setLFSAttributes copies the attributes of the paths tracked by LFS from the
+// root .gitattributes of the default branch into $GIT_DIR/info. git archive
+// reads the attributes from the archived tree, but commands like
+// git cat-file --filters only consult $GIT_DIR/info/attributes in a bare
+// repository.
+func setLFSAttributes(ctx context.Context, dir common.GitDir) error {
+	cmd := exec.CommandContext(ctx, "git", "show", "HEAD:.gitattributes")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		// The default branch has no .gitattributes, so there is nothing
+		// tracked by LFS to resolve.
+		out = nil
+	}
+
+	var attrs bytes.Buffer
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		if bytes.Contains(line, []byte("filter=lfs")) {
+			attrs.Write(bytes.TrimSpace(line))
+			attrs.WriteByte('\n')
+		}
+	}
+
+	if err := os.MkdirAll(dir.Path("info"), os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to set LFS attributes")
+	}
+	if err := os.WriteFile(dir.Path("info", lfsAttributesFile), attrs.Bytes(), 0o644); err != nil {
+		return errors.Wrap(err, "failed to set LFS attributes")
+	}
+	return setGitAttributes(dir)
+}
+
+// lfsPointerPrefix is the prefix of every Git LFS pointer file, and
+// lfsPointerMaxSize is the size a blob must be below to be a pointer. See
+// https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md.
+const (
+	lfsPointerPrefix  = "version https://git-lfs.github.com/spec/v1"
+	lfsPointerMaxSize = 1024
+)
+
+// lfsPointersSize returns the total size of the distinct LFS objects the
+// pointers on the default branch of the repository at dir refer to, as
+// recorded in the pointers.
+func lfsPointersSize(ctx context.Context, dir common.GitDir) (int64, error) {
+	cmd := exec.CommandContext(ctx, "git", "ls-tree", "-r", "-l", "-z", "HEAD")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list LFS pointers")
+	}
+
+	// Only small blobs can be pointers, so we only read those.
+	var candidates bytes.Buffer
+	for _, entry := range bytes.Split(out, []byte{0}) {
+		// <mode> SP <type> SP <object> SP+ <size> TAB <path>
+		meta, _, ok := bytes.Cut(entry, []byte("\t"))
+		fields := bytes.Fields(meta)
+		if !ok || len(fields) != 4 || string(fields[1]) != "blob" {
+			continue
+		}
+		if size, err := strconv.Atoi(string(fields[3])); err != nil || size >= lfsPointerMaxSize {
+			continue
+		}
+		candidates.Write(fields[2])
+		candidates.WriteByte('\n')
+	}
+	if candidates.Len() == 0 {
+		return 0, nil
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	dir.Set(cmd)
+	cmd.Stdin = &candidates
+	out, err = cmd.Output()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read LFS pointers")
+	}
+
+	var total int64
+	seen := map[string]struct{}{}
+	for len(out) > 0 {
+		// <object> SP <type> SP <size> LF <contents> LF
+		header, rest, ok := bytes.Cut(out, []byte("\n"))
+		fields := bytes.Fields(header)
+		if !ok || len(fields) != 3 {
+			return 0, errors.Newf("unexpected git cat-file output: %q", header)
+		}
+		size, err := strconv.Atoi(string(fields[2]))
+		if err != nil || size+1 > len(rest) {
+			return 0, errors.Newf("unexpected git cat-file output: %q", header)
+		}
+		contents := rest[:size]
+		out = rest[size+1:]
+
+		if !bytes.HasPrefix(contents, []byte(lfsPointerPrefix)) {
+			continue
+		}
+		var oid string
+		var objectSize int64
+		for _, line := range bytes.Split(contents, []byte("\n")) {
+			key, value, _ := bytes.Cut(line, []byte(" "))
+			switch string(key) {
+			case "oid":
+				oid = string(value)
+			case "size":
+				objectSize, _ = strconv.ParseInt(string(value), 10, 64)
+			}
+		}
+		if _, ok := seen[oid]; ok || oid == "" {
+			continue
+		}
+		seen[oid] = struct{}{}
+		total += objectSize
+	}
+	return total, nil
+}
+
+// fetchLFS downloads the LFS objects referenced by the default branch into the
+// LFS object store of the repository at dir.
+func (s *gitRepoSyncer) fetchLFS(ctx context.Context, repoName api.RepoName, dir common.GitDir, remoteURL *vcs.URL) error {
+	cmd := exec.CommandContext(ctx, "git", "lfs", "fetch", remoteURL.String(), "HEAD")
+	dir.Set(cmd)
+	output, err := runRemoteGitCommand(ctx, s.recordingCommandFactory.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd), true, nil)
+	if err != nil {
+		return &common.GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(output))}
+	}
+	return nil
+}
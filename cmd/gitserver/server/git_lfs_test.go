@@ -0,0 +1,241 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+)
+
+const (
+	testLFSOID     = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+	testLFSPointer = "version https://git-lfs.github.com/spec/v1\noid sha256:" + testLFSOID + "\nsize 12\n"
+)
+
+// setupGitLFSTest creates a bare repository containing a file tracked by LFS,
+// and puts a stand-in for git-lfs on the PATH which fetches objects from a
+// local directory instead of an LFS server.
+func setupGitLFSTest(t *testing.T) (*Server, common.GitDir, *vcs.URL) {
+	t.Helper()
+
+	lfsServer := t.TempDir()
+	if err := os.WriteFile(filepath.Join(lfsServer, testLFSOID), []byte("hello world\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	bin := t.TempDir()
+	script := `#!/bin/sh
+case "$1" in
+fetch)
+	mkdir -p "$GIT_DIR/lfs/objects" && cp "` + lfsServer + `"/* "$GIT_DIR/lfs/objects/"
+	;;
+smudge)
+	pointer=$(cat)
+	oid=$(echo "$pointer" | sed -n 's/^oid sha256://p')
+	if [ -f "$GIT_DIR/lfs/objects/$oid" ]; then cat "$GIT_DIR/lfs/objects/$oid"; else echo "$pointer"; fi
+	;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(bin, "git-lfs"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	remote := t.TempDir()
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	}
+	cmd("git", "init", ".")
+	cmd("sh", "-c", "echo '*.sql filter=lfs diff=lfs merge=lfs -text' > .gitattributes")
+	cmd("sh", "-c", "printf '"+testLFSPointer+"' > dump.sql")
+	cmd("git", "add", ".")
+	cmd("git", "commit", "-m", "lfs")
+
+	root := t.TempDir()
+	dir := common.GitDir(filepath.Join(root, "repo", ".git"))
+	runCmd(t, root, "git", "clone", "--bare", remote, string(dir))
+	if err := setGitAttributes(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	remoteURL, err := vcs.ParseURL(remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		Logger:                  logtest.Scoped(t),
+		ReposDir:                root,
+		RecordingCommandFactory: wrexec.NewNoOpRecordingCommandFactory(),
+	}
+	return s, dir, remoteURL
+}
+
+// readFromArchive reads dump.sql from a git archive of HEAD, the way searcher
+// reads files.
+func readFromArchive(t *testing.T, dir common.GitDir) string {
+	t.Helper()
+	cmd := exec.Command("git", "archive", "--format=tar", "HEAD", "dump.sql")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(bytes.NewReader(out))
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			break
+		}
+	}
+	content, err := io.ReadAll(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// readWithFilters reads dump.sql the way the gitserver client does for LFS
+// pointers.
+func readWithFilters(t *testing.T, dir common.GitDir) string {
+	t.Helper()
+	cmd := exec.Command("git", "cat-file", "--filters", "--path=dump.sql", "HEAD:dump.sql")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestSyncGitLFS(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("within budget", func(t *testing.T) {
+		s, dir, remoteURL := setupGitLFSTest(t)
+		syncer := NewGitLFSRepoSyncer(s.RecordingCommandFactory, GitLFSOptions{MaxSizeBytes: 1024})
+
+		if err := s.syncGitLFS(ctx, s.name(dir), dir, syncer, remoteURL); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff("hello world\n", readWithFilters(t, dir)); diff != "" {
+			t.Errorf("unexpected content (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff("hello world\n", readFromArchive(t, dir)); diff != "" {
+			t.Errorf("unexpected archive content (-want +got):\n%s", diff)
+		}
+		if got, _ := gitConfigGet(dir, s, gitConfigLFSMaxSize); got != "1024" {
+			t.Errorf("unexpected %s: %q", gitConfigLFSMaxSize, got)
+		}
+	})
+
+	t.Run("exceeding budget", func(t *testing.T) {
+		s, dir, remoteURL := setupGitLFSTest(t)
+		syncer := NewGitLFSRepoSyncer(s.RecordingCommandFactory, GitLFSOptions{MaxSizeBytes: 5})
+
+		if err := s.syncGitLFS(ctx, s.name(dir), dir, syncer, remoteURL); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(testLFSPointer, readWithFilters(t, dir)); diff != "" {
+			t.Errorf("unexpected content (-want +got):\n%s", diff)
+		}
+		if got, _ := gitConfigGet(dir, s, gitConfigLFSExceeded); got != "5" {
+			t.Errorf("unexpected %s: %q", gitConfigLFSExceeded, got)
+		}
+		// The pointers already tell us the objects exceed the budget, so we
+		// never download them.
+		if _, err := os.Stat(dir.Path("lfs")); !os.IsNotExist(err) {
+			t.Errorf("expected LFS objects not to be fetched, got %v", err)
+		}
+
+		// We don't fetch the objects again for the same budget.
+		if err := s.syncGitLFS(ctx, s.name(dir), dir, syncer, remoteURL); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(dir.Path("lfs")); !os.IsNotExist(err) {
+			t.Errorf("expected LFS objects not to be fetched again, got %v", err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		s, dir, remoteURL := setupGitLFSTest(t)
+		syncer := NewGitLFSRepoSyncer(s.RecordingCommandFactory, GitLFSOptions{MaxSizeBytes: 1024})
+		if err := s.syncGitLFS(ctx, s.name(dir), dir, syncer, remoteURL); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.syncGitLFS(ctx, s.name(dir), dir, NewGitRepoSyncer(s.RecordingCommandFactory), remoteURL); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(testLFSPointer, readWithFilters(t, dir)); diff != "" {
+			t.Errorf("unexpected content (-want +got):\n%s", diff)
+		}
+		if _, err := os.Stat(dir.Path("lfs")); !os.IsNotExist(err) {
+			t.Errorf("expected LFS objects to be removed, got %v", err)
+		}
+		attributes, err := os.ReadFile(dir.Path("info", "attributes"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(attributes), "filter=lfs") {
+			t.Errorf("expected LFS attributes to be removed, got %q", attributes)
+		}
+	})
+}
+
+func TestLFSPointersSize(t *testing.T) {
+	_, dir, _ := setupGitLFSTest(t)
+
+	size, err := lfsPointersSize(context.Background(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 12 {
+		t.Errorf("unexpected size: %d", size)
+	}
+}
+
+func TestEnforceGitLFSBudget(t *testing.T) {
+	s, dir, remoteURL := setupGitLFSTest(t)
+	syncer := NewGitLFSRepoSyncer(s.RecordingCommandFactory, GitLFSOptions{MaxSizeBytes: 1024})
+	if err := s.syncGitLFS(context.Background(), s.name(dir), dir, syncer, remoteURL); err != nil {
+		t.Fatal(err)
+	}
+
+	if removed, err := enforceGitLFSBudget(s.Logger, dir, s); err != nil || removed {
+		t.Fatalf("expected objects within budget to be kept, got removed=%v err=%v", removed, err)
+	}
+
+	// Lowering the budget below the size of the objects removes them.
+	if err := gitConfigSet(dir, s, gitConfigLFSMaxSize, "5"); err != nil {
+		t.Fatal(err)
+	}
+	if removed, err := enforceGitLFSBudget(s.Logger, dir, s); err != nil || !removed {
+		t.Fatalf("expected objects exceeding budget to be removed, got removed=%v err=%v", removed, err)
+	}
+	if _, err := os.Stat(dir.Path("lfs")); !os.IsNotExist(err) {
+		t.Errorf("expected LFS objects to be removed, got %v", err)
+	}
+	if got, _ := gitConfigGet(dir, s, gitConfigLFSExceeded); got != "5" {
+		t.Errorf("unexpected %s: %q", gitConfigLFSExceeded, got)
+	}
+}
@@ -61,6 +61,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/internal/wrexec"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -226,6 +227,11 @@ type Server struct {
 type locks struct {
 	once *sync.Once  // consolidates multiple waiting updates
 	mu   *sync.Mutex // prevents updates running in parallel
+
+	// The following fields are guarded by repoUpdateLocksMu.
+
+	waiting bool                 // whether updates are waiting for the next update
+	refs    []protocol.RefUpdate // the refs requested by the waiting updates, or nil for all refs
 }
 
 // shortGitCommandTimeout returns the timeout for git commands that should not
@@ -935,8 +941,10 @@ func (s *Server) repoUpdate(req *protocol.RepoUpdateRequest) protocol.RepoUpdate
 	} else {
 		var statusErr, updateErr error
 
-		if debounce(req.Repo, req.Since) {
-			updateErr = s.doRepoUpdate(ctx, req.Repo, "")
+		// Updates of pushed refs are not debounced, since the refs may have been
+		// pushed after the last update.
+		if len(req.Refs) > 0 || debounce(req.Repo, req.Since) {
+			updateErr = s.doRepoUpdate(ctx, req.Repo, "", req.Refs)
 		}
 
 		// attempts to acquire these values are not contingent on the success of
@@ -1949,6 +1957,22 @@ func (s *Server) setCloneStatusNonFatal(ctx context.Context, name api.RepoName,
 	}
 }
 
+// enqueueCloneEvent sends the repo:cloned outbound webhook event, or
+// repo:clone_failed if cloneErr is non-nil.
+func (s *Server) enqueueCloneEvent(ctx context.Context, name api.RepoName, cloneErr error) {
+	r, err := s.DB.Repos().GetByName(ctx, name)
+	if err != nil || r == nil {
+		s.Logger.Warn("Getting repo for outbound webhook", log.String("repo", string(name)), log.Error(err))
+		return
+	}
+
+	eventType := events.RepoCloned
+	if cloneErr != nil {
+		eventType = events.RepoCloneFailed
+	}
+	database.EnqueueOutboundWebhookEvent(ctx, s.Logger, database.OutboundWebhookJobsWithDefaultKey(s.DB), eventType, events.NewRepository(r, cloneErr))
+}
+
 // setRepoSize calculates the size of the repo and stores it in the database.
 func (s *Server) setRepoSize(ctx context.Context, name api.RepoName) error {
 	return s.DB.GitserverRepos().SetRepoSize(ctx, name, dirSize(s.dir(name).Path(".")), s.Hostname)
@@ -1965,20 +1989,26 @@ func (s *Server) logIfCorrupt(ctx context.Context, repo api.RepoName, dir common
 
 // setGitAttributes writes our global gitattributes to
 // gitDir/info/attributes. This will override .gitattributes inside of
-// repositories. It is used to unset attributes such as export-ignore.
+// repositories. It is used to unset attributes such as export-ignore, and to
+// apply the attributes of paths tracked by Git LFS if LFS is enabled for the
+// repository.
 func setGitAttributes(dir common.GitDir) error {
 	infoDir := dir.Path("info")
 	if err := os.Mkdir(infoDir, os.ModePerm); err != nil && !os.IsExist(err) {
 		return errors.Wrap(err, "failed to set git attributes")
 	}
 
-	_, err := fileutil.UpdateFileIfDifferent(
-		filepath.Join(infoDir, "attributes"),
-		[]byte(`# Managed by Sourcegraph gitserver.
+	attributes := []byte(`# Managed by Sourcegraph gitserver.
 
 # We want every file to be present in git archive.
 * -export-ignore
-`))
+`)
+	if lfsAttributes, err := os.ReadFile(filepath.Join(infoDir, lfsAttributesFile)); err == nil && len(lfsAttributes) > 0 {
+		attributes = append(attributes, "\n# Paths tracked by Git LFS.\n"...)
+		attributes = append(attributes, lfsAttributes...)
+	}
+
+	_, err := fileutil.UpdateFileIfDifferent(filepath.Join(infoDir, "attributes"), attributes)
 	if err != nil {
 		return errors.Wrap(err, "failed to set git attributes")
 	}
@@ -2143,6 +2173,7 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir common.GitD
 	defer func() {
 		// Use a background context to ensure we still update the DB even if we time out
 		s.setCloneStatusNonFatal(context.Background(), repo, cloneStatus(repoCloned(dir), false))
+		s.enqueueCloneEvent(actor.WithInternalActor(context.Background()), repo, err)
 	}()
 
 	cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
@@ -2201,6 +2232,12 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir common.GitD
 		return err
 	}
 
+	// Fetch Git LFS objects if the code host connection enabled it. The clone
+	// is still usable without them, so we only log failures.
+	if err := s.syncGitLFS(ctx, repo, tmp, syncer, remoteURL); err != nil {
+		logger.Warn("failed to fetch Git LFS objects", log.Error(err))
+	}
+
 	if overwrite {
 		// remove the current repo by putting it into our temporary directory
 		err := fileutil.RenameAndSync(dstPath, filepath.Join(filepath.Dir(tmpPath), "old"))
@@ -2449,7 +2486,9 @@ func honeySampleRate(cmd string, actor *actor.Actor) uint {
 
 var headBranchPattern = lazyregexp.New(`HEAD branch: (.+?)\n`)
 
-func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec string) (err error) {
+// doRepoUpdate fetches updates of the repository. If refs is not empty, only the given
+// refs are fetched if possible.
+func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec string, refs []protocol.RefUpdate) (err error) {
 	tr, ctx := trace.New(ctx, "doRepoUpdate", repo.Attr())
 	defer tr.EndWithErr(&err)
 
@@ -2462,6 +2501,12 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec st
 		}
 		s.repoUpdateLocks[repo] = l
 	}
+	// The next update fetches the refs requested by all the updates waiting for it.
+	if l.waiting {
+		l.refs = protocol.MergeRefUpdates(l.refs, refs)
+	} else {
+		l.waiting, l.refs = true, refs
+	}
 	once := l.once
 	mu := l.mu
 	s.repoUpdateLocksMu.Unlock()
@@ -2479,9 +2524,11 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec st
 
 			s.repoUpdateLocksMu.Lock()
 			l.once = new(sync.Once) // Make new requests wait for next update.
+			waitingRefs := l.refs
+			l.waiting, l.refs = false, nil
 			s.repoUpdateLocksMu.Unlock()
 
-			err = s.doBackgroundRepoUpdate(repo, revspec)
+			err = s.doBackgroundRepoUpdate(repo, revspec, waitingRefs)
 			if err != nil {
 				// We don't want to spam our logs when the rate limiter has been set to block all
 				// updates
@@ -2509,7 +2556,7 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec st
 
 var doBackgroundRepoUpdateMock func(api.RepoName) error
 
-func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error {
+func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string, refs []protocol.RefUpdate) error {
 	logger := s.Logger.Scoped("backgroundRepoUpdate", "").With(log.String("repo", string(repo)))
 
 	if doBackgroundRepoUpdateMock != nil {
@@ -2555,7 +2602,7 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 	// when the cleanup happens, just that it does.
 	defer s.cleanTmpFiles(dir)
 
-	output, err := syncer.Fetch(ctx, remoteURL, repo, dir, revspec)
+	output, err := s.fetch(ctx, logger, syncer, remoteURL, repo, dir, revspec, refs)
 	redactedOutput := newURLRedactor(remoteURL).redact(string(output))
 	// best-effort update the output of the fetch
 	go s.setLastOutput(context.Background(), repo, redactedOutput)
@@ -2578,6 +2625,10 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 		return errors.Wrapf(err, "failed to set repository type for repo %q", repo)
 	}
 
+	if err := s.syncGitLFS(ctx, repo, dir, syncer, remoteURL); err != nil {
+		logger.Warn("failed to fetch Git LFS objects", log.Error(err))
+	}
+
 	// Update the last-changed stamp on disk.
 	if err := setLastChanged(logger, dir); err != nil {
 		logger.Warn("failed to update last changed time", log.Error(err))
@@ -2848,7 +2899,7 @@ func (s *Server) ensureRevision(ctx context.Context, repo api.RepoName, rev stri
 		return false
 	}
 	// Revision not found, update before returning.
-	err := s.doRepoUpdate(ctx, repo, rev)
+	err := s.doRepoUpdate(ctx, repo, rev, nil)
 	if err != nil {
 		s.Logger.Warn("failed to perform background repo update", log.Error(err), log.String("repo", string(repo)), log.String("rev", rev))
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/internal/wrexec"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -17,12 +18,21 @@ import (
 // gitRepoSyncer is a syncer for Git repositories.
 type gitRepoSyncer struct {
 	recordingCommandFactory *wrexec.RecordingCommandFactory
+	// lfs is nil unless the Git LFS objects of the repository should be
+	// fetched, see syncGitLFS.
+	lfs *GitLFSOptions
 }
 
 func NewGitRepoSyncer(r *wrexec.RecordingCommandFactory) *gitRepoSyncer {
 	return &gitRepoSyncer{recordingCommandFactory: r}
 }
 
+// NewGitLFSRepoSyncer returns a syncer for Git repositories which also fetches
+// the Git LFS objects of the default branch.
+func NewGitLFSRepoSyncer(r *wrexec.RecordingCommandFactory, lfs GitLFSOptions) *gitRepoSyncer {
+	return &gitRepoSyncer{recordingCommandFactory: r, lfs: &lfs}
+}
+
 func (s *gitRepoSyncer) Type() string {
 	return "git"
 }
@@ -82,6 +92,22 @@ func (s *gitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, repoName
 	return output, nil
 }
 
+// FetchRefs fetches just the given refs of a Git repository. Unlike Fetch, it
+// doesn't prune refs that were deleted on the remote.
+func (s *gitRepoSyncer) FetchRefs(ctx context.Context, remoteURL *vcs.URL, repoName api.RepoName, dir common.GitDir, refs []protocol.RefUpdate) ([]byte, error) {
+	args := []string{"fetch", "--progress", remoteURL.String()}
+	for _, ref := range refs {
+		args = append(args, "+"+ref.Name+":"+ref.Name)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	output, err := runRemoteGitCommand(ctx, s.recordingCommandFactory.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd), true, nil)
+	if err != nil {
+		return nil, &common.GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(output))}
+	}
+	return output, nil
+}
+
 // RemoteShowCommand returns the command to be executed for showing remote of a Git repository.
 func (s *gitRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
 	return exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String()), nil
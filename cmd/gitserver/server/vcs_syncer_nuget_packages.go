@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewNuGetPackagesSyncer(
+	connection *schema.NuGetPackagesConnection,
+	svc *dependencies.Service,
+	client *nuget.Client,
+	reposDir string,
+) VCSSyncer {
+	return &vcsPackagesSyncer{
+		logger:      log.Scoped("NuGetPackagesSyncer", "sync NuGet packages"),
+		typ:         "nuget_packages",
+		scheme:      dependencies.NuGetPackagesScheme,
+		placeholder: reposource.NewNuGetVersionedPackage("Sourcegraph.Placeholder", "0.0.0"),
+		svc:         svc,
+		configDeps:  connection.Dependencies,
+		source:      &nugetDependencySource{client: client, reposDir: reposDir},
+	}
+}
+
+type nugetDependencySource struct {
+	client   *nuget.Client
+	reposDir string
+}
+
+func (nugetDependencySource) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.ParseNuGetVersionedPackage(string(name) + "@" + version)
+}
+
+func (nugetDependencySource) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParseNuGetVersionedPackage(dep)
+}
+
+func (nugetDependencySource) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParseNuGetPackageFromName(name)
+}
+
+func (nugetDependencySource) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParseNuGetPackageFromRepoName(repoName)
+}
+
+func (s *nugetDependencySource) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	pkgContents, err := s.client.GetPackageContents(ctx, dep)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading NuGet package %q", dep.VersionedPackageSyntax())
+	}
+	defer pkgContents.Close()
+
+	if err = unpackNuGetPackage(pkgContents, s.reposDir, dir); err != nil {
+		return errors.Wrapf(err, "failed to unzip NuGet package %q", dep.VersionedPackageSyntax())
+	}
+
+	return nil
+}
+
+// unpackNuGetPackage unpacks the given .nupkg file, which is a zip archive, into
+// workDir. It skips the files that describe the archive itself rather than the
+// package, and any files that aren't valid or that are potentially malicious.
+func unpackNuGetPackage(pkg io.Reader, reposDir, workDir string) error {
+	logger := log.Scoped("unpackNuGetPackage", "unpackNuGetPackage unpacks the given NuGet package archive into workDir")
+
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			if isNuGetPackagingFile(path) {
+				return false
+			}
+
+			size := file.Size()
+
+			const sizeLimit = 15 * 1024 * 1024
+			if size >= sizeLimit {
+				logger.With(
+					log.String("path", file.Name()),
+					log.Int64("size", size),
+					log.Float64("limit", sizeLimit),
+				).Warn("skipping large file in NuGet package")
+				return false
+			}
+
+			malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+
+	// We cannot unzip in a streaming fashion, so we write the zip file to a
+	// temporary file.
+	tmpdir, err := tempDir(reposDir, "nuget-packages")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	zip, zipLen, err := writeZipToTemp(tmpdir, pkg)
+	if err != nil {
+		return err
+	}
+	defer zip.Close()
+
+	// The files of a package are at the root of the archive, next to its
+	// .nuspec manifest, so there is no outermost directory to strip.
+	return unpack.Zip(zip, zipLen, workDir, opts)
+}
+
+// isNuGetPackagingFile returns whether the file at the given path of a .nupkg
+// archive is part of the Open Packaging Conventions container or the package
+// signature, rather than of the package.
+func isNuGetPackagingFile(p string) bool {
+	p = strings.TrimPrefix(path.Clean(p), "/")
+	return p == "[Content_Types].xml" ||
+		p == ".signature.p7s" ||
+		strings.HasPrefix(p, "_rels/") ||
+		strings.HasPrefix(p, "package/services/metadata/")
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnpackNuGetPackage(t *testing.T) {
+	pkg := bytes.NewReader(createZip(t, []fileInfo{
+		{path: "_rels/.rels", contents: []byte("rels")},
+		{path: "[Content_Types].xml", contents: []byte("types")},
+		{path: ".signature.p7s", contents: []byte("signature")},
+		{path: "package/services/metadata/core-properties/0123.psmdcp", contents: []byte("properties")},
+		{path: "Newtonsoft.Json.nuspec", contents: []byte("nuspec")},
+		{path: "README.md", contents: []byte("readme")},
+		{path: "lib/net6.0/Newtonsoft.Json.xml", contents: []byte("docs")},
+		{path: "../outside.cs", contents: []byte("filter me")},
+	}))
+
+	workDir := t.TempDir()
+	if err := unpackNuGetPackage(pkg, t.TempDir(), workDir); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"/Newtonsoft.Json.nuspec", "/README.md", "/lib/net6.0/Newtonsoft.Json.xml"}
+	if d := cmp.Diff(want, listFiles(t, workDir)); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+}
+
+func TestUnpackNuGetPackage_InvalidZip(t *testing.T) {
+	pkg := bytes.NewReader(createTgz(t, []fileInfo{{path: "Newtonsoft.Json.nuspec", contents: []byte("nuspec")}}))
+
+	if err := unpackNuGetPackage(pkg, t.TempDir(), t.TempDir()); err == nil {
+		t.Fatal("no error returned from unpack package")
+	}
+}
+
+func createZip(t *testing.T, fileInfos []fileInfo) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range fileInfos {
+		fw, err := zw.Create(f.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write(f.contents); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// listFiles returns the sorted paths of the files in dir, relative to dir.
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	var files []string
+	if err := filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			files = append(files, strings.TrimPrefix(path, dir))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnpackPHPPackage(t *testing.T) {
+	pkg := bytes.NewReader(createZip(t, []fileInfo{
+		{path: "Seldaek-monolog-e2392369686d420ca32df3803de28b5d6f76867d/composer.json", contents: []byte("{}")},
+		{path: "Seldaek-monolog-e2392369686d420ca32df3803de28b5d6f76867d/src/Monolog/Logger.php", contents: []byte("<?php")},
+		{path: "Seldaek-monolog-e2392369686d420ca32df3803de28b5d6f76867d/.git/config", contents: []byte("filter me")},
+	}))
+
+	workDir := t.TempDir()
+	if err := unpackPHPPackage(pkg, t.TempDir(), workDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// The directory that GitHub archives contain is removed.
+	want := []string{"/composer.json", "/src/Monolog/Logger.php"}
+	if d := cmp.Diff(want, listFiles(t, workDir)); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+}
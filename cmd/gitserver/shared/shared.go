@@ -12,6 +12,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -39,6 +40,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/crates"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodproxy"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/npm"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/packagist"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/pypi"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/rubygems"
 	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
@@ -99,6 +102,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	}
 
 	recordingCommandFactory := wrexec.NewRecordingCommandFactory(nil, 0)
+	gitLFSConfigs := newGitLFSConfigCache()
 	gitserver := server.Server{
 		Logger:             logger,
 		ObservationCtx:     observationCtx,
@@ -116,6 +120,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 				reposDir:                config.ReposDir,
 				coursierCacheDir:        config.CoursierCacheDir,
 				recordingCommandFactory: recordingCommandFactory,
+				gitLFSConfigs:           gitLFSConfigs,
 			})
 		},
 		Hostname:                externalAddress(),
@@ -348,6 +353,7 @@ type newVCSSyncerOpts struct {
 	reposDir                string
 	coursierCacheDir        string
 	recordingCommandFactory *wrexec.RecordingCommandFactory
+	gitLFSConfigs           *gitLFSConfigCache
 }
 
 func getVCSSyncer(ctx context.Context, opts *newVCSSyncerOpts) (server.VCSSyncer, error) {
@@ -458,10 +464,136 @@ func getVCSSyncer(ctx context.Context, opts *newVCSSyncerOpts) (server.VCSSyncer
 			return nil, err
 		}
 		return server.NewRubyPackagesSyncer(&c, opts.depsSvc, cli), nil
+	case extsvc.TypeNuGetPackages:
+		var c schema.NuGetPackagesConnection
+		urn, err := extractOptions(&c)
+		if err != nil {
+			return nil, err
+		}
+		cli, err := nuget.NewClient(urn, c.ServiceIndex, httpcli.ExternalClientFactory)
+		if err != nil {
+			return nil, err
+		}
+		return server.NewNuGetPackagesSyncer(&c, opts.depsSvc, cli, opts.reposDir), nil
+	case extsvc.TypePHPPackages:
+		var c schema.PHPPackagesConnection
+		urn, err := extractOptions(&c)
+		if err != nil {
+			return nil, err
+		}
+		cli, err := packagist.NewClient(urn, c.Repository, httpcli.ExternalClientFactory)
+		if err != nil {
+			return nil, err
+		}
+		return server.NewPHPPackagesSyncer(&c, opts.depsSvc, cli, opts.reposDir), nil
+	case extsvc.TypeGitHub, extsvc.TypeGitLab, extsvc.TypeBitbucketServer, extsvc.TypeOther:
+		lfs, enabled, err := opts.gitLFSConfigs.options(ctx, opts.externalServiceStore, r)
+		if err != nil {
+			return nil, err
+		}
+		if enabled {
+			return server.NewGitLFSRepoSyncer(opts.recordingCommandFactory, lfs), nil
+		}
 	}
 	return server.NewGitRepoSyncer(opts.recordingCommandFactory), nil
 }
 
+// gitLFSOptions returns the Git LFS options for the maxSizeMegabytes setting
+// of a code host connection.
+func gitLFSOptions(maxSizeMegabytes int) server.GitLFSOptions {
+	if maxSizeMegabytes <= 0 {
+		maxSizeMegabytes = 1024
+	}
+	return server.GitLFSOptions{MaxSizeBytes: int64(maxSizeMegabytes) * 1024 * 1024}
+}
+
+// gitLFSConfigTTL is how long the Git LFS settings of a code host connection
+// are cached.
+const gitLFSConfigTTL = time.Minute
+
+// gitLFSConfigCache caches the Git LFS settings of code host connections, so
+// that fetching a repository doesn't read and decrypt the configuration of its
+// code host connections every time.
+type gitLFSConfigCache struct {
+	mu      sync.Mutex
+	configs map[int64]cachedGitLFSConfig
+}
+
+type cachedGitLFSConfig struct {
+	enabled bool
+	lfs     server.GitLFSOptions
+	expires time.Time
+}
+
+func newGitLFSConfigCache() *gitLFSConfigCache {
+	return &gitLFSConfigCache{configs: map[int64]cachedGitLFSConfig{}}
+}
+
+// options returns the Git LFS options of a repository of a code host that
+// supports Git LFS. Git LFS is enabled if any code host connection of the
+// repository enables it, with the largest size limit of those connections, so
+// that the result does not depend on the order in which the sources of the
+// repository are visited.
+func (c *gitLFSConfigCache) options(ctx context.Context, store database.ExternalServiceStore, r *types.Repo) (lfs server.GitLFSOptions, enabled bool, err error) {
+	for _, info := range r.Sources {
+		config, err := c.get(ctx, store, info.ExternalServiceID())
+		if err != nil {
+			return server.GitLFSOptions{}, false, err
+		}
+		if !config.enabled {
+			continue
+		}
+		enabled = true
+		if config.lfs.MaxSizeBytes > lfs.MaxSizeBytes {
+			lfs = config.lfs
+		}
+	}
+	return lfs, enabled, nil
+}
+
+func (c *gitLFSConfigCache) get(ctx context.Context, store database.ExternalServiceStore, id int64) (cachedGitLFSConfig, error) {
+	c.mu.Lock()
+	config, ok := c.configs[id]
+	c.mu.Unlock()
+	if ok && time.Now().Before(config.expires) {
+		return config, nil
+	}
+
+	extSvc, err := store.GetByID(ctx, id)
+	if err != nil {
+		return cachedGitLFSConfig{}, errors.Wrap(err, "get external service")
+	}
+	rawConfig, err := extSvc.Config.Decrypt(ctx)
+	if err != nil {
+		return cachedGitLFSConfig{}, err
+	}
+	normalized, err := jsonc.Parse(rawConfig)
+	if err != nil {
+		return cachedGitLFSConfig{}, errors.Wrap(err, "normalize JSON")
+	}
+	// The GitHub, GitLab, Bitbucket Server and other code host connections
+	// share the gitLFS setting.
+	var conn struct {
+		GitLFS *struct {
+			Enabled          bool `json:"enabled"`
+			MaxSizeMegabytes int  `json:"maxSizeMegabytes"`
+		} `json:"gitLFS"`
+	}
+	if err := jsoniter.Unmarshal(normalized, &conn); err != nil {
+		return cachedGitLFSConfig{}, errors.Wrap(err, "unmarshal JSON")
+	}
+
+	config = cachedGitLFSConfig{expires: time.Now().Add(gitLFSConfigTTL)}
+	if conn.GitLFS != nil && conn.GitLFS.Enabled {
+		config.enabled = true
+		config.lfs = gitLFSOptions(conn.GitLFS.MaxSizeMegabytes)
+	}
+	c.mu.Lock()
+	c.configs[id] = config
+	c.mu.Unlock()
+	return config, nil
+}
+
 func syncExternalServiceRateLimiters(ctx context.Context, store database.ExternalServiceStore) error {
 	svcs, err := store.List(ctx, database.ExternalServicesListOptions{})
 	if err != nil {
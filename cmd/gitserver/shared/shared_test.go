@@ -94,6 +94,53 @@ func TestGetVCSSyncer(t *testing.T) {
 	}
 }
 
+func TestGitLFSConfigCache(t *testing.T) {
+	configs := map[int64]string{
+		1: `{"url": "https://github.com"}`,
+		2: `{"url": "https://github.com", "gitLFS": {"enabled": true, "maxSizeMegabytes": 10}}`,
+		3: `{"url": "https://github.com", "gitLFS": {"enabled": true, "maxSizeMegabytes": 20}}`,
+	}
+	extsvcStore := database.NewMockExternalServiceStore()
+	extsvcStore.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int64) (*types.ExternalService, error) {
+		return &types.ExternalService{
+			ID:     id,
+			Kind:   extsvc.KindGitHub,
+			Config: extsvc.NewUnencryptedConfig(configs[id]),
+		}, nil
+	})
+	newRepo := func(ids ...int64) *types.Repo {
+		r := &types.Repo{Sources: map[string]*types.SourceInfo{}}
+		for _, id := range ids {
+			urn := extsvc.URN(extsvc.KindGitHub, id)
+			r.Sources[urn] = &types.SourceInfo{ID: urn}
+		}
+		return r
+	}
+
+	cache := newGitLFSConfigCache()
+	for i := 0; i < 2; i++ {
+		lfs, enabled, err := cache.options(context.Background(), extsvcStore, newRepo(1, 2, 3))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !enabled || lfs.MaxSizeBytes != 20*1024*1024 {
+			t.Fatalf("want Git LFS enabled with the largest size limit, got enabled=%v, %+v", enabled, lfs)
+		}
+	}
+	if calls := len(extsvcStore.GetByIDFunc.History()); calls != 3 {
+		t.Fatalf("want the config of each code host connection to be read once, got %d reads", calls)
+	}
+
+	for name, r := range map[string]*types.Repo{
+		"disabled":   newRepo(1),
+		"no sources": newRepo(),
+	} {
+		if _, enabled, err := cache.options(context.Background(), extsvcStore, r); err != nil || enabled {
+			t.Fatalf("%s: want Git LFS disabled, got enabled=%v, err=%v", name, enabled, err)
+		}
+	}
+}
+
 func TestMethodSpecificStreamInterceptor(t *testing.T) {
 	tests := []struct {
 		name string
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -32,10 +33,11 @@ func MakePermsSyncerWorker(observationCtx *observation.Context, syncer permsSync
 		logger = observationCtx.Logger.Scoped("UserPermsSyncerWorker", "User permissions sync worker")
 	}
 	return &permsSyncerWorker{
-		logger:    logger,
-		syncer:    syncer,
-		syncType:  syncType,
-		jobsStore: jobsStore,
+		logger:              logger,
+		syncer:              syncer,
+		syncType:            syncType,
+		jobsStore:           jobsStore,
+		outboundWebhookJobs: database.OutboundWebhookJobsWithDefaultKey(jobsStore),
 	}
 }
 
@@ -44,6 +46,8 @@ type permsSyncerWorker struct {
 	syncer    permsSyncer
 	syncType  syncType
 	jobsStore database.PermissionSyncJobStore
+
+	outboundWebhookJobs database.OutboundWebhookJobStore
 }
 
 // PreDequeue in our case does a nice trick of adding a predicate (WHERE clause)
@@ -72,18 +76,22 @@ func (h *permsSyncerWorker) Handle(ctx context.Context, _ log.Logger, record *da
 		log.Int("priority", int(record.Priority)),
 	)
 
-	return h.handlePermsSync(ctx, reqType, reqID, record.ID, record.NoPerms, record.InvalidateCaches)
+	return h.handlePermsSync(ctx, reqType, reqID, record)
 }
 
 // handlePermsSync is effectively a sync version of `perms_syncer.syncPerms`
 // which calls `perms_syncer.syncUserPerms` or `perms_syncer.syncRepoPerms`
 // depending on a request type and logs/adds metrics of sync statistics
 // afterwards.
-func (h *permsSyncerWorker) handlePermsSync(ctx context.Context, reqType requestType, reqID int32, recordID int, noPerms, invalidateCaches bool) error {
+func (h *permsSyncerWorker) handlePermsSync(ctx context.Context, reqType requestType, reqID int32, record *database.PermissionSyncJob) error {
 	var err error
 	var result *database.SetPermissionsResult
 	var providerStates database.CodeHostStatusesSet
 
+	recordID := record.ID
+	noPerms := record.NoPerms
+	invalidateCaches := record.InvalidateCaches
+
 	switch reqType {
 	case requestTypeUser:
 		result, providerStates, err = h.syncer.syncUserPerms(ctx, reqID, noPerms, authz.FetchPermsOptions{InvalidateCaches: invalidateCaches})
@@ -115,9 +123,34 @@ func (h *permsSyncerWorker) handlePermsSync(ctx context.Context, reqType request
 		h.logger.Error(fmt.Sprintf("failed to save permissions sync job(%d) results", recordID), log.Error(saveErr))
 	}
 
+	h.enqueueCompleteEvent(ctx, record, result, providerStates, err)
+
 	return err
 }
 
+// enqueueCompleteEvent sends the permissions_sync:complete outbound webhook
+// event for the given job.
+func (h *permsSyncerWorker) enqueueCompleteEvent(ctx context.Context, record *database.PermissionSyncJob, result *database.SetPermissionsResult, providerStates database.CodeHostStatusesSet, syncErr error) {
+	r := events.PermissionsSyncResult{
+		JobID:        record.ID,
+		UserID:       int32(record.UserID),
+		RepositoryID: api.RepoID(record.RepositoryID),
+		Reason:       string(record.Reason),
+		Err:          syncErr,
+	}
+	if result != nil {
+		r.Added = result.Added
+		r.Removed = result.Removed
+		r.Found = result.Found
+	}
+	if syncErr == nil {
+		_, success, failed := providerStates.CountStatuses()
+		r.PartialSuccess = success > 0 && failed > 0
+	}
+
+	database.EnqueueOutboundWebhookEvent(ctx, h.logger, h.outboundWebhookJobs, events.PermissionsSyncComplete, events.NewPermissionsSync(r))
+}
+
 func MakeStore(observationCtx *observation.Context, dbHandle basestore.TransactableHandle, syncType syncType) dbworkerstore.Store[*database.PermissionSyncJob] {
 	name := "repo_permissions_sync_job_worker_store"
 	if syncType == SyncTypeUser {
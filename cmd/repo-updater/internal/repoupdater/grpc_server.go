@@ -42,9 +42,7 @@ func (s *RepoUpdaterServiceServer) RepoLookup(ctx context.Context, req *proto.Re
 }
 
 func (s *RepoUpdaterServiceServer) EnqueueRepoUpdate(ctx context.Context, req *proto.EnqueueRepoUpdateRequest) (*proto.EnqueueRepoUpdateResponse, error) {
-	args := &protocol.RepoUpdateRequest{
-		Repo: api.RepoName(req.GetRepo()),
-	}
+	args := protocol.RepoUpdateRequestFromProto(req)
 	res, httpStatus, err := s.Server.enqueueRepoUpdate(ctx, args)
 	if err != nil {
 		if httpStatus == http.StatusNotFound {
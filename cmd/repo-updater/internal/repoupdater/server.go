@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
@@ -39,6 +40,7 @@ type Server struct {
 	SourcegraphDotComMode bool
 	Scheduler             interface {
 		UpdateOnce(id api.RepoID, name api.RepoName)
+		UpdateRefs(id api.RepoID, name api.RepoName, refs []gitserverprotocol.RefUpdate)
 		ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
 	}
 	ChangesetSyncRegistry syncer.ChangesetSyncRegistry
@@ -142,7 +144,11 @@ func (s *Server) enqueueRepoUpdate(ctx context.Context, req *protocol.RepoUpdate
 
 	repo := rs[0]
 
-	s.Scheduler.UpdateOnce(repo.ID, repo.Name)
+	if len(req.Refs) > 0 {
+		s.Scheduler.UpdateRefs(repo.ID, repo.Name, req.Refs)
+	} else {
+		s.Scheduler.UpdateOnce(repo.ID, repo.Name)
+	}
 
 	return &protocol.RepoUpdateResponse{
 		ID:   repo.ID,
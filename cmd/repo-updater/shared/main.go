@@ -119,6 +119,10 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	}
 
 	updateScheduler := repos.NewUpdateScheduler(logger, db)
+	if err := updateScheduler.RestoreSchedule(ctx); err != nil {
+		// Not fatal: the scheduler learns the update intervals of the repos again.
+		logger.Error("restoring update schedule", log.Error(err))
+	}
 	server := &repoupdater.Server{
 		Logger:                logger,
 		ObservationCtx:        observationCtx,
@@ -0,0 +1,193 @@
+package search
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+)
+
+// Limits on expanding archives stored in a repository. They bound how much
+// of the searcher disk cache (and memory, since archives are buffered to be
+// read) a single repository can consume via its archives.
+const (
+	// maxArchiveSize is the largest archive we will expand. Larger archives
+	// are only searchable by name.
+	maxArchiveSize = 64 << 20 // 64MB
+
+	// maxArchiveDepth is how deeply we expand archives nested inside of
+	// archives. A depth of 1 means we only expand archives committed to the
+	// repository.
+	maxArchiveDepth = 3
+
+	// maxArchiveExpandedSize is the total number of bytes of archive members
+	// we will add to the zip of a single repository.
+	maxArchiveExpandedSize = 256 << 20 // 256MB
+)
+
+// archiveMemberSeparator separates the path of an archive from the path of a
+// member inside of it, eg lib/foo.jar!/META-INF/MANIFEST.MF.
+const archiveMemberSeparator = "!/"
+
+// archiveExpander writes the members of archives found in a repository into
+// the zip we store on disk. It is not safe for concurrent use.
+type archiveExpander struct {
+	zw     *zip.Writer
+	filter *searchableFilter
+
+	// remaining is the number of bytes of archive members we may still
+	// write. See maxArchiveExpandedSize.
+	remaining int64
+}
+
+func newArchiveExpander(zw *zip.Writer, filter *searchableFilter) *archiveExpander {
+	return &archiveExpander{
+		zw:        zw,
+		filter:    filter,
+		remaining: maxArchiveExpandedSize,
+	}
+}
+
+// Expand writes the text members of the archive at name with content data
+// to the zip. Archives which cannot be read are skipped rather than failing
+// the fetch, so the returned error only reports failures to write to the zip.
+func (e *archiveExpander) Expand(name string, data []byte) error {
+	return e.expand(name, data, 1)
+}
+
+func (e *archiveExpander) expand(name string, data []byte, depth int) error {
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		return e.expandZip(name, data, depth)
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil
+		}
+		// A gzip stream compresses a single file, which we only expand if
+		// it is a tarball. gzipped non-tar files are not archives.
+		return e.expandTar(name, tar.NewReader(gr), depth)
+	case isTar(data):
+		return e.expandTar(name, tar.NewReader(bytes.NewReader(data)), depth)
+	default:
+		return nil
+	}
+}
+
+// isTar reports whether data starts with a ustar (or GNU tar) header.
+func isTar(data []byte) bool {
+	const magicOffset = 257
+	return len(data) >= magicOffset+5 && string(data[magicOffset:magicOffset+5]) == "ustar"
+}
+
+func (e *archiveExpander) expandZip(name string, data []byte, depth int) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !f.Mode().IsRegular() {
+			continue
+		}
+		size := int64(f.UncompressedSize64)
+		if f.UncompressedSize64 > uint64(maxArchiveExpandedSize) {
+			size = maxArchiveExpandedSize + 1
+		}
+		err := e.writeMember(name, f.Name, size, depth, func() (io.ReadCloser, error) {
+			return f.Open()
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *archiveExpander) expandTar(name string, tr *tar.Reader, depth int) error {
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			// io.EOF or a corrupt archive. Either way we are done with it.
+			return nil
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		err = e.writeMember(name, hdr.Name, hdr.Size, depth, func() (io.ReadCloser, error) {
+			return io.NopCloser(tr), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeMember writes the archive member member of archive to the zip. size is
+// the size of the member as reported by the archive.
+func (e *archiveExpander) writeMember(archive, member string, size int64, depth int, open func() (io.ReadCloser, error)) error {
+	hdr := &tar.Header{
+		Name: archive + archiveMemberSeparator + strings.TrimPrefix(member, "/"),
+		Size: size,
+	}
+	if e.filter.Ignore(hdr) {
+		return nil
+	}
+
+	// Once we have run out of budget we stop adding members at all, rather
+	// than only adding their names, so that the cache stays bounded.
+	if e.remaining <= 0 {
+		return nil
+	}
+
+	w, err := e.zw.CreateHeader(&zip.FileHeader{
+		Name:   hdr.Name,
+		Method: zip.Store,
+	})
+	if err != nil {
+		return err
+	}
+
+	nested := depth < maxArchiveDepth && e.filter.IsArchive(hdr.Name) && size <= maxArchiveSize
+	if !nested && e.filter.SkipContent(hdr) {
+		return nil
+	}
+	if size > e.remaining {
+		return nil
+	}
+
+	rc, err := open()
+	if err != nil {
+		return nil
+	}
+	defer rc.Close()
+
+	// Archives may lie about the size of their members, so never read more
+	// than they claim.
+	data, err := io.ReadAll(io.LimitReader(rc, size))
+	if err != nil {
+		return nil
+	}
+	e.remaining -= int64(len(data))
+
+	if nested {
+		return e.expand(hdr.Name, data, depth+1)
+	}
+
+	// Heuristic: Assume file is binary if first 256 bytes contain a 0x00. We
+	// only search names of binary files.
+	if bytes.IndexByte(data[:min(len(data), 256)], 0x00) >= 0 {
+		return nil
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,184 @@
+package search
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestCopySearchable_archives(t *testing.T) {
+	jar := zipBytes(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+		"Foo.class":            "\xca\xfe\xba\xbe\x00\x00",
+	})
+	tgz := tgzBytes(t, map[string]string{
+		"pkg/README.md": "hello from a tarball\n",
+		"pkg/lib.jar":   string(jar),
+	})
+	repo := tarBytes(t, map[string]string{
+		"main.go":       "package main\n",
+		"lib/foo.jar":   string(jar),
+		"dist/pkg.tgz":  string(tgz),
+		"dist/data.zip": string(zipBytes(t, map[string]string{"data.txt": "not expanded\n"})),
+	})
+
+	filter := newSearchableFilter(&schema.SiteConfiguration{
+		SearchArchiveExtensions: []string{".jar", ".TGZ"},
+	})
+	filter.CommitIgnore = func(hdr *tar.Header) bool {
+		return false
+	}
+
+	got := copySearchableToMap(t, repo, filter)
+	want := map[string]string{
+		"main.go":                                         "package main\n",
+		"lib/foo.jar":                                     "",
+		"lib/foo.jar!/META-INF/MANIFEST.MF":               "Manifest-Version: 1.0\n",
+		"lib/foo.jar!/Foo.class":                          "",
+		"dist/pkg.tgz":                                    "",
+		"dist/pkg.tgz!/pkg/README.md":                     "hello from a tarball\n",
+		"dist/pkg.tgz!/pkg/lib.jar":                       "",
+		"dist/pkg.tgz!/pkg/lib.jar!/META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+		"dist/pkg.tgz!/pkg/lib.jar!/Foo.class":            "",
+		"dist/data.zip":                                   "",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("unexpected zip contents (-want +got):\n%s", d)
+	}
+}
+
+func TestCopySearchable_archiveDepth(t *testing.T) {
+	// Nest one more archive than we are willing to expand.
+	archive := zipBytes(t, map[string]string{"deepest.txt": "too deep\n"})
+	for i := 0; i < maxArchiveDepth; i++ {
+		archive = zipBytes(t, map[string]string{"nested.zip": string(archive)})
+	}
+	repo := tarBytes(t, map[string]string{"a.zip": string(archive)})
+
+	filter := newSearchableFilter(&schema.SiteConfiguration{
+		SearchArchiveExtensions: []string{".zip"},
+	})
+	filter.CommitIgnore = func(hdr *tar.Header) bool {
+		return false
+	}
+
+	got := copySearchableToMap(t, repo, filter)
+	want := map[string]string{
+		"a.zip":                         "",
+		"a.zip!/nested.zip":             "",
+		"a.zip!/nested.zip!/nested.zip": "",
+		"a.zip!/nested.zip!/nested.zip!/nested.zip": "",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("unexpected zip contents (-want +got):\n%s", d)
+	}
+}
+
+func TestSearchableFilter_HashKeyArchiveExtensions(t *testing.T) {
+	hash := func(c *schema.SiteConfiguration) string {
+		h := sha256.New()
+		newSearchableFilter(c).HashKey(h)
+		return string(h.Sum(nil))
+	}
+	if hash(&schema.SiteConfiguration{}) == hash(&schema.SiteConfiguration{SearchArchiveExtensions: []string{".jar"}}) {
+		t.Fatal("expected search.archiveExtensions to change the hash key")
+	}
+}
+
+func copySearchableToMap(t *testing.T, repo []byte, filter *searchableFilter) map[string]string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := copySearchable(tar.NewReader(bytes.NewReader(repo)), zw, filter); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[f.Name] = string(b)
+	}
+	return got
+}
+
+func zipBytes(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func tarBytes(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0o600,
+			Size:     int64(len(content)),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(tw, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func tgzBytes(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write(tarBytes(t, files)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -45,7 +45,8 @@ func NewFilter(ctx context.Context, client gitserver.Client, repo api.RepoName,
 
 func newSearchableFilter(c *schema.SiteConfiguration) *searchableFilter {
 	return &searchableFilter{
-		SearchLargeFiles: c.SearchLargeFiles,
+		SearchLargeFiles:  c.SearchLargeFiles,
+		ArchiveExtensions: c.SearchArchiveExtensions,
 	}
 }
 
@@ -63,6 +64,11 @@ type searchableFilter struct {
 	// SearchLargeFiles is a list of globs for files were we do not respect
 	// fileSizeMax. It comes from the site configuration search.largeFiles.
 	SearchLargeFiles []string
+
+	// ArchiveExtensions is a list of file extensions of archives whose
+	// members we expand and search. It comes from the site configuration
+	// search.archiveExtensions.
+	ArchiveExtensions []string
 }
 
 // Ignore returns true if the file should not appear at all when searched. IE
@@ -100,6 +106,17 @@ func (f *searchableFilter) SkipContent(hdr *tar.Header) bool {
 	return true
 }
 
+// IsArchive returns true if the file at name is an archive we should expand.
+func (f *searchableFilter) IsArchive(name string) bool {
+	name = strings.ToLower(name)
+	for _, ext := range f.ArchiveExtensions {
+		if ext = strings.ToLower(strings.TrimSpace(ext)); ext != "" && strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
 func checkIsNegatePattern(pattern string) (bool, string) {
 	negate := "!"
 
@@ -121,4 +138,9 @@ func (f *searchableFilter) HashKey(h hash.Hash) {
 		_, _ = h.Write([]byte{0})
 		_, _ = io.WriteString(h, p)
 	}
+	_, _ = io.WriteString(h, "\x00ArchiveExtensions")
+	for _, ext := range f.ArchiveExtensions {
+		_, _ = h.Write([]byte{0})
+		_, _ = io.WriteString(h, ext)
+	}
 }
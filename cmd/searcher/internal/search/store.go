@@ -315,9 +315,13 @@ func (s *Store) fetch(ctx context.Context, repo api.RepoName, commit api.CommitI
 
 // copySearchable copies searchable files from tr to zw. A searchable file is
 // any file that is under size limit, non-binary, and not matching the filter.
+//
+// If the filter has ArchiveExtensions, the members of matching archives are
+// expanded into zw as well. See archiveExpander.
 func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter) error {
 	// 32*1024 is the same size used by io.Copy
 	buf := make([]byte, 32*1024)
+	archives := newArchiveExpander(zw, filter)
 	for {
 		hdr, err := tr.Next()
 		if err == io.EOF {
@@ -350,6 +354,19 @@ func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter) er
 				return err
 			}
 
+			// Archives are binary, so we only search their names and then
+			// search their members.
+			if filter.IsArchive(hdr.Name) && hdr.Size <= maxArchiveSize {
+				data, err := io.ReadAll(tr)
+				if err != nil {
+					return err
+				}
+				if err := archives.Expand(hdr.Name, data); err != nil {
+					return err
+				}
+				continue
+			}
+
 			// We do not search the content of large files unless they are
 			// allowed.
 			if filter.SkipContent(hdr) {
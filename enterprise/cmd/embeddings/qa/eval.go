@@ -25,13 +25,24 @@ type embeddingsSearcher interface {
 	Search(args embeddings.EmbeddingsSearchParameters) (*embeddings.EmbeddingCombinedSearchResults, error)
 }
 
+// Result is the result of an evaluation.
+type Result struct {
+	// Recall is the fraction of queries for which the relevant file was retrieved.
+	Recall float64
+	// ExactRecall is the fraction of the results of exact searches that were also returned by
+	// the default searches, which use approximate indexes if they are enabled. It is 1 if no
+	// approximate indexes are used.
+	ExactRecall float64
+}
+
 // Run runs the evaluation and returns recall for the test data.
-func Run(searcher embeddingsSearcher) (float64, error) {
+func Run(searcher embeddingsSearcher) (*Result, error) {
 	count, recall := 0.0, 0.0
+	exactCount, exactFound := 0.0, 0.0
 
 	file, err := fs.Open("context_data.tsv")
 	if err != nil {
-		return -1, errors.Wrap(err, "failed to open file")
+		return nil, errors.Wrap(err, "failed to open file")
 	}
 
 	scanner := bufio.NewScanner(file)
@@ -54,7 +65,13 @@ func Run(searcher embeddingsSearcher) (float64, error) {
 
 		results, err := searcher.Search(args)
 		if err != nil {
-			return -1, errors.Wrap(err, "search failed")
+			return nil, errors.Wrap(err, "search failed")
+		}
+
+		args.Exact = true
+		exactResults, err := searcher.Search(args)
+		if err != nil {
+			return nil, errors.Wrap(err, "exact search failed")
 		}
 
 		merged := append(results.CodeResults, results.TextResults...)
@@ -62,6 +79,7 @@ func Run(searcher embeddingsSearcher) (float64, error) {
 		fmt.Println("Results:")
 
 		fileFound := false
+		found := make(map[string]struct{}, len(merged))
 		for i, result := range merged {
 			if result.FileName == relevantFile {
 				fmt.Printf(">> ")
@@ -71,20 +89,36 @@ func Run(searcher embeddingsSearcher) (float64, error) {
 			}
 			fmt.Printf("%d. %s", i+1, result.FileName)
 			fmt.Printf(" (%s)\n", result.ScoreDetails.String())
+			found[resultKey(result)] = struct{}{}
 		}
 		fmt.Println()
 		if fileFound {
 			recall++
 		}
 		count++
+
+		for _, result := range append(exactResults.CodeResults, exactResults.TextResults...) {
+			if _, ok := found[resultKey(result)]; ok {
+				exactFound++
+			}
+			exactCount++
+		}
 	}
 
-	recall = recall / count
+	res := &Result{Recall: recall / count, ExactRecall: 1}
+	if exactCount > 0 {
+		res.ExactRecall = exactFound / exactCount
+	}
 
 	fmt.Println()
-	fmt.Printf("Recall: %f\n", recall)
+	fmt.Printf("Recall: %f\n", res.Recall)
+	fmt.Printf("Recall against exact search: %f\n", res.ExactRecall)
+
+	return res, nil
+}
 
-	return recall, nil
+func resultKey(r embeddings.EmbeddingSearchResult) string {
+	return fmt.Sprintf("%s:%d-%d", r.FileName, r.StartLine, r.EndLine)
 }
 
 type client struct {
@@ -69,7 +69,7 @@ func TestRecall(t *testing.T) {
 		)
 	}
 
-	recall, err := qa.Run(embeddingsSearcherFunc(searcher))
+	res, err := qa.Run(embeddingsSearcherFunc(searcher))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -77,8 +77,8 @@ func TestRecall(t *testing.T) {
 	epsilon := 0.0001
 	wantMinRecall := 0.4285
 
-	if d := wantMinRecall - recall; d > epsilon {
-		t.Fatalf("Recall decreased: want %f, got %f", wantMinRecall, recall)
+	if d := wantMinRecall - res.Recall; d > epsilon {
+		t.Fatalf("Recall decreased: want %f, got %f", wantMinRecall, res.Recall)
 	}
 }
 
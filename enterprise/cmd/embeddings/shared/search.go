@@ -7,6 +7,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -46,6 +47,9 @@ func searchRepoEmbeddingIndexes(
 	searchOpts := embeddings.SearchOptions{
 		UseDocumentRanks: params.UseDocumentRanks,
 	}
+	if !params.Exact {
+		searchOpts.Probes = approximateSearchProbes()
+	}
 
 	searchRepo := func(repoID api.RepoID, repoName api.RepoName) (codeResults, textResults []embeddings.EmbeddingSearchResult, err error) {
 		tr, ctx := trace.New(ctx, "searchRepo",
@@ -85,3 +89,13 @@ func searchRepoEmbeddingIndexes(
 
 	return &result, nil
 }
+
+// approximateSearchProbes returns the number of lists of approximate indexes to search, or 0 if
+// approximate indexes are disabled and all embeddings should be searched.
+func approximateSearchProbes() int {
+	c := conf.GetEmbeddingsConfig(conf.Get().SiteConfig())
+	if c == nil || !c.ApproximateIndex.Enabled {
+		return 0
+	}
+	return c.ApproximateIndex.Probes
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/githuboauth"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/gitlaboauth"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/httpheader"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/ldap"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/openidconnect"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/saml"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/sourcegraphoperator"
@@ -39,6 +40,7 @@ func Init(logger log.Logger, db database.DB) {
 	githuboauth.Init(logger, db)
 	gitlaboauth.Init(logger, db)
 	httpheader.Init()
+	ldap.Init()
 	openidconnect.Init()
 	saml.Init()
 	sourcegraphoperator.Init()
@@ -49,6 +51,7 @@ func Init(logger log.Logger, db database.DB) {
 		sourcegraphoperator.Middleware(db),
 		saml.Middleware(db),
 		httpheader.Middleware(db),
+		ldap.Middleware(db),
 		githuboauth.Middleware(db),
 		gitlaboauth.Middleware(db),
 		bitbucketcloudoauth.Middleware(db),
@@ -90,6 +93,8 @@ func Init(logger log.Logger, db database.DB) {
 				name = "Azure DevOps"
 			case p.HttpHeader != nil:
 				name = "HTTP header"
+			case p.Ldap != nil:
+				name = "LDAP"
 			case p.Openidconnect != nil:
 				name = "OpenID Connect"
 			case p.Saml != nil:
@@ -0,0 +1,154 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const pkgName = "ldap"
+
+func Init() {
+	conf.ContributeValidator(func(cfg conftypes.SiteConfigQuerier) conf.Problems {
+		_, problems := parseConfig(cfg.SiteConfig().AuthProviders)
+		return problems
+	})
+
+	logger := log.Scoped(pkgName, "LDAP authentication config watch")
+	go conf.Watch(func() {
+		ps, _ := parseConfig(conf.Get().AuthProviders)
+		if len(ps) == 0 {
+			providers.Update(pkgName, nil)
+			return
+		}
+
+		if err := licensing.Check(licensing.FeatureSSO); err != nil {
+			logger.Error("Check license for SSO (LDAP)", log.Error(err))
+			providers.Update(pkgName, nil)
+			return
+		}
+
+		newProviders := make([]providers.Provider, len(ps))
+		for i := range ps {
+			newProviders[i] = ps[i]
+		}
+		providers.Update(pkgName, newProviders)
+	})
+}
+
+// parseConfig returns the providers of the valid LDAP auth provider configs, and the problems of
+// the invalid ones.
+func parseConfig(authProviders []schema.AuthProviders) (ps []*Provider, problems conf.Problems) {
+	urls := make(collections.Set[string])
+	for _, pr := range authProviders {
+		if pr.Ldap == nil {
+			continue
+		}
+
+		p, err := newProvider(pr.Ldap)
+		if err != nil {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider %q: %s", pr.Ldap.Url, err)))
+			continue
+		}
+		if urls.Has(pr.Ldap.Url) {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("Cannot have more than one LDAP auth provider with url %q", pr.Ldap.Url)))
+			continue
+		}
+
+		ps = append(ps, p)
+		urls.Add(pr.Ldap.Url)
+	}
+	return ps, problems
+}
+
+func newProvider(c *schema.LDAPAuthProvider) (*Provider, error) {
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid URL")
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return nil, errors.Newf("unsupported URL scheme %q, must be ldap or ldaps", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("URL has no host")
+	}
+	if c.StartTLS && u.Scheme == "ldaps" {
+		return nil, errors.New("startTLS cannot be used with ldaps URLs, which already use TLS")
+	}
+	// 🚨 SECURITY: A bind with an empty password is an unauthenticated bind, which would
+	// silently make searches anonymous.
+	if c.BindDN != "" && c.BindPassword == "" {
+		return nil, errors.New("bindPassword must be set when bindDN is set")
+	}
+	if _, err := ldap.CompileFilter(userFilter(c)); err != nil {
+		return nil, errors.Wrap(err, "invalid userFilter")
+	}
+	if c.GroupSync != nil {
+		if _, err := ldap.CompileFilter(groupFilter(c.GroupSync)); err != nil {
+			return nil, errors.Wrap(err, "invalid groupSync.groupFilter")
+		}
+	}
+
+	tlsConfig := &tls.Config{
+		// StartTLS does not infer the server name from the URL like dialing ldaps URLs does.
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: c.TlsInsecureSkipVerify,
+	}
+	if c.TlsCACertificate != "" {
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM([]byte(c.TlsCACertificate)) {
+			return nil, errors.New("invalid tlsCACertificate")
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	return &Provider{config: *c, tlsConfig: tlsConfig}, nil
+}
+
+// The defaults of the optional properties of the site configuration.
+
+func userFilter(c *schema.LDAPAuthProvider) string {
+	return valueOr(c.UserFilter, "(objectClass=person)")
+}
+
+func attributes(c *schema.LDAPAuthProvider) schema.LDAPAttributes {
+	var a schema.LDAPAttributes
+	if c.Attributes != nil {
+		a = *c.Attributes
+	}
+	a.Username = valueOr(a.Username, "uid")
+	a.Email = valueOr(a.Email, "mail")
+	a.DisplayName = valueOr(a.DisplayName, "cn")
+	return a
+}
+
+func groupFilter(c *schema.LDAPGroupSync) string {
+	return valueOr(c.GroupFilter, "(|(objectClass=groupOfNames)(objectClass=group))")
+}
+
+func memberAttribute(c *schema.LDAPGroupSync) string {
+	return valueOr(c.MemberAttribute, "member")
+}
+
+func nameAttribute(c *schema.LDAPGroupSync) string {
+	return valueOr(c.NameAttribute, "cn")
+}
+
+func valueOr(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
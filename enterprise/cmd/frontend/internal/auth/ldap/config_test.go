@@ -0,0 +1,74 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestParseConfig(t *testing.T) {
+	valid := func(url string) schema.AuthProviders {
+		return schema.AuthProviders{Ldap: &schema.LDAPAuthProvider{
+			Type:           providerType,
+			Url:            url,
+			UserSearchBase: "dc=example,dc=com",
+		}}
+	}
+
+	testCases := map[string]struct {
+		authProviders []schema.AuthProviders
+		wantURLs      []string
+		wantProblems  []string
+	}{
+		"no configs": {},
+		"valid configs": {
+			authProviders: []schema.AuthProviders{valid("ldap://a.example.com"), valid("ldaps://b.example.com:6360"), {Builtin: &schema.BuiltinAuthProvider{}}},
+			wantURLs:      []string{"ldap://a.example.com", "ldaps://b.example.com:6360"},
+		},
+		"duplicate URL": {
+			authProviders: []schema.AuthProviders{valid("ldap://a.example.com"), valid("ldap://a.example.com")},
+			wantURLs:      []string{"ldap://a.example.com"},
+			wantProblems:  []string{`Cannot have more than one LDAP auth provider with url "ldap://a.example.com"`},
+		},
+		"invalid configs": {
+			authProviders: []schema.AuthProviders{
+				valid("http://a.example.com"),
+				valid("ldap://"),
+				{Ldap: &schema.LDAPAuthProvider{Url: "ldaps://b.example.com", StartTLS: true}},
+				{Ldap: &schema.LDAPAuthProvider{Url: "ldap://c.example.com", BindDN: "cn=admin"}},
+				{Ldap: &schema.LDAPAuthProvider{Url: "ldap://d.example.com", UserFilter: "objectClass=person"}},
+				{Ldap: &schema.LDAPAuthProvider{Url: "ldap://e.example.com", GroupSync: &schema.LDAPGroupSync{GroupFilter: "(cn=a"}}},
+				{Ldap: &schema.LDAPAuthProvider{Url: "ldap://f.example.com", TlsCACertificate: "-----BEGIN CERTIFICATE-----\nnope"}},
+			},
+			wantProblems: []string{
+				`LDAP auth provider "http://a.example.com": unsupported URL scheme "http", must be ldap or ldaps`,
+				`LDAP auth provider "ldap://": URL has no host`,
+				`LDAP auth provider "ldaps://b.example.com": startTLS cannot be used with ldaps URLs, which already use TLS`,
+				`LDAP auth provider "ldap://c.example.com": bindPassword must be set when bindDN is set`,
+				`LDAP auth provider "ldap://d.example.com": invalid userFilter: `,
+				`LDAP auth provider "ldap://e.example.com": invalid groupSync.groupFilter: `,
+				`LDAP auth provider "ldap://f.example.com": invalid tlsCACertificate`,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ps, problems := parseConfig(tc.authProviders)
+
+			var urls []string
+			for _, p := range ps {
+				urls = append(urls, p.ConfigID().ID)
+			}
+			assert.Equal(t, tc.wantURLs, urls)
+
+			messages := problems.Messages()
+			if assert.Len(t, messages, len(tc.wantProblems)) {
+				for i, want := range tc.wantProblems {
+					assert.Contains(t, messages[i], want)
+				}
+			}
+		})
+	}
+}
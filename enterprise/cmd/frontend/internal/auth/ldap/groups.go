@@ -0,0 +1,96 @@
+package ldap
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// syncGroups adds the user to the organizations and teams mapped to the LDAP groups they are a
+// member of, and removes them from the ones mapped to groups they are not a member of.
+// Organizations and teams that are not mapped to any group are left untouched, so that
+// memberships managed in Sourcegraph are preserved.
+func syncGroups(ctx context.Context, logger log.Logger, db database.DB, userID int32, c *schema.LDAPGroupSync, groups []string) error {
+	isMember := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		// Group names are compared case-insensitively, like LDAP attribute values.
+		isMember[strings.ToLower(g)] = true
+	}
+
+	var errs error
+	for org, member := range memberships(c.Orgs, isMember) {
+		if err := syncOrgMembership(ctx, db, userID, org, member); err != nil {
+			if errcode.IsNotFound(err) {
+				logger.Warn("organization mapped to LDAP groups does not exist", log.String("org", org))
+				continue
+			}
+			errs = errors.Append(errs, errors.Wrapf(err, "syncing membership of organization %q", org))
+		}
+	}
+	for team, member := range memberships(c.Teams, isMember) {
+		if err := syncTeamMembership(ctx, db, userID, team, member); err != nil {
+			if errcode.IsNotFound(err) {
+				logger.Warn("team mapped to LDAP groups does not exist", log.String("team", team))
+				continue
+			}
+			errs = errors.Append(errs, errors.Wrapf(err, "syncing membership of team %q", team))
+		}
+	}
+	return errs
+}
+
+// memberships maps the name of every organization or team of the mapping to whether the user
+// is a member of one of the groups mapped to it.
+func memberships(mapping map[string]string, isMember map[string]bool) map[string]bool {
+	m := make(map[string]bool, len(mapping))
+	for group, name := range mapping {
+		m[name] = m[name] || isMember[strings.ToLower(group)]
+	}
+	return m
+}
+
+func syncOrgMembership(ctx context.Context, db database.DB, userID int32, name string, member bool) error {
+	org, err := db.Orgs().GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.OrgMembers().GetByOrgIDAndUserID(ctx, org.ID, userID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return err
+	}
+	switch isMember := err == nil; {
+	case member && !isMember:
+		_, err = db.OrgMembers().Create(ctx, org.ID, userID)
+		return err
+	case !member && isMember:
+		return db.OrgMembers().Remove(ctx, org.ID, userID)
+	}
+	return nil
+}
+
+func syncTeamMembership(ctx context.Context, db database.DB, userID int32, name string, member bool) error {
+	team, err := db.Teams().GetTeamByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	isMember, err := db.Teams().IsTeamMember(ctx, team.ID, userID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case member && !isMember:
+		return db.Teams().CreateTeamMember(ctx, &types.TeamMember{TeamID: team.ID, UserID: userID})
+	case !member && isMember:
+		return db.Teams().DeleteTeamMember(ctx, &types.TeamMember{TeamID: team.ID, UserID: userID})
+	}
+	return nil
+}
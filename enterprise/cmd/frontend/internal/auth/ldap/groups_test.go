@@ -0,0 +1,78 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSyncGroups(t *testing.T) {
+	const userID = 42
+	orgIDs := map[string]int32{"eng": 1, "sales": 2, "ops": 3}
+	teamIDs := map[string]int32{"frontend": 10, "backend": 11}
+
+	orgs := database.NewMockOrgStore()
+	orgs.GetByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Org, error) {
+		if id, ok := orgIDs[name]; ok {
+			return &types.Org{ID: id, Name: name}, nil
+		}
+		return nil, &database.OrgNotFoundError{Message: name}
+	})
+	orgMembers := database.NewMockOrgMemberStore()
+	orgMembers.GetByOrgIDAndUserIDFunc.SetDefaultHook(func(_ context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+		// The user is initially a member of sales and ops.
+		if orgID == 2 || orgID == 3 {
+			return &types.OrgMembership{OrgID: orgID, UserID: userID}, nil
+		}
+		return nil, &database.ErrOrgMemberNotFound{}
+	})
+	teams := database.NewMockTeamStore()
+	teams.GetTeamByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Team, error) {
+		if id, ok := teamIDs[name]; ok {
+			return &types.Team{ID: id, Name: name}, nil
+		}
+		return nil, database.TeamNotFoundError{}
+	})
+	teams.IsTeamMemberFunc.SetDefaultHook(func(_ context.Context, teamID, _ int32) (bool, error) {
+		// The user is initially a member of backend.
+		return teamID == 11, nil
+	})
+
+	db := database.NewMockDB()
+	db.OrgsFunc.SetDefaultReturn(orgs)
+	db.OrgMembersFunc.SetDefaultReturn(orgMembers)
+	db.TeamsFunc.SetDefaultReturn(teams)
+
+	err := syncGroups(context.Background(), logtest.Scoped(t), db, userID, &schema.LDAPGroupSync{
+		Orgs: map[string]string{
+			"Engineering":  "eng",
+			"sales-people": "sales",
+			"operations":   "ops",
+			"on-call":      "ops",
+			"unknown":      "missing-org",
+		},
+		Teams: map[string]string{
+			"frontend-devs": "frontend",
+			"backend-devs":  "backend",
+		},
+	}, []string{"engineering", "on-call", "frontend-devs", "not-mapped"})
+	require.NoError(t, err)
+
+	// The user is added to eng, stays in ops thanks to on-call, and is removed from sales.
+	require.Len(t, orgMembers.CreateFunc.History(), 1)
+	assert.Equal(t, int32(1), orgMembers.CreateFunc.History()[0].Arg1)
+	require.Len(t, orgMembers.RemoveFunc.History(), 1)
+	assert.Equal(t, int32(2), orgMembers.RemoveFunc.History()[0].Arg1)
+
+	require.Len(t, teams.CreateTeamMemberFunc.History(), 1)
+	assert.Equal(t, []*types.TeamMember{{TeamID: 10, UserID: userID}}, teams.CreateTeamMemberFunc.History()[0].Arg1)
+	require.Len(t, teams.DeleteTeamMemberFunc.History(), 1)
+	assert.Equal(t, []*types.TeamMember{{TeamID: 11, UserID: userID}}, teams.DeleteTeamMemberFunc.History()[0].Arg1)
+}
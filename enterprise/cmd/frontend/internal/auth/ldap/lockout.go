@@ -0,0 +1,66 @@
+package ldap
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// lockoutStore locks out usernames after consecutive failed sign-in attempts.
+//
+// Unlike the account lockout of the builtin auth provider, attempts are counted per username
+// instead of per user account, because users may not have a Sourcegraph account before their
+// first sign-in.
+type lockoutStore interface {
+	// IsLockedOut returns true if the given username has been locked out.
+	IsLockedOut(key string) bool
+	// IncreaseFailedAttempt increases the failed sign-in attempt count by 1.
+	IncreaseFailedAttempt(key string)
+	// Reset clears the failed sign-in attempt count and releases the lockout.
+	Reset(key string)
+}
+
+// lockoutKey returns the key of the given username for the provider in a lockoutStore.
+func lockoutKey(p *Provider, username string) string {
+	// Most LDAP servers match usernames case-insensitively.
+	return p.config.Url + ":" + strings.ToLower(strings.TrimSpace(username))
+}
+
+type redisLockoutStore struct {
+	failedThreshold int
+	lockouts        *rcache.Cache
+	failedAttempts  *rcache.Cache
+}
+
+// newLockoutStore returns a lockoutStore using the Redis cache, with the same options as the
+// account lockout of the builtin auth provider.
+func newLockoutStore(lockoutOptions *schema.AuthLockout) lockoutStore {
+	return &redisLockoutStore{
+		failedThreshold: lockoutOptions.FailedAttemptThreshold,
+		lockouts:        rcache.NewWithTTL("ldap_lockout", lockoutOptions.LockoutPeriod),
+		failedAttempts:  rcache.NewWithTTL("ldap_failed_attempts", lockoutOptions.ConsecutivePeriod),
+	}
+}
+
+func (s *redisLockoutStore) IsLockedOut(key string) bool {
+	_, locked := s.lockouts.Get(key)
+	return locked
+}
+
+func (s *redisLockoutStore) IncreaseFailedAttempt(key string) {
+	s.failedAttempts.Increase(key)
+
+	// Get right after Increase should make the key always exist
+	v, _ := s.failedAttempts.Get(key)
+	count, _ := strconv.Atoi(string(v))
+	if count >= s.failedThreshold {
+		s.lockouts.Set(key, []byte("too many failed attempts"))
+	}
+}
+
+func (s *redisLockoutStore) Reset(key string) {
+	s.lockouts.Delete(key)
+	s.failedAttempts.Delete(key)
+}
@@ -0,0 +1,42 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestLockoutStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	rcache.SetupForTest(t)
+
+	s := newLockoutStore(&schema.AuthLockout{FailedAttemptThreshold: 2, LockoutPeriod: 60, ConsecutivePeriod: 60})
+
+	s.IncreaseFailedAttempt("alice")
+	assert.False(t, s.IsLockedOut("alice"))
+
+	// Should be locked out after two failed attempts
+	s.IncreaseFailedAttempt("alice")
+	assert.True(t, s.IsLockedOut("alice"))
+	assert.False(t, s.IsLockedOut("bob"))
+
+	// Should be unlocked after reset, and the count of failed attempts starts over
+	s.Reset("alice")
+	assert.False(t, s.IsLockedOut("alice"))
+	s.IncreaseFailedAttempt("alice")
+	assert.False(t, s.IsLockedOut("alice"))
+}
+
+func TestLockoutKey(t *testing.T) {
+	p := &Provider{config: schema.LDAPAuthProvider{Url: "ldap://ldap.example.com"}}
+	assert.Equal(t, lockoutKey(p, "alice"), lockoutKey(p, " Alice "))
+	assert.NotEqual(t, lockoutKey(p, "alice"), lockoutKey(p, "bob"))
+
+	other := &Provider{config: schema.LDAPAuthProvider{Url: "ldap://other.example.com"}}
+	assert.NotEqual(t, lockoutKey(p, "alice"), lockoutKey(other, "alice"))
+}
@@ -0,0 +1,200 @@
+// Package ldap implements auth via LDAP, by checking the username and password of users against
+// their entry in an LDAP directory.
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// All LDAP endpoints are under this path prefix.
+const authPrefix = auth.AuthURLPrefix + "/ldap"
+
+// Middleware is middleware for LDAP authentication, adding the sign-in endpoint under the auth
+// path prefix ("/.auth").
+//
+// Unlike SSO providers, there is no redirect to the LDAP server: the sign-in form of the web app
+// POSTs the username and password to the AuthenticationURL of the provider, and the session is
+// created if the LDAP server accepts a bind with them. Usernames are locked out after
+// consecutive failed attempts, with the current "auth.lockout" options of the builtin auth
+// provider.
+//
+// 🚨 SECURITY
+func Middleware(db database.DB) *auth.Middleware {
+	return &auth.Middleware{
+		API: func(next http.Handler) http.Handler {
+			return next
+		},
+		App: func(next http.Handler) http.Handler {
+			h := signInHandler(db)
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == authPrefix+"/login" {
+					h(w, r)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		},
+	}
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// signInHandler accepts a POST containing username-password credentials and authenticates the
+// current session if the LDAP server accepts them.
+//
+// 🚨 SECURITY
+func signInHandler(db database.DB) http.HandlerFunc {
+	logger := log.Scoped("ldap.signIn", "LDAP sign-in request handler")
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("Unsupported method %s", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		// 🚨 SECURITY: Only trusted origins may send this header (see corsAllowHeader), which
+		// protects the endpoint against login CSRF.
+		if r.Header.Get("X-Requested-With") == "" {
+			http.Error(w, "Missing X-Requested-With header", http.StatusForbidden)
+			return
+		}
+
+		p := getProvider(r.URL.Query().Get("pc"))
+		if p == nil {
+			http.Error(w, "Misconfigured authentication provider.", http.StatusNotFound)
+			return
+		}
+
+		var creds credentials
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		// The store is cheap to create, and reading the options on every request applies changes
+		// to the site configuration right away.
+		lockout := newLockoutStore(conf.AuthLockout())
+		userID, safeErrMsg, status, err := signIn(ctx, logger, db, lockout, p, creds)
+		if err != nil {
+			if status == http.StatusInternalServerError {
+				logger.Error("failed to authenticate with LDAP", log.String("provider", p.config.Url), log.Error(err))
+			} else {
+				logger.Warn("failed to authenticate with LDAP", log.String("provider", p.config.Url), log.Error(err))
+			}
+			logSecurityEvent(ctx, db, r, database.SecurityEventNameSignInFailed, 0, creds.Username)
+			http.Error(w, safeErrMsg, status)
+			return
+		}
+
+		usr, err := db.Users().GetByID(ctx, userID)
+		if err != nil {
+			logger.Error("failed to get user", log.Int32("userID", userID), log.Error(err))
+			http.Error(w, "Unexpected error getting the Sourcegraph user account. Ask a site admin for help.", http.StatusInternalServerError)
+			return
+		}
+		if err := session.SetActor(w, r, actor.FromUser(userID), 0, usr.CreatedAt); err != nil {
+			logger.Error("could not create new user session", log.Error(err))
+			http.Error(w, "Authentication failed. Try signing in again (and clearing cookies for the current site). The error was: could not initiate session.", http.StatusInternalServerError)
+			return
+		}
+		logSecurityEvent(ctx, db, r, database.SecurityEventNameSignInSucceeded, userID, creds.Username)
+	}
+}
+
+// signIn authenticates the user with the LDAP server, and returns the ID of their Sourcegraph
+// user account, which is created if it does not exist yet.
+func signIn(ctx context.Context, logger log.Logger, db database.DB, lockout lockoutStore, p *Provider, creds credentials) (userID int32, safeErrMsg string, status int, err error) {
+	// 🚨 SECURITY: Do not bind as locked out usernames at all, so that their passwords cannot be
+	// guessed by brute force.
+	key := lockoutKey(p, creds.Username)
+	if lockout.IsLockedOut(key) {
+		return 0, "Account has been locked out due to too many failed sign-in attempts. Try again later.", http.StatusUnprocessableEntity, errors.Newf("username %q is locked out", creds.Username)
+	}
+
+	u, err := p.authenticate(creds.Username, creds.Password)
+	if err != nil {
+		if errors.Is(err, errInvalidCredentials) {
+			lockout.IncreaseFailedAttempt(key)
+			return 0, "Authentication failed", http.StatusUnauthorized, err
+		}
+		return 0, "Unexpected error authenticating with the LDAP server. Ask a site admin for help.", http.StatusInternalServerError, err
+	}
+	lockout.Reset(key)
+
+	username, err := auth.NormalizeUsername(u.Username)
+	if err != nil {
+		return 0, fmt.Sprintf("Unable to normalize LDAP username %q.", u.Username), http.StatusInternalServerError, err
+	}
+	data, err := u.accountData()
+	if err != nil {
+		return 0, "Unexpected error authenticating with the LDAP server. Ask a site admin for help.", http.StatusInternalServerError, err
+	}
+	allowSignup := p.config.AllowSignup == nil || *p.config.AllowSignup
+	userID, safeErrMsg, err = auth.GetAndSaveUser(ctx, db, auth.GetAndSaveUserOp{
+		UserProps: database.NewUser{
+			Username:    username,
+			Email:       u.Email,
+			DisplayName: u.DisplayName,
+			// 🚨 SECURITY: Users may be able to change the email of their own entry, and verified
+			// emails link users to existing accounts, so emails from the directory are only
+			// verified if the site admin trusts them.
+			EmailIsVerified: u.Email != "" && p.config.TrustEmailAttribute,
+		},
+		// 🚨 SECURITY: Existing users are only looked up by their LDAP external account or their
+		// verified email, never by username: the username of an LDAP entry says nothing about
+		// which Sourcegraph user it belongs to.
+		ExternalAccount: extsvc.AccountSpec{
+			ServiceType: providerType,
+			ServiceID:   p.config.Url,
+			// Store the username as returned by the LDAP server, not the normalized username, to
+			// prevent two users with distinct pre-normalization usernames from being merged.
+			AccountID: u.Username,
+		},
+		ExternalAccountData: data,
+		CreateIfNotExist:    allowSignup,
+	})
+	if err != nil {
+		return 0, safeErrMsg, http.StatusInternalServerError, err
+	}
+
+	if p.config.GroupSync != nil {
+		// Failing to sync groups must not prevent users from signing in.
+		if err := syncGroups(ctx, logger, db, userID, p.config.GroupSync, u.Groups); err != nil {
+			logger.Error("failed to sync LDAP groups", log.Int32("userID", userID), log.Error(err))
+		}
+	}
+	return userID, "", http.StatusOK, nil
+}
+
+func logSecurityEvent(ctx context.Context, db database.DB, r *http.Request, name database.SecurityEventName, userID int32, username string) {
+	event := &database.SecurityEvent{
+		Name:      name,
+		URL:       r.URL.Path,
+		UserID:    uint32(userID),
+		Source:    "BACKEND",
+		Timestamp: time.Now(),
+	}
+	if userID == 0 {
+		// We don't have a reliable user identifier for failed attempts.
+		event.AnonymousUserID = fmt.Sprintf("unknown LDAP @ %s", time.Now())
+	}
+	event.Argument, _ = json.Marshal(map[string]string{"username": strings.TrimSpace(username)})
+	db.SecurityEventLogs().LogEvent(ctx, event)
+}
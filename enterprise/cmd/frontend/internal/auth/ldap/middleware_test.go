@@ -0,0 +1,169 @@
+package ldap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/ldap/ldaptest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSignIn(t *testing.T) {
+	server := ldaptest.NewServer(t, testEntries...)
+	allowSignup := false
+	p := newTestProvider(t, schema.LDAPAuthProvider{
+		Url:                 server.URL,
+		UserSearchBase:      "ou=people,dc=example,dc=com",
+		AllowSignup:         &allowSignup,
+		TrustEmailAttribute: true,
+		GroupSync: &schema.LDAPGroupSync{
+			GroupSearchBase: "ou=groups,dc=example,dc=com",
+			Teams:           map[string]string{"admins": "admins"},
+		},
+	})
+
+	var gotOp auth.GetAndSaveUserOp
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (int32, string, error) {
+		gotOp = op
+		return 42, "", nil
+	}
+	t.Cleanup(func() { auth.MockGetAndSaveUser = nil })
+
+	teams := database.NewMockTeamStore()
+	teams.GetTeamByNameFunc.SetDefaultReturn(&types.Team{ID: 7, Name: "admins"}, nil)
+	db := database.NewMockDB()
+	db.TeamsFunc.SetDefaultReturn(teams)
+
+	ctx := context.Background()
+	lockout := &fakeLockoutStore{failedThreshold: 2}
+	userID, _, status, err := signIn(ctx, logtest.Scoped(t), db, lockout, p, credentials{Username: "alice", Password: "hunter2"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int32(42), userID)
+
+	assert.Equal(t, database.NewUser{
+		Username:        "alice",
+		Email:           "alice@example.com",
+		DisplayName:     "Alice Liddell",
+		EmailIsVerified: true,
+	}, gotOp.UserProps)
+	assert.Equal(t, extsvc.AccountSpec{
+		ServiceType: "ldap",
+		ServiceID:   server.URL,
+		AccountID:   "alice",
+	}, gotOp.ExternalAccount)
+	assert.False(t, gotOp.CreateIfNotExist)
+	assert.False(t, gotOp.LookUpByUsername)
+
+	require.Len(t, teams.CreateTeamMemberFunc.History(), 1)
+	assert.Equal(t, []*types.TeamMember{{TeamID: 7, UserID: 42}}, teams.CreateTeamMemberFunc.History()[0].Arg1)
+
+	t.Run("untrusted email attribute", func(t *testing.T) {
+		p := newTestProvider(t, schema.LDAPAuthProvider{
+			Url:            server.URL,
+			UserSearchBase: "ou=people,dc=example,dc=com",
+		})
+		_, _, status, err := signIn(ctx, logtest.Scoped(t), db, &fakeLockoutStore{failedThreshold: 2}, p, credentials{Username: "alice", Password: "hunter2"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "alice@example.com", gotOp.UserProps.Email)
+		assert.False(t, gotOp.UserProps.EmailIsVerified)
+	})
+
+	t.Run("entry without email", func(t *testing.T) {
+		_, _, status, err := signIn(ctx, logtest.Scoped(t), db, lockout, p, credentials{Username: "bob", Password: "bob-secret"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		// Users must never be looked up by the username of their LDAP entry.
+		assert.Equal(t, "", gotOp.UserProps.Email)
+		assert.False(t, gotOp.LookUpByUsername)
+	})
+
+	t.Run("lockout", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, _, status, err = signIn(ctx, logtest.Scoped(t), db, lockout, p, credentials{Username: "alice", Password: "wrong"})
+			assert.ErrorIs(t, err, errInvalidCredentials)
+			assert.Equal(t, http.StatusUnauthorized, status)
+		}
+
+		binds := len(server.Binds())
+		_, _, status, err = signIn(ctx, logtest.Scoped(t), db, lockout, p, credentials{Username: "Alice", Password: "hunter2"})
+		assert.ErrorContains(t, err, "locked out")
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Len(t, server.Binds(), binds, "locked out usernames must not be bound as")
+
+		lockout.Reset(lockoutKey(p, "alice"))
+		_, _, status, err = signIn(ctx, logtest.Scoped(t), db, lockout, p, credentials{Username: "alice", Password: "hunter2"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Zero(t, lockout.failedAttempts[lockoutKey(p, "alice")])
+	})
+}
+
+type fakeLockoutStore struct {
+	failedThreshold int
+	failedAttempts  map[string]int
+}
+
+func (s *fakeLockoutStore) IsLockedOut(key string) bool {
+	return s.failedAttempts[key] >= s.failedThreshold
+}
+
+func (s *fakeLockoutStore) IncreaseFailedAttempt(key string) {
+	if s.failedAttempts == nil {
+		s.failedAttempts = make(map[string]int)
+	}
+	s.failedAttempts[key]++
+}
+
+func (s *fakeLockoutStore) Reset(key string) {
+	delete(s.failedAttempts, key)
+}
+
+func TestSignInHandlerRejectsRequests(t *testing.T) {
+	h := Middleware(database.NewMockDB()).App(http.NotFoundHandler())
+
+	for name, tc := range map[string]struct {
+		req      *http.Request
+		wantCode int
+	}{
+		"GET": {
+			req:      httptest.NewRequest(http.MethodGet, authPrefix+"/login", nil),
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		"missing X-Requested-With": {
+			req:      httptest.NewRequest(http.MethodPost, authPrefix+"/login", strings.NewReader(`{}`)),
+			wantCode: http.StatusForbidden,
+		},
+		"unknown provider": {
+			req:      withRequestedWith(httptest.NewRequest(http.MethodPost, authPrefix+"/login?pc=ldap://unknown", strings.NewReader(`{}`))),
+			wantCode: http.StatusNotFound,
+		},
+		"other paths are passed to the next handler": {
+			req:      httptest.NewRequest(http.MethodPost, "/other", nil),
+			wantCode: http.StatusNotFound,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, tc.req)
+			assert.Equal(t, tc.wantCode, rec.Code)
+		})
+	}
+}
+
+func withRequestedWith(r *http.Request) *http.Request {
+	r.Header.Set("X-Requested-With", "Sourcegraph")
+	return r
+}
@@ -0,0 +1,228 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const providerType = "ldap"
+
+// timeout is the timeout of connections to the LDAP server, and of each request on them.
+const timeout = 10 * time.Second
+
+// Provider is an LDAP auth provider, identified by the URL of its server.
+type Provider struct {
+	config    schema.LDAPAuthProvider
+	tlsConfig *tls.Config
+}
+
+var _ providers.Provider = (*Provider)(nil)
+
+// ConfigID implements providers.Provider.
+func (p *Provider) ConfigID() providers.ConfigID {
+	return providers.ConfigID{
+		Type: providerType,
+		ID:   p.config.Url,
+	}
+}
+
+// Config implements providers.Provider.
+func (p *Provider) Config() schema.AuthProviders {
+	return schema.AuthProviders{Ldap: &p.config}
+}
+
+// CachedInfo implements providers.Provider.
+func (p *Provider) CachedInfo() *providers.Info {
+	displayName := p.config.DisplayName
+	if displayName == "" {
+		displayName = "LDAP"
+	}
+	return &providers.Info{
+		ServiceID:         p.config.Url,
+		DisplayName:       displayName,
+		AuthenticationURL: authPrefix + "/login?pc=" + url.QueryEscape(p.config.Url),
+	}
+}
+
+// Refresh implements providers.Provider.
+func (p *Provider) Refresh(context.Context) error { return nil }
+
+// ExternalAccountInfo implements providers.Provider.
+func (p *Provider) ExternalAccountInfo(ctx context.Context, account extsvc.Account) (*extsvc.PublicAccountData, error) {
+	data := &extsvc.PublicAccountData{Login: &account.AccountID}
+	if account.Data == nil {
+		return data, nil
+	}
+	u, err := encryption.DecryptJSON[user](ctx, account.Data)
+	if err != nil {
+		return nil, err
+	}
+	if u.DisplayName != "" {
+		data.DisplayName = &u.DisplayName
+	}
+	return data, nil
+}
+
+// user is the entry of a user authenticated by the LDAP server. It is stored as the data of
+// their external account.
+type user struct {
+	DN          string `json:"dn"`
+	Username    string `json:"username"`
+	Email       string `json:"email,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Groups are the names of the groups the user is a member of, only set with group sync.
+	Groups []string `json:"-"`
+}
+
+func (u *user) accountData() (extsvc.AccountData, error) {
+	serialized, err := json.Marshal(u)
+	if err != nil {
+		return extsvc.AccountData{}, err
+	}
+	return extsvc.AccountData{Data: extsvc.NewUnencryptedData(serialized)}, nil
+}
+
+var errInvalidCredentials = errors.New("invalid username or password")
+
+// authenticate checks the password of the user with the given username, and returns their entry.
+// It returns errInvalidCredentials if there is no such user or the password is wrong.
+//
+// 🚨 SECURITY
+func (p *Provider) authenticate(username, password string) (*user, error) {
+	// 🚨 SECURITY: A bind with an empty password is an unauthenticated bind that succeeds on most
+	// servers.
+	if username == "" || password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	conn, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := p.bindServiceAccount(conn); err != nil {
+		return nil, err
+	}
+
+	attrs := attributes(&p.config)
+	// 🚨 SECURITY: The username must be escaped to prevent injections in the filter.
+	res, err := conn.Search(ldap.NewSearchRequest(
+		p.config.UserSearchBase, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		"(&"+userFilter(&p.config)+"("+attrs.Username+"="+ldap.EscapeFilter(username)+"))",
+		[]string{attrs.Username, attrs.Email, attrs.DisplayName},
+		nil,
+	))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) || res != nil && len(res.Entries) > 1 {
+		// 🚨 SECURITY: Never pick one of several entries, which could belong to another user.
+		return nil, errors.Newf("multiple LDAP entries match username %q", username)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for user")
+	}
+	if len(res.Entries) == 0 {
+		return nil, errInvalidCredentials
+	}
+	entry := res.Entries[0]
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, errInvalidCredentials
+		}
+		return nil, err
+	}
+
+	u := &user{
+		DN:          entry.DN,
+		Username:    entry.GetEqualFoldAttributeValue(attrs.Username),
+		Email:       entry.GetEqualFoldAttributeValue(attrs.Email),
+		DisplayName: entry.GetEqualFoldAttributeValue(attrs.DisplayName),
+	}
+	if u.Username == "" {
+		u.Username = username
+	}
+
+	if p.config.GroupSync != nil {
+		// Search groups with the privileges of the service account, not the user's.
+		if err := p.bindServiceAccount(conn); err != nil {
+			return nil, err
+		}
+		if u.Groups, err = p.groups(conn, entry.DN); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+func (p *Provider) connect() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(p.config.Url,
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(p.tlsConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(timeout)
+	if p.config.StartTLS {
+		if err := conn.StartTLS(p.tlsConfig); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "StartTLS handshake")
+		}
+	}
+	return conn, nil
+}
+
+func (p *Provider) bindServiceAccount(conn *ldap.Conn) error {
+	// Without a service account, searches are anonymous. Binding anonymously also resets the
+	// authentication of the connection.
+	var err error
+	if p.config.BindDN == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(p.config.BindDN, p.config.BindPassword)
+	}
+	if err != nil {
+		return errors.Wrap(err, "binding as the service account")
+	}
+	return nil
+}
+
+// groups returns the names of the groups the entry with the given DN is a member of.
+func (p *Provider) groups(conn *ldap.Conn, dn string) ([]string, error) {
+	c := p.config.GroupSync
+	res, err := conn.Search(ldap.NewSearchRequest(
+		c.GroupSearchBase, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		"(&"+groupFilter(c)+"("+memberAttribute(c)+"="+ldap.EscapeFilter(dn)+"))",
+		[]string{nameAttribute(c)},
+		nil,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for groups")
+	}
+	names := make([]string, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		if name := e.GetEqualFoldAttributeValue(nameAttribute(c)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// getProvider returns the LDAP auth provider with the given ID.
+func getProvider(id string) *Provider {
+	p, _ := providers.GetProviderByConfigID(providers.ConfigID{Type: providerType, ID: id}).(*Provider)
+	return p
+}
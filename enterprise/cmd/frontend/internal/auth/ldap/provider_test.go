@@ -0,0 +1,152 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/ldap/ldaptest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var testEntries = []ldaptest.Entry{
+	{
+		DN:         "cn=sourcegraph,ou=services,dc=example,dc=com",
+		Attributes: map[string][]string{"userPassword": {"service-secret"}},
+	},
+	{
+		DN: "uid=alice,ou=people,dc=example,dc=com",
+		Attributes: map[string][]string{
+			"objectClass":  {"person"},
+			"uid":          {"alice"},
+			"mail":         {"alice@example.com"},
+			"cn":           {"Alice Liddell"},
+			"userPassword": {"hunter2"},
+		},
+	},
+	{
+		DN: "uid=bob,ou=people,dc=example,dc=com",
+		Attributes: map[string][]string{
+			"objectClass":  {"person"},
+			"uid":          {"bob"},
+			"userPassword": {"bob-secret"},
+		},
+	},
+	{
+		DN: "uid=disabled,ou=people,dc=example,dc=com",
+		Attributes: map[string][]string{
+			"objectClass":  {"account"},
+			"uid":          {"disabled"},
+			"userPassword": {"disabled-secret"},
+		},
+	},
+	{
+		DN: "cn=engineering,ou=groups,dc=example,dc=com",
+		Attributes: map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"cn":          {"engineering"},
+			"member":      {"uid=alice,ou=people,dc=example,dc=com", "uid=bob,ou=people,dc=example,dc=com"},
+		},
+	},
+	{
+		DN: "cn=admins,ou=groups,dc=example,dc=com",
+		Attributes: map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"cn":          {"admins"},
+			"member":      {"uid=alice,ou=people,dc=example,dc=com"},
+		},
+	},
+}
+
+func newTestProvider(t *testing.T, c schema.LDAPAuthProvider) *Provider {
+	t.Helper()
+	c.Type = providerType
+	p, err := newProvider(&c)
+	require.NoError(t, err)
+	return p
+}
+
+func TestAuthenticate(t *testing.T) {
+	server := ldaptest.NewServer(t, testEntries...)
+	p := newTestProvider(t, schema.LDAPAuthProvider{
+		Url:            server.URL,
+		BindDN:         "cn=sourcegraph,ou=services,dc=example,dc=com",
+		BindPassword:   "service-secret",
+		UserSearchBase: "ou=people,dc=example,dc=com",
+		GroupSync:      &schema.LDAPGroupSync{GroupSearchBase: "ou=groups,dc=example,dc=com"},
+	})
+
+	u, err := p.authenticate("alice", "hunter2")
+	require.NoError(t, err)
+	assert.Equal(t, &user{
+		DN:          "uid=alice,ou=people,dc=example,dc=com",
+		Username:    "alice",
+		Email:       "alice@example.com",
+		DisplayName: "Alice Liddell",
+		Groups:      []string{"engineering", "admins"},
+	}, u)
+
+	for name, creds := range map[string][2]string{
+		"wrong password":       {"alice", "wrong"},
+		"empty password":       {"alice", ""},
+		"unknown user":         {"carol", "hunter2"},
+		"excluded by filter":   {"disabled", "disabled-secret"},
+		"outside search base":  {"sourcegraph", "service-secret"},
+		"wildcard username":    {"*", "hunter2"},
+		"filter injection":     {"alice)(uid=*", "hunter2"},
+		"another user's entry": {"bob", "hunter2"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := p.authenticate(creds[0], creds[1])
+			assert.ErrorIs(t, err, errInvalidCredentials)
+		})
+	}
+
+	t.Run("multiple entries", func(t *testing.T) {
+		p := newTestProvider(t, schema.LDAPAuthProvider{
+			Url:            server.URL,
+			UserSearchBase: "ou=people,dc=example,dc=com",
+			UserFilter:     "(objectClass=*)",
+			Attributes:     &schema.LDAPAttributes{Username: "objectClass"},
+		})
+		_, err := p.authenticate("person", "hunter2")
+		assert.ErrorContains(t, err, "multiple LDAP entries")
+	})
+
+	t.Run("wrong service account password", func(t *testing.T) {
+		p := newTestProvider(t, schema.LDAPAuthProvider{
+			Url:            server.URL,
+			BindDN:         "cn=sourcegraph,ou=services,dc=example,dc=com",
+			BindPassword:   "wrong",
+			UserSearchBase: "ou=people,dc=example,dc=com",
+		})
+		_, err := p.authenticate("alice", "hunter2")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, errInvalidCredentials)
+	})
+}
+
+func TestAuthenticateStartTLS(t *testing.T) {
+	serverConfig, certPEM := ldaptest.NewTLSConfig(t)
+	server := &ldaptest.Server{Entries: testEntries, TLSConfig: serverConfig}
+	server.Start(t)
+
+	p := newTestProvider(t, schema.LDAPAuthProvider{
+		Url:              server.URL,
+		StartTLS:         true,
+		TlsCACertificate: certPEM,
+		UserSearchBase:   "ou=people,dc=example,dc=com",
+	})
+	u, err := p.authenticate("alice", "hunter2")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", u.Username)
+
+	p = newTestProvider(t, schema.LDAPAuthProvider{
+		Url:            server.URL,
+		StartTLS:       true,
+		UserSearchBase: "ou=people,dc=example,dc=com",
+	})
+	_, err = p.authenticate("alice", "hunter2")
+	assert.ErrorContains(t, err, "StartTLS handshake")
+}
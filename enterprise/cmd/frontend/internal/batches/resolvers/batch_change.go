@@ -321,6 +321,35 @@ func (r *batchChangeResolver) BulkOperations(
 	}, nil
 }
 
+func (r *batchChangeResolver) ScheduledRuns(
+	ctx context.Context,
+	args *graphqlbackend.ListBatchChangeScheduledRunsArgs,
+) (graphqlbackend.BatchChangeScheduledRunConnectionResolver, error) {
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+	opts := store.ListBatchChangeScheduledRunsOpts{
+		LimitOpts: store.LimitOpts{
+			Limit: int(args.First),
+		},
+	}
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+
+	return &batchChangeScheduledRunConnectionResolver{
+		store:           r.store,
+		gitserverClient: r.gitserverClient,
+		logger:          r.logger,
+		batchChangeID:   r.batchChange.ID,
+		opts:            opts,
+	}, nil
+}
+
 func (r *batchChangeResolver) BatchSpecs(
 	ctx context.Context,
 	args *graphqlbackend.ListBatchSpecArgs,
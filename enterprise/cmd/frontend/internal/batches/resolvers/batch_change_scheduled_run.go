@@ -0,0 +1,76 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+const batchChangeScheduledRunIDKind = "BatchChangeScheduledRun"
+
+func marshalBatchChangeScheduledRunID(id int64) graphql.ID {
+	return relay.MarshalID(batchChangeScheduledRunIDKind, id)
+}
+
+type batchChangeScheduledRunResolver struct {
+	store           *store.Store
+	gitserverClient gitserver.Client
+	logger          log.Logger
+	run             *btypes.BatchChangeScheduledRun
+}
+
+var _ graphqlbackend.BatchChangeScheduledRunResolver = &batchChangeScheduledRunResolver{}
+
+func (r *batchChangeScheduledRunResolver) ID() graphql.ID {
+	return marshalBatchChangeScheduledRunID(r.run.ID)
+}
+
+func (r *batchChangeScheduledRunResolver) State() string {
+	return r.run.State.ToGraphQL()
+}
+
+func (r *batchChangeScheduledRunResolver) BatchSpec(ctx context.Context) (graphqlbackend.BatchSpecResolver, error) {
+	if r.run.BatchSpecID == 0 {
+		return nil, nil
+	}
+
+	batchSpec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: r.run.BatchSpecID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, gitserverClient: r.gitserverClient, logger: r.logger, batchSpec: batchSpec}, nil
+}
+
+func (r *batchChangeScheduledRunResolver) NewChangesets() int32 {
+	return r.run.NewChangesets
+}
+
+func (r *batchChangeScheduledRunResolver) FailureMessage() *string {
+	if r.run.FailureMessage == "" {
+		return nil
+	}
+	return &r.run.FailureMessage
+}
+
+func (r *batchChangeScheduledRunResolver) ScheduledAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.run.ScheduledAt}
+}
+
+func (r *batchChangeScheduledRunResolver) FinishedAt() *gqlutil.DateTime {
+	if r.run.FinishedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.run.FinishedAt}
+}
@@ -0,0 +1,78 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+type batchChangeScheduledRunConnectionResolver struct {
+	store           *store.Store
+	gitserverClient gitserver.Client
+	logger          log.Logger
+	batchChangeID   int64
+	opts            store.ListBatchChangeScheduledRunsOpts
+
+	// Cache results because they are used by multiple fields
+	once sync.Once
+	runs []*btypes.BatchChangeScheduledRun
+	next int64
+	err  error
+}
+
+var _ graphqlbackend.BatchChangeScheduledRunConnectionResolver = &batchChangeScheduledRunConnectionResolver{}
+
+func (r *batchChangeScheduledRunConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountBatchChangeScheduledRuns(ctx, store.CountBatchChangeScheduledRunsOpts{
+		BatchChangeID: r.batchChangeID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+func (r *batchChangeScheduledRunConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *batchChangeScheduledRunConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.BatchChangeScheduledRunResolver, error) {
+	runs, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchChangeScheduledRunResolver, 0, len(runs))
+	for _, run := range runs {
+		resolvers = append(resolvers, &batchChangeScheduledRunResolver{store: r.store, gitserverClient: r.gitserverClient, logger: r.logger, run: run})
+	}
+
+	return resolvers, nil
+}
+
+func (r *batchChangeScheduledRunConnectionResolver) compute(ctx context.Context) ([]*btypes.BatchChangeScheduledRun, int64, error) {
+	r.once.Do(func() {
+		opts := r.opts
+		opts.BatchChangeID = r.batchChangeID
+		r.runs, r.next, r.err = r.store.ListBatchChangeScheduledRuns(ctx, opts)
+	})
+
+	return r.runs, r.next, r.err
+}
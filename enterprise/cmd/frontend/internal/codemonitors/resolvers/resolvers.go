@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
@@ -122,6 +123,10 @@ func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.C
 		return nil, err
 	}
 
+	if err := codemonitors.ValidateQuery(ctx, r.logger, r.db, args.Trigger.Query); err != nil {
+		return nil, err
+	}
+
 	// Start transaction.
 	var newMonitor *database.Monitor
 	err = r.withTransact(ctx, func(tx *Resolver) error {
@@ -225,6 +230,10 @@ func (r *Resolver) UpdateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 		return nil, err
 	}
 
+	if err := codemonitors.ValidateQuery(ctx, r.logger, r.db, args.Trigger.Update.Query); err != nil {
+		return nil, err
+	}
+
 	// Get all action IDs of the monitor.
 	actionIDs, err := r.actionIDsForMonitorIDInt64(ctx, monitorID)
 	if err != nil {
@@ -292,15 +301,31 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err != nil {
 				return err
 			}
+		case a.TeamsWebhook != nil:
+			if err := validateTeamsURL(a.TeamsWebhook.URL); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateTeamsWebhookAction(ctx, monitorID, a.TeamsWebhook.Enabled, a.TeamsWebhook.IncludeResults, a.TeamsWebhook.URL)
+			if err != nil {
+				return err
+			}
+		case a.MattermostWebhook != nil:
+			if err := validateMattermostURL(a.MattermostWebhook.URL); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateMattermostWebhookAction(ctx, monitorID, a.MattermostWebhook.Enabled, a.MattermostWebhook.IncludeResults, a.MattermostWebhook.URL)
+			if err != nil {
+				return err
+			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, TeamsWebhook, or MattermostWebhook must be set")
 		}
 	}
 	return nil
 }
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
-	var email, webhook, slackWebhook []int64
+	var email, webhook, slackWebhook, teamsWebhook, mattermostWebhook []int64
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -315,8 +340,12 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 			webhook = append(webhook, intID)
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
+		case monitorActionTeamsWebhookKind:
+			teamsWebhook = append(teamsWebhook, intID)
+		case monitorActionMattermostWebhookKind:
+			mattermostWebhook = append(mattermostWebhook, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			return errors.New("action IDs must be exactly one of email, webhook, slack webhook, teams webhook, or mattermost webhook")
 		}
 	}
 
@@ -332,6 +361,14 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	if err := r.db.CodeMonitors().DeleteTeamsWebhookActions(ctx, monitorID, teamsWebhook...); err != nil {
+		return err
+	}
+
+	if err := r.db.CodeMonitors().DeleteMattermostWebhookActions(ctx, monitorID, mattermostWebhook...); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -412,6 +449,32 @@ func (r *Resolver) TriggerTestSlackWebhookAction(ctx context.Context, args *grap
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) TriggerTestTeamsWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestTeamsWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestTeamsWebhook(ctx, httpcli.ExternalDoer, args.Description, args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) TriggerTestMattermostWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestMattermostWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestMattermostWebhook(ctx, httpcli.ExternalDoer, args.Description, args.MattermostWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func sendTestEmail(ctx context.Context, db database.DB, recipient graphql.ID, description string) error {
 	var (
 		userID int32
@@ -443,7 +506,15 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	teamsWebhookActions, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	mattermostWebhookActions, err := r.db.CodeMonitors().ListMattermostWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(teamsWebhookActions)+len(mattermostWebhookActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -453,6 +524,12 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, teamsWebhookAction := range teamsWebhookActions {
+		ids = append(ids, (&monitorTeamsWebhook{TeamsWebhookAction: teamsWebhookAction}).ID())
+	}
+	for _, mattermostWebhookAction := range mattermostWebhookActions {
+		ids = append(ids, (&monitorMattermostWebhook{MattermostWebhookAction: mattermostWebhookAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -497,6 +574,26 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.TeamsWebhook != nil:
+			if a.TeamsWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{TeamsWebhook: a.TeamsWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.TeamsWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.TeamsWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.TeamsWebhook.Id)
+		case a.MattermostWebhook != nil:
+			if a.MattermostWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{MattermostWebhook: a.MattermostWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.MattermostWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.MattermostWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.MattermostWebhook.Id)
 		}
 	}
 
@@ -576,8 +673,18 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.TeamsWebhook != nil:
+			if err := validateTeamsURL(action.TeamsWebhook.Update.URL); err != nil {
+				return nil, err
+			}
+			err = r.updateTeamsWebhookAction(ctx, *action.TeamsWebhook)
+		case action.MattermostWebhook != nil:
+			if err := validateMattermostURL(action.MattermostWebhook.Update.URL); err != nil {
+				return nil, err
+			}
+			err = r.updateMattermostWebhookAction(ctx, *action.MattermostWebhook)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, teams webhook, or mattermost webhook")
 		}
 		if err != nil {
 			return nil, err
@@ -633,6 +740,28 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 	return err
 }
 
+func (r *Resolver) updateTeamsWebhookAction(ctx context.Context, args graphqlbackend.EditActionTeamsWebhookArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateTeamsWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	return err
+}
+
+func (r *Resolver) updateMattermostWebhookAction(ctx context.Context, args graphqlbackend.EditActionMattermostWebhookArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateMattermostWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	return err
+}
+
 func (r *Resolver) withTransact(ctx context.Context, f func(*Resolver) error) error {
 	return r.db.WithTransact(ctx, func(tx database.DB) error {
 		return f(&Resolver{
@@ -716,6 +845,8 @@ const (
 	monitorActionEmailKind             = "CodeMonitorActionEmail"
 	monitorActionWebhookKind           = "CodeMonitorActionWebhook"
 	monitorActionSlackWebhookKind      = "CodeMonitorActionSlackWebhook"
+	monitorActionTeamsWebhookKind      = "CodeMonitorActionTeamsWebhook"
+	monitorActionMattermostWebhookKind = "CodeMonitorActionMattermostWebhook"
 	monitorActionEmailEventKind        = "CodeMonitorActionEmailEvent"
 	monitorActionWebhookEventKind      = "CodeMonitorActionWebhookEvent"
 	monitorActionSlackWebhookEventKind = "CodeMonitorActionSlackWebhookEvent"
@@ -811,7 +942,17 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	tws, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	mws, err := r.db.CodeMonitors().ListMattermostWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(tws)+len(mws))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -839,6 +980,24 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, tw := range tws {
+		actions = append(actions, &action{
+			teamsWebhook: &monitorTeamsWebhook{
+				Resolver:           r,
+				TeamsWebhookAction: tw,
+				triggerEventID:     triggerEventID,
+			},
+		})
+	}
+	for _, mw := range mws {
+		actions = append(actions, &action{
+			mattermostWebhook: &monitorMattermostWebhook{
+				Resolver:                r,
+				MattermostWebhookAction: mw,
+				triggerEventID:          triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -963,11 +1122,7 @@ func (m *monitorTriggerEvent) Query() *string {
 }
 
 func (m *monitorTriggerEvent) ResultCount() int32 {
-	count := 0
-	for _, cm := range m.TriggerJob.SearchResults {
-		count += cm.ResultCount()
-	}
-	return int32(count)
+	return int32(m.TriggerJob.ResultCount())
 }
 
 func (m *monitorTriggerEvent) Message() *string {
@@ -1012,9 +1167,11 @@ func (a *monitorActionConnection) PageInfo() *graphqlutil.PageInfo {
 
 // Action <<UNION>>
 type action struct {
-	email        graphqlbackend.MonitorEmailResolver
-	webhook      graphqlbackend.MonitorWebhookResolver
-	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	email             graphqlbackend.MonitorEmailResolver
+	webhook           graphqlbackend.MonitorWebhookResolver
+	slackWebhook      graphqlbackend.MonitorSlackWebhookResolver
+	teamsWebhook      graphqlbackend.MonitorTeamsWebhookResolver
+	mattermostWebhook graphqlbackend.MonitorMattermostWebhookResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1025,6 +1182,10 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.teamsWebhook != nil:
+		return a.teamsWebhook.ID()
+	case a.mattermostWebhook != nil:
+		return a.mattermostWebhook.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1042,6 +1203,14 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorTeamsWebhook() (graphqlbackend.MonitorTeamsWebhookResolver, bool) {
+	return a.teamsWebhook, a.teamsWebhook != nil
+}
+
+func (a *action) ToMonitorMattermostWebhook() (graphqlbackend.MonitorMattermostWebhookResolver, bool) {
+	return a.mattermostWebhook, a.mattermostWebhook != nil
+}
+
 // Email
 type monitorEmail struct {
 	*Resolver
@@ -1257,6 +1426,118 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+type monitorTeamsWebhook struct {
+	*Resolver
+	*database.TeamsWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorTeamsWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionTeamsWebhookKind, m.TeamsWebhookAction.ID)
+}
+
+func (m *monitorTeamsWebhook) Enabled() bool {
+	return m.TeamsWebhookAction.Enabled
+}
+
+func (m *monitorTeamsWebhook) IncludeResults() bool {
+	return m.TeamsWebhookAction.IncludeResults
+}
+
+func (m *monitorTeamsWebhook) URL() string {
+	return m.TeamsWebhookAction.URL
+}
+
+func (m *monitorTeamsWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, database.ListActionJobsOpts{
+		TeamsWebhookID: pointers.Ptr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          pointers.Ptr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, database.ListActionJobsOpts{
+		TeamsWebhookID: pointers.Ptr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
+type monitorMattermostWebhook struct {
+	*Resolver
+	*database.MattermostWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorMattermostWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionMattermostWebhookKind, m.MattermostWebhookAction.ID)
+}
+
+func (m *monitorMattermostWebhook) Enabled() bool {
+	return m.MattermostWebhookAction.Enabled
+}
+
+func (m *monitorMattermostWebhook) IncludeResults() bool {
+	return m.MattermostWebhookAction.IncludeResults
+}
+
+func (m *monitorMattermostWebhook) URL() string {
+	return m.MattermostWebhookAction.URL
+}
+
+func (m *monitorMattermostWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, database.ListActionJobsOpts{
+		MattermostWebhookID: pointers.Ptr(int(m.MattermostWebhookAction.ID)),
+		TriggerEventID:      m.triggerEventID,
+		First:               pointers.Ptr(int(args.First)),
+		After:               after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, database.ListActionJobsOpts{
+		MattermostWebhookID: pointers.Ptr(int(m.MattermostWebhookAction.ID)),
+		TriggerEventID:      m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
 		return nil
@@ -1349,3 +1630,45 @@ func validateSlackURL(urlString string) error {
 	}
 	return nil
 }
+
+// teamsWebhookHostSuffixes are the hosts Microsoft Teams webhooks are served
+// from: incoming webhook connectors on webhook.office.com (or the legacy
+// outlook.office.com), and workflows on Azure Logic Apps or Power Platform.
+var teamsWebhookHostSuffixes = []string{
+	".webhook.office.com",
+	".logic.azure.com",
+	".api.powerplatform.com",
+}
+
+func validateTeamsURL(urlString string) error {
+	u, err := url.Parse(urlString)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme == "https" {
+		host := u.Hostname()
+		if host == "outlook.office.com" {
+			return nil
+		}
+		for _, suffix := range teamsWebhookHostSuffixes {
+			if strings.HasSuffix(host, suffix) {
+				return nil
+			}
+		}
+	}
+	return errors.New("Microsoft Teams webhook URL must be an https:// URL on webhook.office.com, outlook.office.com, logic.azure.com or api.powerplatform.com")
+}
+
+func validateMattermostURL(urlString string) error {
+	u, err := url.Parse(urlString)
+	if err != nil {
+		return err
+	}
+
+	// Mattermost is self-hosted, so the webhook can live on any host.
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errors.New("Mattermost webhook URL must begin with 'https://' or 'http://'")
+	}
+	return nil
+}
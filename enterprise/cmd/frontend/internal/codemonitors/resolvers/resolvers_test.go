@@ -1322,3 +1322,49 @@ func TestValidateSlackURL(t *testing.T) {
 		require.Error(t, validateSlackURL(url))
 	}
 }
+
+func TestValidateTeamsURL(t *testing.T) {
+	valid := []string{
+		"https://example.webhook.office.com/webhookb2/8d8d8/IncomingWebhook/838383",
+		"https://prod-00.westus.logic.azure.com:443/workflows/8d8d8/triggers/manual/paths/invoke",
+		"https://default8d8d8.08.environment.api.powerplatform.com:443/powerautomate/automations/direct/workflows/8d8d8/triggers/manual/paths/invoke",
+		"https://outlook.office.com/webhook/8d8d8/IncomingWebhook/838383",
+	}
+
+	for _, url := range valid {
+		require.NoError(t, validateTeamsURL(url))
+	}
+
+	invalid := []string{
+		"http://example.webhook.office.com/webhookb2",
+		"https:///webhookb2",
+		"example.webhook.office.com",
+		"https://example.com/webhookb2",
+		"https://webhook.office.com.example.com/webhookb2",
+		"https://evilwebhook.office.com/webhookb2",
+	}
+
+	for _, url := range invalid {
+		require.Error(t, validateTeamsURL(url))
+	}
+}
+
+func TestValidateMattermostURL(t *testing.T) {
+	valid := []string{
+		"https://mattermost.example.com/hooks/xxx-generatedkey-xxx",
+		"http://internal:8065/hooks/xxx-generatedkey-xxx",
+	}
+
+	for _, url := range valid {
+		require.NoError(t, validateMattermostURL(url))
+	}
+
+	invalid := []string{
+		"ftp://mattermost.example.com/hooks/xxx",
+		"mattermost.example.com/hooks/xxx",
+	}
+
+	for _, url := range invalid {
+		require.Error(t, validateMattermostURL(url))
+	}
+}
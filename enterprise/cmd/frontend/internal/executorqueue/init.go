@@ -1,8 +1,11 @@
 package executorqueue
 
 import (
+	"context"
+
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/batches/sharedcache"
 	"github.com/sourcegraph/sourcegraph/internal/conf/confdefaults"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/conf/deploy"
@@ -12,6 +15,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 )
 
+// LoadConfig loads the configuration of the blob store used to share Batch
+// Changes step results between users.
+func LoadConfig() {
+	sharedcache.ConfigInst.Load()
+}
+
 // Init initializes the executor endpoints required for use with the executor service.
 func Init(
 	observationCtx *observation.Context,
@@ -32,6 +41,14 @@ func Init(
 
 	logger := log.Scoped("executorqueue", "")
 
+	if err := sharedcache.ConfigInst.Validate(); err != nil {
+		return err
+	}
+	batchesSharedStepCache, err := sharedcache.New(context.Background(), observationCtx, sharedcache.ConfigInst)
+	if err != nil {
+		return err
+	}
+
 	queueHandler := newExecutorQueuesHandler(
 		observationCtx,
 		db,
@@ -40,6 +57,7 @@ func Init(
 		codeintelUploadHandler,
 		batchesWorkspaceFileGetHandler,
 		batchesWorkspaceFileExistsHandler,
+		batchesSharedStepCache,
 	)
 
 	enterpriseServices.NewExecutorProxyHandler = queueHandler
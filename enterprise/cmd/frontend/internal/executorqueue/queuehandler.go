@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/queues/batches"
 	codeintelqueue "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/queues/codeintel"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sharedcache"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/executor/store"
@@ -31,6 +32,7 @@ func newExecutorQueuesHandler(
 	uploadHandler http.Handler,
 	batchesWorkspaceFileGetHandler http.Handler,
 	batchesWorkspaceFileExistsHandler http.Handler,
+	batchesSharedStepCache *sharedcache.Cache,
 ) func() http.Handler {
 	metricsStore := metricsstore.NewDistributedStore("executors:")
 	executorStore := db.Executors()
@@ -42,7 +44,7 @@ func newExecutorQueuesHandler(
 	//
 	// Note: In order register a new queue type please change the validate() check code in enterprise/cmd/executor/config.go
 	codeIntelQueueHandler := codeintelqueue.QueueHandler(observationCtx, db, accessToken)
-	batchesQueueHandler := batches.QueueHandler(observationCtx, db, accessToken)
+	batchesQueueHandler := batches.QueueHandler(observationCtx, db, batchesSharedStepCache, accessToken)
 
 	codeintelHandler := handler.NewHandler(executorStore, jobTokenStore, metricsStore, codeIntelQueueHandler)
 	batchesHandler := handler.NewHandler(executorStore, jobTokenStore, metricsStore, batchesQueueHandler)
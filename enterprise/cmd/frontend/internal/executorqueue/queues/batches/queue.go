@@ -6,6 +6,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sharedcache"
 	bstore "github.com/sourcegraph/sourcegraph/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -13,14 +14,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
-func QueueHandler(observationCtx *observation.Context, db database.DB, _ func() string) handler.QueueHandler[*btypes.BatchSpecWorkspaceExecutionJob] {
+func QueueHandler(observationCtx *observation.Context, db database.DB, sharedCache *sharedcache.Cache, _ func() string) handler.QueueHandler[*btypes.BatchSpecWorkspaceExecutionJob] {
 	logger := log.Scoped("executor-queue.batches", "The executor queue handlers for the batches queue")
 	recordTransformer := func(ctx context.Context, version string, record *btypes.BatchSpecWorkspaceExecutionJob, _ handler.ResourceMetadata) (apiclient.Job, error) {
 		batchesStore := bstore.New(db, observationCtx, nil)
 		return transformRecord(ctx, logger, batchesStore, record, version)
 	}
 
-	store := bstore.NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx, db.Handle())
+	store := bstore.NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx, db.Handle(), sharedCache)
 	return handler.QueueHandler[*btypes.BatchSpecWorkspaceExecutionJob]{
 		Name:              "batches",
 		Store:             store,
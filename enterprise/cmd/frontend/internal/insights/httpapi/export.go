@@ -49,21 +49,27 @@ func NewExportHandler(db database.DB, insightsDB edb.InsightsDB) *ExportHandler
 	}
 }
 
+// ExportFunc exports all data points of an insight. By default they are written as a CSV file in a zip archive. The
+// format query parameter can be set to csv or ndjson to stream them, with the series ID of each point, in a format
+// that can be imported back.
 func (h *ExportHandler) ExportFunc() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := mux.Vars(r)["id"]
 
+		switch format := r.URL.Query().Get("format"); format {
+		case "", formatZip:
+			// the zip archive is built in memory below
+		case formatCSV, formatNDJSON:
+			h.streamCodeInsightData(w, r, id, format)
+			return
+		default:
+			http.Error(w, fmt.Sprintf("unsupported export format %q", format), http.StatusBadRequest)
+			return
+		}
+
 		archive, err := h.exportCodeInsightData(r.Context(), id)
 		if err != nil {
-			if errors.Is(err, notFoundError) {
-				http.Error(w, err.Error(), http.StatusNotFound)
-			} else if errors.Is(err, authenticationError) {
-				http.Error(w, err.Error(), http.StatusUnauthorized)
-			} else if errors.Is(err, invalidLicenseError) {
-				http.Error(w, err.Error(), http.StatusForbidden)
-			} else {
-				http.Error(w, fmt.Sprintf("failed to export data: %v", err), http.StatusInternalServerError)
-			}
+			writeExportError(w, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/zip")
@@ -76,6 +82,46 @@ func (h *ExportHandler) ExportFunc() http.HandlerFunc {
 	}
 }
 
+func writeExportError(w http.ResponseWriter, err error) {
+	if errors.Is(err, notFoundError) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if errors.Is(err, authenticationError) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	} else if errors.Is(err, invalidLicenseError) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+	} else {
+		http.Error(w, fmt.Sprintf("failed to export data: %v", err), http.StatusInternalServerError)
+	}
+}
+
+func (h *ExportHandler) streamCodeInsightData(w http.ResponseWriter, r *http.Request, id, format string) {
+	export, err := h.prepareExport(r.Context(), id)
+	if err != nil {
+		writeExportError(w, err)
+		return
+	}
+
+	contentType := "text/csv"
+	if format == formatNDJSON {
+		contentType = "application/x-ndjson"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.%s\"", export.name, format))
+
+	dataWriter := newDataPointWriter(w, format)
+	err = h.seriesStore.StreamAllDataForInsightViewID(r.Context(), export.opts, func(p store.SeriesPointForExport) error {
+		return dataWriter.Write(newDataPoint(p))
+	})
+	if err == nil {
+		err = dataWriter.Flush()
+	}
+	if err != nil {
+		// The status has been sent with the first data points, so abort the response to not leave clients with a
+		// truncated export that looks complete.
+		panic(http.ErrAbortHandler)
+	}
+}
+
 type codeInsightsDataArchive struct {
 	name string
 	data []byte
@@ -85,7 +131,13 @@ var notFoundError = errors.New("insight not found")
 var authenticationError = errors.New("authentication error")
 var invalidLicenseError = errors.New("invalid license for code insights")
 
-func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*codeInsightsDataArchive, error) {
+// insightExport describes the data points to export for an insight the current user can see.
+type insightExport struct {
+	name string
+	opts store.ExportOpts
+}
+
+func (h *ExportHandler) prepareExport(ctx context.Context, id string) (*insightExport, error) {
 	currentActor := actor.FromContext(ctx)
 	if !currentActor.IsAuthenticated() {
 		return nil, authenticationError
@@ -151,13 +203,26 @@ func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*
 	}
 	includeRepo(inc...)
 	excludeRepo(exc...)
-
-	var buf bytes.Buffer
-	zw := zip.NewWriter(&buf)
+	opts.InsightViewUniqueID = insightViewId
 
 	timestamp := time.Now().Format(time.RFC3339)
 	escapedInsightViewTitle := regexp.MustCompile(`\W+`).ReplaceAllString(visibleViewSeries[0].Title, "-")
-	name := fmt.Sprintf("%s-%s", escapedInsightViewTitle, timestamp)
+
+	return &insightExport{
+		name: fmt.Sprintf("%s-%s", escapedInsightViewTitle, timestamp),
+		opts: opts,
+	}, nil
+}
+
+func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*codeInsightsDataArchive, error) {
+	export, err := h.prepareExport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	name := export.name
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
 
 	dataFile, err := zw.Create(fmt.Sprintf("%s.csv", name))
 	if err != nil {
@@ -181,8 +246,7 @@ func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*
 		return nil, errors.Wrap(err, "failed to write csv header")
 	}
 
-	opts.InsightViewUniqueID = insightViewId
-	dataPoints, err := h.seriesStore.GetAllDataForInsightViewID(ctx, opts)
+	dataPoints, err := h.seriesStore.GetAllDataForInsightViewID(ctx, export.opts)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to fetch all data for insight")
 	}
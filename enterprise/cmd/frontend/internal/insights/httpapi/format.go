@@ -0,0 +1,228 @@
+package httpapi
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	formatZip    = "zip"
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// dataPoint is a single exported data point of an insight series. Points with a nil repository only record that the
+// series was sampled at their recording time.
+type dataPoint struct {
+	Title         string    `json:"title"`
+	Label         string    `json:"label"`
+	Query         string    `json:"query"`
+	SeriesID      string    `json:"seriesId"`
+	RecordingTime time.Time `json:"recordingTime"`
+	Snapshot      bool      `json:"snapshot"`
+	Repository    *string   `json:"repository"`
+	Value         int       `json:"value"`
+	Capture       *string   `json:"capture"`
+}
+
+func newDataPoint(p store.SeriesPointForExport) dataPoint {
+	return dataPoint{
+		Title:         p.InsightViewTitle,
+		Label:         p.SeriesLabel,
+		Query:         p.SeriesQuery,
+		SeriesID:      p.SeriesID,
+		RecordingTime: p.RecordingTime.UTC(),
+		Snapshot:      p.Snapshot,
+		Repository:    p.RepoName,
+		Value:         p.Value,
+		Capture:       p.Capture,
+	}
+}
+
+// csvColumns are the columns of streamed CSV exports. The zip export only has a subset of them, but both can be
+// imported.
+var csvColumns = []string{
+	"title",
+	"label",
+	"query",
+	"series_id",
+	"recording_time",
+	"snapshot",
+	"repository",
+	"value",
+	"capture",
+}
+
+// legacyTimeLayout is the format of recording times in zip exports.
+const legacyTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+type dataPointWriter interface {
+	Write(dataPoint) error
+	Flush() error
+}
+
+func newDataPointWriter(w io.Writer, format string) dataPointWriter {
+	if format == formatNDJSON {
+		bw := bufio.NewWriter(w)
+		return &ndjsonWriter{w: bw, enc: json.NewEncoder(bw)}
+	}
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+// writeHeader writes the header once, so that exports without data points are still valid CSV files.
+func (w *csvWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return errors.Wrap(w.w.Write(csvColumns), "failed to write csv header")
+}
+
+func (w *csvWriter) Write(p dataPoint) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	return w.w.Write([]string{
+		p.Title,
+		p.Label,
+		p.Query,
+		p.SeriesID,
+		p.RecordingTime.Format(time.RFC3339Nano),
+		strconv.FormatBool(p.Snapshot),
+		emptyStringIfNil(p.Repository),
+		strconv.Itoa(p.Value),
+		emptyStringIfNil(p.Capture),
+	})
+}
+
+func (w *csvWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type ndjsonWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(p dataPoint) error {
+	return w.enc.Encode(p)
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// readDataPoints calls fn with every data point read from r in the given format.
+func readDataPoints(r io.Reader, format string, fn func(dataPoint) error) error {
+	if format == formatNDJSON {
+		return readNDJSON(r, fn)
+	}
+	return readCSV(r, fn)
+}
+
+func readNDJSON(r io.Reader, fn func(dataPoint) error) error {
+	dec := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		var p dataPoint
+		if err := dec.Decode(&p); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrapf(err, "invalid data point %d", line)
+		}
+		if p.RecordingTime.IsZero() {
+			return errors.Newf("data point %d has no recordingTime", line)
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+}
+
+func readCSV(r io.Reader, fn func(dataPoint) error) error {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to read csv header")
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[name] = i
+	}
+	for _, required := range []string{"recording_time", "value"} {
+		if _, ok := columns[required]; !ok {
+			return errors.Newf("missing csv column %q", required)
+		}
+	}
+	column := func(record []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return record[i]
+		}
+		return ""
+	}
+	optional := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "failed to read csv record")
+		}
+		line, _ := cr.FieldPos(0)
+
+		p := dataPoint{
+			Title:      column(record, "title"),
+			Label:      column(record, "label"),
+			Query:      column(record, "query"),
+			SeriesID:   column(record, "series_id"),
+			Repository: optional(column(record, "repository")),
+			Capture:    optional(column(record, "capture")),
+		}
+		if p.RecordingTime, err = parseRecordingTime(column(record, "recording_time")); err != nil {
+			return errors.Wrapf(err, "line %d", line)
+		}
+		if p.Value, err = strconv.Atoi(column(record, "value")); err != nil {
+			return errors.Wrapf(err, "line %d: invalid value", line)
+		}
+		if snapshot := column(record, "snapshot"); snapshot != "" {
+			if p.Snapshot, err = strconv.ParseBool(snapshot); err != nil {
+				return errors.Wrapf(err, "line %d: invalid snapshot", line)
+			}
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+}
+
+func parseRecordingTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, legacyTimeLayout} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, errors.Newf("invalid recording time %q", s)
+}
@@ -0,0 +1,90 @@
+package httpapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestDataPointsRoundTrip(t *testing.T) {
+	recordingTime := time.Date(2023, 8, 1, 12, 30, 0, 0, time.UTC)
+	points := []dataPoint{
+		{Title: "deprecated APIs", Label: "usages", Query: "deprecatedAPI(", SeriesID: "s1", RecordingTime: recordingTime},
+		{Title: "deprecated APIs", Label: "usages", Query: "deprecatedAPI(", SeriesID: "s1", RecordingTime: recordingTime, Repository: pointers.Ptr("github.com/sourcegraph/sourcegraph"), Value: 42},
+		{Title: "deprecated APIs", Label: "1.0", Query: "version (\\d)", SeriesID: "s2", RecordingTime: recordingTime, Snapshot: true, Repository: pointers.Ptr("github.com/sourcegraph/about"), Value: 3, Capture: pointers.Ptr("1.0")},
+	}
+
+	for _, format := range []string{formatCSV, formatNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := newDataPointWriter(&buf, format)
+			for _, p := range points {
+				require.NoError(t, w.Write(p))
+			}
+			require.NoError(t, w.Flush())
+
+			var read []dataPoint
+			require.NoError(t, readDataPoints(&buf, format, func(p dataPoint) error {
+				read = append(read, p)
+				return nil
+			}))
+			require.Equal(t, points, read)
+		})
+	}
+}
+
+func TestReadZipExportCSV(t *testing.T) {
+	// CSV files of zip exports do not have the series_id and snapshot columns, and have differently formatted times.
+	data := `title,label,query,recording_time,repository,value,capture
+deprecated APIs,usages,deprecatedAPI(,2023-08-01 12:30:00 +0000 UTC,github.com/sourcegraph/sourcegraph,42,
+`
+	var read []dataPoint
+	require.NoError(t, readDataPoints(strings.NewReader(data), formatCSV, func(p dataPoint) error {
+		read = append(read, p)
+		return nil
+	}))
+	require.Equal(t, []dataPoint{{
+		Title:         "deprecated APIs",
+		Label:         "usages",
+		Query:         "deprecatedAPI(",
+		RecordingTime: time.Date(2023, 8, 1, 12, 30, 0, 0, time.UTC),
+		Repository:    pointers.Ptr("github.com/sourcegraph/sourcegraph"),
+		Value:         42,
+	}}, read)
+
+	err := readDataPoints(strings.NewReader("title,value\nfoo,1\n"), formatCSV, func(dataPoint) error { return nil })
+	require.EqualError(t, err, `missing csv column "recording_time"`)
+}
+
+func TestSeriesMatcher(t *testing.T) {
+	m := newSeriesMatcher([]types.InsightViewSeries{
+		{SeriesID: "s1", Query: "foo", Label: "foo usages"},
+		{SeriesID: "s2", Query: "bar", Label: "usages"},
+		{SeriesID: "s3", Query: "bar", Label: "more usages"},
+	})
+
+	for _, tc := range []struct {
+		name   string
+		point  dataPoint
+		series string
+	}{
+		{name: "series ID", point: dataPoint{SeriesID: "s2", Query: "foo"}, series: "s2"},
+		{name: "query of re-created insight", point: dataPoint{SeriesID: "old", Query: "foo"}, series: "s1"},
+		{name: "label when query is ambiguous", point: dataPoint{SeriesID: "old", Query: "bar", Label: "more usages"}, series: "s3"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			series, err := m.match(tc.point)
+			require.NoError(t, err)
+			require.Equal(t, tc.series, series.SeriesID)
+		})
+	}
+
+	_, err := m.match(dataPoint{SeriesID: "old", Query: "bar", Label: "unknown"})
+	require.EqualError(t, err, `invalid import: no unique series of the insight matches the data point of series "old" with query "bar" and label "unknown"`)
+}
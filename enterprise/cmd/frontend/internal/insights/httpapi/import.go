@@ -0,0 +1,401 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	edb "github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/insights/scheduler"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ImportHandler handles importing code insights data that was exported in the csv or ndjson format, replacing the
+// recorded data of the imported repositories of the series at the imported recording times. Data of repositories
+// that are not part of the import, e.g. because the export was filtered, is kept. Importing the history of a series completes its
+// backfill, so that the history does not have to be recomputed when an insight is re-created.
+type ImportHandler struct {
+	primaryDB  database.DB
+	insightsDB edb.InsightsDB
+
+	permStore *store.InsightPermStore
+}
+
+// importBatchSize is the number of data points resolved and recorded at once. Each batch is recorded in its own
+// transaction.
+const importBatchSize = 1000
+
+func NewImportHandler(db database.DB, insightsDB edb.InsightsDB) *ImportHandler {
+	return &ImportHandler{
+		primaryDB:  db,
+		insightsDB: insightsDB,
+		permStore:  store.NewInsightPermissionStore(db),
+	}
+}
+
+// importResult is the response of a successful import.
+type importResult struct {
+	// Series is the number of series with imported data points.
+	Series int `json:"series"`
+	// Imported is the number of imported data points.
+	Imported int `json:"imported"`
+	// Skipped is the number of snapshot data points and data points of repositories that no longer exist, which are
+	// not imported.
+	Skipped int `json:"skipped"`
+}
+
+// importDataError is returned when the imported data cannot be read or does not match the series of the insight.
+type importDataError struct {
+	err error
+}
+
+func (e *importDataError) Error() string {
+	return fmt.Sprintf("invalid import: %v", e.err)
+}
+
+func (h *ImportHandler) ImportFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = formatCSV
+			if strings.Contains(r.Header.Get("Content-Type"), "ndjson") {
+				format = formatNDJSON
+			}
+		}
+		if format != formatCSV && format != formatNDJSON {
+			http.Error(w, fmt.Sprintf("unsupported import format %q", format), http.StatusBadRequest)
+			return
+		}
+
+		result, err := h.importCodeInsightData(r.Context(), id, format, r)
+		if err != nil {
+			var dataErr *importDataError
+			if errors.Is(err, notFoundError) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+			} else if errors.Is(err, authenticationError) {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+			} else if errors.Is(err, auth.ErrMustBeSiteAdmin) || errors.Is(err, invalidLicenseError) {
+				http.Error(w, err.Error(), http.StatusForbidden)
+			} else if errors.As(err, &dataErr) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			} else {
+				http.Error(w, fmt.Sprintf("failed to import data: %v", err), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			http.Error(w, fmt.Sprintf("failed to write response: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+func (h *ImportHandler) importCodeInsightData(ctx context.Context, id, format string, r *http.Request) (_ *importResult, err error) {
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		return nil, authenticationError
+	}
+	// 🚨 SECURITY: importing overwrites the data of every viewer of the insight, so only site admins can do it.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, h.primaryDB); err != nil {
+		return nil, err
+	}
+	if err := licensing.Check(licensing.FeatureCodeInsights); err != nil {
+		return nil, invalidLicenseError
+	}
+
+	var insightViewId string
+	if err := relay.UnmarshalSpec(graphql.ID(id), &insightViewId); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal insight view ID")
+	}
+	userIDs, orgIDs, err := h.permStore.GetUserPermissions(ctx)
+	if err != nil {
+		return nil, authenticationError
+	}
+	viewSeries, err := store.NewInsightStore(h.insightsDB).GetAll(ctx, store.InsightQueryArgs{
+		UniqueIDs: []string{insightViewId},
+		UserIDs:   userIDs,
+		OrgIDs:    orgIDs,
+	})
+	if err != nil {
+		return nil, errors.New("could not fetch insight information")
+	}
+	if len(viewSeries) == 0 {
+		return nil, notFoundError
+	}
+
+	insightsStore := store.New(h.insightsDB, h.permStore)
+	imp := &dataImport{
+		store:      insightsStore,
+		repos:      h.primaryDB.Repos(),
+		series:     newSeriesMatcher(viewSeries),
+		repoIDs:    map[string]api.RepoID{},
+		seenTimes:  map[string]map[time.Time]struct{}{},
+		seenPoints: map[seenPoint]struct{}{},
+		seriesByID: map[string]types.InsightViewSeries{},
+	}
+	var addErr error
+	if err := readDataPoints(r.Body, format, func(p dataPoint) error {
+		addErr = imp.add(ctx, p)
+		return addErr
+	}); err != nil {
+		if addErr == nil {
+			return nil, &importDataError{err: err}
+		}
+		return nil, err
+	}
+	if err := imp.flush(ctx); err != nil {
+		return nil, err
+	}
+
+	// The recording times and backfills are only updated once every data point is recorded. An import that fails
+	// before can be retried, as importing the same data again replaces the data points recorded by the first attempt.
+	tx, err := insightsStore.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+	if err := imp.finish(ctx, tx, store.NewInsightStore(h.insightsDB).With(tx), scheduler.NewBackfillStore(h.insightsDB).With(tx)); err != nil {
+		return nil, err
+	}
+	return &imp.result, nil
+}
+
+// seriesMatcher finds the series of an insight view that exported data points belong to. Points are matched by
+// series ID first, so that the data of a re-created insight can be matched by query or label instead.
+type seriesMatcher struct {
+	byID    map[string]types.InsightViewSeries
+	byQuery map[string][]types.InsightViewSeries
+	byLabel map[string][]types.InsightViewSeries
+}
+
+func newSeriesMatcher(viewSeries []types.InsightViewSeries) *seriesMatcher {
+	m := &seriesMatcher{
+		byID:    map[string]types.InsightViewSeries{},
+		byQuery: map[string][]types.InsightViewSeries{},
+		byLabel: map[string][]types.InsightViewSeries{},
+	}
+	for _, s := range viewSeries {
+		m.byID[s.SeriesID] = s
+		m.byQuery[s.Query] = append(m.byQuery[s.Query], s)
+		m.byLabel[s.Label] = append(m.byLabel[s.Label], s)
+	}
+	return m
+}
+
+func (m *seriesMatcher) match(p dataPoint) (types.InsightViewSeries, error) {
+	if s, ok := m.byID[p.SeriesID]; ok {
+		return s, nil
+	}
+	for _, candidates := range [][]types.InsightViewSeries{m.byQuery[p.Query], m.byLabel[p.Label]} {
+		if len(candidates) == 1 {
+			return candidates[0], nil
+		}
+	}
+	return types.InsightViewSeries{}, &importDataError{err: errors.Newf("no unique series of the insight matches the data point of series %q with query %q and label %q", p.SeriesID, p.Query, p.Label)}
+}
+
+type dataImport struct {
+	store   *store.Store
+	repos   database.RepoStore
+	series  *seriesMatcher
+	repoIDs map[string]api.RepoID
+
+	// seenTimes are the recording times of every imported series.
+	seenTimes map[string]map[time.Time]struct{}
+	// seenPoints are the imported points of every series. The recorded points of a series, repository and time are
+	// replaced the first time they are seen, so that points imported by an earlier batch are not deleted again.
+	seenPoints map[seenPoint]struct{}
+	seriesByID map[string]types.InsightViewSeries
+
+	batch  []importedPoint
+	result importResult
+}
+
+type importedPoint struct {
+	seriesID string
+	dataPoint
+}
+
+// seenPoint identifies the points of a series recorded for a repository at a time. The repository ID is 0 for points
+// that are not associated with a repository.
+type seenPoint struct {
+	seriesID string
+	time     time.Time
+	repoID   api.RepoID
+}
+
+func (i *dataImport) add(ctx context.Context, p dataPoint) error {
+	// snapshots are recomputed regularly and are not part of the history of a series
+	if p.Snapshot {
+		i.result.Skipped++
+		return nil
+	}
+	series, err := i.series.match(p)
+	if err != nil {
+		return err
+	}
+	i.seriesByID[series.SeriesID] = series
+	i.batch = append(i.batch, importedPoint{seriesID: series.SeriesID, dataPoint: p})
+	if len(i.batch) >= importBatchSize {
+		return i.flush(ctx)
+	}
+	return nil
+}
+
+func (i *dataImport) flush(ctx context.Context) (err error) {
+	if len(i.batch) == 0 {
+		return nil
+	}
+	batch := i.batch
+	i.batch = nil
+
+	if err := i.resolveRepos(ctx, batch); err != nil {
+		return err
+	}
+
+	tx, err := i.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	newKeys := map[string][]store.SeriesPointKey{}
+	points := make([]store.RecordSeriesPointArgs, 0, len(batch))
+	skipped := 0
+	for _, p := range batch {
+		times, ok := i.seenTimes[p.seriesID]
+		if !ok {
+			times = map[time.Time]struct{}{}
+			i.seenTimes[p.seriesID] = times
+		}
+		times[p.RecordingTime] = struct{}{}
+
+		// points without a repository are the totals of series that are not broken down by repository
+		var repoName *string
+		var repoID *api.RepoID
+		if p.Repository != nil {
+			id, ok := i.repoIDs[*p.Repository]
+			if !ok {
+				skipped++
+				continue
+			}
+			name := *p.Repository
+			repoName, repoID = &name, &id
+		}
+
+		key := seenPoint{seriesID: p.seriesID, time: p.RecordingTime}
+		if repoID != nil {
+			key.repoID = *repoID
+		}
+		if _, ok := i.seenPoints[key]; !ok {
+			i.seenPoints[key] = struct{}{}
+			newKeys[p.seriesID] = append(newKeys[p.seriesID], store.SeriesPointKey{Time: p.RecordingTime, RepoID: repoID})
+		}
+
+		points = append(points, store.RecordSeriesPointArgs{
+			SeriesID: p.seriesID,
+			Point: store.SeriesPoint{
+				SeriesID: p.seriesID,
+				Time:     p.RecordingTime,
+				Value:    float64(p.Value),
+				Capture:  p.Capture,
+			},
+			RepoName:    repoName,
+			RepoID:      repoID,
+			PersistMode: store.RecordMode,
+		})
+	}
+	for seriesID, keys := range newKeys {
+		if err := tx.DeleteSeriesPointsAt(ctx, seriesID, keys); err != nil {
+			return err
+		}
+	}
+	if err := tx.RecordSeriesPoints(ctx, points); err != nil {
+		return errors.Wrap(err, "RecordSeriesPoints")
+	}
+	i.result.Imported += len(points)
+	i.result.Skipped += skipped
+	return nil
+}
+
+// resolveRepos looks up the IDs of the repositories of the batch that were not looked up yet. Repositories that no
+// longer exist are skipped.
+func (i *dataImport) resolveRepos(ctx context.Context, batch []importedPoint) error {
+	var names []string
+	for _, p := range batch {
+		if p.Repository == nil {
+			continue
+		}
+		if _, ok := i.repoIDs[*p.Repository]; ok {
+			continue
+		}
+		// mark the repository as looked up, it is removed again if it does not exist
+		i.repoIDs[*p.Repository] = 0
+		names = append(names, *p.Repository)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	repos, err := i.repos.List(ctx, database.ReposListOptions{Names: names})
+	if err != nil {
+		return errors.Wrap(err, "listing repositories")
+	}
+	for _, name := range names {
+		delete(i.repoIDs, name)
+	}
+	for _, repo := range repos {
+		i.repoIDs[string(repo.Name)] = repo.ID
+	}
+	return nil
+}
+
+// finish records the imported recording times and completes the backfill of the imported series.
+func (i *dataImport) finish(ctx context.Context, tx *store.Store, insightStore *store.InsightStore, backfillStore *scheduler.BackfillStore) error {
+	now := time.Now()
+	recordingTimes := make([]types.InsightSeriesRecordingTimes, 0, len(i.seenTimes))
+	for seriesID, times := range i.seenTimes {
+		series := i.seriesByID[seriesID]
+		rt := types.InsightSeriesRecordingTimes{InsightSeriesID: series.InsightSeriesID}
+		for t := range times {
+			rt.RecordingTimes = append(rt.RecordingTimes, types.RecordingTime{Timestamp: t})
+		}
+		recordingTimes = append(recordingTimes, rt)
+
+		backfills, err := backfillStore.LoadSeriesBackfills(ctx, series.InsightSeriesID)
+		if err != nil {
+			return errors.Wrap(err, "LoadSeriesBackfills")
+		}
+		for _, backfill := range backfills {
+			if backfill.IsTerminalState() {
+				continue
+			}
+			if err := backfill.SetCompleted(ctx, backfillStore); err != nil {
+				return errors.Wrap(err, "SetCompleted")
+			}
+		}
+		if err := insightStore.SetSeriesBackfillComplete(ctx, seriesID, now); err != nil {
+			return errors.Wrap(err, "SetSeriesBackfillComplete")
+		}
+	}
+	if err := tx.SetInsightSeriesRecordingTimes(ctx, recordingTimes); err != nil {
+		return errors.Wrap(err, "SetInsightSeriesRecordingTimes")
+	}
+	i.result.Series = len(i.seenTimes)
+	return nil
+}
@@ -0,0 +1,83 @@
+package httpapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	insightstypes "github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestDataImport_KeepsPointsOfOtherRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	insightsDB := database.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	insightsStore := store.New(insightsDB, store.NewInsightPermissionStore(postgres))
+
+	const seriesID = "series"
+	recordingTime := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+	point := func(repoName *string, repoID *api.RepoID, value float64) store.RecordSeriesPointArgs {
+		return store.RecordSeriesPointArgs{
+			SeriesID:    seriesID,
+			Point:       store.SeriesPoint{SeriesID: seriesID, Time: recordingTime, Value: value},
+			RepoName:    repoName,
+			RepoID:      repoID,
+			PersistMode: store.RecordMode,
+		}
+	}
+	require.NoError(t, insightsStore.RecordSeriesPoints(ctx, []store.RecordSeriesPointArgs{
+		point(pointers.Ptr("repo-a"), pointers.Ptr(api.RepoID(1)), 1),
+		point(pointers.Ptr("repo-b"), pointers.Ptr(api.RepoID(2)), 2),
+		point(nil, nil, 3),
+	}))
+
+	repos := database.NewMockRepoStore()
+	repos.ListFunc.SetDefaultReturn([]*types.Repo{{ID: 1, Name: "repo-a"}, {ID: 2, Name: "repo-b"}}, nil)
+	imp := &dataImport{
+		store:      insightsStore,
+		repos:      repos,
+		series:     newSeriesMatcher([]insightstypes.InsightViewSeries{{SeriesID: seriesID}}),
+		repoIDs:    map[string]api.RepoID{},
+		seenTimes:  map[string]map[time.Time]struct{}{},
+		seenPoints: map[seenPoint]struct{}{},
+		seriesByID: map[string]insightstypes.InsightViewSeries{},
+	}
+
+	// An export that was filtered down to repo-a, plus the point without a repository.
+	for _, p := range []dataPoint{
+		{SeriesID: seriesID, RecordingTime: recordingTime, Repository: pointers.Ptr("repo-a"), Value: 10},
+		{SeriesID: seriesID, RecordingTime: recordingTime, Value: 30},
+	} {
+		require.NoError(t, imp.add(ctx, p))
+	}
+	require.NoError(t, imp.flush(ctx))
+	assert.Equal(t, importResult{Imported: 2}, imp.result)
+
+	rows, err := insightsDB.QueryContext(ctx, `SELECT COALESCE(repo_id, 0), value FROM series_points WHERE series_id = $1`, seriesID)
+	require.NoError(t, err)
+	defer rows.Close()
+	values := map[api.RepoID][]float64{}
+	for rows.Next() {
+		var repoID api.RepoID
+		var value float64
+		require.NoError(t, rows.Scan(&repoID, &value))
+		values[repoID] = append(values[repoID], value)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, map[api.RepoID][]float64{1: {10}, 2: {2}, 0: {30}}, values)
+}
@@ -40,6 +40,7 @@ func Init(
 	}
 	enterpriseServices.InsightsResolver = resolvers.New(rawInsightsDB, db)
 	enterpriseServices.CodeInsightsDataExportHandler = httpapi.NewExportHandler(db, rawInsightsDB).ExportFunc()
+	enterpriseServices.CodeInsightsDataImportHandler = httpapi.NewImportHandler(db, rawInsightsDB).ImportFunc()
 
 	return nil
 }
@@ -0,0 +1,189 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const insightAlertRuleKind = "InsightAlertRule"
+
+func (i *insightViewResolver) AlertRules(ctx context.Context) ([]graphqlbackend.InsightAlertRuleResolver, error) {
+	rules, err := i.alertRuleStore.GetAlertRules(ctx, store.AlertRuleQueryArgs{ViewUniqueID: &i.view.UniqueID})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAlertRules")
+	}
+	resolvers := make([]graphqlbackend.InsightAlertRuleResolver, 0, len(rules))
+	for _, rule := range rules {
+		resolvers = append(resolvers, &insightAlertRuleResolver{rule: rule})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightAlertRule(ctx context.Context, args *graphqlbackend.CreateInsightAlertRuleArgs) (graphqlbackend.InsightAlertRuleResolver, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	var viewID string
+	if err := relay.UnmarshalSpec(args.Input.InsightViewId, &viewID); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the insight view id")
+	}
+	// 🚨 SECURITY: only users that can see an insight can alert on its series.
+	if err := PermissionsValidatorFromBase(&r.baseInsightResolver).validateUserAccessForView(ctx, viewID); err != nil {
+		return nil, err
+	}
+
+	rule, err := alertRuleFromInput(args.Input)
+	if err != nil {
+		return nil, err
+	}
+	rule.ViewUniqueID = viewID
+	rule.CreatedBy = a.UID
+
+	created, err := r.alertRuleStore.CreateAlertRule(ctx, rule)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAlertRule")
+	}
+	return &insightAlertRuleResolver{rule: created}, nil
+}
+
+func (r *Resolver) DeleteInsightAlertRule(ctx context.Context, args *graphqlbackend.DeleteInsightAlertRuleArgs) (*graphqlbackend.EmptyResponse, error) {
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the alert rule id")
+	}
+	rules, err := r.alertRuleStore.GetAlertRules(ctx, store.AlertRuleQueryArgs{ID: &id})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAlertRules")
+	}
+	if len(rules) == 0 {
+		return nil, errors.New("alert rule not found")
+	}
+	// 🚨 SECURITY: alert rules can be managed by anyone that can see their insight, like the insight itself.
+	if err := PermissionsValidatorFromBase(&r.baseInsightResolver).validateUserAccessForView(ctx, rules[0].ViewUniqueID); err != nil {
+		return nil, err
+	}
+
+	if err := r.alertRuleStore.DeleteAlertRule(ctx, id); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func alertRuleFromInput(input graphqlbackend.CreateInsightAlertRuleInput) (types.AlertRule, error) {
+	rule := types.AlertRule{
+		SeriesID:        input.SeriesId,
+		Capture:         input.Capture,
+		Kind:            types.AlertRuleKind(input.Kind),
+		Comparison:      types.AlertComparison(input.Comparison),
+		Threshold:       input.Threshold,
+		Intervals:       1,
+		Description:     strings.TrimSpace(input.Description),
+		Enabled:         true,
+		Email:           input.Email != nil && *input.Email,
+		SlackWebhookURL: input.SlackWebhookURL,
+		WebhookURL:      input.WebhookURL,
+	}
+
+	if rule.Description == "" {
+		return rule, errors.New("an alert rule requires a description")
+	}
+	if input.Intervals != nil {
+		if rule.Kind != types.AlertOnPercentChange {
+			return rule, errors.New("intervals can only be set on PERCENT_CHANGE alert rules")
+		}
+		if *input.Intervals < 1 {
+			return rule, errors.New("intervals must be at least 1")
+		}
+		rule.Intervals = int(*input.Intervals)
+	}
+	if !rule.Email && rule.SlackWebhookURL == nil && rule.WebhookURL == nil {
+		return rule, errors.New("an alert rule requires at least one of email, slackWebhookURL or webhookURL")
+	}
+	for _, u := range []*string{rule.SlackWebhookURL, rule.WebhookURL} {
+		if u == nil {
+			continue
+		}
+		if parsed, err := url.Parse(*u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return rule, errors.Newf("invalid webhook URL %q", *u)
+		}
+	}
+	return rule, nil
+}
+
+type insightAlertRuleResolver struct {
+	rule *types.AlertRule
+}
+
+func (r *insightAlertRuleResolver) ID() graphql.ID {
+	return relay.MarshalID(insightAlertRuleKind, r.rule.ID)
+}
+
+func (r *insightAlertRuleResolver) SeriesId() string {
+	return r.rule.SeriesID
+}
+
+func (r *insightAlertRuleResolver) Capture() *string {
+	return r.rule.Capture
+}
+
+func (r *insightAlertRuleResolver) Kind() string {
+	return string(r.rule.Kind)
+}
+
+func (r *insightAlertRuleResolver) Comparison() string {
+	return string(r.rule.Comparison)
+}
+
+func (r *insightAlertRuleResolver) Threshold() float64 {
+	return r.rule.Threshold
+}
+
+func (r *insightAlertRuleResolver) Intervals() int32 {
+	return int32(r.rule.Intervals)
+}
+
+func (r *insightAlertRuleResolver) Description() string {
+	return r.rule.Description
+}
+
+func (r *insightAlertRuleResolver) Email() bool {
+	return r.rule.Email
+}
+
+func (r *insightAlertRuleResolver) Firing() bool {
+	return r.rule.Firing
+}
+
+func (r *insightAlertRuleResolver) LastValue() *float64 {
+	return r.rule.LastValue
+}
+
+func (r *insightAlertRuleResolver) WebhookURL() *string {
+	return r.rule.WebhookURL
+}
+
+func (r *insightAlertRuleResolver) SlackWebhookURL() *string {
+	return r.rule.SlackWebhookURL
+}
+
+func (r *insightAlertRuleResolver) LastEvaluatedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.rule.LastEvaluatedAt)
+}
+
+func (r *insightAlertRuleResolver) LastFiredAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.rule.LastFiredAt)
+}
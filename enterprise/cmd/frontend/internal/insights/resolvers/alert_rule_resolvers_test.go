@@ -0,0 +1,81 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestAlertRuleFromInput(t *testing.T) {
+	valid := func() graphqlbackend.CreateInsightAlertRuleInput {
+		return graphqlbackend.CreateInsightAlertRuleInput{
+			SeriesId:    "series",
+			Kind:        string(types.AlertOnPercentChange),
+			Comparison:  string(types.Above),
+			Threshold:   -1,
+			Intervals:   pointers.Ptr(int32(4)),
+			Description: " deprecated API usages stopped decreasing ",
+			Email:       pointers.Ptr(true),
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		rule, err := alertRuleFromInput(valid())
+		require.NoError(t, err)
+		require.Equal(t, types.AlertRule{
+			SeriesID:    "series",
+			Kind:        types.AlertOnPercentChange,
+			Comparison:  types.Above,
+			Threshold:   -1,
+			Intervals:   4,
+			Description: "deprecated API usages stopped decreasing",
+			Enabled:     true,
+			Email:       true,
+		}, rule)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*graphqlbackend.CreateInsightAlertRuleInput)
+		err    string
+	}{
+		{
+			name:   "no description",
+			modify: func(in *graphqlbackend.CreateInsightAlertRuleInput) { in.Description = " " },
+			err:    "an alert rule requires a description",
+		},
+		{
+			name: "intervals on value rule",
+			modify: func(in *graphqlbackend.CreateInsightAlertRuleInput) {
+				in.Kind = string(types.AlertOnValue)
+			},
+			err: "intervals can only be set on PERCENT_CHANGE alert rules",
+		},
+		{
+			name:   "zero intervals",
+			modify: func(in *graphqlbackend.CreateInsightAlertRuleInput) { in.Intervals = pointers.Ptr(int32(0)) },
+			err:    "intervals must be at least 1",
+		},
+		{
+			name:   "no notification",
+			modify: func(in *graphqlbackend.CreateInsightAlertRuleInput) { in.Email = nil },
+			err:    "an alert rule requires at least one of email, slackWebhookURL or webhookURL",
+		},
+		{
+			name:   "invalid webhook URL",
+			modify: func(in *graphqlbackend.CreateInsightAlertRuleInput) { in.WebhookURL = pointers.Ptr("ftp://example.com") },
+			err:    `invalid webhook URL "ftp://example.com"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := valid()
+			tc.modify(&input)
+			_, err := alertRuleFromInput(input)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -40,6 +40,14 @@ func (r *disabledResolver) RemoveInsightViewFromDashboard(ctx context.Context, a
 	return nil, errors.New(r.reason)
 }
 
+func (r *disabledResolver) CreateInsightAlertRule(ctx context.Context, args *graphqlbackend.CreateInsightAlertRuleArgs) (graphqlbackend.InsightAlertRuleResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) DeleteInsightAlertRule(ctx context.Context, args *graphqlbackend.DeleteInsightAlertRuleArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
+
 func (r *disabledResolver) UpdateInsightSeries(ctx context.Context, args *graphqlbackend.UpdateInsightSeriesArgs) (graphqlbackend.InsightSeriesMetadataPayloadResolver, error) {
 	return nil, errors.New(r.reason)
 }
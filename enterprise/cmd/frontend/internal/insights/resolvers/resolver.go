@@ -33,6 +33,7 @@ type baseInsightResolver struct {
 	insightStore    *store.InsightStore
 	timeSeriesStore *store.Store
 	dashboardStore  *store.DBDashboardStore
+	alertRuleStore  *store.DBAlertRuleStore
 	workerBaseStore *basestore.Store
 	scheduler       *scheduler.Scheduler
 
@@ -52,6 +53,7 @@ func WithBase(insightsDB edb.InsightsDB, primaryDB database.DB, clock func() tim
 		insightStore:    insightStore,
 		timeSeriesStore: timeSeriesStore,
 		dashboardStore:  dashboardStore,
+		alertRuleStore:  store.NewAlertRuleStore(insightsDB),
 		workerBaseStore: workerBaseStore,
 		scheduler:       insightsScheduler,
 		insightsDB:      insightsDB,
@@ -0,0 +1,63 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/own/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func computeBlameOwnershipSignals(ctx context.Context, db database.DB, path string, repoID api.RepoID) ([]reasonAndReference, error) {
+	enabled, err := db.OwnSignalConfigurations().IsEnabled(ctx, types.SignalBlameOwnership)
+	if err != nil {
+		return nil, errors.Wrap(err, "IsEnabled")
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	blameOwners, err := db.BlameOwnershipSignals().FindBlameOwners(ctx, repoID, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindBlameOwners")
+	}
+
+	var rrs []reasonAndReference
+	for _, o := range blameOwners {
+		if o.Score < own.MinBlameOwnershipScore {
+			continue
+		}
+		rrs = append(rrs, reasonAndReference{
+			reason: ownershipReason{blameLinesCount: o.LinesCount, blameScore: o.Score},
+			reference: own.Reference{
+				// Just use the email.
+				Email: o.AuthorEmail,
+			},
+		})
+	}
+	return rrs, nil
+}
+
+type blameOwnershipSignal struct {
+	linesCount int32
+	score      float64
+}
+
+func (g *blameOwnershipSignal) Title() (string, error) {
+	return "blame", nil
+}
+
+func (g *blameOwnershipSignal) Description() (string, error) {
+	return fmt.Sprintf("Associated because they last changed %d of the current lines, which is %.0f%% when weighting recent changes higher.", g.linesCount, g.score*100), nil
+}
+
+func (g *blameOwnershipSignal) LinesCount() int32 {
+	return g.linesCount
+}
+
+func (g *blameOwnershipSignal) Score() float64 {
+	return g.score
+}
@@ -0,0 +1,139 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	_ graphqlbackend.CodeownersValidationResolver = &codeownersValidationResolver{}
+	_ graphqlbackend.CodeownersIssueResolver      = &codeownersIssueResolver{}
+)
+
+// GitCommitCodeownersValidation checks the CODEOWNERS file of the repository at
+// given commit. It returns nil if there is no CODEOWNERS file.
+func (r *ownResolver) GitCommitCodeownersValidation(
+	ctx context.Context,
+	commit *graphqlbackend.GitCommitResolver,
+	args graphqlbackend.CodeownersValidationArgs,
+) (graphqlbackend.CodeownersValidationResolver, error) {
+	if commit == nil {
+		return nil, errors.New("cannot resolve git commit")
+	}
+	repo := commit.Repository()
+	validation, err := r.ownService().ValidateCodeowners(ctx, repo.RepoName(), repo.IDInt32(), api.CommitID(commit.OID()))
+	if err != nil || validation == nil {
+		return nil, err
+	}
+	var kinds collections.Set[own.CodeownersIssueKind]
+	if args.Kinds != nil {
+		kinds = collections.NewSet[own.CodeownersIssueKind]()
+		for _, k := range *args.Kinds {
+			kinds.Add(own.CodeownersIssueKind(k))
+		}
+	}
+	var issues []own.CodeownersIssue
+	for _, issue := range validation.Issues {
+		if kinds == nil || kinds.Has(issue.Kind) {
+			issues = append(issues, issue)
+		}
+	}
+	return &codeownersValidationResolver{
+		file: &codeownersFileEntryResolver{
+			db:              r.db,
+			gitserverClient: r.gitserver,
+			source:          validation.Ruleset.GetSource(),
+			repo:            repo,
+		},
+		validation: validation,
+		issues:     issues,
+	}, nil
+}
+
+type codeownersValidationResolver struct {
+	file       *codeownersFileEntryResolver
+	validation *own.CodeownersValidation
+	issues     []own.CodeownersIssue
+}
+
+func (r *codeownersValidationResolver) CodeownersFile(ctx context.Context) (graphqlbackend.FileResolver, error) {
+	return r.file.CodeownersFile(ctx)
+}
+
+func (r *codeownersValidationResolver) TotalFiles() int32 {
+	return int32(r.validation.TotalFiles)
+}
+
+func (r *codeownersValidationResolver) UnownedFilesCount() int32 {
+	return int32(r.validation.UnownedFilesCount)
+}
+
+func (r *codeownersValidationResolver) TotalCount() int32 {
+	return int32(len(r.issues))
+}
+
+func (r *codeownersValidationResolver) Issues() []graphqlbackend.CodeownersIssueResolver {
+	rs := make([]graphqlbackend.CodeownersIssueResolver, 0, len(r.issues))
+	for _, issue := range r.issues {
+		rs = append(rs, &codeownersIssueResolver{issue: issue})
+	}
+	return rs
+}
+
+type codeownersIssueResolver struct {
+	issue own.CodeownersIssue
+}
+
+func (r *codeownersIssueResolver) Kind() string {
+	return string(r.issue.Kind)
+}
+
+func (r *codeownersIssueResolver) Message() string {
+	return r.issue.Message()
+}
+
+func (r *codeownersIssueResolver) LineNumber() *int32 {
+	if r.issue.Rule == nil {
+		return nil
+	}
+	l := r.issue.Rule.GetLineNumber()
+	return &l
+}
+
+func (r *codeownersIssueResolver) Pattern() *string {
+	if r.issue.Rule == nil {
+		return nil
+	}
+	p := r.issue.Rule.GetPattern()
+	return &p
+}
+
+func (r *codeownersIssueResolver) Owner() *string {
+	if r.issue.Kind != own.UnresolvedOwner {
+		return nil
+	}
+	return &r.issue.Owner
+}
+
+func (r *codeownersIssueResolver) Path() *string {
+	if r.issue.Kind != own.UnownedFile {
+		return nil
+	}
+	return &r.issue.Path
+}
+
+func (r *codeownersIssueResolver) ShadowedBy() *[]int32 {
+	if r.issue.Kind != own.ShadowedRule {
+		return nil
+	}
+	lines := make([]int32, 0, len(r.issue.ShadowedBy))
+	for _, rule := range r.issue.ShadowedBy {
+		lines = append(lines, rule.GetLineNumber())
+	}
+	return &lines
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/database/fakedb"
@@ -54,6 +55,8 @@ type fakeOwnService struct {
 	Ruleset        *codeowners.Ruleset
 	AssignedOwners own.AssignedOwners
 	Teams          own.AssignedTeams
+	Blame          own.BlameOwners
+	Validation     *own.CodeownersValidation
 }
 
 func (s fakeOwnService) RulesetForRepo(context.Context, api.RepoName, api.RepoID, api.CommitID) (*codeowners.Ruleset, error) {
@@ -82,6 +85,18 @@ func (s fakeOwnService) AssignedTeams(context.Context, api.RepoID, api.CommitID)
 	return s.Teams, nil
 }
 
+func (s fakeOwnService) BlameOwnership(context.Context, api.RepoID, api.CommitID) (own.BlameOwners, error) {
+	return s.Blame, nil
+}
+
+func (s fakeOwnService) ValidateCodeowners(context.Context, api.RepoName, api.RepoID, api.CommitID) (*own.CodeownersValidation, error) {
+	return s.Validation, nil
+}
+
+func (s fakeOwnService) UnresolvedOwners(context.Context, *codeowners.Ruleset, api.RepoName) (collections.Set[string], error) {
+	return collections.NewSet[string](), nil
+}
+
 // fakeGitServer is a limited gitserver.Client that returns a file for every Stat call.
 type fakeGitserver struct {
 	gitserver.Client
@@ -99,6 +114,7 @@ func fakeOwnDb() *database.MockDB {
 	db.RecentContributionSignalsFunc.SetDefaultReturn(database.NewMockRecentContributionSignalStore())
 	db.RecentViewSignalFunc.SetDefaultReturn(database.NewMockRecentViewSignalStore())
 	db.AssignedOwnersFunc.SetDefaultReturn(database.NewMockAssignedOwnersStore())
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(database.NewMockBlameOwnershipSignalStore())
 
 	configStore := database.NewMockSignalConfigurationStore()
 	configStore.IsEnabledFunc.SetDefaultReturn(true, nil)
@@ -932,6 +948,209 @@ func TestOwnership_WithSignals(t *testing.T) {
 	})
 }
 
+func TestOwnership_WithBlameSignal(t *testing.T) {
+	logger := logtest.Scoped(t)
+	fakeDB := fakedb.New()
+	db := fakeOwnDb()
+
+	blameStore := database.NewMockBlameOwnershipSignalStore()
+	blameStore.FindBlameOwnersFunc.SetDefaultHook(func(_ context.Context, _ api.RepoID, path string) ([]database.BlameOwnerSummary, error) {
+		return []database.BlameOwnerSummary{
+			{FilePath: path, AuthorName: santaName, AuthorEmail: santaEmail, LinesCount: 42, Score: 0.75},
+			// Below the threshold, so not considered an owner.
+			{FilePath: path, AuthorName: "elf", AuthorEmail: "elf@northpole.com", LinesCount: 3, Score: 0.05},
+		}, nil
+	})
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(blameStore)
+	db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+	db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
+
+	fakeDB.Wire(db)
+	repoID := api.RepoID(1)
+	ctx := userCtx(fakeDB.AddUser(types.User{SiteAdmin: true}))
+	repos := database.NewMockRepoStore()
+	db.ReposFunc.SetDefaultReturn(repos)
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: repoID, Name: "github.com/sourcegraph/own"}, nil)
+	backend.Mocks.Repos.ResolveRev = func(_ context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return "deadbeef", nil
+	}
+	git := fakeGitserver{}
+	schema, err := graphqlbackend.NewSchema(db, git, []graphqlbackend.OptionalResolver{{OwnResolver: resolvers.NewWithService(db, git, fakeOwnService{}, logger)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graphqlbackend.RunTest(t, &graphqlbackend.Test{
+		Schema:  schema,
+		Context: ctx,
+		Query: `
+			query FetchOwnership($repo: ID!, $revision: String!, $currentPath: String!) {
+				node(id: $repo) {
+					... on Repository {
+						commit(rev: $revision) {
+							blob(path: $currentPath) {
+								ownership(reasons: [BLAME_OWNERSHIP_SIGNAL]) {
+									totalCount
+									nodes {
+										owner {
+											...on Person {
+												email
+											}
+										}
+										reasons {
+											...on BlameOwnershipSignal {
+												title
+												description
+												linesCount
+												score
+											}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}`,
+		ExpectedResult: `{
+			"node": {
+				"commit": {
+					"blob": {
+						"ownership": {
+							"totalCount": 1,
+							"nodes": [
+								{
+									"owner": {
+										"email": "santa@northpole.com"
+									},
+									"reasons": [
+										{
+											"title": "blame",
+											"description": "Associated because they last changed 42 of the current lines, which is 75% when weighting recent changes higher.",
+											"linesCount": 42,
+											"score": 0.75
+										}
+									]
+								}
+							]
+						}
+					}
+				}
+			}
+		}`,
+		Variables: map[string]any{
+			"repo":        string(graphqlbackend.MarshalRepositoryID(repoID)),
+			"revision":    "revision",
+			"currentPath": "foo/bar.js",
+		},
+	})
+}
+
+func TestCommitCodeownersValidation(t *testing.T) {
+	logger := logtest.Scoped(t)
+	fakeDB := fakedb.New()
+	db := fakeOwnDb()
+	fakeDB.Wire(db)
+	repoID := api.RepoID(1)
+	deadRule := &codeownerspb.Rule{Pattern: "*.rs", Owner: []*codeownerspb.Owner{{Handle: "ghost"}}, LineNumber: 1}
+	jsRule := &codeownerspb.Rule{Pattern: "*.js", Owner: []*codeownerspb.Owner{{Handle: "js-owner"}}, LineNumber: 2}
+	ruleset := codeowners.NewRuleset(
+		codeowners.IngestedRulesetSource{ID: int32(repoID)},
+		&codeownerspb.File{Rule: []*codeownerspb.Rule{deadRule, jsRule}},
+	)
+	ownService := fakeOwnService{
+		Ruleset: ruleset,
+		Validation: &own.CodeownersValidation{
+			Ruleset:           ruleset,
+			TotalFiles:        2,
+			UnownedFilesCount: 1,
+			Issues: []own.CodeownersIssue{
+				{Kind: own.UnresolvedOwner, Rule: deadRule, Owner: "@ghost"},
+				{Kind: own.DeadPattern, Rule: deadRule},
+				{Kind: own.UnownedFile, Path: "README.md"},
+			},
+		},
+	}
+	ctx := userCtx(fakeDB.AddUser(types.User{SiteAdmin: true}))
+	repos := database.NewMockRepoStore()
+	db.ReposFunc.SetDefaultReturn(repos)
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: repoID, Name: "github.com/sourcegraph/own"}, nil)
+	backend.Mocks.Repos.ResolveRev = func(_ context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return "deadbeef", nil
+	}
+	git := fakeGitserver{}
+	schema, err := graphqlbackend.NewSchema(db, git, []graphqlbackend.OptionalResolver{{OwnResolver: resolvers.NewWithService(db, git, ownService, logger)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graphqlbackend.RunTest(t, &graphqlbackend.Test{
+		Schema:  schema,
+		Context: ctx,
+		Query: `
+			query CodeownersValidation($repo: ID!, $revision: String!) {
+				node(id: $repo) {
+					... on Repository {
+						commit(rev: $revision) {
+							codeownersValidation(kinds: [UNRESOLVED_OWNER, UNOWNED_FILE]) {
+								codeownersFile {
+									path
+								}
+								totalFiles
+								unownedFilesCount
+								totalCount
+								issues {
+									kind
+									message
+									lineNumber
+									pattern
+									owner
+									path
+								}
+							}
+						}
+					}
+				}
+			}`,
+		ExpectedResult: `{
+			"node": {
+				"commit": {
+					"codeownersValidation": {
+						"codeownersFile": {
+							"path": "CODEOWNERS"
+						},
+						"totalFiles": 2,
+						"unownedFilesCount": 1,
+						"totalCount": 2,
+						"issues": [
+							{
+								"kind": "UNRESOLVED_OWNER",
+								"message": "Owner @ghost on line 1 does not match any user, team or code host account.",
+								"lineNumber": 1,
+								"pattern": "*.rs",
+								"owner": "@ghost",
+								"path": null
+							},
+							{
+								"kind": "UNOWNED_FILE",
+								"message": "File README.md has no owner.",
+								"lineNumber": null,
+								"pattern": null,
+								"owner": null,
+								"path": "README.md"
+							}
+						]
+					}
+				}
+			}
+		}`,
+		Variables: map[string]any{
+			"repo":     string(graphqlbackend.MarshalRepositoryID(repoID)),
+			"revision": "revision",
+		},
+	})
+}
+
 func TestTreeOwnershipSignals(t *testing.T) {
 	logger := logtest.Scoped(t)
 	fakeDB := fakedb.New()
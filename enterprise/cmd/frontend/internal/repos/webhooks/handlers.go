@@ -2,6 +2,7 @@ package webhooks
 
 import (
 	"context"
+	"strings"
 
 	gh "github.com/google/go-github/v43/github"
 	"github.com/sourcegraph/log"
@@ -9,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/repos/webhooks/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/cloneurls"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -18,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -59,7 +62,7 @@ func (g *GitHubHandler) Register(router *webhooks.Router) {
 }
 
 func (g *GitHubHandler) handlePushEvent(ctx context.Context, db database.DB, payload any) error {
-	return handlePushEvent[*gh.PushEvent](ctx, db, g.logger, payload, gitHubCloneURLFromEvent)
+	return handlePushEvent[*gh.PushEvent](ctx, db, g.logger, payload, gitHubCloneURLFromEvent, gitHubRefsFromEvent)
 }
 
 func gitHubCloneURLFromEvent(event *gh.PushEvent) (string, error) {
@@ -69,6 +72,25 @@ func gitHubCloneURLFromEvent(event *gh.PushEvent) (string, error) {
 	return event.GetRepo().GetCloneURL(), nil
 }
 
+func gitHubRefsFromEvent(event *gh.PushEvent) []gitserverprotocol.RefUpdate {
+	if event.GetRef() == "" {
+		return nil
+	}
+	ref := gitserverprotocol.RefUpdate{
+		Name:   event.GetRef(),
+		Before: pushedCommit(event.GetBefore()),
+		After:  pushedCommit(event.GetAfter()),
+		Forced: event.GetForced(),
+	}
+	if event.GetCreated() {
+		ref.Before = ""
+	}
+	if event.GetDeleted() {
+		ref.After = ""
+	}
+	return []gitserverprotocol.RefUpdate{ref}
+}
+
 type GitLabHandler struct {
 	logger log.Logger
 }
@@ -86,7 +108,7 @@ func (g *GitLabHandler) Register(router *webhooks.Router) {
 }
 
 func (g *GitLabHandler) handlePushEvent(ctx context.Context, db database.DB, payload any) error {
-	return handlePushEvent[*gitlabwebhooks.PushEvent](ctx, db, g.logger, payload, gitLabCloneURLFromEvent)
+	return handlePushEvent[*gitlabwebhooks.PushEvent](ctx, db, g.logger, payload, gitLabCloneURLFromEvent, gitLabRefsFromEvent)
 }
 
 func gitLabCloneURLFromEvent(event *gitlabwebhooks.PushEvent) (string, error) {
@@ -96,6 +118,17 @@ func gitLabCloneURLFromEvent(event *gitlabwebhooks.PushEvent) (string, error) {
 	return event.Repository.GitSSHURL, nil
 }
 
+func gitLabRefsFromEvent(event *gitlabwebhooks.PushEvent) []gitserverprotocol.RefUpdate {
+	if event.Ref == "" {
+		return nil
+	}
+	return []gitserverprotocol.RefUpdate{{
+		Name:   event.Ref,
+		Before: pushedCommit(event.Before),
+		After:  pushedCommit(event.After),
+	}}
+}
+
 type BitbucketServerHandler struct {
 	logger log.Logger
 }
@@ -113,7 +146,7 @@ func (g *BitbucketServerHandler) Register(router *webhooks.Router) {
 }
 
 func (g *BitbucketServerHandler) handlePushEvent(ctx context.Context, db database.DB, payload any) error {
-	return handlePushEvent[*bitbucketserver.PushEvent](ctx, db, g.logger, payload, bitbucketServerCloneURLFromEvent)
+	return handlePushEvent[*bitbucketserver.PushEvent](ctx, db, g.logger, payload, bitbucketServerCloneURLFromEvent, bitbucketServerRefsFromEvent)
 }
 
 func bitbucketServerCloneURLFromEvent(event *bitbucketserver.PushEvent) (string, error) {
@@ -130,6 +163,28 @@ func bitbucketServerCloneURLFromEvent(event *bitbucketserver.PushEvent) (string,
 	return "", errors.New("no ssh URLs found")
 }
 
+func bitbucketServerRefsFromEvent(event *bitbucketserver.PushEvent) []gitserverprotocol.RefUpdate {
+	refs := make([]gitserverprotocol.RefUpdate, 0, len(event.Changes))
+	for _, change := range event.Changes {
+		if change.RefID == "" {
+			return nil
+		}
+		ref := gitserverprotocol.RefUpdate{
+			Name:   change.RefID,
+			Before: pushedCommit(change.FromHash),
+			After:  pushedCommit(change.ToHash),
+		}
+		switch change.Type {
+		case "ADD":
+			ref.Before = ""
+		case "DELETE":
+			ref.After = ""
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
 type BitbucketCloudHandler struct {
 	logger log.Logger
 }
@@ -147,7 +202,7 @@ func (g *BitbucketCloudHandler) Register(router *webhooks.Router) {
 }
 
 func (g *BitbucketCloudHandler) handlePushEvent(ctx context.Context, db database.DB, payload any) error {
-	return handlePushEvent[*bitbucketcloud.PushEvent](ctx, db, g.logger, payload, bitbucketCloudCloneURLFromEvent)
+	return handlePushEvent[*bitbucketcloud.PushEvent](ctx, db, g.logger, payload, bitbucketCloudCloneURLFromEvent, bitbucketCloudRefsFromEvent)
 }
 
 func bitbucketCloudCloneURLFromEvent(event *bitbucketcloud.PushEvent) (string, error) {
@@ -161,10 +216,54 @@ func bitbucketCloudCloneURLFromEvent(event *bitbucketcloud.PushEvent) (string, e
 	return href, nil
 }
 
-// handlePushEvent takes a push payload and a function to extract the repo
-// clone URL from the event. It then uses the clone URL to find a repo and queues
-// a repo update.
-func handlePushEvent[T any](ctx context.Context, db database.DB, logger log.Logger, payload any, cloneURLGetter func(event T) (string, error)) error {
+func bitbucketCloudRefsFromEvent(event *bitbucketcloud.PushEvent) []gitserverprotocol.RefUpdate {
+	refs := make([]gitserverprotocol.RefUpdate, 0, len(event.Push.Changes))
+	for _, change := range event.Push.Changes {
+		var ref gitserverprotocol.RefUpdate
+		for _, r := range []*bitbucketcloud.PushChangeRef{change.Old, change.New} {
+			if r == nil {
+				continue
+			}
+			switch r.Type {
+			case "branch":
+				ref.Name = "refs/heads/" + r.Name
+			case "tag":
+				ref.Name = "refs/tags/" + r.Name
+			default:
+				// Mercurial branches and bookmarks have no Git ref.
+				return nil
+			}
+		}
+		if ref.Name == "" {
+			return nil
+		}
+		if change.Old != nil {
+			ref.Before = pushedCommit(change.Old.Target.Hash)
+		}
+		if change.New != nil {
+			ref.After = pushedCommit(change.New.Target.Hash)
+		}
+		ref.Forced = change.Forced
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// pushedCommit returns the commit of a push payload, or the empty string if it
+// is all zeros, which code hosts use for the commit before a ref was created or
+// after it was deleted.
+func pushedCommit(sha string) api.CommitID {
+	if strings.Trim(sha, "0") == "" {
+		return ""
+	}
+	return api.CommitID(sha)
+}
+
+// handlePushEvent takes a push payload and functions to extract the repo clone
+// URL and the pushed refs from the event. It then uses the clone URL to find a
+// repo and queues an update of the pushed refs. If the pushed refs are unknown,
+// an update of all refs is queued.
+func handlePushEvent[T any](ctx context.Context, db database.DB, logger log.Logger, payload any, cloneURLGetter func(event T) (string, error), refsGetter func(event T) []gitserverprotocol.RefUpdate) error {
 	event, ok := payload.(T)
 	if !ok {
 		return errors.Newf("incorrect event type: %T", payload)
@@ -183,16 +282,17 @@ func handlePushEvent[T any](ctx context.Context, db database.DB, logger log.Logg
 		return errors.New("could not determine repo from CloneURL")
 	}
 
-	resp, err := repoupdater.DefaultClient.EnqueueRepoUpdate(ctx, repoName)
+	refs := refsGetter(event)
+	resp, err := repoupdater.DefaultClient.EnqueueRefsUpdate(ctx, repoName, refs)
 	if err != nil {
 		// Repo not existing on Sourcegraph is fine
 		if errcode.IsNotFound(err) {
 			logger.Warn("push webhook received for unknown repo", log.String("repo", string(repoName)))
 			return nil
 		}
-		return errors.Wrap(err, "handlePushEvent: EnqueueRepoUpdate failed")
+		return errors.Wrap(err, "handlePushEvent: EnqueueRefsUpdate failed")
 	}
 
-	logger.Info("successfully updated", log.String("name", resp.Name))
+	logger.Info("successfully updated", log.String("name", resp.Name), log.Int("refs", len(refs)))
 	return nil
 }
@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	gh "github.com/google/go-github/v43/github"
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -30,6 +31,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	internalgrpc "github.com/sourcegraph/sourcegraph/internal/grpc"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
@@ -215,19 +217,26 @@ func TestGitLabHandler(t *testing.T) {
 	}
 
 	var updateQueued string
-	repoupdater.MockEnqueueRepoUpdate = func(ctx context.Context, repo api.RepoName) (*protocol.RepoUpdateResponse, error) {
+	var refsQueued []gitserverprotocol.RefUpdate
+	repoupdater.MockEnqueueRefsUpdate = func(ctx context.Context, repo api.RepoName, refs []gitserverprotocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
 		updateQueued = string(repo)
+		refsQueued = refs
 		return &protocol.RepoUpdateResponse{
 			ID:   1,
 			Name: string(repo),
 		}, nil
 	}
-	t.Cleanup(func() { repoupdater.MockEnqueueRepoUpdate = nil })
+	t.Cleanup(func() { repoupdater.MockEnqueueRefsUpdate = nil })
 
 	if err := handler.handlePushEvent(context.Background(), db, &payload); err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, repoName, updateQueued)
+	assert.Equal(t, []gitserverprotocol.RefUpdate{{
+		Name:   "refs/heads/master",
+		Before: "65459a05eabb5923245e6b09843c9ea04e53f816",
+		After:  "48ceeed4ca3367e36c979e637914319bef306a5a",
+	}}, refsQueued)
 }
 
 func TestBitbucketServerHandler(t *testing.T) {
@@ -251,19 +260,26 @@ func TestBitbucketServerHandler(t *testing.T) {
 	}
 
 	var updateQueued string
-	repoupdater.MockEnqueueRepoUpdate = func(ctx context.Context, repo api.RepoName) (*protocol.RepoUpdateResponse, error) {
+	var refsQueued []gitserverprotocol.RefUpdate
+	repoupdater.MockEnqueueRefsUpdate = func(ctx context.Context, repo api.RepoName, refs []gitserverprotocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
 		updateQueued = string(repo)
+		refsQueued = refs
 		return &protocol.RepoUpdateResponse{
 			ID:   1,
 			Name: string(repo),
 		}, nil
 	}
-	t.Cleanup(func() { repoupdater.MockEnqueueRepoUpdate = nil })
+	t.Cleanup(func() { repoupdater.MockEnqueueRefsUpdate = nil })
 
 	if err := handler.handlePushEvent(context.Background(), db, &payload); err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, repoName, updateQueued)
+	assert.Equal(t, []gitserverprotocol.RefUpdate{{
+		Name:   "refs/heads/rs/test-branch",
+		Before: "e6dcba6b52fd349279a38d9fa25db894467de609",
+		After:  "4cd5ee68a039032fe8a613879fd73b242592ea6a",
+	}}, refsQueued)
 }
 
 func TestBitbucketCloudHandler(t *testing.T) {
@@ -287,17 +303,89 @@ func TestBitbucketCloudHandler(t *testing.T) {
 	}
 
 	var updateQueued string
-	repoupdater.MockEnqueueRepoUpdate = func(ctx context.Context, repo api.RepoName) (*protocol.RepoUpdateResponse, error) {
+	var refsQueued []gitserverprotocol.RefUpdate
+	repoupdater.MockEnqueueRefsUpdate = func(ctx context.Context, repo api.RepoName, refs []gitserverprotocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
 		updateQueued = string(repo)
+		refsQueued = refs
 		return &protocol.RepoUpdateResponse{
 			ID:   1,
 			Name: string(repo),
 		}, nil
 	}
-	t.Cleanup(func() { repoupdater.MockEnqueueRepoUpdate = nil })
+	t.Cleanup(func() { repoupdater.MockEnqueueRefsUpdate = nil })
 
 	if err := handler.handlePushEvent(context.Background(), db, &payload); err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, repoName, updateQueued)
+	assert.Equal(t, []gitserverprotocol.RefUpdate{{
+		Name:   "refs/heads/rs/push-test",
+		Before: "50e13d91bf02e318063fd0be4077257cec8b51b7",
+		After:  "98550882e23008012dbf4b813cfbb19a4c14982a",
+	}}, refsQueued)
+}
+
+func TestPushEventRefs(t *testing.T) {
+	const (
+		zero   = "0000000000000000000000000000000000000000"
+		before = "e6dcba6b52fd349279a38d9fa25db894467de609"
+		after  = "4cd5ee68a039032fe8a613879fd73b242592ea6a"
+	)
+
+	t.Run("GitHub deleted", func(t *testing.T) {
+		event := &gh.PushEvent{
+			Ref:     gh.String("refs/heads/main"),
+			Before:  gh.String(before),
+			After:   gh.String(zero),
+			Deleted: gh.Bool(true),
+		}
+		assert.Equal(t, []gitserverprotocol.RefUpdate{{Name: "refs/heads/main", Before: before}}, gitHubRefsFromEvent(event))
+	})
+
+	t.Run("GitHub forced", func(t *testing.T) {
+		event := &gh.PushEvent{
+			Ref:    gh.String("refs/heads/main"),
+			Before: gh.String(before),
+			After:  gh.String(after),
+			Forced: gh.Bool(true),
+		}
+		assert.Equal(t, []gitserverprotocol.RefUpdate{{Name: "refs/heads/main", Before: before, After: after, Forced: true}}, gitHubRefsFromEvent(event))
+	})
+
+	t.Run("GitLab created", func(t *testing.T) {
+		event := &gitlabwebhooks.PushEvent{Ref: "refs/tags/v1.0.0", Before: zero, After: after}
+		assert.Equal(t, []gitserverprotocol.RefUpdate{{Name: "refs/tags/v1.0.0", After: after}}, gitLabRefsFromEvent(event))
+	})
+
+	t.Run("Bitbucket Server", func(t *testing.T) {
+		event := &bitbucketserver.PushEvent{Changes: []bitbucketserver.RefChange{
+			{RefID: "refs/heads/added", FromHash: zero, ToHash: after, Type: "ADD"},
+			{RefID: "refs/heads/deleted", FromHash: before, ToHash: zero, Type: "DELETE"},
+		}}
+		assert.Equal(t, []gitserverprotocol.RefUpdate{
+			{Name: "refs/heads/added", After: after},
+			{Name: "refs/heads/deleted", Before: before},
+		}, bitbucketServerRefsFromEvent(event))
+	})
+
+	t.Run("Bitbucket Cloud", func(t *testing.T) {
+		tag := &bitbucketcloud.PushChangeRef{Type: "tag", Name: "v1.0.0"}
+		tag.Target.Hash = after
+		branch := &bitbucketcloud.PushChangeRef{Type: "branch", Name: "main"}
+		branch.Target.Hash = before
+
+		event := &bitbucketcloud.PushEvent{}
+		event.Push.Changes = []bitbucketcloud.PushChange{
+			{New: tag},
+			{Old: branch, Forced: true},
+		}
+		assert.Equal(t, []gitserverprotocol.RefUpdate{
+			{Name: "refs/tags/v1.0.0", After: after},
+			{Name: "refs/heads/main", Before: before, Forced: true},
+		}, bitbucketCloudRefsFromEvent(event))
+
+		// Mercurial branches have no Git refs, so all refs are fetched.
+		event.Push.Changes = []bitbucketcloud.PushChange{{New: &bitbucketcloud.PushChangeRef{Type: "named_branch", Name: "default"}}}
+		assert.Nil(t, bitbucketCloudRefsFromEvent(event))
+	})
 }
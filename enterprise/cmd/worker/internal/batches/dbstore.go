@@ -73,7 +73,7 @@ var initBatchSpecWorkspaceExecutionWorkerStore = memo.NewMemoizedConstructor(fun
 		return nil, err
 	}
 
-	return store.NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx, db.Handle()), nil
+	return store.NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx, db.Handle(), nil), nil
 })
 
 // InitBatchSpecResolutionWorkerStore initializes and returns a dbworker.Store instance for the batch spec workspace resolution worker.
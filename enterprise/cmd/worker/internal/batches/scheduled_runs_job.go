@@ -0,0 +1,41 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/scheduledruns"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type scheduledRunsJob struct{}
+
+func NewScheduledRunsJob() job.Job {
+	return &scheduledRunsJob{}
+}
+
+func (j *scheduledRunsJob) Description() string {
+	return "Re-runs batch specs that include a schedule"
+}
+
+func (j *scheduledRunsJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *scheduledRunsJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	workCtx := actor.WithInternalActor(context.Background())
+
+	bstore, err := InitStore()
+	if err != nil {
+		return nil, err
+	}
+
+	routines := []goroutine.BackgroundRoutine{
+		scheduledruns.NewRunner(workCtx, bstore),
+	}
+
+	return routines, nil
+}
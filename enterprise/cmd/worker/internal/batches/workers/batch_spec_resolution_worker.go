@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/batches/sharedcache"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -21,10 +22,13 @@ func NewBatchSpecResolutionWorker(
 	observationCtx *observation.Context,
 	s *store.Store,
 	workerStore dbworkerstore.Store[*btypes.BatchSpecResolutionJob],
+	sharedCache *sharedcache.Cache,
 ) *workerutil.Worker[*btypes.BatchSpecResolutionJob] {
 	e := &batchSpecWorkspaceCreator{
 		store:  s,
 		logger: log.Scoped("batch-spec-workspace-creator", "The background worker running workspace resolutions for batch changes"),
+
+		sharedCache: sharedCache,
 	}
 
 	options := workerutil.WorkerOptions{
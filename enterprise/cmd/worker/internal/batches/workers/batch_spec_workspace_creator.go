@@ -2,6 +2,8 @@ package workers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
@@ -15,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sharedcache"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store/author"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
@@ -33,6 +36,10 @@ import (
 type batchSpecWorkspaceCreator struct {
 	store  *store.Store
 	logger log.Logger
+
+	// sharedCache is consulted for step results of other users when the
+	// user's own cache has no result for a step. It may be nil.
+	sharedCache *sharedcache.Cache
 }
 
 // HandlerFunc returns a workerutil.HandlerFunc that can be passed to a
@@ -50,6 +57,9 @@ func (r *batchSpecWorkspaceCreator) HandlerFunc() workerutil.HandlerFunc[*btypes
 type stepCacheKey struct {
 	index int
 	key   string
+	// sharedKey is the key of the step in the shared step cache. It is empty
+	// if the step result must not be shared.
+	sharedKey string
 }
 
 type workspaceCacheKey struct {
@@ -189,7 +199,22 @@ func (r *batchSpecWorkspaceCreator) process(
 				return err
 			}
 
-			stepCacheKeys = append(stepCacheKeys, stepCacheKey{index: i, key: rawStepKey})
+			var sharedKey string
+			if sk, ok := key.(cache.SharedKeyer); ok && r.sharedCache.Enabled() {
+				k, shareable, err := sk.SharedKey()
+				if err != nil {
+					return err
+				}
+				if shareable {
+					sharedKey = k
+					if workspace.SharedStepCacheKeys == nil {
+						workspace.SharedStepCacheKeys = make(map[string]string)
+					}
+					workspace.SharedStepCacheKeys[rawStepKey] = sharedKey
+				}
+			}
+
+			stepCacheKeys = append(stepCacheKeys, stepCacheKey{index: i, key: rawStepKey, sharedKey: sharedKey})
 			allStepCacheKeys = append(allStepCacheKeys, rawStepKey)
 		}
 
@@ -240,6 +265,8 @@ func (r *batchSpecWorkspaceCreator) process(
 
 				// Mark the cache entry as used.
 				usedCacheEntries = append(usedCacheEntries, c.ID)
+			} else if res, ok := r.sharedStepResult(ctx, ck); ok {
+				workspace.dbWorkspace.SetStepCacheResult(idx+1, btypes.StepCacheResult{Key: key, Value: res})
 			} else {
 				// Only add cache entries up until we don't have the cache entry
 				// for the previous step anymore.
@@ -336,6 +363,22 @@ func (r *batchSpecWorkspaceCreator) process(
 	return tx.CreateBatchSpecWorkspace(ctx, ws...)
 }
 
+// sharedStepResult looks up the result of the step in the shared step cache.
+func (r *batchSpecWorkspaceCreator) sharedStepResult(ctx context.Context, ck stepCacheKey) (*execution.AfterStepResult, bool) {
+	if ck.sharedKey == "" {
+		return nil, false
+	}
+
+	res, err := r.sharedCache.Get(ctx, ck.sharedKey)
+	if err != nil {
+		// The blob store does not tell missing and unreadable entries apart,
+		// so this is most likely a plain cache miss.
+		r.logger.Debug("no shared step cache result", log.String("key", ck.sharedKey), log.Error(err))
+		return nil, false
+	}
+	return res, true
+}
+
 func listBatchSpecMounts(ctx context.Context, s *store.Store, batchSpecID int64) ([]*btypes.BatchSpecWorkspaceFile, error) {
 	mounts, _, err := s.ListBatchSpecWorkspaceFiles(ctx, store.ListBatchSpecWorkspaceFileOpts{BatchSpecID: batchSpecID})
 	if err != nil {
@@ -360,7 +403,7 @@ func (r *remoteFileMetadataRetriever) Get(steps []batcheslib.Step) ([]cache.Moun
 			var metadata cache.MountMetadata
 			for _, mount := range r.mounts {
 				if filepath.Join(mount.Path, mount.FileName) == mountPath {
-					metadata = cache.MountMetadata{Path: mountPath, Size: mount.Size, Modified: mount.ModifiedAt}
+					metadata = mountMetadata(mountPath, mount)
 				}
 			}
 			if metadata.Path != "" {
@@ -368,7 +411,7 @@ func (r *remoteFileMetadataRetriever) Get(steps []batcheslib.Step) ([]cache.Moun
 			} else {
 				// It is probably a directory
 				for _, mount := range r.mounts {
-					mountsMetadata = append(mountsMetadata, cache.MountMetadata{Path: filepath.Join(mount.Path, mount.FileName), Size: mount.Size, Modified: mount.ModifiedAt})
+					mountsMetadata = append(mountsMetadata, mountMetadata(filepath.Join(mount.Path, mount.FileName), mount))
 				}
 			}
 
@@ -377,6 +420,16 @@ func (r *remoteFileMetadataRetriever) Get(steps []batcheslib.Step) ([]cache.Moun
 	return mountsMetadata, nil
 }
 
+func mountMetadata(path string, mount *btypes.BatchSpecWorkspaceFile) cache.MountMetadata {
+	hash := sha256.Sum256(mount.Content)
+	return cache.MountMetadata{
+		Path:     path,
+		Size:     mount.Size,
+		Modified: mount.ModifiedAt,
+		Hash:     hex.EncodeToString(hash[:]),
+	}
+}
+
 func changesetSpecsForImports(ctx context.Context, s *store.Store, importChangesets []batcheslib.ImportChangeset, batchSpecID int64, userID int32) ([]*btypes.ChangesetSpec, error) {
 	cs := []*btypes.ChangesetSpec{}
 
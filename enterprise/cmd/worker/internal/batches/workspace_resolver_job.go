@@ -6,6 +6,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/batches/workers"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sharedcache"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -22,10 +23,10 @@ func (j *workspaceResolverJob) Description() string {
 }
 
 func (j *workspaceResolverJob) Config() []env.Config {
-	return []env.Config{}
+	return []env.Config{sharedcache.ConfigInst}
 }
 
-func (j *workspaceResolverJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+func (j *workspaceResolverJob) Routines(startupCtx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
 	observationCtx = observation.NewContext(observationCtx.Logger.Scoped("routines", "workspace resolver job routines"))
 	workCtx := actor.WithInternalActor(context.Background())
 
@@ -39,11 +40,17 @@ func (j *workspaceResolverJob) Routines(_ context.Context, observationCtx *obser
 		return nil, err
 	}
 
+	sharedCache, err := sharedcache.New(startupCtx, observationCtx, sharedcache.ConfigInst)
+	if err != nil {
+		return nil, err
+	}
+
 	resolverWorker := workers.NewBatchSpecResolutionWorker(
 		workCtx,
 		observationCtx,
 		bstore,
 		resStore,
+		sharedCache,
 	)
 
 	routines := []goroutine.BackgroundRoutine{
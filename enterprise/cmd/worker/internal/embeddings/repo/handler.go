@@ -197,10 +197,18 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record *bgrepo.
 		log.Object("stats", stats.ToFields()...),
 	)
 
+	var ivfOpts *embeddings.IVFOptions
+	if embeddingsConfig.ApproximateIndex.Enabled {
+		ivfOpts = &embeddings.IVFOptions{MinRows: embeddingsConfig.ApproximateIndex.MinRows}
+	}
+
 	indexName := string(embeddings.GetRepoEmbeddingIndexName(repo.ID))
 	if stats.IsIncremental {
-		return embeddings.UpdateRepoEmbeddingIndex(ctx, h.uploadStore, indexName, previousIndex, repoEmbeddingIndex, toRemove, ranks)
+		return embeddings.UpdateRepoEmbeddingIndex(ctx, h.uploadStore, indexName, previousIndex, repoEmbeddingIndex, toRemove, ranks, ivfOpts)
 	} else {
+		if ivfOpts != nil {
+			repoEmbeddingIndex.BuildIVFIndexes(*ivfOpts)
+		}
 		return embeddings.UploadRepoEmbeddingIndex(ctx, h.uploadStore, indexName, repoEmbeddingIndex)
 	}
 }
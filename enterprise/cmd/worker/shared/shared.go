@@ -41,6 +41,7 @@ var additionalJobs = map[string]job.Job{
 	"batches-reconciler":                    batches.NewReconcilerJob(),
 	"batches-bulk-processor":                batches.NewBulkOperationProcessorJob(),
 	"batches-workspace-resolver":            batches.NewWorkspaceResolverJob(),
+	"batches-scheduled-runs":                batches.NewScheduledRunsJob(),
 	"executors-janitor":                     executors.NewJanitorJob(),
 	"executors-metricsserver":               executors.NewMetricsServerJob(),
 	"executors-multiqueue-metrics-reporter": executormultiqueue.NewMultiqueueMetricsReporterJob(),
@@ -0,0 +1,129 @@
+// Package totp implements time-based one-time passwords (RFC 6238), as generated by authenticator
+// apps such as Google Authenticator, 1Password or Authy.
+//
+// Only the parameters supported by all common authenticator apps are used: HMAC-SHA1, 6 digits and
+// a period of 30 seconds.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// Period is the duration for which a code is valid.
+	Period = 30 * time.Second
+
+	// Digits is the number of digits in a code.
+	Digits = 6
+
+	// Skew is the number of periods before and after the current one whose codes are also
+	// accepted, to tolerate clock drift between the server and the user's device.
+	Skew = 1
+
+	// secretSize is the number of random bytes in a secret, as recommended by RFC 4226 for
+	// HMAC-SHA1.
+	secretSize = 20
+
+	// modulus is 10^Digits.
+	modulus = 1_000_000
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret, encoded in base32 as expected by authenticator apps.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "generating TOTP secret")
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI of the secret, which authenticator apps can import
+// when it is encoded in a QR code.
+//
+// See https://github.com/google/google-authenticator/wiki/Key-Uri-Format.
+func ProvisioningURI(issuer, account, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(Digits))
+	q.Set("period", fmt.Sprint(int(Period.Seconds())))
+	u := url.URL{
+		Scheme: "otpauth",
+		Host:   "totp",
+		Path:   "/" + issuer + ":" + account,
+		// The query parameters are encoded with %20 for spaces, as some apps don't decode "+".
+		RawQuery: strings.ReplaceAll(q.Encode(), "+", "%20"),
+	}
+	return u.String()
+}
+
+// Step returns the time step of t, i.e. the number of periods since the Unix epoch.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// Code returns the code of the secret for the given time step.
+func Code(secret string, step int64) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, step), nil
+}
+
+// Validate reports whether code is the code of the secret at time t, allowing for Skew. If it is,
+// the matching time step is returned, which callers should record to reject replays of the same
+// code.
+func Validate(secret, code string, t time.Time) (step int64, ok bool, err error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false, err
+	}
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != Digits {
+		return 0, false, nil
+	}
+
+	current := Step(t)
+	for s := current - Skew; s <= current+Skew; s++ {
+		if subtle.ConstantTimeCompare([]byte(code), []byte(hotp(key, s))) == 1 {
+			return s, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding TOTP secret")
+	}
+	return key, nil
+}
+
+// hotp implements the HOTP algorithm of RFC 4226 with the time step as counter.
+func hotp(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, value%modulus)
+}
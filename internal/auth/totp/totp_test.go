@@ -0,0 +1,92 @@
+package totp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the base32 encoding of the SHA1 secret "12345678901234567890" of RFC 6238.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// The test vectors of RFC 6238 appendix B, truncated to 6 digits.
+	for unix, want := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		got, err := Code(rfcSecret, Step(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "time %d", unix)
+	}
+
+	_, err := Code("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+
+	for name, tc := range map[string]struct {
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		"current step":      {code: "050471", wantStep: step, wantOK: true},
+		"with spaces":       {code: "050 471", wantStep: step, wantOK: true},
+		"previous step":     {code: mustCode(t, step-1), wantStep: step - 1, wantOK: true},
+		"next step":         {code: mustCode(t, step+1), wantStep: step + 1, wantOK: true},
+		"too old":           {code: mustCode(t, step-2)},
+		"too new":           {code: mustCode(t, step+2)},
+		"wrong code":        {code: "123456"},
+		"too short":         {code: "05047"},
+		"8 digit RFC value": {code: "14050471"},
+		"empty":             {code: ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotStep, ok, err := Validate(rfcSecret, tc.code, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantStep, gotStep)
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	require.NoError(t, err)
+	b, err := GenerateSecret()
+	require.NoError(t, err)
+
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+
+	// The secret round-trips through code generation and validation.
+	now := time.Now()
+	code, err := Code(a, Step(now))
+	require.NoError(t, err)
+	_, ok, err := Validate(a, code, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	assert.Equal(t,
+		"otpauth://totp/ACME%20Sourcegraph:alice@example.com?algorithm=SHA1&digits=6&issuer=ACME%20Sourcegraph&period=30&secret="+rfcSecret,
+		ProvisioningURI("ACME Sourcegraph", "alice@example.com", rfcSecret),
+	)
+}
+
+func mustCode(t *testing.T, step int64) string {
+	t.Helper()
+	code, err := Code(rfcSecret, step)
+	require.NoError(t, err)
+	return code
+}
@@ -41,6 +41,11 @@ type credentials struct {
 	AnonymousUserID string `json:"anonymousUserId"`
 	FirstSourceURL  string `json:"firstSourceUrl"`
 	LastSourceURL   string `json:"lastSourceUrl"`
+	// TOTPCode is a code from the authenticator app of the user, when signing in with a second
+	// factor.
+	TOTPCode string `json:"totpCode"`
+	// RecoveryCode can be provided instead of TOTPCode by users who lost their authenticator app.
+	RecoveryCode string `json:"recoveryCode"`
 }
 
 type unlockAccountInfo struct {
@@ -120,10 +125,16 @@ func handleSignUp(logger log.Logger, db database.DB, w http.ResponseWriter, r *h
 		return
 	}
 
-	// Write the session cookie
-	a := &sgactor.Actor{UID: usr.ID}
-	if err := session.SetActor(w, r, a, 0, usr.CreatedAt); err != nil {
-		httpLogError(logger.Error, w, "Could not create new user session", http.StatusInternalServerError, log.Error(err))
+	if TOTPRequired() {
+		// 🚨 SECURITY: The new user must enroll a second factor before they get a session. They
+		// enroll it when signing in, like existing users who have no second factor yet.
+		w.WriteHeader(http.StatusPreconditionRequired)
+	} else {
+		// Write the session cookie
+		a := &sgactor.Actor{UID: usr.ID}
+		if err := session.SetActor(w, r, a, 0, usr.CreatedAt); err != nil {
+			httpLogError(logger.Error, w, "Could not create new user session", http.StatusInternalServerError, log.Error(err))
+		}
 	}
 
 	// Track user data
@@ -345,6 +356,23 @@ func HandleSignIn(logger log.Logger, db database.DB, store LockoutStore) http.Ha
 			return
 		}
 
+		// 🚨 SECURITY: check the second factor, or make the user enroll one if site config
+		// requires it. Failed checks count towards the account lockout like wrong passwords.
+		pending, recoveryCodes, err := checkSecondFactor(ctx, db, &user, creds)
+		if err != nil {
+			if errors.Is(err, ErrInvalidTOTPCode) {
+				httpLogError(logger.Warn, w, "Invalid two-factor authentication code", http.StatusUnauthorized)
+				return
+			}
+			httpLogError(logger.Error, w, "Error checking two-factor authentication", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if pending != nil {
+			signInResult = database.SecurityEventNameSignInSecondFactorRequired
+			writeJSON(w, http.StatusPreconditionRequired, pending)
+			return
+		}
+
 		// Write the session cookie
 		actor := sgactor.Actor{
 			UID: user.ID,
@@ -355,7 +383,64 @@ func HandleSignIn(logger log.Logger, db database.DB, store LockoutStore) http.Ha
 		}
 
 		signInResult = database.SecurityEventNameSignInSucceeded
+		if len(recoveryCodes) > 0 {
+			writeJSON(w, http.StatusOK, secondFactorResponse{RecoveryCodes: recoveryCodes})
+		}
+	}
+}
+
+// secondFactorResponse is the JSON response to a sign-in request with a correct password if the
+// user needs to provide or enroll a second factor, or just enrolled one.
+type secondFactorResponse struct {
+	// TOTPRequired is set if the user must sign in again with a code from their authenticator
+	// app or a recovery code.
+	TOTPRequired bool `json:"totpRequired,omitempty"`
+	// TOTPEnrollment is set if the user must enroll an authenticator app, by signing in again with
+	// a code generated by the app from this secret.
+	TOTPEnrollment *TOTPEnrollment `json:"totpEnrollment,omitempty"`
+	// RecoveryCodes is set if the user just enrolled an authenticator app while signing in.
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
+
+// checkSecondFactor checks the second factor of a user whose password is correct. If the user
+// cannot sign in until they provide or enroll a second factor, the response telling them so is
+// returned. If the user enrolled an authenticator app with this request, their new recovery codes
+// are returned.
+func checkSecondFactor(ctx context.Context, db database.DB, user *types.User, creds credentials) (pending *secondFactorResponse, recoveryCodes []string, err error) {
+	t, err := userTOTPStore(db).GetByUserID(ctx, user.ID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, nil, err
+	}
+
+	switch {
+	case t != nil && t.Enabled():
+		switch {
+		case creds.TOTPCode != "":
+			return nil, nil, VerifyTOTP(ctx, db, user.ID, creds.TOTPCode)
+		case creds.RecoveryCode != "":
+			return nil, nil, VerifyTOTPRecoveryCode(ctx, db, user.ID, creds.RecoveryCode)
+		default:
+			return &secondFactorResponse{TOTPRequired: true}, nil, nil
+		}
+
+	case TOTPRequired():
+		if t != nil && creds.TOTPCode != "" {
+			recoveryCodes, err := ConfirmTOTPEnrollment(ctx, db, user.ID, creds.TOTPCode)
+			return nil, recoveryCodes, err
+		}
+		enrollment, err := BeginTOTPEnrollment(ctx, db, user.ID, user.Username)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &secondFactorResponse{TOTPEnrollment: enrollment}, nil, nil
 	}
+	return nil, nil, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
 }
 
 func HandleUnlockAccount(logger log.Logger, _ database.DB, store LockoutStore) http.HandlerFunc {
@@ -468,6 +468,58 @@ func TestHandleSignUp(t *testing.T) {
 		mockrequire.CalledOnce(t, authz.GrantPendingPermissionsFunc)
 		mockrequire.CalledOnce(t, users.CreateFunc)
 	})
+
+	t.Run("no session before enrolling a required second factor", func(t *testing.T) {
+		conf.Mock(&conf.Unified{
+			SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{
+						Builtin: &schema.BuiltinAuthProvider{
+							Type:        providerType,
+							AllowSignup: true,
+						},
+					},
+				},
+				AuthTotp: &schema.AuthTOTP{Enforcement: "required"},
+				ExperimentalFeatures: &schema.ExperimentalFeatures{
+					EventLogging: "disabled",
+				},
+			},
+		})
+		defer conf.Mock(nil)
+
+		cleanup := session.ResetMockSessionStore(t)
+		defer cleanup()
+
+		users := database.NewMockUserStore()
+		users.CreateFunc.SetDefaultReturn(&types.User{ID: 1, CreatedAt: time.Now()}, nil)
+
+		db := database.NewMockDB()
+		db.WithTransactFunc.SetDefaultHook(func(ctx context.Context, f func(database.DB) error) error {
+			return f(db)
+		})
+		db.UsersFunc.SetDefaultReturn(users)
+		db.AuthzFunc.SetDefaultReturn(database.NewMockAuthzStore())
+		db.EventLogsFunc.SetDefaultReturn(database.NewMockEventLogStore())
+
+		h := HandleSignUp(logtest.NoOp(t), db)
+
+		body := strings.NewReader(`{
+			"email": "test@test.com",
+			"username": "test-user",
+			"password": "somerandomhardtoguesspassword123456789"
+		}`)
+		req, err := http.NewRequest(http.MethodPost, "/", body)
+		require.NoError(t, err)
+		req.Header.Set("User-Agent", "test")
+
+		resp := httptest.NewRecorder()
+		h(resp, req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, resp.Code)
+		assert.Empty(t, resp.Header().Values("Set-Cookie"))
+		mockrequire.CalledOnce(t, users.CreateFunc)
+	})
 }
 
 func TestHandleSiteInit(t *testing.T) {
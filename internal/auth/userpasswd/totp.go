@@ -0,0 +1,290 @@
+package userpasswd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth/totp"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// recoveryCodeCount is the number of recovery codes users get when they enroll an authenticator
+// app. Each recovery code can be used once instead of a code from the app.
+const recoveryCodeCount = 10
+
+var (
+	// ErrInvalidTOTPCode is returned when a code from an authenticator app or a recovery code is
+	// not valid, or was already used.
+	ErrInvalidTOTPCode = errors.New("invalid two-factor authentication code")
+
+	// ErrTOTPNotEnabled is returned when verifying the second factor of a user who has not
+	// enrolled one.
+	ErrTOTPNotEnabled = errors.New("two-factor authentication is not enabled")
+
+	// ErrTOTPLockedOut is returned instead of checking a code when the user is locked out after
+	// too many failed attempts. It does not tell whether the code was valid.
+	ErrTOTPLockedOut = errors.New("too many failed attempts, try again later")
+
+	// errNoPendingTOTPEnrollment is returned when confirming an enrollment that was not started.
+	errNoPendingTOTPEnrollment = errors.New("no pending two-factor authentication enrollment, start the enrollment again")
+)
+
+// timeNow is mocked in tests.
+var timeNow = time.Now
+
+// TOTPRequired reports whether site config requires users who sign in with the builtin auth
+// provider to use an authenticator app as a second factor.
+func TOTPRequired() bool {
+	c := conf.Get().AuthTotp
+	return c != nil && c.Enforcement == "required"
+}
+
+func totpIssuer() string {
+	if c := conf.Get().AuthTotp; c != nil && c.Issuer != "" {
+		return c.Issuer
+	}
+	return "Sourcegraph"
+}
+
+func userTOTPStore(db database.DB) database.UserTOTPStore {
+	return db.UserTOTP(keyring.Default().UserTOTPKey)
+}
+
+// TOTPEnrollment is a pending enrollment of an authenticator app.
+type TOTPEnrollment struct {
+	// Secret is the base32-encoded secret, for users who enter it manually into their app.
+	Secret string `json:"secret"`
+	// ProvisioningURI is the otpauth:// URI of the secret, which is usually shown as a QR code.
+	ProvisioningURI string `json:"provisioningURI"`
+}
+
+// TOTPEnabled reports whether the user has a confirmed authenticator app enrollment.
+func TOTPEnabled(ctx context.Context, db database.DB, userID int32) (bool, error) {
+	t, err := userTOTPStore(db).GetByUserID(ctx, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return t.Enabled(), nil
+}
+
+// BeginTOTPEnrollment starts enrolling an authenticator app as the second factor of the user, by
+// generating a new secret. The enrollment must be confirmed with a code generated by the app
+// with ConfirmTOTPEnrollment.
+func BeginTOTPEnrollment(ctx context.Context, db database.DB, userID int32, username string) (*TOTPEnrollment, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := userTOTPStore(db).SetPending(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+	return &TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(totpIssuer(), username, secret),
+	}, nil
+}
+
+// ConfirmTOTPEnrollment confirms the pending enrollment of the user with a code generated by their
+// authenticator app. It returns the recovery codes of the user, which are only stored hashed and
+// can't be retrieved again.
+//
+// 🚨 SECURITY: Callers must check that the current user is allowed to change the second factor
+// of the user.
+func ConfirmTOTPEnrollment(ctx context.Context, db database.DB, userID int32, code string) ([]string, error) {
+	store := userTOTPStore(db)
+	t, err := store.GetByUserID(ctx, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, errNoPendingTOTPEnrollment
+		}
+		return nil, err
+	}
+	if t.Enabled() {
+		return nil, database.ErrUserTOTPAlreadyEnabled
+	}
+
+	step, ok, err := totp.Validate(t.Secret, code, timeNow())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		logTOTPEvent(ctx, db, database.SecurityEventNameTOTPVerificationFailed, userID, nil)
+		return nil, ErrInvalidTOTPCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Enable(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+	logTOTPEvent(ctx, db, database.SecurityEventNameTOTPEnrolled, userID, nil)
+	return codes, nil
+}
+
+// VerifyTOTP checks a code from the authenticator app of the user. Each code is only accepted
+// once.
+func VerifyTOTP(ctx context.Context, db database.DB, userID int32, code string) error {
+	store := userTOTPStore(db)
+	t, err := getEnabledTOTP(ctx, store, userID)
+	if err != nil {
+		return err
+	}
+
+	step, ok, err := totp.Validate(t.Secret, code, timeNow())
+	if err != nil {
+		return err
+	}
+	if ok {
+		// 🚨 SECURITY: Reject codes that were already used, e.g. by someone who saw the user
+		// enter it.
+		ok, err = store.UseStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+	}
+	if !ok {
+		logTOTPEvent(ctx, db, database.SecurityEventNameTOTPVerificationFailed, userID, nil)
+		return ErrInvalidTOTPCode
+	}
+	logTOTPEvent(ctx, db, database.SecurityEventNameTOTPVerified, userID, nil)
+	return nil
+}
+
+// VerifyTOTPRecoveryCode checks a recovery code of the user, which can be used instead of a code
+// from the authenticator app. Each recovery code is only accepted once.
+func VerifyTOTPRecoveryCode(ctx context.Context, db database.DB, userID int32, recoveryCode string) error {
+	store := userTOTPStore(db)
+	if _, err := getEnabledTOTP(ctx, store, userID); err != nil {
+		return err
+	}
+
+	ok, err := store.UseRecoveryCode(ctx, userID, hashRecoveryCode(recoveryCode))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		logTOTPEvent(ctx, db, database.SecurityEventNameTOTPVerificationFailed, userID, map[string]any{"recoveryCode": true})
+		return ErrInvalidTOTPCode
+	}
+	logTOTPEvent(ctx, db, database.SecurityEventNameTOTPRecoveryCodeUsed, userID, nil)
+	return nil
+}
+
+// CheckTOTPCodeWithLockout runs check, which checks a code from the authenticator app of the user
+// or one of their recovery codes. Invalid codes count towards the account lockout like failed
+// sign-in attempts, and codes of users who are locked out are not checked at all.
+//
+// 🚨 SECURITY: Every check of a code outside of sign-in must go through this function, so that
+// codes can't be guessed by brute force with a stolen session.
+func CheckTOTPCodeWithLockout(store LockoutStore, userID int32, check func() error) error {
+	if _, locked := store.IsLockedOut(userID); locked {
+		return ErrTOTPLockedOut
+	}
+	err := check()
+	if errors.Is(err, ErrInvalidTOTPCode) {
+		store.IncreaseFailedAttempt(userID)
+	}
+	return err
+}
+
+// RegenerateTOTPRecoveryCodes replaces the recovery codes of the user, after checking a code
+// from their authenticator app.
+//
+// 🚨 SECURITY: Callers must check that the current user is the user.
+func RegenerateTOTPRecoveryCodes(ctx context.Context, db database.DB, userID int32, code string) ([]string, error) {
+	if err := VerifyTOTP(ctx, db, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := userTOTPStore(db).SetRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	logTOTPEvent(ctx, db, database.SecurityEventNameTOTPRecoveryCodesRegenerated, userID, nil)
+	return codes, nil
+}
+
+// DisableTOTP removes the authenticator app second factor of the user.
+//
+// 🚨 SECURITY: Callers must check that the current user is allowed to remove the second factor of
+// the user, and verify a code of the user if needed.
+func DisableTOTP(ctx context.Context, db database.DB, userID, actorUID int32) error {
+	if err := userTOTPStore(db).Delete(ctx, userID); err != nil {
+		return err
+	}
+	logTOTPEvent(ctx, db, database.SecurityEventNameTOTPDisabled, userID, map[string]any{"by": actorUID})
+	return nil
+}
+
+func getEnabledTOTP(ctx context.Context, store database.UserTOTPStore, userID int32) (*database.UserTOTP, error) {
+	t, err := store.GetByUserID(ctx, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, ErrTOTPNotEnabled
+		}
+		return nil, err
+	}
+	if !t.Enabled() {
+		return nil, ErrTOTPNotEnabled
+	}
+	return t, nil
+}
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateRecoveryCodes returns new recovery codes, formatted as "xxxxx-xxxxx", and their hashes.
+func generateRecoveryCodes() (codes, hashes []string, err error) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		// 10 base32 characters encode 50 random bits.
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, errors.Wrap(err, "generating recovery code")
+		}
+		s := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		code := s[:5] + "-" + s[5:10]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode returns the hash of the recovery code that is stored in the database.
+// Recovery codes are random, so a fast hash is sufficient. Users may omit the dash and type
+// upper-case letters.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// logTOTPEvent logs a security event about the second factor of the user.
+func logTOTPEvent(ctx context.Context, db database.DB, name database.SecurityEventName, userID int32, args map[string]any) {
+	event := &database.SecurityEvent{
+		Name:      name,
+		UserID:    uint32(userID),
+		Source:    "BACKEND",
+		Timestamp: time.Now(),
+	}
+	if args != nil {
+		event.Argument, _ = json.Marshal(args)
+	}
+	db.SecurityEventLogs().LogEvent(ctx, event)
+}
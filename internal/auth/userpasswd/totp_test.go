@@ -0,0 +1,268 @@
+package userpasswd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth/totp"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/session"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// newTOTPStore returns a mock store that keeps the enrollment of a single user in memory.
+func newTOTPStore(t *testing.T, initial *database.UserTOTP) (database.UserTOTPStore, *[]string) {
+	t.Helper()
+
+	var (
+		state     = initial
+		recovery  []string
+		store     = database.NewMockUserTOTPStore()
+		notFound  = database.UserTOTPNotFoundErr{}
+		enabledAt = time.Now()
+	)
+	store.GetByUserIDFunc.SetDefaultHook(func(_ context.Context, userID int32) (*database.UserTOTP, error) {
+		if state == nil {
+			return nil, notFound
+		}
+		c := *state
+		c.RecoveryCodesRemaining = len(recovery)
+		return &c, nil
+	})
+	store.SetPendingFunc.SetDefaultHook(func(_ context.Context, userID int32, secret string) error {
+		if state != nil && state.Enabled() {
+			return database.ErrUserTOTPAlreadyEnabled
+		}
+		state = &database.UserTOTP{UserID: userID, Secret: secret}
+		return nil
+	})
+	store.EnableFunc.SetDefaultHook(func(_ context.Context, _ int32, step int64, hashes []string) error {
+		state.EnabledAt = &enabledAt
+		state.LastUsedStep = step
+		recovery = hashes
+		return nil
+	})
+	store.UseStepFunc.SetDefaultHook(func(_ context.Context, _ int32, step int64) (bool, error) {
+		if step <= state.LastUsedStep {
+			return false, nil
+		}
+		state.LastUsedStep = step
+		return true, nil
+	})
+	store.UseRecoveryCodeFunc.SetDefaultHook(func(_ context.Context, _ int32, hash string) (bool, error) {
+		for i, h := range recovery {
+			if h == hash {
+				recovery = append(recovery[:i], recovery[i+1:]...)
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	return store, &recovery
+}
+
+func newTOTPTestDB(store database.UserTOTPStore) *database.MockDB {
+	db := database.NewMockDB()
+	db.UserTOTPFunc.SetDefaultReturn(store)
+	db.SecurityEventLogsFunc.SetDefaultReturn(database.NewMockSecurityEventLogsStore())
+	return db
+}
+
+func mockTimeNow(t *testing.T, now time.Time) {
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+}
+
+func codeAt(t *testing.T, now time.Time) string {
+	t.Helper()
+	code, err := totp.Code(testTOTPSecret, totp.Step(now))
+	require.NoError(t, err)
+	return code
+}
+
+func securityEventNames(db *database.MockDB) []database.SecurityEventName {
+	var names []database.SecurityEventName
+	for _, c := range db.SecurityEventLogs().(*database.MockSecurityEventLogsStore).LogEventFunc.History() {
+		names = append(names, c.Arg1.Name)
+	}
+	return names
+}
+
+func TestTOTPEnrollment(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1111111111, 0)
+	mockTimeNow(t, now)
+
+	store, recovery := newTOTPStore(t, &database.UserTOTP{UserID: 1, Secret: testTOTPSecret})
+	db := newTOTPTestDB(store)
+
+	_, err := ConfirmTOTPEnrollment(ctx, db, 1, "000000")
+	assert.ErrorIs(t, err, ErrInvalidTOTPCode)
+
+	codes, err := ConfirmTOTPEnrollment(ctx, db, 1, codeAt(t, now))
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, codes[0])
+	assert.Len(t, *recovery, recoveryCodeCount)
+	assert.NotContains(t, *recovery, codes[0], "recovery codes must be stored hashed")
+
+	_, err = ConfirmTOTPEnrollment(ctx, db, 1, codeAt(t, now))
+	assert.ErrorIs(t, err, database.ErrUserTOTPAlreadyEnabled)
+
+	// The code that confirmed the enrollment can't be used again.
+	assert.ErrorIs(t, VerifyTOTP(ctx, db, 1, codeAt(t, now)), ErrInvalidTOTPCode)
+	mockTimeNow(t, now.Add(totp.Period))
+	assert.NoError(t, VerifyTOTP(ctx, db, 1, codeAt(t, now.Add(totp.Period))))
+
+	// Recovery codes can be used once, regardless of case and dashes.
+	assert.NoError(t, VerifyTOTPRecoveryCode(ctx, db, 1, strings.ToUpper(strings.ReplaceAll(codes[3], "-", ""))))
+	assert.ErrorIs(t, VerifyTOTPRecoveryCode(ctx, db, 1, codes[3]), ErrInvalidTOTPCode)
+	assert.Len(t, *recovery, recoveryCodeCount-1)
+
+	assert.Equal(t, []database.SecurityEventName{
+		database.SecurityEventNameTOTPVerificationFailed,
+		database.SecurityEventNameTOTPEnrolled,
+		database.SecurityEventNameTOTPVerificationFailed,
+		database.SecurityEventNameTOTPVerified,
+		database.SecurityEventNameTOTPRecoveryCodeUsed,
+		database.SecurityEventNameTOTPVerificationFailed,
+	}, securityEventNames(db))
+}
+
+func TestVerifyTOTPNotEnabled(t *testing.T) {
+	ctx := context.Background()
+	for name, initial := range map[string]*database.UserTOTP{
+		"no enrollment":      nil,
+		"pending enrollment": {UserID: 1, Secret: testTOTPSecret},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store, _ := newTOTPStore(t, initial)
+			db := newTOTPTestDB(store)
+			assert.ErrorIs(t, VerifyTOTP(ctx, db, 1, "123456"), ErrTOTPNotEnabled)
+			assert.ErrorIs(t, VerifyTOTPRecoveryCode(ctx, db, 1, "abcde-fghij"), ErrTOTPNotEnabled)
+		})
+	}
+}
+
+func TestCheckTOTPCodeWithLockout(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1111111111, 0)
+	mockTimeNow(t, now)
+
+	store, _ := newTOTPStore(t, &database.UserTOTP{UserID: 1, Secret: testTOTPSecret})
+	db := newTOTPTestDB(store)
+
+	failed := 0
+	lockout := NewMockLockoutStore()
+	lockout.IsLockedOutFunc.SetDefaultHook(func(int32) (string, bool) { return "", failed >= 2 })
+	lockout.IncreaseFailedAttemptFunc.SetDefaultHook(func(int32) { failed++ })
+
+	confirm := func(code string) error {
+		return CheckTOTPCodeWithLockout(lockout, 1, func() error {
+			_, err := ConfirmTOTPEnrollment(ctx, db, 1, code)
+			return err
+		})
+	}
+	assert.ErrorIs(t, confirm("000000"), ErrInvalidTOTPCode)
+	assert.ErrorIs(t, confirm("000000"), ErrInvalidTOTPCode)
+
+	// The correct code is not even checked after the user was locked out.
+	assert.ErrorIs(t, confirm(codeAt(t, now)), ErrTOTPLockedOut)
+	assert.Empty(t, store.(*database.MockUserTOTPStore).EnableFunc.History())
+}
+
+func TestHandleSignIn_TOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	mockTimeNow(t, now)
+	t.Cleanup(session.ResetMockSessionStore(t))
+
+	mockConf := func(enforcement string) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			AuthProviders: []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{Type: providerType}}},
+			AuthTotp:      &schema.AuthTOTP{Enforcement: enforcement},
+		}})
+		t.Cleanup(func() { conf.Mock(nil) })
+	}
+
+	newHandler := func(store database.UserTOTPStore) (http.HandlerFunc, *MockLockoutStore) {
+		users := database.NewMockUserStore()
+		users.GetByUsernameFunc.SetDefaultReturn(&types.User{ID: 1, Username: "alice"}, nil)
+		users.IsPasswordFunc.SetDefaultReturn(true, nil)
+		db := newTOTPTestDB(store)
+		db.UsersFunc.SetDefaultReturn(users)
+		db.EventLogsFunc.SetDefaultReturn(database.NewMockEventLogStore())
+		lockout := NewMockLockoutStore()
+		return HandleSignIn(logtest.Scoped(t), db, lockout), lockout
+	}
+
+	signIn := func(h http.HandlerFunc, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		require.NoError(t, err)
+		resp := httptest.NewRecorder()
+		h(resp, req)
+		return resp
+	}
+
+	t.Run("enrolled user", func(t *testing.T) {
+		mockConf("optional")
+		store, _ := newTOTPStore(t, &database.UserTOTP{UserID: 1, Secret: testTOTPSecret, EnabledAt: &now})
+		h, lockout := newHandler(store)
+
+		resp := signIn(h, `{"email": "alice", "password": "pw"}`)
+		assert.Equal(t, http.StatusPreconditionRequired, resp.Code)
+		assert.JSONEq(t, `{"totpRequired": true}`, resp.Body.String())
+		assert.Empty(t, lockout.IncreaseFailedAttemptFunc.History())
+
+		resp = signIn(h, `{"email": "alice", "password": "pw", "totpCode": "000000"}`)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Len(t, lockout.IncreaseFailedAttemptFunc.History(), 1)
+
+		resp = signIn(h, `{"email": "alice", "password": "pw", "totpCode": "`+codeAt(t, now)+`"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Empty(t, resp.Body.String())
+		assert.Len(t, lockout.ResetFunc.History(), 1)
+	})
+
+	t.Run("not enrolled, optional", func(t *testing.T) {
+		mockConf("optional")
+		store, _ := newTOTPStore(t, nil)
+		h, _ := newHandler(store)
+
+		resp := signIn(h, `{"email": "alice", "password": "pw"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("not enrolled, required", func(t *testing.T) {
+		mockConf("required")
+		store, _ := newTOTPStore(t, nil)
+		h, _ := newHandler(store)
+
+		resp := signIn(h, `{"email": "alice", "password": "pw"}`)
+		require.Equal(t, http.StatusPreconditionRequired, resp.Code)
+		var pending secondFactorResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &pending))
+		require.NotNil(t, pending.TOTPEnrollment)
+		assert.Contains(t, pending.TOTPEnrollment.ProvisioningURI, "otpauth://totp/Sourcegraph:alice?")
+
+		code, err := totp.Code(pending.TOTPEnrollment.Secret, totp.Step(now))
+		require.NoError(t, err)
+		resp = signIn(h, `{"email": "alice", "password": "pw", "totpCode": "`+code+`"}`)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var enrolled secondFactorResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &enrolled))
+		assert.Len(t, enrolled.RecoveryCodes, recoveryCodeCount)
+	})
+}
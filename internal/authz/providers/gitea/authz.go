@@ -0,0 +1,51 @@
+package gitea
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	atypes "github.com/sourcegraph/sourcegraph/internal/authz/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// NewAuthzProviders returns the set of Gitea authz providers derived from the connections.
+//
+// This constructor does not and should not directly check connectivity to external services - if
+// desired, callers should use `(*Provider).ValidateConnection` directly to get warnings related
+// to connection issues.
+func NewAuthzProviders(conns []*types.GiteaConnection) *atypes.ProviderInitResult {
+	initResults := &atypes.ProviderInitResult{}
+	for _, c := range conns {
+		p, err := newAuthzProvider(c)
+		if err != nil {
+			initResults.InvalidConnections = append(initResults.InvalidConnections, extsvc.VariantGitea.AsType())
+			initResults.Problems = append(initResults.Problems, err.Error())
+		} else if p != nil {
+			initResults.Providers = append(initResults.Providers, p)
+		}
+	}
+
+	return initResults
+}
+
+func newAuthzProvider(c *types.GiteaConnection) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	if err := licensing.Check(licensing.FeatureACLs); err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	cli := gitea.NewClient(c.URN, baseURL, &auth.OAuthBearerToken{Token: c.Token}, nil)
+	return NewProvider(cli, c.URN), nil
+}
@@ -0,0 +1,206 @@
+// Package gitea contains an authorization provider for Gitea and Forgejo.
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository
+// permissions as determined from a Gitea instance API.
+//
+// Gitea has no endpoint listing the repositories another user can access, so
+// the provider impersonates each user with the Sudo header instead. This
+// requires the client to be authenticated as a Gitea site administrator.
+//
+// Sourcegraph users are only ever matched to the Gitea user they are linked
+// to through an external account, never by username: a Sourcegraph user and a
+// Gitea user with the same username need not be the same person.
+type Provider struct {
+	urn      string
+	client   *gitea.Client
+	codeHost *extsvc.CodeHost
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Gitea authorization provider that uses the given
+// gitea.Client to talk to the Gitea API that is the source of truth for
+// permissions.
+func NewProvider(cli *gitea.Client, urn string) *Provider {
+	return &Provider{
+		urn:      urn,
+		client:   cli,
+		codeHost: extsvc.NewCodeHost(cli.URL, extsvc.VariantGitea.AsType()),
+	}
+}
+
+// ValidateConnection validates that the Provider has access to the Gitea API
+// with the credentials it was configured with, and that those credentials
+// belong to a site administrator.
+func (p *Provider) ValidateConnection(ctx context.Context) error {
+	user, err := p.client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return errors.Newf("the Gitea token must belong to a site administrator to enforce permissions, but %q is not one", user.Login)
+	}
+	return nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the absolute URL that identifies the Gitea instance this
+// provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "gitea".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount returns the Gitea account linked to the given Sourcegraph user,
+// or nil if there is none. See linkedUserID for how accounts are linked.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, current []*extsvc.Account, _ []string) (*extsvc.Account, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	id, ok := p.linkedUserID(current)
+	if !ok {
+		return nil, nil
+	}
+
+	giteaUser, err := p.client.GetUserByID(ctx, id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	accountData, err := json.Marshal(giteaUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   strconv.FormatInt(giteaUser.ID, 10),
+		},
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(accountData),
+		},
+	}, nil
+}
+
+// openIDConnectServiceType is the service type of external accounts created by
+// the openidconnect auth provider.
+const openIDConnectServiceType = "openidconnect"
+
+// linkedUserID returns the ID of the Gitea user one of the given external
+// accounts belongs to. This is either an account on this Gitea instance, or an
+// account of an OpenID Connect auth provider whose issuer is this Gitea
+// instance, in which case the subject is the Gitea user ID.
+func (p *Provider) linkedUserID(accounts []*extsvc.Account) (int64, bool) {
+	for _, acct := range accounts {
+		if !extsvc.IsHostOfAccount(p.codeHost, acct) && !(acct.ServiceType == openIDConnectServiceType && p.isIssuer(acct.ServiceID)) {
+			continue
+		}
+		id, err := strconv.ParseInt(acct.AccountID, 10, 64)
+		if err != nil {
+			continue
+		}
+		return id, true
+	}
+	return 0, false
+}
+
+// isIssuer reports whether the given OpenID Connect issuer URL is the URL of
+// this Gitea instance.
+func (p *Provider) isIssuer(issuer string) bool {
+	u, err := url.Parse(issuer)
+	if err != nil {
+		return false
+	}
+	return extsvc.NormalizeBaseURL(u).String() == p.codeHost.ServiceID
+}
+
+// FetchUserPerms returns a list of repository IDs (on code host) that the
+// given account has read access on the code host. The repository ID has the
+// same value as it would be used as api.ExternalRepoSpec.ID. The returned list
+// only includes IDs of repositories that can't be read anonymously, see
+// gitea.Repository.Restricted.
+//
+// This method may return partial but valid results in case of error, and it
+// is up to callers to decide whether to discard.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case account.Data == nil:
+		return nil, errors.New("no account data provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	id, err := strconv.ParseInt(account.AccountID, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing account ID")
+	}
+
+	// The login stored in the account data may be stale if the user was
+	// renamed, so resolve it from the immutable ID before impersonating them.
+	user, err := p.client.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting user")
+	}
+
+	cli := p.client.WithSudo(user.Login)
+
+	// The search endpoint, unlike the user's repository list, includes
+	// internal repositories and those of limited organizations the user is
+	// not a member of.
+	extIDs := []extsvc.RepoID{}
+	for page := 1; ; page++ {
+		repos, hasNext, err := cli.SearchRepos(ctx, "", page)
+		if err != nil {
+			return &authz.ExternalUserPermissions{Exacts: extIDs}, err
+		}
+
+		for _, r := range repos {
+			if r.Restricted() {
+				extIDs = append(extIDs, extsvc.RepoID(strconv.FormatInt(r.ID, 10)))
+			}
+		}
+
+		if !hasNext {
+			break
+		}
+	}
+
+	return &authz.ExternalUserPermissions{
+		Exacts: extIDs,
+	}, nil
+}
+
+// FetchRepoPerms is unimplemented for Gitea, as its API only lists the
+// collaborators of a repository and not the users that can access it through
+// organization teams.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "gitea.FetchRepoPerms"}
+}
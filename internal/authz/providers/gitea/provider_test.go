@@ -0,0 +1,125 @@
+package gitea
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewAuthzProviders(t *testing.T) {
+	t.Cleanup(licensing.MockCheckFeatureError(""))
+
+	conns := []*types.GiteaConnection{
+		{
+			URN: "extsvc:gitea:1",
+			GiteaConnection: &schema.GiteaConnection{
+				Url:   "https://gitea.sgdev.org",
+				Token: "secret",
+			},
+		},
+		{
+			URN: "extsvc:gitea:2",
+			GiteaConnection: &schema.GiteaConnection{
+				Url:           "https://codeberg.org",
+				Token:         "secret",
+				Authorization: &schema.GiteaAuthorization{},
+			},
+		},
+	}
+
+	res := NewAuthzProviders(conns)
+	assert.Empty(t, res.Problems)
+	require.Len(t, res.Providers, 1)
+	assert.Equal(t, "extsvc:gitea:2", res.Providers[0].URN())
+	assert.Equal(t, "https://codeberg.org/", res.Providers[0].ServiceID())
+	assert.Equal(t, extsvc.VariantGitea.AsType(), res.Providers[0].ServiceType())
+
+	t.Run("without ACLs license", func(t *testing.T) {
+		t.Cleanup(licensing.MockCheckFeatureError("failed"))
+
+		res := NewAuthzProviders(conns)
+		assert.Empty(t, res.Providers)
+		assert.Equal(t, []string{extsvc.VariantGitea.AsType()}, res.InvalidConnections)
+		assert.Len(t, res.Problems, 1)
+	})
+}
+
+func TestProvider(t *testing.T) {
+	cli, save := gitea.NewTestClient(t, "Provider", *update)
+	defer save()
+
+	ctx := context.Background()
+	p := NewProvider(cli, "extsvc:gitea:1")
+
+	require.NoError(t, p.ValidateConnection(ctx))
+
+	t.Run("FetchAccount", func(t *testing.T) {
+		user := &types.User{ID: 42, Username: "alice"}
+
+		// A matching username alone doesn't link the user to a Gitea account.
+		acct, err := p.FetchAccount(ctx, user, nil, nil)
+		require.NoError(t, err)
+		assert.Nil(t, acct)
+
+		// Neither does an OpenID Connect account of another issuer.
+		acct, err = p.FetchAccount(ctx, user, []*extsvc.Account{{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: "openidconnect",
+				ServiceID:   "https://accounts.google.com",
+				AccountID:   "3",
+			},
+		}}, nil)
+		require.NoError(t, err)
+		assert.Nil(t, acct)
+
+		acct, err = p.FetchAccount(ctx, user, []*extsvc.Account{{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: "openidconnect",
+				ServiceID:   "https://gitea.sgdev.org",
+				AccountID:   "3",
+			},
+		}}, nil)
+		require.NoError(t, err)
+		require.NotNil(t, acct)
+		assert.Equal(t, int32(42), acct.UserID)
+		assert.Equal(t, extsvc.AccountSpec{
+			ServiceType: extsvc.VariantGitea.AsType(),
+			ServiceID:   p.ServiceID(),
+			AccountID:   "3",
+		}, acct.AccountSpec)
+
+		perms, err := p.FetchUserPerms(ctx, acct, authz.FetchPermsOptions{})
+		require.NoError(t, err)
+		// The private and internal repositories and the public repository
+		// of a limited organization are returned. Public ones are accessible
+		// to everyone anyway.
+		assert.Equal(t, []extsvc.RepoID{"13", "14", "15"}, perms.Exacts)
+	})
+
+	t.Run("FetchUserPerms for another code host", func(t *testing.T) {
+		_, err := p.FetchUserPerms(ctx, &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.VariantGitea.AsType(),
+				ServiceID:   "https://codeberg.org/",
+				AccountID:   "3",
+			},
+			AccountData: extsvc.AccountData{Data: extsvc.NewUnencryptedData([]byte("{}"))},
+		}, authz.FetchPermsOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("FetchRepoPerms", func(t *testing.T) {
+		_, err := p.FetchRepoPerms(ctx, &extsvc.Repository{}, authz.FetchPermsOptions{})
+		assert.True(t, errors.HasType(err, &authz.ErrUnimplemented{}))
+	})
+}
@@ -0,0 +1,289 @@
+// Package scheduledruns implements the background routine that re-runs batch
+// specs that include a schedule.
+//
+// On every tick the routine does two things:
+//
+//  1. For every open batch change whose current batch spec includes a
+//     schedule, it checks whether the next run is due and, if so, creates a
+//     new batch spec from the same raw spec. This re-resolves the workspaces
+//     against the current state of the code host.
+//  2. It advances every scheduled run that is still in progress: once
+//     workspace resolution is done, the batch spec is executed; once
+//     execution is done, the batch spec is either applied automatically or
+//     left for review, depending on the schedule's autoApply policy.
+package scheduledruns
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const interval = 1 * time.Minute
+
+// NewRunner returns a background routine that creates and advances scheduled
+// runs of batch changes.
+func NewRunner(ctx context.Context, s *store.Store) goroutine.BackgroundRoutine {
+	r := &runner{
+		logger: log.Scoped("batches.scheduledruns", "creates and advances scheduled runs of batch changes"),
+		store:  s,
+		svc:    service.New(s),
+		clock:  s.Clock(),
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(r.handle),
+		goroutine.WithName("batchchanges.scheduled-runs"),
+		goroutine.WithDescription("creates and advances scheduled runs of batch changes"),
+		goroutine.WithInterval(interval),
+	)
+}
+
+type runner struct {
+	logger log.Logger
+	store  *store.Store
+	svc    *service.Service
+	clock  func() time.Time
+}
+
+func (r *runner) handle(ctx context.Context) error {
+	var errs error
+	if err := r.advanceRuns(ctx); err != nil {
+		errs = errors.Append(errs, errors.Wrap(err, "advancing scheduled runs"))
+	}
+	if err := r.enqueueRuns(ctx); err != nil {
+		errs = errors.Append(errs, errors.Wrap(err, "enqueueing scheduled runs"))
+	}
+	return errs
+}
+
+// enqueueRuns creates a new scheduled run for every batch change whose
+// schedule is due.
+func (r *runner) enqueueRuns(ctx context.Context) error {
+	batchChanges, _, err := r.store.ListBatchChanges(ctx, store.ListBatchChangesOpts{
+		States:        []btypes.BatchChangeState{btypes.BatchChangeStateOpen},
+		OnlyScheduled: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, bc := range batchChanges {
+		if err := r.maybeEnqueueRun(ctx, bc); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "batch change %d", bc.ID))
+		}
+	}
+	return errs
+}
+
+func (r *runner) maybeEnqueueRun(ctx context.Context, bc *btypes.BatchChange) error {
+	runs, _, err := r.store.ListBatchChangeScheduledRuns(ctx, store.ListBatchChangeScheduledRunsOpts{
+		LimitOpts:     store.LimitOpts{Limit: 1},
+		BatchChangeID: bc.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var last *btypes.BatchChangeScheduledRun
+	if len(runs) > 0 {
+		last = runs[0]
+		// Only one run per batch change may be in progress at a time.
+		if !last.State.Finished() {
+			return nil
+		}
+	}
+
+	spec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: bc.BatchSpecID})
+	if err != nil {
+		return err
+	}
+	if spec.Spec.Schedule == nil {
+		return nil
+	}
+
+	expr, err := btypes.ParseSchedule(spec.Spec.Schedule)
+	if err != nil {
+		return err
+	}
+
+	now := r.clock()
+	if !isDue(expr, bc, last, now) {
+		return nil
+	}
+
+	run := &btypes.BatchChangeScheduledRun{
+		BatchChangeID: bc.ID,
+		State:         btypes.BatchChangeScheduledRunStateResolving,
+		ScheduledAt:   now,
+	}
+
+	// The new batch spec is created on behalf of the user who last applied
+	// the batch change, so that the usual namespace and repository
+	// permissions apply to it.
+	userCtx := actor.WithActor(ctx, actor.FromUser(bc.LastApplierID))
+	newSpec, err := r.svc.CreateBatchSpecFromRaw(userCtx, service.CreateBatchSpecFromRawOpts{
+		RawSpec:          spec.RawSpec,
+		NamespaceUserID:  bc.NamespaceUserID,
+		NamespaceOrgID:   bc.NamespaceOrgID,
+		AllowIgnored:     spec.AllowIgnored,
+		AllowUnsupported: spec.AllowUnsupported,
+		NoCache:          spec.NoCache,
+		BatchChange:      bc.ID,
+	})
+	if err != nil {
+		r.logger.Warn("failed to create batch spec for scheduled run", log.Int64("batchChangeID", bc.ID), log.Error(err))
+		run.State = btypes.BatchChangeScheduledRunStateFailed
+		run.FailureMessage = err.Error()
+		run.FinishedAt = now
+	} else {
+		run.BatchSpecID = newSpec.ID
+	}
+
+	return r.store.CreateBatchChangeScheduledRun(ctx, run)
+}
+
+// isDue returns whether the next run of the given batch change is due at now.
+// The next run is computed from the later of the last time the batch change
+// was applied and the last time it was run on its schedule.
+func isDue(expr *cronexpr.Expression, bc *btypes.BatchChange, last *btypes.BatchChangeScheduledRun, now time.Time) bool {
+	base := bc.LastAppliedAt
+	if last != nil && last.ScheduledAt.After(base) {
+		base = last.ScheduledAt
+	}
+
+	next := expr.Next(base)
+	return !next.IsZero() && !next.After(now)
+}
+
+// advanceRuns moves every scheduled run that is still in progress along, if
+// the batch spec it created has progressed.
+func (r *runner) advanceRuns(ctx context.Context) error {
+	runs, _, err := r.store.ListBatchChangeScheduledRuns(ctx, store.ListBatchChangeScheduledRunsOpts{
+		States: []btypes.BatchChangeScheduledRunState{
+			btypes.BatchChangeScheduledRunStateResolving,
+			btypes.BatchChangeScheduledRunStateExecuting,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, run := range runs {
+		if err := r.advanceRun(ctx, run); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "scheduled run %d", run.ID))
+		}
+	}
+	return errs
+}
+
+func (r *runner) advanceRun(ctx context.Context, run *btypes.BatchChangeScheduledRun) error {
+	if run.BatchSpecID == 0 {
+		return r.finish(ctx, run, btypes.BatchChangeScheduledRunStateFailed, "batch spec was deleted")
+	}
+
+	spec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: run.BatchSpecID})
+	if err != nil {
+		if errors.Is(err, store.ErrNoResults) {
+			return r.finish(ctx, run, btypes.BatchChangeScheduledRunStateFailed, "batch spec was deleted")
+		}
+		return err
+	}
+
+	userCtx := actor.WithActor(ctx, actor.FromUser(spec.UserID))
+
+	switch run.State {
+	case btypes.BatchChangeScheduledRunStateResolving:
+		job, err := r.store.GetBatchSpecResolutionJob(ctx, store.GetBatchSpecResolutionJobOpts{BatchSpecID: spec.ID})
+		if err != nil {
+			return err
+		}
+
+		switch job.State {
+		case btypes.BatchSpecResolutionJobStateCompleted:
+			if _, err := r.svc.ExecuteBatchSpec(userCtx, service.ExecuteBatchSpecOpts{BatchSpecRandID: spec.RandID}); err != nil {
+				return r.finish(ctx, run, btypes.BatchChangeScheduledRunStateFailed, err.Error())
+			}
+			run.State = btypes.BatchChangeScheduledRunStateExecuting
+			return r.store.UpdateBatchChangeScheduledRun(ctx, run)
+
+		case btypes.BatchSpecResolutionJobStateErrored, btypes.BatchSpecResolutionJobStateFailed:
+			msg := "resolving workspaces failed"
+			if job.FailureMessage != nil && *job.FailureMessage != "" {
+				msg = *job.FailureMessage
+			}
+			return r.finish(ctx, run, btypes.BatchChangeScheduledRunStateFailed, msg)
+		}
+
+	case btypes.BatchChangeScheduledRunStateExecuting:
+		stats, err := r.svc.LoadBatchSpecStats(ctx, spec)
+		if err != nil {
+			return err
+		}
+
+		switch btypes.ComputeBatchSpecState(spec, stats) {
+		case btypes.BatchSpecStateCompleted:
+			return r.completeRun(ctx, userCtx, run, spec)
+
+		case btypes.BatchSpecStateFailed:
+			return r.finish(ctx, run, btypes.BatchChangeScheduledRunStateFailed, "executing the batch spec failed")
+
+		case btypes.BatchSpecStateCanceled:
+			return r.finish(ctx, run, btypes.BatchChangeScheduledRunStateFailed, "executing the batch spec was canceled")
+		}
+	}
+
+	return nil
+}
+
+// completeRun records the number of changesets the executed batch spec would
+// create, and applies the batch spec if the schedule allows it.
+func (r *runner) completeRun(ctx, userCtx context.Context, run *btypes.BatchChangeScheduledRun, spec *btypes.BatchSpec) error {
+	mappings, err := r.store.GetRewirerMappings(ctx, store.GetRewirerMappingsOpts{
+		BatchSpecID:   spec.ID,
+		BatchChangeID: run.BatchChangeID,
+	})
+	if err != nil {
+		return err
+	}
+
+	newChangesets := 0
+	for _, m := range mappings {
+		if m.ChangesetSpecID != 0 && m.ChangesetID == 0 {
+			newChangesets++
+		}
+	}
+	run.NewChangesets = int32(newChangesets)
+
+	if spec.Spec.Schedule == nil || !spec.Spec.Schedule.ShouldAutoApply(newChangesets) {
+		return r.finish(ctx, run, btypes.BatchChangeScheduledRunStateNeedsReview, "")
+	}
+
+	if _, err := r.svc.ApplyBatchChange(userCtx, service.ApplyBatchChangeOpts{
+		BatchSpecRandID:     spec.RandID,
+		EnsureBatchChangeID: run.BatchChangeID,
+	}); err != nil {
+		return r.finish(ctx, run, btypes.BatchChangeScheduledRunStateFailed, err.Error())
+	}
+
+	return r.finish(ctx, run, btypes.BatchChangeScheduledRunStateApplied, "")
+}
+
+func (r *runner) finish(ctx context.Context, run *btypes.BatchChangeScheduledRun, state btypes.BatchChangeScheduledRunState, failureMessage string) error {
+	run.State = state
+	run.FailureMessage = failureMessage
+	run.FinishedAt = r.clock()
+	return r.store.UpdateBatchChangeScheduledRun(ctx, run)
+}
@@ -0,0 +1,349 @@
+package scheduledruns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestIsDue(t *testing.T) {
+	// Every day at 06:00.
+	expr := cronexpr.MustParse("0 6 * * *")
+
+	appliedAt := time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+	bc := &btypes.BatchChange{LastAppliedAt: appliedAt}
+
+	for name, tc := range map[string]struct {
+		last *btypes.BatchChangeScheduledRun
+		now  time.Time
+		want bool
+	}{
+		"no runs, before first slot": {
+			now:  time.Date(2023, 8, 2, 5, 59, 0, 0, time.UTC),
+			want: false,
+		},
+		"no runs, at first slot": {
+			now:  time.Date(2023, 8, 2, 6, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		"no runs, long after first slot": {
+			now:  time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		"last run in current slot": {
+			last: &btypes.BatchChangeScheduledRun{ScheduledAt: time.Date(2023, 8, 2, 6, 1, 0, 0, time.UTC)},
+			now:  time.Date(2023, 8, 2, 18, 0, 0, 0, time.UTC),
+			want: false,
+		},
+		"last run in previous slot": {
+			last: &btypes.BatchChangeScheduledRun{ScheduledAt: time.Date(2023, 8, 2, 6, 1, 0, 0, time.UTC)},
+			now:  time.Date(2023, 8, 3, 6, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		"last run before last apply": {
+			last: &btypes.BatchChangeScheduledRun{ScheduledAt: time.Date(2023, 7, 1, 6, 0, 0, 0, time.UTC)},
+			now:  time.Date(2023, 8, 1, 18, 0, 0, 0, time.UTC),
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := isDue(expr, bc, tc.last, tc.now); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunner(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := actor.WithInternalActor(context.Background())
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	admin := bt.CreateTestUser(t, db, true)
+	adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+	repos, _ := bt.CreateTestRepos(t, ctx, db, 1)
+
+	now := timeutil.Now()
+	clock := func() time.Time { return now }
+	s := store.NewWithClock(db, &observation.TestContext, nil, clock)
+
+	r := &runner{
+		logger: logger,
+		store:  s,
+		svc:    service.New(s),
+		clock:  clock,
+	}
+
+	rawSpec := bt.TestRawBatchSpecYAML + `schedule:
+  cron: "@daily"
+`
+
+	createSpec := func(t *testing.T, name, rawSpec string, schedule *batcheslib.Schedule) *btypes.BatchSpec {
+		t.Helper()
+
+		spec := &btypes.BatchSpec{
+			RawSpec:         rawSpec,
+			Spec:            &batcheslib.BatchSpec{Name: name, Schedule: schedule},
+			UserID:          admin.ID,
+			NamespaceUserID: admin.ID,
+			CreatedFromRaw:  true,
+		}
+		require.NoError(t, s.CreateBatchSpec(ctx, spec))
+		return spec
+	}
+
+	createBatchChange := func(t *testing.T, name string, spec *btypes.BatchSpec, appliedAt time.Time) *btypes.BatchChange {
+		t.Helper()
+
+		bc := &btypes.BatchChange{
+			Name:            name,
+			CreatorID:       admin.ID,
+			NamespaceUserID: admin.ID,
+			BatchSpecID:     spec.ID,
+			LastApplierID:   admin.ID,
+			LastAppliedAt:   appliedAt,
+		}
+		require.NoError(t, s.CreateBatchChange(ctx, bc))
+		return bc
+	}
+
+	createResolutionJob := func(t *testing.T, spec *btypes.BatchSpec, state btypes.BatchSpecResolutionJobState) {
+		t.Helper()
+
+		require.NoError(t, s.CreateBatchSpecResolutionJob(ctx, &btypes.BatchSpecResolutionJob{
+			State:       state,
+			BatchSpecID: spec.ID,
+			InitiatorID: admin.ID,
+		}))
+	}
+
+	createRun := func(t *testing.T, bc *btypes.BatchChange, spec *btypes.BatchSpec, state btypes.BatchChangeScheduledRunState) *btypes.BatchChangeScheduledRun {
+		t.Helper()
+
+		run := &btypes.BatchChangeScheduledRun{
+			BatchChangeID: bc.ID,
+			State:         state,
+			ScheduledAt:   now,
+		}
+		if spec != nil {
+			run.BatchSpecID = spec.ID
+		}
+		require.NoError(t, s.CreateBatchChangeScheduledRun(ctx, run))
+		return run
+	}
+
+	listRuns := func(t *testing.T, bc *btypes.BatchChange) []*btypes.BatchChangeScheduledRun {
+		t.Helper()
+
+		runs, _, err := s.ListBatchChangeScheduledRuns(ctx, store.ListBatchChangeScheduledRunsOpts{BatchChangeID: bc.ID})
+		require.NoError(t, err)
+		return runs
+	}
+
+	t.Run("maybeEnqueueRun", func(t *testing.T) {
+		t.Run("not due", func(t *testing.T) {
+			spec := createSpec(t, "enqueue-not-due", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			bc := createBatchChange(t, "enqueue-not-due", spec, now)
+
+			require.NoError(t, r.maybeEnqueueRun(ctx, bc))
+			assert.Empty(t, listRuns(t, bc))
+		})
+
+		t.Run("no schedule", func(t *testing.T) {
+			spec := createSpec(t, "enqueue-no-schedule", bt.TestRawBatchSpecYAML, nil)
+			bc := createBatchChange(t, "enqueue-no-schedule", spec, now.Add(-48*time.Hour))
+
+			require.NoError(t, r.maybeEnqueueRun(ctx, bc))
+			assert.Empty(t, listRuns(t, bc))
+		})
+
+		t.Run("due", func(t *testing.T) {
+			spec := createSpec(t, "enqueue-due", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			bc := createBatchChange(t, "enqueue-due", spec, now.Add(-48*time.Hour))
+
+			require.NoError(t, r.maybeEnqueueRun(ctx, bc))
+
+			runs := listRuns(t, bc)
+			require.Len(t, runs, 1)
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateResolving, runs[0].State)
+			assert.True(t, runs[0].ScheduledAt.Equal(now))
+
+			newSpec, err := s.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: runs[0].BatchSpecID})
+			require.NoError(t, err)
+			assert.Equal(t, bc.ID, newSpec.BatchChangeID)
+			assert.Equal(t, spec.RawSpec, newSpec.RawSpec)
+
+			// The run is still in progress, so no other run is enqueued.
+			require.NoError(t, r.maybeEnqueueRun(ctx, bc))
+			assert.Len(t, listRuns(t, bc), 1)
+		})
+
+		t.Run("creating batch spec fails", func(t *testing.T) {
+			spec := createSpec(t, "enqueue-invalid", "this is not a batch spec", &batcheslib.Schedule{Cron: "@daily"})
+			bc := createBatchChange(t, "enqueue-invalid", spec, now.Add(-48*time.Hour))
+
+			require.NoError(t, r.maybeEnqueueRun(ctx, bc))
+
+			runs := listRuns(t, bc)
+			require.Len(t, runs, 1)
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateFailed, runs[0].State)
+			assert.NotEmpty(t, runs[0].FailureMessage)
+			assert.Zero(t, runs[0].BatchSpecID)
+			assert.True(t, runs[0].FinishedAt.Equal(now))
+		})
+	})
+
+	t.Run("advanceRun", func(t *testing.T) {
+		t.Run("batch spec deleted", func(t *testing.T) {
+			spec := createSpec(t, "advance-deleted", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			bc := createBatchChange(t, "advance-deleted", spec, now)
+			run := createRun(t, bc, nil, btypes.BatchChangeScheduledRunStateResolving)
+
+			require.NoError(t, r.advanceRun(ctx, run))
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateFailed, run.State)
+			assert.Equal(t, "batch spec was deleted", run.FailureMessage)
+		})
+
+		t.Run("resolution pending", func(t *testing.T) {
+			spec := createSpec(t, "advance-pending", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			bc := createBatchChange(t, "advance-pending", spec, now)
+			runSpec := createSpec(t, "advance-pending", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			createResolutionJob(t, runSpec, btypes.BatchSpecResolutionJobStateQueued)
+			run := createRun(t, bc, runSpec, btypes.BatchChangeScheduledRunStateResolving)
+
+			require.NoError(t, r.advanceRun(ctx, run))
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateResolving, run.State)
+			assert.True(t, run.FinishedAt.IsZero())
+		})
+
+		t.Run("resolution failed", func(t *testing.T) {
+			spec := createSpec(t, "advance-resolution-failed", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			bc := createBatchChange(t, "advance-resolution-failed", spec, now)
+			runSpec := createSpec(t, "advance-resolution-failed", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			createResolutionJob(t, runSpec, btypes.BatchSpecResolutionJobStateFailed)
+			run := createRun(t, bc, runSpec, btypes.BatchChangeScheduledRunStateResolving)
+
+			require.NoError(t, r.advanceRun(ctx, run))
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateFailed, run.State)
+			assert.Equal(t, "resolving workspaces failed", run.FailureMessage)
+		})
+
+		t.Run("resolved, executed and left for review", func(t *testing.T) {
+			spec := createSpec(t, "advance-review", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			bc := createBatchChange(t, "advance-review", spec, now)
+			runSpec := createSpec(t, "advance-review", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			createResolutionJob(t, runSpec, btypes.BatchSpecResolutionJobStateCompleted)
+			run := createRun(t, bc, runSpec, btypes.BatchChangeScheduledRunStateResolving)
+
+			require.NoError(t, r.advanceRun(ctx, run))
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateExecuting, run.State)
+
+			// The batch spec has no workspaces, so it is completed right
+			// away.
+			require.NoError(t, r.advanceRun(ctx, run))
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateNeedsReview, run.State)
+			assert.Zero(t, run.NewChangesets)
+			assert.True(t, run.FinishedAt.Equal(now))
+		})
+
+		t.Run("execution failed", func(t *testing.T) {
+			spec := createSpec(t, "advance-execution-failed", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			bc := createBatchChange(t, "advance-execution-failed", spec, now)
+			runSpec := createSpec(t, "advance-execution-failed", rawSpec, &batcheslib.Schedule{Cron: "@daily"})
+			createResolutionJob(t, runSpec, btypes.BatchSpecResolutionJobStateCompleted)
+
+			ws := &btypes.BatchSpecWorkspace{BatchSpecID: runSpec.ID, RepoID: repos[0].ID}
+			require.NoError(t, s.CreateBatchSpecWorkspace(ctx, ws))
+
+			job := &btypes.BatchSpecWorkspaceExecutionJob{BatchSpecWorkspaceID: ws.ID, UserID: admin.ID}
+			require.NoError(t, bt.CreateBatchSpecWorkspaceExecutionJob(ctx, s, store.ScanBatchSpecWorkspaceExecutionJob, job))
+			job.State = btypes.BatchSpecWorkspaceExecutionJobStateFailed
+			job.StartedAt = now
+			job.FinishedAt = now
+			bt.UpdateJobState(t, ctx, s, job)
+
+			run := createRun(t, bc, runSpec, btypes.BatchChangeScheduledRunStateExecuting)
+
+			require.NoError(t, r.advanceRun(ctx, run))
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateFailed, run.State)
+			assert.Equal(t, "executing the batch spec failed", run.FailureMessage)
+		})
+	})
+
+	t.Run("completeRun", func(t *testing.T) {
+		t.Run("too many new changesets", func(t *testing.T) {
+			schedule := &batcheslib.Schedule{Cron: "@daily", AutoApply: batcheslib.ScheduleAutoApplyAlways, MaxNewChangesets: pointers.Ptr(0)}
+			spec := createSpec(t, "complete-capped", rawSpec, schedule)
+			bc := createBatchChange(t, "complete-capped", spec, now)
+			runSpec := createSpec(t, "complete-capped", rawSpec, schedule)
+			bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+				User:      admin.ID,
+				Repo:      repos[0].ID,
+				BatchSpec: runSpec.ID,
+				HeadRef:   "refs/heads/complete-capped",
+				Typ:       btypes.ChangesetSpecTypeBranch,
+			})
+			run := createRun(t, bc, runSpec, btypes.BatchChangeScheduledRunStateExecuting)
+
+			require.NoError(t, r.completeRun(ctx, adminCtx, run, runSpec))
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateNeedsReview, run.State)
+			assert.Equal(t, int32(1), run.NewChangesets)
+
+			reloaded, err := s.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: bc.ID})
+			require.NoError(t, err)
+			assert.Equal(t, spec.ID, reloaded.BatchSpecID)
+		})
+
+		t.Run("applying fails", func(t *testing.T) {
+			schedule := &batcheslib.Schedule{Cron: "@daily", AutoApply: batcheslib.ScheduleAutoApplyAlways}
+			spec := createSpec(t, "complete-apply-fails", rawSpec, schedule)
+			bc := createBatchChange(t, "complete-apply-fails", spec, now)
+			// A batch spec with a different name doesn't belong to the batch
+			// change, so applying it to the batch change fails.
+			runSpec := createSpec(t, "complete-apply-fails-renamed", rawSpec, schedule)
+			run := createRun(t, bc, runSpec, btypes.BatchChangeScheduledRunStateExecuting)
+
+			require.NoError(t, r.completeRun(ctx, adminCtx, run, runSpec))
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateFailed, run.State)
+			assert.Equal(t, service.ErrEnsureBatchChangeFailed.Error(), run.FailureMessage)
+		})
+
+		t.Run("applied", func(t *testing.T) {
+			schedule := &batcheslib.Schedule{Cron: "@daily", AutoApply: batcheslib.ScheduleAutoApplyAlways}
+			spec := createSpec(t, "complete-applied", rawSpec, schedule)
+			bc := createBatchChange(t, "complete-applied", spec, now)
+			runSpec := createSpec(t, "complete-applied", rawSpec, schedule)
+			run := createRun(t, bc, runSpec, btypes.BatchChangeScheduledRunStateExecuting)
+
+			require.NoError(t, r.completeRun(ctx, adminCtx, run, runSpec))
+			assert.Equal(t, btypes.BatchChangeScheduledRunStateApplied, run.State)
+			assert.Empty(t, run.FailureMessage)
+
+			reloaded, err := s.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: bc.ID})
+			require.NoError(t, err)
+			assert.Equal(t, runSpec.ID, reloaded.BatchSpecID)
+		})
+	})
+}
@@ -41,6 +41,11 @@ import (
 // namespace provided are already used by another batch change.
 var ErrNameNotUnique = errors.New("a batch change with this name already exists in this namespace")
 
+// ErrScheduleRequiresServerSideExecution is returned by CreateBatchSpec if the
+// batch spec includes a schedule: scheduled runs are only supported for batch
+// specs that are executed server-side.
+var ErrScheduleRequiresServerSideExecution = errors.New("batch specs with a schedule can only be executed server-side")
+
 // New returns a Service.
 func New(store *store.Store) *Service {
 	return NewWithClock(store, store.Clock())
@@ -395,6 +400,10 @@ func (s *Service) CreateBatchSpec(ctx context.Context, opts CreateBatchSpecOpts)
 		return nil, err
 	}
 
+	if spec.Spec.Schedule != nil {
+		return nil, ErrScheduleRequiresServerSideExecution
+	}
+
 	// Check whether the current user has access to either one of the namespaces.
 	err = s.CheckNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID)
 	if err != nil {
@@ -776,6 +776,24 @@ func TestService(t *testing.T) {
 				t.Fatalf("want no changeset specs attached to batch spec, but have %d", count)
 			}
 		})
+
+		t.Run("schedule is rejected", func(t *testing.T) {
+			opts := CreateBatchSpecOpts{
+				NamespaceUserID: admin.ID,
+				RawSpec: `
+name: scheduled
+steps:
+  - run: echo hello
+    container: alpine:3
+schedule:
+  cron: "@weekly"
+`,
+			}
+
+			if _, err := svc.CreateBatchSpec(adminCtx, opts); err != ErrScheduleRequiresServerSideExecution {
+				t.Fatalf("expected %s error but got %v", ErrScheduleRequiresServerSideExecution, err)
+			}
+		})
 	})
 
 	t.Run("CreateChangesetSpec", func(t *testing.T) {
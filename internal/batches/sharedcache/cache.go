@@ -0,0 +1,121 @@
+// Package sharedcache implements a cache of server-side batch spec execution
+// step results that is shared between all users of an instance.
+//
+// The per-user cache in batch_spec_execution_cache_entries is keyed by
+// cache.CacheKey.Key, which is only stable for a single user. The shared cache
+// is keyed by cache.CacheKey.SharedKey instead, which is content-addressed, so
+// that users running the same batch spec against the same repository revision
+// can reuse each other's step results rather than recomputing them on
+// executors.
+package sharedcache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxResultSize is the largest step result we will read from the store. Step
+// results contain the cumulative diff of a workspace, which can get large, but
+// we never want to load an unbounded object into memory.
+const maxResultSize = 128 << 20 // 128MB
+
+// Cache stores step results in a blob store. A nil *Cache is valid and never
+// finds or stores anything.
+type Cache struct {
+	store uploadstore.Store
+}
+
+// New returns a Cache backed by the blob store described by config.
+func New(ctx context.Context, observationCtx *observation.Context, config *Config) (*Cache, error) {
+	store, err := uploadstore.CreateLazy(ctx, uploadstore.Config{
+		Backend:      config.Backend,
+		ManageBucket: config.ManageBucket,
+		Bucket:       config.Bucket,
+		TTL:          config.TTL,
+		S3: uploadstore.S3Config{
+			Region:          config.S3Region,
+			Endpoint:        config.S3Endpoint,
+			UsePathStyle:    config.S3UsePathStyle,
+			AccessKeyID:     config.S3AccessKeyID,
+			SecretAccessKey: config.S3SecretAccessKey,
+			SessionToken:    config.S3SessionToken,
+		},
+		GCS: uploadstore.GCSConfig{
+			ProjectID:               config.GCSProjectID,
+			CredentialsFile:         config.GCSCredentialsFile,
+			CredentialsFileContents: config.GCSCredentialsFileContents,
+		},
+	}, uploadstore.NewOperations(observationCtx, "batches", "step_cache"))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithStore(store), nil
+}
+
+// NewWithStore returns a Cache backed by the given store.
+func NewWithStore(store uploadstore.Store) *Cache {
+	return &Cache{store: store}
+}
+
+// Enabled returns true if the shared step cache is enabled in the site
+// configuration and c is non-nil.
+func (c *Cache) Enabled() bool {
+	return c != nil && conf.Get().BatchChangesSharedStepCache
+}
+
+// Get returns the step result stored under the given shared key. The blob
+// store does not distinguish between missing and unreadable objects, so any
+// error should be treated as a cache miss.
+func (c *Cache) Get(ctx context.Context, key string) (*execution.AfterStepResult, error) {
+	if !c.Enabled() {
+		return nil, errors.New("shared step cache is disabled")
+	}
+
+	rc, err := c.store.Get(ctx, objectKey(key))
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(rc, maxResultSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > maxResultSize {
+		return nil, errors.Newf("shared step result %q exceeds %d bytes", key, maxResultSize)
+	}
+
+	var result execution.AfterStepResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling shared step result")
+	}
+	return &result, nil
+}
+
+// Set stores the step result under the given shared key.
+func (c *Cache) Set(ctx context.Context, key string, result *execution.AfterStepResult) error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return errors.Wrap(err, "marshalling shared step result")
+	}
+
+	_, err = c.store.Upload(ctx, objectKey(key), bytes.NewReader(raw))
+	return err
+}
+
+func objectKey(key string) string {
+	return "steps/" + key
+}
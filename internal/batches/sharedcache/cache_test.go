@@ -0,0 +1,74 @@
+package sharedcache
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	objects := map[string][]byte{}
+	store := mocks.NewMockStore()
+	store.UploadFunc.SetDefaultHook(func(_ context.Context, key string, r io.Reader) (int64, error) {
+		b, err := io.ReadAll(r)
+		objects[key] = b
+		return int64(len(b)), err
+	})
+	store.GetFunc.SetDefaultHook(func(_ context.Context, key string) (io.ReadCloser, error) {
+		b, ok := objects[key]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		return io.NopCloser(bytes.NewReader(b)), nil
+	})
+
+	cache := NewWithStore(store)
+	result := &execution.AfterStepResult{
+		Version:   2,
+		StepIndex: 1,
+		Diff:      []byte("diff --git a/README.md b/README.md"),
+		Stdout:    "hello",
+		Outputs:   map[string]any{"out": "put"},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		require.NoError(t, cache.Set(ctx, "key-step-1", result))
+		assert.Empty(t, objects)
+
+		_, err := cache.Get(ctx, "key-step-1")
+		assert.Error(t, err)
+	})
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{BatchChangesSharedStepCache: true}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	t.Run("enabled", func(t *testing.T) {
+		require.NoError(t, cache.Set(ctx, "key-step-1", result))
+		assert.Contains(t, objects, "steps/key-step-1")
+
+		have, err := cache.Get(ctx, "key-step-1")
+		require.NoError(t, err)
+		assert.Equal(t, result, have)
+
+		_, err = cache.Get(ctx, "missing-step-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("nil cache", func(t *testing.T) {
+		var c *Cache
+		assert.False(t, c.Enabled())
+		require.NoError(t, c.Set(ctx, "key-step-1", result))
+	})
+}
@@ -0,0 +1,413 @@
+package sources
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GiteaSource is a ChangesetSource for Gitea and Forgejo instances.
+type GiteaSource struct {
+	client *gitea.Client
+}
+
+var (
+	_ ForkableChangesetSource = GiteaSource{}
+	_ DraftChangesetSource    = GiteaSource{}
+)
+
+func NewGiteaSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing Gitea URL")
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	opts := httpClientCertificateOptions(nil, c.Certificate)
+
+	cli, err := cf.Doer(opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client := gitea.NewClient(svc.URN(), baseURL, &auth.OAuthBearerToken{Token: c.Token}, cli)
+	return &GiteaSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GiteaSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GiteaSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, newUnsupportedAuthenticatorError("GiteaSource", a)
+	}
+
+	return &GiteaSource{client: client}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GiteaSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedUser(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GiteaSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	number, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "converting external ID %q", cs.ExternalID)
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, repo, number)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GiteaSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	targetRepo := cs.TargetRepo.Metadata.(*gitea.Repository)
+
+	input := gitea.CreatePullRequestInput{
+		Title: cs.Title,
+		Body:  cs.Body,
+		Head:  s.headRef(cs),
+		Base:  gitdomain.AbbreviateRef(cs.BaseRef),
+	}
+
+	exists := false
+	pr, err := s.client.CreatePullRequest(ctx, targetRepo, input)
+	if err != nil {
+		if !gitea.IsConflict(err) {
+			return false, errors.Wrap(err, "creating pull request")
+		}
+
+		// Gitea doesn't tell us which pull request already exists beyond its
+		// internal ID, so we have to go and find it.
+		pr, err = s.findOpenPullRequest(ctx, targetRepo, cs)
+		if err != nil {
+			return false, errors.Wrap(err, "fetching existing pull request")
+		}
+		exists = true
+	}
+
+	if err := s.setChangesetMetadata(ctx, targetRepo, pr, cs); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// CreateDraftChangeset creates the given changeset on the code host in draft
+// mode. Gitea marks pull requests as drafts by prefixing their title.
+func (s GiteaSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	cs.Title = gitea.SetWorkInProgress(cs.Title)
+
+	exists, err := s.CreateChangeset(ctx, cs)
+	if err != nil {
+		return exists, err
+	}
+
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	// If it already exists, but is not a WIP, we need to update the title.
+	if exists && !gitea.IsWorkInProgress(pr.Title) {
+		if err := s.UpdateChangeset(ctx, cs); err != nil {
+			return exists, err
+		}
+	}
+	return exists, nil
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft
+// mode anymore.
+func (s GiteaSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	// UpdateChangeset keeps the prefix of pull requests that are currently
+	// drafts, so we have to update the title explicitly here.
+	cs.Title = gitea.UnsetWorkInProgress(cs.Title)
+	return s.editPullRequest(ctx, repo, pr.Number, cs, gitea.EditPullRequestInput{
+		Title: &cs.Title,
+	})
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "declined" on
+// Bitbucket Server).
+func (s GiteaSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	state := gitea.PullRequestStateClosed
+	return s.editPullRequest(ctx, repo, pr.Number, cs, gitea.EditPullRequestInput{
+		State: &state,
+	})
+}
+
+// UpdateChangeset can update Changesets.
+func (s GiteaSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	// Avoid accidentally undrafting the changeset by checking its current
+	// status.
+	title := cs.Title
+	if gitea.IsWorkInProgress(pr.Title) {
+		title = gitea.SetWorkInProgress(title)
+	}
+	base := gitdomain.AbbreviateRef(cs.BaseRef)
+
+	return s.editPullRequest(ctx, repo, pr.Number, cs, gitea.EditPullRequestInput{
+		Title: &title,
+		Body:  &cs.Body,
+		Base:  &base,
+	})
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GiteaSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	state := gitea.PullRequestStateOpen
+	return s.editPullRequest(ctx, repo, pr.Number, cs, gitea.EditPullRequestInput{
+		State: &state,
+	})
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GiteaSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	return s.client.CreateComment(ctx, repo, pr.Number, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, and the code host supports squash merges, the source
+// must attempt a squash merge. Otherwise, it is expected to perform a regular
+// merge. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError must be returned.
+func (s GiteaSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	style := gitea.MergeStyleMerge
+	if squash {
+		style = gitea.MergeStyleSquash
+	}
+
+	err := s.client.MergePullRequest(ctx, repo, pr.Number, gitea.MergePullRequestInput{
+		Style:                  style,
+		DeleteBranchAfterMerge: conf.Get().BatchChangesAutoDeleteBranch,
+	})
+	if err != nil {
+		if gitea.IsNotMergeable(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging pull request")
+	}
+
+	// The merge endpoint doesn't return the pull request, so we have to load
+	// it again to pick up the new state.
+	updated, err := s.client.GetPullRequest(ctx, repo, pr.Number)
+	if err != nil {
+		return errors.Wrap(err, "getting merged pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// GetFork returns a repo pointing to a fork of the target repo, ensuring that the fork
+// exists and creating it if it doesn't. If namespace is not provided, the fork will be in
+// the currently authenticated user's namespace. If name is not provided, the fork will be
+// named with the default Sourcegraph convention: "${original-namespace}-${original-name}"
+func (s GiteaSource) GetFork(ctx context.Context, targetRepo *types.Repo, ns, n *string) (*types.Repo, error) {
+	tr := targetRepo.Metadata.(*gitea.Repository)
+
+	var input gitea.ForkRepositoryInput
+	var namespace string
+	if ns != nil {
+		namespace = *ns
+		input.Organization = namespace
+	} else {
+		user, err := s.client.GetAuthenticatedUser(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting the current user")
+		}
+		namespace = user.Login
+	}
+
+	if n != nil {
+		input.Name = *n
+	} else {
+		input.Name = DefaultForkName(tr.Owner.Login, tr.Name)
+	}
+
+	// Figure out if we already have a fork of the repo in the given namespace.
+	if fork, err := s.client.GetRepo(ctx, namespace, input.Name); err == nil {
+		return s.checkAndCopy(targetRepo, fork)
+	} else if !errcode.IsNotFound(err) {
+		return nil, errors.Wrap(err, "checking for fork existence")
+	}
+
+	fork, err := s.client.ForkRepository(ctx, tr, input)
+	if err != nil {
+		return nil, errors.Wrap(err, "forking repository")
+	}
+
+	return s.checkAndCopy(targetRepo, fork)
+}
+
+func (s GiteaSource) BuildCommitOpts(repo *types.Repo, _ *btypes.Changeset, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) protocol.CreateCommitFromPatchRequest {
+	return BuildCommitOptsCommon(repo, spec, pushOpts)
+}
+
+func (s GiteaSource) checkAndCopy(targetRepo *types.Repo, fork *gitea.Repository) (*types.Repo, error) {
+	tr := targetRepo.Metadata.(*gitea.Repository)
+
+	if !fork.Fork || fork.Parent == nil {
+		return nil, errors.New("repo is not a fork")
+	} else if fork.Parent.ID != tr.ID {
+		return nil, errors.New("repo was not forked from the given parent")
+	}
+
+	// Now we make a copy of targetRepo, but with its sources and metadata updated to
+	// point to the fork
+	forkRepo, err := CopyRepoAsFork(targetRepo, fork, tr.FullName, fork.FullName)
+	if err != nil {
+		return nil, errors.Wrap(err, "updating target repo sources and metadata")
+	}
+
+	return forkRepo, nil
+}
+
+// headRef returns the head of the pull request as expected by the Gitea API,
+// which includes the owner of the fork if the changeset is pushed to one.
+func (s GiteaSource) headRef(cs *Changeset) string {
+	head := gitdomain.AbbreviateRef(cs.HeadRef)
+	if cs.RemoteRepo != cs.TargetRepo {
+		remote := cs.RemoteRepo.Metadata.(*gitea.Repository)
+		head = remote.Owner.Login + ":" + head
+	}
+	return head
+}
+
+// findOpenPullRequest returns the open pull request in repo with the same head
+// and base branches as the changeset.
+func (s GiteaSource) findOpenPullRequest(ctx context.Context, repo *gitea.Repository, cs *Changeset) (*gitea.PullRequest, error) {
+	head := gitdomain.AbbreviateRef(cs.HeadRef)
+	base := gitdomain.AbbreviateRef(cs.BaseRef)
+
+	remoteID := repo.ID
+	if cs.RemoteRepo != cs.TargetRepo {
+		remoteID = cs.RemoteRepo.Metadata.(*gitea.Repository).ID
+	}
+
+	for page := 1; ; page++ {
+		prs, hasNext, err := s.client.ListOpenPullRequests(ctx, repo, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			if pr.Head.Ref == head && pr.Base.Ref == base && pr.Head.RepoID == remoteID {
+				return pr, nil
+			}
+		}
+		if !hasNext {
+			return nil, errors.New("no open pull request found")
+		}
+	}
+}
+
+func (s GiteaSource) editPullRequest(ctx context.Context, repo *gitea.Repository, number int64, cs *Changeset, input gitea.EditPullRequestInput) error {
+	updated, err := s.client.EditPullRequest(ctx, repo, number, input)
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+func (s GiteaSource) annotatePullRequest(ctx context.Context, repo *gitea.Repository, pr *gitea.PullRequest) (*giteabatches.AnnotatedPullRequest, error) {
+	reviews, err := s.client.ListPullRequestReviews(ctx, repo, pr.Number)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting pull request reviews")
+	}
+
+	// Statuses are reported against the head commit, which lives in the fork
+	// if the pull request was opened from one.
+	statusRepo := repo
+	if pr.Head != nil && pr.Head.Repository != nil {
+		statusRepo = pr.Head.Repository
+	}
+	status, err := s.client.GetCombinedStatus(ctx, statusRepo, pr.Head.SHA)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting commit statuses")
+	}
+
+	return &giteabatches.AnnotatedPullRequest{
+		PullRequest: pr,
+		Reviews:     reviews,
+		Statuses:    status.Statuses,
+	}, nil
+}
+
+func (s GiteaSource) setChangesetMetadata(ctx context.Context, repo *gitea.Repository, pr *gitea.PullRequest, cs *Changeset) error {
+	apr, err := s.annotatePullRequest(ctx, repo, pr)
+	if err != nil {
+		return errors.Wrap(err, "annotating pull request")
+	}
+
+	if err := cs.SetMetadata(apr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
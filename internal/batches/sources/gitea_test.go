@@ -0,0 +1,423 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// The tests in this file run against https://gitea.sgdev.org. To update them,
+// set GITEA_TOKEN to a token of the sourcegraph-bot user and run the tests
+// with `-update GiteaSource`. Since most of them depend on the pull requests
+// created by earlier tests, they all need to be updated together, against a
+// fresh copy of the sourcegraph-testing/automation-testing repository.
+
+func TestGiteaSource_CreateChangeset(t *testing.T) {
+	repo := giteaTestRepo()
+
+	testCases := []struct {
+		name   string
+		cs     *Changeset
+		err    string
+		exists bool
+	}{
+		{
+			name: "success",
+			cs: &Changeset{
+				Title:      "This is a test PR",
+				Body:       "This is the description of the test PR",
+				HeadRef:    "refs/heads/test-create-changeset",
+				BaseRef:    "refs/heads/main",
+				RemoteRepo: repo,
+				TargetRepo: repo,
+				Changeset:  &btypes.Changeset{},
+			},
+			err: "<nil>",
+		},
+		{
+			name: "already exists",
+			cs: &Changeset{
+				Title:      "This is a test PR",
+				Body:       "This is the description of the test PR",
+				HeadRef:    "refs/heads/test-create-changeset",
+				BaseRef:    "refs/heads/main",
+				RemoteRepo: repo,
+				TargetRepo: repo,
+				Changeset:  &btypes.Changeset{},
+			},
+			// If the PR already exists we'll just return it, no error.
+			err:    "<nil>",
+			exists: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GiteaSource_CreateChangeset_" + strings.ReplaceAll(tc.name, " ", "_")
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			src, save := setupGiteaSource(t, ctx, tc.name)
+			defer save(t)
+
+			exists, err := src.CreateChangeset(ctx, tc.cs)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.exists, exists)
+			assert.Equal(t, "1", tc.cs.ExternalID)
+			assert.Equal(t, "refs/heads/test-create-changeset", tc.cs.ExternalBranch)
+
+			pr, ok := tc.cs.Changeset.Metadata.(*giteabatches.AnnotatedPullRequest)
+			if !ok {
+				t.Fatal("Metadata does not contain PR")
+			}
+
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), pr)
+		})
+	}
+}
+
+func TestGiteaSource_LoadChangeset(t *testing.T) {
+	testCases := []struct {
+		name       string
+		externalID string
+		err        string
+	}{
+		{name: "found", externalID: "1", err: "<nil>"},
+		{name: "not-found", externalID: "100000", err: "Changeset with external ID 100000 not found"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GiteaSource_LoadChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			src, save := setupGiteaSource(t, ctx, tc.name)
+			defer save(t)
+
+			cs := giteaTestChangeset(tc.externalID)
+			err := src.LoadChangeset(ctx, cs)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+			if err != nil {
+				return
+			}
+
+			pr := cs.Changeset.Metadata.(*giteabatches.AnnotatedPullRequest)
+			assert.Equal(t, "This is a test PR", pr.Title)
+			assert.Len(t, pr.Reviews, 2)
+			assert.Len(t, pr.Statuses, 2)
+		})
+	}
+}
+
+func TestGiteaSource_UpdateChangeset(t *testing.T) {
+	ctx := context.Background()
+	name := "GiteaSource_UpdateChangeset"
+	src, save := setupGiteaSource(t, ctx, name)
+	defer save(t)
+
+	cs := giteaTestChangeset("1")
+	require.NoError(t, src.LoadChangeset(ctx, cs))
+
+	cs.Title = "This is an updated test PR"
+	cs.Body = "This is the updated description of the test PR"
+	cs.BaseRef = "refs/heads/main"
+	require.NoError(t, src.UpdateChangeset(ctx, cs))
+
+	title, err := cs.Changeset.Title()
+	require.NoError(t, err)
+	assert.Equal(t, "This is an updated test PR", title)
+
+	body, err := cs.Changeset.Body()
+	require.NoError(t, err)
+	assert.Equal(t, "This is the updated description of the test PR", body)
+}
+
+func TestGiteaSource_CloseChangeset(t *testing.T) {
+	ctx := context.Background()
+	name := "GiteaSource_CloseChangeset"
+	src, save := setupGiteaSource(t, ctx, name)
+	defer save(t)
+
+	cs := giteaTestChangeset("1")
+	require.NoError(t, src.LoadChangeset(ctx, cs))
+	require.NoError(t, src.CloseChangeset(ctx, cs))
+
+	pr := cs.Changeset.Metadata.(*giteabatches.AnnotatedPullRequest)
+	assert.Equal(t, gitea.PullRequestStateClosed, pr.State)
+	assert.False(t, pr.HasMerged)
+}
+
+func TestGiteaSource_ReopenChangeset(t *testing.T) {
+	ctx := context.Background()
+	name := "GiteaSource_ReopenChangeset"
+	src, save := setupGiteaSource(t, ctx, name)
+	defer save(t)
+
+	cs := giteaTestChangeset("1")
+	require.NoError(t, src.LoadChangeset(ctx, cs))
+	require.NoError(t, src.ReopenChangeset(ctx, cs))
+
+	pr := cs.Changeset.Metadata.(*giteabatches.AnnotatedPullRequest)
+	assert.Equal(t, gitea.PullRequestStateOpen, pr.State)
+}
+
+func TestGiteaSource_CreateComment(t *testing.T) {
+	ctx := context.Background()
+	name := "GiteaSource_CreateComment"
+	src, save := setupGiteaSource(t, ctx, name)
+	defer save(t)
+
+	cs := giteaTestChangeset("1")
+	require.NoError(t, src.LoadChangeset(ctx, cs))
+	assert.NoError(t, src.CreateComment(ctx, cs, "test-comment"))
+}
+
+func TestGiteaSource_DraftChangeset(t *testing.T) {
+	ctx := context.Background()
+	name := "GiteaSource_DraftChangeset"
+	src, save := setupGiteaSource(t, ctx, name)
+	defer save(t)
+
+	repo := giteaTestRepo()
+	cs := &Changeset{
+		Title:      "This is a draft test PR",
+		Body:       "This is the description of the draft test PR",
+		HeadRef:    "refs/heads/test-draft-changeset",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{},
+	}
+
+	exists, err := src.CreateDraftChangeset(ctx, cs)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	title, err := cs.Changeset.Title()
+	require.NoError(t, err)
+	assert.Equal(t, "WIP: This is a draft test PR", title)
+
+	// Updating a draft must not undraft it.
+	cs.Title = "This is an updated draft test PR"
+	require.NoError(t, src.UpdateChangeset(ctx, cs))
+	title, err = cs.Changeset.Title()
+	require.NoError(t, err)
+	assert.Equal(t, "WIP: This is an updated draft test PR", title)
+
+	require.NoError(t, src.UndraftChangeset(ctx, cs))
+	title, err = cs.Changeset.Title()
+	require.NoError(t, err)
+	assert.Equal(t, "This is an updated draft test PR", title)
+}
+
+func TestGiteaSource_MergeChangeset(t *testing.T) {
+	testCases := []struct {
+		name         string
+		notMergeable bool
+	}{
+		{name: "success"},
+		// The pull request was merged by the previous test case.
+		{name: "not-mergeable", notMergeable: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GiteaSource_MergeChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			src, save := setupGiteaSource(t, ctx, tc.name)
+			defer save(t)
+
+			cs := giteaTestChangeset("1")
+			require.NoError(t, src.LoadChangeset(ctx, cs))
+
+			err := src.MergeChangeset(ctx, cs, true)
+			if tc.notMergeable {
+				var e ChangesetNotMergeableError
+				assert.True(t, errors.As(err, &e), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+
+			pr := cs.Changeset.Metadata.(*giteabatches.AnnotatedPullRequest)
+			assert.True(t, pr.HasMerged)
+			assert.Equal(t, gitea.PullRequestStateClosed, pr.State)
+		})
+	}
+}
+
+func TestGiteaSource_GetFork(t *testing.T) {
+	upstream := &types.Repo{
+		Name: "gitea.sgdev.org/upstream/tmux",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "10",
+			ServiceType: extsvc.VariantGitea.AsType(),
+			ServiceID:   "https://gitea.sgdev.org/",
+		},
+		Sources: map[string]*types.SourceInfo{
+			"extsvc:gitea:1": {
+				ID:       "extsvc:gitea:1",
+				CloneURL: "https://gitea.sgdev.org/upstream/tmux.git",
+			},
+		},
+		Metadata: &gitea.Repository{
+			ID:       10,
+			Owner:    &gitea.User{Login: "upstream"},
+			Name:     "tmux",
+			FullName: "upstream/tmux",
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		namespace *string
+		forkName  *string
+		wantName  string
+		err       string
+	}{
+		{
+			name:     "new fork in user namespace",
+			wantName: "sourcegraph-bot/upstream-tmux",
+			err:      "<nil>",
+		},
+		{
+			name:     "existing fork in user namespace",
+			wantName: "sourcegraph-bot/upstream-tmux",
+			err:      "<nil>",
+		},
+		{
+			name:      "new fork in organization",
+			namespace: pointers.Ptr("sourcegraph-testing"),
+			forkName:  pointers.Ptr("tmux-fork"),
+			wantName:  "sourcegraph-testing/tmux-fork",
+			err:       "<nil>",
+		},
+		{
+			name:      "not a fork",
+			namespace: pointers.Ptr("sourcegraph-testing"),
+			forkName:  pointers.Ptr("automation-testing"),
+			err:       "repo is not a fork",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GiteaSource_GetFork_" + strings.ReplaceAll(tc.name, " ", "_")
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			src, save := setupGiteaSource(t, ctx, tc.name)
+			defer save(t)
+
+			fork, err := src.GetFork(ctx, upstream, tc.namespace, tc.forkName)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+			if err != nil {
+				return
+			}
+
+			meta := fork.Metadata.(*gitea.Repository)
+			assert.Equal(t, tc.wantName, meta.FullName)
+			assert.Equal(t, int64(10), meta.Parent.ID)
+			assert.Equal(t, "https://gitea.sgdev.org/"+tc.wantName+".git", fork.Sources["extsvc:gitea:1"].CloneURL)
+		})
+	}
+}
+
+func TestGiteaSource_WithAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	src, err := NewGiteaSource(ctx, &types.ExternalService{
+		Kind: extsvc.VariantGitea.AsKind(),
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.GiteaConnection{
+			Url:   "https://gitea.sgdev.org",
+			Token: "token",
+		})),
+	}, nil)
+	require.NoError(t, err)
+
+	t.Run("supported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"OAuthBearerToken": &auth.OAuthBearerToken{Token: "abcdef"},
+			"BasicAuth":        &auth.BasicAuth{Username: "user", Password: "pass"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				out, err := src.WithAuthenticator(tc)
+				require.NoError(t, err)
+				assert.IsType(t, &GiteaSource{}, out)
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := src.WithAuthenticator(&auth.OAuthClient{})
+		assert.Error(t, err)
+		assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+	})
+}
+
+func setupGiteaSource(t *testing.T, ctx context.Context, name string) (*GiteaSource, func(testing.TB)) {
+	t.Helper()
+
+	cf, save := newClientFactory(t, name)
+
+	svc := &types.ExternalService{
+		Kind: extsvc.VariantGitea.AsKind(),
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.GiteaConnection{
+			Url:   "https://gitea.sgdev.org",
+			Token: os.Getenv("GITEA_TOKEN"),
+		})),
+	}
+
+	src, err := NewGiteaSource(ctx, svc, cf)
+	require.NoError(t, err)
+	return src, save
+}
+
+func giteaTestRepo() *types.Repo {
+	return &types.Repo{
+		Metadata: &gitea.Repository{
+			ID:       11,
+			Owner:    &gitea.User{Login: "sourcegraph-testing"},
+			Name:     "automation-testing",
+			FullName: "sourcegraph-testing/automation-testing",
+		},
+	}
+}
+
+func giteaTestChangeset(externalID string) *Changeset {
+	repo := giteaTestRepo()
+	return &Changeset{
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{ExternalID: externalID},
+	}
+}
+
@@ -10865,6 +10865,9 @@ type MockGitserverClient struct {
 	// RequestRepoCloneFunc is an instance of a mock function object
 	// controlling the behavior of the method RequestRepoClone.
 	RequestRepoCloneFunc *GitserverClientRequestRepoCloneFunc
+	// RequestRepoRefsUpdateFunc is an instance of a mock function object
+	// controlling the behavior of the method RequestRepoRefsUpdate.
+	RequestRepoRefsUpdateFunc *GitserverClientRequestRepoRefsUpdateFunc
 	// RequestRepoUpdateFunc is an instance of a mock function object
 	// controlling the behavior of the method RequestRepoUpdate.
 	RequestRepoUpdateFunc *GitserverClientRequestRepoUpdateFunc
@@ -11117,6 +11120,11 @@ func NewMockGitserverClient() *MockGitserverClient {
 				return
 			},
 		},
+		RequestRepoRefsUpdateFunc: &GitserverClientRequestRepoRefsUpdateFunc{
+			defaultHook: func(context.Context, api.RepoName, []protocol.RefUpdate) (r0 *protocol.RepoUpdateResponse, r1 error) {
+				return
+			},
+		},
 		RequestRepoUpdateFunc: &GitserverClientRequestRepoUpdateFunc{
 			defaultHook: func(context.Context, api.RepoName, time.Duration) (r0 *protocol.RepoUpdateResponse, r1 error) {
 				return
@@ -11384,6 +11392,11 @@ func NewStrictMockGitserverClient() *MockGitserverClient {
 				panic("unexpected invocation of MockGitserverClient.RequestRepoClone")
 			},
 		},
+		RequestRepoRefsUpdateFunc: &GitserverClientRequestRepoRefsUpdateFunc{
+			defaultHook: func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+				panic("unexpected invocation of MockGitserverClient.RequestRepoRefsUpdate")
+			},
+		},
 		RequestRepoUpdateFunc: &GitserverClientRequestRepoUpdateFunc{
 			defaultHook: func(context.Context, api.RepoName, time.Duration) (*protocol.RepoUpdateResponse, error) {
 				panic("unexpected invocation of MockGitserverClient.RequestRepoUpdate")
@@ -11562,6 +11575,9 @@ func NewMockGitserverClientFrom(i gitserver.Client) *MockGitserverClient {
 		RequestRepoCloneFunc: &GitserverClientRequestRepoCloneFunc{
 			defaultHook: i.RequestRepoClone,
 		},
+		RequestRepoRefsUpdateFunc: &GitserverClientRequestRepoRefsUpdateFunc{
+			defaultHook: i.RequestRepoRefsUpdate,
+		},
 		RequestRepoUpdateFunc: &GitserverClientRequestRepoUpdateFunc{
 			defaultHook: i.RequestRepoUpdate,
 		},
@@ -16731,6 +16747,121 @@ func (c GitserverClientRequestRepoCloneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GitserverClientRequestRepoRefsUpdateFunc describes the behavior when the
+// RequestRepoRefsUpdate method of the parent MockGitserverClient instance
+// is invoked.
+type GitserverClientRequestRepoRefsUpdateFunc struct {
+	defaultHook func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error)
+	hooks       []func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error)
+	history     []GitserverClientRequestRepoRefsUpdateFuncCall
+	mutex       sync.Mutex
+}
+
+// RequestRepoRefsUpdate delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockGitserverClient) RequestRepoRefsUpdate(v0 context.Context, v1 api.RepoName, v2 []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+	r0, r1 := m.RequestRepoRefsUpdateFunc.nextHook()(v0, v1, v2)
+	m.RequestRepoRefsUpdateFunc.appendCall(GitserverClientRequestRepoRefsUpdateFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// RequestRepoRefsUpdate method of the parent MockGitserverClient instance
+// is invoked and the hook queue is empty.
+func (f *GitserverClientRequestRepoRefsUpdateFunc) SetDefaultHook(hook func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RequestRepoRefsUpdate method of the parent MockGitserverClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverClientRequestRepoRefsUpdateFunc) PushHook(hook func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientRequestRepoRefsUpdateFunc) SetDefaultReturn(r0 *protocol.RepoUpdateResponse, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientRequestRepoRefsUpdateFunc) PushReturn(r0 *protocol.RepoUpdateResponse, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientRequestRepoRefsUpdateFunc) nextHook() func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientRequestRepoRefsUpdateFunc) appendCall(r0 GitserverClientRequestRepoRefsUpdateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitserverClientRequestRepoRefsUpdateFuncCall objects describing the
+// invocations of this function.
+func (f *GitserverClientRequestRepoRefsUpdateFunc) History() []GitserverClientRequestRepoRefsUpdateFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientRequestRepoRefsUpdateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientRequestRepoRefsUpdateFuncCall is an object that describes
+// an invocation of method RequestRepoRefsUpdate on an instance of
+// MockGitserverClient.
+type GitserverClientRequestRepoRefsUpdateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []protocol.RefUpdate
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *protocol.RepoUpdateResponse
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientRequestRepoRefsUpdateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientRequestRepoRefsUpdateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GitserverClientRequestRepoUpdateFunc describes the behavior when the
 // RequestRepoUpdate method of the parent MockGitserverClient instance is
 // invoked.
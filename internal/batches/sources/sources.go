@@ -426,7 +426,8 @@ func loadExternalService(ctx context.Context, s database.ExternalServiceStore, o
 			*schema.BitbucketCloudConnection,
 			*schema.AzureDevOpsConnection,
 			*schema.GerritConnection,
-			*schema.PerforceConnection:
+			*schema.PerforceConnection,
+			*schema.GiteaConnection:
 			return e, nil
 		}
 	}
@@ -452,6 +453,8 @@ func buildChangesetSource(ctx context.Context, tx SourcerStore, cf *httpcli.Fact
 		return NewGerritSource(ctx, externalService, cf)
 	case extsvc.KindPerforce:
 		return NewPerforceSource(ctx, gitserver.NewClient(tx.DatabaseDB()), externalService, cf)
+	case extsvc.VariantGitea.AsKind():
+		return NewGiteaSource(ctx, externalService, cf)
 	default:
 		return nil, errors.Errorf("unsupported external service type %q", extsvc.KindToType(externalService.Kind))
 	}
@@ -492,7 +495,7 @@ func loadSiteCredential(ctx context.Context, s SourcerStore, opts store.GetSiteC
 // with the specific quirks per code host.
 func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 	switch extSvcType {
-	case extsvc.TypeGitHub:
+	case extsvc.TypeGitHub, extsvc.VariantGitea.AsType():
 		u.User = url.User(token)
 
 	case extsvc.TypeGitLab:
@@ -513,7 +516,7 @@ func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	switch extSvcType {
 	case extsvc.TypeGitHub, extsvc.TypeGitLab:
 		return errors.New("need token to push commits to " + extSvcType)
-	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeAzureDevOps, extsvc.TypeGerrit:
+	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeAzureDevOps, extsvc.TypeGerrit, extsvc.VariantGitea.AsType():
 		u.User = url.UserPassword(username, password)
 
 	default:
@@ -10,9 +10,11 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	adobatches "github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 
 	"github.com/sourcegraph/go-diff/diff"
@@ -124,6 +126,8 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 		return computeAzureDevOpsBuildState(m)
 	case *gerritbatches.AnnotatedChange:
 		return computeGerritBuildState(m)
+	case *giteabatches.AnnotatedPullRequest:
+		return computeGiteaBuildState(m)
 	case *protocol.PerforceChangelistState:
 		// Perforce doesn't have builds built-in, its better to be explicit by still
 		// including this case for clarity.
@@ -345,6 +349,27 @@ func parseGerritBuildState(s string) btypes.ChangesetCheckState {
 	}
 }
 
+func computeGiteaBuildState(apr *giteabatches.AnnotatedPullRequest) btypes.ChangesetCheckState {
+	states := make([]btypes.ChangesetCheckState, 0, len(apr.Statuses))
+	for _, status := range apr.Statuses {
+		states = append(states, parseGiteaBuildState(status.State))
+	}
+	return combineCheckStates(states)
+}
+
+func parseGiteaBuildState(s gitea.CommitStatusState) btypes.ChangesetCheckState {
+	switch s {
+	case gitea.CommitStatusError, gitea.CommitStatusFailure:
+		return btypes.ChangesetCheckStateFailed
+	case gitea.CommitStatusPending:
+		return btypes.ChangesetCheckStatePending
+	case gitea.CommitStatusSuccess, gitea.CommitStatusWarning:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStateUnknown
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -639,6 +664,23 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Gerrit Change state: %s", m.Change.Status)
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		switch m.State {
+		case gitea.PullRequestStateClosed:
+			if m.HasMerged {
+				s = btypes.ChangesetExternalStateMerged
+			} else {
+				s = btypes.ChangesetExternalStateClosed
+			}
+		case gitea.PullRequestStateOpen:
+			if gitea.IsWorkInProgress(m.Title) {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gitea pull request state: %s", m.State)
+		}
 	case *protocol.PerforceChangelist:
 		switch m.State {
 		case protocol.PerforceChangelistStateClosed:
@@ -758,6 +800,28 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		// Reviews are returned in the order they were submitted, so the last
+		// review of each reviewer wins. Stale and dismissed reviews no longer
+		// count towards the review state.
+		latest := make(map[int64]gitea.ReviewState)
+		for _, review := range m.Reviews {
+			if review.Stale || review.Dismissed || review.Reviewer == nil {
+				continue
+			}
+			switch review.State {
+			case gitea.ReviewStateApproved, gitea.ReviewStateRequestChanges:
+				latest[review.Reviewer.ID] = review.State
+			}
+		}
+		for _, state := range latest {
+			switch state {
+			case gitea.ReviewStateApproved:
+				states[btypes.ChangesetReviewStateApproved] = true
+			case gitea.ReviewStateRequestChanges:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			}
+		}
 	case *protocol.PerforceChangelist:
 		states[btypes.ChangesetReviewStatePending] = true
 	default:
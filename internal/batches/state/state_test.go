@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	azuredevops2 "github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -17,6 +18,7 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -396,6 +398,61 @@ func TestComputeAzureDevOpsBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeGiteaBuildState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		statuses []gitea.CommitStatusState
+		want     btypes.ChangesetCheckState
+	}{
+		{
+			name:     "no statuses",
+			statuses: nil,
+			want:     btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:     "single success",
+			statuses: []gitea.CommitStatusState{gitea.CommitStatusSuccess},
+			want:     btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:     "single warning",
+			statuses: []gitea.CommitStatusState{gitea.CommitStatusWarning},
+			want:     btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:     "single error",
+			statuses: []gitea.CommitStatusState{gitea.CommitStatusError},
+			want:     btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:     "pending + success",
+			statuses: []gitea.CommitStatusState{gitea.CommitStatusPending, gitea.CommitStatusSuccess},
+			want:     btypes.ChangesetCheckStatePending,
+		},
+		{
+			name:     "failure + success",
+			statuses: []gitea.CommitStatusState{gitea.CommitStatusFailure, gitea.CommitStatusSuccess},
+			want:     btypes.ChangesetCheckStateFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &giteabatches.AnnotatedPullRequest{PullRequest: &gitea.PullRequest{}}
+			for i, state := range tc.statuses {
+				pr.Statuses = append(pr.Statuses, &gitea.CommitStatus{ID: int64(i), State: state})
+			}
+
+			have := computeGiteaBuildState(pr)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Parallel()
 
@@ -655,6 +712,40 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gitea - no reviews",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, "Title"),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gitea - latest review per reviewer wins",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, "Title",
+				&gitea.PullReview{Reviewer: &gitea.User{ID: 1}, State: gitea.ReviewStateRequestChanges},
+				&gitea.PullReview{Reviewer: &gitea.User{ID: 2}, State: gitea.ReviewStateComment},
+				&gitea.PullReview{Reviewer: &gitea.User{ID: 1}, State: gitea.ReviewStateApproved},
+			),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gitea - changes requested",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, "Title",
+				&gitea.PullReview{Reviewer: &gitea.User{ID: 1}, State: gitea.ReviewStateApproved},
+				&gitea.PullReview{Reviewer: &gitea.User{ID: 2}, State: gitea.ReviewStateRequestChanges},
+			),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name: "gitea - dismissed and stale reviews are ignored",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, "Title",
+				&gitea.PullReview{Reviewer: &gitea.User{ID: 1}, State: gitea.ReviewStateRequestChanges, Dismissed: true},
+				&gitea.PullReview{Reviewer: &gitea.User{ID: 2}, State: gitea.ReviewStateApproved, Stale: true},
+			),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
 	}
 
 	for i, tc := range tests {
@@ -894,6 +985,30 @@ func TestComputeExternalState(t *testing.T) {
 			history:   []changesetStatesAtTime{},
 			wantErr:   errors.New("unknown Perforce Change state: foobar"),
 		},
+		{
+			name:      "gitea open - no events",
+			changeset: giteaChangeset(daysAgo(10), gitea.PullRequestStateOpen, "Title"),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gitea work in progress - no events",
+			changeset: giteaChangeset(daysAgo(10), gitea.PullRequestStateOpen, "WIP: Title"),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "gitea closed - no events",
+			changeset: giteaChangeset(daysAgo(10), gitea.PullRequestStateClosed, "Title"),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "gitea merged - no events",
+			changeset: setGiteaMerged(giteaChangeset(daysAgo(10), gitea.PullRequestStateClosed, "Title")),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
 	}
 
 	for _, tc := range tests {
@@ -1061,6 +1176,25 @@ func perforceChangeset(updatedAt time.Time, state protocol.PerforceChangelistSta
 	}
 }
 
+func giteaChangeset(updatedAt time.Time, state gitea.PullRequestState, title string, reviews ...*gitea.PullReview) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.VariantGitea.AsType(),
+		UpdatedAt:           updatedAt,
+		Metadata: &giteabatches.AnnotatedPullRequest{
+			PullRequest: &gitea.PullRequest{
+				Title: title,
+				State: state,
+			},
+			Reviews: reviews,
+		},
+	}
+}
+
+func setGiteaMerged(c *btypes.Changeset) *btypes.Changeset {
+	c.Metadata.(*giteabatches.AnnotatedPullRequest).HasMerged = true
+	return c
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
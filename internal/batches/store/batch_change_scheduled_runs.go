@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// batchChangeScheduledRunInsertColumns is the list of
+// batch_change_scheduled_runs columns that are modified in
+// CreateBatchChangeScheduledRun and UpdateBatchChangeScheduledRun.
+var batchChangeScheduledRunInsertColumns = SQLColumns{
+	"batch_change_id",
+	"batch_spec_id",
+	"state",
+	"new_changesets",
+	"failure_message",
+	"scheduled_at",
+	"finished_at",
+	"created_at",
+	"updated_at",
+}
+
+// batchChangeScheduledRunColumns are used by the scheduled run related Store
+// methods to query and create scheduled runs.
+var batchChangeScheduledRunColumns = SQLColumns{
+	"batch_change_scheduled_runs.id",
+	"batch_change_scheduled_runs.batch_change_id",
+	"batch_change_scheduled_runs.batch_spec_id",
+	"batch_change_scheduled_runs.state",
+	"batch_change_scheduled_runs.new_changesets",
+	"batch_change_scheduled_runs.failure_message",
+	"batch_change_scheduled_runs.scheduled_at",
+	"batch_change_scheduled_runs.finished_at",
+	"batch_change_scheduled_runs.created_at",
+	"batch_change_scheduled_runs.updated_at",
+}
+
+// CreateBatchChangeScheduledRun creates the given scheduled run.
+func (s *Store) CreateBatchChangeScheduledRun(ctx context.Context, run *btypes.BatchChangeScheduledRun) (err error) {
+	ctx, _, endObservation := s.operations.createBatchChangeScheduledRun.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchChangeID", int(run.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = s.now()
+	}
+	if run.UpdatedAt.IsZero() {
+		run.UpdatedAt = run.CreatedAt
+	}
+	if run.State == "" {
+		run.State = btypes.BatchChangeScheduledRunStateResolving
+	}
+
+	q := sqlf.Sprintf(
+		createBatchChangeScheduledRunQueryFmtstr,
+		sqlf.Join(batchChangeScheduledRunInsertColumns.ToSqlf(), ", "),
+		run.BatchChangeID,
+		dbutil.NewNullInt64(run.BatchSpecID),
+		run.State,
+		run.NewChangesets,
+		dbutil.NewNullString(run.FailureMessage),
+		run.ScheduledAt,
+		dbutil.NullTimeColumn(run.FinishedAt),
+		run.CreatedAt,
+		run.UpdatedAt,
+		sqlf.Join(batchChangeScheduledRunColumns.ToSqlf(), ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeScheduledRun(run, sc)
+	})
+}
+
+var createBatchChangeScheduledRunQueryFmtstr = `
+INSERT INTO batch_change_scheduled_runs (%s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateBatchChangeScheduledRun updates the given scheduled run.
+func (s *Store) UpdateBatchChangeScheduledRun(ctx context.Context, run *btypes.BatchChangeScheduledRun) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchChangeScheduledRun.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(run.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	run.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateBatchChangeScheduledRunQueryFmtstr,
+		sqlf.Join(batchChangeScheduledRunInsertColumns.ToSqlf(), ", "),
+		run.BatchChangeID,
+		dbutil.NewNullInt64(run.BatchSpecID),
+		run.State,
+		run.NewChangesets,
+		dbutil.NewNullString(run.FailureMessage),
+		run.ScheduledAt,
+		dbutil.NullTimeColumn(run.FinishedAt),
+		run.CreatedAt,
+		run.UpdatedAt,
+		run.ID,
+		sqlf.Join(batchChangeScheduledRunColumns.ToSqlf(), ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchChangeScheduledRun(run, sc)
+	})
+}
+
+var updateBatchChangeScheduledRunQueryFmtstr = `
+UPDATE batch_change_scheduled_runs
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING %s
+`
+
+// ListBatchChangeScheduledRunsOpts captures the query options needed for
+// listing scheduled runs.
+type ListBatchChangeScheduledRunsOpts struct {
+	LimitOpts
+	Cursor int64
+
+	BatchChangeID int64
+	States        []btypes.BatchChangeScheduledRunState
+}
+
+// ListBatchChangeScheduledRuns lists scheduled runs with the given filters,
+// newest first.
+func (s *Store) ListBatchChangeScheduledRuns(ctx context.Context, opts ListBatchChangeScheduledRunsOpts) (rs []*btypes.BatchChangeScheduledRun, next int64, err error) {
+	ctx, _, endObservation := s.operations.listBatchChangeScheduledRuns.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := listBatchChangeScheduledRunsQuery(opts)
+
+	rs = make([]*btypes.BatchChangeScheduledRun, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var r btypes.BatchChangeScheduledRun
+		if err := scanBatchChangeScheduledRun(&r, sc); err != nil {
+			return err
+		}
+		rs = append(rs, &r)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(rs) == opts.DBLimit() {
+		next = rs[len(rs)-1].ID
+		rs = rs[:len(rs)-1]
+	}
+
+	return rs, next, err
+}
+
+var listBatchChangeScheduledRunsQueryFmtstr = `
+SELECT %s FROM batch_change_scheduled_runs
+WHERE %s
+ORDER BY id DESC
+`
+
+func listBatchChangeScheduledRunsQuery(opts ListBatchChangeScheduledRunsOpts) *sqlf.Query {
+	preds := batchChangeScheduledRunsPreds(opts.BatchChangeID, opts.States)
+
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_scheduled_runs.id <= %s", opts.Cursor))
+	}
+
+	return sqlf.Sprintf(
+		listBatchChangeScheduledRunsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(batchChangeScheduledRunColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// CountBatchChangeScheduledRunsOpts captures the query options needed for
+// counting scheduled runs.
+type CountBatchChangeScheduledRunsOpts struct {
+	BatchChangeID int64
+	States        []btypes.BatchChangeScheduledRunState
+}
+
+// CountBatchChangeScheduledRuns returns the number of scheduled runs matching
+// the given options.
+func (s *Store) CountBatchChangeScheduledRuns(ctx context.Context, opts CountBatchChangeScheduledRunsOpts) (count int, err error) {
+	ctx, _, endObservation := s.operations.countBatchChangeScheduledRuns.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.queryCount(ctx, sqlf.Sprintf(
+		countBatchChangeScheduledRunsQueryFmtstr,
+		sqlf.Join(batchChangeScheduledRunsPreds(opts.BatchChangeID, opts.States), "\n AND "),
+	))
+}
+
+var countBatchChangeScheduledRunsQueryFmtstr = `
+SELECT COUNT(*) FROM batch_change_scheduled_runs
+WHERE %s
+`
+
+func batchChangeScheduledRunsPreds(batchChangeID int64, states []btypes.BatchChangeScheduledRunState) []*sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+
+	if batchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_scheduled_runs.batch_change_id = %s", batchChangeID))
+	}
+
+	if len(states) > 0 {
+		qs := make([]*sqlf.Query, 0, len(states))
+		for _, state := range states {
+			qs = append(qs, sqlf.Sprintf("%s", state))
+		}
+		preds = append(preds, sqlf.Sprintf("batch_change_scheduled_runs.state IN (%s)", sqlf.Join(qs, ", ")))
+	}
+
+	return preds
+}
+
+func scanBatchChangeScheduledRun(r *btypes.BatchChangeScheduledRun, s dbutil.Scanner) error {
+	return s.Scan(
+		&r.ID,
+		&r.BatchChangeID,
+		&dbutil.NullInt64{N: &r.BatchSpecID},
+		&r.State,
+		&r.NewChangesets,
+		&dbutil.NullString{S: &r.FailureMessage},
+		&r.ScheduledAt,
+		&dbutil.NullTime{Time: &r.FinishedAt},
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	)
+}
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+func testStoreBatchChangeScheduledRuns(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	var batchChangeID int64 = 1234
+
+	runs := make([]*btypes.BatchChangeScheduledRun, 0, 3)
+	for i := 0; i < cap(runs); i++ {
+		run := &btypes.BatchChangeScheduledRun{
+			BatchChangeID: batchChangeID,
+			BatchSpecID:   int64(i + 567),
+			ScheduledAt:   clock.Now(),
+		}
+
+		switch i {
+		case 0:
+			run.State = btypes.BatchChangeScheduledRunStateApplied
+			run.NewChangesets = 3
+			run.FinishedAt = clock.Now()
+		case 1:
+			run.State = btypes.BatchChangeScheduledRunStateFailed
+			run.FailureMessage = "bad error"
+			run.FinishedAt = clock.Now()
+		case 2:
+			// Leave the state empty to check the default.
+			run.BatchChangeID = batchChangeID + 1
+		}
+
+		runs = append(runs, run)
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, run := range runs {
+			if err := s.CreateBatchChangeScheduledRun(ctx, run); err != nil {
+				t.Fatal(err)
+			}
+
+			have := run
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want := *have
+			want.CreatedAt = clock.Now()
+			want.UpdatedAt = clock.Now()
+
+			if diff := cmp.Diff(have, &want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+
+		if have, want := runs[2].State, btypes.BatchChangeScheduledRunStateResolving; have != want {
+			t.Fatalf("unexpected default state: have=%q want=%q", have, want)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.Add(1)
+
+		run := runs[2]
+		run.State = btypes.BatchChangeScheduledRunStateNeedsReview
+		run.NewChangesets = 5
+		run.FinishedAt = clock.Now()
+
+		if err := s.UpdateBatchChangeScheduledRun(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := run.UpdatedAt, clock.Now(); !have.Equal(want) {
+			t.Fatalf("unexpected updated at: have=%s want=%s", have, want)
+		}
+
+		have, _, err := s.ListBatchChangeScheduledRuns(ctx, ListBatchChangeScheduledRunsOpts{BatchChangeID: run.BatchChangeID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, []*btypes.BatchChangeScheduledRun{run}); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("ByBatchChangeID", func(t *testing.T) {
+			have, _, err := s.ListBatchChangeScheduledRuns(ctx, ListBatchChangeScheduledRunsOpts{BatchChangeID: batchChangeID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Newest first.
+			want := []*btypes.BatchChangeScheduledRun{runs[1], runs[0]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("ByStates", func(t *testing.T) {
+			have, _, err := s.ListBatchChangeScheduledRuns(ctx, ListBatchChangeScheduledRunsOpts{
+				States: []btypes.BatchChangeScheduledRunState{btypes.BatchChangeScheduledRunStateFailed},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := []*btypes.BatchChangeScheduledRun{runs[1]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimit", func(t *testing.T) {
+			for i := 1; i <= len(runs); i++ {
+				t.Run(strconv.Itoa(i), func(t *testing.T) {
+					have, next, err := s.ListBatchChangeScheduledRuns(ctx, ListBatchChangeScheduledRunsOpts{LimitOpts: LimitOpts{Limit: i}})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if len(have) != i {
+						t.Fatalf("unexpected number of runs: have=%d want=%d", len(have), i)
+					}
+
+					var wantNext int64
+					if i < len(runs) {
+						wantNext = runs[len(runs)-i-1].ID
+					}
+					if next != wantNext {
+						t.Fatalf("unexpected next cursor: have=%d want=%d", next, wantNext)
+					}
+				})
+			}
+		})
+
+		t.Run("WithCursor", func(t *testing.T) {
+			have, _, err := s.ListBatchChangeScheduledRuns(ctx, ListBatchChangeScheduledRunsOpts{Cursor: runs[1].ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := []*btypes.BatchChangeScheduledRun{runs[1], runs[0]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := s.CountBatchChangeScheduledRuns(ctx, CountBatchChangeScheduledRunsOpts{BatchChangeID: batchChangeID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 2 {
+			t.Fatalf("unexpected count: have=%d want=%d", count, 2)
+		}
+
+		count, err = s.CountBatchChangeScheduledRuns(ctx, CountBatchChangeScheduledRunsOpts{
+			States: []btypes.BatchChangeScheduledRunState{btypes.BatchChangeScheduledRunStateNeedsReview},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Fatalf("unexpected count: have=%d want=%d", count, 1)
+		}
+	})
+}
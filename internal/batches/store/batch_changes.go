@@ -571,6 +571,10 @@ type ListBatchChangesOpts struct {
 	RepoID api.RepoID
 
 	ExcludeDraftsNotOwnedByUserID int32
+
+	// OnlyScheduled limits the results to batch changes whose current batch
+	// spec was created server-side and includes a schedule.
+	OnlyScheduled bool
 }
 
 // ListBatchChanges lists batch changes with the given filters.
@@ -684,6 +688,16 @@ func listBatchChangesQuery(opts *ListBatchChangesOpts, repoAuthzConds *sqlf.Quer
 		)`, opts.RepoID, repoAuthzConds))
 	}
 
+	if opts.OnlyScheduled {
+		preds = append(preds, sqlf.Sprintf(`EXISTS(
+			SELECT 1 FROM batch_specs
+			WHERE
+				batch_specs.id = batch_changes.batch_spec_id AND
+				batch_specs.created_from_raw AND
+				batch_specs.spec ? 'schedule'
+		)`))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
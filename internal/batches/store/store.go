@@ -289,6 +289,11 @@ type operations struct {
 	markUsedBatchSpecExecutionCacheEntries *observation.Operation
 	createBatchSpecExecutionCacheEntry     *observation.Operation
 	cleanBatchSpecExecutionCacheEntries    *observation.Operation
+
+	createBatchChangeScheduledRun *observation.Operation
+	updateBatchChangeScheduledRun *observation.Operation
+	listBatchChangeScheduledRuns  *observation.Operation
+	countBatchChangeScheduledRuns *observation.Operation
 }
 
 var (
@@ -435,6 +440,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 			createBatchSpecExecutionCacheEntry:     op("CreateBatchSpecExecutionCacheEntry"),
 
 			cleanBatchSpecExecutionCacheEntries: op("CleanBatchSpecExecutionCacheEntries"),
+
+			createBatchChangeScheduledRun: op("CreateBatchChangeScheduledRun"),
+			updateBatchChangeScheduledRun: op("UpdateBatchChangeScheduledRun"),
+			listBatchChangeScheduledRuns:  op("ListBatchChangeScheduledRuns"),
+			countBatchChangeScheduledRuns: op("CountBatchChangeScheduledRuns"),
 		}
 	})
 
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sharedcache"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store/author"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -55,11 +56,15 @@ var batchSpecWorkspaceExecutionWorkerStoreOptions = dbworkerstore.Options[*btype
 
 // NewBatchSpecWorkspaceExecutionWorkerStore creates a dbworker store that
 // wraps the batch_spec_workspace_execution_jobs table.
-func NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx *observation.Context, handle basestore.TransactableHandle) dbworkerstore.Store[*btypes.BatchSpecWorkspaceExecutionJob] {
+//
+// If sharedCache is non-nil, step results of completed jobs that may be shared
+// between users are also uploaded to it.
+func NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx *observation.Context, handle basestore.TransactableHandle, sharedCache *sharedcache.Cache) dbworkerstore.Store[*btypes.BatchSpecWorkspaceExecutionJob] {
 	return &batchSpecWorkspaceExecutionWorkerStore{
 		Store:          dbworkerstore.New(observationCtx, handle, batchSpecWorkspaceExecutionWorkerStoreOptions),
 		observationCtx: observationCtx,
 		logger:         log.Scoped("batch-spec-workspace-execution-worker-store", "The worker store backing the executor queue for Batch Changes"),
+		sharedCache:    sharedCache,
 	}
 }
 
@@ -75,6 +80,8 @@ type batchSpecWorkspaceExecutionWorkerStore struct {
 	logger log.Logger
 
 	observationCtx *observation.Context
+
+	sharedCache *sharedcache.Cache
 }
 
 type markFinal func(ctx context.Context, tx dbworkerstore.Store[*btypes.BatchSpecWorkspaceExecutionJob]) (_ bool, err error)
@@ -148,6 +155,10 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 	if err != nil {
 		return false, err
 	}
+
+	// sharedResults are uploaded to the shared step cache once the job has
+	// been marked as complete.
+	var sharedResults map[string]*batcheslib.CacheAfterStepResultMetadata
 	defer func() {
 		// If no matching record was found, revert the tx.
 		// We don't want to persist side-effects.
@@ -163,6 +174,10 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 			s.logger.Error("Marking job complete failed, falling back to failure", log.Int("id", id), log.Error(err))
 			// Note: we don't use the transaction.
 			ok, err = s.Store.MarkFailed(ctx, id, err.Error(), options)
+			return
+		}
+		if ok {
+			s.shareStepResults(ctx, sharedResults)
 		}
 	}()
 
@@ -202,6 +217,7 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 	if err := storeCacheResults(ctx, tx, stepResults, batchSpec.UserID); err != nil {
 		return false, err
 	}
+	sharedResults = sharedStepResults(workspace, stepResults)
 
 	// Find the result for the last step. This is the one we'll be building the execution
 	// result from.
@@ -323,6 +339,32 @@ func storeCacheResults(ctx context.Context, tx *Store, results []*batcheslib.Cac
 	return nil
 }
 
+// sharedStepResults returns the results that may be shared with other users,
+// keyed by their key in the shared step cache.
+func sharedStepResults(workspace *btypes.BatchSpecWorkspace, results []*batcheslib.CacheAfterStepResultMetadata) map[string]*batcheslib.CacheAfterStepResultMetadata {
+	shared := make(map[string]*batcheslib.CacheAfterStepResultMetadata)
+	for _, result := range results {
+		if key, ok := workspace.SharedStepCacheKeys[result.Key]; ok {
+			shared[key] = result
+		}
+	}
+	return shared
+}
+
+// shareStepResults uploads the given results to the shared step cache. This is
+// best-effort: the results are already stored in the cache of the user, so
+// failures are only logged.
+func (s *batchSpecWorkspaceExecutionWorkerStore) shareStepResults(ctx context.Context, results map[string]*batcheslib.CacheAfterStepResultMetadata) {
+	if !s.sharedCache.Enabled() {
+		return
+	}
+	for key, result := range results {
+		if err := s.sharedCache.Set(ctx, key, &result.Value); err != nil {
+			s.logger.Warn("failed to upload step result to shared step cache", log.String("key", key), log.Error(err))
+		}
+	}
+}
+
 func extractCacheEntries(events []*batcheslib.LogEvent) (cacheEntries []*batcheslib.CacheAfterStepResultMetadata, err error) {
 	for _, e := range events {
 		if e.Operation == batcheslib.LogEventOperationCacheAfterStepResult {
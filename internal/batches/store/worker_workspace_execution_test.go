@@ -458,6 +458,31 @@ stdout: {"operation":"CACHE_AFTER_STEP_RESULT","timestamp":"2021-11-04T12:43:19.
 	}
 }
 
+func TestSharedStepResults(t *testing.T) {
+	results := []*batcheslib.CacheAfterStepResultMetadata{
+		{Key: "user-key-0", Value: execution.AfterStepResult{StepIndex: 0, Diff: []byte("diff-0")}},
+		{Key: "user-key-1", Value: execution.AfterStepResult{StepIndex: 1, Diff: []byte("diff-1")}},
+	}
+
+	t.Run("no shared keys", func(t *testing.T) {
+		got := sharedStepResults(&btypes.BatchSpecWorkspace{}, results)
+		if len(got) != 0 {
+			t.Fatalf("expected no shared results, got %v", got)
+		}
+	})
+
+	t.Run("only steps with shared keys", func(t *testing.T) {
+		workspace := &btypes.BatchSpecWorkspace{
+			SharedStepCacheKeys: map[string]string{"user-key-1": "shared-key-1"},
+		}
+		got := sharedStepResults(workspace, results)
+		want := map[string]*batcheslib.CacheAfterStepResultMetadata{"shared-key-1": results[1]}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("unexpected shared results (-want +got):\n%s", diff)
+		}
+	})
+}
+
 func TestBatchSpecWorkspaceExecutionWorkerStore_Dequeue_RoundRobin(t *testing.T) {
 	logger := logtest.Scoped(t)
 	ctx := context.Background()
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// BatchChangeScheduledRunState defines the possible states of a
+// BatchChangeScheduledRun.
+type BatchChangeScheduledRunState string
+
+// BatchChangeScheduledRunState constants.
+const (
+	// BatchChangeScheduledRunStateResolving means that the workspaces of the
+	// batch spec of the run are being resolved.
+	BatchChangeScheduledRunStateResolving BatchChangeScheduledRunState = "resolving"
+	// BatchChangeScheduledRunStateExecuting means that the batch spec of the
+	// run is being executed.
+	BatchChangeScheduledRunStateExecuting BatchChangeScheduledRunState = "executing"
+	// BatchChangeScheduledRunStateNeedsReview means that the batch spec of
+	// the run has been executed, but was not applied automatically.
+	BatchChangeScheduledRunStateNeedsReview BatchChangeScheduledRunState = "needs_review"
+	// BatchChangeScheduledRunStateApplied means that the batch spec of the
+	// run has been executed and applied to the batch change.
+	BatchChangeScheduledRunStateApplied BatchChangeScheduledRunState = "applied"
+	BatchChangeScheduledRunStateFailed  BatchChangeScheduledRunState = "failed"
+)
+
+// Valid returns true if the given BatchChangeScheduledRunState is valid.
+func (s BatchChangeScheduledRunState) Valid() bool {
+	switch s {
+	case BatchChangeScheduledRunStateResolving,
+		BatchChangeScheduledRunStateExecuting,
+		BatchChangeScheduledRunStateNeedsReview,
+		BatchChangeScheduledRunStateApplied,
+		BatchChangeScheduledRunStateFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Finished returns whether the run is in a terminal state.
+func (s BatchChangeScheduledRunState) Finished() bool {
+	return s != BatchChangeScheduledRunStateResolving && s != BatchChangeScheduledRunStateExecuting
+}
+
+// ToGraphQL returns the GraphQL representation of the state.
+func (s BatchChangeScheduledRunState) ToGraphQL() string { return strings.ToUpper(string(s)) }
+
+// BatchChangeScheduledRun is a run of a batch change whose batch spec has a
+// schedule. Every run creates a new batch spec from the raw spec of the
+// batch change and executes it.
+type BatchChangeScheduledRun struct {
+	ID            int64
+	BatchChangeID int64
+	// BatchSpecID is zero if the batch spec of the run has been deleted.
+	BatchSpecID int64
+
+	State          BatchChangeScheduledRunState
+	NewChangesets  int32
+	FailureMessage string
+
+	ScheduledAt time.Time
+	FinishedAt  time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
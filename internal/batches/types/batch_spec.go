@@ -4,7 +4,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/cronexpr"
+
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // NewBatchSpecFromRaw parses and validates the given rawSpec, and returns a BatchSpec
@@ -13,10 +16,22 @@ func NewBatchSpecFromRaw(rawSpec string) (_ *BatchSpec, err error) {
 	c := &BatchSpec{RawSpec: rawSpec}
 
 	c.Spec, err = batcheslib.ParseBatchSpec([]byte(rawSpec))
+	if err == nil && c.Spec.Schedule != nil {
+		_, err = ParseSchedule(c.Spec.Schedule)
+	}
 
 	return c, err
 }
 
+// ParseSchedule parses the cron expression of the given batch spec schedule.
+func ParseSchedule(schedule *batcheslib.Schedule) (*cronexpr.Expression, error) {
+	expr, err := cronexpr.Parse(schedule.Cron)
+	if err != nil {
+		return nil, batcheslib.NewValidationError(errors.Wrapf(err, "invalid schedule cron expression %q", schedule.Cron))
+	}
+	return expr, nil
+}
+
 type BatchSpec struct {
 	ID     int64
 	RandID string
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -168,3 +170,42 @@ func TestComputeBatchSpecState(t *testing.T) {
 		}
 	}
 }
+
+func TestNewBatchSpecFromRaw_Schedule(t *testing.T) {
+	const rawSpecFmt = `
+name: hello-world
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+schedule:
+  cron: %q
+`
+
+	t.Run("valid", func(t *testing.T) {
+		spec, err := NewBatchSpecFromRaw(fmt.Sprintf(rawSpecFmt, "0 6 * * 1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if spec.Spec.Schedule == nil || spec.Spec.Schedule.Cron != "0 6 * * 1" {
+			t.Fatalf("unexpected schedule %+v", spec.Spec.Schedule)
+		}
+	})
+
+	t.Run("invalid cron expression", func(t *testing.T) {
+		_, err := NewBatchSpecFromRaw(fmt.Sprintf(rawSpecFmt, "every monday"))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+		if !strings.Contains(err.Error(), "invalid schedule cron expression") {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+}
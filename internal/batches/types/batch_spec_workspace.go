@@ -31,6 +31,11 @@ type BatchSpecWorkspace struct {
 	// The persisted step cache results found for this execution.
 	StepCacheResults map[int]StepCacheResult
 
+	// SharedStepCacheKeys maps the per-user cache keys of the steps of this
+	// workspace to their keys in the shared step cache. Steps that cannot be
+	// shared between users are not included.
+	SharedStepCacheKeys map[string]string
+
 	// Skipped is true if this workspace doesn't need to run. (Has no steps, has
 	// cached result, ...)
 	Skipped bool
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -470,6 +471,20 @@ func (c *Changeset) SetMetadata(meta any) error {
 		c.ExternalServiceType = extsvc.TypeGerrit
 		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Change.Branch)
 		c.ExternalUpdatedAt = pr.Change.Updated
+	case *giteabatches.AnnotatedPullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(pr.Number, 10)
+		c.ExternalServiceType = extsvc.VariantGitea.AsType()
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Head.Ref)
+		c.ExternalUpdatedAt = pr.UpdatedAt
+
+		if pr.Head.RepoID != pr.Base.RepoID && pr.Head.Repository != nil {
+			c.ExternalForkNamespace = pr.Head.Repository.Owner.Login
+			c.ExternalForkName = pr.Head.Repository.Name
+		} else {
+			c.ExternalForkNamespace = ""
+			c.ExternalForkName = ""
+		}
 	case *protocol.PerforceChangelist:
 		c.Metadata = pr
 		c.ExternalID = pr.ID
@@ -510,6 +525,8 @@ func (c *Changeset) Title() (string, error) {
 		// Remove extra quotes added by the commit message
 		title = strings.TrimPrefix(strings.TrimSuffix(title, "\""), "\"")
 		return title, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Title, nil
 	case *protocol.PerforceChangelist:
 		return m.Title, nil
 	default:
@@ -537,6 +554,8 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.CreatedBy.UniqueName, nil
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Owner.Name, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.User.Login, nil
 	case *protocol.PerforceChangelist:
 		return m.Author, nil
 	default:
@@ -572,6 +591,10 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		return m.CreatedBy.UniqueName, nil
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Owner.Email, nil
+	case *giteabatches.AnnotatedPullRequest:
+		// Gitea only exposes the e-mail of other users to administrators or
+		// when the user made it public, so this may be empty.
+		return m.User.Email, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -596,6 +619,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreationDate
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Created
+	case *giteabatches.AnnotatedPullRequest:
+		return m.CreatedAt
 	case *protocol.PerforceChangelist:
 		return m.CreationDate
 	default:
@@ -619,6 +644,8 @@ func (c *Changeset) Body() (string, error) {
 	case *gerritbatches.AnnotatedChange:
 		// Gerrit doesn't really differentiate between title/description.
 		return m.Change.Subject, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Body, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -687,6 +714,8 @@ func (c *Changeset) URL() (s string, err error) {
 		return returnURL.String(), nil
 	case *gerritbatches.AnnotatedChange:
 		return m.CodeHostURL.JoinPath("c", url.PathEscape(m.Change.Project), "+", url.PathEscape(strconv.Itoa(m.Change.ChangeNumber))).String(), nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.HTMLURL, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -907,6 +936,10 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    reviewer,
 			})
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		// Reviews and commit statuses are evaluated straight from the
+		// metadata, so there are no events to create.
+		break
 	case *protocol.PerforceChangelist:
 		// We don't have any events we care about right now
 		break
@@ -932,6 +965,8 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return "", nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Head.SHA, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -955,6 +990,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return m.SourceRefName, nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		return "refs/heads/" + m.Head.Ref, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -979,6 +1016,8 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return "", nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Base.SHA, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -1002,6 +1041,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return m.TargetRefName, nil
 	case *gerritbatches.AnnotatedChange:
 		return "refs/heads/" + m.Change.Branch, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return "refs/heads/" + m.Base.Ref, nil
 	case *protocol.PerforceChangelist:
 		// TODO: @peterguy we may need to change this to something.
 		return "", nil
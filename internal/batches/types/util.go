@@ -23,12 +23,13 @@ type CodehostCapabilities map[CodehostCapability]bool
 // results.
 func GetSupportedExternalServices() map[string]CodehostCapabilities {
 	supportedExternalServices := map[string]CodehostCapabilities{
-		extsvc.TypeGitHub:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
-		extsvc.TypeBitbucketServer: {},
-		extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
-		extsvc.TypeBitbucketCloud:  {},
-		extsvc.TypeAzureDevOps:     {CodehostCapabilityDraftChangesets: true},
-		extsvc.TypeGerrit:          {CodehostCapabilityDraftChangesets: true},
+		extsvc.TypeGitHub:            {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
+		extsvc.TypeBitbucketServer:   {},
+		extsvc.TypeGitLab:            {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
+		extsvc.TypeBitbucketCloud:    {},
+		extsvc.TypeAzureDevOps:       {CodehostCapabilityDraftChangesets: true},
+		extsvc.TypeGerrit:            {CodehostCapabilityDraftChangesets: true},
+		extsvc.VariantGitea.AsType(): {CodehostCapabilityDraftChangesets: true},
 	}
 	if c := conf.Get(); c.ExperimentalFeatures != nil && c.ExperimentalFeatures.BatchChangesEnablePerforce {
 		supportedExternalServices[extsvc.TypePerforce] = CodehostCapabilities{}
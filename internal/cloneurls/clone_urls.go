@@ -120,6 +120,9 @@ func getRepoNameFromService(ctx context.Context, cloneURL string, svc *types.Ext
 	case *schema.GitHubConnection:
 		rs = reposource.GitHub{GitHubConnection: c}
 		host = c.Url
+	case *schema.GiteaConnection:
+		rs = reposource.Gitea{GiteaConnection: c}
+		host = c.Url
 	case *schema.GitLabConnection:
 		rs = reposource.GitLab{GitLabConnection: c}
 		host = c.Url
@@ -58,6 +58,24 @@ func TestInferRepositoryAndRevision(t *testing.T) {
 				repoName: "npm/myscope/mypackage",
 				revision: "v1.0.0",
 			},
+			{
+				pkg: dependencies.MinimialVersionedPackageRepo{
+					Scheme:  "scip-dotnet",
+					Name:    "Newtonsoft.Json",
+					Version: "13.0.3",
+				},
+				repoName: "nuget/Newtonsoft.Json",
+				revision: "v13.0.3",
+			},
+			{
+				pkg: dependencies.MinimialVersionedPackageRepo{
+					Scheme:  "scip-php",
+					Name:    "monolog/monolog",
+					Version: "v3.4.0",
+				},
+				repoName: "packagist/monolog/monolog",
+				revision: "v3.4.0",
+			},
 		}
 
 		for _, testCase := range testCases {
@@ -0,0 +1,74 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotNetGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("dotnet")
+
+	job := func(root string, targets ...string) config.IndexJob {
+		var localSteps []string
+		indexerArgs := []string{"scip-dotnet", "index"}
+		for _, target := range targets {
+			localSteps = append(localSteps, "dotnet restore "+target)
+			indexerArgs = append(indexerArgs, target)
+		}
+
+		return config.IndexJob{
+			Steps:       nil,
+			LocalSteps:  localSteps,
+			Root:        root,
+			Indexer:     expectedIndexerImage,
+			IndexerArgs: indexerArgs,
+			Outfile:     "index.scip",
+		}
+	}
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "solution with nested projects",
+			repositoryContents: map[string]string{
+				"App.sln":                  "",
+				"src/App/App.csproj":       "",
+				"src/Lib/Lib.csproj":       "",
+				"src/App/bin/Gen.csproj":   "",
+				"tests/App.Tests.csproj":   "",
+				"samples/Demo/Demo.csproj": "",
+			},
+			expected: []config.IndexJob{
+				job("", "App.sln"),
+			},
+		},
+		generatorTestCase{
+			description: "standalone projects",
+			repositoryContents: map[string]string{
+				"a/A.csproj": "",
+				"b/B.csproj": "",
+				"b/C.csproj": "",
+			},
+			expected: []config.IndexJob{
+				job("a", "A.csproj"),
+				job("b", "B.csproj", "C.csproj"),
+			},
+		},
+		generatorTestCase{
+			description: "solutions and projects outside of solution directories",
+			repositoryContents: map[string]string{
+				"backend/Backend.sln":            "",
+				"backend/Api/Api.csproj":         "",
+				"frontend/Web.sln":               "",
+				"frontend/Web.Admin.sln":         "",
+				"tools/Migrator/Migrator.csproj": "",
+			},
+			expected: []config.IndexJob{
+				job("backend", "Backend.sln"),
+				job("frontend", "Web.Admin.sln", "Web.sln"),
+				job("tools/Migrator", "Migrator.csproj"),
+			},
+		},
+	)
+}
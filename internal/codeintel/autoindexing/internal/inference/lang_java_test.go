@@ -8,13 +8,17 @@ import (
 )
 
 func autoJob(root string) config.IndexJob {
+	return buildToolJob(root, "auto")
+}
+
+func buildToolJob(root, buildTool string) config.IndexJob {
 	expectedIndexerImage, _ := libs.DefaultIndexerForLang("java")
 	return config.IndexJob{
 		Steps:       nil,
 		LocalSteps:  nil,
 		Root:        root,
 		Indexer:     expectedIndexerImage,
-		IndexerArgs: []string{"scip-java", "index", "--build-tool=auto"},
+		IndexerArgs: []string{"scip-java", "index", "--build-tool=" + buildTool},
 		Outfile:     "index.scip",
 	}
 }
@@ -95,6 +99,33 @@ func TestJavaGenerator(t *testing.T) {
 			},
 			expected: []config.IndexJob{},
 		},
+		generatorTestCase{
+			description: "Kotlin-only project with Gradle Kotlin DSL",
+			repositoryContents: map[string]string{
+				"settings.gradle.kts":                       "",
+				"app/build.gradle.kts":                      "",
+				"app/src/main/kotlin/com/example/App.kt":    "",
+				"app/src/main/kotlin/com/example/Config.kt": "",
+			},
+			expected: []config.IndexJob{buildToolJob("", "gradle")},
+		},
+		generatorTestCase{
+			description: "Mixed Kotlin and Java project with Gradle",
+			repositoryContents: map[string]string{
+				"build.gradle":                          "",
+				"src/main/kotlin/com/example/App.kt":    "",
+				"src/main/java/com/example/Legacy.java": "",
+			},
+			expected: singleTopLevelJob,
+		},
+		generatorTestCase{
+			description: "Kotlin-only project with Maven",
+			repositoryContents: map[string]string{
+				"pom.xml":                            "",
+				"src/main/kotlin/com/example/App.kt": "",
+			},
+			expected: singleTopLevelJob,
+		},
 		generatorTestCase{
 			description: "Nested JVM project with top-level build file",
 			repositoryContents: map[string]string{
@@ -0,0 +1,55 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("php")
+	expectedComposerImage, _ := libs.DefaultIndexerForLang("composer")
+
+	job := func(root string) config.IndexJob {
+		return config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    expectedComposerImage,
+					Commands: []string{"composer install --no-interaction --no-progress --no-scripts --ignore-platform-reqs"},
+				},
+			},
+			LocalSteps:  nil,
+			Root:        root,
+			Indexer:     expectedIndexerImage,
+			IndexerArgs: []string{"scip-php"},
+			Outfile:     "index.scip",
+		}
+	}
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "composer project",
+			repositoryContents: map[string]string{
+				"composer.json":                  "",
+				"composer.lock":                  "",
+				"src/Foo.php":                    "",
+				"vendor/acme/lib/composer.json":  "",
+				"packages/billing/composer.json": "",
+				"packages/billing/src/Bill.php":  "",
+			},
+			expected: []config.IndexJob{
+				job(""),
+				job("packages/billing"),
+			},
+		},
+		generatorTestCase{
+			description: "no composer project",
+			repositoryContents: map[string]string{
+				"index.php": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
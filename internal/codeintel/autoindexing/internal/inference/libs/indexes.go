@@ -17,8 +17,11 @@ type indexesAPI struct{}
 
 var defaultIndexers = map[string]string{
 	"clang":      "sourcegraph/lsif-clang",
+	"composer":   "composer",
+	"dotnet":     "sourcegraph/scip-dotnet",
 	"go":         "sourcegraph/scip-go",
 	"java":       "sourcegraph/scip-java",
+	"php":        "davidrjenni/scip-php",
 	"python":     "sourcegraph/scip-python",
 	"rust":       "sourcegraph/scip-rust",
 	"typescript": "sourcegraph/scip-typescript",
@@ -37,12 +40,26 @@ var defaultIndexerSHAs = map[string]string{
 	"sourcegraph/scip-ruby":       "sha256:0215a5596da9eee736ee7b24e401ad056e65b3bedc5330b3a096b00dd60aaeac",
 }
 
+// Indexers that are not (yet) pinned to a digest in defaultIndexerSHAs. These are
+// referenced by their latest tag instead until update-shas.sh has been run for them.
+var unpinnedIndexers = []string{
+	"composer",
+	"sourcegraph/scip-dotnet",
+	"davidrjenni/scip-php",
+}
+
 func DefaultIndexerForLang(language string) (string, bool) {
 	indexer, ok := defaultIndexers[language]
 	if !ok {
 		return "", false
 	}
 
+	for _, unpinned := range unpinnedIndexers {
+		if indexer == unpinned {
+			return fmt.Sprintf("%s:latest", indexer), true
+		}
+	}
+
 	sha, ok := defaultIndexerSHAs[indexer]
 	if !ok {
 		panic(fmt.Sprintf("no SHA set for indexer %q", indexer))
@@ -0,0 +1,104 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "dotnet"
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "bin",
+  pattern.new_path_segment "obj",
+})
+
+local has_extension = function(filepath, ext)
+  return string.sub(filepath, -string.len(ext)) == ext
+end
+
+-- Groups the given paths by their directory, each group sorted lexicographically. Uploads
+-- are keyed by their root and indexer, so all targets of a directory must be indexed by a
+-- single job: a second job with the same root would replace the first job's upload.
+local paths_by_dir = function(paths)
+  table.sort(paths)
+
+  local by_dir = {}
+  for i = 1, #paths do
+    local dir = path.dirname(paths[i])
+    if by_dir[dir] == nil then
+      by_dir[dir] = {}
+    end
+    table.insert(by_dir[dir], path.basename(paths[i]))
+  end
+
+  return by_dir
+end
+
+local make_job = function(root, targets)
+  -- NuGet packages are restored outside of the repository directory, so the
+  -- restore must happen in the same container as the indexer.
+  local local_steps = {}
+  local indexer_args = { "scip-dotnet", "index" }
+  for i = 1, #targets do
+    table.insert(local_steps, "dotnet restore " .. targets[i])
+    table.insert(indexer_args, targets[i])
+  end
+
+  return {
+    local_steps = local_steps,
+    root = root,
+    indexer = indexer,
+    indexer_args = indexer_args,
+    outfile = outfile,
+  }
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "sln",
+    pattern.new_path_extension "csproj",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when .sln or .csproj files exist. A job indexing every solution of a directory
+  -- is scheduled for each directory containing solution files, and a job indexing every
+  -- project of a directory for each directory containing project files that is not nested
+  -- under such a directory (projects of a solution are indexed with it).
+  generate = function(_, paths)
+    local solutions = {}
+    local projects = {}
+    for i = 1, #paths do
+      if has_extension(paths[i], ".sln") then
+        table.insert(solutions, paths[i])
+      else
+        table.insert(projects, paths[i])
+      end
+    end
+
+    local solutions_by_dir = paths_by_dir(solutions)
+    local projects_by_dir = paths_by_dir(projects)
+
+    local jobs = {}
+    for root, targets in pairs(solutions_by_dir) do
+      table.insert(jobs, make_job(root, targets))
+    end
+
+    for root, targets in pairs(projects_by_dir) do
+      local ancestors = path.ancestors(path.join(root, targets[1]))
+
+      local covered = false
+      for i = 1, #ancestors do
+        if solutions_by_dir[ancestors[i]] ~= nil then
+          covered = true
+          break
+        end
+      end
+
+      if not covered then
+        table.insert(jobs, make_job(root, targets))
+      end
+    end
+
+    return jobs
+  end,
+}
@@ -6,12 +6,24 @@ local is_project_structure_supported = function(base)
     ["pom.xml"] = true,
     ["build.gradle"] = true,
     ["build.gradle.kts"] = true,
+    ["settings.gradle.kts"] = true,
     ["build.sbt"] = true,
     ["build.sc"] = true,
   }
   return supported[base] ~= nil
 end
 
+local is_gradle_build_file = function(base)
+  local gradle = {
+    ["build.gradle"] = true,
+    ["build.gradle.kts"] = true,
+    ["gradlew"] = true,
+    ["settings.gradle"] = true,
+    ["settings.gradle.kts"] = true,
+  }
+  return gradle[base] ~= nil
+end
+
 local recognizer = require("sg.autoindex.recognizer")
 
 local java_indexer = require("sg.autoindex.indexes").get "java"
@@ -31,6 +43,8 @@ end
 -- 1. Identify build roots - paths that contain build files for any of the supported build tools
 -- 2. Among those build roots select only those that have any java/scala/kotlin files in there
 -- We are doing this to avoid creating an indexing job that will fail because there are no sources.
+-- Kotlin-only Gradle builds are indexed with the Gradle build tool selected explicitly, as scip-java
+-- only supports Kotlin through its Gradle integration and the root may contain other build files.
 return recognizer.new_path_recognizer {
   patterns = {
     -- Gradle
@@ -38,6 +52,7 @@ return recognizer.new_path_recognizer {
     pattern.new_path_basename("build.gradle.kts"),
     pattern.new_path_basename("gradlew"),
     pattern.new_path_basename("settings.gradle"),
+    pattern.new_path_basename("settings.gradle.kts"),
     -- Maven
     pattern.new_path_basename("pom.xml"),
     -- SBT
@@ -49,9 +64,14 @@ return recognizer.new_path_recognizer {
   },
   generate = function(api, paths)
     local unique_paths = {}
+    local gradle_roots = {}
 
     for i = 1, #paths do
       unique_paths[path.dirname(paths[i])] = true
+
+      if is_gradle_build_file(path.basename(paths[i])) then
+        gradle_roots[path.dirname(paths[i])] = true
+      end
     end
 
     local unique_paths_array = {}
@@ -73,7 +93,20 @@ return recognizer.new_path_recognizer {
           new_rooted_extension(project_root, "kt"),
         },
 
-        generate = function(_, _)
+        generate = function(_, source_paths)
+          local is_kotlin_only = #source_paths > 0
+          for j = 1, #source_paths do
+            if string.sub(source_paths[j], -3) ~= ".kt" then
+              is_kotlin_only = false
+              break
+            end
+          end
+
+          local build_tool = "auto"
+          if is_kotlin_only and gradle_roots[project_root] ~= nil then
+            build_tool = "gradle"
+          end
+
           local is_nested_root = project_root ~= ''
           local is_toplevel_root = project_root == ''
           local top_level_root_is_already_registerd =
@@ -86,7 +119,7 @@ return recognizer.new_path_recognizer {
             root = project_root,
             outfile = "index.scip",
             indexer = java_indexer,
-            indexer_args = { "scip-java", "index", "--build-tool=auto" },
+            indexer_args = { "scip-java", "index", "--build-tool=" .. build_tool },
           }
           -- top level root should be registered anyways if it has build files and source files
           if is_toplevel_root and (not this_root_already_registered) then
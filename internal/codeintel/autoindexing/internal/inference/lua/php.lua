@@ -0,0 +1,56 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "php"
+local composer = require("sg.autoindex.indexes").get "composer"
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "vendor",
+})
+
+-- Composer manifests of installed dependencies must never become project roots,
+-- even when the vendor directory is committed to the repository.
+local is_vendored = function(filepath)
+  return string.find("/" .. filepath, "/vendor/", 1, true) ~= nil
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "composer.json",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when composer.json files exist. Each Composer project is indexed
+  -- from its own root once its dependencies have been installed into vendor/,
+  -- which scip-php reads to resolve symbols defined by dependencies. The scip-php
+  -- image does not ship Composer, so dependencies are installed with the official
+  -- Composer image, which lacks most PHP extensions the project may require.
+  generate = function(_, paths)
+    local jobs = {}
+    for i = 1, #paths do
+      if not is_vendored(paths[i]) then
+        local root = path.dirname(paths[i])
+
+        table.insert(jobs, {
+          steps = {
+            {
+              root = root,
+              image = composer,
+              commands = { "composer install --no-interaction --no-progress --no-scripts --ignore-platform-reqs" },
+            },
+          },
+          root = root,
+          indexer = indexer,
+          indexer_args = { "scip-php" },
+          outfile = outfile,
+        })
+      end
+    end
+
+    return jobs
+  end,
+}
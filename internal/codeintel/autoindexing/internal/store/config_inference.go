@@ -85,8 +85,10 @@ local custom_recognizer = recognizer.new_path_recognizer {
 
 return require("sg.autoindex.config").new({
 	-- ["sg.clang"] = false,
+	-- ["sg.dotnet"] = false,
 	-- ["sg.go"] = false,
 	-- ["sg.java"] = false,
+	-- ["sg.php"] = false,
 	-- ["sg.python"] = false,
 	-- ["sg.ruby"] = false,
 	-- ["sg.rust"] = false,
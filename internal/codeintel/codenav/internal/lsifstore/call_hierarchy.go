@@ -0,0 +1,277 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetEnclosingCallables returns, for each of the given ranges of the given document, the function
+// or method whose body encloses it. The returned slice is parallel to the given ranges; ranges that
+// do not occur within a callable are paired with a zero-valued callable.
+func (s *store) GetEnclosingCallables(ctx context.Context, uploadID int, path string, ranges []shared.Range) (_ []shared.Callable, err error) {
+	ctx, trace, endObservation := s.operations.getEnclosingCallables.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+		attribute.String("path", path),
+		attribute.Int("numRanges", len(ranges)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		locationsDocumentQuery,
+		uploadID,
+		path,
+	)))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	callables := extractCallables(documentData.SCIPData)
+	trace.AddEvent("ExtractCallables", attribute.Int("numCallables", len(callables)))
+
+	enclosing := make([]shared.Callable, len(ranges))
+	for i, r := range ranges {
+		if callable, ok := findEnclosingCallable(callables, r.Start); ok {
+			callable.DumpID = uploadID
+			callable.Path = path
+			enclosing[i] = callable
+		}
+	}
+
+	return enclosing, nil
+}
+
+// GetCallSites returns the function or method defined at the given position along with the
+// references to other callables within its body. A false-valued flag is returned if no callable
+// is defined at the given position.
+func (s *store) GetCallSites(ctx context.Context, uploadID int, path string, line, character int) (_ shared.Callable, _ []shared.CallSite, _ bool, err error) {
+	ctx, trace, endObservation := s.operations.getCallSites.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+		attribute.String("path", path),
+		attribute.Int("line", line),
+		attribute.Int("character", character),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		locationsDocumentQuery,
+		uploadID,
+		path,
+	)))
+	if err != nil || !exists {
+		return shared.Callable{}, nil, false, err
+	}
+
+	callable, callSites, ok := extractCallSites(documentData.SCIPData, shared.Position{Line: line, Character: character})
+	if !ok {
+		return shared.Callable{}, nil, false, nil
+	}
+	trace.AddEvent("ExtractCallSites", attribute.Int("numCallSites", len(callSites)))
+
+	callable.DumpID = uploadID
+	callable.Path = path
+	return callable, callSites, true, nil
+}
+
+//
+//
+
+// extractCallables returns the functions and methods defined in the given document, ordered by the
+// position of their definition.
+//
+// The extent of a callable is the enclosing range of its definition occurrence. Not every indexer
+// emits enclosing ranges, so without one the extent is approximated: it starts at the definition
+// and ends where the next definition of a callable or a global symbol that is not nested within it
+// (such as a sibling type) starts, or at the end of the document. With this approximation,
+// references that occur at the top level after the body of a callable are attributed to the
+// preceding callable.
+func extractCallables(document *scip.Document) []shared.Callable {
+	isCallable := newCallableSymbolChecker(document)
+
+	type definition struct {
+		symbol    string
+		r         shared.Range
+		enclosing *shared.Range
+		callable  bool
+	}
+
+	var (
+		definitions []definition
+		end         shared.Position
+	)
+	for _, occurrence := range document.Occurrences {
+		r := translateRange(scip.NewRange(occurrence.Range))
+		if comparePositions(end, r.End) < 0 {
+			end = r.End
+		}
+
+		if occurrence.Symbol == "" || !scip.SymbolRole_Definition.Matches(occurrence) {
+			continue
+		}
+
+		callable := isCallable(occurrence.Symbol)
+		if !callable && scip.IsLocalSymbol(occurrence.Symbol) {
+			// Local, non-callable definitions (variables, parameters, ...) never end the
+			// extent of the enclosing callable.
+			continue
+		}
+
+		d := definition{symbol: occurrence.Symbol, r: r, callable: callable}
+		if n := len(occurrence.EnclosingRange); n == 3 || n == 4 {
+			enclosing := translateRange(scip.NewRange(occurrence.EnclosingRange))
+			d.enclosing = &enclosing
+		}
+		definitions = append(definitions, d)
+	}
+
+	sort.SliceStable(definitions, func(i, j int) bool {
+		return comparePositions(definitions[i].r.Start, definitions[j].r.Start) < 0
+	})
+
+	var callables []shared.Callable
+	for i, d := range definitions {
+		if !d.callable {
+			continue
+		}
+
+		if d.enclosing != nil {
+			callables = append(callables, shared.Callable{
+				Symbol:         d.symbol,
+				Range:          d.r,
+				EnclosingRange: *d.enclosing,
+			})
+			continue
+		}
+
+		extentEnd := end
+		for _, next := range definitions[i+1:] {
+			if next.callable || !strings.HasPrefix(next.symbol, d.symbol) {
+				extentEnd = next.r.Start
+				break
+			}
+		}
+
+		callables = append(callables, shared.Callable{
+			Symbol:         d.symbol,
+			Range:          d.r,
+			EnclosingRange: shared.Range{Start: d.r.Start, End: extentEnd},
+		})
+	}
+
+	return callables
+}
+
+// extractCallSites returns the callable defined at the given position of the given document along
+// with the references to callables that occur within its extent.
+func extractCallSites(document *scip.Document, position shared.Position) (shared.Callable, []shared.CallSite, bool) {
+	callables := extractCallables(document)
+
+	var (
+		callable shared.Callable
+		found    bool
+	)
+	definitionsBySymbol := make(map[string]shared.Range, len(callables))
+	for _, c := range callables {
+		if !found && rangeContainsPosition(c.Range, position) {
+			callable, found = c, true
+		}
+		if _, ok := definitionsBySymbol[c.Symbol]; !ok {
+			definitionsBySymbol[c.Symbol] = c.Range
+		}
+	}
+	if !found {
+		return shared.Callable{}, nil, false
+	}
+
+	isCallable := newCallableSymbolChecker(document)
+
+	var callSites []shared.CallSite
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" || scip.SymbolRole_Definition.Matches(occurrence) || !isCallable(occurrence.Symbol) {
+			continue
+		}
+
+		r := translateRange(scip.NewRange(occurrence.Range))
+		if comparePositions(r.Start, callable.Range.End) < 0 || comparePositions(r.Start, callable.EnclosingRange.End) >= 0 {
+			continue
+		}
+
+		callSite := shared.CallSite{Symbol: occurrence.Symbol, Range: r}
+		if definition, ok := definitionsBySymbol[occurrence.Symbol]; ok {
+			definition := definition
+			callSite.Definition = &definition
+		}
+		callSites = append(callSites, callSite)
+	}
+
+	sort.SliceStable(callSites, func(i, j int) bool {
+		return comparePositions(callSites[i].Range.Start, callSites[j].Range.Start) < 0
+	})
+
+	return callable, callSites, true
+}
+
+// findEnclosingCallable returns the callable whose extent contains the given position. The given
+// callables must be ordered by the position of their definition.
+func findEnclosingCallable(callables []shared.Callable, position shared.Position) (shared.Callable, bool) {
+	i := sort.Search(len(callables), func(i int) bool {
+		return comparePositions(callables[i].Range.Start, position) > 0
+	})
+	if i == 0 {
+		return shared.Callable{}, false
+	}
+
+	callable := callables[i-1]
+	if comparePositions(position, callable.EnclosingRange.End) > 0 {
+		return shared.Callable{}, false
+	}
+
+	return callable, true
+}
+
+// newCallableSymbolChecker returns a function that determines whether the given symbol refers to
+// a function or method. Symbols with method descriptors are callables, as are symbols that are
+// described as such by the symbol information of the given document.
+func newCallableSymbolChecker(document *scip.Document) func(symbol string) bool {
+	cache := map[string]bool{}
+	for _, symbol := range document.Symbols {
+		switch symbol.Kind {
+		case scip.SymbolInformation_Function, scip.SymbolInformation_Method, scip.SymbolInformation_Constructor:
+			cache[symbol.Symbol] = true
+		}
+	}
+
+	return func(symbol string) bool {
+		if callable, ok := cache[symbol]; ok {
+			return callable
+		}
+
+		callable := false
+		if !scip.IsLocalSymbol(symbol) {
+			if parsed, err := scip.ParseSymbol(symbol); err == nil && len(parsed.Descriptors) > 0 {
+				callable = parsed.Descriptors[len(parsed.Descriptors)-1].Suffix == scip.Descriptor_Method
+			}
+		}
+
+		cache[symbol] = callable
+		return callable
+	}
+}
+
+func rangeContainsPosition(r shared.Range, position shared.Position) bool {
+	return comparePositions(r.Start, position) <= 0 && comparePositions(position, r.End) <= 0
+}
+
+func comparePositions(a, b shared.Position) int {
+	if a.Line != b.Line {
+		return a.Line - b.Line
+	}
+	return a.Character - b.Character
+}
@@ -0,0 +1,157 @@
+package lsifstore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+)
+
+const (
+	testSymbolA = "scip-go gomod example v1 `example`/A()."
+	testSymbolB = "scip-go gomod example v1 `example`/B()."
+	testSymbolC = "scip-go gomod dep v2 `dep`/C()."
+	testSymbolT = "scip-go gomod example v1 `example`/T#"
+)
+
+// testCallHierarchyDocument describes the following document:
+//
+//	0: func A() {
+//	1:   B()
+//	2:   x := C()
+//	3: }
+//	4: type T struct{}
+//	5: func B() {
+//	6:   A()
+//	7: }
+var testCallHierarchyDocument = &scip.Document{
+	Occurrences: []*scip.Occurrence{
+		{Range: []int32{0, 5, 6}, Symbol: testSymbolA, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{1, 2, 3}, Symbol: testSymbolB},
+		{Range: []int32{2, 2, 3}, Symbol: "local 0", SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{2, 7, 8}, Symbol: testSymbolC},
+		{Range: []int32{4, 5, 6}, Symbol: testSymbolT, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{5, 5, 6}, Symbol: testSymbolB, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{6, 2, 3}, Symbol: testSymbolA},
+	},
+}
+
+func TestExtractCallables(t *testing.T) {
+	expected := []shared.Callable{
+		{Symbol: testSymbolA, Range: newRange(0, 5, 0, 6), EnclosingRange: newRange(0, 5, 4, 5)},
+		{Symbol: testSymbolB, Range: newRange(5, 5, 5, 6), EnclosingRange: newRange(5, 5, 6, 3)},
+	}
+	callables := extractCallables(testCallHierarchyDocument)
+	if diff := cmp.Diff(expected, callables); diff != "" {
+		t.Fatalf("unexpected callables (-want +got):\n%s", diff)
+	}
+
+	for _, testCase := range []struct {
+		position shared.Position
+		symbol   string
+	}{
+		{shared.Position{Line: 1, Character: 2}, testSymbolA},
+		{shared.Position{Line: 2, Character: 7}, testSymbolA},
+		{shared.Position{Line: 6, Character: 2}, testSymbolB},
+		{shared.Position{Line: 0, Character: 0}, ""},
+		{shared.Position{Line: 9, Character: 0}, ""},
+	} {
+		callable, _ := findEnclosingCallable(callables, testCase.position)
+		if callable.Symbol != testCase.symbol {
+			t.Errorf("unexpected enclosing callable at %v: want=%q have=%q", testCase.position, testCase.symbol, callable.Symbol)
+		}
+	}
+}
+
+func TestExtractCallSites(t *testing.T) {
+	definitionB := newRange(5, 5, 5, 6)
+	definitionA := newRange(0, 5, 0, 6)
+
+	for _, testCase := range []struct {
+		name              string
+		position          shared.Position
+		expectedFound     bool
+		expectedSymbol    string
+		expectedCallSites []shared.CallSite
+	}{
+		{
+			name:           "A",
+			position:       shared.Position{Line: 0, Character: 5},
+			expectedFound:  true,
+			expectedSymbol: testSymbolA,
+			expectedCallSites: []shared.CallSite{
+				{Symbol: testSymbolB, Range: newRange(1, 2, 1, 3), Definition: &definitionB},
+				{Symbol: testSymbolC, Range: newRange(2, 7, 2, 8)},
+			},
+		},
+		{
+			name:           "B",
+			position:       shared.Position{Line: 5, Character: 6},
+			expectedFound:  true,
+			expectedSymbol: testSymbolB,
+			expectedCallSites: []shared.CallSite{
+				{Symbol: testSymbolA, Range: newRange(6, 2, 6, 3), Definition: &definitionA},
+			},
+		},
+		{
+			name:          "reference",
+			position:      shared.Position{Line: 1, Character: 2},
+			expectedFound: false,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			callable, callSites, found := extractCallSites(testCallHierarchyDocument, testCase.position)
+			if found != testCase.expectedFound {
+				t.Fatalf("unexpected found flag: want=%v have=%v", testCase.expectedFound, found)
+			}
+			if callable.Symbol != testCase.expectedSymbol {
+				t.Errorf("unexpected callable: want=%q have=%q", testCase.expectedSymbol, callable.Symbol)
+			}
+			if diff := cmp.Diff(testCase.expectedCallSites, callSites); diff != "" {
+				t.Errorf("unexpected call sites (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// testEnclosingRangesDocument describes the following document, whose definitions record the
+// ranges enclosing them:
+//
+//	0: def A():
+//	1:   B()
+//	2: B()
+//	3: def B():
+//	4:   pass
+var testEnclosingRangesDocument = &scip.Document{
+	Occurrences: []*scip.Occurrence{
+		{Range: []int32{0, 4, 5}, Symbol: testSymbolA, SymbolRoles: int32(scip.SymbolRole_Definition), EnclosingRange: []int32{0, 0, 1, 5}},
+		{Range: []int32{1, 2, 3}, Symbol: testSymbolB},
+		{Range: []int32{2, 0, 1}, Symbol: testSymbolB},
+		{Range: []int32{3, 4, 5}, Symbol: testSymbolB, SymbolRoles: int32(scip.SymbolRole_Definition), EnclosingRange: []int32{3, 0, 4, 6}},
+	},
+}
+
+func TestExtractCallSitesEnclosingRanges(t *testing.T) {
+	expected := []shared.Callable{
+		{Symbol: testSymbolA, Range: newRange(0, 4, 0, 5), EnclosingRange: newRange(0, 0, 1, 5)},
+		{Symbol: testSymbolB, Range: newRange(3, 4, 3, 5), EnclosingRange: newRange(3, 0, 4, 6)},
+	}
+	if diff := cmp.Diff(expected, extractCallables(testEnclosingRangesDocument)); diff != "" {
+		t.Fatalf("unexpected callables (-want +got):\n%s", diff)
+	}
+
+	// The top-level reference to B on line 2 follows the body of A and must not be attributed to it
+	definitionB := newRange(3, 4, 3, 5)
+	callable, callSites, found := extractCallSites(testEnclosingRangesDocument, shared.Position{Line: 0, Character: 4})
+	if !found || callable.Symbol != testSymbolA {
+		t.Fatalf("unexpected callable: found=%v symbol=%q", found, callable.Symbol)
+	}
+	expectedCallSites := []shared.CallSite{
+		{Symbol: testSymbolB, Range: newRange(1, 2, 1, 3), Definition: &definitionB},
+	}
+	if diff := cmp.Diff(expectedCallSites, callSites); diff != "" {
+		t.Errorf("unexpected call sites (-want +got):\n%s", diff)
+	}
+}
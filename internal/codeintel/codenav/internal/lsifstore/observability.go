@@ -21,6 +21,8 @@ type operations struct {
 	getHover                   *observation.Operation
 	getDiagnostics             *observation.Operation
 	scipDocument               *observation.Operation
+	getEnclosingCallables      *observation.Operation
+	getCallSites               *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -57,5 +59,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getHover:                   op("GetHover"),
 		getDiagnostics:             op("GetDiagnostics"),
 		scipDocument:               op("SCIPDocument"),
+		getEnclosingCallables:      op("GetEnclosingCallables"),
+		getCallSites:               op("GetCallSites"),
 	}
 }
@@ -35,6 +35,10 @@ type LsifStore interface {
 	GetDiagnostics(ctx context.Context, bundleID int, prefix string, limit, offset int) ([]shared.Diagnostic, int, error)
 	SCIPDocument(ctx context.Context, id int, path string) (_ *scip.Document, err error)
 
+	// Call hierarchy
+	GetEnclosingCallables(ctx context.Context, uploadID int, path string, ranges []shared.Range) ([]shared.Callable, error)
+	GetCallSites(ctx context.Context, uploadID int, path string, line, character int) (shared.Callable, []shared.CallSite, bool, error)
+
 	// Extraction methods
 	ExtractDefinitionLocationsFromPosition(ctx context.Context, locationKey LocationKey) ([]shared.Location, []string, error)
 	ExtractReferenceLocationsFromPosition(ctx context.Context, locationKey LocationKey) ([]shared.Location, []string, error)
@@ -41,12 +41,18 @@ type MockLsifStore struct {
 	// GetBulkMonikerLocationsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetBulkMonikerLocations.
 	GetBulkMonikerLocationsFunc *LsifStoreGetBulkMonikerLocationsFunc
+	// GetCallSitesFunc is an instance of a mock function object controlling
+	// the behavior of the method GetCallSites.
+	GetCallSitesFunc *LsifStoreGetCallSitesFunc
 	// GetDefinitionLocationsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDefinitionLocations.
 	GetDefinitionLocationsFunc *LsifStoreGetDefinitionLocationsFunc
 	// GetDiagnosticsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDiagnostics.
 	GetDiagnosticsFunc *LsifStoreGetDiagnosticsFunc
+	// GetEnclosingCallablesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetEnclosingCallables.
+	GetEnclosingCallablesFunc *LsifStoreGetEnclosingCallablesFunc
 	// GetHoverFunc is an instance of a mock function object controlling the
 	// behavior of the method GetHover.
 	GetHoverFunc *LsifStoreGetHoverFunc
@@ -113,6 +119,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetCallSitesFunc: &LsifStoreGetCallSitesFunc{
+			defaultHook: func(context.Context, int, string, int, int) (r0 shared.Callable, r1 []shared.CallSite, r2 bool, r3 error) {
+				return
+			},
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) (r0 []shared.Location, r1 int, r2 error) {
 				return
@@ -123,6 +134,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetEnclosingCallablesFunc: &LsifStoreGetEnclosingCallablesFunc{
+			defaultHook: func(context.Context, int, string, []shared.Range) (r0 []shared.Callable, r1 error) {
+				return
+			},
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: func(context.Context, int, string, int, int) (r0 string, r1 shared.Range, r2 bool, r3 error) {
 				return
@@ -210,6 +226,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetBulkMonikerLocations")
 			},
 		},
+		GetCallSitesFunc: &LsifStoreGetCallSitesFunc{
+			defaultHook: func(context.Context, int, string, int, int) (shared.Callable, []shared.CallSite, bool, error) {
+				panic("unexpected invocation of MockLsifStore.GetCallSites")
+			},
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
 				panic("unexpected invocation of MockLsifStore.GetDefinitionLocations")
@@ -220,6 +241,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetDiagnostics")
 			},
 		},
+		GetEnclosingCallablesFunc: &LsifStoreGetEnclosingCallablesFunc{
+			defaultHook: func(context.Context, int, string, []shared.Range) ([]shared.Callable, error) {
+				panic("unexpected invocation of MockLsifStore.GetEnclosingCallables")
+			},
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: func(context.Context, int, string, int, int) (string, shared.Range, bool, error) {
 				panic("unexpected invocation of MockLsifStore.GetHover")
@@ -297,12 +323,18 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		GetBulkMonikerLocationsFunc: &LsifStoreGetBulkMonikerLocationsFunc{
 			defaultHook: i.GetBulkMonikerLocations,
 		},
+		GetCallSitesFunc: &LsifStoreGetCallSitesFunc{
+			defaultHook: i.GetCallSites,
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: i.GetDefinitionLocations,
 		},
 		GetDiagnosticsFunc: &LsifStoreGetDiagnosticsFunc{
 			defaultHook: i.GetDiagnostics,
 		},
+		GetEnclosingCallablesFunc: &LsifStoreGetEnclosingCallablesFunc{
+			defaultHook: i.GetEnclosingCallables,
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: i.GetHover,
 		},
@@ -930,6 +962,129 @@ func (c LsifStoreGetBulkMonikerLocationsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LsifStoreGetCallSitesFunc describes the behavior when the GetCallSites
+// method of the parent MockLsifStore instance is invoked.
+type LsifStoreGetCallSitesFunc struct {
+	defaultHook func(context.Context, int, string, int, int) (shared.Callable, []shared.CallSite, bool, error)
+	hooks       []func(context.Context, int, string, int, int) (shared.Callable, []shared.CallSite, bool, error)
+	history     []LsifStoreGetCallSitesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCallSites delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLsifStore) GetCallSites(v0 context.Context, v1 int, v2 string, v3 int, v4 int) (shared.Callable, []shared.CallSite, bool, error) {
+	r0, r1, r2, r3 := m.GetCallSitesFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetCallSitesFunc.appendCall(LsifStoreGetCallSitesFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the GetCallSites method
+// of the parent MockLsifStore instance is invoked and the hook queue is
+// empty.
+func (f *LsifStoreGetCallSitesFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) (shared.Callable, []shared.CallSite, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCallSites method of the parent MockLsifStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *LsifStoreGetCallSitesFunc) PushHook(hook func(context.Context, int, string, int, int) (shared.Callable, []shared.CallSite, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetCallSitesFunc) SetDefaultReturn(r0 shared.Callable, r1 []shared.CallSite, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) (shared.Callable, []shared.CallSite, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetCallSitesFunc) PushReturn(r0 shared.Callable, r1 []shared.CallSite, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, int, string, int, int) (shared.Callable, []shared.CallSite, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *LsifStoreGetCallSitesFunc) nextHook() func(context.Context, int, string, int, int) (shared.Callable, []shared.CallSite, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetCallSitesFunc) appendCall(r0 LsifStoreGetCallSitesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetCallSitesFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetCallSitesFunc) History() []LsifStoreGetCallSitesFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetCallSitesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetCallSitesFuncCall is an object that describes an invocation
+// of method GetCallSites on an instance of MockLsifStore.
+type LsifStoreGetCallSitesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.Callable
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 []shared.CallSite
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetCallSitesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetCallSitesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
 // LsifStoreGetDefinitionLocationsFunc describes the behavior when the
 // GetDefinitionLocations method of the parent MockLsifStore instance is
 // invoked.
@@ -1179,6 +1334,122 @@ func (c LsifStoreGetDiagnosticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LsifStoreGetEnclosingCallablesFunc describes the behavior when the
+// GetEnclosingCallables method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetEnclosingCallablesFunc struct {
+	defaultHook func(context.Context, int, string, []shared.Range) ([]shared.Callable, error)
+	hooks       []func(context.Context, int, string, []shared.Range) ([]shared.Callable, error)
+	history     []LsifStoreGetEnclosingCallablesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetEnclosingCallables delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetEnclosingCallables(v0 context.Context, v1 int, v2 string, v3 []shared.Range) ([]shared.Callable, error) {
+	r0, r1 := m.GetEnclosingCallablesFunc.nextHook()(v0, v1, v2, v3)
+	m.GetEnclosingCallablesFunc.appendCall(LsifStoreGetEnclosingCallablesFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetEnclosingCallables method of the parent MockLsifStore instance is
+// invoked and the hook queue is empty.
+func (f *LsifStoreGetEnclosingCallablesFunc) SetDefaultHook(hook func(context.Context, int, string, []shared.Range) ([]shared.Callable, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetEnclosingCallables method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreGetEnclosingCallablesFunc) PushHook(hook func(context.Context, int, string, []shared.Range) ([]shared.Callable, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetEnclosingCallablesFunc) SetDefaultReturn(r0 []shared.Callable, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, []shared.Range) ([]shared.Callable, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetEnclosingCallablesFunc) PushReturn(r0 []shared.Callable, r1 error) {
+	f.PushHook(func(context.Context, int, string, []shared.Range) ([]shared.Callable, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetEnclosingCallablesFunc) nextHook() func(context.Context, int, string, []shared.Range) ([]shared.Callable, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetEnclosingCallablesFunc) appendCall(r0 LsifStoreGetEnclosingCallablesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetEnclosingCallablesFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetEnclosingCallablesFunc) History() []LsifStoreGetEnclosingCallablesFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetEnclosingCallablesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetEnclosingCallablesFuncCall is an object that describes an
+// invocation of method GetEnclosingCallables on an instance of
+// MockLsifStore.
+type LsifStoreGetEnclosingCallablesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []shared.Range
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Callable
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetEnclosingCallablesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetEnclosingCallablesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LsifStoreGetHoverFunc describes the behavior when the GetHover method of
 // the parent MockLsifStore instance is invoked.
 type LsifStoreGetHoverFunc struct {
@@ -0,0 +1,239 @@
+package codenav
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxCallDefinitions is the maximum number of definitions resolved for the requested function
+// or method, and for each caller or callee of a call hierarchy.
+const maxCallDefinitions = 10
+
+// GetIncomingCalls returns the functions and methods calling the function or method at the given
+// position, along with the call sites within each of them.
+//
+// The references to the requested symbol are traversed exactly like NewGetReferences does, across
+// repositories via their monikers, and each reference is attributed to the callable enclosing it.
+// A page of results therefore covers a page of references: a caller with call sites on several
+// pages is returned on each of them. References outside of any callable are omitted.
+func (s *Service) GetIncomingCalls(
+	ctx context.Context,
+	args PositionalRequestArgs,
+	requestState RequestState,
+	cursor Cursor,
+) (_ []Call, nextCursor Cursor, err error) {
+	references, nextCursor, err := s.gatherLocations(
+		ctx, args, requestState, cursor,
+
+		s.operations.getIncomingCalls, // operation
+		"references",                  // tableName
+		true,                          // includeReferencingIndexes
+		LocationExtractorFunc(s.lsifstore.ExtractReferenceLocationsFromPosition),
+	)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	type documentKey struct {
+		uploadID int
+		path     string
+	}
+
+	// Group references by the indexed document they occur in, so that each document is only
+	// read once to determine the callables enclosing the references.
+	var (
+		documentKeys         []documentKey
+		rangesByDocument     = map[documentKey][]shared.Range{}
+		referencesByDocument = map[documentKey][]shared.UploadLocation{}
+	)
+	for _, reference := range references {
+		indexedRange, ok, err := s.getIndexedRange(ctx, requestState, reference)
+		if err != nil {
+			return nil, Cursor{}, err
+		}
+		if !ok {
+			continue
+		}
+
+		key := documentKey{uploadID: reference.Dump.ID, path: strings.TrimPrefix(reference.Path, reference.Dump.Root)}
+		if _, ok := rangesByDocument[key]; !ok {
+			documentKeys = append(documentKeys, key)
+		}
+		rangesByDocument[key] = append(rangesByDocument[key], indexedRange)
+		referencesByDocument[key] = append(referencesByDocument[key], reference)
+	}
+
+	var calls []Call
+	callIndexes := map[string]int{}
+	for _, key := range documentKeys {
+		callables, err := s.lsifstore.GetEnclosingCallables(ctx, key.uploadID, key.path, rangesByDocument[key])
+		if err != nil {
+			return nil, Cursor{}, err
+		}
+
+		for i, callable := range callables {
+			if callable.Symbol == "" {
+				continue
+			}
+
+			callKey := fmt.Sprintf("%d:%s:%s", callable.DumpID, callable.Path, callable.Symbol)
+			index, ok := callIndexes[callKey]
+			if !ok {
+				definitions, err := s.getUploadLocations(ctx, args.RequestArgs, requestState, []shared.Location{{
+					DumpID: callable.DumpID,
+					Path:   callable.Path,
+					Range:  callable.Range,
+				}}, true)
+				if err != nil {
+					return nil, Cursor{}, err
+				}
+
+				index = len(calls)
+				callIndexes[callKey] = index
+				calls = append(calls, Call{Symbol: callable.Symbol, Definitions: definitions})
+			}
+
+			calls[index].CallSites = append(calls[index].CallSites, referencesByDocument[key][i])
+		}
+	}
+
+	return calls, nextCursor, nil
+}
+
+// GetOutgoingCalls returns the functions and methods called from the body of the function or method
+// at the given position, along with the call sites of each of them. Calls are grouped by callee,
+// ordered by their first call site, and paginated by the given offset into the list of callees. The
+// total number of callees is also returned.
+//
+// Definitions of callees that are not defined in the same document are resolved across repositories
+// via their monikers, like NewGetDefinitionsBySymbolNames does.
+func (s *Service) GetOutgoingCalls(
+	ctx context.Context,
+	args PositionalRequestArgs,
+	requestState RequestState,
+	offset int,
+) (_ []Call, totalCount int, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getOutgoingCalls, serviceObserverThreshold, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", args.RepositoryID),
+		attribute.String("commit", args.Commit),
+		attribute.String("path", args.Path),
+		attribute.Int("line", args.Line),
+		attribute.Int("character", args.Character),
+		attribute.Int("offset", offset),
+	}})
+	defer endObservation()
+
+	definitionArgs := args
+	definitionArgs.Limit = maxCallDefinitions
+	definitions, err := s.NewGetDefinitions(ctx, definitionArgs, requestState)
+	if err != nil {
+		return nil, 0, err
+	}
+	trace.AddEvent("Definitions", attribute.Int("numDefinitions", len(definitions)))
+
+	type pendingCall struct {
+		symbol     string
+		definition *shared.Location
+		callSites  []shared.Location
+	}
+
+	var calls []*pendingCall
+	callsBySymbol := map[string]*pendingCall{}
+	seenDefinitions := map[string]struct{}{}
+	for _, definition := range definitions {
+		indexedRange, ok, err := s.getIndexedRange(ctx, requestState, definition)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !ok {
+			continue
+		}
+
+		path := strings.TrimPrefix(definition.Path, definition.Dump.Root)
+		definitionKey := fmt.Sprintf("%d:%s:%d:%d", definition.Dump.ID, path, indexedRange.Start.Line, indexedRange.Start.Character)
+		if _, ok := seenDefinitions[definitionKey]; ok {
+			continue
+		}
+		seenDefinitions[definitionKey] = struct{}{}
+
+		_, callSites, ok, err := s.lsifstore.GetCallSites(ctx, definition.Dump.ID, path, indexedRange.Start.Line, indexedRange.Start.Character)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !ok {
+			continue
+		}
+
+		for _, callSite := range callSites {
+			call, ok := callsBySymbol[callSite.Symbol]
+			if !ok {
+				call = &pendingCall{symbol: callSite.Symbol}
+				callsBySymbol[callSite.Symbol] = call
+				calls = append(calls, call)
+			}
+
+			call.callSites = append(call.callSites, shared.Location{DumpID: definition.Dump.ID, Path: path, Range: callSite.Range})
+			if callSite.Definition != nil && call.definition == nil {
+				call.definition = &shared.Location{DumpID: definition.Dump.ID, Path: path, Range: *callSite.Definition}
+			}
+		}
+	}
+
+	totalCount = len(calls)
+	page := pageSlice(calls, args.Limit, offset)
+	trace.AddEvent("Callees", attribute.Int("totalCount", totalCount), attribute.Int("numCallees", len(page)))
+
+	resolvedCalls := make([]Call, 0, len(page))
+	for _, call := range page {
+		callSites, err := s.getUploadLocations(ctx, args.RequestArgs, requestState, call.callSites, true)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var definitions []shared.UploadLocation
+		if call.definition != nil {
+			definitions, err = s.getUploadLocations(ctx, args.RequestArgs, requestState, []shared.Location{*call.definition}, true)
+		} else if !scip.IsLocalSymbol(call.symbol) {
+			definitions, err = s.NewGetDefinitionsBySymbolNames(ctx, RequestArgs{
+				RepositoryID: args.RepositoryID,
+				Commit:       args.Commit,
+				Limit:        maxCallDefinitions,
+			}, requestState, []string{call.symbol})
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resolvedCalls = append(resolvedCalls, Call{
+			Symbol:      call.symbol,
+			Definitions: definitions,
+			CallSites:   callSites,
+		})
+	}
+
+	return resolvedCalls, totalCount, nil
+}
+
+// getIndexedRange translates the given adjusted location back into a range within the indexed
+// commit of its upload. This is the inverse of getUploadLocation. If the translation fails, a
+// false-valued flag is returned.
+func (s *Service) getIndexedRange(ctx context.Context, requestState RequestState, location shared.UploadLocation) (shared.Range, bool, error) {
+	if location.TargetCommit == location.Dump.Commit {
+		return location.TargetRange, true, nil
+	}
+
+	_, indexedRange, ok, err := requestState.GitTreeTranslator.GetTargetCommitRangeFromSourceRange(ctx, location.Dump.Commit, location.Path, location.TargetRange, false)
+	if err != nil {
+		return shared.Range{}, false, errors.Wrap(err, "gitTreeTranslator.GetTargetCommitRangeFromSourceRange")
+	}
+
+	return indexedRange, ok, nil
+}
@@ -0,0 +1,177 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGetIncomingCalls(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []uploadsshared.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	// Empty result set (prevents nil pointer as scanner is always non-nil)
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{}, 0, 0, nil)
+
+	locations := []shared.Location{
+		{DumpID: 51, Path: "a.go", Range: testRange1},
+		{DumpID: 51, Path: "a.go", Range: testRange2},
+		{DumpID: 51, Path: "b.go", Range: testRange3},
+		{DumpID: 51, Path: "b.go", Range: testRange4},
+	}
+	mockLsifStore.ExtractReferenceLocationsFromPositionFunc.PushReturn(locations, nil, nil)
+
+	mockLsifStore.GetEnclosingCallablesFunc.SetDefaultHook(func(ctx context.Context, uploadID int, path string, ranges []shared.Range) ([]shared.Callable, error) {
+		callables := make([]shared.Callable, len(ranges))
+		for i, r := range ranges {
+			switch r {
+			case testRange1, testRange2:
+				callables[i] = shared.Callable{DumpID: uploadID, Path: path, Symbol: "go . pkg . caller().", Range: testRange5}
+			case testRange3:
+				callables[i] = shared.Callable{DumpID: uploadID, Path: path, Symbol: "go . pkg . other().", Range: testRange6}
+			}
+		}
+		return callables, nil
+	})
+
+	mockRequest := PositionalRequestArgs{
+		RequestArgs: RequestArgs{
+			RepositoryID: 42,
+			Commit:       mockCommit,
+			Limit:        50,
+		},
+		Path:      mockPath,
+		Line:      10,
+		Character: 20,
+	}
+	calls, _, err := svc.GetIncomingCalls(context.Background(), mockRequest, mockRequestState, Cursor{})
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+
+	expectedCalls := []Call{
+		{
+			Symbol:      "go . pkg . caller().",
+			Definitions: []shared.UploadLocation{{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: "deadbeef", TargetRange: testRange5}},
+			CallSites: []shared.UploadLocation{
+				{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: "deadbeef", TargetRange: testRange1},
+				{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: "deadbeef", TargetRange: testRange2},
+			},
+		},
+		{
+			Symbol:      "go . pkg . other().",
+			Definitions: []shared.UploadLocation{{Dump: uploads[1], Path: "sub2/b.go", TargetCommit: "deadbeef", TargetRange: testRange6}},
+			CallSites: []shared.UploadLocation{
+				{Dump: uploads[1], Path: "sub2/b.go", TargetCommit: "deadbeef", TargetRange: testRange3},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	if history := mockLsifStore.GetEnclosingCallablesFunc.History(); len(history) != 2 {
+		t.Fatalf("unexpected call count for lsifstore.GetEnclosingCallables. want=%d have=%d", 2, len(history))
+	} else if diff := cmp.Diff([]shared.Range{testRange3, testRange4}, history[1].Arg3); diff != "" {
+		t.Errorf("unexpected ranges (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetOutgoingCalls(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []uploadsshared.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	mockLsifStore.ExtractDefinitionLocationsFromPositionFunc.PushReturn([]shared.Location{
+		{DumpID: 51, Path: "a.go", Range: testRange1},
+	}, nil, nil)
+
+	mockLsifStore.GetCallSitesFunc.PushReturn(
+		shared.Callable{DumpID: 51, Path: "a.go", Symbol: "go . pkg . caller().", Range: testRange1},
+		[]shared.CallSite{
+			{Symbol: "go . pkg . first().", Range: testRange2, Definition: &testRange5},
+			{Symbol: "go . pkg . second().", Range: testRange3, Definition: &testRange6},
+			{Symbol: "go . pkg . first().", Range: testRange4, Definition: &testRange5},
+		},
+		true,
+		nil,
+	)
+
+	mockRequest := PositionalRequestArgs{
+		RequestArgs: RequestArgs{
+			RepositoryID: 42,
+			Commit:       mockCommit,
+			Limit:        1,
+		},
+		Path:      mockPath,
+		Line:      10,
+		Character: 20,
+	}
+	calls, totalCount, err := svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState, 0)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+	if totalCount != 2 {
+		t.Errorf("unexpected total count. want=%d have=%d", 2, totalCount)
+	}
+
+	expectedCalls := []Call{
+		{
+			Symbol:      "go . pkg . first().",
+			Definitions: []shared.UploadLocation{{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: "deadbeef", TargetRange: testRange5}},
+			CallSites: []shared.UploadLocation{
+				{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: "deadbeef", TargetRange: testRange2},
+				{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: "deadbeef", TargetRange: testRange4},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	if history := mockLsifStore.GetCallSitesFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for lsifstore.GetCallSites. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg1 != 51 || history[0].Arg2 != "a.go" || history[0].Arg3 != testRange1.Start.Line || history[0].Arg4 != testRange1.Start.Character {
+		t.Errorf("unexpected arguments to lsifstore.GetCallSites: %d %q %d %d", history[0].Arg1, history[0].Arg2, history[0].Arg3, history[0].Arg4)
+	}
+}
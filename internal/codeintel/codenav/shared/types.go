@@ -54,3 +54,22 @@ type Position struct {
 	Line      int
 	Character int
 }
+
+// Callable is a function or method defined within a particular dump. Range is the range of
+// the definition occurrence (the name of the callable) and EnclosingRange is the range of the
+// document attributed to the callable, including its body.
+type Callable struct {
+	DumpID         int
+	Path           string
+	Symbol         string
+	Range          Range
+	EnclosingRange Range
+}
+
+// CallSite is a reference to a callable from within the body of another callable. If the
+// callee is defined in the same document, Definition is the range of its definition.
+type CallSite struct {
+	Symbol     string
+	Range      Range
+	Definition *Range
+}
@@ -20,6 +20,8 @@ type operations struct {
 	references      *observation.Operation
 	implementations *observation.Operation
 	prototypes      *observation.Operation
+	incomingCalls   *observation.Operation
+	outgoingCalls   *observation.Operation
 	diagnostics     *observation.Operation
 	stencil         *observation.Operation
 	ranges          *observation.Operation
@@ -50,6 +52,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 		references:      op("References"),
 		implementations: op("Implementations"),
 		prototypes:      op("Prototypes"),
+		incomingCalls:   op("IncomingCalls"),
+		outgoingCalls:   op("OutgoingCalls"),
 		diagnostics:     op("Diagnostics"),
 		stencil:         op("Stencil"),
 		ranges:          op("Ranges"),
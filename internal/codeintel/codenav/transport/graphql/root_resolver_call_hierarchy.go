@@ -0,0 +1,139 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/resolvers/gitresolvers"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+// DefaultCallHierarchyPageSize is the call hierarchy result page size when no limit is supplied.
+const DefaultCallHierarchyPageSize = 50
+
+func (r *gitBlobLSIFDataResolver) IncomingCalls(ctx context.Context, args *resolverstubs.LSIFCallHierarchyArgs) (_ resolverstubs.CallHierarchyConnectionResolver, err error) {
+	limit := int(pointers.Deref(args.First, DefaultCallHierarchyPageSize))
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	rawCursor, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	requestArgs := codenav.PositionalRequestArgs{
+		RequestArgs: codenav.RequestArgs{
+			RepositoryID: r.requestState.RepositoryID,
+			Commit:       r.requestState.Commit,
+			Limit:        limit,
+			RawCursor:    rawCursor,
+		},
+		Path:      r.requestState.Path,
+		Line:      int(args.Line),
+		Character: int(args.Character),
+	}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.incomingCalls, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	// The cursor is the same traversal cursor used to page through references, as
+	// each page of incoming calls is formed from a page of references.
+	var nextCursor string
+	cursor, err := decodeTraversalCursor(rawCursor)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
+	}
+
+	calls, callsCursor, err := r.codeNavSvc.GetIncomingCalls(ctx, requestArgs, r.requestState, cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetIncomingCalls")
+	}
+
+	if callsCursor.Phase != "done" {
+		nextCursor = encodeTraversalCursor(callsCursor)
+	}
+
+	return newCallHierarchyConnectionResolver(calls, pointers.NonZeroPtr(nextCursor), r.locationResolver), nil
+}
+
+func (r *gitBlobLSIFDataResolver) OutgoingCalls(ctx context.Context, args *resolverstubs.LSIFCallHierarchyArgs) (_ resolverstubs.CallHierarchyConnectionResolver, err error) {
+	limit := int(pointers.Deref(args.First, DefaultCallHierarchyPageSize))
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	rawCursor, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := 0
+	if rawCursor != "" {
+		if offset, err = strconv.Atoi(rawCursor); err != nil || offset < 0 {
+			return nil, errors.Newf("invalid cursor: %q", rawCursor)
+		}
+	}
+
+	requestArgs := codenav.PositionalRequestArgs{
+		RequestArgs: codenav.RequestArgs{
+			RepositoryID: r.requestState.RepositoryID,
+			Commit:       r.requestState.Commit,
+			Limit:        limit,
+			RawCursor:    rawCursor,
+		},
+		Path:      r.requestState.Path,
+		Line:      int(args.Line),
+		Character: int(args.Character),
+	}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.outgoingCalls, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	calls, totalCount, err := r.codeNavSvc.GetOutgoingCalls(ctx, requestArgs, r.requestState, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetOutgoingCalls")
+	}
+
+	var nextCursor string
+	if next := offset + len(calls); next < totalCount {
+		nextCursor = strconv.Itoa(next)
+	}
+
+	return newCallHierarchyConnectionResolver(calls, pointers.NonZeroPtr(nextCursor), r.locationResolver), nil
+}
+
+func newCallHierarchyConnectionResolver(calls []codenav.Call, cursor *string, locationResolver *gitresolvers.CachedLocationResolver) resolverstubs.CallHierarchyConnectionResolver {
+	resolvers := make([]resolverstubs.CallHierarchyCallResolver, 0, len(calls))
+	for _, call := range calls {
+		resolvers = append(resolvers, &callHierarchyCallResolver{
+			call:             call,
+			locationResolver: locationResolver,
+		})
+	}
+
+	return resolverstubs.NewCursorConnectionResolver(resolvers, encodeCursor(cursor))
+}
+
+//
+//
+
+type callHierarchyCallResolver struct {
+	call             codenav.Call
+	locationResolver *gitresolvers.CachedLocationResolver
+}
+
+func (r *callHierarchyCallResolver) Symbol() string {
+	return r.call.Symbol
+}
+
+func (r *callHierarchyCallResolver) Definitions(ctx context.Context) ([]resolverstubs.LocationResolver, error) {
+	return resolveLocations(ctx, r.locationResolver, r.call.Definitions)
+}
+
+func (r *callHierarchyCallResolver) CallSites(ctx context.Context) ([]resolverstubs.LocationResolver, error) {
+	return resolveLocations(ctx, r.locationResolver, r.call.CallSites)
+}
@@ -81,6 +81,15 @@ type AdjustedCodeIntelligenceRange struct {
 	HoverText       string
 }
 
+// Call is an edge of a call hierarchy: a caller (for incoming calls) or a callee (for outgoing
+// calls) of the requested function or method, along with the call sites connecting the two. The
+// locations have been adjusted to fit the target (originally requested) commit.
+type Call struct {
+	Symbol      string
+	Definitions []shared.UploadLocation
+	CallSites   []shared.UploadLocation
+}
+
 // Cursor is a struct that holds the state necessary to resume a locations query from a second or
 // subsequent request. This struct is used internally as a request-specific context object that is
 // mutated as the locations request is fulfilled. This struct is serialized to JSON then base64
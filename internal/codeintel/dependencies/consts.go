@@ -9,4 +9,6 @@ const (
 	PythonPackagesScheme = shared.PythonPackagesScheme
 	RustPackagesScheme   = shared.RustPackagesScheme
 	RubyPackagesScheme   = shared.RubyPackagesScheme
+	NuGetPackagesScheme  = shared.NuGetPackagesScheme
+	PHPPackagesScheme    = shared.PHPPackagesScheme
 )
@@ -7,4 +7,6 @@ const (
 	PythonPackagesScheme = "python"
 	RustPackagesScheme   = "rust-analyzer"
 	RubyPackagesScheme   = "scip-ruby"
+	NuGetPackagesScheme  = "scip-dotnet"
+	PHPPackagesScheme    = "scip-php"
 )
@@ -22,11 +22,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/store"
 	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -80,6 +82,8 @@ type handler struct {
 	budgetRemaining int64
 	enableBudget    bool
 	uploadSizeGauge prometheus.Gauge
+
+	outboundWebhookJobs database.OutboundWebhookJobStore
 }
 
 var (
@@ -111,6 +115,8 @@ func NewUploadProcessorHandler(
 		budgetRemaining: budgetMax,
 		enableBudget:    budgetMax > 0,
 		uploadSizeGauge: operations.uploadSizeGauge,
+
+		outboundWebhookJobs: database.OutboundWebhookJobsWithDefaultKey(store.Handle()),
 	}
 }
 
@@ -126,10 +132,33 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, upload uploadss
 	}()
 
 	requeued, err = h.HandleRawUpload(ctx, logger, upload, h.uploadStore, tr)
+	if !requeued {
+		h.enqueueProcessedEvent(ctx, logger, upload, err)
+	}
 
 	return err
 }
 
+// enqueueProcessedEvent sends the precise_index:processed outbound webhook
+// event, or precise_index:failed if processErr is non-nil.
+func (h *handler) enqueueProcessedEvent(ctx context.Context, logger log.Logger, upload uploadsshared.Upload, processErr error) {
+	eventType := events.PreciseIndexProcessed
+	if processErr != nil {
+		eventType = events.PreciseIndexFailed
+	}
+
+	database.EnqueueOutboundWebhookEvent(ctx, logger, h.outboundWebhookJobs, eventType, events.NewPreciseIndex(events.PreciseIndexUpload{
+		ID:             upload.ID,
+		RepositoryID:   upload.RepositoryID,
+		RepositoryName: upload.RepositoryName,
+		Commit:         upload.Commit,
+		Root:           upload.Root,
+		Indexer:        upload.Indexer,
+		IndexerVersion: upload.IndexerVersion,
+		UploadedAt:     upload.UploadedAt,
+	}, processErr))
+}
+
 func (h *handler) PreDequeue(_ context.Context, _ log.Logger) (bool, any, error) {
 	if !h.enableBudget {
 		return true, nil, nil
@@ -1,9 +1,18 @@
 package background
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // actionArgs is the shared set of arguments needed to execute any
@@ -15,7 +24,112 @@ type actionArgs struct {
 	UTMSource          string
 	MonitorOwnerName   string
 
-	Query          string
-	Results        []*result.CommitMatch
+	Query   string
+	Results []*result.CommitMatch
+	// ContentResults is set instead of Results for monitors on content
+	// search.
+	ContentResults *database.ContentSearchResults
 	IncludeResults bool
 }
+
+// matches returns all results as a single list, with files that no longer
+// match the query of a content search monitor last.
+func (a actionArgs) matches() result.Matches {
+	matches := make(result.Matches, 0, len(a.Results))
+	for _, cm := range a.Results {
+		matches = append(matches, cm)
+	}
+	if a.ContentResults != nil {
+		for _, fm := range a.ContentResults.Added {
+			matches = append(matches, fm)
+		}
+		for _, fm := range a.ContentResults.Removed {
+			matches = append(matches, fm)
+		}
+	}
+	return matches
+}
+
+// isRemoved returns true if m is a file that no longer matches the query of a
+// content search monitor.
+func (a actionArgs) isRemoved(m result.Match) bool {
+	if a.ContentResults == nil {
+		return false
+	}
+	for _, fm := range a.ContentResults.Removed {
+		if m == result.Match(fm) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLink is the title line of a single result in a chat message.
+type matchLink struct {
+	// Kind is one of "Diff", "Message", "Content" or "Removed".
+	Kind  string
+	URL   string
+	Label string
+}
+
+// matchLink returns the title line for m, or false if m is not a result type
+// code monitors produce, in which case callers should skip it.
+func (a actionArgs) matchLink(m result.Match) (matchLink, bool) {
+	switch m := m.(type) {
+	case *result.CommitMatch:
+		kind := "Message"
+		if m.DiffPreview != nil {
+			kind = "Diff"
+		}
+		return matchLink{
+			Kind:  kind,
+			URL:   getCommitURL(a.ExternalURL, string(m.Repo.Name), string(m.Commit.ID), a.UTMSource),
+			Label: fmt.Sprintf("%s@%s", m.Repo.Name, m.Commit.ID.Short()),
+		}, true
+	case *result.FileMatch:
+		kind := "Content"
+		if a.isRemoved(m) {
+			kind = "Removed"
+		}
+		return matchLink{
+			Kind:  kind,
+			URL:   getFileURL(a.ExternalURL, string(m.Repo.Name), string(m.CommitID), m.Path, a.UTMSource),
+			Label: fmt.Sprintf("%s@%s:%s", m.Repo.Name, m.CommitID.Short(), m.Path),
+		}, true
+	default:
+		return matchLink{}, false
+	}
+}
+
+// postJSON posts payload to a chat webhook. Unlike Slack, some chat services
+// answer successful requests with 202 Accepted, so any 2xx status is treated
+// as success.
+func postJSON(ctx context.Context, doer httpcli.Doer, url string, payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
+	if err != nil {
+		return errors.Wrap(err, "failed new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return StatusCodeError{
+			Code:   resp.StatusCode,
+			Status: resp.Status,
+			Body:   string(body),
+		}
+	}
+
+	return nil
+}
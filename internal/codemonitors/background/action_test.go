@@ -0,0 +1,24 @@
+package background
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestMatchLink(t *testing.T) {
+	externalURL, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+	args := actionArgs{ExternalURL: externalURL}
+
+	link, ok := args.matchLink(&result.FileMatch{File: result.File{Path: "a.go"}})
+	require.True(t, ok)
+	require.Equal(t, "Content", link.Kind)
+
+	// Unexpected result types are skipped rather than crashing the worker.
+	_, ok = args.matchLink(&result.RepoMatch{Name: "repo"})
+	require.False(t, ok)
+}
@@ -0,0 +1,32 @@
+package background
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+// The functions below expose the delivery mechanisms of code monitor actions
+// to other features that notify users, such as code insights alerts, so that
+// notifications look and behave the same everywhere.
+
+// PostWebhook posts the JSON encoding of payload to url. Any response other
+// than 200 OK is returned as a StatusCodeError.
+func PostWebhook(ctx context.Context, doer httpcli.Doer, url string, payload any) error {
+	return postWebhook(ctx, doer, url, payload)
+}
+
+// PostSlackWebhook posts msg to the Slack incoming webhook at url.
+func PostSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *slack.WebhookMessage) error {
+	return postSlackWebhook(ctx, doer, url, msg)
+}
+
+// SendEmail sends the rendered template to the verified primary email address
+// of the given user. source identifies the sending feature in email metrics.
+func SendEmail(ctx context.Context, db database.DB, userID int32, source string, template txtypes.Templates, data any) error {
+	return sendEmail(ctx, db, userID, source, template, data)
+}
@@ -31,7 +31,7 @@ func SendEmailForNewSearchResult(ctx context.Context, db database.DB, userID int
 	if MockSendEmailForNewSearchResult != nil {
 		return MockSendEmailForNewSearchResult(ctx, db, userID, data)
 	}
-	return sendEmail(ctx, db, userID, newSearchResultsEmailTemplates, data)
+	return sendEmail(ctx, db, userID, "code-monitor", newSearchResultsEmailTemplates, data)
 }
 
 var (
@@ -77,11 +77,16 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *database.EmailAc
 		priority = ""
 	}
 
-	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.matches(), 5)
 
-	displayResults := make([]*DisplayResult, len(truncatedResults))
-	for i, result := range truncatedResults {
-		displayResults[i] = toDisplayResult(result, args.ExternalURL)
+	displayResults := make([]*DisplayResult, 0, len(truncatedResults))
+	for _, match := range truncatedResults {
+		switch result := match.(type) {
+		case *searchresult.CommitMatch:
+			displayResults = append(displayResults, toDisplayResult(result, args.ExternalURL))
+		case *searchresult.FileMatch:
+			displayResults = append(displayResults, fileToDisplayResult(result, args.isRemoved(result), args.ExternalURL))
+		}
 	}
 
 	return &TemplateDataNewSearchResults{
@@ -120,7 +125,7 @@ func NewTestTemplateDataForNewSearchResults(monitorDescription string) *Template
 	}
 }
 
-func sendEmail(ctx context.Context, db database.DB, userID int32, template txtypes.Templates, data any) error {
+func sendEmail(ctx context.Context, db database.DB, userID int32, source string, template txtypes.Templates, data any) error {
 	email, verified, err := db.UserEmails().GetPrimaryEmail(ctx, userID)
 	if err != nil {
 		if errcode.IsNotFound(err) {
@@ -132,7 +137,7 @@ func sendEmail(ctx context.Context, db database.DB, userID int32, template txtyp
 		return errors.Newf("unable to send email to user ID %d's unverified primary email address", userID)
 	}
 
-	if err := txemail.Send(ctx, "code-monitor", txtypes.Message{
+	if err := txemail.Send(ctx, source, txtypes.Message{
 		To:       []string{email},
 		Template: template,
 		Data:     data,
@@ -154,6 +159,10 @@ func getCommitURL(externalURL *url.URL, repoName, oid, utmSource string) string
 	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
 }
 
+func getFileURL(externalURL *url.URL, repoName, oid, path, utmSource string) string {
+	return sourcegraphURL(externalURL, fmt.Sprintf("%s@%s/-/blob/%s", repoName, oid, path), "", utmSource)
+}
+
 var (
 	externalURLOnce  sync.Once
 	externalURLValue *url.URL
@@ -194,10 +203,13 @@ func pluralize(word string, count int) string {
 
 type DisplayResult struct {
 	ResultType string
-	CommitURL  string
-	RepoName   string
-	CommitID   string
-	Content    string
+	// CommitURL links to the commit, or to the file for content matches.
+	CommitURL string
+	RepoName  string
+	CommitID  string
+	// Path is only set for content matches.
+	Path    string
+	Content string
 }
 
 func toDisplayResult(result *searchresult.CommitMatch, externalURL *url.URL) *DisplayResult {
@@ -215,3 +227,19 @@ func toDisplayResult(result *searchresult.CommitMatch, externalURL *url.URL) *Di
 		Content:    content,
 	}
 }
+
+func fileToDisplayResult(result *searchresult.FileMatch, removed bool, externalURL *url.URL) *DisplayResult {
+	resultType := "Content"
+	if removed {
+		resultType = "Removed"
+	}
+
+	return &DisplayResult{
+		ResultType: resultType,
+		CommitURL:  getFileURL(externalURL, string(result.Repo.Name), string(result.CommitID), result.Path, utmSourceEmail),
+		RepoName:   string(result.Repo.Name),
+		CommitID:   result.CommitID.Short(),
+		Path:       result.Path,
+		Content:    truncateMatchContent(result),
+	}
+}
@@ -0,0 +1,91 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func sendMattermostNotification(ctx context.Context, url string, args actionArgs) error {
+	return postJSON(ctx, httpcli.ExternalDoer, url, mattermostPayload(args))
+}
+
+// mattermostMessage is the body of a Mattermost incoming webhook request. The
+// text is rendered as markdown. See
+// https://developers.mattermost.com/integrate/webhooks/incoming/
+type mattermostMessage struct {
+	Text string `json:"text"`
+}
+
+func mattermostPayload(args actionArgs) *mattermostMessage {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.matches(), 5)
+
+	var b strings.Builder
+	fmt.Fprintf(&b,
+		"%s's Sourcegraph Code monitor, **%s**, detected **%d** new matches.\n\n",
+		args.MonitorOwnerName,
+		args.MonitorDescription,
+		totalCount,
+	)
+
+	if args.IncludeResults {
+		for _, match := range truncatedResults {
+			link, ok := args.matchLink(match)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "%s match: [%s](%s)\n", link.Kind, link.Label, link.URL)
+			if contentRaw := truncateMatchContent(match); contentRaw != "" {
+				b.WriteString(fencedCodeBlock(contentRaw))
+			}
+			b.WriteString("\n")
+		}
+		if truncatedCount > 0 {
+			fmt.Fprintf(&b,
+				"...and [%d more matches](%s).\n\n",
+				truncatedCount,
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+			)
+		}
+	} else {
+		fmt.Fprintf(&b, "[View results](%s)\n\n", getSearchURL(args.ExternalURL, args.Query, args.UTMSource))
+	}
+
+	fmt.Fprintf(&b,
+		"If you are %s, you can [edit your code monitor](%s)",
+		args.MonitorOwnerName,
+		getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	)
+	return &mattermostMessage{Text: b.String()}
+}
+
+// fencedCodeBlock wraps s in a code fence that is longer than any run of
+// backticks in s, so that the content cannot end the block early.
+func fencedCodeBlock(s string) string {
+	fenceLen, run := 3, 0
+	for _, c := range s {
+		if c != '`' {
+			run = 0
+			continue
+		}
+		run++
+		if run >= fenceLen {
+			fenceLen = run + 1
+		}
+	}
+	fence := strings.Repeat("`", fenceLen)
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	return fence + "\n" + s + fence + "\n"
+}
+
+func SendTestMattermostWebhook(ctx context.Context, doer httpcli.Doer, description, url string) error {
+	testMessage := &mattermostMessage{
+		Text: fmt.Sprintf("Test message for Code Monitor '%s'", description),
+	}
+
+	return postJSON(ctx, doer, url, testMessage)
+}
@@ -0,0 +1,112 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestMattermostWebhook(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		IncludeResults:     false,
+	}
+
+	jsonMattermostPayload := func(a actionArgs) autogold.Raw {
+		b, err := json.MarshalIndent(mattermostPayload(a), " ", " ")
+		require.NoError(t, err)
+		return autogold.Raw(b)
+	}
+
+	t.Run("no error", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			autogold.ExpectFile(t, autogold.Raw(b))
+			w.WriteHeader(200)
+		}))
+		defer s.Close()
+
+		client := s.Client()
+		err := postJSON(context.Background(), client, s.URL, mattermostPayload(action))
+		require.NoError(t, err)
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(500)
+		}))
+		defer s.Close()
+
+		client := s.Client()
+		err := postJSON(context.Background(), client, s.URL, mattermostPayload(action))
+		require.Error(t, err)
+	})
+
+	t.Run("golden with results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		autogold.ExpectFile(t, jsonMattermostPayload(actionCopy))
+	})
+
+	t.Run("golden with truncated results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		// quadruple the number of results
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		autogold.ExpectFile(t, jsonMattermostPayload(actionCopy))
+	})
+
+	t.Run("golden with content results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		actionCopy.Results = nil
+		actionCopy.ContentResults = &database.ContentSearchResults{
+			Added:   []*result.FileMatch{&fileResultMock},
+			Removed: []*result.FileMatch{&removedFileResultMock},
+		}
+		autogold.ExpectFile(t, jsonMattermostPayload(actionCopy))
+	})
+
+	t.Run("golden without results", func(t *testing.T) {
+		autogold.ExpectFile(t, jsonMattermostPayload(action))
+	})
+}
+
+func TestTriggerTestMattermostWebhookAction(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		autogold.ExpectFile(t, autogold.Raw(b))
+		w.WriteHeader(200)
+	}))
+	defer s.Close()
+
+	client := s.Client()
+	err := SendTestMattermostWebhook(context.Background(), client, "My test monitor", s.URL)
+	require.NoError(t, err)
+}
+
+func TestFencedCodeBlock(t *testing.T) {
+	require.Equal(t, "```\nfoo\n```\n", fencedCodeBlock("foo"))
+	require.Equal(t, "````\na ``` b\n````\n", fencedCodeBlock("a ``` b\n"))
+}
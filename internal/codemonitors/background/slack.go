@@ -25,7 +25,7 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
 	}
 
-	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.matches(), 5)
 
 	blocks := []slack.Block{
 		newMarkdownSection(fmt.Sprintf(
@@ -37,21 +37,22 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 	}
 
 	if args.IncludeResults {
-		for _, result := range truncatedResults {
-			resultType := "Message"
-			if result.DiffPreview != nil {
-				resultType = "Diff"
+		for _, match := range truncatedResults {
+			link, ok := args.matchLink(match)
+			if !ok {
+				continue
 			}
 			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
-				"%s match: <%s|%s@%s>",
-				resultType,
-				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
-				result.Repo.Name,
-				result.Commit.ID.Short(),
+				"%s match: <%s|%s>",
+				link.Kind,
+				link.URL,
+				link.Label,
 			)))
 
-			contentRaw := truncateMatchContent(result)
-			blocks = append(blocks, newMarkdownSection(formatCodeBlock(contentRaw)))
+			// Files that no longer match have no content to show.
+			if contentRaw := truncateMatchContent(match); contentRaw != "" {
+				blocks = append(blocks, newMarkdownSection(formatCodeBlock(contentRaw)))
+			}
 		}
 		if truncatedCount > 0 {
 			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
@@ -87,21 +88,36 @@ func formatCodeBlock(s string) string {
 // We limit the bytes to ensure we don't hit Slack's max block size of 3000
 // characters. To be conservative, we truncate to 2500 bytes. We also limit
 // the number of lines to 10 to ensure the content is easy to read.
-func truncateMatchContent(result *searchresult.CommitMatch) string {
+func truncateMatchContent(match searchresult.Match) string {
 	const maxBytes = 2500
 	const maxLines = 10
 
-	var matchedString *searchresult.MatchedString
-	switch {
-	case result.DiffPreview != nil:
-		matchedString = result.DiffPreview
-	case result.MessagePreview != nil:
-		matchedString = result.MessagePreview
-	default:
-		panic("exactly one of DiffPreview or MessagePreview must be set")
+	var content string
+	switch result := match.(type) {
+	case *searchresult.CommitMatch:
+		switch {
+		case result.DiffPreview != nil:
+			content = result.DiffPreview.Content
+		case result.MessagePreview != nil:
+			content = result.MessagePreview.Content
+		default:
+			panic("exactly one of DiffPreview or MessagePreview must be set")
+		}
+	case *searchresult.FileMatch:
+		var b strings.Builder
+		for _, cm := range result.ChunkMatches {
+			b.WriteString(cm.Content)
+			if !strings.HasSuffix(cm.Content, "\n") {
+				b.WriteByte('\n')
+			}
+		}
+		content = b.String()
+	}
+	if content == "" {
+		return ""
 	}
 
-	splitLines := strings.SplitAfter(matchedString.Content, "\n")
+	splitLines := strings.SplitAfter(content, "\n")
 	limit := len(splitLines)
 	if limit > maxLines {
 		limit = maxLines
@@ -122,24 +138,12 @@ func truncateMatchContent(result *searchresult.CommitMatch) string {
 	return strings.Join(splitLines, "")
 }
 
-func truncateResults(results []*searchresult.CommitMatch, maxResults int) (_ []*searchresult.CommitMatch, totalCount, truncatedCount int) {
-	// Convert to type result.Matches
-	matches := make(searchresult.Matches, len(results))
-	for i, res := range results {
-		matches[i] = res
-	}
-
+func truncateResults(matches searchresult.Matches, maxResults int) (_ searchresult.Matches, totalCount, truncatedCount int) {
 	totalCount = matches.ResultCount()
 	matches.Limit(maxResults)
 	outputCount := matches.ResultCount()
 
-	// Convert back type []*result.CommitMatch
-	output := make([]*searchresult.CommitMatch, len(matches))
-	for i, match := range matches {
-		output[i] = match.(*searchresult.CommitMatch)
-	}
-
-	return output, totalCount, totalCount - outputCount
+	return matches, totalCount, totalCount - outputCount
 }
 
 // adapted from slack.PostWebhookCustomHTTPContext
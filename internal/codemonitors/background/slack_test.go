@@ -12,6 +12,7 @@ import (
 	"github.com/hexops/autogold/v2"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -88,6 +89,17 @@ func TestSlackWebhook(t *testing.T) {
 		autogold.ExpectFile(t, jsonSlackPayload(actionCopy))
 	})
 
+	t.Run("golden with content results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		actionCopy.Results = nil
+		actionCopy.ContentResults = &database.ContentSearchResults{
+			Added:   []*result.FileMatch{&fileResultMock},
+			Removed: []*result.FileMatch{&removedFileResultMock},
+		}
+		autogold.ExpectFile(t, jsonSlackPayload(actionCopy))
+	})
+
 	t.Run("golden without results", func(t *testing.T) {
 		autogold.ExpectFile(t, jsonSlackPayload(action))
 	})
@@ -0,0 +1,135 @@
+package background
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func sendTeamsNotification(ctx context.Context, url string, args actionArgs) error {
+	return postJSON(ctx, httpcli.ExternalDoer, url, teamsPayload(args))
+}
+
+// teamsMessage is the body of a Microsoft Teams incoming webhook request
+// carrying a single Adaptive Card. See
+// https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using#send-adaptive-cards-using-an-incoming-webhook
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Body    []adaptiveElement `json:"body"`
+	Actions []adaptiveAction  `json:"actions,omitempty"`
+}
+
+// adaptiveElement is either a TextBlock, which renders a subset of markdown,
+// or a RichTextBlock of TextRuns, which renders text verbatim.
+type adaptiveElement struct {
+	Type      string            `json:"type"`
+	Text      string            `json:"text,omitempty"`
+	Inlines   []adaptiveElement `json:"inlines,omitempty"`
+	Wrap      bool              `json:"wrap,omitempty"`
+	FontType  string            `json:"fontType,omitempty"`
+	Separator bool              `json:"separator,omitempty"`
+}
+
+type adaptiveAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func newTeamsMessage(body []adaptiveElement, actions []adaptiveAction) *teamsMessage {
+	return &teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: adaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				Actions: actions,
+			},
+		}},
+	}
+}
+
+func newTeamsTextBlock(s string) adaptiveElement {
+	return adaptiveElement{Type: "TextBlock", Text: s, Wrap: true}
+}
+
+func teamsPayload(args actionArgs) *teamsMessage {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.matches(), 5)
+
+	body := []adaptiveElement{
+		newTeamsTextBlock(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, **%s**, detected **%d** new matches.",
+			args.MonitorOwnerName,
+			args.MonitorDescription,
+			totalCount,
+		)),
+	}
+
+	if args.IncludeResults {
+		for _, match := range truncatedResults {
+			link, ok := args.matchLink(match)
+			if !ok {
+				continue
+			}
+			title := newTeamsTextBlock(fmt.Sprintf("%s match: [%s](%s)", link.Kind, link.Label, link.URL))
+			title.Separator = true
+			body = append(body, title)
+
+			// TextRuns are not rendered as markdown, so the matched content
+			// is shown as is.
+			if contentRaw := truncateMatchContent(match); contentRaw != "" {
+				body = append(body, adaptiveElement{
+					Type: "RichTextBlock",
+					Inlines: []adaptiveElement{{
+						Type:     "TextRun",
+						Text:     contentRaw,
+						FontType: "Monospace",
+					}},
+				})
+			}
+		}
+		if truncatedCount > 0 {
+			body = append(body, newTeamsTextBlock(fmt.Sprintf(
+				"...and [%d more matches](%s).",
+				truncatedCount,
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+			)))
+		}
+	}
+
+	actions := []adaptiveAction{{
+		Type:  "Action.OpenUrl",
+		Title: "View results",
+		URL:   getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+	}, {
+		Type:  "Action.OpenUrl",
+		Title: "Edit code monitor",
+		URL:   getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	}}
+
+	return newTeamsMessage(body, actions)
+}
+
+func SendTestTeamsWebhook(ctx context.Context, doer httpcli.Doer, description, url string) error {
+	testMessage := newTeamsMessage([]adaptiveElement{
+		newTeamsTextBlock(fmt.Sprintf("Test message for Code Monitor '%s'", description)),
+	}, nil)
+
+	return postJSON(ctx, doer, url, testMessage)
+}
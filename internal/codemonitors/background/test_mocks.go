@@ -67,3 +67,35 @@ var longCommitResultMock = result.CommitMatch{
 		}},
 	},
 }
+
+var fileResultMock = result.FileMatch{
+	File: result.File{
+		Repo: types.MinimalRepo{
+			Name: api.RepoName("github.com/test/test"),
+		},
+		CommitID: api.CommitID("7815187511872asbasdfgasd"),
+		Path:     "deploy/app.yaml",
+	},
+	ChunkMatches: result.ChunkMatches{{
+		Content:      "    image: nginx:latest",
+		ContentStart: result.Location{Line: 11, Offset: 204, Column: 0},
+		Ranges: result.Ranges{{
+			Start: result.Location{Line: 11, Offset: 215, Column: 11},
+			End:   result.Location{Line: 11, Offset: 227, Column: 23},
+		}},
+	}},
+}
+
+var fileDisplayResultMock = fileToDisplayResult(&fileResultMock, false, externalURLMock)
+
+var removedFileResultMock = result.FileMatch{
+	File: result.File{
+		Repo: types.MinimalRepo{
+			Name: api.RepoName("github.com/test/test"),
+		},
+		CommitID: api.CommitID("7815187511872asbasdfgasd"),
+		Path:     "deploy/worker.yaml",
+	},
+}
+
+var removedFileDisplayResultMock = fileToDisplayResult(&removedFileResultMock, true, externalURLMock)
@@ -17,7 +17,7 @@ func sendWebhookNotification(ctx context.Context, url string, args actionArgs) e
 	return postWebhook(ctx, httpcli.ExternalDoer, url, generateWebhookPayload(args))
 }
 
-func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload webhookPayload) error {
+func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload any) error {
 	raw, err := json.Marshal(payload)
 	if err != nil {
 		return errors.Wrap(err, "marshal failed")
@@ -72,6 +72,10 @@ func generateWebhookPayload(args actionArgs) webhookPayload {
 
 	if args.IncludeResults {
 		p.Results = generateResults(args.Results)
+		if args.ContentResults != nil {
+			p.Results = append(p.Results, generateFileResults(args.ContentResults.Added, false)...)
+			p.Results = append(p.Results, generateFileResults(args.ContentResults.Removed, true)...)
+		}
 	}
 
 	return p
@@ -84,6 +88,17 @@ type webhookResult struct {
 	MatchedMessageRanges [][2]int `json:"matchedMessageRanges,omitempty"`
 	Diff                 string   `json:"diff,omitempty"`
 	MatchedDiffRanges    [][2]int `json:"matchedDiffRanges,omitempty"`
+
+	// The fields below are only set for monitors on content search.
+	Path    string         `json:"path,omitempty"`
+	Chunks  []webhookChunk `json:"chunks,omitempty"`
+	Removed bool           `json:"removed,omitempty"`
+}
+
+type webhookChunk struct {
+	Content       string   `json:"content"`
+	StartLine     int      `json:"startLine"`
+	MatchedRanges [][2]int `json:"matchedRanges,omitempty"`
 }
 
 func generateResults(in []*result.CommitMatch) []webhookResult {
@@ -106,6 +121,29 @@ func generateResults(in []*result.CommitMatch) []webhookResult {
 	return out
 }
 
+// generateFileResults converts the file matches of a content search monitor.
+// Ranges are relative to the content of their chunk.
+func generateFileResults(in []*result.FileMatch, removed bool) []webhookResult {
+	out := make([]webhookResult, len(in))
+	for i, match := range in {
+		res := webhookResult{
+			Repository: string(match.Repo.Name),
+			Commit:     string(match.CommitID),
+			Path:       match.Path,
+			Removed:    removed,
+		}
+		for _, cm := range match.ChunkMatches {
+			res.Chunks = append(res.Chunks, webhookChunk{
+				Content:       cm.Content,
+				StartLine:     cm.ContentStart.Line,
+				MatchedRanges: rangesToInts(cm.Ranges.Sub(cm.ContentStart)),
+			})
+		}
+		out[i] = res
+	}
+	return out
+}
+
 func rangesToInts(ranges result.Ranges) [][2]int {
 	out := make([][2]int, len(ranges))
 	for i, r := range ranges {
@@ -16,7 +16,6 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
-	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -194,12 +193,19 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 	}
 
 	// Log the actual query we ran and whether we got any new results.
-	err = cm.UpdateTriggerJobWithResults(ctx, triggerJob.ID, q.QueryString, results)
-	if err != nil {
-		return errors.Wrap(err, "UpdateTriggerJobWithResults")
+	if results.Content != nil {
+		err = cm.UpdateTriggerJobWithContentResults(ctx, triggerJob.ID, q.QueryString, results.Content)
+		if err != nil {
+			return errors.Wrap(err, "UpdateTriggerJobWithContentResults")
+		}
+	} else {
+		err = cm.UpdateTriggerJobWithResults(ctx, triggerJob.ID, q.QueryString, results.Commits)
+		if err != nil {
+			return errors.Wrap(err, "UpdateTriggerJobWithResults")
+		}
 	}
 
-	if len(results) > 0 {
+	if !results.Empty() {
 		_, err := cm.EnqueueActionJobsForMonitor(ctx, m.ID, triggerJob.ID)
 		if err != nil {
 			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
@@ -227,8 +233,12 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, j *databas
 		return r.handleWebhook(ctx, j)
 	case j.SlackWebhook != nil:
 		return r.handleSlackWebhook(ctx, j)
+	case j.TeamsWebhook != nil:
+		return r.handleTeamsWebhook(ctx, j)
+	case j.MattermostWebhook != nil:
+		return r.handleMattermostWebhook(ctx, j)
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, teams webhook, or mattermost webhook")
 	}
 }
 
@@ -267,6 +277,7 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *database.ActionJob) e
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentResults:     m.ContentResults,
 		IncludeResults:     e.IncludeResults,
 	}
 
@@ -320,6 +331,7 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *database.ActionJob)
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentResults:     m.ContentResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
@@ -356,12 +368,87 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *database.Actio
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentResults:     m.ContentResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+func (r *actionRunner) handleTeamsWebhook(ctx context.Context, j *database.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetTeamsWebhookAction(ctx, *j.TeamsWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetTeamsWebhookAction")
+	}
+
+	externalURL, err := getExternalURL()
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-teams-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		ContentResults:     m.ContentResults,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendTeamsNotification(ctx, w.URL, args)
+}
+
+func (r *actionRunner) handleMattermostWebhook(ctx context.Context, j *database.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetMattermostWebhookAction(ctx, *j.MattermostWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetMattermostWebhookAction")
+	}
+
+	externalURL, err := getExternalURL()
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-mattermost-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		ContentResults:     m.ContentResults,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendMattermostNotification(ctx, w.URL, args)
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
@@ -372,8 +459,8 @@ func (s StatusCodeError) Error() string {
 	return fmt.Sprintf("non-200 response %d %s with body %q", s.Code, s.Status, s.Body)
 }
 
-func latestResultTime(previousLastResult *time.Time, results []*result.CommitMatch, searchErr error) time.Time {
-	if searchErr != nil || len(results) == 0 {
+func latestResultTime(previousLastResult *time.Time, results *codemonitors.Results, searchErr error) time.Time {
+	if searchErr != nil || results.Empty() {
 		// Error performing the search, or there were no results. Assume the
 		// previous info's result time.
 		if previousLastResult != nil {
@@ -382,8 +469,9 @@ func latestResultTime(previousLastResult *time.Time, results []*result.CommitMat
 		return time.Now()
 	}
 
-	if results[0].Commit.Committer != nil {
-		return results[0].Commit.Committer.Date
+	// Content search results have no date, so they were found now.
+	if len(results.Commits) > 0 && results.Commits[0].Commit.Committer != nil {
+		return results.Commits[0].Commit.Committer.Date
 	}
 	return time.Now()
 }
@@ -0,0 +1,194 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// isContentSearch returns true if the job does not search commits or diffs, in
+// which case the monitor watches the set of files matching the query instead.
+func isContentSearch(j job.Job) bool {
+	return !job.HasDescendent[*commit.SearchJob](j)
+}
+
+// validateContentPlan returns an error if the query of a monitor on content
+// search can return anything but file matches, which are the only results
+// searchContent can compare between runs.
+func validateContentPlan(plan query.Plan) (err error) {
+	for _, b := range plan {
+		nodes := b.ToParseTree()
+		query.VisitField(nodes, query.FieldType, func(value string, negated bool, _ query.Annotation) {
+			if err == nil && !negated && value != "file" {
+				err = errors.Errorf("code monitors do not support type:%s. Monitor file contents by removing type:%s, or commits with type:commit or type:diff.", value, value)
+			}
+		})
+		query.VisitField(nodes, query.FieldSelect, func(value string, negated bool, _ query.Annotation) {
+			sp, selectErr := filter.SelectPathFromString(value)
+			if err == nil && (selectErr != nil || (sp.Root() != filter.Content && sp.Root() != filter.File)) {
+				err = errors.Errorf("code monitors on file contents do not support select:%s. Only select:content and select:file are supported.", value)
+			}
+		})
+	}
+	return err
+}
+
+// searchContent runs a content search for a code monitor and compares the
+// matched files with the fingerprints stored by the previous run. It stores
+// the new fingerprints and returns the files that started or stopped matching.
+func searchContent(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) (*database.ContentSearchResults, error) {
+	agg := streaming.NewAggregatingStream()
+	_, err := planJob.Run(ctx, clients, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[api.RepoID][]*result.FileMatch)
+	for _, res := range agg.Results {
+		fm, ok := res.(*result.FileMatch)
+		if !ok {
+			return nil, errors.Errorf("expected content search to only return file matches, but got type %T", res)
+		}
+		current[fm.Repo.ID] = append(current[fm.Repo.ID], fm)
+	}
+
+	cm := db.CodeMonitors()
+	previous, err := cm.ListMatchFingerprints(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := diffMatchFingerprints(previous, current, func(repoID api.RepoID) bool {
+		_, ok := current[repoID]
+		// Without any matches we can only tell a repo stopped matching if
+		// it was searched completely.
+		return agg.Stats.Status.Get(repoID) == 0 && (ok || !agg.Stats.IsLimitHit)
+	})
+
+	var res database.ContentSearchResults
+	for _, d := range diffs {
+		if err := cm.UpsertMatchFingerprints(ctx, monitorID, d.next); err != nil {
+			return nil, err
+		}
+		res.Added = append(res.Added, d.added...)
+		res.Removed = append(res.Removed, d.removed...)
+	}
+	return &res, nil
+}
+
+type matchFingerprintsDiff struct {
+	// next are the fingerprints to store for the next run.
+	next *database.MatchFingerprints
+
+	added   []*result.FileMatch
+	removed []*result.FileMatch
+}
+
+// diffMatchFingerprints compares the files matched in each repository with the
+// fingerprints of the previous run. complete reports whether a repository was
+// searched completely; if not, files missing from current are kept rather than
+// reported as removed.
+func diffMatchFingerprints(previous []*database.MatchFingerprints, current map[api.RepoID][]*result.FileMatch, complete func(api.RepoID) bool) []matchFingerprintsDiff {
+	previousByRepo := make(map[api.RepoID]*database.MatchFingerprints, len(previous))
+	for _, fp := range previous {
+		previousByRepo[fp.Repo.ID] = fp
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(current)+len(previous))
+	for repoID := range current {
+		repoIDs = append(repoIDs, repoID)
+	}
+	for _, fp := range previous {
+		if _, ok := current[fp.Repo.ID]; !ok {
+			repoIDs = append(repoIDs, fp.Repo.ID)
+		}
+	}
+	sort.Slice(repoIDs, func(i, j int) bool { return repoIDs[i] < repoIDs[j] })
+
+	var diffs []matchFingerprintsDiff
+	for _, repoID := range repoIDs {
+		prev, fms := previousByRepo[repoID], current[repoID]
+		isComplete := complete(repoID)
+		if len(fms) == 0 && !isComplete {
+			continue
+		}
+
+		d := matchFingerprintsDiff{next: &database.MatchFingerprints{Files: make(map[string]string, len(fms))}}
+		if prev != nil {
+			d.next.Repo = prev.Repo
+			d.next.CommitOIDs = prev.CommitOIDs
+		}
+
+		commits := make(map[string]struct{})
+		for _, fm := range fms {
+			d.next.Repo = fm.Repo
+			commits[string(fm.CommitID)] = struct{}{}
+
+			fp := fingerprint(fm)
+			d.next.Files[fm.Path] = fp
+			if prev == nil || prev.Files[fm.Path] != fp {
+				d.added = append(d.added, fm)
+			}
+		}
+		if len(commits) > 0 {
+			d.next.CommitOIDs = make([]string, 0, len(commits))
+			for c := range commits {
+				d.next.CommitOIDs = append(d.next.CommitOIDs, c)
+			}
+			sort.Strings(d.next.CommitOIDs)
+		}
+
+		if prev != nil {
+			paths := make([]string, 0, len(prev.Files))
+			for path := range prev.Files {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+
+			for _, path := range paths {
+				if _, ok := d.next.Files[path]; ok {
+					continue
+				}
+				if !isComplete {
+					d.next.Files[path] = prev.Files[path]
+					continue
+				}
+				var commitID api.CommitID
+				if len(d.next.CommitOIDs) > 0 {
+					commitID = api.CommitID(d.next.CommitOIDs[0])
+				}
+				d.removed = append(d.removed, &result.FileMatch{
+					File: result.File{Repo: d.next.Repo, CommitID: commitID, Path: path},
+				})
+			}
+		}
+
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
+
+// fingerprint identifies the matched content of a file. It does not depend on
+// where in the file the matches are, so that unrelated edits to a file do not
+// trigger the monitor.
+func fingerprint(fm *result.FileMatch) string {
+	h := sha256.New()
+	for _, cm := range fm.ChunkMatches {
+		for _, s := range cm.MatchedContent() {
+			h.Write([]byte(s))
+			h.Write([]byte{0})
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
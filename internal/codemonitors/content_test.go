@@ -0,0 +1,128 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDiffMatchFingerprints(t *testing.T) {
+	repo1 := types.MinimalRepo{ID: 1, Name: "repo1"}
+	repo2 := types.MinimalRepo{ID: 2, Name: "repo2"}
+
+	fileMatch := func(repo types.MinimalRepo, path string, matches ...string) *result.FileMatch {
+		fm := &result.FileMatch{File: result.File{Repo: repo, CommitID: "commit2", Path: path}}
+		for _, m := range matches {
+			fm.ChunkMatches = append(fm.ChunkMatches, result.ChunkMatch{
+				Content: m,
+				Ranges:  result.Ranges{{End: result.Location{Offset: len(m), Column: len(m)}}},
+			})
+		}
+		return fm
+	}
+	removed := func(repo types.MinimalRepo, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: repo, CommitID: "commit2", Path: path}}
+	}
+
+	unchanged := fileMatch(repo1, "unchanged.go", "foo")
+	changed := fileMatch(repo1, "changed.go", "bar")
+	added := fileMatch(repo1, "added.go", "baz")
+
+	previous := []*database.MatchFingerprints{{
+		Repo:       repo1,
+		CommitOIDs: []string{"commit1"},
+		Files: map[string]string{
+			"unchanged.go": fingerprint(unchanged),
+			"changed.go":   fingerprint(fileMatch(repo1, "changed.go", "old")),
+			"removed.go":   fingerprint(fileMatch(repo1, "removed.go", "qux")),
+		},
+	}, {
+		Repo:       repo2,
+		CommitOIDs: []string{"commit1"},
+		Files:      map[string]string{"gone.go": fingerprint(fileMatch(repo2, "gone.go", "qux"))},
+	}}
+
+	current := map[api.RepoID][]*result.FileMatch{
+		repo1.ID: {unchanged, changed, added},
+	}
+
+	t.Run("complete search", func(t *testing.T) {
+		diffs := diffMatchFingerprints(previous, current, func(api.RepoID) bool { return true })
+		require.Len(t, diffs, 2)
+
+		require.Equal(t, []*result.FileMatch{changed, added}, diffs[0].added)
+		require.Equal(t, []*result.FileMatch{removed(repo1, "removed.go")}, diffs[0].removed)
+		require.Equal(t, []string{"commit2"}, diffs[0].next.CommitOIDs)
+		require.Len(t, diffs[0].next.Files, 3)
+		require.NotContains(t, diffs[0].next.Files, "removed.go")
+
+		// A repository without any matches left is reported against the
+		// last commit it was searched at.
+		require.Empty(t, diffs[1].added)
+		require.Equal(t, []*result.FileMatch{{File: result.File{Repo: repo2, CommitID: "commit1", Path: "gone.go"}}}, diffs[1].removed)
+		require.Empty(t, diffs[1].next.Files)
+	})
+
+	t.Run("incomplete search", func(t *testing.T) {
+		diffs := diffMatchFingerprints(previous, current, func(api.RepoID) bool { return false })
+		require.Len(t, diffs, 1)
+
+		require.Equal(t, []*result.FileMatch{changed, added}, diffs[0].added)
+		require.Empty(t, diffs[0].removed)
+		require.Equal(t, previous[0].Files["removed.go"], diffs[0].next.Files["removed.go"])
+	})
+
+	t.Run("first run", func(t *testing.T) {
+		diffs := diffMatchFingerprints(nil, current, func(api.RepoID) bool { return true })
+		require.Len(t, diffs, 1)
+		require.Equal(t, []*result.FileMatch{unchanged, changed, added}, diffs[0].added)
+		require.Empty(t, diffs[0].removed)
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	fm := func(line int, content string) *result.FileMatch {
+		return &result.FileMatch{ChunkMatches: result.ChunkMatches{{
+			Content:      content,
+			ContentStart: result.Location{Line: line},
+			Ranges:       result.Ranges{{Start: result.Location{Line: line}, End: result.Location{Line: line, Offset: 3, Column: 3}}},
+		}}}
+	}
+
+	// Moving a match within the file does not change the fingerprint.
+	require.Equal(t, fingerprint(fm(1, "foo bar")), fingerprint(fm(10, "foo baz")))
+	require.NotEqual(t, fingerprint(fm(1, "foo")), fingerprint(fm(1, "bar")))
+}
+
+func TestValidateContentPlan(t *testing.T) {
+	for q, wantErr := range map[string]bool{
+		"foo":                                false,
+		"type:file foo":                      false,
+		"foo select:content":                 false,
+		"foo select:file":                    false,
+		"foo select:file.directory":          false,
+		"type:repo foo":                      true,
+		"type:symbol foo":                    true,
+		"type:path foo":                      true,
+		"foo select:repo":                    true,
+		"foo select:symbol.function":         true,
+		"(type:file foo) or (type:repo bar)": true,
+	} {
+		t.Run(q, func(t *testing.T) {
+			plan, err := query.Pipeline(query.InitRegexp(q))
+			require.NoError(t, err)
+			err = validateContentPlan(plan)
+			if wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
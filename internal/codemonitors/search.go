@@ -23,7 +23,23 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64) (_ []*result.CommitMatch, err error) {
+// Results are the new results found by a code monitor search. Monitors on
+// commit and diff search set Commits, while monitors on content search set
+// Content.
+type Results struct {
+	Commits []*result.CommitMatch
+	Content *database.ContentSearchResults
+}
+
+// Empty returns true if the search found no new results.
+func (r *Results) Empty() bool {
+	if r == nil {
+		return true
+	}
+	return len(r.Commits) == 0 && (r.Content == nil || r.Content.ResultCount() == 0)
+}
+
+func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64) (_ *Results, err error) {
 	searchClient := client.New(logger, db)
 	inputs, err := searchClient.Plan(
 		ctx,
@@ -44,6 +60,17 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	if isContentSearch(planJob) {
+		if err := validateContentPlan(inputs.Plan); err != nil {
+			return nil, errcode.MakeNonRetryable(err)
+		}
+		content, err := searchContent(ctx, db, clients, planJob, monitorID)
+		if err != nil {
+			return nil, err
+		}
+		return &Results{Content: content}, nil
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 		return hookWithID(ctx, db, logger, gs, monitorID, repoID, args, doSearch)
 	}
@@ -68,7 +95,35 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		results[i] = cm
 	}
 
-	return results, nil
+	return &Results{Commits: results}, nil
+}
+
+// ValidateQuery returns an error if query can't be run by a code monitor. It is
+// checked when a monitor is created or its query changes, so that the monitor
+// doesn't fail on every run instead.
+func ValidateQuery(ctx context.Context, logger log.Logger, db database.DB, query string) error {
+	searchClient := client.New(logger, db)
+	inputs, err := searchClient.Plan(
+		ctx,
+		"V3",
+		nil,
+		query,
+		search.Precise,
+		search.Streaming,
+	)
+	if err != nil {
+		return err
+	}
+
+	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan)
+	if err != nil {
+		return err
+	}
+
+	if isContentSearch(planJob) {
+		return validateContentPlan(inputs.Plan)
+	}
+	return nil
 }
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
@@ -94,6 +149,13 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return err
 	}
 
+	if isContentSearch(planJob) {
+		// Storing the fingerprints of the current matches is the snapshot,
+		// so there is nothing to do with the results.
+		_, err = searchContent(ctx, db, clients, planJob, monitorID)
+		return err
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
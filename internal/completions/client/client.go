@@ -5,21 +5,22 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/azureopenai"
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/codygateway"
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/openai"
+	"github.com/sourcegraph/sourcegraph/internal/completions/client/openaicompatible"
 	"github.com/sourcegraph/sourcegraph/internal/completions/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func Get(endpoint string, provider conftypes.CompletionsProviderName, accessToken string) (types.CompletionsClient, error) {
-	client, err := getBasic(endpoint, provider, accessToken)
+func Get(config *conftypes.CompletionsConfig) (types.CompletionsClient, error) {
+	client, err := getBasic(config.Endpoint, config.Provider, config.AccessToken, config.OpenAICompatible)
 	if err != nil {
 		return nil, err
 	}
 	return newObservedClient(client), nil
 }
 
-func getBasic(endpoint string, provider conftypes.CompletionsProviderName, accessToken string) (types.CompletionsClient, error) {
+func getBasic(endpoint string, provider conftypes.CompletionsProviderName, accessToken string, openAICompatible conftypes.OpenAICompatibleConfig) (types.CompletionsClient, error) {
 	switch provider {
 	case conftypes.CompletionsProviderNameAnthropic:
 		return anthropic.NewClient(httpcli.ExternalDoer, endpoint, accessToken), nil
@@ -27,6 +28,8 @@ func getBasic(endpoint string, provider conftypes.CompletionsProviderName, acces
 		return openai.NewClient(httpcli.ExternalDoer, endpoint, accessToken), nil
 	case conftypes.CompletionsProviderNameAzureOpenAI:
 		return azureopenai.NewClient(httpcli.ExternalDoer, endpoint, accessToken), nil
+	case conftypes.CompletionsProviderNameOpenAICompatible:
+		return openaicompatible.NewClient(httpcli.ExternalDoer, endpoint, accessToken, openAICompatible), nil
 	case conftypes.CompletionsProviderNameSourcegraph:
 		return codygateway.NewClient(httpcli.ExternalDoer, endpoint, accessToken)
 	default:
@@ -0,0 +1,99 @@
+package openaicompatible
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+const maxPayloadSize = 10 * 1024 * 1024 // 10mb
+
+var doneBytes = []byte("[DONE]")
+
+// decoder decodes the data of events from a Server Sent Event stream. Unlike the
+// decoder for the OpenAI API, it is tolerant of the variations of self-hosted
+// servers: lines may end in \r\n, events may have several data lines, comments
+// (keep-alives) and event, id and retry fields are skipped, and the stream may
+// end without a [DONE] sentinel.
+type decoder struct {
+	scanner *bufio.Scanner
+	done    bool
+	data    []byte
+	err     error
+}
+
+func NewDecoder(r io.Reader) *decoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxPayloadSize)
+	return &decoder{
+		scanner: scanner,
+	}
+}
+
+// Scan advances the decoder to the next event with data in the stream. It
+// returns false when it either hits the end of the stream or an error.
+func (d *decoder) Scan() bool {
+	if d.done {
+		return false
+	}
+
+	var data [][]byte
+	for d.scanner.Scan() {
+		line := bytes.TrimSuffix(d.scanner.Bytes(), []byte("\r"))
+		if len(line) == 0 {
+			// An empty line dispatches the event, if it has any data.
+			if len(data) == 0 {
+				continue
+			}
+			return d.dispatch(data)
+		}
+		if line[0] == ':' {
+			// Comment, commonly sent as a keep-alive.
+			continue
+		}
+
+		field, value := splitColon(line)
+		if field == "data" {
+			data = append(data, append([]byte(nil), value...))
+		}
+		// Any other field (event, id, retry) carries nothing we need.
+	}
+
+	if d.err = d.scanner.Err(); d.err != nil {
+		return false
+	}
+	// The stream may end without a trailing empty line.
+	return len(data) > 0 && d.dispatch(data)
+}
+
+// dispatch sets the data of the current event. It returns false if the event
+// is the [DONE] sentinel.
+func (d *decoder) dispatch(data [][]byte) bool {
+	d.data = bytes.Join(data, []byte("\n"))
+	if bytes.Equal(bytes.TrimSpace(d.data), doneBytes) {
+		d.done = true
+		d.data = nil
+		return false
+	}
+	return true
+}
+
+// Data returns the event data of the last decoded event.
+func (d *decoder) Data() []byte {
+	return d.data
+}
+
+// Err returns the last encountered error.
+func (d *decoder) Err() error {
+	return d.err
+}
+
+// splitColon splits a line into its field and value. As in the Server Sent
+// Events specification, a single space after the colon is not part of the value.
+func splitColon(line []byte) (string, []byte) {
+	i := bytes.IndexByte(line, ':')
+	if i < 0 {
+		return string(line), nil
+	}
+	return string(line[:i]), bytes.TrimPrefix(line[i+1:], []byte(" "))
+}
@@ -0,0 +1,79 @@
+package openaicompatible
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+
+	decodeAll := func(input string) ([]string, error) {
+		dec := NewDecoder(strings.NewReader(input))
+		var events []string
+		for dec.Scan() {
+			events = append(events, string(dec.Data()))
+		}
+		return events, dec.Err()
+	}
+
+	for _, tc := range []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "Single",
+			input: "data:b\n\n",
+			want:  []string{"b"},
+		},
+		{
+			name:  "Multiple",
+			input: "data: b\n\ndata: c\n\ndata: [DONE]\n\n",
+			want:  []string{"b", "c"},
+		},
+		{
+			name:  "Ends after done",
+			input: "data: b\n\ndata: [DONE]\n\ndata: c\n\n",
+			want:  []string{"b"},
+		},
+		{
+			name:  "No done",
+			input: "data: b\n\ndata: c\n\n",
+			want:  []string{"b", "c"},
+		},
+		{
+			name:  "No trailing empty line",
+			input: "data: b\n\ndata: c",
+			want:  []string{"b", "c"},
+		},
+		{
+			name:  "CRLF",
+			input: "data: b\r\n\r\ndata: c\r\n\r\ndata: [DONE]\r\n\r\n",
+			want:  []string{"b", "c"},
+		},
+		{
+			name:  "Multi-line data",
+			input: "data: {\"a\":\ndata: 1}\n\n",
+			want:  []string{"{\"a\":\n1}"},
+		},
+		{
+			name:  "Comments and other fields",
+			input: ": ping\n\nevent: message\nid: 1\nretry: 1000\ndata: b\n\n: ping\n\n",
+			want:  []string{"b"},
+		},
+		{
+			name:  "Extra empty lines",
+			input: "\n\n\ndata: b\n\n\n\ndata: c\n\n",
+			want:  []string{"b", "c"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := decodeAll(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, events)
+		})
+	}
+}
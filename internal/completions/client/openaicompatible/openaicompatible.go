@@ -0,0 +1,255 @@
+// Package openaicompatible implements a completions client for self-hosted servers
+// that expose an OpenAI-compatible chat completions API, such as vLLM, text-generation-inference,
+// LocalAI, llama.cpp or Ollama.
+package openaicompatible
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewClient returns a client for the chat completions API served at the base URL
+// endpoint, e.g. "http://localhost:8000/v1".
+func NewClient(cli httpcli.Doer, endpoint, accessToken string, config conftypes.OpenAICompatibleConfig) types.CompletionsClient {
+	return &openAICompatibleClient{
+		cli:         cli,
+		endpoint:    strings.TrimRight(endpoint, "/") + "/chat/completions",
+		accessToken: accessToken,
+		config:      config,
+	}
+}
+
+type openAICompatibleClient struct {
+	cli         httpcli.Doer
+	endpoint    string
+	accessToken string
+	config      conftypes.OpenAICompatibleConfig
+}
+
+func (c *openAICompatibleClient) Complete(
+	ctx context.Context,
+	feature types.CompletionsFeature,
+	requestParams types.CompletionRequestParameters,
+) (*types.CompletionResponse, error) {
+	resp, err := c.makeRequest(ctx, requestParams, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response chatCompletionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	if err := response.Error.err(); err != nil {
+		return nil, err
+	}
+
+	if len(response.Choices) == 0 {
+		// Empty response.
+		return &types.CompletionResponse{}, nil
+	}
+
+	return &types.CompletionResponse{
+		Completion: response.Choices[0].content(),
+		StopReason: response.Choices[0].FinishReason,
+	}, nil
+}
+
+func (c *openAICompatibleClient) Stream(
+	ctx context.Context,
+	feature types.CompletionsFeature,
+	requestParams types.CompletionRequestParameters,
+	sendEvent types.SendCompletionEvent,
+) error {
+	resp, err := c.makeRequest(ctx, requestParams, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	dec := NewDecoder(resp.Body)
+	var content string
+	for dec.Scan() {
+		if ctx.Err() != nil && ctx.Err() == context.Canceled {
+			return nil
+		}
+
+		data := bytes.TrimSpace(dec.Data())
+		// Gracefully skip over any data that isn't JSON-like.
+		if !bytes.HasPrefix(data, []byte("{")) {
+			continue
+		}
+
+		var event chatCompletionsResponse
+		if err := json.Unmarshal(data, &event); err != nil {
+			return errors.Errorf("failed to decode event payload: %w - body: %s", err, string(data))
+		}
+		if err := event.Error.err(); err != nil {
+			return err
+		}
+
+		if len(event.Choices) > 0 {
+			content += event.Choices[0].content()
+			err = sendEvent(types.CompletionResponse{
+				Completion: content,
+				StopReason: event.Choices[0].FinishReason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return dec.Err()
+}
+
+func (c *openAICompatibleClient) makeRequest(ctx context.Context, requestParams types.CompletionRequestParameters, stream bool) (*http.Response, error) {
+	if requestParams.TopP < 0 {
+		requestParams.TopP = 0
+	}
+
+	payload := chatCompletionsRequestParameters{
+		Model:       c.config.Model(requestParams.Model),
+		Temperature: requestParams.Temperature,
+		TopP:        requestParams.TopP,
+		N:           1,
+		Stream:      stream,
+		MaxTokens:   requestParams.MaxTokensToSample,
+		Stop:        requestParams.StopSequences,
+	}
+	for _, m := range requestParams.Messages {
+		// Servers that apply a chat template usually reject an assistant message
+		// without content, which our clients send last to prime the response.
+		if m.Speaker == types.ASISSTANT_MESSAGE_SPEAKER && m.Text == "" {
+			continue
+		}
+		role := "user"
+		if m.Speaker == types.ASISSTANT_MESSAGE_SPEAKER {
+			role = "assistant"
+		}
+		payload.Messages = append(payload.Messages, message{
+			Role:    role,
+			Content: m.Text,
+		})
+	}
+
+	reqBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if stream {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+	// Servers in a trusted network are often run without authentication.
+	if c.accessToken != "" {
+		req.Header.Set(c.config.AuthHeader, c.config.AuthHeaderPrefix+c.accessToken)
+	}
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, types.NewErrStatusNotOK("OpenAI-compatible", resp)
+	}
+
+	return resp, nil
+}
+
+type chatCompletionsRequestParameters struct {
+	Model       string    `json:"model"`
+	Messages    []message `json:"messages"`
+	Temperature float32   `json:"temperature,omitempty"`
+	TopP        float32   `json:"top_p,omitempty"`
+	N           int       `json:"n,omitempty"`
+	Stream      bool      `json:"stream,omitempty"`
+	Stop        []string  `json:"stop,omitempty"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type choiceMessage struct {
+	Content string `json:"content"`
+}
+
+type choice struct {
+	// Message is set in responses to non-streaming requests.
+	Message choiceMessage `json:"message"`
+	// Delta is set in the events of streaming requests.
+	Delta choiceMessage `json:"delta"`
+	// Text is set instead by servers that respond in the format of the legacy
+	// completions API.
+	Text string `json:"text"`
+	// FinishReason is null until the last event of streaming requests, which
+	// decodes to the empty string.
+	FinishReason string `json:"finish_reason"`
+}
+
+func (c choice) content() string {
+	switch {
+	case c.Delta.Content != "":
+		return c.Delta.Content
+	case c.Message.Content != "":
+		return c.Message.Content
+	default:
+		return c.Text
+	}
+}
+
+type chatCompletionsResponse struct {
+	Choices []choice       `json:"choices"`
+	Error   *responseError `json:"error"`
+}
+
+// responseError is an error reported in the body of a response or in an event,
+// either as an object with a message or as a plain string.
+type responseError struct {
+	Message string
+}
+
+func (e *responseError) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		e.Message = message
+		return nil
+	}
+	var object struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	e.Message = object.Message
+	return nil
+}
+
+func (e *responseError) err() error {
+	if e == nil {
+		return nil
+	}
+	if e.Message == "" {
+		return errors.New("OpenAI-compatible: request failed")
+	}
+	return errors.Newf("OpenAI-compatible: %s", e.Message)
+}
@@ -0,0 +1,161 @@
+package openaicompatible
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+)
+
+var testConfig = conftypes.OpenAICompatibleConfig{
+	AuthHeader:       "Authorization",
+	AuthHeaderPrefix: "Bearer ",
+	ModelMapping:     map[string]string{"llama-2-70b-chat": "meta-llama/Llama-2-70b-chat-hf"},
+}
+
+var testParams = types.CompletionRequestParameters{
+	Model:             "llama-2-70b-chat",
+	MaxTokensToSample: 100,
+	Messages: []types.Message{
+		{Speaker: types.HUMAN_MESSAGE_SPEAKER, Text: "Hello"},
+		{Speaker: types.ASISSTANT_MESSAGE_SPEAKER, Text: "Hi!"},
+		{Speaker: types.HUMAN_MESSAGE_SPEAKER, Text: "Write a haiku"},
+		{Speaker: types.ASISSTANT_MESSAGE_SPEAKER},
+	},
+}
+
+// newTestServer returns a stand-in for an OpenAI-compatible server that records the
+// requests it receives and responds with body.
+func newTestServer(t *testing.T, body string) (*httptest.Server, *[]*http.Request, *[]chatCompletionsRequestParameters) {
+	t.Helper()
+
+	var requests []*http.Request
+	var payloads []chatCompletionsRequestParameters
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload chatCompletionsRequestParameters
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, r)
+		payloads = append(payloads, payload)
+		if r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests, &payloads
+}
+
+func TestComplete(t *testing.T) {
+	srv, requests, payloads := newTestServer(t, `{"id":"1","object":"chat.completion","choices":[{"index":0,"message":{"role":"assistant","content":"Silent code compiles"},"finish_reason":"stop"}]}`)
+
+	client := NewClient(http.DefaultClient, srv.URL+"/v1/", "secret", testConfig)
+	resp, err := client.Complete(context.Background(), types.CompletionsFeatureChat, testParams)
+	require.NoError(t, err)
+	assert.Equal(t, &types.CompletionResponse{Completion: "Silent code compiles", StopReason: "stop"}, resp)
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "Bearer secret", (*requests)[0].Header.Get("Authorization"))
+	assert.Equal(t, chatCompletionsRequestParameters{
+		Model: "meta-llama/Llama-2-70b-chat-hf",
+		Messages: []message{
+			{Role: "user", Content: "Hello"},
+			{Role: "assistant", Content: "Hi!"},
+			{Role: "user", Content: "Write a haiku"},
+		},
+		N:         1,
+		MaxTokens: 100,
+	}, (*payloads)[0])
+}
+
+func TestCompleteLegacyText(t *testing.T) {
+	srv, _, _ := newTestServer(t, `{"choices":[{"text":"Silent code compiles","finish_reason":"length"}]}`)
+
+	client := NewClient(http.DefaultClient, srv.URL+"/v1", "", testConfig)
+	resp, err := client.Complete(context.Background(), types.CompletionsFeatureCode, testParams)
+	require.NoError(t, err)
+	assert.Equal(t, &types.CompletionResponse{Completion: "Silent code compiles", StopReason: "length"}, resp)
+}
+
+func TestStream(t *testing.T) {
+	// Mixes the variations of different servers: keep-alive comments, event names,
+	// null finish reasons, CRLF line endings and no [DONE] sentinel.
+	body := ": keep-alive\n\n" +
+		"data: {\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\"},\"finish_reason\":null}]}\n\n" +
+		"event: message\ndata: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Silent \"},\"finish_reason\":null}]}\n\n" +
+		"data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"code\"},\"finish_reason\":null}]}\r\n\r\n" +
+		"data: {\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}]}\n\n"
+	srv, requests, payloads := newTestServer(t, body)
+
+	config := testConfig
+	config.AuthHeader = "X-Api-Key"
+	config.AuthHeaderPrefix = ""
+	client := NewClient(http.DefaultClient, srv.URL+"/v1", "secret", config)
+
+	var events []types.CompletionResponse
+	err := client.Stream(context.Background(), types.CompletionsFeatureChat, testParams, func(event types.CompletionResponse) error {
+		events = append(events, event)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []types.CompletionResponse{
+		{Completion: ""},
+		{Completion: "Silent "},
+		{Completion: "Silent code"},
+		{Completion: "Silent code", StopReason: "stop"},
+	}, events)
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "secret", (*requests)[0].Header.Get("X-Api-Key"))
+	assert.Empty(t, (*requests)[0].Header.Get("Authorization"))
+	assert.True(t, (*payloads)[0].Stream)
+}
+
+func TestStreamError(t *testing.T) {
+	body := "data: {\"choices\":[{\"delta\":{\"content\":\"Silent \"}}]}\n\n" +
+		"data: {\"error\":{\"message\":\"model overloaded\"}}\n\n"
+	srv, _, _ := newTestServer(t, body)
+
+	client := NewClient(http.DefaultClient, srv.URL+"/v1", "", testConfig)
+	err := client.Stream(context.Background(), types.CompletionsFeatureChat, testParams, func(event types.CompletionResponse) error { return nil })
+	require.Error(t, err)
+	autogold.Expect("OpenAI-compatible: model overloaded").Equal(t, err.Error())
+}
+
+func TestErrStatusNotOK(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "oh no, please slow down!", http.StatusTooManyRequests)
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(http.DefaultClient, srv.URL, "", testConfig)
+
+	t.Run("Complete", func(t *testing.T) {
+		resp, err := client.Complete(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{})
+		require.Error(t, err)
+		assert.Nil(t, resp)
+
+		autogold.Expect("OpenAI-compatible: unexpected status code 429: oh no, please slow down!\n").Equal(t, err.Error())
+		_, ok := types.IsErrStatusNotOK(err)
+		assert.True(t, ok)
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		err := client.Stream(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{}, func(event types.CompletionResponse) error { return nil })
+		require.Error(t, err)
+
+		_, ok := types.IsErrStatusNotOK(err)
+		assert.True(t, ok)
+	})
+}
@@ -60,11 +60,7 @@ func newCompletionsHandler(
 			Build()
 		defer done()
 
-		completionClient, err := client.Get(
-			completionsConfig.Endpoint,
-			completionsConfig.Provider,
-			completionsConfig.AccessToken,
-		)
+		completionClient, err := client.Get(completionsConfig)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
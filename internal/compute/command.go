@@ -21,9 +21,11 @@ type Command interface {
 var (
 	_ Command = (*MatchOnly)(nil)
 	_ Command = (*Replace)(nil)
+	_ Command = (*ReplaceDiff)(nil)
 	_ Command = (*Output)(nil)
 )
 
-func (MatchOnly) command() {}
-func (Replace) command()   {}
-func (Output) command()    {}
+func (MatchOnly) command()   {}
+func (Replace) command()     {}
+func (ReplaceDiff) command() {}
+func (Output) command()      {}
@@ -83,6 +83,18 @@ func resultChunks(r result.Match, kind string, onlyPath bool) []string {
 			return []string{strings.Join(chunks, "")}
 		}
 
+		return chunks
+	case *result.FileDiffMatch:
+		if onlyPath {
+			return []string{m.Path}
+		}
+
+		chunks := make([]string, 0, len(m.Added))
+		for _, cm := range m.Added {
+			for _, range_ := range cm.Ranges {
+				chunks = append(chunks, chunkContent(cm, range_))
+			}
+		}
 		return chunks
 	case *result.CommitDiffMatch:
 		var sb strings.Builder
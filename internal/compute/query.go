@@ -2,6 +2,7 @@ package compute
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/regexp"
 
@@ -100,10 +101,15 @@ var ComputePredicateRegistry = query.PredicateRegistry{
 		"replace":            func() query.Predicate { return query.EmptyPredicate{} },
 		"replace.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
 		"replace.structural": func() query.Predicate { return query.EmptyPredicate{} },
-		"output":             func() query.Predicate { return query.EmptyPredicate{} },
-		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
-		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
-		"output.extra":       func() query.Predicate { return query.EmptyPredicate{} },
+
+		"replace.diff":            func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.diff.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.diff.structural": func() query.Predicate { return query.EmptyPredicate{} },
+
+		"output":            func() query.Predicate { return query.EmptyPredicate{} },
+		"output.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
+		"output.structural": func() query.Predicate { return query.EmptyPredicate{} },
+		"output.extra":      func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
@@ -147,13 +153,13 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 
 	var matchPattern MatchPattern
 	switch name {
-	case "replace", "replace.regexp":
+	case "replace", "replace.regexp", "replace.diff", "replace.diff.regexp":
 		var err error
 		matchPattern, err = toRegexpPattern(left)
 		if err != nil {
 			return nil, false, errors.Wrap(err, "replace command")
 		}
-	case "replace.structural":
+	case "replace.structural", "replace.diff.structural":
 		// structural search doesn't do any match pattern validation
 		matchPattern = &Comby{Value: left}
 	default:
@@ -161,6 +167,13 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 		return nil, false, nil
 	}
 
+	if strings.HasPrefix(name, "replace.diff") {
+		return &ReplaceDiff{
+			SearchPattern:  matchPattern,
+			ReplacePattern: right,
+		}, true, nil
+	}
+
 	return &Replace{
 		SearchPattern:  matchPattern,
 		ReplacePattern: right,
@@ -41,6 +41,12 @@ func TestParse(t *testing.T) {
 
 	autogold.Expect("Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Expect("Command: `Replace diff: (a) -> (b)`").
+		Equal(t, test("content:replace.diff(a -> b)"))
+
+	autogold.Expect("Command: `Replace diff: (foo(:[x])) -> (bar(:[x]))`").
+		Equal(t, test("content:replace.diff.structural(foo(:[x]) -> bar(:[x]))"))
 }
 
 func TestToSearchQuery(t *testing.T) {
@@ -0,0 +1,107 @@
+package compute
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// ReplaceDiff replaces all matches of SearchPattern in a file, like Replace,
+// but outputs the change as a diff of the file rather than its new content.
+// This previews a rewrite across many files without applying it.
+type ReplaceDiff struct {
+	SearchPattern  MatchPattern
+	ReplacePattern string
+}
+
+func (c *ReplaceDiff) ToSearchPattern() string {
+	return c.SearchPattern.String()
+}
+
+func (c *ReplaceDiff) String() string {
+	return fmt.Sprintf("Replace diff: (%s) -> (%s)", c.SearchPattern.String(), c.ReplacePattern)
+}
+
+// replaceDiff returns the diff of replacing all matches in the content of the
+// file at path. It returns nil if the replacement does not change the file.
+func replaceDiff(ctx context.Context, path string, content []byte, matchPattern MatchPattern, replacePattern string) (*Text, error) {
+	replaced, err := replace(ctx, content, matchPattern, replacePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	before := string(content)
+	edits := myers.ComputeEdits(span.URIFromPath(path), before, replaced.Value)
+	unified := gotextdiff.ToUnified(path, path, before, edits)
+	if len(unified.Hunks) == 0 {
+		return nil, nil
+	}
+
+	fileDiff := &diff.FileDiff{
+		OrigName: path,
+		NewName:  path,
+		Hunks:    make([]*diff.Hunk, 0, len(unified.Hunks)),
+	}
+	for _, h := range unified.Hunks {
+		fileDiff.Hunks = append(fileDiff.Hunks, toDiffHunk(h))
+	}
+
+	return &Text{Value: search.FormatFullDiff([]*diff.FileDiff{fileDiff}), Kind: "replace-diff"}, nil
+}
+
+func toDiffHunk(h *gotextdiff.Hunk) *diff.Hunk {
+	hunk := &diff.Hunk{
+		OrigStartLine: int32(h.FromLine),
+		NewStartLine:  int32(h.ToLine),
+	}
+
+	var body bytes.Buffer
+	for _, l := range h.Lines {
+		switch l.Kind {
+		case gotextdiff.Delete:
+			body.WriteByte('-')
+			hunk.OrigLines++
+		case gotextdiff.Insert:
+			body.WriteByte('+')
+			hunk.NewLines++
+		default:
+			body.WriteByte(' ')
+			hunk.OrigLines++
+			hunk.NewLines++
+		}
+		body.WriteString(l.Content)
+		if !strings.HasSuffix(l.Content, "\n") {
+			body.WriteByte('\n')
+		}
+	}
+	hunk.Body = body.Bytes()
+	return hunk
+}
+
+func (c *ReplaceDiff) Run(ctx context.Context, gitserverClient gitserver.Client, r result.Match) (Result, error) {
+	switch m := r.(type) {
+	case *result.FileMatch:
+		content, err := gitserverClient.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, m.Repo.Name, m.CommitID, m.Path)
+		if err != nil {
+			return nil, err
+		}
+		text, err := replaceDiff(ctx, m.Path, content, c.SearchPattern, c.ReplacePattern)
+		if text == nil || err != nil {
+			// Avoid returning a typed nil Result when nothing changed.
+			return nil, err
+		}
+		return text, nil
+	}
+	return nil, nil
+}
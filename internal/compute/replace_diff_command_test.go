@@ -0,0 +1,52 @@
+package compute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/regexp"
+	"github.com/hexops/autogold/v2"
+)
+
+func Test_replaceDiff(t *testing.T) {
+	test := func(input string, cmd *ReplaceDiff) string {
+		result, err := replaceDiff(context.Background(), "main.go", []byte(input), cmd.SearchPattern, cmd.ReplacePattern)
+		if err != nil {
+			return err.Error()
+		}
+		if result == nil {
+			return "<no change>"
+		}
+		return result.Value
+	}
+
+	input := `package main
+
+func main() {
+	foo()
+	bar()
+	foo()
+}
+`
+
+	autogold.Expect(`main.go main.go
+@@ -3,5 +3,5 @@ 
+ func main() {
+-	foo()
++	baz()
+ 	bar()
+-	foo()
++	baz()
+ }
+`).
+		Equal(t, test(input, &ReplaceDiff{
+			SearchPattern:  &Regexp{Value: regexp.MustCompile(`foo\(\)`)},
+			ReplacePattern: "baz()",
+		}))
+
+	autogold.Expect("<no change>").
+		Equal(t, test(input, &ReplaceDiff{
+			SearchPattern:  &Regexp{Value: regexp.MustCompile(`qux`)},
+			ReplacePattern: "quux",
+		}))
+}
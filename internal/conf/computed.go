@@ -734,12 +734,35 @@ func GetCompletionsConfig(siteConfig schema.SiteConfiguration) (c *conftypes.Com
 		if completionsConfig.CompletionModel == "" {
 			return nil
 		}
+	} else if completionsConfig.Provider == string(conftypes.CompletionsProviderNameOpenAICompatible) {
+		// If no base URL is configured, we don't know where the server is. Bail.
+		if completionsConfig.Endpoint == "" {
+			return nil
+		}
+
+		// Self-hosted servers serve any models, so we cannot pick a default. Bail.
+		if completionsConfig.ChatModel == "" {
+			return nil
+		}
+
+		// If not fast chat model is set, we fall back to the Chat Model.
+		if completionsConfig.FastChatModel == "" {
+			completionsConfig.FastChatModel = completionsConfig.ChatModel
+		}
+
+		// If not completions model is set, we fall back to the Chat Model.
+		if completionsConfig.CompletionModel == "" {
+			completionsConfig.CompletionModel = completionsConfig.ChatModel
+		}
 	}
 
-	// Make sure models are always treated case-insensitive.
-	completionsConfig.ChatModel = strings.ToLower(completionsConfig.ChatModel)
-	completionsConfig.FastChatModel = strings.ToLower(completionsConfig.FastChatModel)
-	completionsConfig.CompletionModel = strings.ToLower(completionsConfig.CompletionModel)
+	// Make sure models are always treated case-insensitive. Model names of self-hosted
+	// servers are often case-sensitive, so they are sent as configured.
+	if completionsConfig.Provider != string(conftypes.CompletionsProviderNameOpenAICompatible) {
+		completionsConfig.ChatModel = strings.ToLower(completionsConfig.ChatModel)
+		completionsConfig.FastChatModel = strings.ToLower(completionsConfig.FastChatModel)
+		completionsConfig.CompletionModel = strings.ToLower(completionsConfig.CompletionModel)
+	}
 
 	// If after trying to set default we still have not all models configured, completions are
 	// not available.
@@ -772,6 +795,9 @@ func GetCompletionsConfig(siteConfig schema.SiteConfiguration) (c *conftypes.Com
 		PerUserDailyLimit:                completionsConfig.PerUserDailyLimit,
 		PerUserCodeCompletionsDailyLimit: completionsConfig.PerUserCodeCompletionsDailyLimit,
 	}
+	if computedConfig.Provider == conftypes.CompletionsProviderNameOpenAICompatible {
+		computedConfig.OpenAICompatible = getOpenAICompatibleConfig(completionsConfig.OpenAICompatible)
+	}
 
 	return computedConfig
 }
@@ -916,6 +942,21 @@ func GetEmbeddingsConfig(siteConfig schema.SiteConfiguration) *conftypes.Embeddi
 		// Make sure models are always treated case-insensitive.
 		// TODO: Are model names on azure case insensitive?
 		embeddingsConfig.Model = strings.ToLower(embeddingsConfig.Model)
+	} else if embeddingsConfig.Provider == string(conftypes.EmbeddingsProviderNameOpenAICompatible) {
+		// If no base URL is configured, we don't know where the server is. Bail.
+		if embeddingsConfig.Endpoint == "" {
+			return nil
+		}
+
+		// If no model is set, we cannot do anything here.
+		if embeddingsConfig.Model == "" {
+			return nil
+		}
+
+		// We cannot know the dimensions of self-hosted models. Bail.
+		if embeddingsConfig.Dimensions <= 0 {
+			return nil
+		}
 	} else {
 		// Unknown provider value.
 		return nil
@@ -959,9 +1000,39 @@ func GetEmbeddingsConfig(siteConfig schema.SiteConfiguration) *conftypes.Embeddi
 		computedConfig.MinimumInterval = d
 	}
 
+	if computedConfig.Provider == conftypes.EmbeddingsProviderNameOpenAICompatible {
+		computedConfig.OpenAICompatible = getOpenAICompatibleConfig(embeddingsConfig.OpenAICompatible)
+	}
+
+	if ai := embeddingsConfig.ApproximateIndex; ai != nil && ai.Enabled {
+		computedConfig.ApproximateIndex = conftypes.EmbeddingsApproximateIndex{
+			Enabled: true,
+			MinRows: defaultTo(ai.MinRows, defaultApproximateIndexMinRows),
+			Probes:  defaultTo(ai.Probes, defaultApproximateIndexProbes),
+		}
+	}
+
 	return computedConfig
 }
 
+func getOpenAICompatibleConfig(c *schema.OpenAICompatibleProvider) conftypes.OpenAICompatibleConfig {
+	computed := conftypes.OpenAICompatibleConfig{
+		AuthHeader:       "Authorization",
+		AuthHeaderPrefix: "Bearer ",
+	}
+	if c == nil {
+		return computed
+	}
+	if c.AuthHeader != "" {
+		computed.AuthHeader = c.AuthHeader
+	}
+	if c.AuthHeaderPrefix != nil {
+		computed.AuthHeaderPrefix = *c.AuthHeaderPrefix
+	}
+	computed.ModelMapping = c.ModelMapping
+	return computed
+}
+
 func getSourcegraphProviderAccessToken(accessToken string, config schema.SiteConfiguration) string {
 	// If an access token is configured, use it.
 	if accessToken != "" {
@@ -986,6 +1057,8 @@ const (
 	defaultMinimumInterval            = 24 * time.Hour
 	defaultMaxCodeEmbeddingsPerRepo   = 3_072_000
 	defaultMaxTextEmbeddingsPerRepo   = 512_000
+	defaultApproximateIndexMinRows    = 50_000
+	defaultApproximateIndexProbes     = 32
 )
 
 func defaultTo(val, def int) int {
@@ -1010,7 +1083,7 @@ func defaultMaxPromptTokens(provider conftypes.CompletionsProviderName, model st
 		return anthropicDefaultMaxPromptTokens(model)
 	case conftypes.CompletionsProviderNameOpenAI:
 		return openaiDefaultMaxPromptTokens(model)
-	case conftypes.CompletionsProviderNameAzureOpenAI:
+	case conftypes.CompletionsProviderNameAzureOpenAI, conftypes.CompletionsProviderNameOpenAICompatible:
 		// We cannot know based on the model name what model is actually used,
 		// this is a sane default for GPT in general.
 		return 8_000
@@ -549,6 +549,49 @@ func TestGetCompletionsConfig(t *testing.T) {
 				Endpoint:                 "https://acmecorp.openai.azure.com",
 			},
 		},
+		{
+			name: "OpenAI-compatible completions",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Completions: &schema.Completions{
+					Provider:  "openai-compatible",
+					Endpoint:  "http://localhost:8000/v1",
+					ChatModel: "Llama-2-70b-chat",
+					OpenAICompatible: &schema.OpenAICompatibleProvider{
+						AuthHeaderPrefix: pointers.Ptr(""),
+						ModelMapping:     map[string]string{"Llama-2-70b-chat": "meta-llama/Llama-2-70b-chat-hf"},
+					},
+				},
+			},
+			wantConfig: &conftypes.CompletionsConfig{
+				ChatModel:                "Llama-2-70b-chat",
+				ChatModelMaxTokens:       8000,
+				FastChatModel:            "Llama-2-70b-chat",
+				FastChatModelMaxTokens:   8000,
+				CompletionModel:          "Llama-2-70b-chat",
+				CompletionModelMaxTokens: 8000,
+				Provider:                 "openai-compatible",
+				Endpoint:                 "http://localhost:8000/v1",
+				OpenAICompatible: conftypes.OpenAICompatibleConfig{
+					AuthHeader:       "Authorization",
+					AuthHeaderPrefix: "",
+					ModelMapping:     map[string]string{"Llama-2-70b-chat": "meta-llama/Llama-2-70b-chat-hf"},
+				},
+			},
+		},
+		{
+			name: "OpenAI-compatible completions without base URL",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Completions: &schema.Completions{
+					Provider:  "openai-compatible",
+					ChatModel: "llama-2-70b-chat",
+				},
+			},
+			wantDisabled: true,
+		},
 		{
 			name: "zero-config cody gateway completions without license key",
 			siteConfig: schema.SiteConfiguration{
@@ -962,6 +1005,51 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				ExcludeChunkOnError: true,
 			},
 		},
+		{
+			name: "OpenAI-compatible provider",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider:   "openai-compatible",
+					Endpoint:   "http://localhost:8000/v1",
+					Dimensions: 768,
+					Model:      "nomic-embed-text",
+				},
+			},
+			wantConfig: &conftypes.EmbeddingsConfig{
+				Provider:                   "openai-compatible",
+				Model:                      "nomic-embed-text",
+				Endpoint:                   "http://localhost:8000/v1",
+				Dimensions:                 768,
+				Incremental:                true,
+				MinimumInterval:            24 * time.Hour,
+				MaxCodeEmbeddingsPerRepo:   3_072_000,
+				MaxTextEmbeddingsPerRepo:   512_000,
+				PolicyRepositoryMatchLimit: pointers.Ptr(5000),
+				FileFilters: conftypes.EmbeddingsFileFilters{
+					MaxFileSizeBytes: 1000000,
+				},
+				ExcludeChunkOnError: true,
+				OpenAICompatible: conftypes.OpenAICompatibleConfig{
+					AuthHeader:       "Authorization",
+					AuthHeaderPrefix: "Bearer ",
+				},
+			},
+		},
+		{
+			name: "OpenAI-compatible provider without dimensions",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider: "openai-compatible",
+					Endpoint: "http://localhost:8000/v1",
+					Model:    "nomic-embed-text",
+				},
+			},
+			wantDisabled: true,
+		},
 		{
 			name:       "App default config",
 			deployType: deploy.App,
@@ -1054,6 +1142,38 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				ExcludeChunkOnError: true,
 			},
 		},
+		{
+			name: "Approximate index enabled",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider:         "sourcegraph",
+					ApproximateIndex: &schema.EmbeddingsApproximateIndex{Enabled: true, Probes: 64},
+				},
+			},
+			wantConfig: &conftypes.EmbeddingsConfig{
+				Provider:                   "sourcegraph",
+				AccessToken:                licenseAccessToken,
+				Model:                      "openai/text-embedding-ada-002",
+				Endpoint:                   "https://cody-gateway.sourcegraph.com/v1/embeddings",
+				Dimensions:                 1536,
+				Incremental:                true,
+				MinimumInterval:            24 * time.Hour,
+				MaxCodeEmbeddingsPerRepo:   3_072_000,
+				MaxTextEmbeddingsPerRepo:   512_000,
+				PolicyRepositoryMatchLimit: pointers.Ptr(5000),
+				FileFilters: conftypes.EmbeddingsFileFilters{
+					MaxFileSizeBytes: 1000000,
+				},
+				ExcludeChunkOnError: true,
+				ApproximateIndex: conftypes.EmbeddingsApproximateIndex{
+					Enabled: true,
+					MinRows: 50_000,
+					Probes:  64,
+				},
+			},
+		},
 		{
 			name:       "App without dotcom or user token",
 			deployType: deploy.App,
@@ -17,15 +17,19 @@ type CompletionsConfig struct {
 	Endpoint                         string
 	PerUserDailyLimit                int
 	PerUserCodeCompletionsDailyLimit int
+
+	// OpenAICompatible is only set for the openai-compatible provider.
+	OpenAICompatible OpenAICompatibleConfig
 }
 
 type CompletionsProviderName string
 
 const (
-	CompletionsProviderNameAnthropic   CompletionsProviderName = "anthropic"
-	CompletionsProviderNameOpenAI      CompletionsProviderName = "openai"
-	CompletionsProviderNameAzureOpenAI CompletionsProviderName = "azure-openai"
-	CompletionsProviderNameSourcegraph CompletionsProviderName = "sourcegraph"
+	CompletionsProviderNameAnthropic        CompletionsProviderName = "anthropic"
+	CompletionsProviderNameOpenAI           CompletionsProviderName = "openai"
+	CompletionsProviderNameAzureOpenAI      CompletionsProviderName = "azure-openai"
+	CompletionsProviderNameSourcegraph      CompletionsProviderName = "sourcegraph"
+	CompletionsProviderNameOpenAICompatible CompletionsProviderName = "openai-compatible"
 )
 
 type EmbeddingsConfig struct {
@@ -41,14 +45,18 @@ type EmbeddingsConfig struct {
 	MaxTextEmbeddingsPerRepo   int
 	PolicyRepositoryMatchLimit *int
 	ExcludeChunkOnError        bool
+	ApproximateIndex           EmbeddingsApproximateIndex
+	// OpenAICompatible is only set for the openai-compatible provider.
+	OpenAICompatible OpenAICompatibleConfig
 }
 
 type EmbeddingsProviderName string
 
 const (
-	EmbeddingsProviderNameOpenAI      EmbeddingsProviderName = "openai"
-	EmbeddingsProviderNameAzureOpenAI EmbeddingsProviderName = "azure-openai"
-	EmbeddingsProviderNameSourcegraph EmbeddingsProviderName = "sourcegraph"
+	EmbeddingsProviderNameOpenAI           EmbeddingsProviderName = "openai"
+	EmbeddingsProviderNameAzureOpenAI      EmbeddingsProviderName = "azure-openai"
+	EmbeddingsProviderNameSourcegraph      EmbeddingsProviderName = "sourcegraph"
+	EmbeddingsProviderNameOpenAICompatible EmbeddingsProviderName = "openai-compatible"
 )
 
 type EmbeddingsFileFilters struct {
@@ -56,3 +64,27 @@ type EmbeddingsFileFilters struct {
 	ExcludedFilePathPatterns []string
 	MaxFileSizeBytes         int
 }
+
+type EmbeddingsApproximateIndex struct {
+	Enabled bool
+	MinRows int
+	Probes  int
+}
+
+// OpenAICompatibleConfig configures how to talk to a server that implements the OpenAI API.
+type OpenAICompatibleConfig struct {
+	// AuthHeader is the header in which the access token is sent.
+	AuthHeader string
+	// AuthHeaderPrefix is prepended to the access token in the auth header.
+	AuthHeaderPrefix string
+	// ModelMapping maps configured model names to the model names sent to the server.
+	ModelMapping map[string]string
+}
+
+// Model returns the name of the model that is sent to the server for the configured model.
+func (c OpenAICompatibleConfig) Model(model string) string {
+	if mapped, ok := c.ModelMapping[model]; ok {
+		return mapped
+	}
+	return model
+}
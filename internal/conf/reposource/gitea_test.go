@@ -0,0 +1,49 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGitea_cloneURLToRepoName(t *testing.T) {
+	tests := []struct {
+		conn schema.GiteaConnection
+		urls []urlToRepoName
+	}{{
+		conn: schema.GiteaConnection{
+			Url: "https://gitea.example.org",
+		},
+		urls: []urlToRepoName{
+			{"git@gitea.example.org:owner/repo.git", "gitea.example.org/owner/repo"},
+			{"https://gitea.example.org/owner/repo.git", "gitea.example.org/owner/repo"},
+			{"https://token@gitea.example.org/owner/repo.git", "gitea.example.org/owner/repo"},
+
+			{"git@asdf.com:owner/repo.git", ""},
+			{"https://asdf.com/owner/repo.git", ""},
+		},
+	}, {
+		conn: schema.GiteaConnection{
+			Url:                   "https://codeberg.org",
+			RepositoryPathPattern: "forgejo/{nameWithOwner}",
+		},
+		urls: []urlToRepoName{
+			{"git@codeberg.org:owner/repo.git", "forgejo/owner/repo"},
+			{"https://codeberg.org/owner/repo.git", "forgejo/owner/repo"},
+
+			{"https://asdf.com/owner/repo.git", ""},
+		},
+	}}
+
+	for _, test := range tests {
+		for _, u := range test.urls {
+			repoName, err := Gitea{&test.conn}.CloneURLToRepoName(u.cloneURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.repoName != string(repoName) {
+				t.Errorf("expected %q but got %q for clone URL %q (connection: %+v)", u.repoName, repoName, u.cloneURL, test.conn)
+			}
+		}
+	}
+}
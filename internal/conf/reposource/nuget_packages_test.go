@@ -0,0 +1,35 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNuGetVersionedPackage(t *testing.T) {
+	table := []struct {
+		dependency string
+		name       PackageName
+		version    string
+		valid      bool
+	}{
+		{"Newtonsoft.Json@13.0.3", "Newtonsoft.Json", "13.0.3", true},
+		{"Microsoft.Extensions.Logging@8.0.0-preview.7", "Microsoft.Extensions.Logging", "8.0.0-preview.7", true},
+		{"xunit", "xunit", "", true},
+		{"@13.0.3", "", "", false},
+		{"../Newtonsoft.Json@13.0.3", "", "", false},
+		{"..@13.0.3", "", "", false},
+		{"Newtonsoft Json@13.0.3", "", "", false},
+	}
+	for _, entry := range table {
+		dep, err := ParseNuGetVersionedPackage(entry.dependency)
+		if !entry.valid {
+			assert.Error(t, err, entry.dependency)
+			continue
+		}
+		require.NoError(t, err, entry.dependency)
+		assert.Equal(t, entry.name, dep.Name)
+		assert.Equal(t, entry.version, dep.Version)
+	}
+}
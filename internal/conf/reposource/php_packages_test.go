@@ -0,0 +1,50 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParsePHPVersionedPackage(t *testing.T) {
+	table := []struct {
+		dependency string
+		name       PackageName
+		version    string
+		valid      bool
+	}{
+		{"monolog/monolog@3.4.0", "monolog/monolog", "3.4.0", true},
+		{"symfony/console@v6.3.4", "symfony/console", "v6.3.4", true},
+		{"psr/log", "psr/log", "", true},
+		{"monolog@3.4.0", "", "", false},
+		{"/monolog@3.4.0", "", "", false},
+		{"monolog/@3.4.0", "", "", false},
+		{"monolog/monolog/extra@3.4.0", "", "", false},
+		{"../monolog@3.4.0", "", "", false},
+		{"monolog/..@3.4.0", "", "", false},
+		{"./monolog@3.4.0", "", "", false},
+	}
+	for _, entry := range table {
+		dep, err := ParsePHPVersionedPackage(entry.dependency)
+		if !entry.valid {
+			assert.Error(t, err, entry.dependency)
+			continue
+		}
+		require.NoError(t, err, entry.dependency)
+		assert.Equal(t, entry.name, dep.Name)
+		assert.Equal(t, entry.version, dep.Version)
+	}
+}
+
+func TestParsePHPPackageFromRepoName(t *testing.T) {
+	dep, err := ParsePHPPackageFromRepoName("packagist/monolog/monolog")
+	require.NoError(t, err)
+	assert.Equal(t, api.RepoName("packagist/monolog/monolog"), dep.RepoName())
+	assert.Equal(t, "v3.4.0", NewPHPVersionedPackage(dep.Name, "3.4.0").GitTagFromVersion())
+
+	_, err = ParsePHPPackageFromRepoName("github.com/monolog/monolog")
+	assert.Error(t, err)
+}
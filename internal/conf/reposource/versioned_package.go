@@ -25,4 +25,6 @@ var (
 	_ VersionedPackage = (*GoVersionedPackage)(nil)
 	_ VersionedPackage = (*PythonVersionedPackage)(nil)
 	_ VersionedPackage = (*RustVersionedPackage)(nil)
+	_ VersionedPackage = (*NuGetVersionedPackage)(nil)
+	_ VersionedPackage = (*PHPVersionedPackage)(nil)
 )
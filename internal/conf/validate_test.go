@@ -296,6 +296,29 @@ func TestRedactConfSecrets(t *testing.T) {
 	}
 }
 
+func TestRedactLDAPBindPassword(t *testing.T) {
+	site := `{
+  "auth.providers": [
+    {
+      "bindDN": "cn=sourcegraph,dc=example,dc=com",
+      "bindPassword": "strongsecret",
+      "type": "ldap",
+      "url": "ldaps://ldap.example.com",
+      "userSearchBase": "dc=example,dc=com"
+    }
+  ]
+}`
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.NotContains(t, redacted.Site, "strongsecret")
+	assert.Contains(t, redacted.Site, `"bindPassword": "REDACTED"`)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Equal(t, site, unredacted)
+}
+
 func TestReturnSafeConfig(t *testing.T) {
 	conf := `{
   "executors.frontendURL": "http://host.docker.internal:3082",
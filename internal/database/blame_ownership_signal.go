@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type BlameOwnershipSignalStore interface {
+	// ReplaceSignals replaces all the blame ownership signals of the given
+	// repository with the given ones.
+	ReplaceSignals(ctx context.Context, repoID api.RepoID, signals []BlameOwnershipSignal) error
+	// FindBlameOwners returns the blame owners of the file or directory tree at
+	// given path, ordered by score.
+	FindBlameOwners(ctx context.Context, repoID api.RepoID, path string) ([]BlameOwnerSummary, error)
+	// ListBlameOwnersForRepo returns the blame owners of all the files and
+	// directory trees of the given repository whose score is at least minScore.
+	ListBlameOwnersForRepo(ctx context.Context, repoID api.RepoID, minScore float64) ([]BlameOwnerSummary, error)
+	// ClearSignals removes all the blame ownership signals of the given
+	// repository.
+	ClearSignals(ctx context.Context, repoID api.RepoID) error
+	WithTransact(context.Context, func(store BlameOwnershipSignalStore) error) error
+}
+
+func BlameOwnershipSignalStoreWith(other basestore.ShareableStore) BlameOwnershipSignalStore {
+	return &blameOwnershipSignalStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+// BlameOwnershipSignal is the share of the current lines of a file or directory
+// tree that were last changed by a given commit author.
+type BlameOwnershipSignal struct {
+	// Path of the file or directory tree. Empty string designates the repo root.
+	Path        string
+	AuthorName  string
+	AuthorEmail string
+	// LinesCount is the number of lines last changed by the author.
+	LinesCount int
+	// Score is the share of the lines last changed by the author, between 0
+	// and 1, where each line is weighted by the age of its last change.
+	Score float64
+}
+
+type BlameOwnerSummary struct {
+	FilePath    string
+	AuthorName  string
+	AuthorEmail string
+	LinesCount  int
+	Score       float64
+}
+
+type blameOwnershipSignalStore struct {
+	*basestore.Store
+}
+
+func (s *blameOwnershipSignalStore) WithTransact(ctx context.Context, f func(store BlameOwnershipSignalStore) error) error {
+	return s.Store.WithTransact(ctx, func(tx *basestore.Store) error {
+		return f(BlameOwnershipSignalStoreWith(tx))
+	})
+}
+
+const clearBlameOwnershipSignalsFmtstr = `
+	WITH rps AS (
+		SELECT id FROM repo_paths WHERE repo_id = %s
+	)
+	DELETE FROM own_aggregate_blame_ownership
+	WHERE file_path_id IN (SELECT * FROM rps)
+`
+
+func (s *blameOwnershipSignalStore) ClearSignals(ctx context.Context, repoID api.RepoID) error {
+	return s.Exec(ctx, sqlf.Sprintf(clearBlameOwnershipSignalsFmtstr, repoID))
+}
+
+type commitAuthor struct {
+	name, email string
+}
+
+// ReplaceSignals clears the existing signals of the repository and inserts
+// the given ones in a single transaction, so that readers never observe a
+// partially indexed repository.
+func (s *blameOwnershipSignalStore) ReplaceSignals(ctx context.Context, repoID api.RepoID, signals []BlameOwnershipSignal) error {
+	return s.Store.WithTransact(ctx, func(tx *basestore.Store) error {
+		if err := tx.Exec(ctx, sqlf.Sprintf(clearBlameOwnershipSignalsFmtstr, repoID)); err != nil {
+			return errors.Wrap(err, "cannot clear signals")
+		}
+		if len(signals) == 0 {
+			return nil
+		}
+
+		// Get or create commit authors and repo paths of all the signals:
+		authorIDs := map[commitAuthor]int{}
+		var paths []string
+		seenPaths := map[string]bool{}
+		for _, signal := range signals {
+			author := commitAuthor{name: signal.AuthorName, email: signal.AuthorEmail}
+			if _, ok := authorIDs[author]; !ok {
+				id, err := ensureCommitAuthor(ctx, tx, author.name, author.email)
+				if err != nil {
+					return errors.Wrap(err, "cannot insert commit author")
+				}
+				authorIDs[author] = id
+			}
+			if !seenPaths[signal.Path] {
+				seenPaths[signal.Path] = true
+				paths = append(paths, signal.Path)
+			}
+		}
+		ids, err := ensureRepoPaths(ctx, tx, paths, repoID)
+		if err != nil {
+			return errors.Wrap(err, "cannot insert repo paths")
+		}
+		pathIDs := make(map[string]int, len(paths))
+		for i, p := range paths {
+			pathIDs[p] = ids[i]
+		}
+
+		inserter := batch.NewInserter(ctx, tx.Handle(), "own_aggregate_blame_ownership", batch.MaxNumPostgresParameters, "commit_author_id", "file_path_id", "lines_count", "score")
+		for _, signal := range signals {
+			if err := inserter.Insert(
+				ctx,
+				authorIDs[commitAuthor{name: signal.AuthorName, email: signal.AuthorEmail}],
+				pathIDs[signal.Path],
+				signal.LinesCount,
+				signal.Score,
+			); err != nil {
+				return err
+			}
+		}
+		return inserter.Flush(ctx)
+	})
+}
+
+const findBlameOwnersFmtstr = `
+	SELECT p.absolute_path, a.name, a.email, b.lines_count, b.score
+	FROM own_aggregate_blame_ownership AS b
+	INNER JOIN commit_authors AS a
+	ON a.id = b.commit_author_id
+	INNER JOIN repo_paths AS p
+	ON p.id = b.file_path_id
+	WHERE %s
+	ORDER BY b.score DESC, b.lines_count DESC, a.email
+`
+
+var scanBlameOwnerSummaries = basestore.NewSliceScanner(func(scanner dbutil.Scanner) (BlameOwnerSummary, error) {
+	var summary BlameOwnerSummary
+	if err := scanner.Scan(&summary.FilePath, &summary.AuthorName, &summary.AuthorEmail, &summary.LinesCount, &summary.Score); err != nil {
+		return BlameOwnerSummary{}, err
+	}
+	return summary, nil
+})
+
+// FindBlameOwners returns the blame owners for given `repoID` and `path`.
+// `path` has no forward slash at the beginning, and empty string designates
+// the repo root.
+func (s *blameOwnershipSignalStore) FindBlameOwners(ctx context.Context, repoID api.RepoID, path string) ([]BlameOwnerSummary, error) {
+	q := sqlf.Sprintf(findBlameOwnersFmtstr, sqlf.Sprintf("p.repo_id = %s AND p.absolute_path = %s", repoID, path))
+	return scanBlameOwnerSummaries(s.Query(ctx, q))
+}
+
+func (s *blameOwnershipSignalStore) ListBlameOwnersForRepo(ctx context.Context, repoID api.RepoID, minScore float64) ([]BlameOwnerSummary, error) {
+	q := sqlf.Sprintf(findBlameOwnersFmtstr, sqlf.Sprintf("p.repo_id = %s AND b.score >= %s", repoID, minScore))
+	return scanBlameOwnerSummaries(s.Query(ctx, q))
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestBlameOwnershipSignalStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	store := BlameOwnershipSignalStoreWith(db)
+
+	ctx := context.Background()
+	repo := mustCreate(ctx, t, db, &types.Repo{Name: "a/b"})
+
+	signals := []BlameOwnershipSignal{
+		{Path: "", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 10, Score: 0.8},
+		{Path: "", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 5, Score: 0.2},
+		{Path: "dir/file.txt", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 10, Score: 0.8},
+		{Path: "dir/file.txt", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 5, Score: 0.2},
+	}
+	require.NoError(t, store.ReplaceSignals(ctx, repo.ID, signals))
+
+	got, err := store.FindBlameOwners(ctx, repo.ID, "dir/file.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []BlameOwnerSummary{
+		{FilePath: "dir/file.txt", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 10, Score: 0.8},
+		{FilePath: "dir/file.txt", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 5, Score: 0.2},
+	}, got)
+
+	got, err = store.ListBlameOwnersForRepo(ctx, repo.ID, 0.5)
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+	for _, summary := range got {
+		assert.Equal(t, "alice@example.com", summary.AuthorEmail)
+	}
+
+	// Replacing the signals removes the ones that are gone.
+	require.NoError(t, store.ReplaceSignals(ctx, repo.ID, signals[:2]))
+	got, err = store.FindBlameOwners(ctx, repo.ID, "dir/file.txt")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	require.NoError(t, store.ClearSignals(ctx, repo.ID))
+	got, err = store.FindBlameOwners(ctx, repo.ID, "")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -14,11 +14,13 @@ import (
 )
 
 type ActionJob struct {
-	ID           int32
-	Email        *int64
-	Webhook      *int64
-	SlackWebhook *int64
-	TriggerEvent int32
+	ID                int32
+	Email             *int64
+	Webhook           *int64
+	SlackWebhook      *int64
+	TeamsWebhook      *int64
+	MattermostWebhook *int64
+	TriggerEvent      int32
 
 	// Fields demanded by any dbworker.
 	State          string
@@ -45,6 +47,10 @@ type ActionJobMetadata struct {
 	Results     []*result.CommitMatch
 	OwnerName   string
 
+	// ContentResults is set instead of Results for monitors on content
+	// search.
+	ContentResults *ContentSearchResults
+
 	// The query with after: filter.
 	Query string
 }
@@ -56,6 +62,8 @@ var ActionJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.email"),
 	sqlf.Sprintf("cm_action_jobs.webhook"),
 	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
+	sqlf.Sprintf("cm_action_jobs.teams_webhook"),
+	sqlf.Sprintf("cm_action_jobs.mattermost_webhook"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
@@ -87,6 +95,16 @@ type ListActionJobsOpts struct {
 	// the given slack webhook action. Refers to cm_slack_webhooks(id)
 	SlackWebhookID *int
 
+	// TeamsWebhookID, if set, will filter to only actions jobs that are
+	// executing the given Microsoft Teams webhook action. Refers to
+	// cm_teams_webhooks(id)
+	TeamsWebhookID *int
+
+	// MattermostWebhookID, if set, will filter to only actions jobs that are
+	// executing the given Mattermost webhook action. Refers to
+	// cm_mattermost_webhooks(id)
+	MattermostWebhookID *int
+
 	// First, if defined, limits the operation to only the first n results
 	First *int
 
@@ -110,6 +128,12 @@ func (o ListActionJobsOpts) Conds() *sqlf.Query {
 	if o.SlackWebhookID != nil {
 		conds = append(conds, sqlf.Sprintf("slack_webhook = %s", *o.SlackWebhookID))
 	}
+	if o.TeamsWebhookID != nil {
+		conds = append(conds, sqlf.Sprintf("teams_webhook = %s", *o.TeamsWebhookID))
+	}
+	if o.MattermostWebhookID != nil {
+		conds = append(conds, sqlf.Sprintf("mattermost_webhook = %s", *o.MattermostWebhookID))
+	}
 	if o.After != nil {
 		conds = append(conds, sqlf.Sprintf("id > %s", *o.After))
 	}
@@ -197,14 +221,36 @@ WITH due_emails AS (
 	SELECT DISTINCT slack_webhook as id FROM cm_action_jobs
 	WHERE state = 'queued'
 		OR state = 'processing'
+), due_teams_webhooks AS (
+	SELECT id
+	FROM cm_teams_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT teams_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
+), due_mattermost_webhooks AS (
+	SELECT id
+	FROM cm_mattermost_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT mattermost_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
 )
-INSERT INTO cm_action_jobs (email, webhook, slack_webhook, trigger_event)
-SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+INSERT INTO cm_action_jobs (email, webhook, slack_webhook, teams_webhook, mattermost_webhook, trigger_event)
+SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
 UNION
-SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_webhooks
+SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_slack_webhooks
-ORDER BY 1, 2, 3
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_slack_webhooks
+UNION
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_teams_webhooks
+UNION
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_mattermost_webhooks
+ORDER BY 1, 2, 3, 4, 5
 RETURNING %s
 `
 
@@ -214,6 +260,10 @@ func (s *codeMonitorStore) EnqueueActionJobsForMonitor(ctx context.Context, moni
 		monitorID,
 		monitorID,
 		monitorID,
+		monitorID,
+		monitorID,
+		triggerJobID,
+		triggerJobID,
 		triggerJobID,
 		triggerJobID,
 		triggerJobID,
@@ -233,6 +283,7 @@ SELECT
 	ctj.query_string,
 	cm.id AS monitorID,
 	ctj.search_results,
+	ctj.content_search_results,
 	CASE WHEN LENGTH(users.display_name) > 0 THEN users.display_name ELSE users.username END
 FROM cm_action_jobs caj
 INNER JOIN cm_trigger_jobs ctj on caj.trigger_event = ctj.id
@@ -245,15 +296,21 @@ WHERE caj.id = %s
 // GetActionJobMetada returns the set of fields needed to execute all action jobs
 func (s *codeMonitorStore) GetActionJobMetadata(ctx context.Context, jobID int32) (*ActionJobMetadata, error) {
 	row := s.Store.QueryRow(ctx, sqlf.Sprintf(getActionJobMetadataFmtStr, jobID))
-	var resultsJSON []byte
+	var resultsJSON, contentResultsJSON []byte
 	m := &ActionJobMetadata{}
-	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &m.OwnerName)
+	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &contentResultsJSON, &m.OwnerName)
 	if err != nil {
 		return nil, err
 	}
 	if err := json.Unmarshal(resultsJSON, &m.Results); err != nil {
 		return nil, err
 	}
+	if len(contentResultsJSON) > 0 {
+		m.ContentResults = &ContentSearchResults{}
+		if err := json.Unmarshal(contentResultsJSON, m.ContentResults); err != nil {
+			return nil, err
+		}
+	}
 	return m, nil
 }
 
@@ -288,6 +345,8 @@ func ScanActionJob(row dbutil.Scanner) (*ActionJob, error) {
 		&aj.Email,
 		&aj.Webhook,
 		&aj.SlackWebhook,
+		&aj.TeamsWebhook,
+		&aj.MattermostWebhook,
 		&aj.TriggerEvent,
 		&aj.State,
 		&aj.FailureMessage,
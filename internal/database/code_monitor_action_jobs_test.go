@@ -39,6 +39,47 @@ func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
 	require.Equal(t, want, actionJobs[0])
 }
 
+func TestEnqueueActionJobsForChatWebhooks(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, userCTX := newTestUser(ctx, t, db)
+	fixtures := s.insertTestMonitor(userCTX, t)
+
+	teams, err := s.CreateTeamsWebhookAction(userCTX, fixtures.monitor.ID, true, true, "https://example.webhook.office.com/webhookb2/1")
+	require.NoError(t, err)
+	mattermost, err := s.CreateMattermostWebhookAction(userCTX, fixtures.monitor.ID, true, true, "https://mattermost.example.com/hooks/1")
+	require.NoError(t, err)
+	_, err = s.CreateMattermostWebhookAction(userCTX, fixtures.monitor.ID, false, true, "https://mattermost.example.com/hooks/2")
+	require.NoError(t, err)
+
+	triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, triggerJobs, 1)
+
+	actionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobs[0].ID)
+	require.NoError(t, err)
+	// Two emails, one Teams webhook and the enabled Mattermost webhook.
+	require.Len(t, actionJobs, 4)
+
+	var teamsJobs, mattermostJobs []*ActionJob
+	for _, j := range actionJobs {
+		if j.TeamsWebhook != nil {
+			require.Equal(t, teams.ID, *j.TeamsWebhook)
+			teamsJobs = append(teamsJobs, j)
+		}
+		if j.MattermostWebhook != nil {
+			require.Equal(t, mattermost.ID, *j.MattermostWebhook)
+			mattermostJobs = append(mattermostJobs, j)
+		}
+	}
+	require.Len(t, teamsJobs, 1)
+	require.Len(t, mattermostJobs, 1)
+
+	teamsID := int(teams.ID)
+	count, err := s.CountActionJobs(ctx, ListActionJobsOpts{TeamsWebhookID: &teamsID})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
 func TestGetActionJobMetadata(t *testing.T) {
 	ctx, db, s := newTestStore(t)
 	userName, _, userCTX := newTestUser(ctx, t, db)
@@ -3,11 +3,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -56,3 +58,74 @@ func (s *codeMonitorStore) GetLastSearched(ctx context.Context, monitorID int64,
 	}
 	return commitOIDs, err
 }
+
+// MatchFingerprints is the set of files in a repository that a content search
+// code monitor matched on its last run.
+type MatchFingerprints struct {
+	Repo       types.MinimalRepo
+	CommitOIDs []string
+
+	// Files maps the path of each matched file to a fingerprint of its
+	// matches, so that changed matches can be detected on the next run.
+	Files map[string]string
+}
+
+func (s *codeMonitorStore) UpsertMatchFingerprints(ctx context.Context, monitorID int64, fingerprints *MatchFingerprints) error {
+	rawQuery := `
+	INSERT INTO cm_last_searched (monitor_id, repo_id, commit_oids, match_fingerprints)
+	VALUES (%s, %s, %s, %s)
+	ON CONFLICT (monitor_id, repo_id) DO UPDATE
+	SET commit_oids = EXCLUDED.commit_oids,
+		match_fingerprints = EXCLUDED.match_fingerprints
+	`
+
+	// Appease non-null constraints on columns
+	commitOIDs := fingerprints.CommitOIDs
+	if commitOIDs == nil {
+		commitOIDs = []string{}
+	}
+	files := fingerprints.Files
+	if files == nil {
+		files = map[string]string{}
+	}
+	filesJSON, err := json.Marshal(files)
+	if err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(fingerprints.Repo.ID), pq.StringArray(commitOIDs), filesJSON)
+	return s.Exec(ctx, q)
+}
+
+func (s *codeMonitorStore) ListMatchFingerprints(ctx context.Context, monitorID int64) ([]*MatchFingerprints, error) {
+	rawQuery := `
+	SELECT repo.id, repo.name, cm_last_searched.commit_oids, cm_last_searched.match_fingerprints
+	FROM cm_last_searched
+	JOIN repo ON repo.id = cm_last_searched.repo_id
+	WHERE cm_last_searched.monitor_id = %s
+		AND repo.deleted_at IS NULL
+	ORDER BY repo.id
+	`
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(rawQuery, monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*MatchFingerprints
+	for rows.Next() {
+		var (
+			fp        MatchFingerprints
+			filesJSON []byte
+		)
+		if err := rows.Scan(&fp.Repo.ID, &fp.Repo.Name, (*pq.StringArray)(&fp.CommitOIDs), &filesJSON); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(filesJSON, &fp.Files); err != nil {
+			return nil, err
+		}
+		res = append(res, &fp)
+	}
+	return res, rows.Err()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 func TestCodeMonitorStoreLastSearched(t *testing.T) {
@@ -115,3 +116,41 @@ func TestCodeMonitorHasAnyLastSearched(t *testing.T) {
 		require.True(t, hasLastSearched)
 	})
 }
+
+func TestCodeMonitorStoreMatchFingerprints(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	fixtures := populateCodeMonitorFixtures(t, db)
+	cm := db.CodeMonitors()
+
+	// No fingerprints before the first run
+	fps, err := cm.ListMatchFingerprints(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.Empty(t, fps)
+
+	repo := types.MinimalRepo{ID: fixtures.Repo.ID, Name: fixtures.Repo.Name}
+
+	// Insert
+	insert := &MatchFingerprints{
+		Repo:       repo,
+		CommitOIDs: []string{"commit1"},
+		Files:      map[string]string{"a.yaml": "fp1", "b.yaml": "fp2"},
+	}
+	err = cm.UpsertMatchFingerprints(ctx, fixtures.Monitor.ID, insert)
+	require.NoError(t, err)
+
+	fps, err = cm.ListMatchFingerprints(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*MatchFingerprints{insert}, fps)
+
+	// Update to no matches
+	update := &MatchFingerprints{Repo: repo}
+	err = cm.UpsertMatchFingerprints(ctx, fixtures.Monitor.ID, update)
+	require.NoError(t, err)
+
+	fps, err = cm.ListMatchFingerprints(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*MatchFingerprints{{Repo: repo, CommitOIDs: []string{}, Files: map[string]string{}}}, fps)
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+type MattermostWebhookAction struct {
+	ID             int64
+	Monitor        int64
+	Enabled        bool
+	URL            string
+	IncludeResults bool
+
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+const updateMattermostWebhookActionQuery = `
+UPDATE cm_mattermost_webhooks
+SET enabled = %s,
+	include_results = %s,
+	url = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE
+	id = %s
+	AND EXISTS (
+		SELECT 1 FROM cm_monitors
+		WHERE cm_monitors.id = cm_mattermost_webhooks.monitor
+			AND %s
+	)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) UpdateMattermostWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*MattermostWebhookAction, error) {
+	a := actor.FromContext(ctx)
+
+	user, err := a.User(ctx, s.userStore)
+	if err != nil {
+		return nil, err
+	}
+
+	q := sqlf.Sprintf(
+		updateMattermostWebhookActionQuery,
+		enabled,
+		includeResults,
+		url,
+		a.UID,
+		s.Now(),
+		id,
+		namespaceScopeQuery(user),
+		sqlf.Join(mattermostWebhookActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanMattermostWebhookAction(row)
+}
+
+const createMattermostWebhookActionQuery = `
+INSERT INTO cm_mattermost_webhooks
+(monitor, enabled, include_results, url, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) CreateMattermostWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*MattermostWebhookAction, error) {
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createMattermostWebhookActionQuery,
+		monitorID,
+		enabled,
+		includeResults,
+		url,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(mattermostWebhookActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanMattermostWebhookAction(row)
+}
+
+const deleteMattermostWebhookActionQuery = `
+DELETE FROM cm_mattermost_webhooks
+WHERE id in (%s)
+	AND MONITOR = %s
+`
+
+func (s *codeMonitorStore) DeleteMattermostWebhookActions(ctx context.Context, monitorID int64, webhookIDs ...int64) error {
+	if len(webhookIDs) == 0 {
+		return nil
+	}
+
+	deleteIDs := make([]*sqlf.Query, 0, len(webhookIDs))
+	for _, ids := range webhookIDs {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", ids))
+	}
+	q := sqlf.Sprintf(
+		deleteMattermostWebhookActionQuery,
+		sqlf.Join(deleteIDs, ","),
+		monitorID,
+	)
+
+	return s.Exec(ctx, q)
+}
+
+const countMattermostWebhookActionsQuery = `
+SELECT COUNT(*)
+FROM cm_mattermost_webhooks
+WHERE monitor = %s;
+`
+
+func (s *codeMonitorStore) CountMattermostWebhookActions(ctx context.Context, monitorID int64) (int, error) {
+	var count int
+	err := s.QueryRow(ctx, sqlf.Sprintf(countMattermostWebhookActionsQuery, monitorID)).Scan(&count)
+	return count, err
+}
+
+const getMattermostWebhookActionQuery = `
+SELECT %s -- MattermostWebhookActionColumns
+FROM cm_mattermost_webhooks
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) GetMattermostWebhookAction(ctx context.Context, id int64) (*MattermostWebhookAction, error) {
+	q := sqlf.Sprintf(
+		getMattermostWebhookActionQuery,
+		sqlf.Join(mattermostWebhookActionColumns, ","),
+		id,
+	)
+	row := s.QueryRow(ctx, q)
+	return scanMattermostWebhookAction(row)
+}
+
+const listMattermostWebhookActionsQuery = `
+SELECT %s -- MattermostWebhookActionColumns
+FROM cm_mattermost_webhooks
+WHERE %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+func (s *codeMonitorStore) ListMattermostWebhookActions(ctx context.Context, opts ListActionsOpts) ([]*MattermostWebhookAction, error) {
+	q := sqlf.Sprintf(
+		listMattermostWebhookActionsQuery,
+		sqlf.Join(mattermostWebhookActionColumns, ","),
+		opts.Conds(),
+		opts.Limit(),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMattermostWebhookActions(rows)
+}
+
+// mattermostWebhookActionColumns is the set of columns in the cm_mattermost_webhooks table
+// This must be kept in sync with scanMattermostWebhook
+var mattermostWebhookActionColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_mattermost_webhooks.id"),
+	sqlf.Sprintf("cm_mattermost_webhooks.monitor"),
+	sqlf.Sprintf("cm_mattermost_webhooks.enabled"),
+	sqlf.Sprintf("cm_mattermost_webhooks.url"),
+	sqlf.Sprintf("cm_mattermost_webhooks.include_results"),
+	sqlf.Sprintf("cm_mattermost_webhooks.created_by"),
+	sqlf.Sprintf("cm_mattermost_webhooks.created_at"),
+	sqlf.Sprintf("cm_mattermost_webhooks.changed_by"),
+	sqlf.Sprintf("cm_mattermost_webhooks.changed_at"),
+}
+
+func scanMattermostWebhookActions(rows *sql.Rows) ([]*MattermostWebhookAction, error) {
+	var ws []*MattermostWebhookAction
+	for rows.Next() {
+		w, err := scanMattermostWebhookAction(rows)
+		if err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	return ws, rows.Err()
+}
+
+// scanMattermostWebhookAction scans a MattermostWebhookAction from a *sql.Row or *sql.Rows.
+// It must be kept in sync with mattermostWebhookActionColumns.
+func scanMattermostWebhookAction(scanner dbutil.Scanner) (*MattermostWebhookAction, error) {
+	var w MattermostWebhookAction
+	err := scanner.Scan(
+		&w.ID,
+		&w.Monitor,
+		&w.Enabled,
+		&w.URL,
+		&w.IncludeResults,
+		&w.CreatedBy,
+		&w.CreatedAt,
+		&w.ChangedBy,
+		&w.ChangedAt,
+	)
+	return &w, err
+}
@@ -9,8 +9,10 @@ import (
 
 	"github.com/keegancsmith/sqlf"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 type TriggerJob struct {
@@ -22,6 +24,10 @@ type TriggerJob struct {
 
 	SearchResults []*result.CommitMatch
 
+	// ContentSearchResults is set instead of SearchResults for monitors
+	// on content search.
+	ContentSearchResults *ContentSearchResults
+
 	// Fields demanded for any dbworker.
 	State          string
 	FailureMessage *string
@@ -33,6 +39,18 @@ type TriggerJob struct {
 	LogContents    *string
 }
 
+// ResultCount returns the number of results found by the trigger job.
+func (r *TriggerJob) ResultCount() int {
+	count := 0
+	for _, cm := range r.SearchResults {
+		count += cm.ResultCount()
+	}
+	if r.ContentSearchResults != nil {
+		count += r.ContentSearchResults.ResultCount()
+	}
+	return count
+}
+
 func (r *TriggerJob) RecordID() int {
 	return int(r.ID)
 }
@@ -90,6 +108,107 @@ func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, trig
 	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, resultsJSON, triggerJobID))
 }
 
+// ContentSearchResults is the change in the set of files matched by a content
+// search code monitor since its previous run.
+type ContentSearchResults struct {
+	// Added are the files that are new matches, or whose matches changed.
+	Added []*result.FileMatch
+
+	// Removed are the files that no longer match. They have no chunk matches.
+	Removed []*result.FileMatch
+}
+
+// ResultCount returns the number of matches in added files plus the number of
+// removed files.
+func (r *ContentSearchResults) ResultCount() int {
+	count := len(r.Removed)
+	for _, fm := range r.Added {
+		count += fm.ResultCount()
+	}
+	return count
+}
+
+// contentMatchJSON is the serialized form of a result.FileMatch, which does
+// not serialize its repository and commit itself.
+type contentMatchJSON struct {
+	Repo         types.MinimalRepo   `json:"repo"`
+	CommitID     api.CommitID        `json:"commitID"`
+	Path         string              `json:"path"`
+	ChunkMatches result.ChunkMatches `json:"chunkMatches,omitempty"`
+}
+
+type contentSearchResultsJSON struct {
+	Added   []contentMatchJSON `json:"added,omitempty"`
+	Removed []contentMatchJSON `json:"removed,omitempty"`
+}
+
+func (r *ContentSearchResults) MarshalJSON() ([]byte, error) {
+	toJSON := func(fms []*result.FileMatch) []contentMatchJSON {
+		out := make([]contentMatchJSON, 0, len(fms))
+		for _, fm := range fms {
+			out = append(out, contentMatchJSON{
+				Repo:         fm.Repo,
+				CommitID:     fm.CommitID,
+				Path:         fm.Path,
+				ChunkMatches: fm.ChunkMatches,
+			})
+		}
+		return out
+	}
+	return json.Marshal(contentSearchResultsJSON{
+		Added:   toJSON(r.Added),
+		Removed: toJSON(r.Removed),
+	})
+}
+
+func (r *ContentSearchResults) UnmarshalJSON(data []byte) error {
+	var v contentSearchResultsJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	fromJSON := func(ms []contentMatchJSON) []*result.FileMatch {
+		out := make([]*result.FileMatch, 0, len(ms))
+		for _, m := range ms {
+			out = append(out, &result.FileMatch{
+				File: result.File{
+					Repo:     m.Repo,
+					CommitID: m.CommitID,
+					Path:     m.Path,
+				},
+				ChunkMatches: m.ChunkMatches,
+			})
+		}
+		return out
+	}
+	r.Added = fromJSON(v.Added)
+	r.Removed = fromJSON(v.Removed)
+	return nil
+}
+
+const logContentSearchFmtStr = `
+UPDATE cm_trigger_jobs
+SET query_string = %s,
+    search_results = '[]'::jsonb,
+    content_search_results = %s
+WHERE id = %s
+`
+
+// UpdateTriggerJobWithContentResults records the results of a trigger job of a
+// content search code monitor.
+func (s *codeMonitorStore) UpdateTriggerJobWithContentResults(ctx context.Context, triggerJobID int32, queryString string, results *ContentSearchResults) error {
+	// Store NULL rather than an empty object so that runs without results
+	// are easy to filter out.
+	var resultsJSON any
+	if results != nil && (len(results.Added) > 0 || len(results.Removed) > 0) {
+		raw, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		resultsJSON = raw
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logContentSearchFmtStr, queryString, resultsJSON, triggerJobID))
+}
+
 const deleteOldJobLogsFmtStr = `
 DELETE FROM cm_trigger_jobs
 WHERE finished_at < (NOW() - (%s * '1 day'::interval));
@@ -151,7 +270,7 @@ func (s *codeMonitorStore) ListQueryTriggerJobs(ctx context.Context, opts ListTr
 const totalCountEventsForQueryIDInt64FmtStr = `
 SELECT COUNT(*)
 FROM cm_trigger_jobs
-WHERE ((state = 'completed' AND jsonb_array_length(search_results) > 0) OR (state != 'completed'))
+WHERE ((state = 'completed' AND (jsonb_array_length(search_results) > 0 OR content_search_results IS NOT NULL)) OR (state != 'completed'))
 AND query = %s
 `
 
@@ -178,13 +297,14 @@ func scanTriggerJobs(rows *sql.Rows) ([]*TriggerJob, error) {
 }
 
 func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
-	var resultsJSON []byte
+	var resultsJSON, contentResultsJSON []byte
 	m := &TriggerJob{}
 	err := scanner.Scan(
 		&m.ID,
 		&m.Query,
 		&m.QueryString,
 		&resultsJSON,
+		&contentResultsJSON,
 		&m.State,
 		&m.FailureMessage,
 		&m.StartedAt,
@@ -204,6 +324,13 @@ func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 		}
 	}
 
+	if len(contentResultsJSON) > 0 {
+		m.ContentSearchResults = &ContentSearchResults{}
+		if err := json.Unmarshal(contentResultsJSON, m.ContentSearchResults); err != nil {
+			return nil, err
+		}
+	}
+
 	return m, nil
 }
 
@@ -212,6 +339,7 @@ var TriggerJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_trigger_jobs.query"),
 	sqlf.Sprintf("cm_trigger_jobs.query_string"),
 	sqlf.Sprintf("cm_trigger_jobs.search_results"),
+	sqlf.Sprintf("cm_trigger_jobs.content_search_results"),
 	sqlf.Sprintf("cm_trigger_jobs.state"),
 	sqlf.Sprintf("cm_trigger_jobs.failure_message"),
 	sqlf.Sprintf("cm_trigger_jobs.started_at"),
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/keegancsmith/sqlf"
@@ -10,6 +11,8 @@ import (
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 const setToCompletedFmtStr = `
@@ -83,6 +86,73 @@ func TestUpdateTriggerJob(t *testing.T) {
 		err = db.CodeMonitors().UpdateTriggerJobWithResults(ctx, jobs[0].ID, "", nil)
 		require.NoError(t, err)
 	})
+
+	t.Run("content results", func(t *testing.T) {
+		ctx := context.Background()
+		db := NewDB(logger, dbtest.NewDB(logger, t))
+		f := populateCodeMonitorFixtures(t, db)
+		jobs, err := db.CodeMonitors().EnqueueQueryTriggerJobs(ctx)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+
+		results := &ContentSearchResults{
+			Added: []*result.FileMatch{{
+				File: result.File{
+					Repo:     types.MinimalRepo{ID: f.Repo.ID, Name: f.Repo.Name},
+					CommitID: "commit1",
+					Path:     "deploy.yaml",
+				},
+				ChunkMatches: result.ChunkMatches{{
+					Content: "image: nginx:latest",
+					Ranges:  result.Ranges{{End: result.Location{Offset: 19, Column: 19}}},
+				}},
+			}},
+		}
+		err = db.CodeMonitors().UpdateTriggerJobWithContentResults(ctx, jobs[0].ID, "", results)
+		require.NoError(t, err)
+
+		js, err := db.CodeMonitors().ListQueryTriggerJobs(ctx, ListTriggerJobsOpts{QueryID: &f.Query.ID})
+		require.NoError(t, err)
+		require.Len(t, js, 1)
+		require.Equal(t, results, js[0].ContentSearchResults)
+		require.Empty(t, js[0].SearchResults)
+		require.Equal(t, 1, js[0].ResultCount())
+	})
+}
+
+func TestContentSearchResultsJSON(t *testing.T) {
+	in := &ContentSearchResults{
+		Added: []*result.FileMatch{{
+			File: result.File{
+				Repo:     types.MinimalRepo{ID: 1, Name: "repo"},
+				CommitID: "commit1",
+				Path:     "deploy.yaml",
+			},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "image: nginx:latest",
+				ContentStart: result.Location{Offset: 10, Line: 1},
+				Ranges: result.Ranges{{
+					Start: result.Location{Offset: 10, Line: 1},
+					End:   result.Location{Offset: 29, Line: 1, Column: 19},
+				}},
+			}},
+		}},
+		Removed: []*result.FileMatch{{
+			File: result.File{
+				Repo:     types.MinimalRepo{ID: 1, Name: "repo"},
+				CommitID: "commit1",
+				Path:     "old.yaml",
+			},
+		}},
+	}
+
+	raw, err := json.Marshal(in)
+	require.NoError(t, err)
+
+	var out ContentSearchResults
+	require.NoError(t, json.Unmarshal(raw, &out))
+	require.Equal(t, in, &out)
+	require.Equal(t, 2, out.ResultCount())
 }
 
 func TestListTriggerJobs(t *testing.T) {
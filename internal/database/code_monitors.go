@@ -51,6 +51,7 @@ type CodeMonitorStore interface {
 	CountQueryTriggerJobs(ctx context.Context, queryID int64) (int32, error)
 
 	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error
+	UpdateTriggerJobWithContentResults(ctx context.Context, triggerJobID int32, queryString string, results *ContentSearchResults) error
 	DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error
 
 	UpdateEmailAction(_ context.Context, id int64, _ *EmailActionArgs) (*EmailAction, error)
@@ -73,6 +74,20 @@ type CodeMonitorStore interface {
 	GetSlackWebhookAction(ctx context.Context, id int64) (*SlackWebhookAction, error)
 	ListSlackWebhookActions(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
 
+	UpdateTeamsWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error)
+	CreateTeamsWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error)
+	DeleteTeamsWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountTeamsWebhookActions(ctx context.Context, monitorID int64) (int, error)
+	GetTeamsWebhookAction(ctx context.Context, id int64) (*TeamsWebhookAction, error)
+	ListTeamsWebhookActions(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)
+
+	UpdateMattermostWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*MattermostWebhookAction, error)
+	CreateMattermostWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*MattermostWebhookAction, error)
+	DeleteMattermostWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountMattermostWebhookActions(ctx context.Context, monitorID int64) (int, error)
+	GetMattermostWebhookAction(ctx context.Context, id int64) (*MattermostWebhookAction, error)
+	ListMattermostWebhookActions(context.Context, ListActionsOpts) ([]*MattermostWebhookAction, error)
+
 	CreateRecipient(ctx context.Context, emailID int64, userID, orgID *int32) (*Recipient, error)
 	DeleteRecipients(ctx context.Context, emailID int64) error
 	ListRecipients(context.Context, ListRecipientsOpts) ([]*Recipient, error)
@@ -91,6 +106,12 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	// UpsertMatchFingerprints and ListMatchFingerprints store the files matched
+	// by the last run of a content search code monitor, so that the next run
+	// can report which files started or stopped matching.
+	UpsertMatchFingerprints(ctx context.Context, monitorID int64, fingerprints *MatchFingerprints) error
+	ListMatchFingerprints(ctx context.Context, monitorID int64) ([]*MatchFingerprints, error)
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
@@ -26,6 +26,7 @@ type DB interface {
 	AccessTokens() AccessTokenStore
 	Authz() AuthzStore
 	BitbucketProjectPermissions() BitbucketProjectPermissionsStore
+	BlameOwnershipSignals() BlameOwnershipSignalStore
 	CodeMonitors() CodeMonitorStore
 	CodeHosts() CodeHostStore
 	Codeowners() CodeownersStore
@@ -57,6 +58,7 @@ type DB interface {
 	RepoCommitsChangelists() RepoCommitsChangelistsStore
 	RepoKVPs() RepoKVPStore
 	RepoPaths() RepoPathStore
+	RepoUpdateSchedules() RepoUpdateScheduleStore
 	RolePermissions() RolePermissionStore
 	Roles() RoleStore
 	SavedSearches() SavedSearchStore
@@ -68,6 +70,7 @@ type DB interface {
 	UserEmails() UserEmailsStore
 	UserExternalAccounts() UserExternalAccountsStore
 	UserRoles() UserRoleStore
+	UserTOTP(encryption.Key) UserTOTPStore
 	Users() UserStore
 	WebhookLogs(encryption.Key) WebhookLogStore
 	Webhooks(encryption.Key) WebhookStore
@@ -233,6 +236,10 @@ func (d *db) OwnershipStats() OwnershipStatsStore {
 	return &ownershipStats{d.Store}
 }
 
+func (d *db) BlameOwnershipSignals() BlameOwnershipSignalStore {
+	return BlameOwnershipSignalStoreWith(d.Store)
+}
+
 func (d *db) RecentContributionSignals() RecentContributionSignalStore {
 	return RecentContributionSignalStoreWith(d.Store)
 }
@@ -273,6 +280,10 @@ func (d *db) RepoPaths() RepoPathStore {
 	return &repoPathStore{d.Store}
 }
 
+func (d *db) RepoUpdateSchedules() RepoUpdateScheduleStore {
+	return RepoUpdateSchedulesWith(d.Store)
+}
+
 func (d *db) RolePermissions() RolePermissionStore {
 	return RolePermissionsWith(d.Store)
 }
@@ -317,6 +328,10 @@ func (d *db) UserRoles() UserRoleStore {
 	return UserRolesWith(d.Store)
 }
 
+func (d *db) UserTOTP(key encryption.Key) UserTOTPStore {
+	return UserTOTPWith(d.Store, key)
+}
+
 func (d *db) Users() UserStore {
 	return UsersWith(d.logger, d.Store)
 }
@@ -26,6 +26,7 @@ var EncryptionConfigs = []EncryptionConfig{
 	webhooklogsEncryptionConfig,
 	executorSecretsEncryptionConfig,
 	outboundWebhooksEncryptionConfig,
+	userTOTPEncryptionConfig,
 }
 
 var externalServicesEncryptionConfig = EncryptionConfig{
@@ -101,6 +102,16 @@ var outboundWebhooksEncryptionConfig = EncryptionConfig{
 	Limit:               5,
 }
 
+var userTOTPEncryptionConfig = EncryptionConfig{
+	TableName:           "user_totp",
+	IDFieldName:         "user_id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"secret"},
+	Scan:                basestore.NewMapScanner(scanEncryptedString),
+	Key:                 func() encryption.Key { return keyring.Default().UserTOTPKey },
+	Limit:               100,
+}
+
 func scanEncryptedString(scanner dbutil.Scanner) (id int, e Encrypted, err error) {
 	e.Values = make([]string, 1)
 	err = scanner.Scan(&id, &e.KeyID, &e.Values[0])
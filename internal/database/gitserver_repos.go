@@ -37,6 +37,9 @@ type GitserverRepoStore interface {
 	// LogCorruption sets the corrupted at value and logs the corruption reason. Reason will be truncated if it exceeds
 	// MaxReasonSizeInMB
 	LogCorruption(ctx context.Context, name api.RepoName, reason string, shardID string) error
+	// SetIntegrityCheck records the result of a periodic integrity check of the
+	// repo on disk.
+	SetIntegrityCheck(ctx context.Context, name api.RepoName, passed bool, shardID string) error
 	// SetCloneStatus will attempt to update ONLY the clone status of a
 	// GitServerRepo. If a matching row does not yet exist a new one will be created.
 	// If the status value hasn't changed, the row will not be updated.
@@ -408,6 +411,8 @@ SELECT
 	gr.corrupted_at,
 	gr.corruption_logs,
 	gr.pool_repo_id,
+	gr.integrity_checked_at,
+	gr.integrity_check_passed,
 	go.last_output
 FROM gitserver_repos gr
 JOIN repo ON gr.repo_id = repo.id
@@ -444,6 +449,8 @@ SELECT
 	gr.corrupted_at,
 	gr.corruption_logs,
 	gr.pool_repo_id,
+	gr.integrity_checked_at,
+	gr.integrity_check_passed,
 	go.last_output
 FROM gitserver_repos gr
 LEFT OUTER JOIN gitserver_repos_sync_output go ON gr.repo_id = go.repo_id
@@ -477,6 +484,8 @@ SELECT
 	gr.corrupted_at,
 	gr.corruption_logs,
 	gr.pool_repo_id,
+	gr.integrity_checked_at,
+	gr.integrity_check_passed,
 	go.last_output
 FROM gitserver_repos gr
 JOIN repo r ON r.id = gr.repo_id
@@ -504,6 +513,8 @@ SELECT
 	gr.corrupted_at,
 	gr.corruption_logs,
 	gr.pool_repo_id,
+	gr.integrity_checked_at,
+	gr.integrity_check_passed,
 	go.last_output
 FROM gitserver_repos gr
 JOIN repo r on r.id = gr.repo_id
@@ -551,6 +562,8 @@ func scanGitserverRepo(scanner dbutil.Scanner) (*types.GitserverRepo, api.RepoNa
 		&dbutil.NullTime{Time: &gr.CorruptedAt},
 		&rawLogs,
 		&dbutil.NullInt32{N: &poolRepoID},
+		&dbutil.NullTime{Time: &gr.IntegrityCheckedAt},
+		&dbutil.NullBool{B: &gr.IntegrityCheckPassed},
 		&dbutil.NullString{S: &gr.LastSyncOutput},
 	)
 	if err != nil {
@@ -688,6 +701,24 @@ AND
 	return nil
 }
 
+func (s *gitserverRepoStore) SetIntegrityCheck(ctx context.Context, name api.RepoName, passed bool, shardID string) error {
+	err := s.Exec(ctx, sqlf.Sprintf(`
+UPDATE gitserver_repos
+SET
+	integrity_checked_at = NOW(),
+	integrity_check_passed = %s,
+	shard_id = %s,
+	updated_at = NOW()
+WHERE
+	repo_id = (SELECT id FROM repo WHERE name = %s)
+`, passed, shardID, name))
+	if err != nil {
+		return errors.Wrap(err, "setting integrity check")
+	}
+
+	return nil
+}
+
 // GitserverFetchData is the metadata associated with a fetch operation on
 // gitserver.
 type GitserverFetchData struct {
@@ -837,6 +837,45 @@ func TestSetLastError(t *testing.T) {
 	}
 }
 
+func TestSetIntegrityCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	repo, gitserverRepo := createTestRepo(ctx, t, db, &createTestRepoPayload{
+		Name:          "github.com/sourcegraph/repo",
+		CloneStatus:   types.CloneStatusCloned,
+		RepoSizeBytes: 100,
+	})
+	if !gitserverRepo.IntegrityCheckedAt.IsZero() {
+		t.Fatalf("expected repo not to be checked yet, got %s", gitserverRepo.IntegrityCheckedAt)
+	}
+
+	for _, passed := range []bool{false, true} {
+		if err := db.GitserverRepos().SetIntegrityCheck(ctx, repo.Name, passed, "gitserver-0"); err != nil {
+			t.Fatal(err)
+		}
+
+		fromDB, err := db.GitserverRepos().GetByID(ctx, gitserverRepo.RepoID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fromDB.IntegrityCheckedAt.IsZero() {
+			t.Fatal("expected integrity check time to be set")
+		}
+		if fromDB.IntegrityCheckPassed != passed {
+			t.Fatalf("unexpected integrity check result: want %t, got %t", passed, fromDB.IntegrityCheckPassed)
+		}
+		if fromDB.ShardID != "gitserver-0" {
+			t.Fatalf("unexpected shard ID: %q", fromDB.ShardID)
+		}
+	}
+}
+
 func TestSetRepoSize(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
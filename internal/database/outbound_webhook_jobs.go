@@ -3,15 +3,18 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
+	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -53,6 +56,12 @@ func OutboundWebhookJobsWith(other basestore.ShareableStore, key encryption.Key)
 	}
 }
 
+// OutboundWebhookJobsWithDefaultKey returns an OutboundWebhookJobStore on the
+// handle of other that encrypts payloads with the default outbound webhook key.
+func OutboundWebhookJobsWithDefaultKey(other basestore.ShareableStore) OutboundWebhookJobStore {
+	return OutboundWebhookJobsWith(other, keyring.Default().OutboundWebhookKey)
+}
+
 func (s *outboundWebhookJobStore) With(other basestore.ShareableStore) OutboundWebhookJobStore {
 	return &outboundWebhookJobStore{
 		Store: s.Store.With(other),
@@ -254,3 +263,32 @@ ORDER BY
 	id DESC
 LIMIT 1
 `
+
+// EnqueueOutboundWebhookEvent marshals payload and enqueues an outbound webhook
+// job for the given event type in store.
+//
+// Webhooks are fire and forget from the point of view of the calling code, so
+// errors are logged rather than returned. The job is created in a savepoint
+// when store is in a transaction, so that a failure here can't abort the
+// surrounding transaction. A nil store, or one without a handle such as a mock,
+// is a no-op.
+func EnqueueOutboundWebhookEvent(ctx context.Context, logger log.Logger, store OutboundWebhookJobStore, eventType string, payload any) {
+	if store == nil || store.Handle() == nil {
+		return
+	}
+
+	logger = logger.With(log.String("event_type", eventType))
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("error marshalling webhook payload", log.Error(err))
+		return
+	}
+
+	if err := store.WithTransact(ctx, func(tx OutboundWebhookJobStore) error {
+		_, err := tx.Create(ctx, eventType, nil, data)
+		return err
+	}); err != nil {
+		logger.Error("error enqueuing webhook job", log.Error(err))
+	}
+}
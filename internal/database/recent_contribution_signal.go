@@ -73,16 +73,21 @@ const commitAuthorInsertFmtstr = `
 // ensureAuthor makes sure the that commit author designated by name and email
 // exists in the `commit_authors` table, and returns its ID.
 func (s *recentContributionSignalStore) ensureAuthor(ctx context.Context, commit Commit) (int, error) {
-	db := s.Store
+	return ensureCommitAuthor(ctx, s.Store, commit.AuthorName, commit.AuthorEmail)
+}
+
+// ensureCommitAuthor makes sure the that commit author designated by name and
+// email exists in the `commit_authors` table, and returns its ID.
+func ensureCommitAuthor(ctx context.Context, db *basestore.Store, name, email string) (int, error) {
 	var authorID int
 	if err := db.QueryRow(
 		ctx,
 		sqlf.Sprintf(
 			commitAuthorInsertFmtstr,
-			commit.AuthorName,
-			commit.AuthorEmail,
-			commit.AuthorName,
-			commit.AuthorEmail,
+			name,
+			email,
+			name,
+			email,
 		),
 	).Scan(&authorID); err != nil {
 		return 0, err
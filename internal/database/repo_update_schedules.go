@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// RepoUpdateSchedule is the persisted state of a repository in the update scheduler of
+// repo-updater.
+type RepoUpdateSchedule struct {
+	RepoID api.RepoID
+	// Interval is how regularly the repository is fetched.
+	Interval time.Duration
+	// DueAt is the next time the repository is due to be fetched.
+	DueAt time.Time
+	// LastFetchedAt is when the repository was last fetched by the scheduler, if known.
+	LastFetchedAt *time.Time
+	UpdatedAt     time.Time
+}
+
+// RepoUpdateScheduleListOptions contains options for listing repository update schedules.
+type RepoUpdateScheduleListOptions struct {
+	*LimitOffset
+	// After only lists the schedules that come after the given position.
+	After *RepoUpdateScheduleCursor
+}
+
+// RepoUpdateScheduleCursor is the position of a schedule in the order of
+// RepoUpdateScheduleStore.List.
+type RepoUpdateScheduleCursor struct {
+	DueAt  time.Time
+	RepoID api.RepoID
+}
+
+// RepoUpdateScheduleStore stores the update schedule of repo-updater, so that the update
+// intervals it learned survive restarts.
+type RepoUpdateScheduleStore interface {
+	basestore.ShareableStore
+
+	// Upsert inserts or replaces the schedules of the given repositories.
+	Upsert(ctx context.Context, schedules ...*RepoUpdateSchedule) error
+	// Delete removes the schedules of the given repositories.
+	Delete(ctx context.Context, repoIDs ...api.RepoID) error
+	// List returns the schedules of repositories that are not deleted, in the order in which
+	// they are due. Schedules that are due at the same time are ordered by repository ID.
+	List(ctx context.Context, opts RepoUpdateScheduleListOptions) ([]*RepoUpdateSchedule, error)
+	// Count returns the number of schedules of repositories that are not deleted.
+	Count(ctx context.Context) (int, error)
+}
+
+type repoUpdateScheduleStore struct {
+	*basestore.Store
+}
+
+var _ RepoUpdateScheduleStore = &repoUpdateScheduleStore{}
+
+// RepoUpdateSchedulesWith instantiates and returns a new RepoUpdateScheduleStore using the
+// other store handle.
+func RepoUpdateSchedulesWith(other basestore.ShareableStore) RepoUpdateScheduleStore {
+	return &repoUpdateScheduleStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *repoUpdateScheduleStore) Upsert(ctx context.Context, schedules ...*RepoUpdateSchedule) (err error) {
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	inserter := batch.NewInserterWithConflict(
+		ctx,
+		tx.Handle(),
+		"repo_update_schedules",
+		batch.MaxNumPostgresParameters,
+		upsertRepoUpdateSchedulesOnConflictClause,
+		"repo_id", "interval_seconds", "due_at", "last_fetched_at", "updated_at",
+	)
+	now := time.Now()
+	for _, schedule := range schedules {
+		if err := inserter.Insert(
+			ctx,
+			schedule.RepoID,
+			int(schedule.Interval/time.Second),
+			schedule.DueAt,
+			dbutil.NullTime{Time: schedule.LastFetchedAt},
+			now,
+		); err != nil {
+			return err
+		}
+	}
+	return inserter.Flush(ctx)
+}
+
+const upsertRepoUpdateSchedulesOnConflictClause = `
+ON CONFLICT (repo_id) DO UPDATE SET
+	interval_seconds = EXCLUDED.interval_seconds,
+	due_at = EXCLUDED.due_at,
+	last_fetched_at = COALESCE(EXCLUDED.last_fetched_at, repo_update_schedules.last_fetched_at),
+	updated_at = EXCLUDED.updated_at
+`
+
+func (s *repoUpdateScheduleStore) Delete(ctx context.Context, repoIDs ...api.RepoID) error {
+	if len(repoIDs) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(deleteRepoUpdateSchedulesQueryFmtstr, pq.Array(repoIDs)))
+}
+
+const deleteRepoUpdateSchedulesQueryFmtstr = `
+DELETE FROM repo_update_schedules WHERE repo_id = ANY(%s)
+`
+
+func (s *repoUpdateScheduleStore) List(ctx context.Context, opts RepoUpdateScheduleListOptions) ([]*RepoUpdateSchedule, error) {
+	cond := sqlf.Sprintf("TRUE")
+	if opts.After != nil {
+		cond = sqlf.Sprintf("(s.due_at, s.repo_id) > (%s, %s)", opts.After.DueAt, opts.After.RepoID)
+	}
+	return scanRepoUpdateSchedules(s.Query(ctx, sqlf.Sprintf(listRepoUpdateSchedulesQueryFmtstr, cond, opts.LimitOffset.SQL())))
+}
+
+const listRepoUpdateSchedulesQueryFmtstr = `
+SELECT
+	s.repo_id,
+	s.interval_seconds,
+	s.due_at,
+	s.last_fetched_at,
+	s.updated_at
+FROM repo_update_schedules s
+JOIN repo ON repo.id = s.repo_id
+WHERE repo.deleted_at IS NULL AND %s
+ORDER BY s.due_at, s.repo_id
+%s
+`
+
+func (s *repoUpdateScheduleStore) Count(ctx context.Context) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(countRepoUpdateSchedulesQuery)))
+	return count, err
+}
+
+const countRepoUpdateSchedulesQuery = `
+SELECT COUNT(*)
+FROM repo_update_schedules s
+JOIN repo ON repo.id = s.repo_id
+WHERE repo.deleted_at IS NULL
+`
+
+var scanRepoUpdateSchedules = basestore.NewSliceScanner(func(sc dbutil.Scanner) (*RepoUpdateSchedule, error) {
+	var (
+		schedule        RepoUpdateSchedule
+		intervalSeconds int
+		lastFetchedAt   sql.NullTime
+	)
+	if err := sc.Scan(
+		&schedule.RepoID,
+		&intervalSeconds,
+		&schedule.DueAt,
+		&lastFetchedAt,
+		&schedule.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	schedule.Interval = time.Duration(intervalSeconds) * time.Second
+	if lastFetchedAt.Valid {
+		schedule.LastFetchedAt = &lastFetchedAt.Time
+	}
+	return &schedule, nil
+})
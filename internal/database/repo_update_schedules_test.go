@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestRepoUpdateSchedules(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	store := db.RepoUpdateSchedules()
+
+	repo1, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo1"})
+	repo2, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo2"})
+	repo3, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo3"})
+
+	now := time.Now().Truncate(time.Second)
+	lastFetched := now.Add(-time.Hour)
+	require.NoError(t, store.Upsert(ctx,
+		&RepoUpdateSchedule{RepoID: repo1.ID, Interval: time.Hour, DueAt: now.Add(2 * time.Hour), LastFetchedAt: &lastFetched},
+		&RepoUpdateSchedule{RepoID: repo2.ID, Interval: time.Minute, DueAt: now.Add(time.Minute)},
+		&RepoUpdateSchedule{RepoID: repo3.ID, Interval: 8 * time.Hour, DueAt: now.Add(8 * time.Hour)},
+	))
+
+	listRepoIDs := func() []api.RepoID {
+		t.Helper()
+		schedules, err := store.List(ctx, RepoUpdateScheduleListOptions{})
+		require.NoError(t, err)
+		ids := make([]api.RepoID, 0, len(schedules))
+		for _, s := range schedules {
+			ids = append(ids, s.RepoID)
+		}
+		return ids
+	}
+
+	t.Run("List orders by due time", func(t *testing.T) {
+		assert.Equal(t, []api.RepoID{repo2.ID, repo1.ID, repo3.ID}, listRepoIDs())
+
+		schedules, err := store.List(ctx, RepoUpdateScheduleListOptions{LimitOffset: &LimitOffset{Limit: 1, Offset: 1}})
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		assert.Equal(t, repo1.ID, schedules[0].RepoID)
+		assert.Equal(t, time.Hour, schedules[0].Interval)
+		assert.True(t, now.Add(2*time.Hour).Equal(schedules[0].DueAt))
+		require.NotNil(t, schedules[0].LastFetchedAt)
+		assert.True(t, lastFetched.Equal(*schedules[0].LastFetchedAt))
+
+		count, err := store.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("List after a cursor", func(t *testing.T) {
+		schedules, err := store.List(ctx, RepoUpdateScheduleListOptions{
+			After: &RepoUpdateScheduleCursor{DueAt: now.Add(time.Minute), RepoID: repo2.ID},
+		})
+		require.NoError(t, err)
+		require.Len(t, schedules, 2)
+		assert.Equal(t, repo1.ID, schedules[0].RepoID)
+		assert.Equal(t, repo3.ID, schedules[1].RepoID)
+	})
+
+	t.Run("Upsert replaces schedules but keeps the last fetch time", func(t *testing.T) {
+		require.NoError(t, store.Upsert(ctx, &RepoUpdateSchedule{RepoID: repo1.ID, Interval: time.Minute, DueAt: now}))
+		assert.Equal(t, []api.RepoID{repo1.ID, repo2.ID, repo3.ID}, listRepoIDs())
+
+		schedules, err := store.List(ctx, RepoUpdateScheduleListOptions{LimitOffset: &LimitOffset{Limit: 1}})
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, schedules[0].Interval)
+		require.NotNil(t, schedules[0].LastFetchedAt)
+		assert.True(t, lastFetched.Equal(*schedules[0].LastFetchedAt))
+	})
+
+	t.Run("deleted repos are not listed", func(t *testing.T) {
+		require.NoError(t, db.Repos().Delete(ctx, repo3.ID))
+		assert.Equal(t, []api.RepoID{repo1.ID, repo2.ID}, listRepoIDs())
+
+		count, err := store.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, repo1.ID))
+		assert.Equal(t, []api.RepoID{repo2.ID}, listRepoIDs())
+	})
+}
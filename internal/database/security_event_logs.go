@@ -29,6 +29,9 @@ const (
 	SecurityEventNameSignInAttempted SecurityEventName = "SignInAttempted"
 	SecurityEventNameSignInFailed    SecurityEventName = "SignInFailed"
 	SecurityEventNameSignInSucceeded SecurityEventName = "SignInSucceeded"
+	// SecurityEventNameSignInSecondFactorRequired is logged when the password of a user was
+	// correct, but they still need to provide (or enroll) a second factor to sign in.
+	SecurityEventNameSignInSecondFactorRequired SecurityEventName = "SignInSecondFactorRequired"
 
 	SecurityEventNameAccountCreated  SecurityEventName = "AccountCreated"
 	SecurityEventNameAccountDeleted  SecurityEventName = "AccountDeleted"
@@ -41,6 +44,13 @@ const (
 
 	SecurityEventNameEmailVerified SecurityEventName = "EmailVerified"
 
+	SecurityEventNameTOTPEnrolled                 SecurityEventName = "TOTPEnrolled"
+	SecurityEventNameTOTPDisabled                 SecurityEventName = "TOTPDisabled"
+	SecurityEventNameTOTPVerified                 SecurityEventName = "TOTPVerified"
+	SecurityEventNameTOTPVerificationFailed       SecurityEventName = "TOTPVerificationFailed"
+	SecurityEventNameTOTPRecoveryCodeUsed         SecurityEventName = "TOTPRecoveryCodeUsed"
+	SecurityEventNameTOTPRecoveryCodesRegenerated SecurityEventName = "TOTPRecoveryCodesRegenerated"
+
 	SecurityEventNameRoleChangeDenied  SecurityEventName = "RoleChangeDenied"
 	SecurityEventNameRoleChangeGranted SecurityEventName = "RoleChangeGranted"
 
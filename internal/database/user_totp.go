@@ -0,0 +1,246 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// UserTOTP is the authenticator app (TOTP) second factor of a user.
+type UserTOTP struct {
+	UserID int32
+	// Secret is the decrypted, base32-encoded TOTP secret.
+	Secret string
+	// RecoveryCodesRemaining is the number of recovery codes that were not used yet.
+	RecoveryCodesRemaining int
+	// LastUsedStep is the time step of the last accepted code.
+	LastUsedStep int64
+	// EnabledAt is nil while the enrollment is pending, i.e. was not confirmed with a code yet.
+	EnabledAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Enabled reports whether the enrollment was confirmed, so that the second factor must be
+// provided when signing in.
+func (t *UserTOTP) Enabled() bool {
+	return t.EnabledAt != nil
+}
+
+// UserTOTPNotFoundErr is returned when a user has no TOTP enrollment.
+type UserTOTPNotFoundErr struct {
+	UserID int32
+}
+
+func (e UserTOTPNotFoundErr) Error() string {
+	return "no TOTP enrollment found for user"
+}
+
+func (UserTOTPNotFoundErr) NotFound() bool {
+	return true
+}
+
+// ErrUserTOTPAlreadyEnabled is returned when starting or confirming the enrollment of a user whose
+// enrollment was already confirmed.
+var ErrUserTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+// UserTOTPStore stores the TOTP second factor of users. Secrets are encrypted with the key the
+// store was created with.
+type UserTOTPStore interface {
+	basestore.ShareableStore
+
+	// GetByUserID returns the TOTP enrollment of the user, or a UserTOTPNotFoundErr.
+	GetByUserID(ctx context.Context, userID int32) (*UserTOTP, error)
+	// SetPending stores a new unconfirmed secret for the user, replacing a previous pending
+	// enrollment. It returns ErrUserTOTPAlreadyEnabled if the user has a confirmed enrollment.
+	SetPending(ctx context.Context, userID int32, secret string) error
+	// Enable confirms the pending enrollment of the user, recording the time step of the code that
+	// confirmed it and the hashes of the user's recovery codes. It returns
+	// ErrUserTOTPAlreadyEnabled if the enrollment was already confirmed.
+	Enable(ctx context.Context, userID int32, step int64, recoveryCodeHashes []string) error
+	// UseStep records that a code of the given time step was accepted. It returns false if a code
+	// of the same or a later time step was already accepted, in which case the code must be
+	// rejected as a replay.
+	UseStep(ctx context.Context, userID int32, step int64) (bool, error)
+	// UseRecoveryCode removes the recovery code with the given hash. It returns false if the user
+	// has no such recovery code.
+	UseRecoveryCode(ctx context.Context, userID int32, hash string) (bool, error)
+	// SetRecoveryCodes replaces the recovery codes of the user.
+	SetRecoveryCodes(ctx context.Context, userID int32, hashes []string) error
+	// Delete removes the TOTP enrollment of the user, if any.
+	Delete(ctx context.Context, userID int32) error
+}
+
+type userTOTPStore struct {
+	*basestore.Store
+
+	key encryption.Key
+}
+
+var _ UserTOTPStore = &userTOTPStore{}
+
+// UserTOTPWith instantiates and returns a new UserTOTPStore using the other store handle.
+func UserTOTPWith(other basestore.ShareableStore, key encryption.Key) UserTOTPStore {
+	return &userTOTPStore{
+		Store: basestore.NewWithHandle(other.Handle()),
+		key:   key,
+	}
+}
+
+const getUserTOTPQueryFmtstr = `
+SELECT
+	user_id,
+	secret,
+	encryption_key_id,
+	cardinality(recovery_codes),
+	last_used_step,
+	enabled_at,
+	created_at,
+	updated_at
+FROM user_totp
+WHERE user_id = %s
+`
+
+func (s *userTOTPStore) GetByUserID(ctx context.Context, userID int32) (*UserTOTP, error) {
+	var (
+		t         UserTOTP
+		secret    string
+		keyID     string
+		enabledAt sql.NullTime
+	)
+	err := s.QueryRow(ctx, sqlf.Sprintf(getUserTOTPQueryFmtstr, userID)).Scan(
+		&t.UserID,
+		&secret,
+		&keyID,
+		&t.RecoveryCodesRemaining,
+		&t.LastUsedStep,
+		&enabledAt,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, UserTOTPNotFoundErr{UserID: userID}
+		}
+		return nil, err
+	}
+	if enabledAt.Valid {
+		t.EnabledAt = &enabledAt.Time
+	}
+
+	t.Secret, err = encryption.MaybeDecrypt(ctx, s.key, secret, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting TOTP secret")
+	}
+	return &t, nil
+}
+
+const setPendingUserTOTPQueryFmtstr = `
+INSERT INTO user_totp (user_id, secret, encryption_key_id)
+VALUES (%s, %s, %s)
+ON CONFLICT (user_id) DO UPDATE SET
+	secret = EXCLUDED.secret,
+	encryption_key_id = EXCLUDED.encryption_key_id,
+	recovery_codes = '{}',
+	last_used_step = 0,
+	created_at = NOW(),
+	updated_at = NOW()
+WHERE user_totp.enabled_at IS NULL
+`
+
+func (s *userTOTPStore) SetPending(ctx context.Context, userID int32, secret string) error {
+	encrypted, keyID, err := encryption.MaybeEncrypt(ctx, s.key, secret)
+	if err != nil {
+		return errors.Wrap(err, "encrypting TOTP secret")
+	}
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(setPendingUserTOTPQueryFmtstr, userID, encrypted, keyID))
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res, ErrUserTOTPAlreadyEnabled)
+}
+
+const enableUserTOTPQueryFmtstr = `
+UPDATE user_totp
+SET
+	enabled_at = NOW(),
+	last_used_step = %s,
+	recovery_codes = %s,
+	updated_at = NOW()
+WHERE user_id = %s AND enabled_at IS NULL
+`
+
+func (s *userTOTPStore) Enable(ctx context.Context, userID int32, step int64, recoveryCodeHashes []string) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(enableUserTOTPQueryFmtstr, step, pq.Array(recoveryCodeHashes), userID))
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res, ErrUserTOTPAlreadyEnabled)
+}
+
+const useUserTOTPStepQueryFmtstr = `
+UPDATE user_totp
+SET last_used_step = %s, updated_at = NOW()
+WHERE user_id = %s AND last_used_step < %s
+`
+
+func (s *userTOTPStore) UseStep(ctx context.Context, userID int32, step int64) (bool, error) {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(useUserTOTPStepQueryFmtstr, step, userID, step))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+const useUserTOTPRecoveryCodeQueryFmtstr = `
+UPDATE user_totp
+SET recovery_codes = array_remove(recovery_codes, %s), updated_at = NOW()
+WHERE user_id = %s AND %s = ANY(recovery_codes)
+`
+
+func (s *userTOTPStore) UseRecoveryCode(ctx context.Context, userID int32, hash string) (bool, error) {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(useUserTOTPRecoveryCodeQueryFmtstr, hash, userID, hash))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+const setUserTOTPRecoveryCodesQueryFmtstr = `
+UPDATE user_totp
+SET recovery_codes = %s, updated_at = NOW()
+WHERE user_id = %s
+`
+
+func (s *userTOTPStore) SetRecoveryCodes(ctx context.Context, userID int32, hashes []string) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(setUserTOTPRecoveryCodesQueryFmtstr, pq.Array(hashes), userID))
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res, UserTOTPNotFoundErr{UserID: userID})
+}
+
+func (s *userTOTPStore) Delete(ctx context.Context, userID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf("DELETE FROM user_totp WHERE user_id = %s", userID))
+}
+
+// expectOneRow returns errNoRow if the statement did not affect any row.
+func expectOneRow(res sql.Result, errNoRow error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoRow
+	}
+	return nil
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -431,6 +432,8 @@ func (u *userStore) CreateInTransaction(ctx context.Context, info NewUser, spec
 		}
 	}
 
+	EnqueueOutboundWebhookEvent(ctx, u.logger, OutboundWebhookJobsWithDefaultKey(u), events.UserCreated, events.NewUser(user))
+
 	return user, nil
 }
 
@@ -617,6 +620,13 @@ func (u *userStore) DeleteList(ctx context.Context, ids []int32) (err error) {
 
 	idsCond := sqlf.Join(userIDs, ",")
 
+	// Load the users before they are deleted so that we can describe them in
+	// the outbound webhook payloads.
+	deleted, err := UsersWith(u.logger, tx).List(ctx, &UsersListOptions{UserIDs: ids})
+	if err != nil {
+		return err
+	}
+
 	res, err := tx.ExecResult(ctx, sqlf.Sprintf("UPDATE users SET deleted_at=now() WHERE id IN (%s) AND deleted_at IS NULL", idsCond))
 	if err != nil {
 		return err
@@ -650,6 +660,9 @@ func (u *userStore) DeleteList(ctx context.Context, ids []int32) (err error) {
 	}
 
 	logUserDeletionEvents(ctx, NewDBWith(u.logger, u), ids, SecurityEventNameAccountDeleted)
+	for _, user := range deleted {
+		EnqueueOutboundWebhookEvent(ctx, u.logger, OutboundWebhookJobsWithDefaultKey(tx), events.UserDeleted, events.NewUser(user))
+	}
 
 	return nil
 }
@@ -679,6 +692,13 @@ func (u *userStore) HardDeleteList(ctx context.Context, ids []int32) (err error)
 
 	idsCond := sqlf.Join(userIDs, ",")
 
+	// Users that were already soft deleted had their user:deleted event sent
+	// at that point, so only users that are still live are loaded here.
+	deleted, err := UsersWith(u.logger, tx).List(ctx, &UsersListOptions{UserIDs: ids})
+	if err != nil {
+		return err
+	}
+
 	if err := tx.Exec(ctx, sqlf.Sprintf("DELETE FROM names WHERE user_id IN (%s)", idsCond)); err != nil {
 		return err
 	}
@@ -738,6 +758,9 @@ func (u *userStore) HardDeleteList(ctx context.Context, ids []int32) (err error)
 	}
 
 	logUserDeletionEvents(ctx, NewDBWith(u.logger, u), ids, SecurityEventNameAccountNuked)
+	for _, user := range deleted {
+		EnqueueOutboundWebhookEvent(ctx, u.logger, OutboundWebhookJobsWithDefaultKey(tx), events.UserDeleted, events.NewUser(user))
+	}
 
 	return nil
 }
@@ -875,11 +898,30 @@ func (u *userStore) SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bo
 	db := NewDBWith(u.logger, u)
 	return db.WithTransact(ctx, func(tx DB) error {
 		userStore := tx.Users()
+
+		// Load the user before promoting them, so that the outbound webhook
+		// is only sent when they weren't already a site admin.
+		var promoted *types.User
+		if isSiteAdmin {
+			user, err := userStore.GetByID(ctx, id)
+			if err != nil && !IsUserNotFoundErr(err) {
+				return err
+			}
+			if user != nil && !user.SiteAdmin {
+				promoted = user
+			}
+		}
+
 		err := userStore.Exec(ctx, sqlf.Sprintf("UPDATE users SET site_admin=%s WHERE id=%s", isSiteAdmin, id))
 		if err != nil {
 			return err
 		}
 
+		if promoted != nil {
+			promoted.SiteAdmin = true
+			EnqueueOutboundWebhookEvent(ctx, u.logger, OutboundWebhookJobsWithDefaultKey(tx), events.UserSiteAdminGranted, events.NewUser(promoted))
+		}
+
 		userRoleStore := tx.UserRoles()
 		if isSiteAdmin {
 			err := userRoleStore.AssignSystemRole(ctx, AssignSystemRoleOpts{
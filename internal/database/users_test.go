@@ -18,6 +18,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -1079,6 +1080,49 @@ func TestUsers_InvalidateSessions(t *testing.T) {
 	}
 }
 
+func TestUsers_OutboundWebhookEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	eventTypes := func(t *testing.T) []string {
+		t.Helper()
+		have, err := basestore.ScanStrings(db.Handle().QueryContext(ctx, "SELECT event_type FROM outbound_webhook_jobs ORDER BY id"))
+		require.NoError(t, err)
+		return have
+	}
+
+	// The first user is created as a site admin.
+	_, err := db.Users().Create(ctx, NewUser{Username: "admin"})
+	require.NoError(t, err)
+	user, err := db.Users().Create(ctx, NewUser{Username: "u"})
+	require.NoError(t, err)
+	other, err := db.Users().Create(ctx, NewUser{Username: "other"})
+	require.NoError(t, err)
+
+	require.NoError(t, db.Users().SetIsSiteAdmin(ctx, user.ID, true))
+	// Promoting an existing site admin doesn't send another event.
+	require.NoError(t, db.Users().SetIsSiteAdmin(ctx, user.ID, true))
+
+	require.NoError(t, db.Users().Delete(ctx, user.ID))
+	// Hard deleting a soft deleted user doesn't send another event.
+	require.NoError(t, db.Users().HardDelete(ctx, user.ID))
+	require.NoError(t, db.Users().HardDelete(ctx, other.ID))
+
+	assert.Equal(t, []string{
+		"user:created",
+		"user:created",
+		"user:created",
+		"user:site_admin_granted",
+		"user:deleted",
+		"user:deleted",
+	}, eventTypes(t))
+}
+
 func TestUsers_SetIsSiteAdmin(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -63,6 +63,10 @@ type EmbeddingsSearchParameters struct {
 	TextResultsCount int            `json:"textResultsCount"`
 
 	UseDocumentRanks bool `json:"useDocumentRanks"`
+
+	// Exact disables approximate indexes, so that all embeddings are searched. It is used to
+	// compare the results of approximate searches to exact ones.
+	Exact bool `json:"exact,omitempty"`
 }
 
 func (p *EmbeddingsSearchParameters) Attrs() []attribute.KeyValue {
@@ -72,6 +76,7 @@ func (p *EmbeddingsSearchParameters) Attrs() []attribute.KeyValue {
 		attribute.Int("codeResultsCount", p.CodeResultsCount),
 		attribute.Int("textResultsCount", p.TextResultsCount),
 		attribute.Bool("useDocumentRanks", p.UseDocumentRanks),
+		attribute.Bool("exact", p.Exact),
 	}
 }
 
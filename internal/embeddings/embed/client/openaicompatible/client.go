@@ -0,0 +1,152 @@
+// Package openaicompatible implements an embeddings client for self-hosted servers that
+// expose an OpenAI-compatible embeddings API.
+package openaicompatible
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/modeltransformations"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewClient returns a client for the embeddings API served at the base URL in
+// config.Endpoint, e.g. "http://localhost:8000/v1".
+func NewClient(httpClient *http.Client, config *conftypes.EmbeddingsConfig) *openaiCompatibleEmbeddingsClient {
+	return &openaiCompatibleEmbeddingsClient{
+		httpClient:  httpClient,
+		dimensions:  config.Dimensions,
+		accessToken: config.AccessToken,
+		model:       config.Model,
+		endpoint:    strings.TrimRight(config.Endpoint, "/") + "/embeddings",
+		config:      config.OpenAICompatible,
+	}
+}
+
+type openaiCompatibleEmbeddingsClient struct {
+	httpClient  *http.Client
+	model       string
+	dimensions  int
+	endpoint    string
+	accessToken string
+	config      conftypes.OpenAICompatibleConfig
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetDimensions() (int, error) {
+	if c.dimensions <= 0 {
+		return 0, errors.New("invalid config for embeddings.dimensions, must be > 0")
+	}
+	return c.dimensions, nil
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetModelIdentifier() string {
+	return fmt.Sprintf("openai-compatible/%s", c.model)
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetQueryEmbedding(ctx context.Context, query string) (*client.EmbeddingsResults, error) {
+	return c.getEmbeddings(ctx, []string{modeltransformations.ApplyToQuery(query, c.GetModelIdentifier())})
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetDocumentEmbeddings(ctx context.Context, documents []string) (*client.EmbeddingsResults, error) {
+	return c.getEmbeddings(ctx, modeltransformations.ApplyToDocuments(documents, c.GetModelIdentifier()))
+}
+
+func (c *openaiCompatibleEmbeddingsClient) getEmbeddings(ctx context.Context, texts []string) (*client.EmbeddingsResults, error) {
+	for _, text := range texts {
+		if text == "" {
+			// Like the OpenAI API, most servers return an error if any of the strings in texts
+			// is an empty string, so fail fast to avoid making tons of retryable requests.
+			return nil, errors.New("cannot generate embeddings for an empty string")
+		}
+	}
+
+	response, err := c.do(ctx, embeddingAPIRequest{Model: c.config.Model(c.model), Input: texts})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) != len(texts) {
+		return nil, errors.Newf("expected %d embeddings, got %d", len(texts), len(response.Data))
+	}
+
+	// Some servers omit the index of the embeddings, which then all decode to
+	// 0. A stable sort keeps those in the order of the response.
+	sort.SliceStable(response.Data, func(i, j int) bool {
+		return response.Data[i].Index < response.Data[j].Index
+	})
+
+	dimensionality := c.dimensions
+	embeddings := make([]float32, 0, len(response.Data)*dimensionality)
+	failed := make([]int, 0)
+	for i, embedding := range response.Data {
+		if len(embedding.Embedding) == 0 {
+			failed = append(failed, i)
+
+			// Provide a zero value embedding for the failed chunk.
+			embeddings = append(embeddings, make([]float32, dimensionality)...)
+			continue
+		}
+		if len(embedding.Embedding) != dimensionality {
+			return nil, errors.Newf("expected embeddings with %d dimensions, got %d: check the embeddings.dimensions setting", dimensionality, len(embedding.Embedding))
+		}
+		embeddings = append(embeddings, embedding.Embedding...)
+	}
+
+	return &client.EmbeddingsResults{Embeddings: embeddings, Failed: failed, Dimensions: dimensionality}, nil
+}
+
+func (c *openaiCompatibleEmbeddingsClient) do(ctx context.Context, request embeddingAPIRequest) (*embeddingAPIResponse, error) {
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Servers in a trusted network are often run without authentication.
+	if c.accessToken != "" {
+		req.Header.Set(c.config.AuthHeader, c.config.AuthHeaderPrefix+c.accessToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("embeddings: %s %q: failed with status %d: %s", req.Method, req.URL.String(), resp.StatusCode, string(respBody))
+	}
+
+	var response embeddingAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+type embeddingAPIRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingAPIResponse struct {
+	Data []embeddingAPIResponseData `json:"data"`
+}
+
+type embeddingAPIResponseData struct {
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
@@ -0,0 +1,102 @@
+package openaicompatible
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+)
+
+func TestOpenAICompatible(t *testing.T) {
+	config := func(endpoint string) *conftypes.EmbeddingsConfig {
+		return &conftypes.EmbeddingsConfig{
+			Provider:    conftypes.EmbeddingsProviderNameOpenAICompatible,
+			Endpoint:    endpoint,
+			AccessToken: "secret",
+			Model:       "nomic-embed-text",
+			Dimensions:  3,
+			OpenAICompatible: conftypes.OpenAICompatibleConfig{
+				AuthHeader:       "Authorization",
+				AuthHeaderPrefix: "Bearer ",
+				ModelMapping:     map[string]string{"nomic-embed-text": "nomic-ai/nomic-embed-text-v1"},
+			},
+		}
+	}
+
+	// newServer returns a stand-in for an OpenAI-compatible server that responds with
+	// data and records the requests it receives.
+	newServer := func(t *testing.T, data []embeddingAPIResponseData) (*httptest.Server, *[]embeddingAPIRequest) {
+		var requests []embeddingAPIRequest
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer secret" {
+				http.Error(w, "unexpected request", http.StatusBadRequest)
+				return
+			}
+			var req embeddingAPIRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			requests = append(requests, req)
+			json.NewEncoder(w).Encode(embeddingAPIResponse{Data: data})
+		}))
+		t.Cleanup(s.Close)
+		return s, &requests
+	}
+
+	t.Run("errors on empty embedding string", func(t *testing.T) {
+		client := NewClient(http.DefaultClient, config(""))
+		_, err := client.GetDocumentEmbeddings(context.Background(), []string{"a", ""})
+		require.ErrorContains(t, err, "empty string")
+	})
+
+	t.Run("embeddings are in the order of the input", func(t *testing.T) {
+		s, requests := newServer(t, []embeddingAPIResponseData{
+			{Index: 1, Embedding: []float32{4, 5, 6}},
+			{Index: 0, Embedding: []float32{1, 2, 3}},
+		})
+
+		client := NewClient(s.Client(), config(s.URL+"/v1/"))
+		resp, err := client.GetDocumentEmbeddings(context.Background(), []string{"a", "b"})
+		require.NoError(t, err)
+		require.Equal(t, []float32{1, 2, 3, 4, 5, 6}, resp.Embeddings)
+		require.Empty(t, resp.Failed)
+		require.Equal(t, 3, resp.Dimensions)
+		require.Equal(t, []embeddingAPIRequest{{Model: "nomic-ai/nomic-embed-text-v1", Input: []string{"a", "b"}}}, *requests)
+	})
+
+	t.Run("embeddings without index are in the order of the response", func(t *testing.T) {
+		s, _ := newServer(t, []embeddingAPIResponseData{
+			{Embedding: []float32{1, 2, 3}},
+			{Embedding: nil},
+			{Embedding: []float32{7, 8, 9}},
+		})
+
+		client := NewClient(s.Client(), config(s.URL+"/v1"))
+		resp, err := client.GetDocumentEmbeddings(context.Background(), []string{"a", "b", "c"})
+		require.NoError(t, err)
+		require.Equal(t, []float32{1, 2, 3, 0, 0, 0, 7, 8, 9}, resp.Embeddings)
+		require.Equal(t, []int{1}, resp.Failed)
+	})
+
+	t.Run("errors on unexpected dimensions", func(t *testing.T) {
+		s, _ := newServer(t, []embeddingAPIResponseData{{Embedding: []float32{1, 2}}})
+
+		client := NewClient(s.Client(), config(s.URL+"/v1"))
+		_, err := client.GetQueryEmbedding(context.Background(), "a")
+		require.ErrorContains(t, err, "embeddings.dimensions")
+	})
+
+	t.Run("errors on status not OK", func(t *testing.T) {
+		s, _ := newServer(t, nil)
+
+		client := NewClient(s.Client(), config(s.URL))
+		_, err := client.GetQueryEmbedding(context.Background(), "a")
+		require.ErrorContains(t, err, "failed with status 400")
+	})
+}
@@ -20,10 +20,11 @@ import (
 // way that affects how it's decoded, we add a new format version and update CurrentFormatVersion to the latest.
 type IndexFormatVersion int
 
-const CurrentFormatVersion = EmbeddingModelVersion
+const CurrentFormatVersion = IVFIndexVersion
 const (
 	InitialVersion        IndexFormatVersion = iota // The initial format, before we started tracking format versions
 	EmbeddingModelVersion                           // Added the model name used to create embeddings
+	IVFIndexVersion                                 // Added the optional approximate (IVF) index of each embedding index
 )
 
 func DownloadIndex[T any](ctx context.Context, uploadStore uploadstore.Store, key string) (_ *T, err error) {
@@ -89,6 +90,7 @@ func UpdateRepoEmbeddingIndex(
 	new *RepoEmbeddingIndex,
 	toRemove []string,
 	ranks types.RepoPathRanks,
+	ivfOpts *IVFOptions,
 ) error {
 	// update revision
 	previous.Revision = new.Revision
@@ -107,6 +109,11 @@ func UpdateRepoEmbeddingIndex(
 	previous.CodeIndex.append(new.CodeIndex)
 	previous.TextIndex.append(new.TextIndex)
 
+	// rebuild the approximate indexes, since the rows have changed
+	if ivfOpts != nil {
+		previous.BuildIVFIndexes(*ivfOpts)
+	}
+
 	// re-upload
 	return UploadRepoEmbeddingIndex(ctx, uploadStore, key, previous)
 }
@@ -238,6 +245,16 @@ func (d *decoder) decode() (*RepoEmbeddingIndex, error) {
 			}
 			ei.Embeddings = append(ei.Embeddings, Quantize(embeddingsBuf, quantizeBuf)...)
 		}
+
+		if d.formatVersion >= IVFIndexVersion {
+			var ivf IVFIndex
+			if err := d.dec.Decode(&ivf); err != nil {
+				return nil, err
+			}
+			if ivf.NumLists() > 0 {
+				ei.IVF = &ivf
+			}
+		}
 	}
 
 	return rei, nil
@@ -314,6 +331,18 @@ func (e *encoder) encode(rei *RepoEmbeddingIndex) error {
 				return err
 			}
 		}
+
+		if e.formatVersion >= IVFIndexVersion {
+			// Indexes without an approximate index are encoded as an empty IVFIndex, since gob
+			// can't encode nil pointers.
+			var ivf IVFIndex
+			if ei.IVF != nil {
+				ivf = *ei.IVF
+			}
+			if err := e.enc.Encode(ivf); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
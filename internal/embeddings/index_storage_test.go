@@ -143,6 +143,42 @@ func TestRepoEmbeddingIndexStorage(t *testing.T) {
 	require.Equal(t, index, downloadedIndex)
 }
 
+func TestRepoEmbeddingIndexStorageWithIVF(t *testing.T) {
+	prng := rand.New(rand.NewSource(0))
+	index := &RepoEmbeddingIndex{
+		RepoName:  api.RepoName("repo"),
+		Revision:  api.CommitID("commit"),
+		CodeIndex: getClusteredEmbeddingIndex(prng, 100, 5, 8),
+		TextIndex: getClusteredEmbeddingIndex(prng, 10, 5, 8),
+	}
+	index.BuildIVFIndexes(IVFOptions{MinRows: 50})
+	require.NotNil(t, index.CodeIndex.IVF)
+	require.Nil(t, index.TextIndex.IVF)
+
+	ctx := context.Background()
+	uploadStore := newMockUploadStore()
+
+	err := UploadRepoEmbeddingIndex(ctx, uploadStore, "0.embeddingindex", index)
+	require.NoError(t, err)
+
+	downloadedIndex, err := DownloadRepoEmbeddingIndex(ctx, uploadStore, 0, "")
+	require.NoError(t, err)
+
+	require.Equal(t, index, downloadedIndex)
+
+	// Indexes written before approximate indexes existed can still be read.
+	var buf bytes.Buffer
+	enc := newEncoder(gob.NewEncoder(&buf), EmbeddingModelVersion, embeddingsChunkSize)
+	require.NoError(t, enc.encode(index))
+	_, err = uploadStore.Upload(ctx, "1.embeddingindex", &buf)
+	require.NoError(t, err)
+
+	downloadedIndex, err = DownloadRepoEmbeddingIndex(ctx, uploadStore, 1, "")
+	require.NoError(t, err)
+	require.Nil(t, downloadedIndex.CodeIndex.IVF)
+	require.Equal(t, index.CodeIndex.Embeddings, downloadedIndex.CodeIndex.Embeddings)
+}
+
 func TestIndexFormatVersion(t *testing.T) {
 	index := &RepoEmbeddingIndex{
 		RepoName: api.RepoName("repo"),
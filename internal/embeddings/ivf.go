@@ -0,0 +1,224 @@
+package embeddings
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+
+	"github.com/sourcegraph/conc"
+)
+
+const (
+	// ivfTrainingIterations is the number of k-means iterations used to compute the centroids.
+	ivfTrainingIterations = 10
+	// ivfTrainingRowsPerList is the number of sampled rows per list that the centroids are
+	// trained on. Training on all rows of large indexes takes long and barely improves recall.
+	ivfTrainingRowsPerList = 64
+)
+
+// IVFIndex is an approximate nearest neighbor index over the rows of an EmbeddingIndex. Rows are
+// partitioned into lists by their most similar centroid (an inverted file index), and searches
+// only scan the rows in the few lists whose centroids are most similar to the query.
+type IVFIndex struct {
+	// Centroids holds the quantized and normalized centroid of each list, one row per list.
+	Centroids []int8
+	// Offsets holds the start of each list in Rows, followed by len(Rows).
+	Offsets []int32
+	// Rows holds the numbers of the rows in the embedding index, grouped by list.
+	Rows []int32
+}
+
+// NumLists returns the number of lists in the index.
+func (ivf *IVFIndex) NumLists() int {
+	return max(0, len(ivf.Offsets)-1)
+}
+
+func (ivf *IVFIndex) list(l int) []int32 {
+	return ivf.Rows[ivf.Offsets[l]:ivf.Offsets[l+1]]
+}
+
+func (ivf *IVFIndex) estimateSize() uint64 {
+	return uint64(len(ivf.Centroids) + len(ivf.Offsets)*4 + len(ivf.Rows)*4)
+}
+
+// IVFOptions configures how approximate indexes are built.
+type IVFOptions struct {
+	// MinRows is the minimum number of rows for which an index is built. Smaller embedding
+	// indexes are fast to search exactly.
+	MinRows int
+	// NumWorkers is the number of goroutines used to assign rows to lists. It defaults to
+	// GOMAXPROCS.
+	NumWorkers int
+}
+
+// BuildIVFIndexes builds the approximate indexes of the code and text indexes, replacing the
+// previous ones.
+func (i *RepoEmbeddingIndex) BuildIVFIndexes(opts IVFOptions) {
+	i.CodeIndex.IVF = buildIVFIndex(&i.CodeIndex, opts)
+	i.TextIndex.IVF = buildIVFIndex(&i.TextIndex, opts)
+}
+
+// buildIVFIndex clusters the rows of the index with spherical k-means into roughly sqrt(numRows)
+// lists. It returns nil if the index has fewer than opts.MinRows rows.
+func buildIVFIndex(index *EmbeddingIndex, opts IVFOptions) *IVFIndex {
+	numRows := len(index.RowMetadata)
+	if numRows == 0 || numRows < opts.MinRows {
+		return nil
+	}
+	if opts.NumWorkers <= 0 {
+		opts.NumWorkers = runtime.GOMAXPROCS(0)
+	}
+
+	dim := index.ColumnDimension
+	numLists := max(1, int(math.Sqrt(float64(numRows))))
+	// Seed with the number of rows so that building the same index twice gives the same result.
+	prng := rand.New(rand.NewSource(int64(numRows)))
+
+	sample := prng.Perm(numRows)[:min(numRows, numLists*ivfTrainingRowsPerList)]
+	centroids := make([]int8, numLists*dim)
+	for l := 0; l < numLists; l++ {
+		copy(centroids[l*dim:(l+1)*dim], index.Row(sample[l]))
+	}
+
+	sums := make([]float32, numLists*dim)
+	counts := make([]int, numLists)
+	normalized := make([]float32, dim)
+	for iter := 0; iter < ivfTrainingIterations; iter++ {
+		assignments := assignLists(index, sample, centroids, opts.NumWorkers)
+
+		for j := range sums {
+			sums[j] = 0
+		}
+		for l := range counts {
+			counts[l] = 0
+		}
+		for j, row := range sample {
+			l := int(assignments[j])
+			counts[l]++
+			sum := sums[l*dim : (l+1)*dim]
+			for k, v := range index.Row(row) {
+				sum[k] += float32(v)
+			}
+		}
+
+		for l := 0; l < numLists; l++ {
+			centroid := centroids[l*dim : (l+1)*dim]
+			if counts[l] == 0 {
+				// Move centroids without any rows to a random row, so that no list stays empty.
+				copy(centroid, index.Row(sample[prng.Intn(len(sample))]))
+				continue
+			}
+			normalize(sums[l*dim:(l+1)*dim], normalized)
+			Quantize(normalized, centroid)
+		}
+	}
+
+	allRows := make([]int, numRows)
+	for j := range allRows {
+		allRows[j] = j
+	}
+	assignments := assignLists(index, allRows, centroids, opts.NumWorkers)
+
+	// Group the rows by list with a counting sort, which keeps the rows of each list in order.
+	offsets := make([]int32, numLists+1)
+	for _, l := range assignments {
+		offsets[l+1]++
+	}
+	for l := 0; l < numLists; l++ {
+		offsets[l+1] += offsets[l]
+	}
+	rows := make([]int32, numRows)
+	next := append([]int32(nil), offsets[:numLists]...)
+	for row, l := range assignments {
+		rows[next[l]] = int32(row)
+		next[l]++
+	}
+
+	return &IVFIndex{
+		Centroids: centroids,
+		Offsets:   offsets,
+		Rows:      rows,
+	}
+}
+
+// assignLists returns the list with the most similar centroid for each of the given rows.
+func assignLists(index *EmbeddingIndex, rows []int, centroids []int8, numWorkers int) []int32 {
+	dim := index.ColumnDimension
+	numLists := len(centroids) / dim
+	assignments := make([]int32, len(rows))
+
+	var wg conc.WaitGroup
+	for _, part := range splitRows(len(rows), numWorkers, 0) {
+		part := part
+		wg.Go(func() {
+			for j := part.start; j < part.end; j++ {
+				row := index.Row(rows[j])
+				best, bestScore := 0, int32(math.MinInt32)
+				for l := 0; l < numLists; l++ {
+					if score := Dot(row, centroids[l*dim:(l+1)*dim]); score > bestScore {
+						best, bestScore = l, score
+					}
+				}
+				assignments[j] = int32(best)
+			}
+		})
+	}
+	wg.Wait()
+
+	return assignments
+}
+
+// normalize writes the unit vector with the direction of v to out.
+func normalize(v, out []float32) {
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	norm = math.Sqrt(norm)
+	if norm == 0 {
+		norm = 1
+	}
+	for k, x := range v {
+		out[k] = float32(float64(x) / norm)
+	}
+}
+
+// candidates returns the rows in the probes lists whose centroids are most similar to the query,
+// in ascending order. It returns nil if the rows should be searched exactly instead, because
+// the probed lists would cover all lists or hold fewer than numResults rows.
+func (ivf *IVFIndex) candidates(query []int8, probes, numResults int) []int32 {
+	numLists := ivf.NumLists()
+	if probes <= 0 || probes >= numLists {
+		return nil
+	}
+	dim := len(ivf.Centroids) / numLists
+
+	nnHeap := newNearestNeighborsHeap()
+	for l := 0; l < numLists; l++ {
+		score := Dot(query, ivf.Centroids[l*dim:(l+1)*dim])
+		if nnHeap.Len() < probes {
+			heap.Push(nnHeap, nearestNeighbor{index: l, scoreDetails: SearchScoreDetails{Score: score}})
+		} else if score > nnHeap.Peek().scoreDetails.Score {
+			heap.Pop(nnHeap)
+			heap.Push(nnHeap, nearestNeighbor{index: l, scoreDetails: SearchScoreDetails{Score: score}})
+		}
+	}
+
+	numCandidates := 0
+	for _, nn := range nnHeap.neighbors {
+		numCandidates += len(ivf.list(nn.index))
+	}
+	if numCandidates < numResults {
+		return nil
+	}
+
+	candidates := make([]int32, 0, numCandidates)
+	for _, nn := range nnHeap.neighbors {
+		candidates = append(candidates, ivf.list(nn.index)...)
+	}
+	// Search the rows in index order, so that ties are broken like in an exact search.
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+	return candidates
+}
@@ -0,0 +1,129 @@
+package embeddings
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// getClusteredEmbeddingIndex returns an index of normalized rows that are scattered around
+// numClusters random directions, like embeddings of similar code are.
+func getClusteredEmbeddingIndex(prng *rand.Rand, numRows, numClusters, columnDimension int) EmbeddingIndex {
+	randomUnitVector := func(center []float32, spread float32) []float32 {
+		v := make([]float32, columnDimension)
+		for k := range v {
+			v[k] = float32(prng.NormFloat64()) * spread
+			if center != nil {
+				v[k] += center[k]
+			}
+		}
+		normalize(v, v)
+		return v
+	}
+
+	centers := make([][]float32, numClusters)
+	for c := range centers {
+		centers[c] = randomUnitVector(nil, 1)
+	}
+
+	index := EmbeddingIndex{ColumnDimension: columnDimension}
+	for i := 0; i < numRows; i++ {
+		row := randomUnitVector(centers[prng.Intn(numClusters)], 0.1)
+		index.Embeddings = append(index.Embeddings, Quantize(row, nil)...)
+		index.RowMetadata = append(index.RowMetadata, RepoEmbeddingRowMetadata{FileName: strconv.Itoa(i)})
+	}
+	return index
+}
+
+func TestBuildIVFIndex(t *testing.T) {
+	prng := rand.New(rand.NewSource(0))
+	index := getClusteredEmbeddingIndex(prng, 2000, 20, 16)
+
+	require.Nil(t, buildIVFIndex(&index, IVFOptions{MinRows: 2001}))
+
+	ivf := buildIVFIndex(&index, IVFOptions{MinRows: 2000})
+	require.NotNil(t, ivf)
+	require.Equal(t, 44, ivf.NumLists())
+	require.Len(t, ivf.Centroids, 44*16)
+
+	// Every row is in exactly one list, in order within the list.
+	var rows []int
+	for l := 0; l < ivf.NumLists(); l++ {
+		list := ivf.list(l)
+		require.True(t, sort.SliceIsSorted(list, func(i, j int) bool { return list[i] < list[j] }))
+		for _, row := range list {
+			rows = append(rows, int(row))
+		}
+	}
+	sort.Ints(rows)
+	for i, row := range rows {
+		require.Equal(t, i, row)
+	}
+
+	// Building the index again gives the same result.
+	require.Equal(t, ivf, buildIVFIndex(&index, IVFOptions{MinRows: 2000, NumWorkers: 3}))
+}
+
+func TestApproximateSimilaritySearch(t *testing.T) {
+	prng := rand.New(rand.NewSource(0))
+	index := getClusteredEmbeddingIndex(prng, 5000, 50, 32)
+	queries := getClusteredEmbeddingIndex(prng, 20, 50, 32)
+
+	search := func(query []int8, numResults, probes int) []string {
+		results := index.SimilaritySearch(query, numResults, WorkerOptions{NumWorkers: 2}, SearchOptions{Probes: probes}, "", "")
+		fileNames := make([]string, len(results))
+		for i, r := range results {
+			fileNames[i] = r.FileName
+		}
+		return fileNames
+	}
+
+	index.IVF = buildIVFIndex(&index, IVFOptions{})
+	numLists := index.IVF.NumLists()
+
+	t.Run("without probes, search is exact", func(t *testing.T) {
+		for q := 0; q < len(queries.RowMetadata); q++ {
+			query := queries.Row(q)
+			exact := search(query, 10, 0)
+			require.Equal(t, exact, search(query, 10, numLists))
+
+			ivf := index.IVF
+			index.IVF = nil
+			require.Equal(t, exact, search(query, 10, 8))
+			index.IVF = ivf
+		}
+	})
+
+	t.Run("falls back to exact search if the lists are too small", func(t *testing.T) {
+		query := queries.Row(0)
+		exact := search(query, 1000, 0)
+		require.Equal(t, exact, search(query, 1000, 1))
+	})
+
+	t.Run("recall increases with probes", func(t *testing.T) {
+		recall := func(probes int) float64 {
+			found, total := 0, 0
+			for q := 0; q < len(queries.RowMetadata); q++ {
+				query := queries.Row(q)
+				approximate := map[string]struct{}{}
+				for _, fileName := range search(query, 10, probes) {
+					approximate[fileName] = struct{}{}
+				}
+				for _, fileName := range search(query, 10, 0) {
+					if _, ok := approximate[fileName]; ok {
+						found++
+					}
+					total++
+				}
+			}
+			return float64(found) / float64(total)
+		}
+
+		low, high := recall(1), recall(8)
+		require.GreaterOrEqual(t, high, low)
+		require.GreaterOrEqual(t, high, 0.9)
+	})
+}
@@ -81,6 +81,8 @@ type WorkerOptions struct {
 }
 
 // SimilaritySearch finds the `nResults` most similar rows to a query vector. It uses the cosine similarity metric.
+// If opts.Probes is set and the index has an approximate index, only the rows in the most similar lists of the
+// approximate index are searched.
 // IMPORTANT: The vectors in the embedding index have to be normalized for similarity search to work correctly.
 func (index *EmbeddingIndex) SimilaritySearch(
 	query []int8,
@@ -100,8 +102,18 @@ func (index *EmbeddingIndex) SimilaritySearch(
 	// We need at least 1 worker.
 	numWorkers := max(1, workerOptions.NumWorkers)
 
+	// Narrow down the rows to search with the approximate index. If candidates is nil, we search all rows.
+	var candidates []int32
+	if index.IVF != nil {
+		candidates = index.IVF.candidates(query, opts.Probes, numResults)
+	}
+	numCandidates := numRows
+	if candidates != nil {
+		numCandidates = len(candidates)
+	}
+
 	// Split index rows among the workers. Each worker will run a partial similarity search on the assigned rows.
-	rowsPerWorker := splitRows(numRows, numWorkers, workerOptions.MinRowsToSplit)
+	rowsPerWorker := splitRows(numCandidates, numWorkers, workerOptions.MinRowsToSplit)
 	heaps := make([]*nearestNeighborsHeap, len(rowsPerWorker))
 
 	if len(rowsPerWorker) > 1 {
@@ -110,13 +122,13 @@ func (index *EmbeddingIndex) SimilaritySearch(
 			// Capture the loop variable value so we can use it in the closure below.
 			workerIdx := workerIdx
 			wg.Go(func() {
-				heaps[workerIdx] = index.partialSimilaritySearch(query, numResults, rowsPerWorker[workerIdx], opts)
+				heaps[workerIdx] = index.partialSimilaritySearch(query, numResults, rowsPerWorker[workerIdx], candidates, opts)
 			})
 		}
 		wg.Wait()
 	} else {
 		// Run the similarity search directly when we have a single worker to eliminate the concurrency overhead.
-		heaps[0] = index.partialSimilaritySearch(query, numResults, rowsPerWorker[0], opts)
+		heaps[0] = index.partialSimilaritySearch(query, numResults, rowsPerWorker[0], candidates, opts)
 	}
 
 	// Collect all heap neighbors from workers into a single array.
@@ -147,26 +159,35 @@ func (index *EmbeddingIndex) SimilaritySearch(
 	return results
 }
 
-func (index *EmbeddingIndex) partialSimilaritySearch(query []int8, numResults int, partialRows partialRows, opts SearchOptions) *nearestNeighborsHeap {
+// partialSimilaritySearch searches the rows in partialRows. If candidates is not nil, partialRows
+// is a range of candidates instead of a range of rows.
+func (index *EmbeddingIndex) partialSimilaritySearch(query []int8, numResults int, partialRows partialRows, candidates []int32, opts SearchOptions) *nearestNeighborsHeap {
 	nRows := partialRows.end - partialRows.start
 	if nRows <= 0 {
 		return nil
 	}
 	numResults = min(nRows, numResults)
 
+	row := func(i int) int {
+		if candidates == nil {
+			return i
+		}
+		return int(candidates[i])
+	}
+
 	nnHeap := newNearestNeighborsHeap()
 	for i := partialRows.start; i < partialRows.start+numResults; i++ {
-		scoreDetails := index.score(query, i, opts)
-		heap.Push(nnHeap, nearestNeighbor{index: i, scoreDetails: scoreDetails})
+		scoreDetails := index.score(query, row(i), opts)
+		heap.Push(nnHeap, nearestNeighbor{index: row(i), scoreDetails: scoreDetails})
 	}
 
 	for i := partialRows.start + numResults; i < partialRows.end; i++ {
-		scoreDetails := index.score(query, i, opts)
+		scoreDetails := index.score(query, row(i), opts)
 		// Add row if it has greater similarity than the smallest similarity in the heap.
 		// This way we ensure keep a set of the highest similarities in the heap.
 		if scoreDetails.Score > nnHeap.Peek().scoreDetails.Score {
 			heap.Pop(nnHeap)
-			heap.Push(nnHeap, nearestNeighbor{index: i, scoreDetails: scoreDetails})
+			heap.Push(nnHeap, nearestNeighbor{index: row(i), scoreDetails: scoreDetails})
 		}
 	}
 
@@ -218,4 +239,7 @@ func max(a, b int) int {
 
 type SearchOptions struct {
 	UseDocumentRanks bool
+	// Probes is the number of lists of the approximate index to search. More probes increase recall and
+	// latency. If zero, or if the index has no approximate index, all rows are searched exactly.
+	Probes int
 }
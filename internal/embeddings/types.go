@@ -13,6 +13,10 @@ type EmbeddingIndex struct {
 	ColumnDimension int
 	RowMetadata     []RepoEmbeddingRowMetadata
 	Ranks           []float32
+
+	// IVF is the optional approximate nearest neighbor index of the rows. It is nil for small
+	// indexes and for indexes created before approximate indexes existed.
+	IVF *IVFIndex
 }
 
 // Row returns the embeddings for the nth row in the index
@@ -21,7 +25,11 @@ func (index *EmbeddingIndex) Row(n int) []int8 {
 }
 
 func (index *EmbeddingIndex) EstimateSize() uint64 {
-	return uint64(len(index.Embeddings) + len(index.RowMetadata)*(16+8+8) + len(index.Ranks)*4)
+	size := uint64(len(index.Embeddings) + len(index.RowMetadata)*(16+8+8) + len(index.Ranks)*4)
+	if index.IVF != nil {
+		size += index.IVF.estimateSize()
+	}
+	return size
 }
 
 // Filter removes all files from the index that are in the set and updates the ranks
@@ -49,12 +57,16 @@ func (index *EmbeddingIndex) filter(set map[string]struct{}, ranks types.RepoPat
 	index.RowMetadata = index.RowMetadata[:cursor]
 	index.Ranks = index.Ranks[:cursor]
 	index.Embeddings = index.Embeddings[:cursor*index.ColumnDimension]
+	// The row numbers have changed, so the approximate index must be rebuilt.
+	index.IVF = nil
 }
 
 func (index *EmbeddingIndex) append(other EmbeddingIndex) {
 	index.RowMetadata = append(index.RowMetadata, other.RowMetadata...)
 	index.Ranks = append(index.Ranks, other.Ranks...)
 	index.Embeddings = append(index.Embeddings, other.Embeddings...)
+	// The approximate index doesn't contain the new rows, so it must be rebuilt.
+	index.IVF = nil
 }
 
 type RepoEmbeddingRowMetadata struct {
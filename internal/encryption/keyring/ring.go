@@ -113,6 +113,13 @@ func NewRing(ctx context.Context, keyConfig *schema.EncryptionKeys) (*Ring, erro
 		}
 	}
 
+	if keyConfig.UserTOTPKey != nil {
+		r.UserTOTPKey, err = NewKey(ctx, keyConfig.UserTOTPKey, keyConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if keyConfig.WebhookKey != nil {
 		r.WebhookKey, err = NewKey(ctx, keyConfig.WebhookKey, keyConfig)
 		if err != nil {
@@ -143,6 +150,7 @@ type Ring struct {
 	GitHubAppKey              encryption.Key
 	OutboundWebhookKey        encryption.Key
 	UserExternalAccountKey    encryption.Key
+	UserTOTPKey               encryption.Key
 	WebhookKey                encryption.Key
 	WebhookLogKey             encryption.Key
 	ExecutorSecretKey         encryption.Key
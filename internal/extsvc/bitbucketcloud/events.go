@@ -53,6 +53,27 @@ func ParseWebhookEvent(eventKey string, payload []byte) (any, error) {
 
 type PushEvent struct {
 	RepoEvent
+	Push struct {
+		Changes []PushChange `json:"changes"`
+	} `json:"push"`
+}
+
+// PushChange is the change of a single branch or tag in a push.
+type PushChange struct {
+	// Old is nil if the push created the branch or tag.
+	Old *PushChangeRef `json:"old"`
+	// New is nil if the push deleted the branch or tag.
+	New    *PushChangeRef `json:"new"`
+	Forced bool           `json:"forced"`
+}
+
+type PushChangeRef struct {
+	// Type is one of branch, named_branch, bookmark or tag.
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
 }
 
 type PullRequestEvent struct {
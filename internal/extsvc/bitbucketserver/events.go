@@ -41,7 +41,17 @@ func ParseWebhookEvent(eventType string, payload []byte) (e any, err error) {
 type PingEvent struct{}
 
 type PushEvent struct {
-	Repository Repo `json:"repository"`
+	Repository Repo        `json:"repository"`
+	Changes    []RefChange `json:"changes"`
+}
+
+// RefChange is the change of a single ref in a push.
+type RefChange struct {
+	RefID    string `json:"refId"`
+	FromHash string `json:"fromHash"`
+	ToHash   string `json:"toHash"`
+	// Type is one of ADD, UPDATE or DELETE.
+	Type string `json:"type"`
 }
 
 type PullRequestActivityEvent struct {
@@ -0,0 +1,230 @@
+//nolint:bodyclose // Body is closed in Client.do, but the response is still returned to provide access to the headers
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultPageSize is the number of items requested per page from list
+// endpoints. Gitea caps it at its MAX_RESPONSE_ITEMS setting, which defaults
+// to 50.
+const DefaultPageSize = 50
+
+// Client accesses a Gitea or Forgejo instance via the REST API.
+type Client struct {
+	// URL is the base URL of the Gitea instance.
+	URL *url.URL
+
+	// HTTP Client used to communicate with the API.
+	httpClient httpcli.Doer
+
+	urn  string
+	auth auth.Authenticator
+
+	// sudo is the username of the user that requests are made on behalf of.
+	// It requires the client to be authenticated as a site administrator.
+	sudo string
+
+	// rateLimit is the self-imposed rate limiter (since Gitea does not have a
+	// concept of rate limiting in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an authenticated Gitea API client with the provided
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will be
+// used.
+func NewClient(urn string, baseURL *url.URL, a auth.Authenticator, httpClient httpcli.Doer) *Client {
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	u := *baseURL
+	return &Client{
+		URL:        extsvc.NormalizeBaseURL(&u),
+		httpClient: httpClient,
+		urn:        urn,
+		auth:       a,
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+	}
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTP client, and rate limiter as the current Client, except authenticated
+// with the given authenticator instance.
+//
+// Gitea accepts access tokens both as bearer tokens and as the password of
+// basic auth, so both kinds of authenticators are supported.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	switch a.(type) {
+	case *auth.OAuthBearerToken, *auth.OAuthBearerTokenWithSSH, *auth.BasicAuth, *auth.BasicAuthWithSSH:
+		break
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Gitea clients: %T", a)
+	}
+
+	cp := *c
+	cp.auth = a
+	return &cp, nil
+}
+
+// WithSudo returns a new Client that makes its requests on behalf of the user
+// with the given username. The client must be authenticated as a site
+// administrator for this to work.
+func (c *Client) WithSudo(username string) *Client {
+	cp := *c
+	cp.sudo = username
+	return &cp
+}
+
+// Authenticator returns the authenticator used by the client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.auth
+}
+
+func pageQuery(qs url.Values, pageNumber int) url.Values {
+	if qs == nil {
+		qs = make(url.Values)
+	}
+	qs.Set("page", strconv.Itoa(pageNumber))
+	qs.Set("limit", strconv.Itoa(DefaultPageSize))
+	return qs
+}
+
+// list issues a GET request against a paginated endpoint and decodes the
+// items of the requested page into result. It returns true if there is a next
+// page.
+func (c *Client) list(ctx context.Context, path string, qs url.Values, pageNumber int, result any) (bool, error) {
+	u := url.URL{Path: path, RawQuery: pageQuery(qs, pageNumber).Encode()}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.do(ctx, req, result)
+	if err != nil {
+		return false, err
+	}
+
+	return hasNextPage(resp.Header.Get("Link")), nil
+}
+
+// hasNextPage reports whether the given RFC 5988 Link header contains a link
+// to a next page.
+func hasNextPage(link string) bool {
+	for _, part := range strings.Split(link, ",") {
+		if strings.Contains(part, `rel="next"`) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) newJSONRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshalling request body")
+		}
+		r = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(&url.URL{Path: "api/v1/"}).ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+
+	if c.auth != nil {
+		if err := c.auth.Authenticate(req); err != nil {
+			return nil, errors.Wrap(err, "authenticating request")
+		}
+	}
+	if c.sudo != "" {
+		req.Header.Set("Sudo", c.sudo)
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result == nil || len(bs) == 0 {
+		return resp, nil
+	}
+	return resp, json.Unmarshal(bs, result)
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsConflict reports whether err was caused by the Gitea API responding with
+// 409 Conflict, which it does when creating a pull request that already exists
+// or merging a pull request that has conflicts.
+func IsConflict(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.StatusCode == http.StatusConflict
+}
+
+// IsNotMergeable reports whether err was caused by the Gitea API refusing to
+// merge a pull request.
+func IsNotMergeable(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && (e.StatusCode == http.StatusMethodNotAllowed || e.StatusCode == http.StatusConflict)
+}
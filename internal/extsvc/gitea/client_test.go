@@ -0,0 +1,108 @@
+package gitea
+
+import (
+	"context"
+	"flag"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/inconshreveable/log15"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		log15.Root().SetHandler(log15.LvlFilterHandler(log15.LvlError, log15.Root().GetHandler()))
+	}
+	os.Exit(m.Run())
+}
+
+func TestNewClient(t *testing.T) {
+	u, err := url.Parse("https://gitea.example.com/sub")
+	require.NoError(t, err)
+
+	cli := NewClient("urn", u, nil, nil)
+	assert.Equal(t, "https://gitea.example.com/sub/", cli.URL.String())
+	// The given URL must not be modified.
+	assert.Equal(t, "https://gitea.example.com/sub", u.String())
+}
+
+func TestClient_WithAuthenticator(t *testing.T) {
+	u, err := url.Parse("https://gitea.example.com")
+	require.NoError(t, err)
+	cli := NewClient("urn", u, &auth.OAuthBearerToken{Token: "abc"}, nil)
+
+	for name, a := range map[string]auth.Authenticator{
+		"bearer token": &auth.OAuthBearerToken{Token: "def"},
+		"basic auth":   &auth.BasicAuth{Username: "user", Password: "def"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			other, err := cli.WithAuthenticator(a)
+			require.NoError(t, err)
+			assert.Same(t, a, other.Authenticator())
+			assert.NotSame(t, cli, other)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := cli.WithAuthenticator(&auth.OAuthClient{})
+		assert.Error(t, err)
+	})
+}
+
+func TestHasNextPage(t *testing.T) {
+	for link, want := range map[string]bool{
+		"": false,
+		`<https://gitea.example.com/api/v1/user/repos?limit=50&page=2>; rel="next",<https://gitea.example.com/api/v1/user/repos?limit=50&page=3>; rel="last"`:  true,
+		`<https://gitea.example.com/api/v1/user/repos?limit=50&page=1>; rel="first",<https://gitea.example.com/api/v1/user/repos?limit=50&page=2>; rel="prev"`: false,
+	} {
+		assert.Equal(t, want, hasNextPage(link), link)
+	}
+}
+
+func TestClient_GetAuthenticatedUser(t *testing.T) {
+	cli, save := NewTestClient(t, "GetAuthenticatedUser", *update)
+	defer save()
+
+	user, err := cli.GetAuthenticatedUser(context.Background())
+	require.NoError(t, err)
+	testutil.AssertGolden(t, "testdata/golden/GetAuthenticatedUser.json", *update, user)
+}
+
+func TestClient_GetUser(t *testing.T) {
+	cli, save := NewTestClient(t, "GetUser", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	user, err := cli.GetUser(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Login)
+
+	_, err = cli.GetUser(ctx, "nobody")
+	assert.True(t, errcode.IsNotFound(err), "expected not found error, got %v", err)
+}
+
+func TestClient_GetUserByID(t *testing.T) {
+	cli, save := NewTestClient(t, "GetUserByID", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	user, err := cli.GetUserByID(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Login)
+	assert.Equal(t, UserVisibilityPublic, user.Visibility)
+
+	_, err = cli.GetUserByID(ctx, 9999)
+	assert.True(t, errcode.IsNotFound(err), "expected not found error, got %v", err)
+}
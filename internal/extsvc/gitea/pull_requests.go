@@ -0,0 +1,160 @@
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// CreatePullRequestInput is the input to CreatePullRequest.
+type CreatePullRequestInput struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// Head is the name of the branch to merge. For pull requests from a fork,
+	// it must be of the form "owner:branch".
+	Head string `json:"head"`
+	// Base is the name of the branch the pull request is merged into.
+	Base string `json:"base"`
+}
+
+// CreatePullRequest opens a pull request in the given repository. If a pull
+// request for the same head and base already exists, the returned error
+// satisfies IsConflict.
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repository, input CreatePullRequestInput) (*PullRequest, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPost, repoPath(repo.Owner.Login, repo.Name)+"/pulls", input)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetPullRequest returns the pull request with the given number.
+func (c *Client) GetPullRequest(ctx context.Context, repo *Repository, number int64) (*PullRequest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pullRequestPath(repo, number), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// ListOpenPullRequests lists the open pull requests of the given repository.
+// It returns true if there is a next page.
+func (c *Client) ListOpenPullRequests(ctx context.Context, repo *Repository, page int) ([]*PullRequest, bool, error) {
+	qs := make(url.Values)
+	qs.Set("state", string(PullRequestStateOpen))
+
+	var prs []*PullRequest
+	hasNext, err := c.list(ctx, repoPath(repo.Owner.Login, repo.Name)+"/pulls", qs, page, &prs)
+	return prs, hasNext, err
+}
+
+// EditPullRequestInput is the input to EditPullRequest. Nil fields are left
+// unchanged.
+type EditPullRequestInput struct {
+	Title *string           `json:"title,omitempty"`
+	Body  *string           `json:"body,omitempty"`
+	Base  *string           `json:"base,omitempty"`
+	State *PullRequestState `json:"state,omitempty"`
+}
+
+// EditPullRequest updates the pull request with the given number. It is also
+// used to close and reopen pull requests.
+func (c *Client) EditPullRequest(ctx context.Context, repo *Repository, number int64, input EditPullRequestInput) (*PullRequest, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPatch, pullRequestPath(repo, number), input)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MergeStyle is the strategy used to merge a pull request.
+type MergeStyle string
+
+const (
+	MergeStyleMerge  MergeStyle = "merge"
+	MergeStyleSquash MergeStyle = "squash"
+)
+
+// MergePullRequestInput is the input to MergePullRequest.
+type MergePullRequestInput struct {
+	Style                  MergeStyle `json:"Do"`
+	DeleteBranchAfterMerge bool       `json:"delete_branch_after_merge,omitempty"`
+}
+
+// MergePullRequest merges the pull request with the given number. If the pull
+// request cannot be merged, the returned error satisfies IsNotMergeable.
+func (c *Client) MergePullRequest(ctx context.Context, repo *Repository, number int64, input MergePullRequestInput) error {
+	req, err := c.newJSONRequest(ctx, http.MethodPost, pullRequestPath(repo, number)+"/merge", input)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// ListPullRequestReviews returns all reviews of the pull request with the
+// given number.
+func (c *Client) ListPullRequestReviews(ctx context.Context, repo *Repository, number int64) ([]*PullReview, error) {
+	var all []*PullReview
+	for page := 1; ; page++ {
+		var reviews []*PullReview
+		hasNext, err := c.list(ctx, pullRequestPath(repo, number)+"/reviews", nil, page, &reviews)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, reviews...)
+		if !hasNext {
+			return all, nil
+		}
+	}
+}
+
+// GetCombinedStatus returns the combined commit status of the given ref.
+func (c *Client) GetCombinedStatus(ctx context.Context, repo *Repository, ref string) (*CombinedStatus, error) {
+	path := repoPath(repo.Owner.Login, repo.Name) + "/commits/" + url.PathEscape(ref) + "/status"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var status CombinedStatus
+	if _, err := c.do(ctx, req, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// CreateComment posts a comment on the pull request with the given number.
+func (c *Client) CreateComment(ctx context.Context, repo *Repository, number int64, body string) error {
+	path := repoPath(repo.Owner.Login, repo.Name) + "/issues/" + strconv.FormatInt(number, 10) + "/comments"
+	req, err := c.newJSONRequest(ctx, http.MethodPost, path, struct {
+		Body string `json:"body"`
+	}{Body: body})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+func pullRequestPath(repo *Repository, number int64) string {
+	return repoPath(repo.Owner.Login, repo.Name) + "/pulls/" + strconv.FormatInt(number, 10)
+}
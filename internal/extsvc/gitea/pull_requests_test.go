@@ -0,0 +1,108 @@
+package gitea
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+)
+
+func TestClient_PullRequests(t *testing.T) {
+	cli, save := NewTestClient(t, "PullRequests", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	repo, err := cli.GetRepo(ctx, "sourcegraph-testing", "automation-testing")
+	require.NoError(t, err)
+
+	input := CreatePullRequestInput{
+		Title: "WIP: Add README",
+		Body:  "This is a test pull request.",
+		Head:  "test-branch",
+		Base:  "main",
+	}
+	pr, err := cli.CreatePullRequest(ctx, repo, input)
+	require.NoError(t, err)
+	testutil.AssertGolden(t, "testdata/golden/CreatePullRequest.json", *update, pr)
+
+	t.Run("already exists", func(t *testing.T) {
+		_, err := cli.CreatePullRequest(ctx, repo, input)
+		assert.True(t, IsConflict(err), "expected conflict error, got %v", err)
+
+		open, hasNext, err := cli.ListOpenPullRequests(ctx, repo, 1)
+		require.NoError(t, err)
+		assert.False(t, hasNext)
+		require.Len(t, open, 1)
+		assert.Equal(t, pr.Number, open[0].Number)
+	})
+
+	t.Run("edit", func(t *testing.T) {
+		title := UnsetWorkInProgress(pr.Title)
+		edited, err := cli.EditPullRequest(ctx, repo, pr.Number, EditPullRequestInput{Title: &title})
+		require.NoError(t, err)
+		assert.Equal(t, "Add README", edited.Title)
+		assert.Equal(t, pr.Body, edited.Body)
+	})
+
+	t.Run("close and reopen", func(t *testing.T) {
+		closed := PullRequestStateClosed
+		updated, err := cli.EditPullRequest(ctx, repo, pr.Number, EditPullRequestInput{State: &closed})
+		require.NoError(t, err)
+		assert.Equal(t, PullRequestStateClosed, updated.State)
+
+		open := PullRequestStateOpen
+		updated, err = cli.EditPullRequest(ctx, repo, pr.Number, EditPullRequestInput{State: &open})
+		require.NoError(t, err)
+		assert.Equal(t, PullRequestStateOpen, updated.State)
+	})
+
+	t.Run("reviews and statuses", func(t *testing.T) {
+		reviews, err := cli.ListPullRequestReviews(ctx, repo, pr.Number)
+		require.NoError(t, err)
+		require.Len(t, reviews, 2)
+		assert.Equal(t, ReviewStateRequestChanges, reviews[0].State)
+		assert.Equal(t, ReviewStateApproved, reviews[1].State)
+
+		status, err := cli.GetCombinedStatus(ctx, repo, pr.Head.SHA)
+		require.NoError(t, err)
+		assert.Equal(t, CommitStatusFailure, status.State)
+		assert.Len(t, status.Statuses, 2)
+	})
+
+	t.Run("comment", func(t *testing.T) {
+		require.NoError(t, cli.CreateComment(ctx, repo, pr.Number, "Hello from Sourcegraph"))
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		require.NoError(t, cli.MergePullRequest(ctx, repo, pr.Number, MergePullRequestInput{Style: MergeStyleSquash}))
+
+		merged, err := cli.GetPullRequest(ctx, repo, pr.Number)
+		require.NoError(t, err)
+		assert.True(t, merged.HasMerged)
+		assert.Equal(t, PullRequestStateClosed, merged.State)
+
+		err = cli.MergePullRequest(ctx, repo, pr.Number, MergePullRequestInput{Style: MergeStyleMerge})
+		assert.True(t, IsNotMergeable(err), "expected not mergeable error, got %v", err)
+	})
+}
+
+func TestWorkInProgress(t *testing.T) {
+	for title, want := range map[string]bool{
+		"WIP: Add README":   true,
+		"wip: Add README":   true,
+		"[WIP] Add README":  true,
+		"Add README":        false,
+		"Add WIP: handling": false,
+	} {
+		assert.Equal(t, want, IsWorkInProgress(title), title)
+	}
+
+	assert.Equal(t, "WIP: Add README", SetWorkInProgress("Add README"))
+	assert.Equal(t, "WIP: Add README", SetWorkInProgress("[WIP] Add README"))
+	assert.Equal(t, "Add README", UnsetWorkInProgress("WIP: Add README"))
+	assert.Equal(t, "Add README", UnsetWorkInProgress("Add README"))
+}
@@ -0,0 +1,87 @@
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ListOrgRepos lists the repositories of the given organization visible to the
+// authenticated user. It returns true if there is a next page.
+func (c *Client) ListOrgRepos(ctx context.Context, org string, page int) ([]*Repository, bool, error) {
+	var repos []*Repository
+	hasNext, err := c.list(ctx, "orgs/"+url.PathEscape(org)+"/repos", nil, page, &repos)
+	return repos, hasNext, err
+}
+
+// ListAuthenticatedUserRepos lists the repositories the authenticated user
+// owns, collaborates on, or can access through an organization. It returns
+// true if there is a next page.
+func (c *Client) ListAuthenticatedUserRepos(ctx context.Context, page int) ([]*Repository, bool, error) {
+	var repos []*Repository
+	hasNext, err := c.list(ctx, "user/repos", nil, page, &repos)
+	return repos, hasNext, err
+}
+
+// searchReposResponse is the envelope returned by the repository search
+// endpoint.
+type searchReposResponse struct {
+	OK   bool          `json:"ok"`
+	Data []*Repository `json:"data"`
+}
+
+// SearchRepos searches the repositories visible to the authenticated user by
+// keyword. An empty query matches all repositories. It returns true if there
+// is a next page.
+func (c *Client) SearchRepos(ctx context.Context, query string, page int) ([]*Repository, bool, error) {
+	qs := make(url.Values)
+	if query != "" {
+		qs.Set("q", query)
+	}
+
+	var resp searchReposResponse
+	hasNext, err := c.list(ctx, "repos/search", qs, page, &resp)
+	return resp.Data, hasNext, err
+}
+
+// GetRepo returns the repository with the given owner and name.
+func (c *Client) GetRepo(ctx context.Context, owner, name string) (*Repository, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, repoPath(owner, name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if _, err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// ForkRepositoryInput is the input to ForkRepository.
+type ForkRepositoryInput struct {
+	// Organization is the organization to create the fork in. If empty, the
+	// fork is created in the namespace of the authenticated user.
+	Organization string `json:"organization,omitempty"`
+	// Name is the name of the fork. If empty, the name of the original
+	// repository is used.
+	Name string `json:"name,omitempty"`
+}
+
+// ForkRepository forks the given repository.
+func (c *Client) ForkRepository(ctx context.Context, repo *Repository, input ForkRepositoryInput) (*Repository, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPost, repoPath(repo.Owner.Login, repo.Name)+"/forks", input)
+	if err != nil {
+		return nil, err
+	}
+
+	var fork Repository
+	if _, err := c.do(ctx, req, &fork); err != nil {
+		return nil, err
+	}
+	return &fork, nil
+}
+
+func repoPath(owner, name string) string {
+	return "repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
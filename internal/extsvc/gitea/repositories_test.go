@@ -0,0 +1,128 @@
+package gitea
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+)
+
+func TestClient_ListOrgRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "ListOrgRepos", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	var repos []*Repository
+	for page := 1; ; page++ {
+		rs, hasNext, err := cli.ListOrgRepos(ctx, "sourcegraph-testing", page)
+		require.NoError(t, err)
+		repos = append(repos, rs...)
+		if !hasNext {
+			break
+		}
+	}
+
+	testutil.AssertGolden(t, "testdata/golden/ListOrgRepos.json", *update, repos)
+}
+
+func TestClient_ListAuthenticatedUserRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "ListAuthenticatedUserRepos", *update)
+	defer save()
+
+	repos, hasNext, err := cli.ListAuthenticatedUserRepos(context.Background(), 1)
+	require.NoError(t, err)
+	assert.True(t, hasNext)
+	assert.Len(t, repos, 2)
+}
+
+func TestClient_WithSudo(t *testing.T) {
+	cli, save := NewTestClient(t, "WithSudo", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	user, err := cli.WithSudo("alice").GetAuthenticatedUser(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Login)
+
+	repos, hasNext, err := cli.WithSudo("alice").ListAuthenticatedUserRepos(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, hasNext)
+
+	var names []string
+	for _, r := range repos {
+		names = append(names, r.FullName)
+	}
+	assert.Equal(t, []string{"sourcegraph-testing/automation-testing", "sourcegraph-testing/private-repo"}, names)
+}
+
+func TestClient_SearchRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "SearchRepos", *update)
+	defer save()
+
+	repos, hasNext, err := cli.SearchRepos(context.Background(), "tmux", 1)
+	require.NoError(t, err)
+	assert.False(t, hasNext)
+
+	var names []string
+	for _, r := range repos {
+		names = append(names, r.FullName)
+	}
+	assert.Equal(t, []string{"upstream/tmux", "sourcegraph-testing/tmux"}, names)
+}
+
+func TestClient_GetRepo(t *testing.T) {
+	cli, save := NewTestClient(t, "GetRepo", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	repo, err := cli.GetRepo(ctx, "sourcegraph-testing", "tmux")
+	require.NoError(t, err)
+	testutil.AssertGolden(t, "testdata/golden/GetRepo.json", *update, repo)
+
+	_, err = cli.GetRepo(ctx, "sourcegraph-testing", "does-not-exist")
+	assert.True(t, errcode.IsNotFound(err), "expected not found error, got %v", err)
+}
+
+func TestClient_ForkRepository(t *testing.T) {
+	cli, save := NewTestClient(t, "ForkRepository", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	repo, err := cli.GetRepo(ctx, "sourcegraph-testing", "automation-testing")
+	require.NoError(t, err)
+
+	fork, err := cli.ForkRepository(ctx, repo, ForkRepositoryInput{Name: "sourcegraph-testing-automation-testing"})
+	require.NoError(t, err)
+	assert.True(t, fork.Fork)
+	assert.Equal(t, "sourcegraph-bot/sourcegraph-testing-automation-testing", fork.FullName)
+	require.NotNil(t, fork.Parent)
+	assert.Equal(t, repo.ID, fork.Parent.ID)
+}
+
+func TestRepository_Restricted(t *testing.T) {
+	owner := func(v UserVisibility) *User { return &User{Visibility: v} }
+
+	for name, tc := range map[string]struct {
+		repo Repository
+		want bool
+	}{
+		"public":                {repo: Repository{Owner: owner(UserVisibilityPublic)}, want: false},
+		"public without owner":  {repo: Repository{}, want: false},
+		"private":               {repo: Repository{Private: true, Owner: owner(UserVisibilityPublic)}, want: true},
+		"internal":              {repo: Repository{Internal: true, Owner: owner(UserVisibilityPublic)}, want: true},
+		"public of limited org": {repo: Repository{Owner: owner(UserVisibilityLimited)}, want: true},
+		"public of private org": {repo: Repository{Owner: owner(UserVisibilityPrivate)}, want: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.repo.Restricted())
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package gitea
+
+import (
+	"strings"
+	"time"
+)
+
+// User is a Gitea user or organization.
+type User struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+	IsAdmin   bool   `json:"is_admin"`
+
+	// Visibility is one of the UserVisibility values. For organizations it
+	// also determines who can see their public repositories.
+	Visibility UserVisibility `json:"visibility"`
+}
+
+// UserVisibility is the visibility of a Gitea user or organization.
+type UserVisibility string
+
+const (
+	UserVisibilityPublic UserVisibility = "public"
+	// UserVisibilityLimited users are only visible to signed-in users.
+	UserVisibilityLimited UserVisibility = "limited"
+	// UserVisibilityPrivate users are only visible to their members.
+	UserVisibilityPrivate UserVisibility = "private"
+)
+
+// Permission describes the permissions the authenticated user has on a
+// repository.
+type Permission struct {
+	Admin bool `json:"admin"`
+	Push  bool `json:"push"`
+	Pull  bool `json:"pull"`
+}
+
+// Repository is a Gitea repository.
+type Repository struct {
+	ID            int64       `json:"id"`
+	Owner         *User       `json:"owner"`
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Description   string      `json:"description"`
+	Empty         bool        `json:"empty"`
+	Private       bool        `json:"private"`
+	Internal      bool        `json:"internal"`
+	Fork          bool        `json:"fork"`
+	Parent        *Repository `json:"parent,omitempty"`
+	Mirror        bool        `json:"mirror"`
+	Archived      bool        `json:"archived"`
+	HTMLURL       string      `json:"html_url"`
+	SSHURL        string      `json:"ssh_url"`
+	CloneURL      string      `json:"clone_url"`
+	DefaultBranch string      `json:"default_branch"`
+	StarsCount    int         `json:"stars_count"`
+	Permissions   *Permission `json:"permissions,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// Restricted reports whether the repository can't be read anonymously. This
+// is the case for private repositories, but also for "internal" repositories
+// and public repositories of limited or private owners, which are only
+// visible to signed-in users or members of the owner.
+func (r *Repository) Restricted() bool {
+	if r.Private || r.Internal {
+		return true
+	}
+	return r.Owner != nil && (r.Owner.Visibility == UserVisibilityLimited || r.Owner.Visibility == UserVisibilityPrivate)
+}
+
+// PullRequestState is the state of a Gitea pull request. Merged pull
+// requests are closed and have HasMerged set.
+type PullRequestState string
+
+const (
+	PullRequestStateOpen   PullRequestState = "open"
+	PullRequestStateClosed PullRequestState = "closed"
+)
+
+// PullRequestBranch describes the base or head of a pull request.
+type PullRequestBranch struct {
+	Label      string      `json:"label"`
+	Ref        string      `json:"ref"`
+	SHA        string      `json:"sha"`
+	RepoID     int64       `json:"repo_id"`
+	Repository *Repository `json:"repo"`
+}
+
+// PullRequest is a Gitea pull request.
+type PullRequest struct {
+	ID        int64              `json:"id"`
+	Number    int64              `json:"number"`
+	User      *User              `json:"user"`
+	Title     string             `json:"title"`
+	Body      string             `json:"body"`
+	State     PullRequestState   `json:"state"`
+	HTMLURL   string             `json:"html_url"`
+	Mergeable bool               `json:"mergeable"`
+	HasMerged bool               `json:"merged"`
+	MergedAt  *time.Time         `json:"merged_at"`
+	Base      *PullRequestBranch `json:"base"`
+	Head      *PullRequestBranch `json:"head"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	ClosedAt  *time.Time         `json:"closed_at"`
+}
+
+// workInProgressPrefixes are the title prefixes Gitea uses to mark a pull
+// request as work in progress in its default configuration.
+var workInProgressPrefixes = []string{"WIP:", "[WIP]"}
+
+// IsWorkInProgress returns true if the title marks the pull request as work
+// in progress, which is how Gitea represents draft pull requests.
+func IsWorkInProgress(title string) bool {
+	for _, prefix := range workInProgressPrefixes {
+		if len(title) >= len(prefix) && strings.EqualFold(title[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetWorkInProgress ensures the title is prefixed with "WIP: ".
+func SetWorkInProgress(title string) string {
+	return "WIP: " + UnsetWorkInProgress(title)
+}
+
+// UnsetWorkInProgress removes any work in progress prefix from the title.
+func UnsetWorkInProgress(title string) string {
+	for _, prefix := range workInProgressPrefixes {
+		if len(title) >= len(prefix) && strings.EqualFold(title[:len(prefix)], prefix) {
+			return strings.TrimSpace(title[len(prefix):])
+		}
+	}
+	return title
+}
+
+// ReviewState is the state of a pull request review.
+type ReviewState string
+
+const (
+	ReviewStateApproved       ReviewState = "APPROVED"
+	ReviewStatePending        ReviewState = "PENDING"
+	ReviewStateComment        ReviewState = "COMMENT"
+	ReviewStateRequestChanges ReviewState = "REQUEST_CHANGES"
+	ReviewStateRequestReview  ReviewState = "REQUEST_REVIEW"
+)
+
+// PullReview is a review submitted on a pull request.
+type PullReview struct {
+	ID          int64       `json:"id"`
+	Reviewer    *User       `json:"user"`
+	State       ReviewState `json:"state"`
+	Body        string      `json:"body"`
+	CommitID    string      `json:"commit_id"`
+	Stale       bool        `json:"stale"`
+	Dismissed   bool        `json:"dismissed"`
+	SubmittedAt time.Time   `json:"submitted_at"`
+}
+
+// CommitStatusState is the state of a commit status.
+type CommitStatusState string
+
+const (
+	CommitStatusPending CommitStatusState = "pending"
+	CommitStatusSuccess CommitStatusState = "success"
+	CommitStatusError   CommitStatusState = "error"
+	CommitStatusFailure CommitStatusState = "failure"
+	CommitStatusWarning CommitStatusState = "warning"
+)
+
+// CommitStatus is a status reported by a CI system for a commit.
+type CommitStatus struct {
+	ID          int64             `json:"id"`
+	State       CommitStatusState `json:"status"`
+	Context     string            `json:"context"`
+	Description string            `json:"description"`
+	TargetURL   string            `json:"target_url"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// CombinedStatus is the combined state of all the statuses of a commit.
+type CombinedStatus struct {
+	State    CommitStatusState `json:"state"`
+	SHA      string            `json:"sha"`
+	Statuses []*CommitStatus   `json:"statuses"`
+}
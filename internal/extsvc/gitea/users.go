@@ -0,0 +1,59 @@
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// GetAuthenticatedUser returns the user the client is authenticated as.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*User, error) {
+	return c.getUser(ctx, "user")
+}
+
+// GetUser returns the user with the given username.
+func (c *Client) GetUser(ctx context.Context, username string) (*User, error) {
+	return c.getUser(ctx, "users/"+url.PathEscape(username))
+}
+
+// searchUsersResponse is the envelope returned by the user search endpoint.
+type searchUsersResponse struct {
+	OK   bool    `json:"ok"`
+	Data []*User `json:"data"`
+}
+
+// GetUserByID returns the user with the given ID. Unlike usernames, IDs never
+// change, so this is how users linked to Sourcegraph accounts are resolved.
+func (c *Client) GetUserByID(ctx context.Context, id int64) (*User, error) {
+	qs := url.Values{"uid": []string{strconv.FormatInt(id, 10)}}
+	u := url.URL{Path: "users/search", RawQuery: qs.Encode()}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp searchUsersResponse
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, errors.WithStack(&httpError{URL: req.URL, StatusCode: http.StatusNotFound})
+	}
+	return resp.Data[0], nil
+}
+
+func (c *Client) getUser(ctx context.Context, path string) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if _, err := c.do(ctx, req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -29,6 +29,13 @@ type PipelineEvent struct {
 // PushEvent represents a push to a repository.
 // https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#push-events
 type PushEvent struct {
+	// Ref is the full name of the pushed ref, such as refs/heads/main.
+	Ref string `json:"ref"`
+	// Before and After are the commits the ref pointed to before and after the
+	// push. Before is all zeros if the push created the ref, and After is all zeros
+	// if the push deleted the ref.
+	Before     string `json:"before"`
+	After      string `json:"after"`
 	Repository struct {
 		GitSSHURL string `json:"git_ssh_url,omitempty"`
 	} `json:"repository"`
@@ -0,0 +1,139 @@
+// Package nuget is a client for the NuGet V3 server API described in
+// https://learn.microsoft.com/en-us/nuget/api/overview.
+package nuget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// packageBaseAddressType is the type of the service index resource that serves the
+// .nupkg files of packages.
+const packageBaseAddressType = "PackageBaseAddress/3.0.0"
+
+type Client struct {
+	// The URL of the service index of the package source, for example
+	// https://api.nuget.org/v3/index.json.
+	serviceIndexURL string
+
+	uncachedClient httpcli.Doer
+	cachedClient   httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+}
+
+func NewClient(urn string, serviceIndexURL string, httpfactory *httpcli.Factory) (*Client, error) {
+	uncached, err := httpfactory.Doer(httpcli.NewCachedTransportOpt(httpcli.NoopCache{}, false))
+	if err != nil {
+		return nil, err
+	}
+	cached, err := httpfactory.Doer()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		serviceIndexURL: serviceIndexURL,
+		uncachedClient:  uncached,
+		cachedClient:    cached,
+		limiter:         ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// GetPackageContents returns the contents of the .nupkg file of the given package
+// version, which is a zip archive.
+func (c *Client) GetPackageContents(ctx context.Context, dep reposource.VersionedPackage) (io.ReadCloser, error) {
+	baseURL, err := c.packageBaseAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Package IDs and versions are case-insensitive, and the package content
+	// resource expects both in lowercase.
+	id := strings.ToLower(string(dep.PackageSyntax()))
+	version := strings.ToLower(dep.PackageVersion())
+	url := fmt.Sprintf("%s/%s/%s/%s.%s.nupkg", strings.TrimSuffix(baseURL, "/"), id, version, id, version)
+
+	return c.get(ctx, c.uncachedClient, url)
+}
+
+type serviceIndex struct {
+	Resources []struct {
+		ID   string `json:"@id"`
+		Type string `json:"@type"`
+	} `json:"resources"`
+}
+
+// packageBaseAddress returns the base URL of the package content resource of the
+// package source.
+func (c *Client) packageBaseAddress(ctx context.Context) (string, error) {
+	body, err := c.get(ctx, c.cachedClient, c.serviceIndexURL)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get NuGet service index")
+	}
+	defer body.Close()
+
+	var index serviceIndex
+	if err := json.NewDecoder(body).Decode(&index); err != nil {
+		return "", errors.Wrap(err, "failed to decode NuGet service index")
+	}
+	for _, resource := range index.Resources {
+		if resource.Type == packageBaseAddressType {
+			return resource.ID, nil
+		}
+	}
+	return "", errors.Newf("NuGet service index %s has no %s resource", c.serviceIndexURL, packageBaseAddressType)
+}
+
+func (c *Client) get(ctx context.Context, doer httpcli.Doer, url string) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-nuget-syncer (sourcegraph.com)")
+
+	return c.do(doer, req)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(doer httpcli.Doer, req *http.Request) (io.ReadCloser, error) {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		bs, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			bs = []byte(errors.Wrap(err, "failed to read body").Error())
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+	return resp.Body, nil
+}
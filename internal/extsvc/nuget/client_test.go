@@ -0,0 +1,84 @@
+package nuget
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func TestGetPackageContents(t *testing.T) {
+	var nupkg bytes.Buffer
+	zw := zip.NewWriter(&nupkg)
+	w, err := zw.Create("lib/net6.0/Newtonsoft.Json.xml")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("<doc/>"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/v3/index.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"version": "3.0.0",
+			"resources": [
+				{"@id": "%[1]s/query", "@type": "SearchQueryService"},
+				{"@id": "%[1]s/v3-flatcontainer/", "@type": "PackageBaseAddress/3.0.0"}
+			]
+		}`, srv.URL)
+	})
+	mux.HandleFunc("/v3-flatcontainer/newtonsoft.json/13.0.3/newtonsoft.json.13.0.3.nupkg", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(nupkg.Bytes())
+	})
+
+	client, err := NewClient("nuget_urn", srv.URL+"/v3/index.json", httpcli.NewFactory(httpcli.NewMiddleware()))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		dep, err := reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@13.0.3")
+		require.NoError(t, err)
+		body, err := client.GetPackageContents(ctx, dep)
+		require.NoError(t, err)
+		defer body.Close()
+
+		contents, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.Equal(t, nupkg.Bytes(), contents)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dep, err := reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@0.0.0")
+		require.NoError(t, err)
+		_, err = client.GetPackageContents(ctx, dep)
+		require.True(t, errcode.IsNotFound(err), "got %v", err)
+	})
+}
+
+func TestGetPackageContents_NoPackageBaseAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version": "3.0.0", "resources": []}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("nuget_urn", srv.URL, httpcli.NewFactory(httpcli.NewMiddleware()))
+	require.NoError(t, err)
+
+	dep, err := reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@13.0.3")
+	require.NoError(t, err)
+	_, err = client.GetPackageContents(context.Background(), dep)
+	require.ErrorContains(t, err, "has no PackageBaseAddress/3.0.0 resource")
+}
@@ -0,0 +1,204 @@
+// Package packagist is a client for Composer repositories such as
+// https://repo.packagist.org, using the metadata API described in
+// https://packagist.org/apidoc#get-package-metadata-v2.
+package packagist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type Client struct {
+	// The URL of the Composer repository, for example https://repo.packagist.org.
+	repositoryURL string
+
+	uncachedClient httpcli.Doer
+	cachedClient   httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+}
+
+func NewClient(urn string, repositoryURL string, httpfactory *httpcli.Factory) (*Client, error) {
+	uncached, err := httpfactory.Doer(httpcli.NewCachedTransportOpt(httpcli.NoopCache{}, false))
+	if err != nil {
+		return nil, err
+	}
+	cached, err := httpfactory.Doer()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		repositoryURL:  repositoryURL,
+		uncachedClient: uncached,
+		cachedClient:   cached,
+		limiter:        ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// Version is a released version of a package.
+type Version struct {
+	Version string `json:"version"`
+	Dist    *Dist  `json:"dist"`
+}
+
+// Dist is the archive that a version of a package is distributed as.
+type Dist struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Versions returns the released versions of the given package, newest first.
+func (c *Client) Versions(ctx context.Context, name reposource.PackageName) ([]*Version, error) {
+	url := fmt.Sprintf("%s/p2/%s.json", strings.TrimSuffix(c.repositoryURL, "/"), strings.ToLower(string(name)))
+	body, err := c.get(ctx, c.cachedClient, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var metadata struct {
+		Packages map[string][]map[string]json.RawMessage `json:"packages"`
+		Minified string                                  `json:"minified"`
+	}
+	if err := json.NewDecoder(body).Decode(&metadata); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode metadata of PHP package %q", name)
+	}
+
+	entries := metadata.Packages[strings.ToLower(string(name))]
+	if metadata.Minified != "" {
+		entries = expand(entries)
+	}
+
+	versions := make([]*Version, 0, len(entries))
+	for _, entry := range entries {
+		var v Version
+		if err := json.Unmarshal(marshal(entry), &v); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode version of PHP package %q", name)
+		}
+		versions = append(versions, &v)
+	}
+	return versions, nil
+}
+
+// expand restores the versions of a package in minified metadata, in which each
+// version only contains the fields that differ from the previous one, and fields
+// that are removed have the value "__unset".
+func expand(minified []map[string]json.RawMessage) []map[string]json.RawMessage {
+	expanded := make([]map[string]json.RawMessage, 0, len(minified))
+	var previous map[string]json.RawMessage
+	for _, entry := range minified {
+		current := make(map[string]json.RawMessage, len(previous)+len(entry))
+		for k, v := range previous {
+			current[k] = v
+		}
+		for k, v := range entry {
+			if string(v) == `"__unset"` {
+				delete(current, k)
+			} else {
+				current[k] = v
+			}
+		}
+		expanded = append(expanded, current)
+		previous = current
+	}
+	return expanded
+}
+
+func marshal(entry map[string]json.RawMessage) []byte {
+	// Marshaling a map of raw messages cannot fail.
+	b, _ := json.Marshal(entry)
+	return b
+}
+
+// GetPackageContents returns the contents of the zip archive of the given package
+// version.
+func (c *Client) GetPackageContents(ctx context.Context, dep reposource.VersionedPackage) (io.ReadCloser, error) {
+	versions, err := c.Versions(ctx, dep.PackageSyntax())
+	if err != nil {
+		return nil, err
+	}
+
+	v := FindVersion(versions, dep.PackageVersion())
+	if v == nil {
+		return nil, &Error{path: string(dep.PackageSyntax()), code: http.StatusNotFound, message: fmt.Sprintf("version %q not found", dep.PackageVersion())}
+	}
+	if v.Dist == nil || v.Dist.URL == "" {
+		return nil, errors.Newf("version %q of PHP package %q has no dist archive", v.Version, dep.PackageSyntax())
+	}
+	if v.Dist.Type != "zip" {
+		return nil, errors.Newf("unsupported dist archive type %q of PHP package %q", v.Dist.Type, dep.PackageSyntax())
+	}
+
+	return c.get(ctx, c.uncachedClient, v.Dist.URL)
+}
+
+// FindVersion returns the version with the given name, which tags of PHP packages
+// often prefix with a 'v' while dependencies don't, or nil if there is none.
+func FindVersion(versions []*Version, version string) *Version {
+	for _, v := range versions {
+		if v.Version == version {
+			return v
+		}
+	}
+	version = strings.TrimPrefix(version, "v")
+	for _, v := range versions {
+		if strings.TrimPrefix(v.Version, "v") == version {
+			return v
+		}
+	}
+	return nil
+}
+
+func (c *Client) get(ctx context.Context, doer httpcli.Doer, url string) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-packagist-syncer (sourcegraph.com)")
+
+	return c.do(doer, req)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(doer httpcli.Doer, req *http.Request) (io.ReadCloser, error) {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		bs, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			bs = []byte(errors.Wrap(err, "failed to read body").Error())
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+	return resp.Body, nil
+}
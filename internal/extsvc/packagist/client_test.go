@@ -0,0 +1,126 @@
+package packagist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func TestClient(t *testing.T) {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	// Minified metadata, in which each version only lists the fields that changed.
+	mux.HandleFunc("/p2/monolog/monolog.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"minified": "composer/2.0",
+			"packages": {
+				"monolog/monolog": [
+					{
+						"name": "monolog/monolog",
+						"version": "3.4.0",
+						"license": ["MIT"],
+						"dist": {"type": "zip", "url": "%[1]s/dist/3.4.0.zip"}
+					},
+					{
+						"version": "3.3.1",
+						"dist": {"type": "zip", "url": "%[1]s/dist/3.3.1.zip"}
+					},
+					{
+						"version": "v1.0.0",
+						"license": "__unset",
+						"dist": {"type": "tar", "url": "%[1]s/dist/1.0.0.tar"}
+					}
+				]
+			}
+		}`, srv.URL)
+	})
+	mux.HandleFunc("/dist/3.3.1.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("zip"))
+	})
+
+	client, err := NewClient("packagist_urn", srv.URL+"/", httpcli.NewFactory(httpcli.NewMiddleware()))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("versions", func(t *testing.T) {
+		versions, err := client.Versions(ctx, "Monolog/Monolog")
+		require.NoError(t, err)
+		require.Equal(t, []*Version{
+			{Version: "3.4.0", Dist: &Dist{Type: "zip", URL: srv.URL + "/dist/3.4.0.zip"}},
+			{Version: "3.3.1", Dist: &Dist{Type: "zip", URL: srv.URL + "/dist/3.3.1.zip"}},
+			{Version: "v1.0.0", Dist: &Dist{Type: "tar", URL: srv.URL + "/dist/1.0.0.tar"}},
+		}, versions)
+
+		require.Equal(t, "v1.0.0", FindVersion(versions, "1.0.0").Version)
+		require.Equal(t, "3.3.1", FindVersion(versions, "v3.3.1").Version)
+		require.Nil(t, FindVersion(versions, "2.0.0"))
+	})
+
+	t.Run("package contents", func(t *testing.T) {
+		dep, err := reposource.ParsePHPVersionedPackage("monolog/monolog@3.3.1")
+		require.NoError(t, err)
+
+		body, err := client.GetPackageContents(ctx, dep)
+		require.NoError(t, err)
+		defer body.Close()
+
+		contents, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.Equal(t, "zip", string(contents))
+	})
+
+	t.Run("unsupported dist", func(t *testing.T) {
+		dep, err := reposource.ParsePHPVersionedPackage("monolog/monolog@1.0.0")
+		require.NoError(t, err)
+
+		_, err = client.GetPackageContents(ctx, dep)
+		require.ErrorContains(t, err, `unsupported dist archive type "tar"`)
+	})
+
+	t.Run("version not found", func(t *testing.T) {
+		dep, err := reposource.ParsePHPVersionedPackage("monolog/monolog@2.0.0")
+		require.NoError(t, err)
+
+		_, err = client.GetPackageContents(ctx, dep)
+		require.True(t, errcode.IsNotFound(err), "got %v", err)
+	})
+
+	t.Run("package not found", func(t *testing.T) {
+		_, err := client.Versions(ctx, "monolog/missing")
+		require.True(t, errcode.IsNotFound(err), "got %v", err)
+	})
+}
+
+func TestExpand(t *testing.T) {
+	ms := func(m map[string]string) map[string]json.RawMessage {
+		r := make(map[string]json.RawMessage, len(m))
+		for k, v := range m {
+			r[k] = json.RawMessage(v)
+		}
+		return r
+	}
+
+	got := expand([]map[string]json.RawMessage{
+		ms(map[string]string{"version": `"2.0.0"`, "license": `["MIT"]`}),
+		ms(map[string]string{"version": `"1.0.0"`, "license": `"__unset"`}),
+		ms(map[string]string{"version": `"0.1.0"`}),
+	})
+	require.Equal(t, []map[string]json.RawMessage{
+		ms(map[string]string{"version": `"2.0.0"`, "license": `["MIT"]`}),
+		ms(map[string]string{"version": `"1.0.0"`}),
+		ms(map[string]string{"version": `"0.1.0"`}),
+	}, got)
+}
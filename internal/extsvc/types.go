@@ -163,6 +163,10 @@ const (
 	// VariantGerrit is the (api.ExternalRepoSpec).ServiceType value for Gerrit projects.
 	VariantGerrit
 
+	// VariantGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea and Forgejo repositories. The
+	// ServiceID value is the base URL to the Gitea instance.
+	VariantGitea
+
 	// VariantGitHub is the (api.ExternalRepoSpec).ServiceType value for GitHub repositories. The ServiceID value
 	// is the base URL to the GitHub instance (https://github.com or the GitHub Enterprise URL).
 	VariantGitHub
@@ -207,6 +211,12 @@ const (
 	// VariantRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	VariantRubyPackages
 
+	// VariantNuGetPackages is the (api.ExternalRepoSpec).ServiceType value for NuGet packages (.NET ecosystem libraries).
+	VariantNuGetPackages
+
+	// VariantPHPPackages is the (api.ExternalRepoSpec).ServiceType value for PHP packages.
+	VariantPHPPackages
+
 	// VariantOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	VariantOther
 
@@ -228,16 +238,19 @@ var variantValuesMap = map[Variant]variantValues{
 	VariantBitbucketCloud:  {AsKind: "BITBUCKETCLOUD", AsType: "bitbucketCloud", ConfigPrototype: func() any { return &schema.BitbucketCloudConnection{} }, WebhookURLPath: "bitbucket-cloud-webhooks", SupportsRepoExclusion: true},
 	VariantBitbucketServer: {AsKind: "BITBUCKETSERVER", AsType: "bitbucketServer", ConfigPrototype: func() any { return &schema.BitbucketServerConnection{} }, WebhookURLPath: "bitbucket-server-webhooks", SupportsRepoExclusion: true},
 	VariantGerrit:          {AsKind: "GERRIT", AsType: "gerrit", ConfigPrototype: func() any { return &schema.GerritConnection{} }},
+	VariantGitea:           {AsKind: "GITEA", AsType: "gitea", ConfigPrototype: func() any { return &schema.GiteaConnection{} }, SupportsRepoExclusion: true},
 	VariantGitHub:          {AsKind: "GITHUB", AsType: "github", ConfigPrototype: func() any { return &schema.GitHubConnection{} }, WebhookURLPath: "github-webhooks", SupportsRepoExclusion: true},
 	VariantGitLab:          {AsKind: "GITLAB", AsType: "gitlab", ConfigPrototype: func() any { return &schema.GitLabConnection{} }, WebhookURLPath: "gitlab-webhooks", SupportsRepoExclusion: true},
 	VariantGitolite:        {AsKind: "GITOLITE", AsType: "gitolite", ConfigPrototype: func() any { return &schema.GitoliteConnection{} }, SupportsRepoExclusion: true},
 	VariantGoPackages:      {AsKind: "GOMODULES", AsType: "goModules", ConfigPrototype: func() any { return &schema.GoModulesConnection{} }},
 	VariantJVMPackages:     {AsKind: "JVMPACKAGES", AsType: "jvmPackages", ConfigPrototype: func() any { return &schema.JVMPackagesConnection{} }},
 	VariantNpmPackages:     {AsKind: "NPMPACKAGES", AsType: "npmPackages", ConfigPrototype: func() any { return &schema.NpmPackagesConnection{} }},
+	VariantNuGetPackages:   {AsKind: "NUGETPACKAGES", AsType: "nugetPackages", ConfigPrototype: func() any { return &schema.NuGetPackagesConnection{} }},
 	VariantOther:           {AsKind: "OTHER", AsType: "other", ConfigPrototype: func() any { return &schema.OtherExternalServiceConnection{} }},
 	VariantPagure:          {AsKind: "PAGURE", AsType: "pagure", ConfigPrototype: func() any { return &schema.PagureConnection{} }},
 	VariantPerforce:        {AsKind: "PERFORCE", AsType: "perforce", ConfigPrototype: func() any { return &schema.PerforceConnection{} }},
 	VariantPhabricator:     {AsKind: "PHABRICATOR", AsType: "phabricator", ConfigPrototype: func() any { return &schema.PhabricatorConnection{} }},
+	VariantPHPPackages:     {AsKind: "PHPPACKAGES", AsType: "phpPackages", ConfigPrototype: func() any { return &schema.PHPPackagesConnection{} }},
 	VariantPythonPackages:  {AsKind: "PYTHONPACKAGES", AsType: "pythonPackages", ConfigPrototype: func() any { return &schema.PythonPackagesConnection{} }},
 	VariantRubyPackages:    {AsKind: "RUBYPACKAGES", AsType: "rubyPackages", ConfigPrototype: func() any { return &schema.RubyPackagesConnection{} }},
 	VariantRustPackages:    {AsKind: "RUSTPACKAGES", AsType: "rustPackages", ConfigPrototype: func() any { return &schema.RustPackagesConnection{} }},
@@ -305,6 +318,8 @@ var (
 	KindPythonPackages  = VariantPythonPackages.AsKind()
 	KindRustPackages    = VariantRustPackages.AsKind()
 	KindRubyPackages    = VariantRubyPackages.AsKind()
+	KindNuGetPackages   = VariantNuGetPackages.AsKind()
+	KindPHPPackages     = VariantPHPPackages.AsKind()
 	KindNpmPackages     = VariantNpmPackages.AsKind()
 	KindPagure          = VariantPagure.AsKind()
 	KindAzureDevOps     = VariantAzureDevOps.AsKind()
@@ -373,6 +388,12 @@ var (
 	// TypeRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	TypeRubyPackages = VariantRubyPackages.AsType()
 
+	// TypeNuGetPackages is the (api.ExternalRepoSpec).ServiceType value for NuGet packages.
+	TypeNuGetPackages = VariantNuGetPackages.AsType()
+
+	// TypePHPPackages is the (api.ExternalRepoSpec).ServiceType value for PHP packages.
+	TypePHPPackages = VariantPHPPackages.AsType()
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = VariantOther.AsType()
 )
@@ -554,6 +575,8 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.GitLabConnection:
 		return c.Token, nil
+	case *schema.GiteaConnection:
+		return c.Token, nil
 	case *schema.AzureDevOpsConnection:
 		return c.Token, nil
 	case *schema.BitbucketServerConnection:
@@ -651,6 +674,13 @@ func GetLimitFromConfig(kind string, config any) (limit rate.Limit, isDefault bo
 			isDefault = false
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.GiteaConnection:
+		// 8/s is the default limit we enforce
+		limit = rate.Limit(8)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.NpmPackagesConnection:
 		limit = rate.Limit(6000 / 3600.0) // Same as the default in npm-packages.schema.json
 		if c != nil && c.RateLimit != nil {
@@ -688,6 +718,22 @@ func GetLimitFromConfig(kind string, config any) (limit rate.Limit, isDefault bo
 			isDefault = false
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.NuGetPackagesConnection:
+		// nuget.org doesn't document a rate limit for the package content API, which
+		// is served from a CDN.
+		limit = rate.Limit(36000.0 / 3600.0) // Same as default in nuget-packages.schema.json
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.PHPPackagesConnection:
+		// repo.packagist.org doesn't document a rate limit, but the package archives
+		// are usually downloaded from GitHub.
+		limit = rate.Limit(18000.0 / 3600.0) // Same as default in php-packages.schema.json
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	default:
 		return limit, isDefault, ErrRateLimitUnsupported{codehostKind: kind}
 	}
@@ -799,6 +845,8 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.GerritConnection:
 		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
@@ -824,6 +872,10 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		return VariantRustPackages.AsKind(), nil
 	case *schema.RubyPackagesConnection:
 		return VariantRubyPackages.AsKind(), nil
+	case *schema.NuGetPackagesConnection:
+		return VariantNuGetPackages.AsKind(), nil
+	case *schema.PHPPackagesConnection:
+		return VariantPHPPackages.AsKind(), nil
 	case *schema.PagureConnection:
 		rawURL = c.Url
 	case *schema.LocalGitExternalService:
@@ -37,6 +37,9 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantGerrit.ConfigPrototype().(*schema.GerritConnection); !ok {
 		t.Errorf("wrong type for Gerrit configuration prototype: %T", y)
 	}
+	if y, ok := VariantGitea.ConfigPrototype().(*schema.GiteaConnection); !ok {
+		t.Errorf("wrong type for Gitea configuration prototype: %T", y)
+	}
 	if y, ok := VariantGitHub.ConfigPrototype().(*schema.GitHubConnection); !ok {
 		t.Errorf("wrong type for GitHub configuration prototype: %T", y)
 	}
@@ -55,6 +58,9 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantNpmPackages.ConfigPrototype().(*schema.NpmPackagesConnection); !ok {
 		t.Errorf("wrong type for NPM Packages configuration prototype: %T", y)
 	}
+	if y, ok := VariantNuGetPackages.ConfigPrototype().(*schema.NuGetPackagesConnection); !ok {
+		t.Errorf("wrong type for NuGet Packages configuration prototype: %T", y)
+	}
 	if y, ok := VariantOther.ConfigPrototype().(*schema.OtherExternalServiceConnection); !ok {
 		t.Errorf("wrong type for Other configuration prototype: %T", y)
 	}
@@ -67,6 +73,9 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantPhabricator.ConfigPrototype().(*schema.PhabricatorConnection); !ok {
 		t.Errorf("wrong type for Phabricator configuration prototype: %T", y)
 	}
+	if y, ok := VariantPHPPackages.ConfigPrototype().(*schema.PHPPackagesConnection); !ok {
+		t.Errorf("wrong type for PHP Packages configuration prototype: %T", y)
+	}
 	if y, ok := VariantPythonPackages.ConfigPrototype().(*schema.PythonPackagesConnection); !ok {
 		t.Errorf("wrong type for Python Packages configuration prototype: %T", y)
 	}
@@ -94,6 +103,11 @@ func TestExtractToken(t *testing.T) {
 			kind:   KindGitHub,
 			want:   "deadbeef",
 		},
+		{
+			config: `{"token": "deadbeef"}`,
+			kind:   VariantGitea.AsKind(),
+			want:   "deadbeef",
+		},
 		{
 			config: `{"token": "deadbeef"}`,
 			kind:   KindAzureDevOps,
@@ -165,6 +179,13 @@ func TestExtractRateLimitConfig(t *testing.T) {
 			want:          2.0,
 			expectDefault: true,
 		},
+		{
+			name:          "Gitea default",
+			config:        `{"url": "https://example.com/"}`,
+			kind:          VariantGitea.AsKind(),
+			want:          8.0,
+			expectDefault: true,
+		},
 		{
 			name:          "GitLab non-default",
 			config:        `{"url": "https://example.com/", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
@@ -186,6 +207,13 @@ func TestExtractRateLimitConfig(t *testing.T) {
 			want:          1.0,
 			expectDefault: false,
 		},
+		{
+			name:          "Gitea non-default",
+			config:        `{"url": "https://example.com/", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
+			kind:          VariantGitea.AsKind(),
+			want:          1.0,
+			expectDefault: false,
+		},
 		{
 			name:   "Bitbucket Cloud non-default",
 			config: `{"url": "https://example.com/", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
@@ -335,6 +335,12 @@ type Client interface {
 	// update won't happen.
 	RequestRepoUpdate(context.Context, api.RepoName, time.Duration) (*protocol.RepoUpdateResponse, error)
 
+	// RequestRepoRefsUpdate is like RequestRepoUpdate, but only fetches the given
+	// refs, which were pushed to the code host. Gitserver falls back to fetching all
+	// refs if it can't fetch just the given ones. Unlike RequestRepoUpdate, the
+	// update is not debounced. Do not use this if you are not repo-updater.
+	RequestRepoRefsUpdate(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error)
+
 	// RequestRepoClone is an asynchronous request to clone a repository.
 	RequestRepoClone(context.Context, api.RepoName) (*protocol.RepoCloneResponse, error)
 
@@ -1170,11 +1176,21 @@ func (c *clientImplementor) gitCommand(repo api.RepoName, arg ...string) GitComm
 }
 
 func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.RepoName, since time.Duration) (*protocol.RepoUpdateResponse, error) {
-	req := &protocol.RepoUpdateRequest{
+	return c.requestRepoUpdate(ctx, &protocol.RepoUpdateRequest{
 		Repo:  repo,
 		Since: since,
-	}
+	})
+}
+
+func (c *clientImplementor) RequestRepoRefsUpdate(ctx context.Context, repo api.RepoName, refs []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+	return c.requestRepoUpdate(ctx, &protocol.RepoUpdateRequest{
+		Repo: repo,
+		Refs: refs,
+	})
+}
 
+func (c *clientImplementor) requestRepoUpdate(ctx context.Context, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
+	repo := req.Repo
 	if conf.IsGRPCEnabled(ctx) {
 		client, err := c.ClientForRepo(ctx, repo)
 		if err != nil {
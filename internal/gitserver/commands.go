@@ -1,6 +1,7 @@
 package gitserver
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/hex"
@@ -1402,6 +1403,9 @@ type blobReader struct {
 	name   string
 	cmd    GitCommand
 	rc     io.ReadCloser
+
+	// checkedLFS is true once we checked whether the blob is a Git LFS pointer.
+	checkedLFS bool
 }
 
 func (c *clientImplementor) blobOID(ctx context.Context, repo api.RepoName, commit api.CommitID, name string) (string, error) {
@@ -1470,6 +1474,13 @@ func (c *clientImplementor) newBlobReader(ctx context.Context, repo api.RepoName
 }
 
 func (br *blobReader) Read(p []byte) (int, error) {
+	if !br.checkedLFS {
+		br.checkedLFS = true
+		if err := br.resolveLFSPointer(); err != nil {
+			return 0, br.convertError(err)
+		}
+	}
+
 	n, err := br.rc.Read(p)
 	if err != nil {
 		return n, br.convertError(err)
@@ -1481,6 +1492,78 @@ func (br *blobReader) Close() error {
 	return br.rc.Close()
 }
 
+// lfsPointerPrefix is the prefix of every Git LFS pointer file, and
+// lfsPointerMaxSize is the size a blob must be below to be a pointer. See
+// https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md.
+const (
+	lfsPointerPrefix  = "version https://git-lfs.github.com/spec/v1"
+	lfsPointerMaxSize = 1024
+)
+
+// resolveLFSPointer replaces the content read by br with the content resolved
+// by the Git LFS filter of the repository if the blob is a Git LFS pointer in
+// a path tracked by LFS. gitserver only resolves pointers for repositories
+// with LFS enabled, for all others we read the pointer as before.
+func (br *blobReader) resolveLFSPointer() error {
+	buffered := bufio.NewReaderSize(br.rc, lfsPointerMaxSize)
+	br.rc = struct {
+		io.Reader
+		io.Closer
+	}{buffered, br.rc}
+
+	head, err := buffered.Peek(lfsPointerMaxSize)
+	if err == nil {
+		// Blobs of this size are never pointers.
+		return nil
+	}
+	if err != io.EOF {
+		return err
+	}
+	if !bytes.HasPrefix(head, []byte(lfsPointerPrefix)) {
+		return nil
+	}
+
+	if tracked, err := br.c.isLFSTracked(br.ctx, br.repo, br.name); err != nil || !tracked {
+		return err
+	}
+
+	var cmd GitCommand
+	if strings.Contains(br.name, "..") {
+		// See newBlobReader for why we don't pass commit:name here.
+		blobOID, err := br.c.blobOID(br.ctx, br.repo, br.commit, br.name)
+		if err != nil {
+			return err
+		}
+		cmd = br.c.gitCommand(br.repo, "cat-file", "--filters", "--path="+br.name, blobOID)
+	} else {
+		cmd = br.c.gitCommand(br.repo, "cat-file", "--filters", string(br.commit)+":"+br.name)
+	}
+	stdout, err := cmd.StdoutReader(br.ctx)
+	if err != nil {
+		return err
+	}
+
+	_ = br.rc.Close()
+	br.cmd = cmd
+	br.rc = stdout
+	return nil
+}
+
+// isLFSTracked returns whether name is tracked by Git LFS in repo. gitserver
+// only writes the LFS attributes of a repository to $GIT_DIR/info/attributes
+// if LFS is enabled for it, so this is false for all paths of repositories
+// without LFS, no matter what their .gitattributes say.
+func (c *clientImplementor) isLFSTracked(ctx context.Context, repo api.RepoName, name string) (bool, error) {
+	out, err := c.gitCommand(repo, "check-attr", "-z", "filter", "--", name).Output(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check LFS attributes")
+	}
+
+	// <path> NUL filter NUL <value> NUL
+	fields := bytes.Split(out, []byte{0})
+	return len(fields) >= 3 && string(fields[2]) == "lfs", nil
+}
+
 // convertError converts an error returned from 'git show' into a more appropriate error type
 func (br *blobReader) convertError(err error) error {
 	if err == nil {
@@ -2660,6 +2660,68 @@ func TestRead(t *testing.T) {
 	}
 }
 
+func TestRead_gitLFS(t *testing.T) {
+	const (
+		resolvedOID = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+		missingOID  = "0000000000000000000000000000000000000000000000000000000000000000"
+	)
+	pointer := func(oid string) string {
+		return "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 12\n"
+	}
+
+	// lfsStore stands in for the LFS object store gitserver fetches objects
+	// into, and smudge for git-lfs resolving pointers from it.
+	lfsStore := t.TempDir()
+	if err := os.WriteFile(filepath.Join(lfsStore, resolvedOID), []byte("hello world\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	smudge := filepath.Join(t.TempDir(), "smudge")
+	script := `#!/bin/sh
+pointer=$(cat)
+oid=$(echo "$pointer" | sed -n 's/^oid sha256://p')
+if [ -f "` + lfsStore + `/$oid" ]; then cat "` + lfsStore + `/$oid"; else echo "$pointer"; fi
+`
+	if err := os.WriteFile(smudge, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, dir := MakeGitRepositoryAndReturnDir(t,
+		"echo '*.sql filter=lfs diff=lfs merge=lfs -text' > .gitattributes",
+		"printf '"+pointer(resolvedOID)+"' > resolved.sql",
+		"printf '"+pointer(missingOID)+"' > missing.sql",
+		"printf '"+pointer(resolvedOID)+"' > untracked.txt",
+		"git add .",
+		"git commit -m commit1",
+		"git config filter.lfs.smudge "+smudge,
+	)
+	commitID := api.CommitID(GetHeadCommitFromGitDir(t, dir))
+
+	client := NewClient(database.NewMockDB())
+	ClientMocks.LocalGitserver = true
+	t.Cleanup(func() {
+		ResetClientMocks()
+	})
+
+	for file, want := range map[string]string{
+		// Pointers to objects in the LFS store are resolved.
+		"resolved.sql": "hello world\n",
+		// Pointers to objects which were not fetched stay as is.
+		"missing.sql": pointer(missingOID),
+		// Pointers in paths not tracked by LFS are ordinary files.
+		"untracked.txt": pointer(resolvedOID),
+	} {
+		t.Run(file, func(t *testing.T) {
+			data, err := client.ReadFile(context.Background(), nil, repo, commitID, file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, string(data)); diff != "" {
+				t.Errorf("unexpected content (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func runNewFileReaderTest(ctx context.Context, t *testing.T, repo api.RepoName, commitID api.CommitID, file string,
 	checker authz.SubRepoPermissionChecker, checkFn func(*testing.T, error, []byte)) {
 	t.Helper()
@@ -34,7 +34,8 @@ var (
 		"merge-base":   {"--"},
 		"show-ref":     {"--heads"},
 		"shortlog":     {"-s", "-n", "-e", "--no-merges", "--after", "--before"},
-		"cat-file":     {"-p"},
+		"cat-file":     {"-p", "--filters", "--path"},
+		"check-attr":   {"-z", "--"},
 		"lfs":          {},
 		"apply":        {"--cached", "-p0"},
 
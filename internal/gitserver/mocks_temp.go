@@ -160,6 +160,9 @@ type MockClient struct {
 	// RequestRepoCloneFunc is an instance of a mock function object
 	// controlling the behavior of the method RequestRepoClone.
 	RequestRepoCloneFunc *ClientRequestRepoCloneFunc
+	// RequestRepoRefsUpdateFunc is an instance of a mock function object
+	// controlling the behavior of the method RequestRepoRefsUpdate.
+	RequestRepoRefsUpdateFunc *ClientRequestRepoRefsUpdateFunc
 	// RequestRepoUpdateFunc is an instance of a mock function object
 	// controlling the behavior of the method RequestRepoUpdate.
 	RequestRepoUpdateFunc *ClientRequestRepoUpdateFunc
@@ -412,6 +415,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		RequestRepoRefsUpdateFunc: &ClientRequestRepoRefsUpdateFunc{
+			defaultHook: func(context.Context, api.RepoName, []protocol.RefUpdate) (r0 *protocol.RepoUpdateResponse, r1 error) {
+				return
+			},
+		},
 		RequestRepoUpdateFunc: &ClientRequestRepoUpdateFunc{
 			defaultHook: func(context.Context, api.RepoName, time.Duration) (r0 *protocol.RepoUpdateResponse, r1 error) {
 				return
@@ -679,6 +687,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.RequestRepoClone")
 			},
 		},
+		RequestRepoRefsUpdateFunc: &ClientRequestRepoRefsUpdateFunc{
+			defaultHook: func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+				panic("unexpected invocation of MockClient.RequestRepoRefsUpdate")
+			},
+		},
 		RequestRepoUpdateFunc: &ClientRequestRepoUpdateFunc{
 			defaultHook: func(context.Context, api.RepoName, time.Duration) (*protocol.RepoUpdateResponse, error) {
 				panic("unexpected invocation of MockClient.RequestRepoUpdate")
@@ -856,6 +869,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		RequestRepoCloneFunc: &ClientRequestRepoCloneFunc{
 			defaultHook: i.RequestRepoClone,
 		},
+		RequestRepoRefsUpdateFunc: &ClientRequestRepoRefsUpdateFunc{
+			defaultHook: i.RequestRepoRefsUpdate,
+		},
 		RequestRepoUpdateFunc: &ClientRequestRepoUpdateFunc{
 			defaultHook: i.RequestRepoUpdate,
 		},
@@ -5952,6 +5968,118 @@ func (c ClientRequestRepoCloneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientRequestRepoRefsUpdateFunc describes the behavior when the
+// RequestRepoRefsUpdate method of the parent MockClient instance is
+// invoked.
+type ClientRequestRepoRefsUpdateFunc struct {
+	defaultHook func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error)
+	hooks       []func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error)
+	history     []ClientRequestRepoRefsUpdateFuncCall
+	mutex       sync.Mutex
+}
+
+// RequestRepoRefsUpdate delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockClient) RequestRepoRefsUpdate(v0 context.Context, v1 api.RepoName, v2 []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+	r0, r1 := m.RequestRepoRefsUpdateFunc.nextHook()(v0, v1, v2)
+	m.RequestRepoRefsUpdateFunc.appendCall(ClientRequestRepoRefsUpdateFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// RequestRepoRefsUpdate method of the parent MockClient instance is invoked
+// and the hook queue is empty.
+func (f *ClientRequestRepoRefsUpdateFunc) SetDefaultHook(hook func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RequestRepoRefsUpdate method of the parent MockClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ClientRequestRepoRefsUpdateFunc) PushHook(hook func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientRequestRepoRefsUpdateFunc) SetDefaultReturn(r0 *protocol.RepoUpdateResponse, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientRequestRepoRefsUpdateFunc) PushReturn(r0 *protocol.RepoUpdateResponse, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientRequestRepoRefsUpdateFunc) nextHook() func(context.Context, api.RepoName, []protocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientRequestRepoRefsUpdateFunc) appendCall(r0 ClientRequestRepoRefsUpdateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientRequestRepoRefsUpdateFuncCall objects
+// describing the invocations of this function.
+func (f *ClientRequestRepoRefsUpdateFunc) History() []ClientRequestRepoRefsUpdateFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientRequestRepoRefsUpdateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientRequestRepoRefsUpdateFuncCall is an object that describes an
+// invocation of method RequestRepoRefsUpdate on an instance of MockClient.
+type ClientRequestRepoRefsUpdateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []protocol.RefUpdate
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *protocol.RepoUpdateResponse
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientRequestRepoRefsUpdateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientRequestRepoRefsUpdateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientRequestRepoUpdateFunc describes the behavior when the
 // RequestRepoUpdate method of the parent MockClient instance is invoked.
 type ClientRequestRepoUpdateFunc struct {
@@ -397,20 +397,100 @@ type RepoUpdateRequest struct {
 	Repo api.RepoName `json:"repo"`
 	// Since is a debounce interval for queries, used only with request-repo-update.
 	Since time.Duration `json:"since"`
+	// Refs, if set, are the refs that were pushed to the repository. Only these refs
+	// are fetched, unless gitserver has to fall back to fetching all refs.
+	Refs []RefUpdate `json:"refs,omitempty"`
 }
 
 func (r *RepoUpdateRequest) ToProto() *proto.RepoUpdateRequest {
+	var refs []*proto.RefUpdate
+	for _, ref := range r.Refs {
+		refs = append(refs, ref.ToProto())
+	}
 	return &proto.RepoUpdateRequest{
 		Repo:  string(r.Repo),
 		Since: durationpb.New(r.Since),
+		Refs:  refs,
 	}
 }
 
 func (r *RepoUpdateRequest) FromProto(p *proto.RepoUpdateRequest) {
+	var refs []RefUpdate
+	for _, ref := range p.GetRefs() {
+		var u RefUpdate
+		u.FromProto(ref)
+		refs = append(refs, u)
+	}
 	*r = RepoUpdateRequest{
 		Repo:  api.RepoName(p.GetRepo()),
 		Since: p.GetSince().AsDuration(),
+		Refs:  refs,
+	}
+}
+
+// RefUpdate is an update of a single ref, as reported by the push webhook of a code
+// host.
+type RefUpdate struct {
+	// Name is the full name of the ref, such as refs/heads/main.
+	Name string `json:"name"`
+	// Before is the commit the ref pointed to before the push. It is empty if the push
+	// created the ref.
+	Before api.CommitID `json:"before,omitempty"`
+	// After is the commit the ref points to after the push. It is empty if the push
+	// deleted the ref.
+	After api.CommitID `json:"after,omitempty"`
+	// Forced is whether the code host reported the push as a force-push.
+	Forced bool `json:"forced,omitempty"`
+}
+
+func (u *RefUpdate) ToProto() *proto.RefUpdate {
+	return &proto.RefUpdate{
+		Name:   u.Name,
+		Before: string(u.Before),
+		After:  string(u.After),
+		Forced: u.Forced,
+	}
+}
+
+func (u *RefUpdate) FromProto(p *proto.RefUpdate) {
+	*u = RefUpdate{
+		Name:   p.GetName(),
+		Before: api.CommitID(p.GetBefore()),
+		After:  api.CommitID(p.GetAfter()),
+		Forced: p.GetForced(),
+	}
+}
+
+// Deleted returns whether the push deleted the ref.
+func (u *RefUpdate) Deleted() bool {
+	return u.After == ""
+}
+
+// MergeRefUpdates returns the updates of refs in a followed by those in b. Two
+// updates of the same ref are merged into one from the first Before to the last
+// After. If either a or b is nil, which requests an update of all refs, it returns
+// nil.
+func MergeRefUpdates(a, b []RefUpdate) []RefUpdate {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	merged := append(make([]RefUpdate, 0, len(a)+len(b)), a...)
+	for _, u := range b {
+		found := false
+		for i := range merged {
+			if merged[i].Name == u.Name {
+				merged[i].After = u.After
+				merged[i].Forced = merged[i].Forced || u.Forced
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, u)
+		}
 	}
+	return merged
 }
 
 // RepoUpdateResponse returns meta information of the repo enqueued for update.
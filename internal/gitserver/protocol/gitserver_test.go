@@ -57,3 +57,38 @@ func TestCommitMatchProtoRoundtrip(t *testing.T) {
 	roundtripped := CommitMatchFromProto(protoReq)
 	require.Equal(t, req, roundtripped)
 }
+
+func TestRepoUpdateRequestProtoRoundtrip(t *testing.T) {
+	req := &RepoUpdateRequest{
+		Repo:  "github.com/sourcegraph/sourcegraph",
+		Since: time.Second,
+		Refs: []RefUpdate{
+			{Name: "refs/heads/main", Before: "4cd5ee68a039032fe8a613879fd73b242592ea6a", After: "e6dcba6b52fd349279a38d9fa25db894467de609"},
+			{Name: "refs/heads/feature", Before: "e6dcba6b52fd349279a38d9fa25db894467de609", After: "50e13d91bf02e318063fd0be4077257cec8b51b7", Forced: true},
+			{Name: "refs/tags/v1.0.0", After: "50e13d91bf02e318063fd0be4077257cec8b51b7"},
+		},
+	}
+
+	var roundtripped RepoUpdateRequest
+	roundtripped.FromProto(req.ToProto())
+	require.Equal(t, req, &roundtripped)
+}
+
+func TestMergeRefUpdates(t *testing.T) {
+	main1 := RefUpdate{Name: "refs/heads/main", Before: "a", After: "b"}
+	main2 := RefUpdate{Name: "refs/heads/main", Before: "b", After: "c", Forced: true}
+	feature := RefUpdate{Name: "refs/heads/feature", After: "d"}
+
+	require.Nil(t, MergeRefUpdates(nil, []RefUpdate{main1}))
+	require.Nil(t, MergeRefUpdates([]RefUpdate{main1}, nil))
+	require.Equal(t, []RefUpdate{main1, feature}, MergeRefUpdates([]RefUpdate{main1}, []RefUpdate{feature}))
+	require.Equal(t,
+		[]RefUpdate{{Name: "refs/heads/main", Before: "a", After: "c", Forced: true}, feature},
+		MergeRefUpdates([]RefUpdate{main1, feature}, []RefUpdate{main2}),
+	)
+
+	// The inputs are not modified.
+	a := []RefUpdate{main1}
+	MergeRefUpdates(a, []RefUpdate{main2})
+	require.Equal(t, []RefUpdate{main1}, a)
+}
@@ -24,7 +24,22 @@ func escapeUTF8AndSpaces(s string) string {
 	return escaper.Replace(strings.ToValidUTF8(s, "�"))
 }
 
+// FormatDiff formats rawDiff for display as a search result. Only the files and
+// hunks in highlights are included (or all of them if highlights is empty), and
+// the output is truncated to a few files, hunks and lines.
 func FormatDiff(rawDiff []*diff.FileDiff, highlights map[int]MatchedFileDiff) (string, result.Ranges) {
+	return formatDiff(rawDiff, highlights, maxFiles, maxHunksPerFile, maxLinesPerHunk)
+}
+
+// FormatFullDiff formats rawDiff in the same format as FormatDiff, but without
+// truncating the number of files, hunks or lines.
+func FormatFullDiff(rawDiff []*diff.FileDiff) string {
+	formatted, _ := formatDiff(rawDiff, nil, 0, 0, 0)
+	return formatted
+}
+
+// formatDiff implements FormatDiff. A limit of zero means no limit.
+func formatDiff(rawDiff []*diff.FileDiff, highlights map[int]MatchedFileDiff, maxFiles, maxHunksPerFile, maxLinesPerHunk int) (string, result.Ranges) {
 	var buf strings.Builder
 	var loc result.Location
 	var ranges result.Ranges
@@ -35,7 +50,7 @@ func FormatDiff(rawDiff []*diff.FileDiff, highlights map[int]MatchedFileDiff) (s
 		if !ok && len(highlights) > 0 {
 			continue
 		}
-		if fileCount >= maxFiles {
+		if maxFiles > 0 && fileCount >= maxFiles {
 			break
 		}
 		fileCount++
@@ -64,7 +79,7 @@ func FormatDiff(rawDiff []*diff.FileDiff, highlights map[int]MatchedFileDiff) (s
 			if !ok && len(filteredHighlights) > 0 {
 				continue
 			}
-			if hunkCount >= maxHunksPerFile {
+			if maxHunksPerFile > 0 && hunkCount >= maxHunksPerFile {
 				break
 			}
 			hunkCount++
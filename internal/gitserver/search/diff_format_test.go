@@ -84,4 +84,37 @@ index dbace57d5f..53357b4971 100644
 		require.Equal(t, expectedFormatted, formatted)
 		require.True(t, utf8.ValidString(formatted))
 	})
+	t.Run("full diff is not truncated", func(t *testing.T) {
+		rawDiff := `--- a.txt
++++ a.txt
+@@ -1,8 +1,8 @@
+-1
+-2
+-3
+-4
+-5
+-6
+-7
+-8
++one
++two
++three
++four
++five
++six
++seven
++eight
+`
+		parsedDiff, err := diff.NewMultiFileDiffReader(strings.NewReader(rawDiff)).ReadAllFiles()
+		require.NoError(t, err)
+
+		truncated, _ := FormatDiff(parsedDiff, nil)
+		require.Contains(t, truncated, "... +")
+
+		expectedFormatted := "a.txt a.txt\n" +
+			"@@ -1,8 +1,8 @@ \n" +
+			"-1\n-2\n-3\n-4\n-5\n-6\n-7\n-8\n" +
+			"+one\n+two\n+three\n+four\n+five\n+six\n+seven\n+eight\n"
+		require.Equal(t, expectedFormatted, FormatFullDiff(parsedDiff))
+	})
 }
@@ -0,0 +1,80 @@
+// Package alerts evaluates the alert rules defined on insight series after each recording
+// of a series and notifies the creator of a rule when it starts firing.
+package alerts
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+// Evaluate evaluates rule against the points of its series. The returned value is the one
+// compared with the threshold: the latest value of the series for VALUE rules, or its percent
+// change over the last rule.Intervals recordings for PERCENT_CHANGE rules.
+//
+// ok is false if the rule cannot be evaluated, because there are not enough data points or
+// because the percent change from a zero value is undefined.
+func Evaluate(rule *types.AlertRule, points []store.SeriesPoint) (value float64, firing, ok bool) {
+	values := seriesValues(points, rule.Capture)
+
+	switch rule.Kind {
+	case types.AlertOnValue:
+		if len(values) == 0 {
+			return 0, false, false
+		}
+		value = values[len(values)-1]
+
+	case types.AlertOnPercentChange:
+		intervals := rule.Intervals
+		if intervals <= 0 {
+			intervals = 1
+		}
+		if len(values) <= intervals {
+			return 0, false, false
+		}
+		latest, previous := values[len(values)-1], values[len(values)-1-intervals]
+		if previous == 0 {
+			return 0, false, false
+		}
+		value = (latest - previous) / previous * 100
+
+	default:
+		return 0, false, false
+	}
+
+	switch rule.Comparison {
+	case types.Above:
+		return value, value > rule.Threshold, true
+	case types.Below:
+		return value, value < rule.Threshold, true
+	default:
+		return 0, false, false
+	}
+}
+
+// seriesValues returns the values of the series in chronological order. Points of series
+// generated from capture groups are filtered down to the given capture, or summed for every
+// recording time if capture is nil.
+func seriesValues(points []store.SeriesPoint, capture *string) []float64 {
+	totals := make(map[time.Time]float64)
+	for _, point := range points {
+		if capture != nil && (point.Capture == nil || *point.Capture != *capture) {
+			continue
+		}
+		totals[point.Time] += point.Value
+	}
+
+	times := make([]time.Time, 0, len(totals))
+	for t := range totals {
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	values := make([]float64, 0, len(times))
+	for _, t := range times {
+		values = append(values, totals[t])
+	}
+	return values
+}
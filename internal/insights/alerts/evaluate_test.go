@@ -0,0 +1,118 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestEvaluate(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2023, 8, d, 0, 0, 0, 0, time.UTC)
+	}
+	point := func(d int, value float64, capture *string) store.SeriesPoint {
+		return store.SeriesPoint{SeriesID: "s", Time: day(d), Value: value, Capture: capture}
+	}
+	// Out of chronological order on purpose.
+	decreasing := []store.SeriesPoint{point(3, 80, nil), point(1, 100, nil), point(2, 90, nil)}
+	flat := []store.SeriesPoint{point(1, 100, nil), point(2, 100, nil), point(3, 100, nil)}
+	captures := []store.SeriesPoint{
+		point(1, 10, pointers.Ptr("1.0")), point(1, 5, pointers.Ptr("2.0")),
+		point(2, 12, pointers.Ptr("1.0")), point(2, 20, pointers.Ptr("2.0")),
+	}
+
+	for _, tc := range []struct {
+		name   string
+		rule   types.AlertRule
+		points []store.SeriesPoint
+		value  float64
+		firing bool
+		ok     bool
+	}{
+		{
+			name:   "value above",
+			rule:   types.AlertRule{Kind: types.AlertOnValue, Comparison: types.Above, Threshold: 50},
+			points: decreasing,
+			value:  80,
+			firing: true,
+			ok:     true,
+		},
+		{
+			name:   "value not below",
+			rule:   types.AlertRule{Kind: types.AlertOnValue, Comparison: types.Below, Threshold: 50},
+			points: decreasing,
+			value:  80,
+			ok:     true,
+		},
+		{
+			name:   "value without points",
+			rule:   types.AlertRule{Kind: types.AlertOnValue, Comparison: types.Above, Threshold: 50},
+			points: nil,
+		},
+		{
+			name:   "percent change over one interval",
+			rule:   types.AlertRule{Kind: types.AlertOnPercentChange, Comparison: types.Below, Threshold: -5, Intervals: 1},
+			points: decreasing,
+			value:  (80.0 - 90.0) / 90.0 * 100,
+			firing: true,
+			ok:     true,
+		},
+		{
+			name:   "percent change over two intervals",
+			rule:   types.AlertRule{Kind: types.AlertOnPercentChange, Comparison: types.Below, Threshold: -25, Intervals: 2},
+			points: decreasing,
+			value:  -20,
+			ok:     true,
+		},
+		{
+			name:   "series stopped decreasing",
+			rule:   types.AlertRule{Kind: types.AlertOnPercentChange, Comparison: types.Above, Threshold: -1, Intervals: 2},
+			points: flat,
+			value:  0,
+			firing: true,
+			ok:     true,
+		},
+		{
+			name:   "not enough intervals",
+			rule:   types.AlertRule{Kind: types.AlertOnPercentChange, Comparison: types.Above, Threshold: 0, Intervals: 3},
+			points: decreasing,
+		},
+		{
+			name:   "percent change from zero",
+			rule:   types.AlertRule{Kind: types.AlertOnPercentChange, Comparison: types.Above, Threshold: 0, Intervals: 1},
+			points: []store.SeriesPoint{point(1, 0, nil), point(2, 10, nil)},
+		},
+		{
+			name:   "captures are summed",
+			rule:   types.AlertRule{Kind: types.AlertOnValue, Comparison: types.Above, Threshold: 30},
+			points: captures,
+			value:  32,
+			firing: true,
+			ok:     true,
+		},
+		{
+			name:   "single capture",
+			rule:   types.AlertRule{Kind: types.AlertOnPercentChange, Comparison: types.Above, Threshold: 100, Capture: pointers.Ptr("2.0")},
+			points: captures,
+			value:  300,
+			firing: true,
+			ok:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			value, firing, ok := Evaluate(&tc.rule, tc.points)
+			if ok != tc.ok {
+				t.Fatalf("unexpected ok: want %v, got %v", tc.ok, ok)
+			}
+			if value != tc.value {
+				t.Errorf("unexpected value: want %v, got %v", tc.value, value)
+			}
+			if firing != tc.firing {
+				t.Errorf("unexpected firing: want %v, got %v", tc.firing, firing)
+			}
+		})
+	}
+}
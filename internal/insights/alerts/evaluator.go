@@ -0,0 +1,91 @@
+package alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Evaluator evaluates the alert rules of a series after it has been recorded.
+type Evaluator struct {
+	alertRuleStore store.AlertRuleStore
+	seriesStore    store.Interface
+	notifier       Notifier
+	logger         log.Logger
+	now            func() time.Time
+}
+
+func NewEvaluator(alertRuleStore store.AlertRuleStore, seriesStore store.Interface, notifier Notifier, logger log.Logger) *Evaluator {
+	return &Evaluator{
+		alertRuleStore: alertRuleStore,
+		seriesStore:    seriesStore,
+		notifier:       notifier,
+		logger:         logger,
+		now:            time.Now,
+	}
+}
+
+// EvaluateSeries evaluates every enabled alert rule of the series with the given unique
+// series ID and notifies the creators of rules that started firing. A failure to evaluate
+// or to notify for one rule does not prevent the evaluation of the others; all errors are
+// returned together.
+func (e *Evaluator) EvaluateSeries(ctx context.Context, seriesID string) error {
+	rules, err := e.alertRuleStore.GetAlertRules(ctx, store.AlertRuleQueryArgs{SeriesID: &seriesID, EnabledOnly: true})
+	if err != nil {
+		return errors.Wrap(err, "GetAlertRules")
+	}
+
+	var errs error
+	for _, rule := range rules {
+		if err := e.evaluateRule(ctx, rule); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "alert rule %d", rule.ID))
+		}
+	}
+	return errs
+}
+
+func (e *Evaluator) evaluateRule(ctx context.Context, rule *types.AlertRule) error {
+	// 🚨 SECURITY: The series is loaded with the permissions of the creator of the rule, so that
+	// notifications never reveal data from repositories they cannot see.
+	userCtx := actor.WithActor(ctx, actor.FromUser(rule.CreatedBy))
+	points, err := e.seriesStore.SeriesPoints(userCtx, store.SeriesPointsOpts{
+		SeriesID:             &rule.SeriesID,
+		ID:                   &rule.InsightSeriesID,
+		SupportsAugmentation: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "SeriesPoints")
+	}
+
+	value, firing, ok := Evaluate(rule, points)
+	if !ok {
+		e.logger.Debug("not enough data to evaluate alert rule", log.Int("ruleID", rule.ID), log.String("seriesID", rule.SeriesID))
+		return nil
+	}
+
+	now := e.now()
+	update := store.UpdateAlertRuleStateArgs{
+		ID:          rule.ID,
+		Firing:      firing,
+		LastValue:   &value,
+		EvaluatedAt: now,
+	}
+
+	var notifyErr error
+	// Only notify when the rule starts firing, not on every recording for which it holds.
+	if firing && !rule.Firing {
+		update.FiredAt = &now
+		notifyErr = e.notifier.Notify(ctx, Notification{Rule: rule, Value: value})
+	}
+
+	if err := e.alertRuleStore.UpdateAlertRuleState(ctx, update); err != nil {
+		return errors.Append(notifyErr, err)
+	}
+	return notifyErr
+}
@@ -0,0 +1,71 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type notifierFunc func(ctx context.Context, n Notification) error
+
+func (f notifierFunc) Notify(ctx context.Context, n Notification) error {
+	return f(ctx, n)
+}
+
+func TestEvaluateSeries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC)
+
+	rules := []*types.AlertRule{
+		// Starts firing, so it notifies.
+		{ID: 1, SeriesID: "s", InsightSeriesID: 7, CreatedBy: 42, Kind: types.AlertOnValue, Comparison: types.Above, Threshold: 5},
+		// Was already firing, so it does not notify again.
+		{ID: 2, SeriesID: "s", InsightSeriesID: 7, CreatedBy: 42, Kind: types.AlertOnValue, Comparison: types.Above, Threshold: 5, Firing: true},
+		// Stops firing.
+		{ID: 3, SeriesID: "s", InsightSeriesID: 7, CreatedBy: 42, Kind: types.AlertOnValue, Comparison: types.Below, Threshold: 5, Firing: true},
+	}
+
+	alertRuleStore := store.NewMockAlertRuleStore()
+	alertRuleStore.GetAlertRulesFunc.SetDefaultHook(func(_ context.Context, args store.AlertRuleQueryArgs) ([]*types.AlertRule, error) {
+		require.Equal(t, "s", *args.SeriesID)
+		require.True(t, args.EnabledOnly)
+		return rules, nil
+	})
+
+	seriesStore := store.NewMockInterface()
+	seriesStore.SeriesPointsFunc.SetDefaultHook(func(ctx context.Context, opts store.SeriesPointsOpts) ([]store.SeriesPoint, error) {
+		// The series must be loaded with the permissions of the creator of the rule.
+		require.Equal(t, int32(42), actor.FromContext(ctx).UID)
+		require.Equal(t, 7, *opts.ID)
+		return []store.SeriesPoint{{SeriesID: "s", Time: now, Value: 10}}, nil
+	})
+
+	var notified []int
+	notifier := notifierFunc(func(_ context.Context, n Notification) error {
+		notified = append(notified, n.Rule.ID)
+		return errors.New("webhook is down")
+	})
+
+	evaluator := NewEvaluator(alertRuleStore, seriesStore, notifier, logtest.Scoped(t))
+	evaluator.now = func() time.Time { return now }
+
+	err := evaluator.EvaluateSeries(ctx, "s")
+	require.ErrorContains(t, err, "webhook is down")
+	require.Equal(t, []int{1}, notified)
+
+	// The state of every rule is updated, even when a notification failed.
+	updates := alertRuleStore.UpdateAlertRuleStateFunc.History()
+	require.Len(t, updates, 3)
+	value := 10.0
+	require.Equal(t, store.UpdateAlertRuleStateArgs{ID: 1, Firing: true, LastValue: &value, EvaluatedAt: now, FiredAt: &now}, updates[0].Arg1)
+	require.Equal(t, store.UpdateAlertRuleStateArgs{ID: 2, Firing: true, LastValue: &value, EvaluatedAt: now}, updates[1].Arg1)
+	require.Equal(t, store.UpdateAlertRuleStateArgs{ID: 3, Firing: false, LastValue: &value, EvaluatedAt: now}, updates[2].Arg1)
+}
@@ -0,0 +1,174 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/slack-go/slack"
+
+	cmbackground "github.com/sourcegraph/sourcegraph/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Notification describes an alert rule that started firing.
+type Notification struct {
+	Rule *types.AlertRule
+	// Value is the value that crossed the threshold of the rule.
+	Value float64
+}
+
+// Notifier delivers the notifications of alert rules that started firing.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// NewNotifier returns a Notifier that delivers notifications by email, Slack and webhook
+// exactly like code monitor actions are.
+func NewNotifier(db database.DB) Notifier {
+	return &notifier{db: db, doer: httpcli.ExternalDoer}
+}
+
+type notifier struct {
+	db   database.DB
+	doer httpcli.Doer
+}
+
+// emailSource identifies insights alert emails in the email metrics.
+const emailSource = "code-insights-alert"
+
+func (n *notifier) Notify(ctx context.Context, notification Notification) error {
+	rule := notification.Rule
+	insightURL, err := insightURL(rule.ViewUniqueID)
+	if err != nil {
+		return err
+	}
+	condition := describeCondition(rule)
+	value := formatValue(rule, notification.Value)
+
+	var errs error
+	if rule.Email {
+		data := &templateData{
+			Description: rule.Description,
+			Condition:   condition,
+			Value:       value,
+			InsightURL:  insightURL,
+		}
+		if err := cmbackground.SendEmail(ctx, n.db, rule.CreatedBy, emailSource, emailTemplates, data); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "email"))
+		}
+	}
+	if rule.SlackWebhookURL != nil {
+		msg := slackMessage(rule.Description, condition, value, insightURL)
+		if err := cmbackground.PostSlackWebhook(ctx, n.doer, *rule.SlackWebhookURL, msg); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "Slack webhook"))
+		}
+	}
+	if rule.WebhookURL != nil {
+		payload := webhookPayload{
+			Description: rule.Description,
+			InsightURL:  insightURL,
+			SeriesID:    rule.SeriesID,
+			Capture:     rule.Capture,
+			Kind:        string(rule.Kind),
+			Comparison:  string(rule.Comparison),
+			Threshold:   rule.Threshold,
+			Intervals:   rule.Intervals,
+			Value:       notification.Value,
+		}
+		if err := cmbackground.PostWebhook(ctx, n.doer, *rule.WebhookURL, payload); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "webhook"))
+		}
+	}
+	return errs
+}
+
+type webhookPayload struct {
+	Description string  `json:"description"`
+	InsightURL  string  `json:"insightURL"`
+	SeriesID    string  `json:"seriesId"`
+	Capture     *string `json:"capture,omitempty"`
+	Kind        string  `json:"kind"`
+	Comparison  string  `json:"comparison"`
+	Threshold   float64 `json:"threshold"`
+	Intervals   int     `json:"intervals,omitempty"`
+	Value       float64 `json:"value"`
+}
+
+func slackMessage(description, condition, value, insightURL string) *slack.WebhookMessage {
+	text := fmt.Sprintf("Sourcegraph code insight alert *%s* is firing: %s (currently %s).\n<%s|View insight>", description, condition, value, insightURL)
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil),
+	}}}
+}
+
+type templateData struct {
+	Description string
+	Condition   string
+	Value       string
+	InsightURL  string
+}
+
+var emailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph code insight alert {{.Description}} is firing`,
+	Text: `
+Your code insight alert "{{.Description}}" is firing: {{.Condition}} (currently {{.Value}}).
+
+View the insight: {{.InsightURL}}
+`,
+	HTML: `
+<p>Your code insight alert <strong>{{.Description}}</strong> is firing: {{.Condition}} (currently {{.Value}}).</p>
+
+<p><a href="{{.InsightURL}}">View the insight</a></p>
+`,
+})
+
+// describeCondition describes the condition of rule in words, e.g. "the value is above 10".
+func describeCondition(rule *types.AlertRule) string {
+	comparison := "above"
+	if rule.Comparison == types.Below {
+		comparison = "below"
+	}
+
+	subject := "the value"
+	if rule.Capture != nil {
+		subject = fmt.Sprintf("the value for %q", *rule.Capture)
+	}
+
+	if rule.Kind == types.AlertOnPercentChange {
+		intervals := "the last interval"
+		if rule.Intervals > 1 {
+			intervals = fmt.Sprintf("the last %d intervals", rule.Intervals)
+		}
+		return fmt.Sprintf("the change of %s over %s is %s %s%%", subject, intervals, comparison, formatFloat(rule.Threshold))
+	}
+	return fmt.Sprintf("%s is %s %s", subject, comparison, formatFloat(rule.Threshold))
+}
+
+func formatValue(rule *types.AlertRule, value float64) string {
+	if rule.Kind == types.AlertOnPercentChange {
+		return strconv.FormatFloat(value, 'f', 1, 64) + "%"
+	}
+	return formatFloat(value)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func insightURL(viewUniqueID string) (string, error) {
+	externalURL, err := url.Parse(conf.Get().ExternalURL)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing external URL")
+	}
+	path := "/insights/insight/" + string(relay.MarshalID("insight_view", viewUniqueID))
+	return externalURL.ResolveReference(&url.URL{Path: path}).String(), nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	internalGitserver "github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/insights/alerts"
 	"github.com/sourcegraph/sourcegraph/internal/insights/background/limiter"
 	"github.com/sourcegraph/sourcegraph/internal/insights/background/pings"
 	"github.com/sourcegraph/sourcegraph/internal/insights/background/queryrunner"
@@ -107,6 +108,7 @@ func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB databas
 				}),
 			CostAnalyzer:      priority.DefaultQueryAnalyzer(),
 			RepoQueryExecutor: query.NewStreamingRepoQueryExecutor(logger.Scoped("StreamingRepoExecutor", "execute repo search in background workers")),
+			MainAppDB:         mainAppDB,
 		}
 
 		// Add the backfill v2 workers
@@ -135,10 +137,14 @@ func GetBackgroundQueryRunnerJob(ctx context.Context, logger log.Logger, mainApp
 	workerStore := queryrunner.CreateDBWorkerStore(observationCtx, workerBaseStore)
 	seachQueryLimiter := limiter.SearchQueryRate()
 
+	// Alert rules of a series are evaluated after each of its recordings, and notify through the
+	// same channels as code monitors.
+	alertEvaluator := alerts.NewEvaluator(store.NewAlertRuleStore(insightsDB), insightsStore, alerts.NewNotifier(mainAppDB), logger.Scoped("alerts.Evaluator", ""))
+
 	return []goroutine.BackgroundRoutine{
 		// Register the query-runner worker and resetter, which executes search queries and records
 		// results to the insights DB.
-		queryrunner.NewWorker(ctx, logger.Scoped("queryrunner.Worker", ""), workerStore, insightsStore, repoStore, queryRunnerWorkerMetrics, seachQueryLimiter),
+		queryrunner.NewWorker(ctx, logger.Scoped("queryrunner.Worker", ""), workerStore, insightsStore, repoStore, alertEvaluator, queryRunnerWorkerMetrics, seachQueryLimiter),
 		queryrunner.NewResetter(ctx, logger.Scoped("queryrunner.Resetter", ""), workerStore, queryRunnerResetterMetrics),
 		queryrunner.NewCleaner(ctx, observationCtx, workerBaseStore),
 	}
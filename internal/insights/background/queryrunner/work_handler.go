@@ -30,6 +30,7 @@ type workHandler struct {
 	repoStore       discovery.RepoStore
 	metadadataStore *store.InsightStore
 	limiter         *ratelimit.InstrumentedLimiter
+	alertEvaluator  AlertEvaluator
 	logger          log.Logger
 
 	mu          sync.RWMutex
@@ -38,6 +39,11 @@ type workHandler struct {
 	searchHandlers map[types.GenerationMethod]InsightsHandler
 }
 
+// AlertEvaluator evaluates the alert rules of a series after a new point of the series was recorded.
+type AlertEvaluator interface {
+	EvaluateSeries(ctx context.Context, seriesID string) error
+}
+
 type InsightsHandler func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error)
 
 func (r *workHandler) getSeries(ctx context.Context, seriesID string) (*types.InsightSeries, error) {
@@ -142,7 +148,18 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record *Job
 		return err
 	}
 
-	return r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime)
+	if err := r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime); err != nil {
+		return err
+	}
+
+	if r.alertEvaluator != nil && isGlobal && job.PersistMode == string(store.RecordMode) {
+		// Alerts are best effort: failing to evaluate or deliver them must not fail, and retry,
+		// a job whose recording was already persisted.
+		if err := r.alertEvaluator.EvaluateSeries(ctx, series.SeriesID); err != nil {
+			logger.Error("failed to evaluate insight alert rules", log.String("seriesUniqueId", series.SeriesID), log.Error(err))
+		}
+	}
+	return nil
 }
 
 func TranslateIncompleteReasons(err error) store.IncompleteReason {
@@ -25,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/insights/timeseries"
 	itypes "github.com/sourcegraph/sourcegraph/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -68,6 +69,9 @@ func makeInProgressWorker(ctx context.Context, config JobMonitorConfig) (*worker
 		clock:              glock.NewRealClock(),
 		config:             handlerConfig,
 	}
+	if config.MainAppDB != nil {
+		task.outboundWebhookJobs = database.OutboundWebhookJobsWithDefaultKey(config.MainAppDB)
+	}
 
 	worker := dbworker.NewWorker(ctx, workerStore, workerutil.Handler[*BaseJob](task), workerutil.WorkerOptions{
 		Name:              name,
@@ -118,6 +122,10 @@ type inProgressHandler struct {
 	backfillRunner     pipeline.Backfiller
 	config             handlerConfig
 
+	// outboundWebhookJobs is where outbound webhook events are enqueued. Events
+	// are not sent if it is nil.
+	outboundWebhookJobs database.OutboundWebhookJobStore
+
 	clock glock.Clock
 }
 
@@ -142,6 +150,11 @@ func (h *inProgressHandler) Handle(ctx context.Context, logger log.Logger, job *
 		return err
 	}
 	execution.config = h.config
+	// the backfill is completed without running when the history of the series is imported while it is in progress
+	if execution.backfill.IsTerminalState() {
+		logger.Info("insights backfill already in a terminal state", log.Int("backfillId", execution.backfill.Id), log.String("state", string(execution.backfill.State)))
+		return nil
+	}
 
 	logger.Info("insights backfill progress handler loaded",
 		log.Int("recordId", job.RecordID()),
@@ -271,7 +284,17 @@ func (h *inProgressHandler) doExecution(ctx context.Context, execution *backfill
 	}
 
 	if !execution.itr.HasMore() && !execution.itr.HasErrors() {
-		return false, h.finish(ctx, execution)
+		if err := h.finish(ctx, execution); err != nil {
+			return false, err
+		}
+		if h.outboundWebhookJobs != nil {
+			database.EnqueueOutboundWebhookEvent(ctx, execution.logger, h.outboundWebhookJobs, events.InsightSeriesBackfillComplete, events.NewInsightSeries(
+				execution.series.SeriesID,
+				execution.series.Query,
+				execution.itr.CompletedAt,
+			))
+		}
+		return false, nil
 	} else {
 		// in this state we have some errors that will need reprocessing, we will place this job back in queue
 		return true, nil
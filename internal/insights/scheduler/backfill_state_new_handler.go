@@ -104,6 +104,11 @@ func (h *newBackfillHandler) Handle(ctx context.Context, logger log.Logger, job
 	if err != nil {
 		return errors.Wrap(err, "loadBackfill")
 	}
+	// the backfill is already complete when the history of the series was imported
+	if backfill.IsTerminalState() {
+		logger.Info("insights backfill already in a terminal state", log.Int("backfillId", backfill.Id), log.String("state", string(backfill.State)))
+		return nil
+	}
 	series, err := h.seriesReader.GetDataSeriesByID(ctx, backfill.SeriesId)
 	if err != nil {
 		return errors.Wrap(err, "GetDataSeriesByID")
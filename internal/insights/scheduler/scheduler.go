@@ -118,6 +118,8 @@ type JobMonitorConfig struct {
 	AllRepoIterator   *discovery.AllReposIterator
 	CostAnalyzer      *priority.QueryAnalyzer
 	RepoQueryExecutor query.RepoQueryExecutor
+	// MainAppDB is used to enqueue outbound webhook events. It may be nil.
+	MainAppDB database.DB
 }
 
 func NewBackgroundJobMonitor(ctx context.Context, config JobMonitorConfig) *BackgroundJobMonitor {
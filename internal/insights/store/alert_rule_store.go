@@ -0,0 +1,206 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	edb "github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AlertRuleStore persists the alert rules defined on insight series.
+type AlertRuleStore interface {
+	CreateAlertRule(ctx context.Context, rule types.AlertRule) (*types.AlertRule, error)
+	GetAlertRules(ctx context.Context, args AlertRuleQueryArgs) ([]*types.AlertRule, error)
+	DeleteAlertRule(ctx context.Context, id int) error
+	UpdateAlertRuleState(ctx context.Context, args UpdateAlertRuleStateArgs) error
+}
+
+var _ AlertRuleStore = &DBAlertRuleStore{}
+
+type DBAlertRuleStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertRuleStore returns a new DBAlertRuleStore backed by the given Postgres db.
+func NewAlertRuleStore(db edb.InsightsDB) *DBAlertRuleStore {
+	return &DBAlertRuleStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+func (s *DBAlertRuleStore) With(other basestore.ShareableStore) *DBAlertRuleStore {
+	return &DBAlertRuleStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *DBAlertRuleStore) Transact(ctx context.Context) (*DBAlertRuleStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &DBAlertRuleStore{Store: txBase, Now: s.Now}, err
+}
+
+var ErrAlertRuleSeriesNotFound = errors.New("series is not part of the insight")
+
+// CreateAlertRule creates the given rule on the series identified by rule.SeriesID of the insight
+// view identified by rule.ViewUniqueID. The series must be attached to the view.
+func (s *DBAlertRuleStore) CreateAlertRule(ctx context.Context, rule types.AlertRule) (*types.AlertRule, error) {
+	if rule.Intervals <= 0 {
+		rule.Intervals = 1
+	}
+	q := sqlf.Sprintf(
+		createAlertRuleSql,
+		rule.Capture,
+		rule.Kind,
+		rule.Comparison,
+		rule.Threshold,
+		rule.Intervals,
+		rule.Description,
+		rule.Enabled,
+		rule.Email,
+		rule.SlackWebhookURL,
+		rule.WebhookURL,
+		rule.CreatedBy,
+		s.Now(),
+		rule.ViewUniqueID,
+		rule.SeriesID,
+	)
+	id, ok, err := basestore.ScanFirstInt(s.Query(ctx, q))
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAlertRule")
+	}
+	if !ok {
+		return nil, ErrAlertRuleSeriesNotFound
+	}
+
+	rules, err := s.GetAlertRules(ctx, AlertRuleQueryArgs{ID: &id})
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, errors.Newf("alert rule %d not found after creation", id)
+	}
+	return rules[0], nil
+}
+
+const createAlertRuleSql = `
+INSERT INTO insight_series_alert_rules (insight_view_id, insight_series_id, capture, kind, comparison, threshold, intervals,
+                                        description, enabled, email, slack_webhook_url, webhook_url, created_by, created_at)
+SELECT iv.id, s.id, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s
+FROM insight_view iv
+         JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
+         JOIN insight_series s ON ivs.insight_series_id = s.id
+WHERE iv.unique_id = %s AND s.series_id = %s AND s.deleted_at IS NULL
+RETURNING id;
+`
+
+type AlertRuleQueryArgs struct {
+	ID           *int
+	ViewUniqueID *string
+	SeriesID     *string
+	EnabledOnly  bool
+}
+
+func (s *DBAlertRuleStore) GetAlertRules(ctx context.Context, args AlertRuleQueryArgs) ([]*types.AlertRule, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.ID != nil {
+		preds = append(preds, sqlf.Sprintf("r.id = %s", *args.ID))
+	}
+	if args.ViewUniqueID != nil {
+		preds = append(preds, sqlf.Sprintf("iv.unique_id = %s", *args.ViewUniqueID))
+	}
+	if args.SeriesID != nil {
+		preds = append(preds, sqlf.Sprintf("s.series_id = %s", *args.SeriesID))
+	}
+	if args.EnabledOnly {
+		preds = append(preds, sqlf.Sprintf("r.enabled"))
+	}
+	return scanAlertRules(s.Query(ctx, sqlf.Sprintf(getAlertRulesSql, sqlf.Join(preds, "\n AND"))))
+}
+
+const getAlertRulesSql = `
+SELECT r.id, r.insight_view_id, iv.unique_id, r.insight_series_id, s.series_id, r.capture, r.kind, r.comparison,
+       r.threshold, r.intervals, r.description, r.enabled, r.email, r.slack_webhook_url, r.webhook_url, r.created_by,
+       r.created_at, r.firing, r.last_value, r.last_evaluated_at, r.last_fired_at
+FROM insight_series_alert_rules r
+         JOIN insight_view iv ON r.insight_view_id = iv.id
+         JOIN insight_series s ON r.insight_series_id = s.id
+WHERE %s
+ORDER BY r.id;
+`
+
+func (s *DBAlertRuleStore) DeleteAlertRule(ctx context.Context, id int) error {
+	if err := s.Exec(ctx, sqlf.Sprintf(deleteAlertRuleSql, id)); err != nil {
+		return errors.Wrapf(err, "failed to delete alert rule with id: %d", id)
+	}
+	return nil
+}
+
+const deleteAlertRuleSql = `
+DELETE FROM insight_series_alert_rules WHERE id = %s;
+`
+
+type UpdateAlertRuleStateArgs struct {
+	ID          int
+	Firing      bool
+	LastValue   *float64
+	EvaluatedAt time.Time
+	// FiredAt is only written when set, so that the time the rule last started firing is kept.
+	FiredAt *time.Time
+}
+
+// UpdateAlertRuleState records the outcome of an evaluation of a rule.
+func (s *DBAlertRuleStore) UpdateAlertRuleState(ctx context.Context, args UpdateAlertRuleStateArgs) error {
+	q := sqlf.Sprintf(updateAlertRuleStateSql, args.Firing, args.LastValue, args.EvaluatedAt, args.FiredAt, args.ID)
+	if err := s.Exec(ctx, q); err != nil {
+		return errors.Wrapf(err, "failed to update state of alert rule with id: %d", args.ID)
+	}
+	return nil
+}
+
+const updateAlertRuleStateSql = `
+UPDATE insight_series_alert_rules
+SET firing = %s, last_value = %s, last_evaluated_at = %s, last_fired_at = COALESCE(%s, last_fired_at)
+WHERE id = %s;
+`
+
+func scanAlertRules(rows *sql.Rows, queryErr error) (_ []*types.AlertRule, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []*types.AlertRule
+	for rows.Next() {
+		var temp types.AlertRule
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.InsightViewID,
+			&temp.ViewUniqueID,
+			&temp.InsightSeriesID,
+			&temp.SeriesID,
+			&temp.Capture,
+			&temp.Kind,
+			&temp.Comparison,
+			&temp.Threshold,
+			&temp.Intervals,
+			&temp.Description,
+			&temp.Enabled,
+			&temp.Email,
+			&temp.SlackWebhookURL,
+			&temp.WebhookURL,
+			&temp.CreatedBy,
+			&temp.CreatedAt,
+			&temp.Firing,
+			&temp.LastValue,
+			&temp.LastEvaluatedAt,
+			&temp.LastFiredAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, &temp)
+	}
+	return results, nil
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestAlertRuleStore(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	now := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	insightStore := NewInsightStore(insightsDB)
+	insightStore.Now = func() time.Time { return now }
+	alertRuleStore := NewAlertRuleStore(insightsDB)
+	alertRuleStore.Now = func() time.Time { return now }
+
+	view, err := insightStore.CreateView(ctx, types.InsightView{
+		Title:            "deprecated API usages",
+		UniqueID:         "view1",
+		PresentationType: types.Line,
+	}, []InsightViewGrant{GlobalGrant()})
+	require.NoError(t, err)
+	series, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           "series1",
+		Query:              "deprecatedAPI(",
+		CreatedAt:          now,
+		OldestHistoricalAt: now,
+		LastRecordedAt:     now,
+		NextRecordingAfter: now,
+		LastSnapshotAt:     now,
+		NextSnapshotAfter:  now,
+		SampleIntervalUnit: string(types.Week),
+		GenerationMethod:   types.Search,
+	})
+	require.NoError(t, err)
+	require.NoError(t, insightStore.AttachSeriesToView(ctx, series, view, types.InsightViewSeriesMetadata{Label: "usages", Stroke: "red"}))
+
+	t.Run("series must belong to the view", func(t *testing.T) {
+		_, err := alertRuleStore.CreateAlertRule(ctx, types.AlertRule{
+			ViewUniqueID: "view1",
+			SeriesID:     "unknown",
+			Kind:         types.AlertOnValue,
+			Comparison:   types.Above,
+			Description:  "unknown series",
+			CreatedBy:    1,
+		})
+		require.ErrorIs(t, err, ErrAlertRuleSeriesNotFound)
+	})
+
+	rule, err := alertRuleStore.CreateAlertRule(ctx, types.AlertRule{
+		ViewUniqueID: "view1",
+		SeriesID:     "series1",
+		Kind:         types.AlertOnPercentChange,
+		Comparison:   types.Above,
+		Threshold:    -1,
+		Intervals:    4,
+		Description:  "usages stopped decreasing",
+		Enabled:      true,
+		WebhookURL:   pointers.Ptr("https://example.com/hook"),
+		CreatedBy:    1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &types.AlertRule{
+		ID:              rule.ID,
+		InsightViewID:   view.ID,
+		ViewUniqueID:    "view1",
+		InsightSeriesID: series.ID,
+		SeriesID:        "series1",
+		Kind:            types.AlertOnPercentChange,
+		Comparison:      types.Above,
+		Threshold:       -1,
+		Intervals:       4,
+		Description:     "usages stopped decreasing",
+		Enabled:         true,
+		WebhookURL:      pointers.Ptr("https://example.com/hook"),
+		CreatedBy:       1,
+		CreatedAt:       now,
+	}, rule)
+
+	t.Run("update state", func(t *testing.T) {
+		evaluatedAt := now.Add(time.Hour)
+		err := alertRuleStore.UpdateAlertRuleState(ctx, UpdateAlertRuleStateArgs{
+			ID:          rule.ID,
+			Firing:      true,
+			LastValue:   pointers.Ptr(2.5),
+			EvaluatedAt: evaluatedAt,
+			FiredAt:     &evaluatedAt,
+		})
+		require.NoError(t, err)
+
+		// The time the rule last fired is kept when it is not set.
+		err = alertRuleStore.UpdateAlertRuleState(ctx, UpdateAlertRuleStateArgs{
+			ID:          rule.ID,
+			Firing:      false,
+			LastValue:   pointers.Ptr(-3.0),
+			EvaluatedAt: evaluatedAt.Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		rules, err := alertRuleStore.GetAlertRules(ctx, AlertRuleQueryArgs{SeriesID: pointers.Ptr("series1"), EnabledOnly: true})
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		require.False(t, rules[0].Firing)
+		require.Equal(t, -3.0, *rules[0].LastValue)
+		require.True(t, evaluatedAt.Equal(*rules[0].LastFiredAt))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, alertRuleStore.DeleteAlertRule(ctx, rule.ID))
+		rules, err := alertRuleStore.GetAlertRules(ctx, AlertRuleQueryArgs{ViewUniqueID: pointers.Ptr("view1")})
+		require.NoError(t, err)
+		require.Empty(t, rules)
+	})
+}
@@ -14,6 +14,533 @@ import (
 	types "github.com/sourcegraph/sourcegraph/internal/insights/types"
 )
 
+// MockAlertRuleStore is a mock implementation of the AlertRuleStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/insights/store) used for unit
+// testing.
+type MockAlertRuleStore struct {
+	// CreateAlertRuleFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateAlertRule.
+	CreateAlertRuleFunc *AlertRuleStoreCreateAlertRuleFunc
+	// DeleteAlertRuleFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteAlertRule.
+	DeleteAlertRuleFunc *AlertRuleStoreDeleteAlertRuleFunc
+	// GetAlertRulesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetAlertRules.
+	GetAlertRulesFunc *AlertRuleStoreGetAlertRulesFunc
+	// UpdateAlertRuleStateFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateAlertRuleState.
+	UpdateAlertRuleStateFunc *AlertRuleStoreUpdateAlertRuleStateFunc
+}
+
+// NewMockAlertRuleStore creates a new mock of the AlertRuleStore interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockAlertRuleStore() *MockAlertRuleStore {
+	return &MockAlertRuleStore{
+		CreateAlertRuleFunc: &AlertRuleStoreCreateAlertRuleFunc{
+			defaultHook: func(context.Context, types.AlertRule) (r0 *types.AlertRule, r1 error) {
+				return
+			},
+		},
+		DeleteAlertRuleFunc: &AlertRuleStoreDeleteAlertRuleFunc{
+			defaultHook: func(context.Context, int) (r0 error) {
+				return
+			},
+		},
+		GetAlertRulesFunc: &AlertRuleStoreGetAlertRulesFunc{
+			defaultHook: func(context.Context, AlertRuleQueryArgs) (r0 []*types.AlertRule, r1 error) {
+				return
+			},
+		},
+		UpdateAlertRuleStateFunc: &AlertRuleStoreUpdateAlertRuleStateFunc{
+			defaultHook: func(context.Context, UpdateAlertRuleStateArgs) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockAlertRuleStore creates a new mock of the AlertRuleStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockAlertRuleStore() *MockAlertRuleStore {
+	return &MockAlertRuleStore{
+		CreateAlertRuleFunc: &AlertRuleStoreCreateAlertRuleFunc{
+			defaultHook: func(context.Context, types.AlertRule) (*types.AlertRule, error) {
+				panic("unexpected invocation of MockAlertRuleStore.CreateAlertRule")
+			},
+		},
+		DeleteAlertRuleFunc: &AlertRuleStoreDeleteAlertRuleFunc{
+			defaultHook: func(context.Context, int) error {
+				panic("unexpected invocation of MockAlertRuleStore.DeleteAlertRule")
+			},
+		},
+		GetAlertRulesFunc: &AlertRuleStoreGetAlertRulesFunc{
+			defaultHook: func(context.Context, AlertRuleQueryArgs) ([]*types.AlertRule, error) {
+				panic("unexpected invocation of MockAlertRuleStore.GetAlertRules")
+			},
+		},
+		UpdateAlertRuleStateFunc: &AlertRuleStoreUpdateAlertRuleStateFunc{
+			defaultHook: func(context.Context, UpdateAlertRuleStateArgs) error {
+				panic("unexpected invocation of MockAlertRuleStore.UpdateAlertRuleState")
+			},
+		},
+	}
+}
+
+// NewMockAlertRuleStoreFrom creates a new mock of the MockAlertRuleStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockAlertRuleStoreFrom(i AlertRuleStore) *MockAlertRuleStore {
+	return &MockAlertRuleStore{
+		CreateAlertRuleFunc: &AlertRuleStoreCreateAlertRuleFunc{
+			defaultHook: i.CreateAlertRule,
+		},
+		DeleteAlertRuleFunc: &AlertRuleStoreDeleteAlertRuleFunc{
+			defaultHook: i.DeleteAlertRule,
+		},
+		GetAlertRulesFunc: &AlertRuleStoreGetAlertRulesFunc{
+			defaultHook: i.GetAlertRules,
+		},
+		UpdateAlertRuleStateFunc: &AlertRuleStoreUpdateAlertRuleStateFunc{
+			defaultHook: i.UpdateAlertRuleState,
+		},
+	}
+}
+
+// AlertRuleStoreCreateAlertRuleFunc describes the behavior when the
+// CreateAlertRule method of the parent MockAlertRuleStore instance is
+// invoked.
+type AlertRuleStoreCreateAlertRuleFunc struct {
+	defaultHook func(context.Context, types.AlertRule) (*types.AlertRule, error)
+	hooks       []func(context.Context, types.AlertRule) (*types.AlertRule, error)
+	history     []AlertRuleStoreCreateAlertRuleFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateAlertRule delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAlertRuleStore) CreateAlertRule(v0 context.Context, v1 types.AlertRule) (*types.AlertRule, error) {
+	r0, r1 := m.CreateAlertRuleFunc.nextHook()(v0, v1)
+	m.CreateAlertRuleFunc.appendCall(AlertRuleStoreCreateAlertRuleFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateAlertRule
+// method of the parent MockAlertRuleStore instance is invoked and the hook
+// queue is empty.
+func (f *AlertRuleStoreCreateAlertRuleFunc) SetDefaultHook(hook func(context.Context, types.AlertRule) (*types.AlertRule, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateAlertRule method of the parent MockAlertRuleStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AlertRuleStoreCreateAlertRuleFunc) PushHook(hook func(context.Context, types.AlertRule) (*types.AlertRule, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AlertRuleStoreCreateAlertRuleFunc) SetDefaultReturn(r0 *types.AlertRule, r1 error) {
+	f.SetDefaultHook(func(context.Context, types.AlertRule) (*types.AlertRule, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AlertRuleStoreCreateAlertRuleFunc) PushReturn(r0 *types.AlertRule, r1 error) {
+	f.PushHook(func(context.Context, types.AlertRule) (*types.AlertRule, error) {
+		return r0, r1
+	})
+}
+
+func (f *AlertRuleStoreCreateAlertRuleFunc) nextHook() func(context.Context, types.AlertRule) (*types.AlertRule, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AlertRuleStoreCreateAlertRuleFunc) appendCall(r0 AlertRuleStoreCreateAlertRuleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AlertRuleStoreCreateAlertRuleFuncCall
+// objects describing the invocations of this function.
+func (f *AlertRuleStoreCreateAlertRuleFunc) History() []AlertRuleStoreCreateAlertRuleFuncCall {
+	f.mutex.Lock()
+	history := make([]AlertRuleStoreCreateAlertRuleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AlertRuleStoreCreateAlertRuleFuncCall is an object that describes an
+// invocation of method CreateAlertRule on an instance of
+// MockAlertRuleStore.
+type AlertRuleStoreCreateAlertRuleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.AlertRule
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.AlertRule
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AlertRuleStoreCreateAlertRuleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AlertRuleStoreCreateAlertRuleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AlertRuleStoreDeleteAlertRuleFunc describes the behavior when the
+// DeleteAlertRule method of the parent MockAlertRuleStore instance is
+// invoked.
+type AlertRuleStoreDeleteAlertRuleFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []AlertRuleStoreDeleteAlertRuleFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteAlertRule delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAlertRuleStore) DeleteAlertRule(v0 context.Context, v1 int) error {
+	r0 := m.DeleteAlertRuleFunc.nextHook()(v0, v1)
+	m.DeleteAlertRuleFunc.appendCall(AlertRuleStoreDeleteAlertRuleFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteAlertRule
+// method of the parent MockAlertRuleStore instance is invoked and the hook
+// queue is empty.
+func (f *AlertRuleStoreDeleteAlertRuleFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteAlertRule method of the parent MockAlertRuleStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AlertRuleStoreDeleteAlertRuleFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AlertRuleStoreDeleteAlertRuleFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AlertRuleStoreDeleteAlertRuleFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *AlertRuleStoreDeleteAlertRuleFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AlertRuleStoreDeleteAlertRuleFunc) appendCall(r0 AlertRuleStoreDeleteAlertRuleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AlertRuleStoreDeleteAlertRuleFuncCall
+// objects describing the invocations of this function.
+func (f *AlertRuleStoreDeleteAlertRuleFunc) History() []AlertRuleStoreDeleteAlertRuleFuncCall {
+	f.mutex.Lock()
+	history := make([]AlertRuleStoreDeleteAlertRuleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AlertRuleStoreDeleteAlertRuleFuncCall is an object that describes an
+// invocation of method DeleteAlertRule on an instance of
+// MockAlertRuleStore.
+type AlertRuleStoreDeleteAlertRuleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AlertRuleStoreDeleteAlertRuleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AlertRuleStoreDeleteAlertRuleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AlertRuleStoreGetAlertRulesFunc describes the behavior when the
+// GetAlertRules method of the parent MockAlertRuleStore instance is
+// invoked.
+type AlertRuleStoreGetAlertRulesFunc struct {
+	defaultHook func(context.Context, AlertRuleQueryArgs) ([]*types.AlertRule, error)
+	hooks       []func(context.Context, AlertRuleQueryArgs) ([]*types.AlertRule, error)
+	history     []AlertRuleStoreGetAlertRulesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetAlertRules delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAlertRuleStore) GetAlertRules(v0 context.Context, v1 AlertRuleQueryArgs) ([]*types.AlertRule, error) {
+	r0, r1 := m.GetAlertRulesFunc.nextHook()(v0, v1)
+	m.GetAlertRulesFunc.appendCall(AlertRuleStoreGetAlertRulesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetAlertRules method
+// of the parent MockAlertRuleStore instance is invoked and the hook queue
+// is empty.
+func (f *AlertRuleStoreGetAlertRulesFunc) SetDefaultHook(hook func(context.Context, AlertRuleQueryArgs) ([]*types.AlertRule, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetAlertRules method of the parent MockAlertRuleStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AlertRuleStoreGetAlertRulesFunc) PushHook(hook func(context.Context, AlertRuleQueryArgs) ([]*types.AlertRule, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AlertRuleStoreGetAlertRulesFunc) SetDefaultReturn(r0 []*types.AlertRule, r1 error) {
+	f.SetDefaultHook(func(context.Context, AlertRuleQueryArgs) ([]*types.AlertRule, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AlertRuleStoreGetAlertRulesFunc) PushReturn(r0 []*types.AlertRule, r1 error) {
+	f.PushHook(func(context.Context, AlertRuleQueryArgs) ([]*types.AlertRule, error) {
+		return r0, r1
+	})
+}
+
+func (f *AlertRuleStoreGetAlertRulesFunc) nextHook() func(context.Context, AlertRuleQueryArgs) ([]*types.AlertRule, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AlertRuleStoreGetAlertRulesFunc) appendCall(r0 AlertRuleStoreGetAlertRulesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AlertRuleStoreGetAlertRulesFuncCall objects
+// describing the invocations of this function.
+func (f *AlertRuleStoreGetAlertRulesFunc) History() []AlertRuleStoreGetAlertRulesFuncCall {
+	f.mutex.Lock()
+	history := make([]AlertRuleStoreGetAlertRulesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AlertRuleStoreGetAlertRulesFuncCall is an object that describes an
+// invocation of method GetAlertRules on an instance of MockAlertRuleStore.
+type AlertRuleStoreGetAlertRulesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 AlertRuleQueryArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.AlertRule
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AlertRuleStoreGetAlertRulesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AlertRuleStoreGetAlertRulesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AlertRuleStoreUpdateAlertRuleStateFunc describes the behavior when the
+// UpdateAlertRuleState method of the parent MockAlertRuleStore instance is
+// invoked.
+type AlertRuleStoreUpdateAlertRuleStateFunc struct {
+	defaultHook func(context.Context, UpdateAlertRuleStateArgs) error
+	hooks       []func(context.Context, UpdateAlertRuleStateArgs) error
+	history     []AlertRuleStoreUpdateAlertRuleStateFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateAlertRuleState delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAlertRuleStore) UpdateAlertRuleState(v0 context.Context, v1 UpdateAlertRuleStateArgs) error {
+	r0 := m.UpdateAlertRuleStateFunc.nextHook()(v0, v1)
+	m.UpdateAlertRuleStateFunc.appendCall(AlertRuleStoreUpdateAlertRuleStateFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdateAlertRuleState
+// method of the parent MockAlertRuleStore instance is invoked and the hook
+// queue is empty.
+func (f *AlertRuleStoreUpdateAlertRuleStateFunc) SetDefaultHook(hook func(context.Context, UpdateAlertRuleStateArgs) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateAlertRuleState method of the parent MockAlertRuleStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AlertRuleStoreUpdateAlertRuleStateFunc) PushHook(hook func(context.Context, UpdateAlertRuleStateArgs) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AlertRuleStoreUpdateAlertRuleStateFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, UpdateAlertRuleStateArgs) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AlertRuleStoreUpdateAlertRuleStateFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, UpdateAlertRuleStateArgs) error {
+		return r0
+	})
+}
+
+func (f *AlertRuleStoreUpdateAlertRuleStateFunc) nextHook() func(context.Context, UpdateAlertRuleStateArgs) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AlertRuleStoreUpdateAlertRuleStateFunc) appendCall(r0 AlertRuleStoreUpdateAlertRuleStateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AlertRuleStoreUpdateAlertRuleStateFuncCall
+// objects describing the invocations of this function.
+func (f *AlertRuleStoreUpdateAlertRuleStateFunc) History() []AlertRuleStoreUpdateAlertRuleStateFuncCall {
+	f.mutex.Lock()
+	history := make([]AlertRuleStoreUpdateAlertRuleStateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AlertRuleStoreUpdateAlertRuleStateFuncCall is an object that describes an
+// invocation of method UpdateAlertRuleState on an instance of
+// MockAlertRuleStore.
+type AlertRuleStoreUpdateAlertRuleStateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 UpdateAlertRuleStateArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AlertRuleStoreUpdateAlertRuleStateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AlertRuleStoreUpdateAlertRuleStateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockDataSeriesStore is a mock implementation of the DataSeriesStore
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/insights/store) used for unit
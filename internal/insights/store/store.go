@@ -10,6 +10,7 @@ import (
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	edb "github.com/sourcegraph/sourcegraph/internal/database"
@@ -526,6 +527,43 @@ func (s *Store) DeleteSnapshots(ctx context.Context, series *types.InsightSeries
 	return nil
 }
 
+// SeriesPointKey identifies the points of a series that were recorded for a repository at a time. A nil RepoID
+// identifies the points that are not associated with a repository.
+type SeriesPointKey struct {
+	Time   time.Time
+	RepoID *api.RepoID
+}
+
+// DeleteSeriesPointsAt deletes the recorded and archived points of the series with the given keys. It is used to
+// replace the data of a series when importing it. Points of other repositories recorded at the same times are kept.
+func (s *Store) DeleteSeriesPointsAt(ctx context.Context, seriesID string, keys []SeriesPointKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	times := make([]time.Time, 0, len(keys))
+	repoIDs := make([]sql.NullInt32, 0, len(keys))
+	for _, k := range keys {
+		times = append(times, k.Time.UTC())
+		if k.RepoID != nil {
+			repoIDs = append(repoIDs, sql.NullInt32{Int32: int32(*k.RepoID), Valid: true})
+		} else {
+			repoIDs = append(repoIDs, sql.NullInt32{})
+		}
+	}
+	for _, table := range []string{recordingTable, recordingTableArchive} {
+		if err := s.Exec(ctx, sqlf.Sprintf(deleteSeriesPointsAtSql, quote(table), pq.Array(times), pq.Array(repoIDs), seriesID)); err != nil {
+			return errors.Wrapf(err, "failed to delete insights series points for series_id: %s", seriesID)
+		}
+	}
+	return nil
+}
+
+const deleteSeriesPointsAtSql = `
+DELETE FROM %s sp
+USING unnest(%s::timestamptz[], %s::integer[]) AS k(time, repo_id)
+WHERE sp.series_id = %s AND sp.time = k.time AND sp.repo_id IS NOT DISTINCT FROM k.repo_id;
+`
+
 const deleteSnapshotsSql = `
 DELETE FROM %s WHERE series_id = %s;
 `
@@ -884,7 +922,9 @@ type SeriesPointForExport struct {
 	InsightViewTitle string
 	SeriesLabel      string
 	SeriesQuery      string
+	SeriesID         string
 	RecordingTime    time.Time
+	Snapshot         bool
 	RepoName         *string
 	Value            int
 	Capture          *string
@@ -896,13 +936,25 @@ type ExportOpts struct {
 	ExcludeRepoRegex    []string
 }
 
-func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts) (_ []SeriesPointForExport, err error) {
+func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts) ([]SeriesPointForExport, error) {
+	var results []SeriesPointForExport
+	err := s.StreamAllDataForInsightViewID(ctx, opts, func(point SeriesPointForExport) error {
+		results = append(results, point)
+		return nil
+	})
+	return results, err
+}
+
+// StreamAllDataForInsightViewID calls fn with every data point of the insight view, including its per-repository
+// breakdown, starting with the oldest archived points. Points are not held in memory, so this can be used to export
+// insights with a long history.
+func (s *Store) StreamAllDataForInsightViewID(ctx context.Context, opts ExportOpts, fn func(SeriesPointForExport) error) (err error) {
 	// 🚨 SECURITY: this function will only be called if the insight with the given insightViewId is visible given
 	// this user context. This is similar to how `SeriesPoints` works.
 	// We enforce repo permissions here as we store repository data at this level.
 	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "GetUnauthorizedRepoIDs")
+		return errors.Wrap(err, "GetUnauthorizedRepoIDs")
 	}
 	excludedRepoIDs := make([]*sqlf.Query, 0)
 	for _, repoID := range denylist {
@@ -939,18 +991,19 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 
 	tx, err := s.Transact(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer func() { err = tx.Done(err) }()
 
-	var results []SeriesPointForExport
 	exportScanner := func(sc scanner) error {
 		var tmp SeriesPointForExport
-		if err = sc.Scan(
+		if err := sc.Scan(
 			&tmp.InsightViewTitle,
 			&tmp.SeriesLabel,
 			&tmp.SeriesQuery,
+			&tmp.SeriesID,
 			&tmp.RecordingTime,
+			&tmp.Snapshot,
 			&tmp.RepoName,
 			&tmp.Value,
 			&tmp.Capture,
@@ -961,26 +1014,25 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 		if tmp.Capture != nil {
 			tmp.SeriesLabel = *tmp.Capture
 		}
-		results = append(results, tmp)
-		return nil
+		return fn(tmp)
 	}
 
 	formattedPreds := sqlf.Join(preds, "AND")
 	// start with the oldest archived points and add them to the results
 	if err := tx.query(ctx, sqlf.Sprintf(exportCodeInsightsDataSql, quote(recordingTimesTableArchive), quote(recordingTableArchive), opts.InsightViewUniqueID, formattedPreds), exportScanner); err != nil {
-		return nil, errors.Wrap(err, "fetching archived code insights data")
+		return errors.Wrap(err, "fetching archived code insights data")
 	}
 	// then add live points
 	// we join both series points tables
 	if err := tx.query(ctx, sqlf.Sprintf(exportCodeInsightsDataSql, quote(recordingTimesTable), quote("(select * from series_points union all select * from series_points_snapshots)"), opts.InsightViewUniqueID, formattedPreds), exportScanner); err != nil {
-		return nil, errors.Wrap(err, "fetching code insights data")
+		return errors.Wrap(err, "fetching code insights data")
 	}
 
-	return results, nil
+	return nil
 }
 
 const exportCodeInsightsDataSql = `
-select iv.title, ivs.label, i.query, isrt.recording_time, rn.name, coalesce(sp.value, 0) as value, sp.capture
+select iv.title, ivs.label, i.query, i.series_id, isrt.recording_time, coalesce(isrt.snapshot, false), rn.name, coalesce(sp.value, 0) as value, sp.capture
 from %s isrt
     join insight_series i on i.id = isrt.insight_series_id
     join insight_view_series ivs ON i.id = ivs.insight_series_id
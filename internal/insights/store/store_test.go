@@ -537,6 +537,72 @@ func TestDeleteSnapshots(t *testing.T) {
 	autogold.Expect(gotRecordingTimes).Equal(t, wantRecordingTimes)
 }
 
+func TestDeleteSeriesPointsAt(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := NewWithClock(insightsDB, NewInsightPermissionStore(postgres), timeutil.Now)
+
+	repoName := "repo1"
+	repoID := api.RepoID(3)
+	current := time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC)
+	seriesID := "one"
+
+	var records []RecordSeriesPointArgs
+	for i := 0; i < 3; i++ {
+		records = append(records, RecordSeriesPointArgs{
+			SeriesID:    seriesID,
+			Point:       SeriesPoint{Time: current.AddDate(0, i, 0), Value: float64(i)},
+			RepoName:    &repoName,
+			RepoID:      &repoID,
+			PersistMode: RecordMode,
+		})
+	}
+	if err := store.RecordSeriesPoints(ctx, records); err != nil {
+		t.Fatal(err)
+	}
+
+	otherRepoName := "repo2"
+	otherRepoID := api.RepoID(4)
+	if err := store.RecordSeriesPoints(ctx, []RecordSeriesPointArgs{{
+		SeriesID:    seriesID,
+		Point:       SeriesPoint{Time: current, Value: 10},
+		RepoName:    &otherRepoName,
+		RepoID:      &otherRepoID,
+		PersistMode: RecordMode,
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.DeleteSeriesPointsAt(ctx, seriesID, []SeriesPointKey{
+		{Time: current, RepoID: &repoID},
+		{Time: current.AddDate(0, 2, 0), RepoID: &repoID},
+		// there are no points without a repository to delete
+		{Time: current.AddDate(0, 1, 0)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	points, err := store.SeriesPoints(ctx, SeriesPointsOpts{SeriesID: &seriesID, Included: []api.RepoID{repoID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 1 || !points[0].Time.Equal(current.AddDate(0, 1, 0)) {
+		t.Errorf("unexpected series points after deleting: %v", points)
+	}
+	points, err = store.SeriesPoints(ctx, SeriesPointsOpts{SeriesID: &seriesID, Included: []api.RepoID{otherRepoID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 1 || points[0].Value != 10 {
+		t.Errorf("points of other repositories must be kept, got: %v", points)
+	}
+}
+
 func TestValues(t *testing.T) {
 	ids := []api.RepoID{1, 2, 3, 4, 5, 6}
 	got := values(ids)
@@ -206,6 +206,46 @@ type RecordingTime struct {
 	Snapshot  bool
 }
 
+type AlertRuleKind string
+
+const (
+	AlertOnValue         AlertRuleKind = "VALUE"          // Compares the latest value of the series.
+	AlertOnPercentChange AlertRuleKind = "PERCENT_CHANGE" // Compares the percent change of the series over a number of intervals.
+)
+
+type AlertComparison string
+
+const (
+	Above AlertComparison = "ABOVE"
+	Below AlertComparison = "BELOW"
+)
+
+// AlertRule is a threshold or trend rule on an insight series that is evaluated after each
+// recording of the series.
+type AlertRule struct {
+	ID              int
+	InsightViewID   int    // references insight_view(id)
+	ViewUniqueID    string // the unique_id of the referenced view
+	InsightSeriesID int    // references insight_series(id)
+	SeriesID        string // the unique series_id of the referenced series
+	Capture         *string
+	Kind            AlertRuleKind
+	Comparison      AlertComparison
+	Threshold       float64
+	Intervals       int
+	Description     string
+	Enabled         bool
+	Email           bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+	CreatedBy       int32
+	CreatedAt       time.Time
+	Firing          bool
+	LastValue       *float64
+	LastEvaluatedAt *time.Time
+	LastFiredAt     *time.Time
+}
+
 type SearchAggregationMode string
 
 const (
@@ -0,0 +1,337 @@
+// Package ldaptest provides an in-process LDAP server for tests.
+package ldaptest
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Entry is an entry of the directory. Simple binds with the DN of an entry succeed if the
+// password is one of the values of its userPassword attribute.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Server is an in-process LDAP server serving a fixed directory. It supports simple binds,
+// searches with the filters supported by ldap.CompileFilter except approximate and extensible
+// matches, and StartTLS when TLSConfig is set.
+type Server struct {
+	Entries []Entry
+	// TLSConfig enables StartTLS.
+	TLSConfig *tls.Config
+
+	// URL is the ldap:// URL of the server.
+	URL string
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu    sync.Mutex
+	binds []string
+}
+
+// NewServer starts a server serving the given entries, which is closed at the end of the test.
+func NewServer(t testing.TB, entries ...Entry) *Server {
+	t.Helper()
+	s := &Server{Entries: entries}
+	s.Start(t)
+	return s
+}
+
+// Start starts the server, which is closed at the end of the test.
+func (s *Server) Start(t testing.TB) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.listener = l
+	s.URL = "ldap://" + l.Addr().String()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.serve(conn)
+			}()
+		}
+	}()
+	t.Cleanup(func() {
+		l.Close()
+		s.wg.Wait()
+	})
+}
+
+// Binds returns the DNs of all successful binds, in order.
+func (s *Server) Binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.binds...)
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer func() { conn.Close() }()
+	r := bufio.NewReader(conn)
+	for {
+		msg, err := ber.ReadPacket(r)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		id := msg.Children[0]
+		op := msg.Children[1]
+		respond := func(op *ber.Packet) bool {
+			resp := ber.NewSequence("LDAP Response")
+			resp.AppendChild(id)
+			resp.AppendChild(op)
+			_, err := conn.Write(resp.Bytes())
+			return err == nil
+		}
+
+		if op.ClassType != ber.ClassApplication {
+			return
+		}
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			if !respond(result(ldap.ApplicationBindResponse, s.bind(op))) {
+				return
+			}
+		case ldap.ApplicationSearchRequest:
+			entries, code := s.search(op)
+			for _, e := range entries {
+				if !respond(e) {
+					return
+				}
+			}
+			if !respond(result(ldap.ApplicationSearchResultDone, code)) {
+				return
+			}
+		case ldap.ApplicationExtendedRequest:
+			if s.TLSConfig == nil || len(op.Children) == 0 || str(op.Children[0]) != startTLSOID {
+				if !respond(result(ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError)) {
+					return
+				}
+				continue
+			}
+			if !respond(result(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess)) {
+				return
+			}
+			tlsConn := tls.Server(conn, s.TLSConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			r = bufio.NewReader(conn)
+		default:
+			// unbind, or unsupported operations
+			return
+		}
+	}
+}
+
+// startTLSOID is the name of the StartTLS extended operation.
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+func result(op ber.Tag, code uint16) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, op, nil, "")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "resultCode"))
+	p.AppendChild(newString(""))
+	p.AppendChild(newString(""))
+	return p
+}
+
+func newString(s string) *ber.Packet {
+	return ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, s, "")
+}
+
+// str returns the content of a primitive packet, such as an octet string or a context-specific
+// string of a filter.
+func str(p *ber.Packet) string {
+	return p.Data.String()
+}
+
+func integer(p *ber.Packet) int64 {
+	i, _ := p.Value.(int64)
+	return i
+}
+
+func (s *Server) bind(op *ber.Packet) uint16 {
+	if len(op.Children) != 3 {
+		return ldap.LDAPResultProtocolError
+	}
+	dn, password := str(op.Children[1]), str(op.Children[2])
+	if dn == "" && password == "" {
+		return ldap.LDAPResultSuccess // anonymous bind
+	}
+	for _, e := range s.Entries {
+		if !strings.EqualFold(e.DN, dn) {
+			continue
+		}
+		for _, p := range e.Attributes["userPassword"] {
+			if password != "" && p == password {
+				s.mu.Lock()
+				s.binds = append(s.binds, e.DN)
+				s.mu.Unlock()
+				return ldap.LDAPResultSuccess
+			}
+		}
+	}
+	return ldap.LDAPResultInvalidCredentials
+}
+
+func (s *Server) search(op *ber.Packet) ([]*ber.Packet, uint16) {
+	if len(op.Children) != 8 {
+		return nil, ldap.LDAPResultProtocolError
+	}
+	base := str(op.Children[0])
+	scope := integer(op.Children[1])
+	sizeLimit := integer(op.Children[3])
+	filter := op.Children[6]
+
+	var results []*ber.Packet
+	for _, e := range s.Entries {
+		if !inScope(e.DN, base, scope) || !matches(e, filter) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(results)) == sizeLimit {
+			return results, ldap.LDAPResultSizeLimitExceeded
+		}
+		attributes := ber.NewSequence("attributes")
+		for name, values := range e.Attributes {
+			if name == "userPassword" || !requested(op.Children[7], name) {
+				continue
+			}
+			set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "vals")
+			for _, v := range values {
+				set.AppendChild(newString(v))
+			}
+			attribute := ber.NewSequence("attribute")
+			attribute.AppendChild(newString(name))
+			attribute.AppendChild(set)
+			attributes.AppendChild(attribute)
+		}
+		entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+		entry.AppendChild(newString(e.DN))
+		entry.AppendChild(attributes)
+		results = append(results, entry)
+	}
+	return results, ldap.LDAPResultSuccess
+}
+
+func inScope(dn, base string, scope int64) bool {
+	dn, base = strings.ToLower(dn), strings.ToLower(base)
+	switch scope {
+	case ldap.ScopeBaseObject:
+		return dn == base
+	case ldap.ScopeSingleLevel:
+		parent := dn[strings.IndexByte(dn, ',')+1:]
+		return strings.Contains(dn, ",") && parent == base
+	default:
+		return base == "" || dn == base || strings.HasSuffix(dn, ","+base)
+	}
+}
+
+func requested(attributes *ber.Packet, name string) bool {
+	if len(attributes.Children) == 0 {
+		return true
+	}
+	for _, a := range attributes.Children {
+		if strings.EqualFold(str(a), name) || str(a) == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func values(e Entry, name string) []string {
+	for n, v := range e.Attributes {
+		if strings.EqualFold(n, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+func matches(e Entry, filter *ber.Packet) bool {
+	if filter.ClassType != ber.ClassContext {
+		return false
+	}
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, f := range filter.Children {
+			if !matches(e, f) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, f := range filter.Children {
+			if matches(e, f) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return len(filter.Children) == 1 && !matches(e, filter.Children[0])
+	case ldap.FilterPresent:
+		return len(values(e, str(filter))) > 0
+	case ldap.FilterEqualityMatch, ldap.FilterGreaterOrEqual, ldap.FilterLessOrEqual:
+		want := strings.ToLower(str(filter.Children[1]))
+		for _, v := range values(e, str(filter.Children[0])) {
+			v = strings.ToLower(v)
+			if (filter.Tag == ldap.FilterEqualityMatch && v == want) ||
+				(filter.Tag == ldap.FilterGreaterOrEqual && v >= want) ||
+				(filter.Tag == ldap.FilterLessOrEqual && v <= want) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterSubstrings:
+		for _, v := range values(e, str(filter.Children[0])) {
+			if matchesSubstrings(strings.ToLower(v), filter.Children[1].Children) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func matchesSubstrings(v string, substrings []*ber.Packet) bool {
+	for _, sub := range substrings {
+		s := strings.ToLower(str(sub))
+		switch sub.Tag {
+		case ldap.FilterSubstringsInitial:
+			if !strings.HasPrefix(v, s) {
+				return false
+			}
+			v = v[len(s):]
+		case ldap.FilterSubstringsFinal:
+			if !strings.HasSuffix(v, s) {
+				return false
+			}
+			v = v[:len(v)-len(s)]
+		default:
+			i := strings.Index(v, s)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(s):]
+		}
+	}
+	return true
+}
@@ -191,6 +191,8 @@ func (h *handler) Handle(ctx context.Context, lgr log.Logger, record *Job) error
 	switch record.ConfigName {
 	case types.SignalRecentContributors:
 		delegate = handleRecentContributors
+	case types.SignalBlameOwnership:
+		delegate = handleBlameOwnership
 	case types.Analytics:
 		delegate = handleAnalytics
 	default:
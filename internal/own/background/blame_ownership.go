@@ -0,0 +1,216 @@
+package background
+
+import (
+	"context"
+	"math"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	logger "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// blameOwnershipHalfLife is the age of a change after which the lines it
+	// last touched count half as much towards the ownership of its author.
+	blameOwnershipHalfLife = 180 * 24 * time.Hour
+	// blameOwnershipMaxFiles is the maximum number of files that are blamed in
+	// a single repository, so that huge monorepos don't block the queue.
+	blameOwnershipMaxFiles = 25000
+)
+
+func handleBlameOwnership(ctx context.Context, lgr logger.Logger, repoId api.RepoID, db database.DB, subRepoPermsCache *rcache.Cache) error {
+	// 🚨 SECURITY: we use the internal actor because the background indexer is not associated with any user, and needs
+	// to see all repos and files
+	internalCtx := actor.WithInternalActor(ctx)
+
+	indexer := newBlameOwnershipIndexer(gitserver.NewClient(db), db, lgr, subRepoPermsCache)
+	return indexer.indexRepo(internalCtx, repoId, authz.DefaultSubRepoPermsChecker)
+}
+
+type blameOwnershipIndexer struct {
+	client            gitserver.Client
+	db                database.DB
+	logger            logger.Logger
+	subRepoPermsCache rcache.Cache
+	now               func() time.Time
+}
+
+func newBlameOwnershipIndexer(client gitserver.Client, db database.DB, lgr logger.Logger, subRepoPermsCache *rcache.Cache) *blameOwnershipIndexer {
+	return &blameOwnershipIndexer{client: client, db: db, logger: lgr, subRepoPermsCache: *subRepoPermsCache, now: time.Now}
+}
+
+var blamedFilesCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "src",
+	Name:      "own_blame_ownership_files_indexed_total",
+})
+
+func (b *blameOwnershipIndexer) indexRepo(ctx context.Context, repoId api.RepoID, checker authz.SubRepoPermissionChecker) error {
+	// If the repo has sub-repo perms enabled, skip indexing.
+	isSubRepoPermsRepo, err := isSubRepoPermsRepo(ctx, repoId, b.subRepoPermsCache, checker)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	} else if isSubRepoPermsRepo {
+		b.logger.Debug("skipping own blame ownership signal due to the repo having subrepo perms enabled", logger.Int32("repoID", int32(repoId)))
+		return nil
+	}
+
+	repo, err := b.db.Repos().Get(ctx, repoId)
+	if err != nil {
+		return errors.Wrap(err, "repoStore.Get")
+	}
+	_, commitID, err := b.client.GetDefaultBranch(ctx, repo.Name, true)
+	if err != nil {
+		return errors.Wrap(err, "GetDefaultBranch")
+	}
+	if commitID == "" {
+		// Empty repository, there is nothing to blame.
+		return b.db.BlameOwnershipSignals().ClearSignals(ctx, repoId)
+	}
+
+	files, err := b.client.LsFiles(ctx, checker, repo.Name, commitID)
+	if err != nil {
+		return errors.Wrap(err, "LsFiles")
+	}
+	if len(files) > blameOwnershipMaxFiles {
+		b.logger.Warn("repository has too many files, only blaming some of them",
+			logger.Int("repo_id", int(repoId)),
+			logger.Int("files", len(files)),
+			logger.Int("limit", blameOwnershipMaxFiles))
+		files = files[:blameOwnershipMaxFiles]
+	}
+
+	agg := newBlameOwnershipAggregate(b.now(), blameOwnershipHalfLife)
+	for _, file := range files {
+		hunks, err := b.client.BlameFile(ctx, checker, repo.Name, file, &gitserver.BlameOptions{NewestCommit: commitID})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// A single file that cannot be blamed, for instance a submodule,
+			// should not fail indexing the whole repository.
+			b.logger.Debug("cannot blame file", logger.String("file", file), logger.Error(err))
+			continue
+		}
+		agg.add(file, hunks)
+	}
+
+	signals := agg.signals()
+	if err := b.db.BlameOwnershipSignals().ReplaceSignals(ctx, repoId, signals); err != nil {
+		return errors.Wrap(err, "ReplaceSignals")
+	}
+	b.logger.Info("blame ownership signals inserted", logger.Int("count", len(signals)), logger.Int("repo_id", int(repoId)))
+	blamedFilesCounter.Add(float64(len(files)))
+	return nil
+}
+
+type blameAuthor struct {
+	name, email string
+}
+
+type blameShare struct {
+	lines  int
+	weight float64
+}
+
+// blameOwnershipAggregate sums up the lines last changed by each author for
+// every blamed file and all its ancestor directories.
+type blameOwnershipAggregate struct {
+	now      time.Time
+	halfLife time.Duration
+	byPath   map[string]map[blameAuthor]*blameShare
+}
+
+func newBlameOwnershipAggregate(now time.Time, halfLife time.Duration) *blameOwnershipAggregate {
+	return &blameOwnershipAggregate{now: now, halfLife: halfLife, byPath: map[string]map[blameAuthor]*blameShare{}}
+}
+
+// weight returns the weight of a line last changed at given time. It halves
+// with every halfLife passed since the change.
+func (a *blameOwnershipAggregate) weight(changedAt time.Time) float64 {
+	age := a.now.Sub(changedAt)
+	if age < 0 {
+		age = 0
+	}
+	return math.Exp2(-float64(age) / float64(a.halfLife))
+}
+
+func (a *blameOwnershipAggregate) add(file string, hunks []*gitserver.Hunk) {
+	for _, h := range hunks {
+		lines := h.EndLine - h.StartLine
+		if lines <= 0 {
+			continue
+		}
+		author := blameAuthor{name: h.Author.Name, email: h.Author.Email}
+		weight := float64(lines) * a.weight(h.Author.Date)
+		// Attribute the lines to the file and all its ancestors, up to the
+		// repo root designated by an empty path.
+		for p := file; ; p = path.Dir(p) {
+			if p == "." {
+				p = ""
+			}
+			byAuthor, ok := a.byPath[p]
+			if !ok {
+				byAuthor = map[blameAuthor]*blameShare{}
+				a.byPath[p] = byAuthor
+			}
+			share, ok := byAuthor[author]
+			if !ok {
+				share = &blameShare{}
+				byAuthor[author] = share
+			}
+			share.lines += lines
+			share.weight += weight
+			if p == "" {
+				break
+			}
+		}
+	}
+}
+
+// signals returns the signals for all paths, in a deterministic order. The
+// score of an author is their share of the weighted lines of a path.
+func (a *blameOwnershipAggregate) signals() []database.BlameOwnershipSignal {
+	var signals []database.BlameOwnershipSignal
+	for p, byAuthor := range a.byPath {
+		var total float64
+		for _, share := range byAuthor {
+			total += share.weight
+		}
+		for author, share := range byAuthor {
+			var score float64
+			if total > 0 {
+				score = share.weight / total
+			}
+			signals = append(signals, database.BlameOwnershipSignal{
+				Path:        p,
+				AuthorName:  author.name,
+				AuthorEmail: author.email,
+				LinesCount:  share.lines,
+				Score:       score,
+			})
+		}
+	}
+	sort.Slice(signals, func(i, j int) bool {
+		if signals[i].Path != signals[j].Path {
+			return signals[i].Path < signals[j].Path
+		}
+		if signals[i].Score != signals[j].Score {
+			return signals[i].Score > signals[j].Score
+		}
+		return signals[i].AuthorEmail < signals[j].AuthorEmail
+	})
+	return signals
+}
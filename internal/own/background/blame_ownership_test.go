@@ -0,0 +1,115 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var blameTestNow = time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+func fakeHunk(name string, startLine, endLine int, age time.Duration) *gitserver.Hunk {
+	return &gitserver.Hunk{
+		StartLine: startLine,
+		EndLine:   endLine,
+		Author: gitdomain.Signature{
+			Name:  name,
+			Email: name + "@example.com",
+			Date:  blameTestNow.Add(-age),
+		},
+	}
+}
+
+func TestBlameOwnershipAggregate(t *testing.T) {
+	agg := newBlameOwnershipAggregate(blameTestNow, blameOwnershipHalfLife)
+	agg.add("dir/a.go", []*gitserver.Hunk{
+		// Alice wrote 10 lines a half-life ago, which weigh as much as the
+		// 5 lines Bob wrote just now.
+		fakeHunk("alice", 1, 11, blameOwnershipHalfLife),
+		fakeHunk("bob", 11, 16, 0),
+	})
+	agg.add("b.go", []*gitserver.Hunk{
+		fakeHunk("bob", 1, 4, 0),
+	})
+
+	want := []database.BlameOwnershipSignal{
+		{Path: "", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 8, Score: 8.0 / 13},
+		{Path: "", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 10, Score: 5.0 / 13},
+		{Path: "b.go", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 3, Score: 1},
+		{Path: "dir", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 10, Score: 0.5},
+		{Path: "dir", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 5, Score: 0.5},
+		{Path: "dir/a.go", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 10, Score: 0.5},
+		{Path: "dir/a.go", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 5, Score: 0.5},
+	}
+	got := agg.signals()
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Equal(t, want[i].Path, got[i].Path)
+		assert.Equal(t, want[i].AuthorEmail, got[i].AuthorEmail)
+		assert.Equal(t, want[i].LinesCount, got[i].LinesCount)
+		assert.InDelta(t, want[i].Score, got[i].Score, 1e-9)
+	}
+}
+
+func Test_BlameOwnershipIndexFromGitserver(t *testing.T) {
+	rcache.SetupForTest(t)
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	ctx := context.Background()
+
+	err := db.Repos().Create(ctx, &types.Repo{
+		ID:   1,
+		Name: "own/repo1",
+	})
+	require.NoError(t, err)
+
+	client := gitserver.NewMockClient()
+	client.GetDefaultBranchFunc.SetDefaultReturn("refs/heads/main", "deadbeef", nil)
+	client.LsFilesFunc.SetDefaultReturn([]string{"file1.txt", "dir/file2.txt"}, nil)
+	client.BlameFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, path string, opts *gitserver.BlameOptions) ([]*gitserver.Hunk, error) {
+		assert.Equal(t, api.CommitID("deadbeef"), opts.NewestCommit)
+		if path == "file1.txt" {
+			return []*gitserver.Hunk{fakeHunk("alice", 1, 4, 0), fakeHunk("bob", 4, 5, 0)}, nil
+		}
+		return []*gitserver.Hunk{fakeHunk("bob", 1, 3, 0)}, nil
+	})
+	indexer := newBlameOwnershipIndexer(client, db, logger, rcache.New("testing_own_signals"))
+	indexer.now = func() time.Time { return blameTestNow }
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.EnabledForRepoIDFunc.SetDefaultReturn(false, nil)
+	err = indexer.indexRepo(ctx, api.RepoID(1), checker)
+	require.NoError(t, err)
+
+	for p, w := range map[string][]database.BlameOwnerSummary{
+		"file1.txt": {
+			{FilePath: "file1.txt", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 3, Score: 0.75},
+			{FilePath: "file1.txt", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 1, Score: 0.25},
+		},
+		"dir": {
+			{FilePath: "dir", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 2, Score: 1},
+		},
+		"": {
+			{FilePath: "", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 3, Score: 0.5},
+			{FilePath: "", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 3, Score: 0.5},
+		},
+	} {
+		got, err := db.BlameOwnershipSignals().FindBlameOwners(ctx, 1, p)
+		require.NoError(t, err)
+		assert.Equal(t, w, got, "path %q", p)
+	}
+}
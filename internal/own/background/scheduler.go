@@ -33,6 +33,10 @@ var QueuePerRepoIndexJobs = []IndexJobType{
 		Name:            types.SignalRecentContributors,
 		IndexInterval:   time.Hour * 24,
 		RefreshInterval: time.Minute * 5,
+	}, {
+		Name:            types.SignalBlameOwnership,
+		IndexInterval:   time.Hour * 24 * 7,
+		RefreshInterval: time.Minute * 5,
 	}, {
 		Name:            types.Analytics,
 		IndexInterval:   time.Hour * 24,
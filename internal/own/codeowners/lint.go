@@ -0,0 +1,91 @@
+package codeowners
+
+import (
+	"sort"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/v1"
+)
+
+// LintResult is the outcome of evaluating a ruleset against all the files
+// of a repository.
+type LintResult struct {
+	// DeadRules are the rules whose pattern does not match any file.
+	DeadRules []*codeownerspb.Rule
+	// ShadowedRules are the rules whose pattern matches some files, but for
+	// each of them a rule further down the file takes precedence, so the rule
+	// never determines ownership.
+	ShadowedRules []ShadowedRule
+	// UnownedFiles are the files that either no rule matches, or the matching
+	// rule lists no owners.
+	UnownedFiles []string
+}
+
+type ShadowedRule struct {
+	Rule *codeownerspb.Rule
+	// ShadowedBy are the rules that take precedence for the files matched by
+	// Rule, in the order of the CODEOWNERS file.
+	ShadowedBy []*codeownerspb.Rule
+}
+
+// Lint evaluates the ruleset against given files, which are all the files of
+// a repository at the commit the ruleset is for. File paths are expected
+// without a leading `/`, as returned by git.
+func (x *Ruleset) Lint(files []string) LintResult {
+	var (
+		// matched[i] is true if rules[i] matches any file.
+		matched = make([]bool, len(x.rules))
+		// shadowedBy[i] are the indexes of the rules that take precedence for
+		// files matched by rules[i].
+		shadowedBy = make([]map[int]struct{}, len(x.rules))
+		// won[i] is true if rules[i] determines ownership of any file.
+		won = make([]bool, len(x.rules))
+		res LintResult
+	)
+	for _, file := range files {
+		path := "/" + file
+		winner := -1
+		for i := len(x.rules) - 1; i >= 0; i-- {
+			// Once a winner is found, we only need to evaluate rules that are
+			// not yet known to be shadowed by it.
+			if winner != -1 {
+				if _, ok := shadowedBy[i][winner]; ok || won[i] {
+					continue
+				}
+			}
+			if !x.rules[i].match(path) {
+				continue
+			}
+			matched[i] = true
+			if winner == -1 {
+				winner = i
+				won[i] = true
+				continue
+			}
+			if shadowedBy[i] == nil {
+				shadowedBy[i] = map[int]struct{}{}
+			}
+			shadowedBy[i][winner] = struct{}{}
+		}
+		if winner == -1 || len(x.rules[winner].proto.GetOwner()) == 0 {
+			res.UnownedFiles = append(res.UnownedFiles, file)
+		}
+	}
+	for i, r := range x.rules {
+		switch {
+		case !matched[i]:
+			res.DeadRules = append(res.DeadRules, r.proto)
+		case !won[i]:
+			var indexes []int
+			for j := range shadowedBy[i] {
+				indexes = append(indexes, j)
+			}
+			sort.Ints(indexes)
+			s := ShadowedRule{Rule: r.proto}
+			for _, j := range indexes {
+				s.ShadowedBy = append(s.ShadowedBy, x.rules[j].proto)
+			}
+			res.ShadowedRules = append(res.ShadowedRules, s)
+		}
+	}
+	return res
+}
@@ -0,0 +1,52 @@
+package codeowners_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+)
+
+func TestLint(t *testing.T) {
+	file, err := codeowners.Parse(strings.NewReader(strings.Join([]string{
+		"*.go @go-owner",
+		"/docs/ @docs-owner",
+		"*.rs @rust-owner",
+		"/cmd/*.go @cmd-owner",
+		"/cmd/ @cmd-owner",
+		"/docs/README.md",
+	}, "\n")))
+	require.NoError(t, err)
+	rs := codeowners.NewRuleset(codeowners.IngestedRulesetSource{}, file)
+
+	res := rs.Lint([]string{
+		"main.go",
+		"cmd/main.go",
+		"docs/index.md",
+		"docs/README.md",
+		"Makefile",
+	})
+
+	var dead []int32
+	for _, r := range res.DeadRules {
+		dead = append(dead, r.GetLineNumber())
+	}
+	assert.Equal(t, []int32{3}, dead, "dead rules")
+
+	var shadowed [][]int32
+	for _, s := range res.ShadowedRules {
+		lines := []int32{s.Rule.GetLineNumber()}
+		for _, r := range s.ShadowedBy {
+			lines = append(lines, r.GetLineNumber())
+		}
+		shadowed = append(shadowed, lines)
+	}
+	// The /cmd/*.go rule only matches cmd/main.go, for which /cmd/ takes precedence.
+	assert.Equal(t, [][]int32{{4, 5}}, shadowed, "shadowed rules")
+
+	// docs/README.md is matched by a rule without owners.
+	assert.Equal(t, []string{"docs/README.md", "Makefile"}, res.UnownedFiles)
+}
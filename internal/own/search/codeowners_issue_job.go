@@ -0,0 +1,143 @@
+package search
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewFileHasCodeownersIssueJob filters file matches down to the files whose
+// ownership as determined by the CODEOWNERS file of their repository has an
+// issue of all the given kinds. See query.FileHasCodeownersIssuePredicate.
+func NewFileHasCodeownersIssueJob(child job.Job, kinds []string) job.Job {
+	return &fileHasCodeownersIssueJob{
+		child: child,
+		kinds: kinds,
+	}
+}
+
+type fileHasCodeownersIssueJob struct {
+	child job.Job
+
+	kinds []string
+}
+
+func (s *fileHasCodeownersIssueJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var maxAlerter search.MaxAlerter
+
+	rules := NewRulesCache(clients.Gitserver, clients.DB)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = applyCodeownersIssueFiltering(ctx, &rules, s.kinds, event.Results)
+		if err != nil {
+			maxAlerter.Add(search.AlertForOwnershipSearchError())
+		}
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, filteredStream)
+	maxAlerter.Add(alert)
+	return maxAlerter.Alert, err
+}
+
+func (s *fileHasCodeownersIssueJob) Name() string {
+	return "FileHasCodeownersIssueFilterJob"
+}
+
+func (s *fileHasCodeownersIssueJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			attribute.StringSlice("kinds", s.kinds),
+		)
+	}
+	return res
+}
+
+func (s *fileHasCodeownersIssueJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *fileHasCodeownersIssueJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
+
+func applyCodeownersIssueFiltering(
+	ctx context.Context,
+	rules *RulesCache,
+	kinds []string,
+	matches []result.Match,
+) ([]result.Match, error) {
+	var errs error
+
+	filtered := matches[:0]
+
+matchesLoop:
+	for _, m := range matches {
+		mm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue matchesLoop
+		}
+		ruleset, err := rules.Codeowners(ctx, mm.Repo.Name, mm.Repo.ID, mm.CommitID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue matchesLoop
+		}
+		// Repositories without a CODEOWNERS file have no CODEOWNERS issues.
+		if ruleset.GetSource() == nil {
+			continue matchesLoop
+		}
+		rule := ruleset.Match(mm.File.Path)
+		unowned := len(rule.GetOwner()) == 0
+		for _, kind := range kinds {
+			if kind == query.CodeownersIssueUnowned || (kind == "" && unowned) {
+				if !unowned {
+					continue matchesLoop
+				}
+				continue
+			}
+			// The remaining kinds are both query.CodeownersIssueUnresolvedOwner
+			// and any issue for an owned file.
+			unresolved, err := rules.UnresolvedOwners(ctx, mm.Repo.Name, mm.Repo.ID, mm.CommitID)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				continue matchesLoop
+			}
+			if !hasUnresolvedOwner(rule, unresolved) {
+				continue matchesLoop
+			}
+		}
+		filtered = append(filtered, m)
+	}
+
+	return filtered, errs
+}
+
+// hasUnresolvedOwner returns true if any of the owners of given rule is in the
+// unresolved set, which holds owners as written in the CODEOWNERS file.
+func hasUnresolvedOwner(rule *codeownerspb.Rule, unresolved collections.Set[string]) bool {
+	for _, o := range rule.GetOwner() {
+		if unresolved.Has(own.OwnerText(o)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package search
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestApplyCodeownersIssueFiltering(t *testing.T) {
+	tests := []struct {
+		name        string
+		kinds       []string
+		repoContent map[string]string
+		want        []string
+	}{
+		{
+			name:  "no CODEOWNERS file",
+			kinds: []string{""},
+			want:  nil,
+		},
+		{
+			name:        "any issue",
+			kinds:       []string{""},
+			repoContent: map[string]string{"CODEOWNERS": "*.go @alice\n/docs/ @ghost\n"},
+			want:        []string{"docs/index.md", "README.md"},
+		},
+		{
+			name:        "unowned",
+			kinds:       []string{query.CodeownersIssueUnowned},
+			repoContent: map[string]string{"CODEOWNERS": "*.go @alice\n/docs/ @ghost\n"},
+			want:        []string{"README.md"},
+		},
+		{
+			name:        "unresolved owner",
+			kinds:       []string{query.CodeownersIssueUnresolvedOwner},
+			repoContent: map[string]string{"CODEOWNERS": "*.go @alice\n/docs/ @ghost\n"},
+			want:        []string{"docs/index.md"},
+		},
+		{
+			name:        "owners resolved through code host accounts or teams",
+			kinds:       []string{""},
+			repoContent: map[string]string{"CODEOWNERS": "*.go @alice\n/docs/ @org/docs\n* @alice\n"},
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			gitserverClient := gitserver.NewMockClient()
+			gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, file string) ([]byte, error) {
+				content, ok := tt.repoContent[file]
+				if !ok {
+					return nil, fs.ErrNotExist
+				}
+				return []byte(content), nil
+			})
+
+			codeownersStore := database.NewMockCodeownersStore()
+			codeownersStore.GetCodeownersForRepoFunc.SetDefaultReturn(nil, nil)
+			usersStore := database.NewMockUserStore()
+			usersStore.GetByUsernameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.User, error) {
+				if name == "alice" {
+					return &types.User{ID: 1, Username: "alice"}, nil
+				}
+				return nil, database.NewUserNotFoundErr()
+			})
+			usersStore.GetByIDFunc.SetDefaultReturn(&types.User{ID: 1, Username: "alice"}, nil)
+			usersStore.GetByVerifiedEmailFunc.SetDefaultReturn(nil, database.NewUserNotFoundErr())
+			teamsStore := database.NewMockTeamStore()
+			teamsStore.GetTeamByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Team, error) {
+				if name == "docs" {
+					return &types.Team{ID: 1, Name: "docs"}, nil
+				}
+				return nil, database.TeamNotFoundError{}
+			})
+			repoStore := database.NewMockRepoStore()
+			repoStore.GetFunc.SetDefaultReturn(&types.Repo{ExternalRepo: api.ExternalRepoSpec{ServiceType: "github"}}, nil)
+			db := database.NewMockDB()
+			db.CodeownersFunc.SetDefaultReturn(codeownersStore)
+			db.UsersFunc.SetDefaultReturn(usersStore)
+			db.TeamsFunc.SetDefaultReturn(teamsStore)
+			db.ReposFunc.SetDefaultReturn(repoStore)
+			db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+			db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
+
+			var matches []result.Match
+			for _, path := range []string{"main.go", "docs/index.md", "README.md"} {
+				matches = append(matches, &result.FileMatch{File: result.File{Path: path}})
+			}
+			rules := NewRulesCache(gitserverClient, db)
+			filtered, err := applyCodeownersIssueFiltering(ctx, &rules, tt.kinds, matches)
+			require.NoError(t, err)
+			var got []string
+			for _, m := range filtered {
+				got = append(got, m.(*result.FileMatch).Path)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
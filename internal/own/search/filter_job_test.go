@@ -531,6 +531,40 @@ func TestApplyCodeOwnershipFiltering(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "selects results owned through blame",
+			args: args{
+				includeOwners: []string{"blamed@example.com"},
+				excludeOwners: []string{},
+				matches: []result.Match{
+					&result.FileMatch{
+						File: result.File{
+							Path: "src/main/blamed.go",
+						},
+					},
+					&result.FileMatch{
+						File: result.File{
+							// Blame ownership is not inherited, so the owner
+							// of the directory does not own this file.
+							Path: "src/main/other.go",
+						},
+					},
+				},
+				// No CODEOWNERS
+				repoContent: map[string]string{},
+			},
+			setup: blameOwnerSetup([]database.BlameOwnerSummary{
+				{FilePath: "src/main/blamed.go", AuthorEmail: "blamed@example.com", Score: 0.7},
+				{FilePath: "src/main", AuthorEmail: "blamed@example.com", Score: 0.3},
+			}),
+			want: autogold.Expect([]result.Match{
+				&result.FileMatch{
+					File: result.File{
+						Path: "src/main/blamed.go",
+					},
+				},
+			}),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -566,6 +600,7 @@ func TestApplyCodeOwnershipFiltering(t *testing.T) {
 			userExternalAccountsStore.ListFunc.SetDefaultReturn(nil, nil)
 			db.UserExternalAccountsFunc.SetDefaultReturn(userExternalAccountsStore)
 			db.TeamsFunc.SetDefaultReturn(database.NewMockTeamStore())
+			db.OwnSignalConfigurationsFunc.SetDefaultReturn(database.NewMockSignalConfigurationStore())
 			repoStore := database.NewMockRepoStore()
 			repoStore.GetFunc.SetDefaultReturn(&types.Repo{ExternalRepo: api.ExternalRepoSpec{ServiceType: "github"}}, nil)
 			db.ReposFunc.SetDefaultReturn(repoStore)
@@ -621,3 +656,14 @@ func assignedOwnerSetup(path string, user *types.User) func(*database.MockDB) {
 		db.AssignedOwnersFunc.SetDefaultReturn(assignedOwnersStore)
 	}
 }
+
+func blameOwnerSetup(summaries []database.BlameOwnerSummary) func(*database.MockDB) {
+	return func(db *database.MockDB) {
+		configStore := database.NewMockSignalConfigurationStore()
+		configStore.IsEnabledFunc.SetDefaultReturn(true, nil)
+		db.OwnSignalConfigurationsFunc.SetDefaultReturn(configStore)
+		blameStore := database.NewMockBlameOwnershipSignalStore()
+		blameStore.ListBlameOwnersForRepoFunc.SetDefaultReturn(summaries, nil)
+		db.BlameOwnershipSignalsFunc.SetDefaultReturn(blameStore)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/own"
@@ -27,11 +28,15 @@ type RulesCache struct {
 	rules         map[RulesKey]*codeowners.Ruleset
 	assigned      map[AssignedKey]own.AssignedOwners
 	assignedTeams map[AssignedKey]own.AssignedTeams
+	blame         map[AssignedKey]own.BlameOwners
+	unresolved    map[RulesKey]collections.Set[string]
 	ownService    own.Service
 
 	rulesMu         sync.RWMutex
 	assignedMu      sync.RWMutex
 	assignedTeamsMu sync.RWMutex
+	blameMu         sync.RWMutex
+	unresolvedMu    sync.RWMutex
 }
 
 func NewRulesCache(gs gitserver.Client, db database.DB) RulesCache {
@@ -39,6 +44,8 @@ func NewRulesCache(gs gitserver.Client, db database.DB) RulesCache {
 		rules:         make(map[RulesKey]*codeowners.Ruleset),
 		assigned:      make(map[AssignedKey]own.AssignedOwners),
 		assignedTeams: make(map[AssignedKey]own.AssignedTeams),
+		blame:         make(map[AssignedKey]own.BlameOwners),
+		unresolved:    make(map[RulesKey]collections.Set[string]),
 		ownService:    own.NewService(gs, db),
 	}
 }
@@ -52,6 +59,10 @@ func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, repoName api.RepoN
 	if err != nil {
 		return repoOwnershipData{}, err
 	}
+	blame, err := c.BlameOwners(ctx, repoID, commitID)
+	if err != nil {
+		return repoOwnershipData{}, err
+	}
 	codeowners, err := c.Codeowners(ctx, repoName, repoID, commitID)
 	if err != nil {
 		return repoOwnershipData{}, err
@@ -59,6 +70,7 @@ func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, repoName api.RepoN
 	return repoOwnershipData{
 		assigned:      assigned,
 		assignedTeams: assignedTeams,
+		blame:         blame,
 		codeowners:    codeowners,
 	}, nil
 }
@@ -105,6 +117,27 @@ func (c *RulesCache) AssignedTeams(ctx context.Context, repoID api.RepoID, commi
 	return c.assignedTeams[key], nil
 }
 
+func (c *RulesCache) BlameOwners(ctx context.Context, repoID api.RepoID, commitID api.CommitID) (own.BlameOwners, error) {
+	c.blameMu.RLock()
+	key := AssignedKey{repoID}
+	if v, ok := c.blame[key]; ok {
+		defer c.blameMu.RUnlock()
+		return v, nil
+	}
+	c.blameMu.RUnlock()
+	c.blameMu.Lock()
+	defer c.blameMu.Unlock()
+	if _, ok := c.blame[key]; !ok {
+		blame, err := c.ownService.BlameOwnership(ctx, repoID, commitID)
+		if err != nil {
+			// Error is picked up on a call site and in most cases a search alert is created.
+			return nil, err
+		}
+		c.blame[key] = blame
+	}
+	return c.blame[key], nil
+}
+
 func (c *RulesCache) Codeowners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (*codeowners.Ruleset, error) {
 	c.rulesMu.RLock()
 	key := RulesKey{repoName, commitID}
@@ -130,10 +163,39 @@ func (c *RulesCache) Codeowners(ctx context.Context, repoName api.RepoName, repo
 	return c.rules[key], nil
 }
 
+// UnresolvedOwners returns the owners of the CODEOWNERS file of the repository
+// at given commit, as written in the file, that do not resolve to any user,
+// team or code host account.
+func (c *RulesCache) UnresolvedOwners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (collections.Set[string], error) {
+	ruleset, err := c.Codeowners(ctx, repoName, repoID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	c.unresolvedMu.RLock()
+	key := RulesKey{repoName, commitID}
+	if _, ok := c.unresolved[key]; ok {
+		defer c.unresolvedMu.RUnlock()
+		return c.unresolved[key], nil
+	}
+	c.unresolvedMu.RUnlock()
+	c.unresolvedMu.Lock()
+	defer c.unresolvedMu.Unlock()
+	// Recheck condition.
+	if _, ok := c.unresolved[key]; !ok {
+		unresolved, err := c.ownService.UnresolvedOwners(ctx, ruleset, repoName)
+		if err != nil {
+			return nil, err
+		}
+		c.unresolved[key] = unresolved
+	}
+	return c.unresolved[key], nil
+}
+
 type repoOwnershipData struct {
 	codeowners    *codeowners.Ruleset
 	assigned      own.AssignedOwners
 	assignedTeams own.AssignedTeams
+	blame         own.BlameOwners
 }
 
 func (o repoOwnershipData) Match(path string) fileOwnershipData {
@@ -145,6 +207,7 @@ func (o repoOwnershipData) Match(path string) fileOwnershipData {
 		rule:           rule,
 		assignedOwners: o.assigned.Match(path),
 		assignedTeams:  o.assignedTeams.Match(path),
+		blameOwners:    o.blame.Match(path),
 	}
 }
 
@@ -152,6 +215,7 @@ type fileOwnershipData struct {
 	rule           *codeownerspb.Rule
 	assignedOwners []database.AssignedOwnerSummary
 	assignedTeams  []database.AssignedTeamSummary
+	blameOwners    []database.BlameOwnerSummary
 }
 
 func (d fileOwnershipData) References() []own.Reference {
@@ -165,6 +229,9 @@ func (d fileOwnershipData) References() []own.Reference {
 	for _, o := range d.assignedTeams {
 		rs = append(rs, own.Reference{TeamID: o.OwnerTeamID})
 	}
+	for _, o := range d.blameOwners {
+		rs = append(rs, own.Reference{Email: o.AuthorEmail})
+	}
 	return rs
 }
 
@@ -182,6 +249,9 @@ func (d fileOwnershipData) NonEmpty() bool {
 	if len(d.assignedTeams) > 0 {
 		return true
 	}
+	if len(d.blameOwners) > 0 {
+		return true
+	}
 	return false
 }
 
@@ -204,6 +274,11 @@ func (d fileOwnershipData) IsWithin(bag own.Bag) bool {
 			return true
 		}
 	}
+	for _, o := range d.blameOwners {
+		if bag.Contains(own.Reference{Email: o.AuthorEmail}) {
+			return true
+		}
+	}
 	return false
 }
 
@@ -223,5 +298,8 @@ func (d fileOwnershipData) String() string {
 	for _, o := range d.assignedTeams {
 		references = append(references, fmt.Sprintf("#%d", o.OwnerTeamID))
 	}
+	for _, o := range d.blameOwners {
+		references = append(references, o.AuthorEmail)
+	}
 	return fmt.Sprintf("[%s]", strings.Join(references, ", "))
 }
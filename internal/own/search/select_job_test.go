@@ -31,6 +31,7 @@ func TestGetCodeOwnersFromMatches(t *testing.T) {
 		db.CodeownersFunc.SetDefaultReturn(codeownersStore)
 		db.AssignedOwnersFunc.SetDefaultReturn(database.NewMockAssignedOwnersStore())
 		db.AssignedTeamsFunc.SetDefaultReturn(database.NewMockAssignedTeamsStore())
+		db.OwnSignalConfigurationsFunc.SetDefaultReturn(database.NewMockSignalConfigurationStore())
 		db.ReposFunc.SetDefaultReturn(repoStore)
 		return db
 	}
@@ -8,10 +8,12 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/own/types"
 )
 
 // Service gives access to code ownership data.
@@ -37,6 +39,22 @@ type Service interface {
 	// team of 'src/test' in a given repo transitively owns all files within the
 	// directory tree at that root like 'src/test/com/sourcegraph/Test.java'.
 	AssignedTeams(context.Context, api.RepoID, api.CommitID) (AssignedTeams, error)
+
+	// BlameOwnership returns the authors of a significant share of the current
+	// lines of each file and directory tree in given repo, as indexed from git
+	// blame by the blame-ownership signal. Unlike assigned ownership, it is not
+	// inherited down the file tree. It is empty if the signal is disabled.
+	BlameOwnership(context.Context, api.RepoID, api.CommitID) (BlameOwners, error)
+
+	// ValidateCodeowners checks the CODEOWNERS file of a given repository at
+	// given commit against the files of the repository and the known users
+	// and teams. It returns nil if the repository has no CODEOWNERS file.
+	ValidateCodeowners(context.Context, api.RepoName, api.RepoID, api.CommitID) (*CodeownersValidation, error)
+
+	// UnresolvedOwners returns the owners of given CODEOWNERS ruleset that do
+	// not resolve to any user, team or code host account, as they are written
+	// in the file.
+	UnresolvedOwners(context.Context, *codeowners.Ruleset, api.RepoName) (collections.Set[string], error)
 }
 
 type AssignedOwners map[string][]database.AssignedOwnerSummary
@@ -71,6 +89,18 @@ func match[T any](assigned map[string][]T, path string) []T {
 	return summaries
 }
 
+type BlameOwners map[string][]database.BlameOwnerSummary
+
+// Match returns the blame owner summaries for exactly the given path.
+func (bo BlameOwners) Match(path string) []database.BlameOwnerSummary {
+	return bo[path]
+}
+
+// MinBlameOwnershipScore is the share of the weighted lines of a file or
+// directory tree its author needs to be considered an owner by the
+// blame-ownership signal.
+const MinBlameOwnershipScore = 0.2
+
 var _ Service = &service{}
 
 func NewService(g gitserver.Client, db database.DB) Service {
@@ -170,3 +200,22 @@ func (s *service) AssignedTeams(ctx context.Context, repoID api.RepoID, _ api.Co
 	}
 	return assignedTeams, nil
 }
+
+func (s *service) BlameOwnership(ctx context.Context, repoID api.RepoID, _ api.CommitID) (BlameOwners, error) {
+	enabled, err := s.db.OwnSignalConfigurations().IsEnabled(ctx, types.SignalBlameOwnership)
+	if err != nil {
+		return nil, err
+	}
+	blameOwners := BlameOwners{}
+	if !enabled {
+		return blameOwners, nil
+	}
+	summaries, err := s.db.BlameOwnershipSignals().ListBlameOwnersForRepo(ctx, repoID, MinBlameOwnershipScore)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries {
+		blameOwners[summary.FilePath] = append(blameOwners[summary.FilePath], summary)
+	}
+	return blameOwners, nil
+}
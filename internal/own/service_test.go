@@ -521,3 +521,40 @@ func createTeam(t *testing.T, ctx context.Context, db database.DB, teamName stri
 	require.NoError(t, err)
 	return team
 }
+
+func TestBlameOwnership(t *testing.T) {
+	summaries := []database.BlameOwnerSummary{
+		{FilePath: "", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 10, Score: 0.8},
+		{FilePath: "src/main", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 4, Score: 0.5},
+		{FilePath: "src/main", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 4, Score: 0.5},
+	}
+	blameStore := database.NewMockBlameOwnershipSignalStore()
+	blameStore.ListBlameOwnersForRepoFunc.SetDefaultHook(func(_ context.Context, repoID api.RepoID, minScore float64) ([]database.BlameOwnerSummary, error) {
+		assert.Equal(t, api.RepoID(1), repoID)
+		assert.Equal(t, MinBlameOwnershipScore, minScore)
+		return summaries, nil
+	})
+	configStore := database.NewMockSignalConfigurationStore()
+	db := database.NewMockDB()
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(blameStore)
+	db.OwnSignalConfigurationsFunc.SetDefaultReturn(configStore)
+	s := NewService(nil, db)
+	ctx := context.Background()
+
+	t.Run("disabled", func(t *testing.T) {
+		configStore.IsEnabledFunc.SetDefaultReturn(false, nil)
+		got, err := s.BlameOwnership(ctx, 1, "sha")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		configStore.IsEnabledFunc.SetDefaultReturn(true, nil)
+		got, err := s.BlameOwnership(ctx, 1, "sha")
+		require.NoError(t, err)
+		assert.Equal(t, summaries[1:], got.Match("src/main"))
+		// Blame ownership is not inherited from parent directories.
+		assert.Empty(t, got.Match("src/main/foo.go"))
+		assert.Equal(t, summaries[:1], got.Match(""))
+	})
+}
@@ -20,5 +20,6 @@ type CodeownersFile struct {
 const (
 	SignalRecentContributors = "recent-contributors"
 	SignalRecentViews        = "recent-views"
+	SignalBlameOwnership     = "blame-ownership"
 	Analytics                = "analytics"
 )
@@ -0,0 +1,278 @@
+package own
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type CodeownersIssueKind string
+
+const (
+	// UnresolvedOwner is an owner in a CODEOWNERS rule that does not
+	// resolve to any user, team or code host account.
+	UnresolvedOwner CodeownersIssueKind = "UNRESOLVED_OWNER"
+	// DeadPattern is a CODEOWNERS rule whose pattern matches no file.
+	DeadPattern CodeownersIssueKind = "DEAD_PATTERN"
+	// ShadowedRule is a CODEOWNERS rule that never determines ownership,
+	// because rules further down the file take precedence for every file
+	// it matches.
+	ShadowedRule CodeownersIssueKind = "SHADOWED_RULE"
+	// UnownedFile is a file of the repository without owners.
+	UnownedFile CodeownersIssueKind = "UNOWNED_FILE"
+)
+
+// CodeownersIssue is a single problem found in a CODEOWNERS file.
+type CodeownersIssue struct {
+	Kind CodeownersIssueKind
+	// Rule is the rule the issue was found for. It is nil for UnownedFile.
+	Rule *codeownerspb.Rule
+	// Owner is the text of the unresolved owner for UnresolvedOwner.
+	Owner string
+	// Path is the unowned file for UnownedFile.
+	Path string
+	// ShadowedBy are the rules taking precedence for ShadowedRule.
+	ShadowedBy []*codeownerspb.Rule
+}
+
+func (i CodeownersIssue) Message() string {
+	switch i.Kind {
+	case UnresolvedOwner:
+		return fmt.Sprintf("Owner %s on line %d does not match any user, team or code host account.", i.Owner, i.Rule.GetLineNumber())
+	case DeadPattern:
+		return fmt.Sprintf("Pattern %s on line %d does not match any file.", i.Rule.GetPattern(), i.Rule.GetLineNumber())
+	case ShadowedRule:
+		lines := make([]string, 0, len(i.ShadowedBy))
+		for _, r := range i.ShadowedBy {
+			lines = append(lines, fmt.Sprint(r.GetLineNumber()))
+		}
+		return fmt.Sprintf("Rule on line %d never applies, because the rules on lines %s take precedence for all the files it matches.", i.Rule.GetLineNumber(), strings.Join(lines, ", "))
+	case UnownedFile:
+		return fmt.Sprintf("File %s has no owner.", i.Path)
+	}
+	return ""
+}
+
+// CodeownersValidation is the result of validating the CODEOWNERS file of a
+// repository at a given commit.
+type CodeownersValidation struct {
+	Ruleset *codeowners.Ruleset
+	// TotalFiles is the number of files the CODEOWNERS file was checked against.
+	TotalFiles int
+	// UnownedFilesCount is the number of files without owners. Only the first
+	// MaxReportedUnownedFiles of them are reported as issues.
+	UnownedFilesCount int
+	// Issues are ordered by the line of the CODEOWNERS file they were found
+	// at, followed by unowned files.
+	Issues []CodeownersIssue
+}
+
+// MaxReportedUnownedFiles is the maximum number of UnownedFile issues in a
+// CodeownersValidation.
+const MaxReportedUnownedFiles = 1000
+
+func (s *service) ValidateCodeowners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (*CodeownersValidation, error) {
+	ruleset, err := s.RulesetForRepo(ctx, repoName, repoID, commitID)
+	if err != nil || ruleset == nil {
+		return nil, err
+	}
+	files, err := s.gitserverClient.LsFiles(ctx, authz.DefaultSubRepoPermsChecker, repoName, commitID)
+	if err != nil {
+		return nil, errors.Wrap(err, "LsFiles")
+	}
+	unresolved, err := s.UnresolvedOwners(ctx, ruleset, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	lint := ruleset.Lint(files)
+	var issues []CodeownersIssue
+	for _, r := range ruleset.GetFile().GetRule() {
+		for _, o := range r.GetOwner() {
+			if text := OwnerText(o); unresolved.Has(text) {
+				issues = append(issues, CodeownersIssue{Kind: UnresolvedOwner, Rule: r, Owner: text})
+			}
+		}
+	}
+	for _, r := range lint.DeadRules {
+		issues = append(issues, CodeownersIssue{Kind: DeadPattern, Rule: r})
+	}
+	for _, sr := range lint.ShadowedRules {
+		issues = append(issues, CodeownersIssue{Kind: ShadowedRule, Rule: sr.Rule, ShadowedBy: sr.ShadowedBy})
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Rule.GetLineNumber() < issues[j].Rule.GetLineNumber()
+	})
+	for i, path := range lint.UnownedFiles {
+		if i == MaxReportedUnownedFiles {
+			break
+		}
+		issues = append(issues, CodeownersIssue{Kind: UnownedFile, Path: path})
+	}
+	return &CodeownersValidation{
+		Ruleset:           ruleset,
+		TotalFiles:        len(files),
+		UnownedFilesCount: len(lint.UnownedFiles),
+		Issues:            issues,
+	}, nil
+}
+
+func (s *service) UnresolvedOwners(ctx context.Context, ruleset *codeowners.Ruleset, repoName api.RepoName) (collections.Set[string], error) {
+	var repoContext *RepoContext
+	if t := ruleset.GetCodeHostType(); t != "" {
+		repoContext = &RepoContext{Name: repoName, CodeHostKind: t}
+	}
+	owners := map[string]Reference{}
+	b := EmptyBag()
+	for _, r := range ruleset.GetFile().GetRule() {
+		for _, o := range r.GetOwner() {
+			text := OwnerText(o)
+			if _, ok := owners[text]; ok {
+				continue
+			}
+			ref := Reference{RepoContext: repoContext, Handle: o.GetHandle(), Email: o.GetEmail()}
+			owners[text] = ref
+			b.Add(ref)
+			// Team handles in GitHub and GitLab CODEOWNERS files are prefixed
+			// with the organization, which synced teams may not be.
+			if _, name, ok := strings.Cut(o.GetHandle(), "/"); ok {
+				b.Add(Reference{Handle: name})
+			}
+		}
+	}
+	b.Resolve(ctx, s.db)
+
+	unresolved := collections.NewSet[string]()
+	var unresolvedHandles []string
+	for text, ref := range owners {
+		if _, ok := b.FindResolved(ref); ok {
+			continue
+		}
+		if _, name, ok := strings.Cut(ref.Handle, "/"); ok {
+			if _, ok := b.FindResolved(Reference{Handle: name}); ok {
+				continue
+			}
+		}
+		unresolved.Add(text)
+		if ref.Handle != "" {
+			unresolvedHandles = append(unresolvedHandles, text)
+		}
+	}
+	if len(unresolvedHandles) == 0 || repoContext == nil {
+		return unresolved, nil
+	}
+
+	// Handles may also be code host handles of people who did not sign in to
+	// Sourcegraph with that code host, but whose accounts were synced.
+	wanted := make([]string, 0, len(unresolvedHandles))
+	for _, text := range unresolvedHandles {
+		wanted = append(wanted, strings.ToLower(strings.TrimPrefix(text, "@")))
+	}
+	handles, err := codeHostHandles(ctx, s.db, repoContext.CodeHostKind, wanted)
+	if err != nil {
+		return nil, err
+	}
+	for _, text := range unresolvedHandles {
+		if handles.Has(strings.ToLower(strings.TrimPrefix(text, "@"))) {
+			unresolved.Remove(text)
+		}
+	}
+	return unresolved, nil
+}
+
+const (
+	// codeHostHandlesPageSize is the number of external accounts
+	// codeHostHandles decrypts at a time.
+	codeHostHandlesPageSize = 500
+	// codeHostHandlesTTL is how long codeHostHandles remembers whether a
+	// handle belongs to an external account.
+	codeHostHandlesTTL = 5 * time.Minute
+)
+
+// codeHostHandlesCache maps a service type and lowercased handle to whether
+// the handle belongs to an external account of that service type. Login names
+// are only stored encrypted, so every lookup has to decrypt accounts.
+var codeHostHandlesCache = func() *lru.Cache[string, cachedHandle] {
+	c, _ := lru.New[string, cachedHandle](10000)
+	return c
+}()
+
+type cachedHandle struct {
+	found   bool
+	expires time.Time
+}
+
+// codeHostHandles returns which of the given lowercased handles belong to a
+// non-expired external account of given code host kind. It pages through the
+// accounts only until all handles are found, and caches the results.
+func codeHostHandles(ctx context.Context, db database.DB, serviceType string, handles []string) (collections.Set[string], error) {
+	found := collections.NewSet[string]()
+	p := providers.GetProviderbyServiceType(serviceType)
+	if p == nil {
+		return found, nil
+	}
+
+	now := time.Now()
+	pending := collections.NewSet[string]()
+	for _, h := range handles {
+		if c, ok := codeHostHandlesCache.Get(serviceType + ":" + h); ok && now.Before(c.expires) {
+			if c.found {
+				found.Add(h)
+			}
+			continue
+		}
+		pending.Add(h)
+	}
+
+	expires := now.Add(codeHostHandlesTTL)
+	for offset := 0; !pending.IsEmpty(); offset += codeHostHandlesPageSize {
+		accounts, err := db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+			ServiceType:    serviceType,
+			ExcludeExpired: true,
+			LimitOffset:    &database.LimitOffset{Limit: codeHostHandlesPageSize, Offset: offset},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "UserExternalAccounts.List")
+		}
+		for _, account := range accounts {
+			data, err := p.ExternalAccountInfo(ctx, *account)
+			// Best effort: an account with broken data just cannot be matched.
+			if err != nil || data == nil || data.Login == nil {
+				continue
+			}
+			if login := strings.ToLower(*data.Login); pending.Has(login) {
+				pending.Remove(login)
+				found.Add(login)
+				codeHostHandlesCache.Add(serviceType+":"+login, cachedHandle{found: true, expires: expires})
+			}
+		}
+		if len(accounts) < codeHostHandlesPageSize {
+			break
+		}
+	}
+	for h := range pending {
+		codeHostHandlesCache.Add(serviceType+":"+h, cachedHandle{expires: expires})
+	}
+	return found, nil
+}
+
+// OwnerText returns the owner as written in the CODEOWNERS file.
+func OwnerText(o *codeownerspb.Owner) string {
+	if h := o.GetHandle(); h != "" {
+		return "@" + h
+	}
+	return o.GetEmail()
+}
@@ -0,0 +1,147 @@
+package own
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/own/types"
+	itypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestValidateCodeowners(t *testing.T) {
+	codeownersFile, err := codeowners.Parse(strings.NewReader(strings.Join([]string{
+		"*.go @alice",
+		"/docs/ @sourcegraph/docs-team ghost@example.com",
+		"*.rs @alice",
+		"/cmd/*.go @ghost",
+		"/cmd/ @alice",
+	}, "\n")))
+	require.NoError(t, err)
+
+	git := gitserver.NewMockClient()
+	git.LsFilesFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, _ ...gitdomain.Pathspec) ([]string, error) {
+		assert.Equal(t, api.RepoName("repo"), repo)
+		assert.Equal(t, api.CommitID("SHA"), commit)
+		return []string{"main.go", "cmd/main.go", "docs/index.md", "Makefile"}, nil
+	})
+
+	codeownersStore := database.NewMockCodeownersStore()
+	codeownersStore.GetCodeownersForRepoFunc.SetDefaultReturn(&types.CodeownersFile{Proto: codeownersFile}, nil)
+	reposStore := database.NewMockRepoStore()
+	reposStore.GetFunc.SetDefaultReturn(&itypes.Repo{ExternalRepo: api.ExternalRepoSpec{ServiceType: "github"}}, nil)
+	usersStore := database.NewMockUserStore()
+	usersStore.GetByUsernameFunc.SetDefaultHook(func(_ context.Context, username string) (*itypes.User, error) {
+		if username == "alice" {
+			return &itypes.User{ID: 1, Username: "alice"}, nil
+		}
+		return nil, database.NewUserNotFoundErr()
+	})
+	usersStore.GetByIDFunc.SetDefaultReturn(&itypes.User{ID: 1, Username: "alice"}, nil)
+	usersStore.GetByVerifiedEmailFunc.SetDefaultReturn(nil, database.NewUserNotFoundErr())
+	teamsStore := database.NewMockTeamStore()
+	teamsStore.GetTeamByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*itypes.Team, error) {
+		// Synced teams are not prefixed with the organization.
+		if name == "docs-team" {
+			return &itypes.Team{ID: 1, Name: "docs-team"}, nil
+		}
+		return nil, database.TeamNotFoundError{}
+	})
+	db := database.NewMockDB()
+	db.CodeownersFunc.SetDefaultReturn(codeownersStore)
+	db.ReposFunc.SetDefaultReturn(reposStore)
+	db.UsersFunc.SetDefaultReturn(usersStore)
+	db.TeamsFunc.SetDefaultReturn(teamsStore)
+	db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+	db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
+
+	got, err := NewService(git, db).ValidateCodeowners(context.Background(), "repo", 1, "SHA")
+	require.NoError(t, err)
+	assert.Equal(t, 4, got.TotalFiles)
+	assert.Equal(t, 1, got.UnownedFilesCount)
+
+	var messages []string
+	for _, issue := range got.Issues {
+		messages = append(messages, issue.Message())
+	}
+	assert.Equal(t, []string{
+		"Owner ghost@example.com on line 2 does not match any user, team or code host account.",
+		"Pattern *.rs on line 3 does not match any file.",
+		"Owner @ghost on line 4 does not match any user, team or code host account.",
+		"Rule on line 4 never applies, because the rules on lines 5 take precedence for all the files it matches.",
+		"File Makefile has no owner.",
+	}, messages)
+}
+
+// loginProvider is an auth provider whose accounts have their account ID as
+// login.
+type loginProvider struct {
+	providers.MockAuthProvider
+}
+
+func (loginProvider) ExternalAccountInfo(_ context.Context, account extsvc.Account) (*extsvc.PublicAccountData, error) {
+	login := account.AccountID
+	return &extsvc.PublicAccountData{Login: &login}, nil
+}
+
+func TestCodeHostHandles(t *testing.T) {
+	providers.MockProviders = []providers.Provider{loginProvider{providers.MockAuthProvider{
+		MockConfigID: providers.ConfigID{Type: extsvc.TypeGitHub},
+	}}}
+	codeHostHandlesCache.Purge()
+	t.Cleanup(func() {
+		providers.MockProviders = nil
+		codeHostHandlesCache.Purge()
+	})
+
+	var accounts []*extsvc.Account
+	for i := 0; i < 1200; i++ {
+		accounts = append(accounts, &extsvc.Account{AccountSpec: extsvc.AccountSpec{AccountID: fmt.Sprintf("User%d", i)}})
+	}
+	var pages int
+	accountsStore := database.NewMockUserExternalAccountsStore()
+	accountsStore.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		pages++
+		start := opts.LimitOffset.Offset
+		end := start + opts.LimitOffset.Limit
+		if end > len(accounts) {
+			end = len(accounts)
+		}
+		return accounts[start:end], nil
+	})
+	db := database.NewMockDB()
+	db.UserExternalAccountsFunc.SetDefaultReturn(accountsStore)
+
+	// Paging stops once all handles are found.
+	got, err := codeHostHandles(context.Background(), db, extsvc.TypeGitHub, []string{"user3", "user700"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user3", "user700"}, got.Sorted(collections.NaturalCompare[string]))
+	assert.Equal(t, 2, pages)
+
+	// Unknown handles are looked up in all accounts, known ones are cached.
+	pages = 0
+	got, err = codeHostHandles(context.Background(), db, extsvc.TypeGitHub, []string{"user3", "ghost"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user3"}, got.Values())
+	assert.Equal(t, 3, pages)
+
+	// Both results are cached now.
+	pages = 0
+	got, err = codeHostHandles(context.Background(), db, extsvc.TypeGitHub, []string{"user700", "ghost"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user700"}, got.Values())
+	assert.Zero(t, pages)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	ghauth "github.com/sourcegraph/sourcegraph/internal/extsvc/github/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -62,6 +63,10 @@ func cloneURL(ctx context.Context, db database.DB, parsed any, logger log.Logger
 		if r, ok := repo.Metadata.(*gerrit.Project); ok {
 			return gerritCloneURL(logger, r, t), nil
 		}
+	case *schema.GiteaConnection:
+		if r, ok := repo.Metadata.(*gitea.Repository); ok {
+			return giteaCloneURL(logger, r, t), nil
+		}
 	case *schema.GitHubConnection:
 		if r, ok := repo.Metadata.(*github.Repository); ok {
 			return githubCloneURL(ctx, logger, db, r, t)
@@ -100,6 +105,10 @@ func cloneURL(ctx context.Context, db database.DB, parsed any, logger log.Logger
 		return string(repo.Name), nil
 	case *schema.RubyPackagesConnection:
 		return string(repo.Name), nil
+	case *schema.NuGetPackagesConnection:
+		return string(repo.Name), nil
+	case *schema.PHPPackagesConnection:
+		return string(repo.Name), nil
 	case *schema.JVMPackagesConnection:
 		if r, ok := repo.Metadata.(*reposource.MavenMetadata); ok {
 			return r.Module.CloneURL(), nil
@@ -190,6 +199,22 @@ func bitbucketCloudCloneURL(logger log.Logger, repo *bitbucketcloud.Repo, cfg *s
 	return u.String()
 }
 
+// giteaCloneURL returns the Gitea repository's Git remote URL with the
+// configured access token inserted in the URL userinfo. Gitea accepts a token
+// as the username without a password.
+func giteaCloneURL(logger log.Logger, repo *gitea.Repository, cfg *schema.GiteaConnection) string {
+	if cfg.GitURLType == "ssh" {
+		return repo.SSHURL // SSH authentication must be provided out-of-band
+	}
+	u, err := url.Parse(repo.CloneURL)
+	if err != nil {
+		logger.Warn("Error adding authentication to Gitea repository Git remote URL.", log.String("url", repo.CloneURL), log.Error(err))
+		return repo.CloneURL
+	}
+	u.User = url.User(cfg.Token)
+	return u.String()
+}
+
 func githubCloneURL(ctx context.Context, logger log.Logger, db database.DB, repo *github.Repository, cfg *schema.GitHubConnection) (string, error) {
 	if cfg.GitURLType == "ssh" {
 		baseURL, err := url.Parse(cfg.Url)
@@ -0,0 +1,267 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea or Forgejo connection
+// configured in Sourcegraph via the external services configuration.
+type GiteaSource struct {
+	svc     *types.ExternalService
+	config  *schema.GiteaConnection
+	exclude excludeFunc
+	cli     *gitea.Client
+	logger  log.Logger
+}
+
+var _ Source = &GiteaSource{}
+var _ UserSource = &GiteaSource{}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, logger log.Logger, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	var opts []httpcli.Opt
+	if c.Certificate != "" {
+		opts = append(opts, httpcli.NewCertPoolOpt(c.Certificate))
+	}
+
+	httpCli, err := cf.Doer(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		if r.Archived {
+			eb.Generic(func(repo any) bool {
+				if r, ok := repo.(gitea.Repository); ok {
+					return r.Archived
+				}
+				return false
+			})
+		}
+		if r.Forks {
+			eb.Generic(func(repo any) bool {
+				if r, ok := repo.(gitea.Repository); ok {
+					return r.Fork
+				}
+				return false
+			})
+		}
+		eb.Exact(r.Name)
+		if r.Id != 0 {
+			eb.Exact(strconv.Itoa(r.Id))
+		}
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{
+		svc:     svc,
+		config:  &c,
+		exclude: exclude,
+		cli:     gitea.NewClient(svc.URN(), baseURL, &auth.OAuthBearerToken{Token: c.Token}, httpCli),
+		logger:  logger,
+	}, nil
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s *GiteaSource) WithAuthenticator(a auth.Authenticator) (Source, error) {
+	cli, err := s.cli.WithAuthenticator(a)
+	if err != nil {
+		return nil, newUnsupportedAuthenticatorError("GiteaSource", a)
+	}
+
+	sc := *s
+	sc.cli = cli
+	return &sc, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+func (s *GiteaSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.cli.GetAuthenticatedUser(ctx)
+	return err
+}
+
+// CheckConnection returns an error if the Gitea API cannot be reached with the
+// configured token.
+func (s *GiteaSource) CheckConnection(ctx context.Context) error {
+	if _, err := s.cli.GetAuthenticatedUser(ctx); err != nil {
+		return errors.Wrap(err, "connection check failed. could not fetch authenticated user")
+	}
+	return nil
+}
+
+// ListRepos returns all Gitea repositories configured with this GiteaSource's
+// config.
+func (s *GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[int64]bool)
+	emit := func(repos ...*gitea.Repository) {
+		for _, r := range repos {
+			if seen[r.ID] || s.excludes(r) {
+				continue
+			}
+			seen[r.ID] = true
+			results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+		}
+	}
+
+	for _, name := range s.config.Repos {
+		owner, repoName, ok := strings.Cut(name, "/")
+		if !ok {
+			results <- SourceResult{Source: s, Err: errors.Errorf("invalid Gitea repository name %q, expected \"owner/name\"", name)}
+			continue
+		}
+
+		repo, err := s.cli.GetRepo(ctx, owner, repoName)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				s.logger.Warn("skipping missing gitea.repos entry", log.String("name", name), log.Error(err))
+				continue
+			}
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.repos: name: %q", name)}
+			continue
+		}
+		emit(repo)
+	}
+
+	for _, org := range s.config.Orgs {
+		err := s.paginate(ctx, emit, func(page int) ([]*gitea.Repository, bool, error) {
+			return s.cli.ListOrgRepos(ctx, org, page)
+		})
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.orgs: name: %q", org)}
+		}
+	}
+
+	for _, query := range s.config.RepositoryQuery {
+		var list func(page int) ([]*gitea.Repository, bool, error)
+		switch query {
+		case "none":
+			continue
+		case "affiliated":
+			list = func(page int) ([]*gitea.Repository, bool, error) {
+				return s.cli.ListAuthenticatedUserRepos(ctx, page)
+			}
+		case "all":
+			list = func(page int) ([]*gitea.Repository, bool, error) {
+				return s.cli.SearchRepos(ctx, "", page)
+			}
+		default:
+			query := query
+			list = func(page int) ([]*gitea.Repository, bool, error) {
+				return s.cli.SearchRepos(ctx, query, page)
+			}
+		}
+
+		if err := s.paginate(ctx, emit, list); err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.repositoryQuery: %q", query)}
+		}
+	}
+}
+
+// paginate calls list for consecutive pages until there are no more pages,
+// passing each page of repositories to emit.
+func (s *GiteaSource) paginate(ctx context.Context, emit func(...*gitea.Repository), list func(page int) ([]*gitea.Repository, bool, error)) error {
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		repos, hasNext, err := list(page)
+		if err != nil {
+			return err
+		}
+		emit(repos...)
+
+		if !hasNext {
+			return nil
+		}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repository) bool {
+	return s.exclude(r.FullName) || s.exclude(strconv.FormatInt(r.ID, 10)) || s.exclude(*r)
+}
+
+func (s *GiteaSource) makeRepo(r *gitea.Repository) *types.Repo {
+	urn := s.svc.URN()
+	host := s.cli.URL.Hostname()
+
+	return &types.Repo{
+		Name:        reposource.GiteaRepoName(s.config.RepositoryPathPattern, host, r.FullName),
+		URI:         string(reposource.GiteaRepoName("", host, r.FullName)),
+		Description: r.Description,
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		Stars:       r.StarsCount,
+		Private:     r.Restricted(),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(r.ID, 10),
+			ServiceType: extsvc.VariantGitea.AsType(),
+			ServiceID:   s.cli.URL.String(),
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.remoteURL(r),
+			},
+		},
+		Metadata: r,
+	}
+}
+
+// remoteURL returns the Gitea repository's Git remote URL.
+//
+// note: this does not contain credentials. If you need an authenticated clone
+// URL use repos.CloneURL.
+func (s *GiteaSource) remoteURL(r *gitea.Repository) string {
+	if s.config.GitURLType == "ssh" {
+		return r.SSHURL // SSH authentication must be provided out-of-band
+	}
+	return r.CloneURL
+}
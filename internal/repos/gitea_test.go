@@ -0,0 +1,74 @@
+package repos
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	newSource := func(t *testing.T, conf *schema.GiteaConnection) (*GiteaSource, func(testing.TB)) {
+		t.Helper()
+
+		cf, save := NewClientFactory(t, t.Name())
+
+		svc := &types.ExternalService{
+			Kind:   extsvc.VariantGitea.AsKind(),
+			Config: extsvc.NewUnencryptedConfig(MarshalJSON(t, conf)),
+		}
+
+		src, err := NewGiteaSource(context.Background(), logtest.Scoped(t), svc, cf)
+		require.NoError(t, err)
+		return src, save
+	}
+
+	t.Run("orgs repos and repositoryQuery", func(t *testing.T) {
+		src, save := newSource(t, &schema.GiteaConnection{
+			Url:             "https://gitea.sgdev.org",
+			Token:           os.Getenv("GITEA_TOKEN"),
+			Orgs:            []string{"sourcegraph-testing"},
+			Repos:           []string{"upstream/tmux", "sourcegraph-testing/automation-testing"},
+			RepositoryQuery: []string{"affiliated"},
+			Exclude:         []*schema.ExcludedGiteaRepo{{Archived: true}},
+		})
+		defer save(t)
+
+		repos, err := ListAll(context.Background(), src)
+		require.NoError(t, err)
+
+		testutil.AssertGolden(t, "testdata/sources/GITEA/"+t.Name(), Update(t.Name()), repos)
+	})
+
+	t.Run("with exclusions", func(t *testing.T) {
+		src, save := newSource(t, &schema.GiteaConnection{
+			Url:   "https://gitea.sgdev.org",
+			Token: os.Getenv("GITEA_TOKEN"),
+			Orgs:  []string{"sourcegraph-testing"},
+			Exclude: []*schema.ExcludedGiteaRepo{
+				{Forks: true},
+				{Name: "sourcegraph-testing/archived-repo"},
+				{Id: 13},
+			},
+			RepositoryPathPattern: "gitea/{nameWithOwner}",
+		})
+		defer save(t)
+
+		repos, err := ListAll(context.Background(), src)
+		require.NoError(t, err)
+
+		var names []string
+		for _, r := range repos {
+			names = append(names, string(r.Name))
+		}
+		assert.Equal(t, []string{"gitea/sourcegraph-testing/automation-testing"}, names)
+	})
+}
@@ -38,6 +38,8 @@ func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateSched
 
 	logger = logger.Scoped("RunScheduler", "git fetch scheduler")
 
+	go scheduler.runPersistLoop(ctx)
+
 	conf.Watch(func() {
 		c := conf.Get()
 
@@ -88,6 +90,9 @@ const (
 
 	// maxDelay is the maximum amount of time between scheduled updates for a single repository.
 	maxDelay = 8 * time.Hour
+
+	// persistInterval is how often changes to the schedule are persisted to the database.
+	persistInterval = 30 * time.Second
 )
 
 // UpdateScheduler schedules repo update (or clone) requests to gitserver.
@@ -108,6 +113,9 @@ const (
 //
 // A worker continuously dequeues repos and sends updates to gitserver, but its concurrency
 // is limited by the gitMaxConcurrentClones site configuration.
+//
+// The schedule is persisted to the database periodically. After a restart, repos that are
+// added to the scheduler again keep their learned interval and due time (see RestoreSchedule).
 type UpdateScheduler struct {
 	db          database.DB
 	updateQueue *updateQueue
@@ -178,6 +186,7 @@ func (s *UpdateScheduler) runSchedule() {
 		s.updateQueue.enqueue(repoUpdate.Repo, priorityLow)
 		repoUpdate.Due = timeNow().Add(repoUpdate.Interval)
 		heap.Fix(s.schedule, 0)
+		s.schedule.markChanged(repoUpdate.Repo.ID)
 	}
 }
 
@@ -200,7 +209,7 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 				return
 			}
 
-			repo, ok := s.updateQueue.acquireNext()
+			repo, refs, ok := s.updateQueue.acquireNext()
 			if !ok {
 				cancel()
 				break
@@ -208,7 +217,7 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 
 			subLogger := s.logger.Scoped("RunUpdateLoop", "")
 
-			go func(ctx context.Context, repo configuredRepo, cancel context.CancelFunc) {
+			go func(ctx context.Context, repo configuredRepo, refs []gitserverprotocol.RefUpdate, cancel context.CancelFunc) {
 				defer cancel()
 				defer s.updateQueue.remove(repo, true)
 
@@ -216,7 +225,7 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 				// if it doesn't exist or update it if it does. The timeout of this request depends
 				// on the value of conf.GitLongCommandTimeout() or if the passed context has a set
 				// deadline shorter than the value of this config.
-				resp, err := requestRepoUpdate(ctx, s.db, repo, 1*time.Second)
+				resp, err := requestRepoUpdate(ctx, s.db, repo, 1*time.Second, refs)
 				if err != nil {
 					schedError.WithLabelValues("requestRepoUpdate").Inc()
 					subLogger.Error("error requesting repo update", log.Error(err), log.String("uri", string(repo.Name)))
@@ -229,6 +238,10 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 					}
 				}
 
+				if resp != nil && resp.LastFetched != nil {
+					s.schedule.setLastFetched(repo, *resp.LastFetched)
+				}
+
 				if interval := getCustomInterval(subLogger, conf.Get(), string(repo.Name)); interval > 0 {
 					s.schedule.updateInterval(repo, interval)
 					return
@@ -246,7 +259,7 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 					interval := resp.LastFetched.Sub(*resp.LastChanged) / 2
 					s.schedule.updateInterval(repo, interval)
 				}
-			}(ctx, repo, cancel)
+			}(ctx, repo, refs, cancel)
 		}
 	}
 }
@@ -268,8 +281,12 @@ func getCustomInterval(logger log.Logger, c *conf.Unified, repoName string) time
 	return 0
 }
 
-// requestRepoUpdate sends a request to gitserver to request an update.
-var requestRepoUpdate = func(ctx context.Context, db database.DB, repo configuredRepo, since time.Duration) (*gitserverprotocol.RepoUpdateResponse, error) {
+// requestRepoUpdate sends a request to gitserver to request an update. If refs is
+// not empty, only the given refs are fetched.
+var requestRepoUpdate = func(ctx context.Context, db database.DB, repo configuredRepo, since time.Duration, refs []gitserverprotocol.RefUpdate) (*gitserverprotocol.RepoUpdateResponse, error) {
+	if len(refs) > 0 {
+		return gitserver.NewClient(db).RequestRepoRefsUpdate(ctx, repo.Name, refs)
+	}
 	return gitserver.NewClient(db).RequestRepoUpdate(ctx, repo.Name, since)
 }
 
@@ -403,6 +420,19 @@ func (s *UpdateScheduler) UpdateOnce(id api.RepoID, name api.RepoName) {
 	s.updateQueue.enqueue(repo, priorityHigh)
 }
 
+// UpdateRefs causes a single update of the given refs of the repository, which were
+// pushed to the code host. If the repository is already queued for a full update,
+// the refs are fetched as part of it.
+// It neither adds nor removes the repo from the schedule.
+func (s *UpdateScheduler) UpdateRefs(id api.RepoID, name api.RepoName, refs []gitserverprotocol.RefUpdate) {
+	repo := configuredRepo{
+		ID:   id,
+		Name: name,
+	}
+	schedManualFetch.Inc()
+	s.updateQueue.enqueueRefs(repo, priorityHigh, refs)
+}
+
 // DebugDump returns the state of the update scheduler for debugging.
 func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 	data := struct {
@@ -490,6 +520,52 @@ func (s *UpdateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedu
 	return &result
 }
 
+// RestoreSchedule loads the persisted schedule. Repos that are added to the scheduler
+// afterwards keep the update interval and due time that they had when the schedule was last
+// persisted, instead of all becoming due shortly after repo-updater starts.
+func (s *UpdateScheduler) RestoreSchedule(ctx context.Context) error {
+	schedules, err := s.db.RepoUpdateSchedules().List(ctx, database.RepoUpdateScheduleListOptions{})
+	if err != nil {
+		return err
+	}
+	s.schedule.restore(schedules)
+	s.logger.Info("restored update schedule", log.Int("repos", len(schedules)))
+	return nil
+}
+
+// runPersistLoop periodically persists the changes to the schedule.
+func (s *UpdateScheduler) runPersistLoop(ctx context.Context) {
+	ticker := time.NewTicker(persistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		if err := s.persistSchedule(ctx); err != nil {
+			schedError.WithLabelValues("persistSchedule").Inc()
+			s.logger.Warn("failed to persist update schedule", log.Error(err))
+		}
+	}
+}
+
+// persistSchedule persists the schedule of the repos that were changed or removed since it
+// was last persisted.
+func (s *UpdateScheduler) persistSchedule(ctx context.Context) error {
+	changed, removed := s.schedule.takeChanges()
+
+	store := s.db.RepoUpdateSchedules()
+	if err := store.Delete(ctx, removed...); err != nil {
+		return err
+	}
+	// Failed changes are not retried: the schedule of a repo changes again whenever it
+	// is fetched, so it will be persisted soon enough.
+	return store.Upsert(ctx, changed...)
+}
+
 // updateQueue is a priority queue of repos to update.
 // A repo can't have more than one location in the queue.
 // Implements heap.Interface and sort.Interface.
@@ -518,9 +594,16 @@ const (
 type repoUpdate struct {
 	Repo     configuredRepo
 	Priority priority
-	Seq      uint64 // the sequence number of the update
-	Updating bool   // whether the repo has been acquired for update
-	Index    int    `json:"-"` // the index in the heap
+	Seq      uint64                        // the sequence number of the update
+	Updating bool                          // whether the repo has been acquired for update
+	Refs     []gitserverprotocol.RefUpdate // the pushed refs to fetch, or nil to fetch all refs
+	Index    int                           `json:"-"` // the index in the heap
+
+	// Pending holds the refs that were pushed while the repo was updating. They might
+	// not have been fetched by the running update, so they are enqueued again with
+	// PendingPriority once it finishes.
+	Pending         []gitserverprotocol.RefUpdate
+	PendingPriority priority
 }
 
 func (q *updateQueue) reset() {
@@ -543,6 +626,16 @@ func (q *updateQueue) reset() {
 // If the given priority is higher than the one in the queue,
 // the repo's position in the queue is updated accordingly.
 func (q *updateQueue) enqueue(repo configuredRepo, p priority) (updated bool) {
+	return q.enqueueRefs(repo, p, nil)
+}
+
+// enqueueRefs is like enqueue, but only requests an update of the given refs. If
+// refs is nil, or the repo is already queued for an update of all refs, all refs
+// are updated.
+//
+// If the repo is already updating, the given refs are kept on its update and
+// enqueued again when the update is removed from the queue.
+func (q *updateQueue) enqueueRefs(repo configuredRepo, p priority, refs []gitserverprotocol.RefUpdate) (updated bool) {
 	if repo.ID == 0 {
 		panic("repo.id is zero")
 	}
@@ -555,16 +648,29 @@ func (q *updateQueue) enqueue(repo configuredRepo, p priority) (updated bool) {
 		heap.Push(q, &repoUpdate{
 			Repo:     repo,
 			Priority: p,
+			Refs:     refs,
 		})
 		notify(q.notifyEnqueue)
 		return false
 	}
 
 	if update.Updating {
-		return false
+		if refs == nil {
+			return false
+		}
+		if update.Pending == nil {
+			update.Pending = refs
+		} else {
+			update.Pending = gitserverprotocol.MergeRefUpdates(update.Pending, refs)
+		}
+		if p > update.PendingPriority {
+			update.PendingPriority = p
+		}
+		return true
 	}
 
 	update.Repo = repo
+	update.Refs = gitserverprotocol.MergeRefUpdates(update.Refs, refs)
 	if p <= update.Priority {
 		// Repo is already in the queue with at least as good priority.
 		return true
@@ -587,6 +693,7 @@ func (q *updateQueue) nextSeq() uint64 {
 }
 
 // remove removes the repo from the queue if the repo.Updating matches the updating argument.
+// The refs that were pushed while the repo was updating are enqueued again.
 func (q *updateQueue) remove(repo configuredRepo, updating bool) (removed bool) {
 	if repo.ID == 0 {
 		panic("repo.id is zero")
@@ -598,29 +705,38 @@ func (q *updateQueue) remove(repo configuredRepo, updating bool) (removed bool)
 	update := q.index[repo.ID]
 	if update != nil && update.Updating == updating {
 		heap.Remove(q, update.Index)
+		if update.Pending != nil {
+			heap.Push(q, &repoUpdate{
+				Repo:     update.Repo,
+				Priority: update.PendingPriority,
+				Refs:     update.Pending,
+			})
+			notify(q.notifyEnqueue)
+		}
 		return true
 	}
 
 	return false
 }
 
-// acquireNext acquires the next repo for update.
+// acquireNext acquires the next repo for update, and returns the refs to update
+// or nil if all refs should be updated.
 // The acquired repo must be removed from the queue
 // when the update finishes (independent of success or failure).
-func (q *updateQueue) acquireNext() (configuredRepo, bool) {
+func (q *updateQueue) acquireNext() (configuredRepo, []gitserverprotocol.RefUpdate, bool) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 	if len(q.heap) == 0 {
-		return configuredRepo{}, false
+		return configuredRepo{}, nil, false
 	}
 	update := q.heap[0]
 	if update.Updating {
 		// Everything in the queue is already updating.
-		return configuredRepo{}, false
+		return configuredRepo{}, nil, false
 	}
 	update.Updating = true
 	heap.Fix(q, update.Index)
-	return update.Repo, true
+	return update.Repo, update.Refs, true
 }
 
 // The following methods implement heap.Interface based on the priority queue example:
@@ -690,6 +806,13 @@ type schedule struct {
 	randGenerator interface {
 		Int63n(n int64) int64
 	}
+
+	// restored holds the persisted schedule of repos that were not added to the schedule yet.
+	restored map[api.RepoID]*database.RepoUpdateSchedule
+	// changed and removed hold the repos whose schedule was changed or removed since it was
+	// last persisted.
+	changed map[api.RepoID]struct{}
+	removed map[api.RepoID]struct{}
 }
 
 // scheduledRepoUpdate is the update schedule for a single repo.
@@ -698,6 +821,8 @@ type scheduledRepoUpdate struct {
 	Interval time.Duration  // how regularly the repo is updated
 	Due      time.Time      // the next time that the repo will be enqueued for a update
 	Index    int            `json:"-"` // the index in the heap
+
+	LastFetched time.Time // the last time that the repo was fetched, if known
 }
 
 // upsert inserts or updates a repo in the schedule.
@@ -714,11 +839,8 @@ func (s *schedule) upsert(repo configuredRepo) (updated bool) {
 		return true
 	}
 
-	heap.Push(s, &scheduledRepoUpdate{
-		Repo:     repo,
-		Interval: minDelay,
-		Due:      timeNow().Add(minDelay),
-	})
+	heap.Push(s, s.newUpdate(repo, timeNow().Add(minDelay)))
+	s.markChanged(repo.ID)
 
 	s.rescheduleTimer()
 
@@ -739,15 +861,16 @@ func (s *schedule) prioritiseUncloned(uncloned []types.MinimalRepo) {
 	rescheduleTimer := false
 	for _, repo := range uncloned {
 		if repoUpdate := s.index[repo.ID]; repoUpdate == nil {
-			heap.Push(s, &scheduledRepoUpdate{
-				Repo:     configuredRepo{ID: repo.ID, Name: repo.Name},
-				Interval: minDelay,
-				Due:      notClonedDue,
-			})
+			repoUpdate = s.newUpdate(configuredRepo{ID: repo.ID, Name: repo.Name}, notClonedDue)
+			// Uncloned repos are due for cloning regardless of their restored schedule.
+			repoUpdate.Due = notClonedDue
+			heap.Push(s, repoUpdate)
+			s.markChanged(repo.ID)
 			rescheduleTimer = true
 		} else if repoUpdate.Due.After(notClonedDue) {
 			repoUpdate.Due = notClonedDue
 			heap.Fix(s, repoUpdate.Index)
+			s.markChanged(repo.ID)
 			rescheduleTimer = true
 		}
 	}
@@ -783,11 +906,8 @@ func (s *schedule) insertNew(repos []types.MinimalRepo) {
 		if update := s.index[repo.ID]; update != nil {
 			continue
 		}
-		heap.Push(s, &scheduledRepoUpdate{
-			Repo:     repo,
-			Interval: minDelay,
-			Due:      due,
-		})
+		heap.Push(s, s.newUpdate(repo, due))
+		s.markChanged(repo.ID)
 		rescheduleTimer = true
 	}
 
@@ -824,11 +944,24 @@ func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
 			log.Object("repo", log.String("name", string(repo.Name)), log.Duration("due", update.Due.Sub(timeNow()))),
 		)
 		heap.Fix(s, update.Index)
+		s.markChanged(repo.ID)
 		s.rescheduleTimer()
 	}
 	s.mu.Unlock()
 }
 
+// setLastFetched records when a repo in the schedule was last fetched.
+// It does nothing if the repo is not in the schedule.
+func (s *schedule) setLastFetched(repo configuredRepo, lastFetched time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if update := s.index[repo.ID]; update != nil {
+		update.LastFetched = lastFetched
+		s.markChanged(repo.ID)
+	}
+}
+
 // getCurrentInterval gets the current interval for the supplied repo and a bool
 // indicating whether it was found.
 func (s *schedule) getCurrentInterval(repo configuredRepo) (time.Duration, bool) {
@@ -861,9 +994,108 @@ func (s *schedule) remove(repo configuredRepo) (removed bool) {
 		s.rescheduleTimer()
 	}
 
+	delete(s.changed, repo.ID)
+	if s.removed == nil {
+		s.removed = make(map[api.RepoID]struct{})
+	}
+	s.removed[repo.ID] = struct{}{}
+
 	return true
 }
 
+// newUpdate returns the schedule of a repo that is added to the schedule. It is due at due,
+// unless the repo has a restored schedule, which is used instead.
+// The caller must hold the lock on s.mu.
+func (s *schedule) newUpdate(repo configuredRepo, due time.Time) *scheduledRepoUpdate {
+	update := &scheduledRepoUpdate{
+		Repo:     repo,
+		Interval: minDelay,
+		Due:      due,
+	}
+
+	restored := s.restored[repo.ID]
+	if restored == nil {
+		return update
+	}
+	delete(s.restored, repo.ID)
+
+	if restored.Interval > 0 {
+		update.Interval = restored.Interval
+	}
+	if restored.LastFetchedAt != nil {
+		update.LastFetched = *restored.LastFetchedAt
+	}
+	if restored.DueAt.After(due) {
+		update.Due = restored.DueAt
+	} else {
+		// The repo became due while it was not scheduled, e.g. because repo-updater was
+		// restarting. Spread such repos over their interval, as if they had been fetched at
+		// random times during the last interval, so that they aren't all fetched at once.
+		update.Due = due.Add(time.Duration(s.randGenerator.Int63n(int64(update.Interval))))
+	}
+	return update
+}
+
+// restore sets the restored schedule of the repos that are not in the schedule yet.
+func (s *schedule) restore(schedules []*database.RepoUpdateSchedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.restored == nil {
+		s.restored = make(map[api.RepoID]*database.RepoUpdateSchedule, len(schedules))
+	}
+	for _, restored := range schedules {
+		if s.index[restored.RepoID] == nil {
+			s.restored[restored.RepoID] = restored
+		}
+	}
+}
+
+// markChanged records that the schedule of a repo changed since it was last persisted.
+// The caller must hold the lock on s.mu.
+func (s *schedule) markChanged(id api.RepoID) {
+	if s.changed == nil {
+		s.changed = make(map[api.RepoID]struct{})
+	}
+	s.changed[id] = struct{}{}
+	delete(s.removed, id)
+}
+
+// takeChanges returns the schedule of the repos that were changed and the repos that were
+// removed since the last call.
+func (s *schedule) takeChanges() (changed []*database.RepoUpdateSchedule, removed []api.RepoID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id := range s.changed {
+		// Repos are no longer in the schedule after a reset.
+		if update := s.index[id]; update != nil {
+			changed = append(changed, update.persisted())
+		}
+	}
+	for id := range s.removed {
+		removed = append(removed, id)
+	}
+	s.changed = nil
+	s.removed = nil
+
+	return changed, removed
+}
+
+// persisted returns the schedule of the repo to persist.
+func (u *scheduledRepoUpdate) persisted() *database.RepoUpdateSchedule {
+	schedule := &database.RepoUpdateSchedule{
+		RepoID:   u.Repo.ID,
+		Interval: u.Interval,
+		DueAt:    u.Due,
+	}
+	if !u.LastFetched.IsZero() {
+		lastFetched := u.LastFetched
+		schedule.LastFetchedAt = &lastFetched
+	}
+	return schedule
+}
+
 // rescheduleTimer schedules the scheduler to wakeup
 // at the time that the next repo is due for an update.
 // The caller must hold the lock on s.mu.
@@ -884,6 +1116,14 @@ func (s *schedule) reset() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// Keep the schedule of the repos, so that it is restored when they are added again.
+	if s.restored == nil {
+		s.restored = make(map[api.RepoID]*database.RepoUpdateSchedule, len(s.heap))
+	}
+	for _, update := range s.heap {
+		s.restored[update.Repo.ID] = update.persisted()
+	}
+
 	s.heap = s.heap[:0]
 	s.index = map[api.RepoID]*scheduledRepoUpdate{}
 	s.wakeup = make(chan struct{}, notifyChanBuffer)
@@ -4,6 +4,7 @@ import (
 	"container/heap"
 	"context"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -75,7 +76,11 @@ func TestUpdateQueue_enqueue(t *testing.T) {
 	type enqueueCall struct {
 		repo     configuredRepo
 		priority priority
+		refs     []gitserverprotocol.RefUpdate
 	}
+	main1 := gitserverprotocol.RefUpdate{Name: "refs/heads/main", Before: "1", After: "2"}
+	main2 := gitserverprotocol.RefUpdate{Name: "refs/heads/main", Before: "2", After: "3"}
+	tag := gitserverprotocol.RefUpdate{Name: "refs/tags/v1", After: "3"}
 	tests := []struct {
 		name                  string
 		calls                 []*enqueueCall
@@ -111,6 +116,55 @@ func TestUpdateQueue_enqueue(t *testing.T) {
 			},
 			expectedNotifications: 1,
 		},
+		{
+			name: "enqueue refs",
+			calls: []*enqueueCall{
+				{repo: a, priority: priorityHigh, refs: []gitserverprotocol.RefUpdate{main1}},
+				{repo: a, priority: priorityHigh, refs: []gitserverprotocol.RefUpdate{main2, tag}},
+			},
+			expectedUpdates: []*repoUpdate{
+				{
+					Repo:     a,
+					Priority: priorityHigh,
+					Seq:      1,
+					Refs: []gitserverprotocol.RefUpdate{
+						{Name: "refs/heads/main", Before: "1", After: "3"},
+						tag,
+					},
+				},
+			},
+			expectedNotifications: 1,
+		},
+		{
+			name: "enqueue refs then all refs",
+			calls: []*enqueueCall{
+				{repo: a, priority: priorityHigh, refs: []gitserverprotocol.RefUpdate{main1}},
+				{repo: a, priority: priorityLow},
+			},
+			expectedUpdates: []*repoUpdate{
+				{
+					Repo:     a,
+					Priority: priorityHigh,
+					Seq:      1,
+				},
+			},
+			expectedNotifications: 1,
+		},
+		{
+			name: "enqueue all refs then refs",
+			calls: []*enqueueCall{
+				{repo: a, priority: priorityLow},
+				{repo: a, priority: priorityHigh, refs: []gitserverprotocol.RefUpdate{main1}},
+			},
+			expectedUpdates: []*repoUpdate{
+				{
+					Repo:     a,
+					Priority: priorityHigh,
+					Seq:      2,
+				},
+			},
+			expectedNotifications: 2,
+		},
 		{
 			name: "enqueue low b then high a",
 			calls: []*enqueueCall{
@@ -228,6 +282,29 @@ func TestUpdateQueue_enqueue(t *testing.T) {
 			},
 			expectedNotifications: 1,
 		},
+		{
+			name: "refs are kept if already updating",
+			calls: []*enqueueCall{
+				{repo: a, priority: priorityLow, refs: []gitserverprotocol.RefUpdate{tag}},
+				{repo: a, priority: priorityHigh, refs: []gitserverprotocol.RefUpdate{main1}},
+				{repo: a, priority: priorityLow, refs: []gitserverprotocol.RefUpdate{main2}},
+			},
+			acquire: 1,
+			expectedUpdates: []*repoUpdate{
+				{
+					Repo:     a,
+					Priority: priorityLow,
+					Updating: true,
+					Seq:      1,
+					Refs:     []gitserverprotocol.RefUpdate{tag},
+					Pending: []gitserverprotocol.RefUpdate{
+						{Name: "refs/heads/main", Before: "1", After: "3"},
+					},
+					PendingPriority: priorityHigh,
+				},
+			},
+			expectedNotifications: 1,
+		},
 		{
 			name: "heap is fixed when priority is bumped",
 			calls: []*enqueueCall{
@@ -282,7 +359,7 @@ func TestUpdateQueue_enqueue(t *testing.T) {
 			s := NewUpdateScheduler(logtest.Scoped(t), db)
 
 			for _, call := range test.calls {
-				s.updateQueue.enqueue(call.repo, call.priority)
+				s.updateQueue.enqueueRefs(call.repo, call.priority, call.refs)
 				if test.acquire > 0 {
 					s.updateQueue.acquireNext()
 					test.acquire--
@@ -464,6 +541,32 @@ func TestUpdateQueue_remove(t *testing.T) {
 	}
 }
 
+func TestUpdateQueue_removeEnqueuesPendingRefs(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	main := gitserverprotocol.RefUpdate{Name: "refs/heads/main", Before: "1", After: "2"}
+
+	r, stop := startRecording()
+	defer stop()
+
+	s := NewUpdateScheduler(logtest.Scoped(t), database.NewMockDB())
+	setupInitialQueue(s, []*repoUpdate{
+		{Repo: a, Updating: true, Pending: []gitserverprotocol.RefUpdate{main}, PendingPriority: priorityHigh},
+	})
+
+	if !s.updateQueue.remove(a, true) {
+		t.Fatal("expected the updating repo to be removed")
+	}
+
+	verifyQueue(t, s, []*repoUpdate{
+		{Repo: a, Priority: priorityHigh, Seq: 2, Refs: []gitserverprotocol.RefUpdate{main}},
+	})
+
+	expectedRecording := &recording{notifications: []chan struct{}{s.updateQueue.notifyEnqueue}}
+	if !reflect.DeepEqual(expectedRecording, r) {
+		t.Fatalf("\nexpected\n%s\ngot\n%s", spew.Sdump(expectedRecording), spew.Sdump(r))
+	}
+}
+
 func TestUpdateQueue_acquireNext(t *testing.T) {
 	a := configuredRepo{ID: 1, Name: "a"}
 	b := configuredRepo{ID: 2, Name: "b"}
@@ -522,7 +625,7 @@ func TestUpdateQueue_acquireNext(t *testing.T) {
 
 			// Test aquireNext.
 			for i, expected := range test.acquireResults {
-				actual, ok := s.updateQueue.acquireNext()
+				actual, _, ok := s.updateQueue.acquireNext()
 				got := &actual
 				if !ok {
 					got = nil
@@ -1396,7 +1499,7 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 				},
 			},
 			finalSchedule: []*scheduledRepoUpdate{
-				{Repo: a, Interval: time.Minute, Due: defaultTime.Add(time.Minute)},
+				{Repo: a, Interval: time.Minute, Due: defaultTime.Add(time.Minute), LastFetched: defaultTime.Add(2 * time.Minute)},
 			},
 			timeAfterFuncDelays: []time.Duration{time.Minute},
 			expectedNotifications: func(s *UpdateScheduler) []chan struct{} {
@@ -1426,7 +1529,7 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 
 			contexts := make(chan context.Context, expectedRequestCount)
 			db := database.NewMockDB()
-			requestRepoUpdate = func(ctx context.Context, db database.DB, repo configuredRepo, since time.Duration) (*gitserverprotocol.RepoUpdateResponse, error) {
+			requestRepoUpdate = func(ctx context.Context, db database.DB, repo configuredRepo, since time.Duration, refs []gitserverprotocol.RefUpdate) (*gitserverprotocol.RepoUpdateResponse, error) {
 				select {
 				case mock := <-mockRequestRepoUpdates:
 					if !reflect.DeepEqual(mock.repo, repo) {
@@ -1613,3 +1716,114 @@ func TestGetCustomInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateScheduler_RestoreSchedule(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+	c := configuredRepo{ID: 3, Name: "c"}
+	d := configuredRepo{ID: 4, Name: "d"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	lastFetched := defaultTime.Add(-time.Hour)
+	store := database.NewMockRepoUpdateScheduleStore()
+	store.ListFunc.SetDefaultReturn([]*database.RepoUpdateSchedule{
+		// Due in the future.
+		{RepoID: a.ID, Interval: 2 * time.Hour, DueAt: defaultTime.Add(time.Hour), LastFetchedAt: &lastFetched},
+		// Became due while repo-updater was restarting.
+		{RepoID: b.ID, Interval: time.Hour, DueAt: defaultTime.Add(-time.Minute)},
+		// Not cloned.
+		{RepoID: c.ID, Interval: time.Hour, DueAt: defaultTime.Add(time.Hour)},
+	}, nil)
+	db := database.NewMockDB()
+	db.RepoUpdateSchedulesFunc.SetDefaultReturn(store)
+
+	s := NewUpdateScheduler(logtest.Scoped(t), db)
+	s.schedule.randGenerator = &mockRandomGenerator{}
+	if err := s.RestoreSchedule(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, repo := range []configuredRepo{a, b} {
+		s.schedule.upsert(repo)
+	}
+	s.schedule.prioritiseUncloned([]types.MinimalRepo{{ID: c.ID, Name: c.Name}})
+	// Repos without a restored schedule are scheduled as usual.
+	mockTime(defaultTime.Add(time.Minute))
+	s.schedule.upsert(d)
+
+	verifySchedule(t, s, []*scheduledRepoUpdate{
+		{Repo: c, Interval: time.Hour, Due: defaultTime.Add(minDelay)},
+		{Repo: d, Interval: minDelay, Due: defaultTime.Add(time.Minute + minDelay)},
+		{Repo: b, Interval: time.Hour, Due: defaultTime.Add(minDelay + 30*time.Minute)},
+		{Repo: a, Interval: 2 * time.Hour, Due: defaultTime.Add(time.Hour), LastFetched: lastFetched},
+	})
+}
+
+func TestUpdateScheduler_persistSchedule(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+	c := configuredRepo{ID: 3, Name: "c"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	store := database.NewMockRepoUpdateScheduleStore()
+	db := database.NewMockDB()
+	db.RepoUpdateSchedulesFunc.SetDefaultReturn(store)
+
+	s := NewUpdateScheduler(logtest.Scoped(t), db)
+	s.schedule.randGenerator = &mockRandomGenerator{}
+	for _, repo := range []configuredRepo{a, b, c} {
+		s.schedule.upsert(repo)
+	}
+
+	persist := func() (upserted []*database.RepoUpdateSchedule, deleted []api.RepoID) {
+		t.Helper()
+		upserts, deletes := len(store.UpsertFunc.History()), len(store.DeleteFunc.History())
+		if err := s.persistSchedule(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		for _, call := range store.UpsertFunc.History()[upserts:] {
+			upserted = append(upserted, call.Arg1...)
+		}
+		for _, call := range store.DeleteFunc.History()[deletes:] {
+			deleted = append(deleted, call.Arg1...)
+		}
+		sort.Slice(upserted, func(i, j int) bool { return upserted[i].RepoID < upserted[j].RepoID })
+		return upserted, deleted
+	}
+
+	upserted, deleted := persist()
+	if diff := cmp.Diff([]*database.RepoUpdateSchedule{
+		{RepoID: a.ID, Interval: minDelay, DueAt: defaultTime.Add(minDelay)},
+		{RepoID: b.ID, Interval: minDelay, DueAt: defaultTime.Add(minDelay)},
+		{RepoID: c.ID, Interval: minDelay, DueAt: defaultTime.Add(minDelay)},
+	}, upserted); diff != "" {
+		t.Fatalf("unexpected upserted schedules (-want +got):\n%s", diff)
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("unexpected deleted schedules: %v", deleted)
+	}
+
+	// Only changes since the schedule was last persisted are persisted.
+	s.schedule.updateInterval(a, time.Hour)
+	s.schedule.setLastFetched(a, defaultTime)
+	s.schedule.remove(b)
+
+	upserted, deleted = persist()
+	if diff := cmp.Diff([]*database.RepoUpdateSchedule{
+		{RepoID: a.ID, Interval: time.Hour, DueAt: defaultTime.Add(time.Hour), LastFetchedAt: pointers.Ptr(defaultTime)},
+	}, upserted); diff != "" {
+		t.Fatalf("unexpected upserted schedules (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]api.RepoID{b.ID}, deleted); diff != "" {
+		t.Fatalf("unexpected deleted schedules (-want +got):\n%s", diff)
+	}
+
+	upserted, deleted = persist()
+	if len(upserted) != 0 || len(deleted) != 0 {
+		t.Fatalf("unexpected changes: %v, %v", upserted, deleted)
+	}
+}
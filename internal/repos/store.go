@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -122,6 +123,13 @@ func (s *store) ExternalServiceStore() database.ExternalServiceStore {
 	return database.ExternalServicesWith(s.Logger, s)
 }
 
+// outboundWebhookJobStore returns a database.OutboundWebhookJobStore using the
+// same database handle, so that events are only enqueued if the surrounding
+// transaction commits.
+func (s *store) outboundWebhookJobStore() database.OutboundWebhookJobStore {
+	return database.OutboundWebhookJobsWithDefaultKey(s)
+}
+
 func (s *store) SetMetrics(m StoreMetrics) { s.Metrics = m }
 
 func (s *store) With(other basestore.ShareableStore) Store {
@@ -289,26 +297,63 @@ func (s *store) DeleteExternalServiceRepo(ctx context.Context, svc *types.Extern
 		return errors.Wrap(err, "failed to delete external service repo")
 	}
 
-	err = s.Exec(ctx, sqlf.Sprintf(deleteRepoIfOrphanQuery, id, id))
+	deleted, _, err := basestore.NewFirstScanner(scanDeletedRepo)(s.Query(ctx, sqlf.Sprintf(deleteRepoIfOrphanQuery, id, id)))
 	if err != nil {
 		return errors.Wrap(err, "failed to delete orphaned repo")
 	}
 
+	// Only announce repos that weren't already soft deleted.
+	if deleted != nil {
+		database.EnqueueOutboundWebhookEvent(ctx, s.Logger, s.outboundWebhookJobStore(), events.RepoDeleted, events.NewRepository(deleted, nil))
+	}
+
 	return nil
 }
 
+// scanDeletedRepo scans a row returned by deleteRepoIfOrphanQuery. It returns
+// nil if the repo had already been soft deleted.
+func scanDeletedRepo(sc dbutil.Scanner) (*types.Repo, error) {
+	var (
+		r          types.Repo
+		wasDeleted bool
+	)
+	if err := sc.Scan(
+		&r.ID,
+		&r.Name,
+		&r.Private,
+		&dbutil.NullString{S: &r.ExternalRepo.ServiceType},
+		&dbutil.NullString{S: &r.ExternalRepo.ServiceID},
+		&dbutil.NullString{S: &r.ExternalRepo.ID},
+		&wasDeleted,
+	); err != nil {
+		return nil, err
+	}
+	if wasDeleted {
+		return nil, nil
+	}
+	return &r, nil
+}
+
 const deleteExternalServiceRepoQuery = `
 DELETE FROM external_service_repos
 WHERE external_service_id = %s AND repo_id = %s
 `
 
+// deleteRepoIfOrphanQuery returns the repo as it was before being deleted, so
+// that it can be described in the outbound webhook payload.
 const deleteRepoIfOrphanQuery = `
 UPDATE repo
-SET name = soft_deleted_repository_name(name), deleted_at = now()
-WHERE id = %s AND NOT EXISTS (
+SET name = soft_deleted_repository_name(repo.name), deleted_at = now()
+FROM (
+	SELECT id, name, private, external_service_type, external_service_id, external_id, deleted_at
+	FROM repo
+	WHERE id = %s
+) AS old
+WHERE repo.id = old.id AND NOT EXISTS (
 	SELECT FROM external_service_repos
 	WHERE repo_id = %s LIMIT 1
 )
+RETURNING old.id, old.name, old.private, old.external_service_type, old.external_service_id, old.external_id, old.deleted_at IS NOT NULL
 `
 
 func (s *store) CreateExternalServiceRepo(ctx context.Context, svc *types.ExternalService, r *types.Repo) (err error) {
@@ -379,11 +424,17 @@ func (s *store) CreateExternalServiceRepo(ctx context.Context, svc *types.Extern
 		return err
 	}
 
-	return s.Exec(ctx, sqlf.Sprintf(upsertExternalServiceRepoQuery,
+	if err = s.Exec(ctx, sqlf.Sprintf(upsertExternalServiceRepoQuery,
 		svc.ID,
 		r.ID,
 		src.CloneURL,
-	))
+	)); err != nil {
+		return err
+	}
+
+	database.EnqueueOutboundWebhookEvent(ctx, s.Logger, s.outboundWebhookJobStore(), events.RepoAdded, events.NewRepository(r, nil))
+
+	return nil
 }
 
 const createRepoQuery = `
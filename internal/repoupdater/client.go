@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/deploy"
+	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
@@ -207,17 +208,35 @@ func (c *Client) EnqueueRepoUpdate(ctx context.Context, repo api.RepoName) (*pro
 		return MockEnqueueRepoUpdate(ctx, repo)
 	}
 
+	return c.enqueueRepoUpdate(ctx, &protocol.RepoUpdateRequest{Repo: repo})
+}
+
+// MockEnqueueRefsUpdate mocks (*Client).EnqueueRefsUpdate for tests.
+var MockEnqueueRefsUpdate func(ctx context.Context, repo api.RepoName, refs []gitserverprotocol.RefUpdate) (*protocol.RepoUpdateResponse, error)
+
+// EnqueueRefsUpdate requests that the given refs of the named repository, which
+// were pushed to the code host, be fetched in the near future. Gitserver falls
+// back to fetching all refs if it can't fetch just the given ones, or if refs is
+// empty. It does not wait for the update.
+func (c *Client) EnqueueRefsUpdate(ctx context.Context, repo api.RepoName, refs []gitserverprotocol.RefUpdate) (*protocol.RepoUpdateResponse, error) {
+	if MockEnqueueRefsUpdate != nil {
+		return MockEnqueueRefsUpdate(ctx, repo, refs)
+	}
+
+	return c.enqueueRepoUpdate(ctx, &protocol.RepoUpdateRequest{Repo: repo, Refs: refs})
+}
+
+func (c *Client) enqueueRepoUpdate(ctx context.Context, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
 	if conf.IsGRPCEnabled(ctx) {
 		client, err := c.grpcClient()
 		if err != nil {
 			return nil, err
 		}
 
-		req := proto.EnqueueRepoUpdateRequest{Repo: string(repo)}
-		resp, err := client.EnqueueRepoUpdate(ctx, &req)
+		resp, err := client.EnqueueRepoUpdate(ctx, req.ToProto())
 		if err != nil {
 			if s, ok := status.FromError(err); ok && s.Code() == codes.NotFound {
-				return nil, &repoNotFoundError{repo: string(repo), responseBody: s.Message()}
+				return nil, &repoNotFoundError{repo: string(req.Repo), responseBody: s.Message()}
 			}
 
 			return nil, err
@@ -226,10 +245,6 @@ func (c *Client) EnqueueRepoUpdate(ctx context.Context, repo api.RepoName) (*pro
 		return protocol.RepoUpdateResponseFromProto(resp), nil
 	}
 
-	req := &protocol.RepoUpdateRequest{
-		Repo: repo,
-	}
-
 	resp, err := c.httpPost(ctx, "enqueue-repo-update", req)
 	if err != nil {
 		return nil, err
@@ -243,7 +258,7 @@ func (c *Client) EnqueueRepoUpdate(ctx context.Context, repo api.RepoName) (*pro
 
 	var res protocol.RepoUpdateResponse
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, &repoNotFoundError{string(repo), string(bs)}
+		return nil, &repoNotFoundError{string(req.Repo), string(bs)}
 	} else if resp.StatusCode < 200 || resp.StatusCode >= 400 {
 		return nil, errors.New(string(bs))
 	} else if err = json.Unmarshal(bs, &res); err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	proto "github.com/sourcegraph/sourcegraph/internal/repoupdater/v1"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -367,6 +368,41 @@ func RepoLinksFromProto(p *proto.RepoLinks) *RepoLinks {
 // RepoUpdateRequest is a request to update the contents of a given repo, or clone it if it doesn't exist.
 type RepoUpdateRequest struct {
 	Repo api.RepoName `json:"repo"`
+	// Refs, if set, are the refs that were pushed to the repository. Only these refs
+	// are fetched, unless gitserver has to fall back to fetching all refs.
+	Refs []gitserverprotocol.RefUpdate `json:"refs,omitempty"`
+}
+
+func (a *RepoUpdateRequest) ToProto() *proto.EnqueueRepoUpdateRequest {
+	var refs []*proto.RefUpdate
+	for _, ref := range a.Refs {
+		refs = append(refs, &proto.RefUpdate{
+			Name:   ref.Name,
+			Before: string(ref.Before),
+			After:  string(ref.After),
+			Forced: ref.Forced,
+		})
+	}
+	return &proto.EnqueueRepoUpdateRequest{
+		Repo: string(a.Repo),
+		Refs: refs,
+	}
+}
+
+func RepoUpdateRequestFromProto(p *proto.EnqueueRepoUpdateRequest) *RepoUpdateRequest {
+	var refs []gitserverprotocol.RefUpdate
+	for _, ref := range p.GetRefs() {
+		refs = append(refs, gitserverprotocol.RefUpdate{
+			Name:   ref.GetName(),
+			Before: api.CommitID(ref.GetBefore()),
+			After:  api.CommitID(ref.GetAfter()),
+			Forced: ref.GetForced(),
+		})
+	}
+	return &RepoUpdateRequest{
+		Repo: api.RepoName(p.GetRepo()),
+		Refs: refs,
+	}
 }
 
 func (a *RepoUpdateRequest) String() string {
@@ -27,3 +27,18 @@ func TestExternalServiceNamespacesArgs_Roundtrip(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRepoUpdateRequest_Roundtrip(t *testing.T) {
+	err := quick.Check(func(input RepoUpdateRequest) bool {
+		if len(input.Refs) == 0 {
+			// Empty and nil refs are the same in protobuf.
+			input.Refs = nil
+		}
+		output := input.ToProto()
+		input2 := RepoUpdateRequestFromProto(output)
+		return reflect.DeepEqual(&input, input2)
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
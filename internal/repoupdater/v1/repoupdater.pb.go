@@ -731,6 +731,9 @@ type EnqueueRepoUpdateRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Repo string `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// refs, if set, are the refs that were pushed to the repository. Only these
+	// refs are fetched, unless gitserver has to fall back to fetching all refs.
+	Refs []*RefUpdate `protobuf:"bytes,2,rep,name=refs,proto3" json:"refs,omitempty"`
 }
 
 func (x *EnqueueRepoUpdateRequest) Reset() {
@@ -772,6 +775,92 @@ func (x *EnqueueRepoUpdateRequest) GetRepo() string {
 	return ""
 }
 
+func (x *EnqueueRepoUpdateRequest) GetRefs() []*RefUpdate {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+// RefUpdate is an update of a single ref, as reported by the push webhook of a
+// code host.
+type RefUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name is the full name of the ref, such as refs/heads/main.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// before is the commit the ref pointed to before the push. It is empty if
+	// the push created the ref.
+	Before string `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	// after is the commit the ref points to after the push. It is empty if the
+	// push deleted the ref.
+	After string `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	// forced is whether the code host reported the push as a force-push.
+	Forced bool `protobuf:"varint,4,opt,name=forced,proto3" json:"forced,omitempty"`
+}
+
+func (x *RefUpdate) Reset() {
+	*x = RefUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repoupdater_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefUpdate) ProtoMessage() {}
+
+func (x *RefUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_repoupdater_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefUpdate.ProtoReflect.Descriptor instead.
+func (*RefUpdate) Descriptor() ([]byte, []int) {
+	return file_repoupdater_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RefUpdate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RefUpdate) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *RefUpdate) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *RefUpdate) GetForced() bool {
+	if x != nil {
+		return x.Forced
+	}
+	return false
+}
+
 // EnqueueRepoUpdateResponse is a response type to a EnqueueRepoUpdateResponse
 type EnqueueRepoUpdateResponse struct {
 	state         protoimpl.MessageState
@@ -787,7 +876,7 @@ type EnqueueRepoUpdateResponse struct {
 func (x *EnqueueRepoUpdateResponse) Reset() {
 	*x = EnqueueRepoUpdateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[11]
+		mi := &file_repoupdater_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -800,7 +889,7 @@ func (x *EnqueueRepoUpdateResponse) String() string {
 func (*EnqueueRepoUpdateResponse) ProtoMessage() {}
 
 func (x *EnqueueRepoUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[11]
+	mi := &file_repoupdater_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -813,7 +902,7 @@ func (x *EnqueueRepoUpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnqueueRepoUpdateResponse.ProtoReflect.Descriptor instead.
 func (*EnqueueRepoUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{11}
+	return file_repoupdater_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *EnqueueRepoUpdateResponse) GetId() int32 {
@@ -841,7 +930,7 @@ type EnqueueChangesetSyncRequest struct {
 func (x *EnqueueChangesetSyncRequest) Reset() {
 	*x = EnqueueChangesetSyncRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[12]
+		mi := &file_repoupdater_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -854,7 +943,7 @@ func (x *EnqueueChangesetSyncRequest) String() string {
 func (*EnqueueChangesetSyncRequest) ProtoMessage() {}
 
 func (x *EnqueueChangesetSyncRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[12]
+	mi := &file_repoupdater_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -867,7 +956,7 @@ func (x *EnqueueChangesetSyncRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnqueueChangesetSyncRequest.ProtoReflect.Descriptor instead.
 func (*EnqueueChangesetSyncRequest) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{12}
+	return file_repoupdater_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *EnqueueChangesetSyncRequest) GetIds() []int64 {
@@ -886,7 +975,7 @@ type EnqueueChangesetSyncResponse struct {
 func (x *EnqueueChangesetSyncResponse) Reset() {
 	*x = EnqueueChangesetSyncResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[13]
+		mi := &file_repoupdater_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -899,7 +988,7 @@ func (x *EnqueueChangesetSyncResponse) String() string {
 func (*EnqueueChangesetSyncResponse) ProtoMessage() {}
 
 func (x *EnqueueChangesetSyncResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[13]
+	mi := &file_repoupdater_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -912,7 +1001,7 @@ func (x *EnqueueChangesetSyncResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnqueueChangesetSyncResponse.ProtoReflect.Descriptor instead.
 func (*EnqueueChangesetSyncResponse) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{13}
+	return file_repoupdater_proto_rawDescGZIP(), []int{14}
 }
 
 type FetchPermsOptions struct {
@@ -926,7 +1015,7 @@ type FetchPermsOptions struct {
 func (x *FetchPermsOptions) Reset() {
 	*x = FetchPermsOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[14]
+		mi := &file_repoupdater_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -939,7 +1028,7 @@ func (x *FetchPermsOptions) String() string {
 func (*FetchPermsOptions) ProtoMessage() {}
 
 func (x *FetchPermsOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[14]
+	mi := &file_repoupdater_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -952,7 +1041,7 @@ func (x *FetchPermsOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchPermsOptions.ProtoReflect.Descriptor instead.
 func (*FetchPermsOptions) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{14}
+	return file_repoupdater_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *FetchPermsOptions) GetInvalidateCaches() bool {
@@ -973,7 +1062,7 @@ type SyncExternalServiceRequest struct {
 func (x *SyncExternalServiceRequest) Reset() {
 	*x = SyncExternalServiceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[15]
+		mi := &file_repoupdater_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -986,7 +1075,7 @@ func (x *SyncExternalServiceRequest) String() string {
 func (*SyncExternalServiceRequest) ProtoMessage() {}
 
 func (x *SyncExternalServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[15]
+	mi := &file_repoupdater_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,7 +1088,7 @@ func (x *SyncExternalServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncExternalServiceRequest.ProtoReflect.Descriptor instead.
 func (*SyncExternalServiceRequest) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{15}
+	return file_repoupdater_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SyncExternalServiceRequest) GetExternalServiceId() int64 {
@@ -1018,7 +1107,7 @@ type SyncExternalServiceResponse struct {
 func (x *SyncExternalServiceResponse) Reset() {
 	*x = SyncExternalServiceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[16]
+		mi := &file_repoupdater_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1031,7 +1120,7 @@ func (x *SyncExternalServiceResponse) String() string {
 func (*SyncExternalServiceResponse) ProtoMessage() {}
 
 func (x *SyncExternalServiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[16]
+	mi := &file_repoupdater_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1044,7 +1133,7 @@ func (x *SyncExternalServiceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncExternalServiceResponse.ProtoReflect.Descriptor instead.
 func (*SyncExternalServiceResponse) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{16}
+	return file_repoupdater_proto_rawDescGZIP(), []int{17}
 }
 
 type ExternalServiceNamespacesRequest struct {
@@ -1060,7 +1149,7 @@ type ExternalServiceNamespacesRequest struct {
 func (x *ExternalServiceNamespacesRequest) Reset() {
 	*x = ExternalServiceNamespacesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[17]
+		mi := &file_repoupdater_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1073,7 +1162,7 @@ func (x *ExternalServiceNamespacesRequest) String() string {
 func (*ExternalServiceNamespacesRequest) ProtoMessage() {}
 
 func (x *ExternalServiceNamespacesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[17]
+	mi := &file_repoupdater_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1086,7 +1175,7 @@ func (x *ExternalServiceNamespacesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExternalServiceNamespacesRequest.ProtoReflect.Descriptor instead.
 func (*ExternalServiceNamespacesRequest) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{17}
+	return file_repoupdater_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ExternalServiceNamespacesRequest) GetExternalServiceId() int64 {
@@ -1121,7 +1210,7 @@ type ExternalServiceNamespacesResponse struct {
 func (x *ExternalServiceNamespacesResponse) Reset() {
 	*x = ExternalServiceNamespacesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[18]
+		mi := &file_repoupdater_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1134,7 +1223,7 @@ func (x *ExternalServiceNamespacesResponse) String() string {
 func (*ExternalServiceNamespacesResponse) ProtoMessage() {}
 
 func (x *ExternalServiceNamespacesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[18]
+	mi := &file_repoupdater_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1147,7 +1236,7 @@ func (x *ExternalServiceNamespacesResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use ExternalServiceNamespacesResponse.ProtoReflect.Descriptor instead.
 func (*ExternalServiceNamespacesResponse) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{18}
+	return file_repoupdater_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ExternalServiceNamespacesResponse) GetNamespaces() []*ExternalServiceNamespace {
@@ -1171,7 +1260,7 @@ type ExternalServiceNamespace struct {
 func (x *ExternalServiceNamespace) Reset() {
 	*x = ExternalServiceNamespace{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[19]
+		mi := &file_repoupdater_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1184,7 +1273,7 @@ func (x *ExternalServiceNamespace) String() string {
 func (*ExternalServiceNamespace) ProtoMessage() {}
 
 func (x *ExternalServiceNamespace) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[19]
+	mi := &file_repoupdater_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1197,7 +1286,7 @@ func (x *ExternalServiceNamespace) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExternalServiceNamespace.ProtoReflect.Descriptor instead.
 func (*ExternalServiceNamespace) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{19}
+	return file_repoupdater_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ExternalServiceNamespace) GetId() int64 {
@@ -1237,7 +1326,7 @@ type ExternalServiceRepositoriesRequest struct {
 func (x *ExternalServiceRepositoriesRequest) Reset() {
 	*x = ExternalServiceRepositoriesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[20]
+		mi := &file_repoupdater_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1250,7 +1339,7 @@ func (x *ExternalServiceRepositoriesRequest) String() string {
 func (*ExternalServiceRepositoriesRequest) ProtoMessage() {}
 
 func (x *ExternalServiceRepositoriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[20]
+	mi := &file_repoupdater_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1263,7 +1352,7 @@ func (x *ExternalServiceRepositoriesRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use ExternalServiceRepositoriesRequest.ProtoReflect.Descriptor instead.
 func (*ExternalServiceRepositoriesRequest) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{20}
+	return file_repoupdater_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ExternalServiceRepositoriesRequest) GetExternalServiceId() int64 {
@@ -1319,7 +1408,7 @@ type ExternalServiceRepositoriesResponse struct {
 func (x *ExternalServiceRepositoriesResponse) Reset() {
 	*x = ExternalServiceRepositoriesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[21]
+		mi := &file_repoupdater_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1332,7 +1421,7 @@ func (x *ExternalServiceRepositoriesResponse) String() string {
 func (*ExternalServiceRepositoriesResponse) ProtoMessage() {}
 
 func (x *ExternalServiceRepositoriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[21]
+	mi := &file_repoupdater_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1345,7 +1434,7 @@ func (x *ExternalServiceRepositoriesResponse) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use ExternalServiceRepositoriesResponse.ProtoReflect.Descriptor instead.
 func (*ExternalServiceRepositoriesResponse) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{21}
+	return file_repoupdater_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ExternalServiceRepositoriesResponse) GetRepos() []*ExternalServiceRepository {
@@ -1369,7 +1458,7 @@ type ExternalServiceRepository struct {
 func (x *ExternalServiceRepository) Reset() {
 	*x = ExternalServiceRepository{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repoupdater_proto_msgTypes[22]
+		mi := &file_repoupdater_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1382,7 +1471,7 @@ func (x *ExternalServiceRepository) String() string {
 func (*ExternalServiceRepository) ProtoMessage() {}
 
 func (x *ExternalServiceRepository) ProtoReflect() protoreflect.Message {
-	mi := &file_repoupdater_proto_msgTypes[22]
+	mi := &file_repoupdater_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1395,7 +1484,7 @@ func (x *ExternalServiceRepository) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExternalServiceRepository.ProtoReflect.Descriptor instead.
 func (*ExternalServiceRepository) Descriptor() ([]byte, []int) {
-	return file_repoupdater_proto_rawDescGZIP(), []int{22}
+	return file_repoupdater_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ExternalServiceRepository) GetId() int32 {
@@ -1508,137 +1597,147 @@ var file_repoupdater_proto_rawDesc = []byte{
 	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
 	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x2e,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x5d,
 	0x0a, 0x18, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x70, 0x64,
 	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65,
-	0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0x3f,
-	0x0a, 0x19, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
-	0x2f, 0x0a, 0x1b, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x73, 0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
-	0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x03, 0x69, 0x64, 0x73,
-	0x22, 0x1e, 0x0a, 0x1c, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x73, 0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x40, 0x0a, 0x11, 0x46, 0x65, 0x74, 0x63, 0x68, 0x50, 0x65, 0x72, 0x6d, 0x73, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64,
-	0x61, 0x74, 0x65, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x10, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x63, 0x68,
-	0x65, 0x73, 0x22, 0x4c, 0x0a, 0x1a, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x2e, 0x0a, 0x13, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x65,
-	0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64,
-	0x22, 0x1d, 0x0a, 0x1b, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x9b, 0x01, 0x0a, 0x20, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x13, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x48, 0x00, 0x52, 0x11, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x22, 0x6d, 0x0a,
-	0x21, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x48, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x22, 0x5f, 0x0a, 0x18,
-	0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e,
-	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
-	0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x22, 0xee, 0x01,
-	0x0a, 0x22, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x13, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x48, 0x00, 0x52, 0x11, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a,
-	0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x66,
-	0x69, 0x72, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x69, 0x72, 0x73,
-	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x72, 0x65, 0x70,
-	0x6f, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
-	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72,
-	0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x22, 0x66,
-	0x0a, 0x23, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52,
-	0x05, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x22, 0x60, 0x0a, 0x19, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
-	0x6f, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x78, 0x74, 0x65, 0x72,
-	0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x32, 0xbe, 0x06, 0x0a, 0x12, 0x52, 0x65, 0x70,
-	0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x7a, 0x0a, 0x17, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68,
-	0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2e, 0x2e, 0x72, 0x65, 0x70,
-	0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x72, 0x65, 0x70,
-	0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0a, 0x52,
-	0x65, 0x70, 0x6f, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x12, 0x21, 0x2e, 0x72, 0x65, 0x70, 0x6f,
-	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x4c,
-	0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x72,
+	0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x2d,
+	0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72,
 	0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x70, 0x6f, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x68, 0x0a, 0x11, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61,
+	0x66, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x22, 0x65, 0x0a,
+	0x09, 0x52, 0x65, 0x66, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x6f,
+	0x72, 0x63, 0x65, 0x64, 0x22, 0x3f, 0x0a, 0x19, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x2f, 0x0a, 0x1b, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x1e, 0x0a, 0x1c, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x40, 0x0a, 0x11, 0x46, 0x65, 0x74, 0x63, 0x68, 0x50,
+	0x65, 0x72, 0x6d, 0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x69,
+	0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x73, 0x22, 0x4c, 0x0a, 0x1a, 0x53, 0x79, 0x6e, 0x63,
+	0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x11, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x1d, 0x0a, 0x1b, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x9b, 0x01, 0x0a, 0x20, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x13, 0x65, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x11, 0x65, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x16, 0x0a, 0x14, 0x5f,
+	0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x22, 0x6d, 0x0a, 0x21, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x73, 0x22, 0x5f, 0x0a, 0x18, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x49, 0x64, 0x22, 0xee, 0x01, 0x0a, 0x22, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x13, 0x65, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x11, 0x65, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x42, 0x16, 0x0a, 0x14,
+	0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x69, 0x64, 0x22, 0x66, 0x0a, 0x23, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x05, 0x72,
+	0x65, 0x70, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x72, 0x65, 0x70,
+	0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x05, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x22, 0x60, 0x0a, 0x19,
+	0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x32, 0xbe,
+	0x06, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x7a, 0x0a, 0x17, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x2e, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2f, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x53, 0x0a, 0x0a, 0x52, 0x65, 0x70, 0x6f, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x12,
+	0x21, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x68, 0x0a, 0x11, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x71,
+	0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61,
 	0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65,
-	0x70, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x29, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a, 0x14, 0x45, 0x6e,
-	0x71, 0x75, 0x65, 0x75, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x65, 0x74, 0x53, 0x79,
-	0x6e, 0x63, 0x12, 0x2b, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x73, 0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x2c, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x65,
-	0x74, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6e, 0x0a,
-	0x13, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x2a, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x2b, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x80, 0x01,
-	0x0a, 0x19, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x30, 0x2e, 0x72, 0x65,
-	0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74,
-	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e,
-	0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45,
-	0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x86, 0x01, 0x0a, 0x1b, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73,
-	0x12, 0x32, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72,
-	0x61, 0x70, 0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x71, 0x0a, 0x14, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x2b, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x6e, 0x0a, 0x13, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2a, 0x2e, 0x72, 0x65, 0x70,
+	0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63,
+	0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x78, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x80, 0x01, 0x0a, 0x19, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x73, 0x12, 0x30, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x86, 0x01, 0x0a, 0x1b, 0x45, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x32, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e, 0x72, 0x65, 0x70,
+	0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72,
+	0x65, 0x70, 0x6f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1653,7 +1752,7 @@ func file_repoupdater_proto_rawDescGZIP() []byte {
 	return file_repoupdater_proto_rawDescData
 }
 
-var file_repoupdater_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_repoupdater_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_repoupdater_proto_goTypes = []interface{}{
 	(*RepoUpdateSchedulerInfoRequest)(nil),      // 0: repoupdater.v1.RepoUpdateSchedulerInfoRequest
 	(*RepoUpdateSchedulerInfoResponse)(nil),     // 1: repoupdater.v1.RepoUpdateSchedulerInfoResponse
@@ -1666,49 +1765,51 @@ var file_repoupdater_proto_goTypes = []interface{}{
 	(*RepoLinks)(nil),                           // 8: repoupdater.v1.RepoLinks
 	(*ExternalRepoSpec)(nil),                    // 9: repoupdater.v1.ExternalRepoSpec
 	(*EnqueueRepoUpdateRequest)(nil),            // 10: repoupdater.v1.EnqueueRepoUpdateRequest
-	(*EnqueueRepoUpdateResponse)(nil),           // 11: repoupdater.v1.EnqueueRepoUpdateResponse
-	(*EnqueueChangesetSyncRequest)(nil),         // 12: repoupdater.v1.EnqueueChangesetSyncRequest
-	(*EnqueueChangesetSyncResponse)(nil),        // 13: repoupdater.v1.EnqueueChangesetSyncResponse
-	(*FetchPermsOptions)(nil),                   // 14: repoupdater.v1.FetchPermsOptions
-	(*SyncExternalServiceRequest)(nil),          // 15: repoupdater.v1.SyncExternalServiceRequest
-	(*SyncExternalServiceResponse)(nil),         // 16: repoupdater.v1.SyncExternalServiceResponse
-	(*ExternalServiceNamespacesRequest)(nil),    // 17: repoupdater.v1.ExternalServiceNamespacesRequest
-	(*ExternalServiceNamespacesResponse)(nil),   // 18: repoupdater.v1.ExternalServiceNamespacesResponse
-	(*ExternalServiceNamespace)(nil),            // 19: repoupdater.v1.ExternalServiceNamespace
-	(*ExternalServiceRepositoriesRequest)(nil),  // 20: repoupdater.v1.ExternalServiceRepositoriesRequest
-	(*ExternalServiceRepositoriesResponse)(nil), // 21: repoupdater.v1.ExternalServiceRepositoriesResponse
-	(*ExternalServiceRepository)(nil),           // 22: repoupdater.v1.ExternalServiceRepository
-	(*timestamppb.Timestamp)(nil),               // 23: google.protobuf.Timestamp
+	(*RefUpdate)(nil),                           // 11: repoupdater.v1.RefUpdate
+	(*EnqueueRepoUpdateResponse)(nil),           // 12: repoupdater.v1.EnqueueRepoUpdateResponse
+	(*EnqueueChangesetSyncRequest)(nil),         // 13: repoupdater.v1.EnqueueChangesetSyncRequest
+	(*EnqueueChangesetSyncResponse)(nil),        // 14: repoupdater.v1.EnqueueChangesetSyncResponse
+	(*FetchPermsOptions)(nil),                   // 15: repoupdater.v1.FetchPermsOptions
+	(*SyncExternalServiceRequest)(nil),          // 16: repoupdater.v1.SyncExternalServiceRequest
+	(*SyncExternalServiceResponse)(nil),         // 17: repoupdater.v1.SyncExternalServiceResponse
+	(*ExternalServiceNamespacesRequest)(nil),    // 18: repoupdater.v1.ExternalServiceNamespacesRequest
+	(*ExternalServiceNamespacesResponse)(nil),   // 19: repoupdater.v1.ExternalServiceNamespacesResponse
+	(*ExternalServiceNamespace)(nil),            // 20: repoupdater.v1.ExternalServiceNamespace
+	(*ExternalServiceRepositoriesRequest)(nil),  // 21: repoupdater.v1.ExternalServiceRepositoriesRequest
+	(*ExternalServiceRepositoriesResponse)(nil), // 22: repoupdater.v1.ExternalServiceRepositoriesResponse
+	(*ExternalServiceRepository)(nil),           // 23: repoupdater.v1.ExternalServiceRepository
+	(*timestamppb.Timestamp)(nil),               // 24: google.protobuf.Timestamp
 }
 var file_repoupdater_proto_depIdxs = []int32{
 	2,  // 0: repoupdater.v1.RepoUpdateSchedulerInfoResponse.schedule:type_name -> repoupdater.v1.RepoScheduleState
 	3,  // 1: repoupdater.v1.RepoUpdateSchedulerInfoResponse.queue:type_name -> repoupdater.v1.RepoQueueState
-	23, // 2: repoupdater.v1.RepoScheduleState.due:type_name -> google.protobuf.Timestamp
+	24, // 2: repoupdater.v1.RepoScheduleState.due:type_name -> google.protobuf.Timestamp
 	6,  // 3: repoupdater.v1.RepoLookupResponse.repo:type_name -> repoupdater.v1.RepoInfo
 	7,  // 4: repoupdater.v1.RepoInfo.vcs_info:type_name -> repoupdater.v1.VCSInfo
 	8,  // 5: repoupdater.v1.RepoInfo.links:type_name -> repoupdater.v1.RepoLinks
 	9,  // 6: repoupdater.v1.RepoInfo.external_repo:type_name -> repoupdater.v1.ExternalRepoSpec
-	19, // 7: repoupdater.v1.ExternalServiceNamespacesResponse.namespaces:type_name -> repoupdater.v1.ExternalServiceNamespace
-	22, // 8: repoupdater.v1.ExternalServiceRepositoriesResponse.repos:type_name -> repoupdater.v1.ExternalServiceRepository
-	0,  // 9: repoupdater.v1.RepoUpdaterService.RepoUpdateSchedulerInfo:input_type -> repoupdater.v1.RepoUpdateSchedulerInfoRequest
-	4,  // 10: repoupdater.v1.RepoUpdaterService.RepoLookup:input_type -> repoupdater.v1.RepoLookupRequest
-	10, // 11: repoupdater.v1.RepoUpdaterService.EnqueueRepoUpdate:input_type -> repoupdater.v1.EnqueueRepoUpdateRequest
-	12, // 12: repoupdater.v1.RepoUpdaterService.EnqueueChangesetSync:input_type -> repoupdater.v1.EnqueueChangesetSyncRequest
-	15, // 13: repoupdater.v1.RepoUpdaterService.SyncExternalService:input_type -> repoupdater.v1.SyncExternalServiceRequest
-	17, // 14: repoupdater.v1.RepoUpdaterService.ExternalServiceNamespaces:input_type -> repoupdater.v1.ExternalServiceNamespacesRequest
-	20, // 15: repoupdater.v1.RepoUpdaterService.ExternalServiceRepositories:input_type -> repoupdater.v1.ExternalServiceRepositoriesRequest
-	1,  // 16: repoupdater.v1.RepoUpdaterService.RepoUpdateSchedulerInfo:output_type -> repoupdater.v1.RepoUpdateSchedulerInfoResponse
-	5,  // 17: repoupdater.v1.RepoUpdaterService.RepoLookup:output_type -> repoupdater.v1.RepoLookupResponse
-	11, // 18: repoupdater.v1.RepoUpdaterService.EnqueueRepoUpdate:output_type -> repoupdater.v1.EnqueueRepoUpdateResponse
-	13, // 19: repoupdater.v1.RepoUpdaterService.EnqueueChangesetSync:output_type -> repoupdater.v1.EnqueueChangesetSyncResponse
-	16, // 20: repoupdater.v1.RepoUpdaterService.SyncExternalService:output_type -> repoupdater.v1.SyncExternalServiceResponse
-	18, // 21: repoupdater.v1.RepoUpdaterService.ExternalServiceNamespaces:output_type -> repoupdater.v1.ExternalServiceNamespacesResponse
-	21, // 22: repoupdater.v1.RepoUpdaterService.ExternalServiceRepositories:output_type -> repoupdater.v1.ExternalServiceRepositoriesResponse
-	16, // [16:23] is the sub-list for method output_type
-	9,  // [9:16] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	11, // 7: repoupdater.v1.EnqueueRepoUpdateRequest.refs:type_name -> repoupdater.v1.RefUpdate
+	20, // 8: repoupdater.v1.ExternalServiceNamespacesResponse.namespaces:type_name -> repoupdater.v1.ExternalServiceNamespace
+	23, // 9: repoupdater.v1.ExternalServiceRepositoriesResponse.repos:type_name -> repoupdater.v1.ExternalServiceRepository
+	0,  // 10: repoupdater.v1.RepoUpdaterService.RepoUpdateSchedulerInfo:input_type -> repoupdater.v1.RepoUpdateSchedulerInfoRequest
+	4,  // 11: repoupdater.v1.RepoUpdaterService.RepoLookup:input_type -> repoupdater.v1.RepoLookupRequest
+	10, // 12: repoupdater.v1.RepoUpdaterService.EnqueueRepoUpdate:input_type -> repoupdater.v1.EnqueueRepoUpdateRequest
+	13, // 13: repoupdater.v1.RepoUpdaterService.EnqueueChangesetSync:input_type -> repoupdater.v1.EnqueueChangesetSyncRequest
+	16, // 14: repoupdater.v1.RepoUpdaterService.SyncExternalService:input_type -> repoupdater.v1.SyncExternalServiceRequest
+	18, // 15: repoupdater.v1.RepoUpdaterService.ExternalServiceNamespaces:input_type -> repoupdater.v1.ExternalServiceNamespacesRequest
+	21, // 16: repoupdater.v1.RepoUpdaterService.ExternalServiceRepositories:input_type -> repoupdater.v1.ExternalServiceRepositoriesRequest
+	1,  // 17: repoupdater.v1.RepoUpdaterService.RepoUpdateSchedulerInfo:output_type -> repoupdater.v1.RepoUpdateSchedulerInfoResponse
+	5,  // 18: repoupdater.v1.RepoUpdaterService.RepoLookup:output_type -> repoupdater.v1.RepoLookupResponse
+	12, // 19: repoupdater.v1.RepoUpdaterService.EnqueueRepoUpdate:output_type -> repoupdater.v1.EnqueueRepoUpdateResponse
+	14, // 20: repoupdater.v1.RepoUpdaterService.EnqueueChangesetSync:output_type -> repoupdater.v1.EnqueueChangesetSyncResponse
+	17, // 21: repoupdater.v1.RepoUpdaterService.SyncExternalService:output_type -> repoupdater.v1.SyncExternalServiceResponse
+	19, // 22: repoupdater.v1.RepoUpdaterService.ExternalServiceNamespaces:output_type -> repoupdater.v1.ExternalServiceNamespacesResponse
+	22, // 23: repoupdater.v1.RepoUpdaterService.ExternalServiceRepositories:output_type -> repoupdater.v1.ExternalServiceRepositoriesResponse
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_repoupdater_proto_init() }
@@ -1850,7 +1951,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EnqueueRepoUpdateResponse); i {
+			switch v := v.(*RefUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1862,7 +1963,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EnqueueChangesetSyncRequest); i {
+			switch v := v.(*EnqueueRepoUpdateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1874,7 +1975,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EnqueueChangesetSyncResponse); i {
+			switch v := v.(*EnqueueChangesetSyncRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1886,7 +1987,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchPermsOptions); i {
+			switch v := v.(*EnqueueChangesetSyncResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1898,7 +1999,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncExternalServiceRequest); i {
+			switch v := v.(*FetchPermsOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1910,7 +2011,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncExternalServiceResponse); i {
+			switch v := v.(*SyncExternalServiceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1922,7 +2023,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExternalServiceNamespacesRequest); i {
+			switch v := v.(*SyncExternalServiceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1934,7 +2035,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExternalServiceNamespacesResponse); i {
+			switch v := v.(*ExternalServiceNamespacesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1946,7 +2047,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExternalServiceNamespace); i {
+			switch v := v.(*ExternalServiceNamespacesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1958,7 +2059,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExternalServiceRepositoriesRequest); i {
+			switch v := v.(*ExternalServiceNamespace); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1970,7 +2071,7 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExternalServiceRepositoriesResponse); i {
+			switch v := v.(*ExternalServiceRepositoriesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1982,6 +2083,18 @@ func file_repoupdater_proto_init() {
 			}
 		}
 		file_repoupdater_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExternalServiceRepositoriesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repoupdater_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ExternalServiceRepository); i {
 			case 0:
 				return &v.state
@@ -1994,15 +2107,15 @@ func file_repoupdater_proto_init() {
 			}
 		}
 	}
-	file_repoupdater_proto_msgTypes[17].OneofWrappers = []interface{}{}
-	file_repoupdater_proto_msgTypes[20].OneofWrappers = []interface{}{}
+	file_repoupdater_proto_msgTypes[18].OneofWrappers = []interface{}{}
+	file_repoupdater_proto_msgTypes[21].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_repoupdater_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
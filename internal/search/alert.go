@@ -166,6 +166,19 @@ func AlertForInvalidRevision(revision string) *Alert {
 	}
 }
 
+// AlertForSearchDiffLimitHit returns an alert for a search.diff: query where
+// the search at either revision hit its result limit, so that matches missing
+// from one side may be reported as added or removed.
+func AlertForSearchDiffLimitHit() *Alert {
+	return &Alert{
+		PrometheusType: "search_diff_limit_hit",
+		Kind:           "search-diff-limit-hit",
+		Title:          "Search diff may be incomplete",
+		Description:    "The search at one of the compared revisions hit its result limit or matched too many files to compare, so some added or removed matches may be wrong. Narrow the query with repo: or file: filters, or raise count:, to compare all matches.",
+		Priority:       1,
+	}
+}
+
 func AlertForUnownedResult() *Alert {
 	return &Alert{
 		Kind:        "unowned-results",
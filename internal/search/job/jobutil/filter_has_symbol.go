@@ -0,0 +1,458 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+	"github.com/sourcegraph/sourcegraph/internal/symbols"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// hasSymbolLimit is the number of symbols we inspect when a has.symbol()
+	// predicate filters on kind, since the kind can only be checked after
+	// fetching symbols by name, or is evaluated for several files at once.
+	hasSymbolLimit = 10000
+
+	// hasSymbolConcurrency bounds the number of repository revisions whose
+	// symbols are looked up concurrently for a single event.
+	hasSymbolConcurrency = 8
+)
+
+// NewFileHasSymbolJob creates a filter job to post-filter results for the
+// file:has.symbol() predicate. A file match is kept only if it satisfies all
+// of the given predicates. Results that are not file matches are dropped.
+func NewFileHasSymbolJob(child job.Job, args []query.HasSymbolArgs, isCaseSensitive bool) job.Job {
+	return &fileHasSymbolJob{
+		child:           child,
+		args:            args,
+		isCaseSensitive: isCaseSensitive,
+		searchSymbols:   symbols.DefaultClient.Search,
+	}
+}
+
+type fileHasSymbolJob struct {
+	child job.Job
+
+	args            []query.HasSymbolArgs
+	isCaseSensitive bool
+
+	// searchSymbols queries the symbols service for unindexed repositories.
+	searchSymbols symbolsSearchFunc
+}
+
+func (j *fileHasSymbolJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		// Symbols are looked up once per repository revision and predicate
+		// for all the files of an event, rather than once per file.
+		var (
+			groups []*fileGroup
+			byKey  = make(map[repoCommit]*fileGroup)
+		)
+		for _, res := range event.Results {
+			fm, ok := res.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			key := repoCommit{repo: fm.Repo.ID, commit: fm.CommitID}
+			g, ok := byKey[key]
+			if !ok {
+				g = &fileGroup{repo: fm.Repo, commit: fm.CommitID}
+				byKey[key] = g
+				groups = append(groups, g)
+			}
+			g.paths = append(g.paths, fm.Path)
+		}
+
+		p := pool.New().WithMaxGoroutines(hasSymbolConcurrency)
+		for _, g := range groups {
+			g := g
+			p.Go(func() {
+				// We quit early on context deadline exceeded rather than
+				// sending further symbols requests.
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					mu.Lock()
+					errs = errors.Append(errs, ctx.Err())
+					mu.Unlock()
+					return
+				}
+
+				keep, err := filesWithSymbols(ctx, clients, j.searchSymbols, g.repo, g.commit, g.paths, j.args, j.isCaseSensitive)
+				if err != nil {
+					mu.Lock()
+					errs = errors.Append(errs, err)
+					mu.Unlock()
+					return
+				}
+				g.keep = keep
+			})
+		}
+		p.Wait()
+
+		filtered := event.Results[:0]
+		for _, res := range event.Results {
+			fm, ok := res.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			if _, ok := byKey[repoCommit{repo: fm.Repo.ID, commit: fm.CommitID}].keep[fm.Path]; ok {
+				filtered = append(filtered, fm)
+			}
+		}
+
+		event.Results = filtered
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+type repoCommit struct {
+	repo   api.RepoID
+	commit api.CommitID
+}
+
+// fileGroup is the file matches of an event at the same repository revision.
+type fileGroup struct {
+	repo   types.MinimalRepo
+	commit api.CommitID
+	paths  []string
+
+	// keep is the set of paths that satisfy the predicates.
+	keep map[string]struct{}
+}
+
+func (j *fileHasSymbolJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+func (j *fileHasSymbolJob) Name() string {
+	return "FileHasSymbolFilterJob"
+}
+
+func (j *fileHasSymbolJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHasSymbolJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res, hasSymbolAttributes(j.args, j.isCaseSensitive)...)
+	}
+	return res
+}
+
+// NewRepoHasSymbolJob creates a filter job to post-filter results for the
+// repo:has.symbol() predicate. A match is kept only if its repository
+// satisfies all of the given predicates at the revision of the match.
+func NewRepoHasSymbolJob(child job.Job, args []query.HasSymbolArgs, isCaseSensitive bool) job.Job {
+	return &repoHasSymbolJob{
+		child:           child,
+		args:            args,
+		isCaseSensitive: isCaseSensitive,
+		searchSymbols:   symbols.DefaultClient.Search,
+	}
+}
+
+type repoHasSymbolJob struct {
+	child job.Job
+
+	args            []query.HasSymbolArgs
+	isCaseSensitive bool
+
+	// searchSymbols queries the symbols service for unindexed repositories.
+	searchSymbols symbolsSearchFunc
+}
+
+func (j *repoHasSymbolJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+
+		// keep caches the outcome for each repository revision, since
+		// many results usually share the same revision.
+		keep = make(map[repoCommit]bool)
+	)
+
+	appendErr := func(err error) {
+		mu.Lock()
+		errs = errors.Append(errs, err)
+		mu.Unlock()
+	}
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			appendErr(ctx.Err())
+			event.Results = event.Results[:0]
+			stream.Send(event)
+			return
+		}
+
+		// Resolve the revision of every match first, so that each revision
+		// missing from the cache is only evaluated once.
+		keys := make([]*repoCommit, len(event.Results))
+		p := pool.New().WithMaxGoroutines(hasSymbolConcurrency)
+		for i, res := range event.Results {
+			i, res := i, res
+			p.Go(func() {
+				commit, err := matchCommit(ctx, clients.Gitserver, res)
+				if err != nil {
+					appendErr(err)
+					return
+				}
+				keys[i] = &repoCommit{repo: res.RepoName().ID, commit: commit}
+			})
+		}
+		p.Wait()
+
+		pending := make(map[repoCommit]types.MinimalRepo)
+		mu.Lock()
+		for i, key := range keys {
+			if key == nil {
+				continue
+			}
+			if _, ok := keep[*key]; !ok {
+				pending[*key] = event.Results[i].RepoName()
+			}
+		}
+		mu.Unlock()
+
+		p = pool.New().WithMaxGoroutines(hasSymbolConcurrency)
+		for key, repo := range pending {
+			key, repo := key, repo
+			p.Go(func() {
+				ok, err := allHaveSymbol(j.args, func(arg query.HasSymbolArgs) (bool, error) {
+					paths, err := symbolPaths(ctx, clients, j.searchSymbols, repo, key.commit, nil, arg, j.isCaseSensitive)
+					return len(paths) > 0, err
+				})
+				if err != nil {
+					appendErr(err)
+					return
+				}
+				mu.Lock()
+				keep[key] = ok
+				mu.Unlock()
+			})
+		}
+		p.Wait()
+
+		filtered := event.Results[:0]
+		mu.Lock()
+		for i, res := range event.Results {
+			if key := keys[i]; key != nil && keep[*key] {
+				filtered = append(filtered, res)
+			}
+		}
+		mu.Unlock()
+
+		event.Results = filtered
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (j *repoHasSymbolJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+func (j *repoHasSymbolJob) Name() string {
+	return "RepoHasSymbolFilterJob"
+}
+
+func (j *repoHasSymbolJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *repoHasSymbolJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res, hasSymbolAttributes(j.args, j.isCaseSensitive)...)
+	}
+	return res
+}
+
+type symbolsSearchFunc func(context.Context, search.SymbolsParameters) (result.Symbols, error)
+
+// matchCommit returns the commit a match was found at. Matches without a
+// commit are evaluated at the revision they were searched at, or HEAD.
+func matchCommit(ctx context.Context, client gitserver.Client, m result.Match) (api.CommitID, error) {
+	var rev string
+	switch v := m.(type) {
+	case *result.FileMatch:
+		return v.CommitID, nil
+	case *result.CommitMatch:
+		return v.Commit.ID, nil
+	case *result.RepoMatch:
+		rev = v.Rev
+	}
+	return client.ResolveRevision(ctx, m.RepoName().Name, rev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+}
+
+// allHaveSymbol returns true if every predicate holds, where has reports
+// whether a symbol matching the predicate exists.
+func allHaveSymbol(args []query.HasSymbolArgs, has func(query.HasSymbolArgs) (bool, error)) (bool, error) {
+	for _, arg := range args {
+		ok, err := has(arg)
+		if err != nil {
+			return false, err
+		}
+		if ok == arg.Negated {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// filesWithSymbols returns the subset of paths in repo at commit that satisfy
+// every predicate.
+func filesWithSymbols(ctx context.Context, clients job.RuntimeClients, searchSymbols symbolsSearchFunc, repo types.MinimalRepo, commit api.CommitID, paths []string, args []query.HasSymbolArgs, isCaseSensitive bool) (map[string]struct{}, error) {
+	remaining := paths
+	for _, arg := range args {
+		if len(remaining) == 0 {
+			break
+		}
+
+		has, err := symbolPaths(ctx, clients, searchSymbols, repo, commit, remaining, arg, isCaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []string
+		for _, path := range remaining {
+			if _, ok := has[path]; ok != arg.Negated {
+				next = append(next, path)
+			}
+		}
+		remaining = next
+	}
+
+	keep := make(map[string]struct{}, len(remaining))
+	for _, path := range remaining {
+		keep[path] = struct{}{}
+	}
+	return keep, nil
+}
+
+// symbolPaths returns the set of paths of files in repo at commit that define a
+// symbol matching arg. If paths is non-empty, only symbols defined in those
+// files are considered.
+//
+// Symbols are looked up in Zoekt if it has indexed symbols for the commit, and
+// in the symbols service otherwise.
+func symbolPaths(ctx context.Context, clients job.RuntimeClients, searchSymbols symbolsSearchFunc, repo types.MinimalRepo, commit api.CommitID, paths []string, arg query.HasSymbolArgs, isCaseSensitive bool) (map[string]struct{}, error) {
+	// Without a kind the first symbol with a matching name is enough to
+	// decide for a single file or the whole repository.
+	limit := 1
+	if arg.Kind != "" || len(paths) > 1 {
+		limit = hasSymbolLimit
+	}
+
+	var (
+		matches []*result.SymbolMatch
+		indexed bool
+		err     error
+	)
+	if clients.Zoekt != nil {
+		matches, indexed, err = zoekt.SearchSymbolsAtCommit(ctx, clients.Zoekt, repo, commit, zoekt.SymbolsAtCommitOptions{
+			Name:            arg.Name,
+			Paths:           paths,
+			IsCaseSensitive: isCaseSensitive,
+			Limit:           limit,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "zoekt symbol search")
+		}
+	}
+
+	if !indexed {
+		params := search.SymbolsParameters{
+			Repo:            repo.Name,
+			CommitID:        commit,
+			Query:           arg.Name,
+			IsRegExp:        true,
+			IsCaseSensitive: isCaseSensitive,
+			First:           limit,
+		}
+		if len(paths) > 0 {
+			params.IncludePatterns = []string{zoekt.ExactPathsPattern(paths)}
+		}
+		syms, err := searchSymbols(ctx, params)
+		if err != nil {
+			return nil, errors.Wrap(err, "symbols search")
+		}
+		matches = make([]*result.SymbolMatch, 0, len(syms))
+		for i := range syms {
+			matches = append(matches, &result.SymbolMatch{
+				Symbol: syms[i],
+				File:   &result.File{Repo: repo, CommitID: commit, Path: syms[i].Path},
+			})
+		}
+	}
+
+	if arg.Kind != "" {
+		matches = result.SelectSymbolKind(matches, arg.Kind)
+	}
+
+	found := make(map[string]struct{}, len(matches))
+	for _, m := range matches {
+		found[m.File.Path] = struct{}{}
+	}
+	return found, nil
+}
+
+func hasSymbolAttributes(args []query.HasSymbolArgs, isCaseSensitive bool) []attribute.KeyValue {
+	var include, exclude []string
+	for _, arg := range args {
+		s := "name:" + arg.Name + " kind:" + arg.Kind
+		if arg.Negated {
+			exclude = append(exclude, s)
+		} else {
+			include = append(include, s)
+		}
+	}
+	return []attribute.KeyValue{
+		attribute.StringSlice("includeSymbols", include),
+		attribute.StringSlice("excludeSymbols", exclude),
+		attribute.Bool("isCaseSensitive", isCaseSensitive),
+	}
+}
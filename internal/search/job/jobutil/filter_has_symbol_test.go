@@ -0,0 +1,278 @@
+package jobutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileHasSymbolJob(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "repo"}
+
+	fm := func() *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     repo,
+				Path:     "client.go",
+				CommitID: "commitID",
+			},
+		}
+	}
+
+	// indexed returns a Zoekt with symbols for repo at commitID, where
+	// client.go defines a symbol of the given kind.
+	indexed := func(kind string) *backend.FakeStreamer {
+		return &backend.FakeStreamer{
+			Repos: []*zoekt.RepoListEntry{{
+				Repository: zoekt.Repository{
+					ID:         uint32(repo.ID),
+					Name:       string(repo.Name),
+					HasSymbols: true,
+					Branches:   []zoekt.RepositoryBranch{{Name: "HEAD", Version: "commitID"}},
+				},
+			}},
+			Results: []*zoekt.SearchResult{{
+				Files: []zoekt.FileMatch{{
+					FileName:   "client.go",
+					Repository: string(repo.Name),
+					Version:    "commitID",
+					ChunkMatches: []zoekt.ChunkMatch{{
+						Content:      []byte("func NewClient() {}"),
+						ContentStart: zoekt.Location{LineNumber: 1, Column: 1},
+						Ranges:       []zoekt.Range{{Start: zoekt.Location{LineNumber: 1, Column: 6}}},
+						SymbolInfo:   []*zoekt.Symbol{{Sym: "NewClient", Kind: kind}},
+					}},
+				}},
+			}},
+		}
+	}
+
+	unindexed := &backend.FakeStreamer{}
+
+	tests := []struct {
+		name        string
+		args        []query.HasSymbolArgs
+		zoekt       *backend.FakeStreamer
+		symbols     result.Symbols
+		matches     result.Match
+		outputEvent streaming.SearchEvent
+	}{{
+		name:        "indexed name matches",
+		args:        []query.HasSymbolArgs{{Name: "NewClient"}},
+		zoekt:       indexed("func"),
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{fm()}},
+	}, {
+		name:        "indexed kind matches",
+		args:        []query.HasSymbolArgs{{Name: "NewClient", Kind: "function"}},
+		zoekt:       indexed("func"),
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{fm()}},
+	}, {
+		name:        "indexed kind does not match",
+		args:        []query.HasSymbolArgs{{Name: "NewClient", Kind: "struct"}},
+		zoekt:       indexed("func"),
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "indexed negated matches",
+		args:        []query.HasSymbolArgs{{Kind: "function", Negated: true}},
+		zoekt:       indexed("func"),
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "unindexed kind matches",
+		args:        []query.HasSymbolArgs{{Kind: "struct"}},
+		zoekt:       unindexed,
+		symbols:     result.Symbols{{Name: "Client", Kind: "struct", Path: "client.go"}},
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{fm()}},
+	}, {
+		name:        "unindexed has no symbols",
+		args:        []query.HasSymbolArgs{{Name: "NewClient"}},
+		zoekt:       unindexed,
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "unindexed negated has no symbols",
+		args:        []query.HasSymbolArgs{{Name: "NewClient", Negated: true}},
+		zoekt:       unindexed,
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{fm()}},
+	}, {
+		name:        "not all matches are files",
+		args:        []query.HasSymbolArgs{{Name: "NewClient"}},
+		zoekt:       indexed("func"),
+		matches:     &result.CommitMatch{},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: result.Matches{tc.matches}})
+				return nil, nil
+			})
+
+			var gotParams []search.SymbolsParameters
+			j := NewFileHasSymbolJob(childJob, tc.args, false).(*fileHasSymbolJob)
+			j.searchSymbols = func(_ context.Context, params search.SymbolsParameters) (result.Symbols, error) {
+				gotParams = append(gotParams, params)
+				return tc.symbols, nil
+			}
+
+			var resultEvent streaming.SearchEvent
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				resultEvent = ev
+			})
+
+			alert, err := j.Run(context.Background(), job.RuntimeClients{Zoekt: tc.zoekt}, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputEvent, resultEvent)
+
+			for _, params := range gotParams {
+				require.Equal(t, []string{`^client\.go$`}, params.IncludePatterns)
+				require.Equal(t, api.CommitID("commitID"), params.CommitID)
+			}
+		})
+	}
+}
+
+func TestFileHasSymbolJob_Batched(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "repo"}
+	fm := func(path string, commit api.CommitID) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: repo, Path: path, CommitID: commit}}
+	}
+
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{
+			fm("a.go", "c1"),
+			fm("b.go", "c1"),
+			fm("c.go", "c1"),
+			fm("a.go", "c2"),
+		}})
+		return nil, nil
+	})
+
+	var (
+		mu        sync.Mutex
+		gotParams []search.SymbolsParameters
+	)
+	j := NewFileHasSymbolJob(childJob, []query.HasSymbolArgs{{Name: "NewClient"}}, false).(*fileHasSymbolJob)
+	j.searchSymbols = func(_ context.Context, params search.SymbolsParameters) (result.Symbols, error) {
+		mu.Lock()
+		gotParams = append(gotParams, params)
+		mu.Unlock()
+		if params.CommitID == "c1" {
+			return result.Symbols{{Name: "NewClient", Path: "a.go"}, {Name: "NewClient", Path: "c.go"}}, nil
+		}
+		return nil, nil
+	}
+
+	var resultEvent streaming.SearchEvent
+	alert, err := j.Run(context.Background(), job.RuntimeClients{Zoekt: &backend.FakeStreamer{}}, streaming.StreamFunc(func(ev streaming.SearchEvent) {
+		resultEvent = ev
+	}))
+	require.Nil(t, alert)
+	require.NoError(t, err)
+	require.Equal(t, result.Matches{fm("a.go", "c1"), fm("c.go", "c1")}, resultEvent.Results)
+
+	// The symbols service is asked once per revision rather than per file.
+	sort.Slice(gotParams, func(i, k int) bool { return gotParams[i].CommitID < gotParams[k].CommitID })
+	require.Len(t, gotParams, 2)
+	require.Equal(t, []string{`^(?:a\.go|b\.go|c\.go)$`}, gotParams[0].IncludePatterns)
+	require.Equal(t, hasSymbolLimit, gotParams[0].First)
+	require.Equal(t, []string{`^a\.go$`}, gotParams[1].IncludePatterns)
+	require.Equal(t, 1, gotParams[1].First)
+}
+
+func TestRepoHasSymbolJob(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "repo"}
+
+	fm := func(path string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     repo,
+				Path:     path,
+				CommitID: "commitID",
+			},
+		}
+	}
+	rm := &result.RepoMatch{ID: repo.ID, Name: repo.Name}
+
+	tests := []struct {
+		name        string
+		args        []query.HasSymbolArgs
+		symbols     result.Symbols
+		matches     result.Matches
+		outputEvent streaming.SearchEvent
+	}{{
+		name:        "repo defines symbol",
+		args:        []query.HasSymbolArgs{{Name: "^NewClient$", Kind: "function"}},
+		symbols:     result.Symbols{{Name: "NewClient", Kind: "function", Path: "client.go"}},
+		matches:     result.Matches{fm("a.go"), fm("b.go"), rm},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{fm("a.go"), fm("b.go"), rm}},
+	}, {
+		name:        "repo does not define symbol",
+		args:        []query.HasSymbolArgs{{Name: "^NewClient$"}},
+		matches:     result.Matches{fm("a.go"), rm},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "negated repo does not define symbol",
+		args:        []query.HasSymbolArgs{{Name: "^NewClient$", Negated: true}},
+		matches:     result.Matches{fm("a.go"), rm},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{fm("a.go"), rm}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: append(result.Matches{}, tc.matches...)})
+				return nil, nil
+			})
+
+			gitServerClient := gitserver.NewMockClient()
+			gitServerClient.ResolveRevisionFunc.SetDefaultReturn("commitID", nil)
+
+			calls := 0
+			j := NewRepoHasSymbolJob(childJob, tc.args, false).(*repoHasSymbolJob)
+			j.searchSymbols = func(_ context.Context, params search.SymbolsParameters) (result.Symbols, error) {
+				calls++
+				require.Empty(t, params.IncludePatterns)
+				return tc.symbols, nil
+			}
+
+			var resultEvent streaming.SearchEvent
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				resultEvent = ev
+			})
+
+			clients := job.RuntimeClients{Zoekt: &backend.FakeStreamer{}, Gitserver: gitServerClient}
+			alert, err := j.Run(context.Background(), clients, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputEvent, resultEvent)
+
+			// All matches share a revision, so the symbols service is only
+			// asked once.
+			require.Equal(t, 1, calls)
+		})
+	}
+}
@@ -69,6 +69,11 @@ func NewPlanJob(inputs *search.Inputs, plan query.Plan) (job.Job, error) {
 
 // NewBasicJob converts a query.Basic into its job tree representation.
 func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
+	if b.Exists(query.FieldSearchDiff) {
+		// Compare the results of the query at two revisions.
+		return NewSearchDiffJob(inputs, b)
+	}
+
 	var children []job.Job
 	addJob := func(j job.Job) {
 		children = append(children, j)
@@ -203,6 +208,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.codeowners.issue() post-search filter
+		if kinds := b.FileHasCodeownersIssue(); len(kinds) > 0 {
+			basicJob = ownsearch.NewFileHasCodeownersIssueJob(basicJob, kinds)
+		}
+	}
+
 	{ // Apply file:has.contributor() post-search filter
 		if includeContributors, excludeContributors, ok := isContributorSearch(b); ok {
 			includeRe := contributorsAsRegexp(includeContributors, b.IsCaseSensitive())
@@ -211,6 +222,15 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.symbol() and repo:has.symbol() post-search filters
+		if args := b.FileHasSymbol(); len(args) > 0 {
+			basicJob = NewFileHasSymbolJob(basicJob, args, b.IsCaseSensitive())
+		}
+		if args := b.RepoHasSymbol(); len(args) > 0 {
+			basicJob = NewRepoHasSymbolJob(basicJob, args, b.IsCaseSensitive())
+		}
+	}
+
 	{ // Apply subrepo permissions checks
 		checker := authz.DefaultSubRepoPermsChecker
 		if authz.SubRepoEnabled(checker) {
@@ -529,7 +549,7 @@ func getPathRegexpsFromTextPatternInfo(patternInfo *search.TextPatternInfo) (pat
 
 func computeFileMatchLimit(b query.Basic, p search.Protocol) int {
 	// Temporary fix:
-	// If doing ownership or contributor search, we post-filter results so we may need more than
+	// If doing ownership, contributor, has.codeowners.issue() or has.symbol() search, we post-filter results so we may need more than
 	// b.Count() results from the search backends to end up with enough results
 	// sent down the stream.
 	//
@@ -547,6 +567,14 @@ func computeFileMatchLimit(b query.Basic, p search.Protocol) int {
 		// This is the int equivalent of count:all.
 		return query.CountAllLimit
 	}
+	if isSymbolPredicateSearch(b) {
+		// This is the int equivalent of count:all.
+		return query.CountAllLimit
+	}
+	if len(b.FileHasCodeownersIssue()) > 0 {
+		// This is the int equivalent of count:all.
+		return query.CountAllLimit
+	}
 	if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 		sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
 		if isSelectOwnersSearch(sp) {
@@ -575,6 +603,10 @@ func isOwnershipSearch(b query.Basic) (include, exclude []string, ok bool) {
 	return nil, nil, false
 }
 
+func isSymbolPredicateSearch(b query.Basic) bool {
+	return len(b.FileHasSymbol()) > 0 || len(b.RepoHasSymbol()) > 0
+}
+
 func isSelectOwnersSearch(sp filter.SelectPath) bool {
 	// If the filter is for file.owners, this is a select:file.owners search, and we should apply special limits.
 	return sp.Root() == filter.File && len(sp) == 2 && sp[1] == "owners"
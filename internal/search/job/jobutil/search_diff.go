@@ -0,0 +1,239 @@
+package jobutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// NewSearchDiffJob creates a job for a query containing search.diff:. It runs
+// the query at the base revision given by search.diff: and at the revision
+// specified by the repo: filters, and streams a result.FileDiffMatch for every
+// file whose content matches differ between the two revisions.
+func NewSearchDiffJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
+	baseRev, baseQuery, headQuery := query.SearchDiffQueries(b)
+
+	base, err := NewBasicJob(inputs, baseQuery)
+	if err != nil {
+		return nil, err
+	}
+	head, err := NewBasicJob(inputs, headQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &searchDiffJob{baseRev: baseRev, base: base, head: head}, nil
+}
+
+type searchDiffJob struct {
+	baseRev string
+	base    job.Job
+	head    job.Job
+}
+
+func (j *searchDiffJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		baseFiles  = newFileMatchCollector(stream, maxSearchDiffFiles)
+		headFiles  = newFileMatchCollector(stream, maxSearchDiffFiles)
+		pl         = pool.New().WithContext(ctx)
+		maxAlerter search.MaxAlerter
+	)
+	pl.Go(func(ctx context.Context) error {
+		alert, err := j.base.Run(ctx, clients, baseFiles)
+		maxAlerter.Add(alert)
+		return err
+	})
+	pl.Go(func(ctx context.Context) error {
+		alert, err := j.head.Run(ctx, clients, headFiles)
+		maxAlerter.Add(alert)
+		return err
+	})
+	if err := pl.Wait(); err != nil {
+		return maxAlerter.Alert, err
+	}
+
+	// If either side is incomplete, or matched more files than are buffered, a
+	// match missing from it is reported as added or removed even though it may
+	// be unchanged.
+	limitHit := baseFiles.limitHit || headFiles.limitHit
+	if limitHit {
+		maxAlerter.Add(search.AlertForSearchDiffLimitHit())
+	}
+
+	if matches := diffFileMatches(j.baseRev, baseFiles.files, headFiles.files, limitHit); len(matches) > 0 || limitHit {
+		stream.Send(streaming.SearchEvent{
+			Results: matches,
+			Stats:   streaming.Stats{IsLimitHit: limitHit},
+		})
+	}
+	return maxAlerter.Alert, nil
+}
+
+func (j *searchDiffJob) Name() string {
+	return "SearchDiffJob"
+}
+
+func (j *searchDiffJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res, attribute.String("baseRev", j.baseRev))
+	}
+	return res
+}
+
+func (j *searchDiffJob) Children() []job.Describer {
+	return []job.Describer{j.base, j.head}
+}
+
+func (j *searchDiffJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.base = job.Map(j.base, fn)
+	cp.head = job.Map(j.head, fn)
+	return &cp
+}
+
+// maxSearchDiffFiles is the maximum number of files buffered for each of the
+// compared revisions. Both searches must complete before their matches can be
+// compared, so the matches of further files are dropped and the diff is marked
+// as incomplete.
+var maxSearchDiffFiles = 10_000
+
+type fileKey struct {
+	repo api.RepoID
+	path string
+}
+
+// fileMatchCollector accumulates the file matches of a search, merging matches
+// for the same file, for at most limit files. Stats are forwarded to parent as
+// they arrive so that progress is still reported while results are held back.
+type fileMatchCollector struct {
+	parent streaming.Sender
+	limit  int
+
+	mu    sync.Mutex
+	files map[fileKey]*result.FileMatch
+
+	// limitHit is true if the search did not return all of its matches, or
+	// matches of more than limit files were dropped.
+	limitHit bool
+}
+
+func newFileMatchCollector(parent streaming.Sender, limit int) *fileMatchCollector {
+	return &fileMatchCollector{parent: parent, limit: limit, files: make(map[fileKey]*result.FileMatch)}
+}
+
+func (c *fileMatchCollector) Send(event streaming.SearchEvent) {
+	c.mu.Lock()
+	c.limitHit = c.limitHit || event.Stats.IsLimitHit
+	for _, match := range event.Results {
+		fm, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		key := fileKey{repo: fm.Repo.ID, path: fm.Path}
+		if existing, ok := c.files[key]; ok {
+			existing.AppendMatches(fm)
+		} else if len(c.files) < c.limit {
+			c.files[key] = fm
+		} else {
+			c.limitHit = true
+		}
+	}
+	c.mu.Unlock()
+
+	c.parent.Send(streaming.SearchEvent{Stats: event.Stats})
+}
+
+// diffFileMatches returns a result.FileDiffMatch for every file in base or
+// head whose matched chunks differ. Results are sorted by repository and path.
+// If limitHit is true, or either file match was truncated, the diff may be
+// inaccurate and the FileDiffMatch is marked with LimitHit.
+func diffFileMatches(baseRev string, base, head map[fileKey]*result.FileMatch, limitHit bool) result.Matches {
+	keys := make([]fileKey, 0, len(head))
+	for key := range head {
+		keys = append(keys, key)
+	}
+	for key := range base {
+		if _, ok := head[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].repo != keys[j].repo {
+			return keys[i].repo < keys[j].repo
+		}
+		return keys[i].path < keys[j].path
+	})
+
+	var matches result.Matches
+	for _, key := range keys {
+		baseMatch, headMatch := base[key], head[key]
+
+		var baseChunks, headChunks result.ChunkMatches
+		var file result.File
+		var baseCommitID api.CommitID
+		fileLimitHit := limitHit
+		if baseMatch != nil {
+			baseChunks = baseMatch.ChunkMatches
+			file = baseMatch.File
+			baseCommitID = baseMatch.CommitID
+			fileLimitHit = fileLimitHit || baseMatch.LimitHit
+		}
+		if headMatch != nil {
+			headChunks = headMatch.ChunkMatches
+			file = headMatch.File
+			fileLimitHit = fileLimitHit || headMatch.LimitHit
+		}
+
+		added := subtractChunks(headChunks, baseChunks)
+		removed := subtractChunks(baseChunks, headChunks)
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+
+		matches = append(matches, &result.FileDiffMatch{
+			File:         file,
+			BaseRev:      baseRev,
+			BaseCommitID: baseCommitID,
+			Added:        added,
+			Removed:      removed,
+			LimitHit:     fileLimitHit,
+		})
+	}
+	return matches
+}
+
+// subtractChunks returns the chunks in a that have no counterpart with the
+// same content in b. Chunks are compared by content only, so a match that
+// moved to different lines between revisions is not reported as changed.
+func subtractChunks(a, b result.ChunkMatches) result.ChunkMatches {
+	remaining := make(map[string]int, len(b))
+	for _, chunk := range b {
+		remaining[chunk.Content]++
+	}
+
+	var res result.ChunkMatches
+	for _, chunk := range a {
+		if remaining[chunk.Content] > 0 {
+			remaining[chunk.Content]--
+			continue
+		}
+		res = append(res, chunk)
+	}
+	return res
+}
@@ -0,0 +1,173 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSearchDiffJob(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "foo"}
+	fm := func(path string, chunks ...string) *result.FileMatch {
+		cms := make(result.ChunkMatches, 0, len(chunks))
+		for _, chunk := range chunks {
+			cms = append(cms, result.ChunkMatch{Content: chunk, Ranges: make(result.Ranges, 1)})
+		}
+		return &result.FileMatch{
+			File:         result.File{Repo: repo, Path: path},
+			ChunkMatches: cms,
+		}
+	}
+	at := func(commit api.CommitID, fm *result.FileMatch) *result.FileMatch {
+		fm.CommitID = commit
+		return fm
+	}
+
+	mockChild := func(matches ...result.Match) job.Job {
+		child := mockjob.NewMockJob()
+		child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: matches})
+			return nil, nil
+		})
+		return child
+	}
+
+	j := &searchDiffJob{
+		baseRev: "v1",
+		base: mockChild(
+			at("base", fm("a.go", "unchanged", "removed")),
+			fm("b.go", "deleted file"),
+			fm("c.go", "same"),
+		),
+		head: mockChild(
+			at("head", fm("a.go", "unchanged", "added")),
+			fm("c.go", "same"),
+			fm("d.go", "new file"),
+		),
+	}
+
+	agg := streaming.NewAggregatingStream()
+	alert, err := j.Run(context.Background(), job.RuntimeClients{}, agg)
+	require.Nil(t, alert)
+	require.NoError(t, err)
+
+	chunks := func(cms result.ChunkMatches) (res []string) {
+		for _, cm := range cms {
+			res = append(res, cm.Content)
+		}
+		return res
+	}
+
+	type diff struct {
+		path    string
+		added   []string
+		removed []string
+	}
+	var got []diff
+	for _, m := range agg.Results {
+		fd, ok := m.(*result.FileDiffMatch)
+		require.True(t, ok)
+		require.Equal(t, "v1", fd.BaseRev)
+		got = append(got, diff{path: fd.Path, added: chunks(fd.Added), removed: chunks(fd.Removed)})
+		if fd.Path == "a.go" {
+			// Removed chunks have the line numbers of the base revision.
+			require.Equal(t, api.CommitID("head"), fd.CommitID)
+			require.Equal(t, api.CommitID("base"), fd.BaseCommitID)
+		}
+	}
+
+	require.Equal(t, []diff{
+		{path: "a.go", added: []string{"added"}, removed: []string{"removed"}},
+		{path: "b.go", removed: []string{"deleted file"}},
+		{path: "d.go", added: []string{"new file"}},
+	}, got)
+}
+
+func TestSearchDiffJob_LimitHit(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "foo"}
+	child := func(event streaming.SearchEvent) job.Job {
+		child := mockjob.NewMockJob()
+		child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(event)
+			return nil, nil
+		})
+		return child
+	}
+
+	j := &searchDiffJob{
+		baseRev: "v1",
+		base: child(streaming.SearchEvent{
+			Stats: streaming.Stats{IsLimitHit: true},
+		}),
+		head: child(streaming.SearchEvent{
+			Results: result.Matches{&result.FileMatch{
+				File:         result.File{Repo: repo, Path: "a.go"},
+				ChunkMatches: result.ChunkMatches{{Content: "x", Ranges: make(result.Ranges, 1)}},
+			}},
+		}),
+	}
+
+	agg := streaming.NewAggregatingStream()
+	alert, err := j.Run(context.Background(), job.RuntimeClients{}, agg)
+	require.NoError(t, err)
+	require.Equal(t, search.AlertForSearchDiffLimitHit(), alert)
+	require.True(t, agg.Stats.IsLimitHit)
+	require.Len(t, agg.Results, 1)
+	require.True(t, agg.Results[0].(*result.FileDiffMatch).LimitHit)
+}
+
+func TestSearchDiffJob_MaxFiles(t *testing.T) {
+	old := maxSearchDiffFiles
+	maxSearchDiffFiles = 1
+	t.Cleanup(func() { maxSearchDiffFiles = old })
+
+	repo := types.MinimalRepo{ID: 1, Name: "foo"}
+	child := func(paths ...string) job.Job {
+		var matches result.Matches
+		for _, path := range paths {
+			matches = append(matches, &result.FileMatch{
+				File:         result.File{Repo: repo, Path: path},
+				ChunkMatches: result.ChunkMatches{{Content: path, Ranges: make(result.Ranges, 1)}},
+			})
+		}
+		child := mockjob.NewMockJob()
+		child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: matches})
+			return nil, nil
+		})
+		return child
+	}
+
+	j := &searchDiffJob{baseRev: "v1", base: child("a.go"), head: child("a.go", "b.go")}
+
+	agg := streaming.NewAggregatingStream()
+	alert, err := j.Run(context.Background(), job.RuntimeClients{}, agg)
+	require.NoError(t, err)
+	require.Equal(t, search.AlertForSearchDiffLimitHit(), alert)
+	require.True(t, agg.Stats.IsLimitHit)
+	// b.go is dropped, and a.go is unchanged.
+	require.Empty(t, agg.Results)
+}
+
+func TestSubtractChunks(t *testing.T) {
+	cms := func(contents ...string) (res result.ChunkMatches) {
+		for _, c := range contents {
+			res = append(res, result.ChunkMatch{Content: c})
+		}
+		return res
+	}
+
+	// Duplicated chunks are compared as a multiset.
+	require.Equal(t, cms("x"), subtractChunks(cms("x", "x", "y"), cms("x", "y")))
+	require.Nil(t, subtractChunks(cms("x", "y"), cms("y", "x")))
+	require.Equal(t, cms("x"), subtractChunks(cms("x"), nil))
+}
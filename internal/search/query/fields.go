@@ -33,6 +33,9 @@ const (
 	FieldTimeout   = "timeout"
 	FieldCombyRule = "rule"
 	FieldSelect    = "select"
+
+	// Compares the results of a search at two revisions.
+	FieldSearchDiff = "search.diff"
 )
 
 var allFields = map[string]struct{}{
@@ -71,6 +74,7 @@ var allFields = map[string]struct{}{
 	FieldRev:                empty,
 	"revision":              empty,
 	FieldSelect:             empty,
+	FieldSearchDiff:         empty,
 }
 
 var aliases = map[string]string{
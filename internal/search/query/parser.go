@@ -601,7 +601,9 @@ func ScanField(buf []byte) (string, bool, int) {
 	success := false
 	for len(buf) > 0 {
 		r = next()
-		if strings.ContainsRune(allowed, r) {
+		// Dots are allowed after the first character to support
+		// namespaced fields like search.diff:.
+		if strings.ContainsRune(allowed, r) || r == '.' {
 			result = append(result, r)
 			continue
 		}
@@ -845,7 +847,7 @@ func (p *parser) ParsePattern(label labels) Pattern {
 
 // ParseParameter returns a leaf node corresponding to the syntax
 // (-?)field:<string> where : matches the first encountered colon, and field
-// must match ^[a-zA-Z]+ and be allowed by allFields. Field may optionally
+// must match ^[a-zA-Z][a-zA-Z.]* and be allowed by allFields. Field may optionally
 // be preceded by '-' which means the parameter is negated.
 func (p *parser) ParseParameter() (Parameter, bool, error) {
 	start := p.pos
@@ -7,6 +7,7 @@ import (
 	"github.com/grafana/regexp"
 	"github.com/grafana/regexp/syntax"
 
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -40,15 +41,18 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.key":               func() Predicate { return &RepoHasKeyPredicate{} },
 		"has.meta":              func() Predicate { return &RepoHasMetaPredicate{} },
 		"has.topic":             func() Predicate { return &RepoHasTopicPredicate{} },
+		"has.symbol":            func() Predicate { return &RepoHasSymbolPredicate{} },
 
 		// Deprecated predicates
 		"contains": func() Predicate { return &RepoContainsPredicate{} },
 	},
 	FieldFile: {
-		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
-		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
-		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
-		"has.contributor":  func() Predicate { return &FileHasContributorPredicate{} },
+		"contains.content":     func() Predicate { return &FileContainsContentPredicate{} },
+		"has.content":          func() Predicate { return &FileContainsContentPredicate{} },
+		"has.owner":            func() Predicate { return &FileHasOwnerPredicate{} },
+		"has.contributor":      func() Predicate { return &FileHasContributorPredicate{} },
+		"has.codeowners.issue": func() Predicate { return &FileHasCodeownersIssuePredicate{} },
+		"has.symbol":           func() Predicate { return &FileHasSymbolPredicate{} },
 	},
 }
 
@@ -614,3 +618,144 @@ func (f *FileHasContributorPredicate) Unmarshal(params string, negated bool) err
 
 func (f FileHasContributorPredicate) Field() string { return FieldFile }
 func (f FileHasContributorPredicate) Name() string  { return "has.contributor" }
+
+/* file:has.codeowners.issue(kind) */
+
+const (
+	// CodeownersIssueUnowned matches files that no CODEOWNERS rule assigns
+	// an owner to.
+	CodeownersIssueUnowned = "unowned"
+	// CodeownersIssueUnresolvedOwner matches files whose CODEOWNERS rule
+	// lists an owner that does not resolve to any user, team or code host
+	// account.
+	CodeownersIssueUnresolvedOwner = "unresolved-owner"
+)
+
+// FileHasCodeownersIssuePredicate represents the `file:has.codeowners.issue()`
+// predicate, which filters to files of repositories with a CODEOWNERS file,
+// whose ownership as determined by that file is problematic. An empty Kind
+// matches any issue.
+type FileHasCodeownersIssuePredicate struct {
+	Kind string
+}
+
+func (f *FileHasCodeownersIssuePredicate) Unmarshal(params string, negated bool) error {
+	if negated {
+		return &NegatedPredicateError{f.Field() + ":" + f.Name()}
+	}
+	switch kind := strings.ToLower(strings.TrimSpace(params)); kind {
+	case "", CodeownersIssueUnowned, CodeownersIssueUnresolvedOwner:
+		f.Kind = kind
+		return nil
+	default:
+		return errors.Errorf("the file:has.codeowners.issue() predicate expects one of %q or %q, got %q", CodeownersIssueUnowned, CodeownersIssueUnresolvedOwner, params)
+	}
+}
+
+func (f FileHasCodeownersIssuePredicate) Field() string { return FieldFile }
+func (f FileHasCodeownersIssuePredicate) Name() string  { return "has.codeowners.issue" }
+
+/* repo:has.symbol(kind:... name:...) */
+
+// RepoHasSymbolPredicate represents the `repo:has.symbol()` predicate, which
+// filters to repos that define a symbol with a matching name and/or kind.
+type RepoHasSymbolPredicate struct {
+	Symbol  string // pattern matched against symbol names
+	Kind    string
+	Negated bool
+}
+
+func (f *RepoHasSymbolPredicate) Unmarshal(params string, negated bool) (err error) {
+	f.Symbol, f.Kind, err = parseSymbolPredicateParams(f.Field()+":"+f.Name(), params)
+	f.Negated = negated
+	return err
+}
+
+func (f *RepoHasSymbolPredicate) Field() string { return FieldRepo }
+func (f *RepoHasSymbolPredicate) Name() string  { return "has.symbol" }
+
+/* file:has.symbol(kind:... name:...) */
+
+// FileHasSymbolPredicate represents the `file:has.symbol()` predicate, which
+// filters to files that define a symbol with a matching name and/or kind.
+type FileHasSymbolPredicate struct {
+	Symbol  string // pattern matched against symbol names
+	Kind    string
+	Negated bool
+}
+
+func (f *FileHasSymbolPredicate) Unmarshal(params string, negated bool) (err error) {
+	f.Symbol, f.Kind, err = parseSymbolPredicateParams(f.Field()+":"+f.Name(), params)
+	f.Negated = negated
+	return err
+}
+
+func (f *FileHasSymbolPredicate) Field() string { return FieldFile }
+func (f *FileHasSymbolPredicate) Name() string  { return "has.symbol" }
+
+// parseSymbolPredicateParams parses the arguments of a has.symbol() predicate.
+// The arguments are a `name:` regular expression matched against symbol names
+// and a `kind:` that is any symbol kind supported by `select:symbol.<kind>`.
+// A pattern without a prefix is treated as the name.
+func parseSymbolPredicateParams(predicate, params string) (name, kind string, err error) {
+	nodes, err := Parse(params, SearchTypeRegex)
+	if err != nil {
+		return "", "", err
+	}
+
+	var parseNode func(Node) error
+	parseNode = func(n Node) error {
+		switch v := n.(type) {
+		case Pattern:
+			if v.Negated {
+				return errors.New("predicates do not currently support negated values")
+			}
+			key, value, ok := strings.Cut(v.Value, ":")
+			if ok && strings.EqualFold(key, "kind") {
+				if kind != "" {
+					return errors.New("cannot specify kind multiple times")
+				}
+				kind = strings.ToLower(value)
+				if _, err := filter.SelectPathFromString(filter.Symbol + "." + kind); kind == "" || err != nil {
+					return errors.Errorf("the %s() predicate has invalid `kind` argument %q", predicate, value)
+				}
+				return nil
+			}
+			if !ok || !strings.EqualFold(key, "name") {
+				value = v.Value
+			}
+			if name != "" {
+				return errors.New("cannot specify name multiple times")
+			}
+			if _, err := syntax.Parse(value, syntax.Perl); err != nil {
+				return errors.Errorf("the %s() predicate has invalid `name` argument: %w", predicate, err)
+			}
+			name = value
+		case Parameter:
+			return errors.Errorf("unsupported option %q", v.Field)
+		case Operator:
+			if v.Kind == Or {
+				return errors.New("predicates do not currently support 'or' queries")
+			}
+			for _, operand := range v.Operands {
+				if err := parseNode(operand); err != nil {
+					return err
+				}
+			}
+		default:
+			return errors.Errorf("unsupported node type %T", n)
+		}
+		return nil
+	}
+
+	for _, node := range nodes {
+		if err := parseNode(node); err != nil {
+			return "", "", err
+		}
+	}
+
+	if name == "" && kind == "" {
+		return "", "", errors.Errorf("the %s() predicate requires one of name or kind", predicate)
+	}
+	return name, kind, nil
+}
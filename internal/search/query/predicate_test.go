@@ -331,6 +331,32 @@ func TestFileHasOwnerPredicate(t *testing.T) {
 	})
 }
 
+func TestFileHasCodeownersIssuePredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		valid := map[string]string{
+			``:                  "",
+			`unowned`:           CodeownersIssueUnowned,
+			`Unresolved-Owner `: CodeownersIssueUnresolvedOwner,
+		}
+		for params, kind := range valid {
+			p := &FileHasCodeownersIssuePredicate{}
+			if err := p.Unmarshal(params, false); err != nil {
+				t.Fatalf("unexpected error for %q: %s", params, err)
+			}
+			if p.Kind != kind {
+				t.Fatalf("expected kind %q for %q, got %q", kind, params, p.Kind)
+			}
+		}
+
+		if err := (&FileHasCodeownersIssuePredicate{}).Unmarshal("dead", false); err == nil {
+			t.Fatal("expected error for unknown kind")
+		}
+		if err := (&FileHasCodeownersIssuePredicate{}).Unmarshal("", true); err == nil {
+			t.Fatal("expected error for negation")
+		}
+	})
+}
+
 func TestFileHasContributorPredicate(t *testing.T) {
 	t.Run("Unmarshal", func(t *testing.T) {
 		type test struct {
@@ -366,3 +392,63 @@ func TestFileHasContributorPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestHasSymbolPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *FileHasSymbolPredicate
+		}
+
+		valid := []test{
+			{`name`, `name:^NewClient$`, &FileHasSymbolPredicate{Symbol: "^NewClient$"}},
+			{`unnamed name`, `NewClient`, &FileHasSymbolPredicate{Symbol: "NewClient"}},
+			{`kind`, `kind:struct`, &FileHasSymbolPredicate{Kind: "struct"}},
+			{`kind is case insensitive`, `kind:Function`, &FileHasSymbolPredicate{Kind: "function"}},
+			{`kind and name`, `kind:function name:^New`, &FileHasSymbolPredicate{Symbol: "^New", Kind: "function"}},
+			{`name and kind`, `name:^New kind:enum-member`, &FileHasSymbolPredicate{Symbol: "^New", Kind: "enum-member"}},
+			{`name with colon`, `name:a:b`, &FileHasSymbolPredicate{Symbol: "a:b"}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasSymbolPredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, nil},
+			{`unknown kind`, `kind:widget`, nil},
+			{`empty kind`, `kind:`, nil},
+			{`invalid name regexp`, `name:([)`, nil},
+			{`name twice`, `name:a name:b`, nil},
+			{`kind twice`, `kind:struct kind:function`, nil},
+			{`or`, `name:a or name:b`, nil},
+			{`unsupported option`, `lang:go`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasSymbolPredicate{}
+				if err := p.Unmarshal(tc.params, false); err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+
+	t.Run("repo predicate", func(t *testing.T) {
+		p := &RepoHasSymbolPredicate{}
+		require.NoError(t, p.Unmarshal(`kind:function name:^NewClient$`, true))
+		require.Equal(t, &RepoHasSymbolPredicate{Symbol: "^NewClient$", Kind: "function", Negated: true}, p)
+	})
+}
@@ -536,6 +536,30 @@ func ConcatRevFilters(b Basic) Basic {
 	return Basic{Parameters: toParameters(modified), Pattern: b.Pattern}
 }
 
+// SearchDiffQueries splits a query containing a search.diff: parameter into
+// the two queries whose results are compared. The head query is the original
+// query without search.diff:. The base query searches the same pattern at the
+// revision specified by search.diff: in every repo: filter.
+// Invariant: Guaranteed to succeed on a valid Basic query with rev: filters
+// already concatenated.
+func SearchDiffQueries(b Basic) (baseRev string, base, head Basic) {
+	nodes := MapField(toNodes(b.Parameters), FieldSearchDiff, func(value string, _ bool, _ Annotation) Node {
+		baseRev = value
+		return nil // remove this node
+	})
+	head = Basic{Parameters: toParameters(nodes), Pattern: b.Pattern}
+
+	nodes = MapField(nodes, FieldRepo, func(value string, negated bool, ann Annotation) Node {
+		if !negated && !ann.Labels.IsSet(IsPredicate) {
+			repo, _, _ := strings.Cut(value, "@")
+			value = repo + "@" + baseRev
+		}
+		return Parameter{Value: value, Field: FieldRepo, Negated: negated, Annotation: ann}
+	})
+	base = Basic{Parameters: toParameters(nodes), Pattern: b.Pattern}
+	return baseRev, base, head
+}
+
 // labelStructural converts Literal labels to Structural labels. Structural
 // queries are parsed the same as literal queries, we just convert the labels as
 // a postprocessing step to keep the parser lean.
@@ -411,6 +411,52 @@ func TestConcatRevFilters(t *testing.T) {
 	}
 }
 
+func TestSearchDiffQueries(t *testing.T) {
+	cases := []struct {
+		input    string
+		wantRev  string
+		wantBase string
+		wantHead string
+	}{
+		{
+			input:    "repo:foo search.diff:main bar",
+			wantRev:  "main",
+			wantBase: `"repo:foo@main" "bar"`,
+			wantHead: `"repo:foo" "bar"`,
+		},
+		{
+			input:    "repo:foo rev:v2 search.diff:v1 bar",
+			wantRev:  "v1",
+			wantBase: `"repo:foo@v1" "bar"`,
+			wantHead: `"repo:foo@v2" "bar"`,
+		},
+		{
+			input:    "repo:foo@v2 -repo:baz repo:has.file(go.mod) search.diff:v1 bar",
+			wantRev:  "v1",
+			wantBase: `"repo:foo@v1" "-repo:baz" "repo:has.file(go.mod)" "bar"`,
+			wantHead: `"repo:foo@v2" "-repo:baz" "repo:has.file(go.mod)" "bar"`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			plan, err := Pipeline(InitRegexp(c.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotRev, base, head := SearchDiffQueries(plan[0])
+			if gotRev != c.wantRev {
+				t.Errorf("got base revision %q, want %q", gotRev, c.wantRev)
+			}
+			if diff := cmp.Diff(c.wantBase, toString(base.ToParseTree())); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(c.wantHead, toString(head.ToParseTree())); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func TestConcatRevFiltersTopLevelAnd(t *testing.T) {
 	cases := []struct {
 		input string
@@ -505,6 +505,46 @@ func (p Parameters) FileHasContributor() (include []string, exclude []string) {
 	return include, exclude
 }
 
+// FileHasCodeownersIssue returns the kinds of the file:has.codeowners.issue()
+// predicates of the query.
+func (p Parameters) FileHasCodeownersIssue() (kinds []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasCodeownersIssuePredicate) {
+		kinds = append(kinds, pred.Kind)
+	})
+	return kinds
+}
+
+// HasSymbolArgs represents the args of the repo:has.symbol() and
+// file:has.symbol() predicates.
+type HasSymbolArgs struct {
+	// At least one of these strings is non-empty
+	Name    string // optional
+	Kind    string // optional
+	Negated bool
+}
+
+func (p Parameters) RepoHasSymbol() (res []HasSymbolArgs) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasSymbolPredicate) {
+		res = append(res, HasSymbolArgs{
+			Name:    pred.Symbol,
+			Kind:    pred.Kind,
+			Negated: pred.Negated,
+		})
+	})
+	return res
+}
+
+func (p Parameters) FileHasSymbol() (res []HasSymbolArgs) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasSymbolPredicate) {
+		res = append(res, HasSymbolArgs{
+			Name:    pred.Symbol,
+			Kind:    pred.Kind,
+			Negated: pred.Negated,
+		})
+	})
+	return res
+}
+
 // Exists returns whether a parameter exists in the query (whether negated or not).
 func (p Parameters) Exists(field string) bool {
 	found := false
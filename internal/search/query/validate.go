@@ -298,6 +298,9 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
+	case
+		FieldSearchDiff:
+		return satisfies(isSingular, isNotNegated)
 	default:
 		return isUnrecognizedField()
 	}
@@ -358,6 +361,44 @@ func validateCommitParameters(nodes []Node) error {
 	return nil
 }
 
+// A query with a search.diff: parameter is invalid if:
+// (1) no repo is specified, OR
+// (2) it searches commits or diffs, which already compare revisions.
+func validateSearchDiff(nodes []Node) error {
+	var seenSearchDiff, seenRepo, seenRepoPredicate, seenCommitType bool
+	VisitParameter(nodes, func(field, value string, negated bool, ann Annotation) {
+		switch field {
+		case FieldSearchDiff:
+			seenSearchDiff = true
+		case FieldRepo:
+			// Predicates are not rewritten to the base revision, so they
+			// don't select a revision to compare against.
+			if ann.Labels.IsSet(IsPredicate) {
+				seenRepoPredicate = true
+			} else if !negated && value != "" {
+				seenRepo = true
+			}
+		case FieldType:
+			if value == "commit" || value == "diff" {
+				seenCommitType = true
+			}
+		}
+	})
+	if !seenSearchDiff {
+		return nil
+	}
+	if !seenRepo && seenRepoPredicate {
+		return errors.New("`search.diff:` requires a `repo:` filter that matches repository names. Predicates such as `repo:has.file()` cannot select the revision to compare. Add a `repo:` filter and try again")
+	}
+	if !seenRepo {
+		return errors.New("invalid syntax. The query contains `search.diff:` without `repo:`. Add a `repo:` filter and try again")
+	}
+	if seenCommitType {
+		return errors.New("`search.diff:` compares file content at two revisions and cannot be combined with type:commit or type:diff")
+	}
+	return nil
+}
+
 func validateTypeStructural(nodes []Node) error {
 	seenStructural := false
 	seenType := false
@@ -500,6 +541,7 @@ func validate(nodes []Node) error {
 		validateCommitParameters,
 		validateTypeStructural,
 		validateRefGlobs,
+		validateSearchDiff,
 	)
 }
 
@@ -91,6 +91,22 @@ func TestValidation(t *testing.T) {
 			input: `repo:'' rev:bedge`,
 			want:  "invalid syntax. The query contains `rev:` without `repo:`. Add a `repo:` filter and try again",
 		},
+		{
+			input: "search.diff:main foo",
+			want:  "invalid syntax. The query contains `search.diff:` without `repo:`. Add a `repo:` filter and try again",
+		},
+		{
+			input: "repo:has.file(go.mod) search.diff:main foo",
+			want:  "`search.diff:` requires a `repo:` filter that matches repository names. Predicates such as `repo:has.file()` cannot select the revision to compare. Add a `repo:` filter and try again",
+		},
+		{
+			input: "repo:foo search.diff:main search.diff:release bar",
+			want:  `field "search.diff" may not be used more than once`,
+		},
+		{
+			input: "repo:foo search.diff:main type:diff bar",
+			want:  "`search.diff:` compares file content at two revisions and cannot be combined with type:commit or type:diff",
+		},
 		{
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
@@ -0,0 +1,105 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// FileDiffMatch describes how the content matches of a file changed between
+// two revisions. It is the result of a search.diff: query. File identifies the
+// file at the head revision, or at the base revision if the file only has
+// removed matches.
+type FileDiffMatch struct {
+	File
+
+	// BaseRev is the revision the matches were compared against.
+	BaseRev string
+
+	// BaseCommitID is the commit BaseRev resolved to. The line numbers of
+	// Removed refer to the file at this commit, while those of Added refer to
+	// the file at File.CommitID.
+	BaseCommitID api.CommitID
+
+	// Added are the chunks that match at the head revision but not at the
+	// base revision.
+	Added ChunkMatches
+
+	// Removed are the chunks that match at the base revision but not at the
+	// head revision.
+	Removed ChunkMatches
+
+	LimitHit bool
+}
+
+func (fd *FileDiffMatch) RepoName() types.MinimalRepo {
+	return fd.File.Repo
+}
+
+func (fd *FileDiffMatch) searchResultMarker() {}
+
+func (fd *FileDiffMatch) ResultCount() int {
+	rc := fd.Added.MatchCount() + fd.Removed.MatchCount()
+	if rc == 0 {
+		return 1
+	}
+	return rc
+}
+
+// Limit truncates added chunks before removed chunks so that the match has at
+// most limit results.
+func (fd *FileDiffMatch) Limit(limit int) int {
+	addedCount, removedCount := fd.Added.MatchCount(), fd.Removed.MatchCount()
+
+	// An empty FileDiffMatch should still count against the limit -- see *FileDiffMatch.ResultCount()
+	if addedCount == 0 && removedCount == 0 {
+		return limit - 1
+	}
+
+	if limit < addedCount+removedCount {
+		fd.LimitHit = true
+	}
+
+	if limit <= addedCount {
+		fd.Added.Limit(limit)
+		fd.Removed = nil
+		return limit - fd.Added.MatchCount()
+	}
+	limit -= addedCount
+
+	if limit < removedCount {
+		fd.Removed.Limit(limit)
+		return 0
+	}
+	return limit - removedCount
+}
+
+func (fd *FileDiffMatch) Select(selectPath filter.SelectPath) Match {
+	switch selectPath.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: fd.Repo.Name,
+			ID:   fd.Repo.ID,
+		}
+	case filter.File:
+		return &FileMatch{File: fd.File}
+	case filter.Content:
+		return fd
+	}
+	return nil
+}
+
+func (fd *FileDiffMatch) Key() Key {
+	k := Key{
+		TypeRank: rankFileDiff,
+		Repo:     fd.Repo.Name,
+		Commit:   fd.CommitID,
+		Path:     fd.Path,
+	}
+
+	if fd.InputRev != nil {
+		k.Rev = *fd.InputRev
+	}
+
+	return k
+}
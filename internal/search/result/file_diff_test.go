@@ -0,0 +1,60 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileDiffMatch_Limit(t *testing.T) {
+	newMatch := func() *FileDiffMatch {
+		return &FileDiffMatch{
+			Added: ChunkMatches{
+				{Content: "a", Ranges: make(Ranges, 2)},
+				{Content: "b", Ranges: make(Ranges, 1)},
+			},
+			Removed: ChunkMatches{
+				{Content: "c", Ranges: make(Ranges, 2)},
+			},
+		}
+	}
+
+	cases := []struct {
+		limit       int
+		wantRemain  int
+		wantAdded   int
+		wantRemoved int
+		wantHit     bool
+	}{
+		{limit: 10, wantRemain: 5, wantAdded: 3, wantRemoved: 2},
+		{limit: 5, wantRemain: 0, wantAdded: 3, wantRemoved: 2},
+		{limit: 4, wantRemain: 0, wantAdded: 3, wantRemoved: 1, wantHit: true},
+		{limit: 3, wantRemain: 0, wantAdded: 3, wantRemoved: 0, wantHit: true},
+		{limit: 1, wantRemain: 0, wantAdded: 1, wantRemoved: 0, wantHit: true},
+	}
+
+	for _, tc := range cases {
+		fd := newMatch()
+		remain := fd.Limit(tc.limit)
+		require.Equal(t, tc.wantRemain, remain)
+		require.Equal(t, tc.wantAdded, fd.Added.MatchCount())
+		require.Equal(t, tc.wantRemoved, fd.Removed.MatchCount())
+		require.Equal(t, tc.wantHit, fd.LimitHit)
+		require.Equal(t, tc.wantAdded+tc.wantRemoved, fd.ResultCount())
+	}
+
+	t.Run("empty match counts as one", func(t *testing.T) {
+		fd := &FileDiffMatch{}
+		require.Equal(t, 1, fd.ResultCount())
+		require.Equal(t, 4, fd.Limit(5))
+	})
+}
+
+func TestFileDiffMatch_Key(t *testing.T) {
+	file := File{Repo: types.MinimalRepo{Name: "foo"}, Path: "a.go"}
+	fd := &FileDiffMatch{File: file}
+	fm := &FileMatch{File: file}
+	require.NotEqual(t, fm.Key(), fd.Key())
+}
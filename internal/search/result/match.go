@@ -35,6 +35,7 @@ var (
 	_ Match = (*CommitMatch)(nil)
 	_ Match = (*CommitDiffMatch)(nil)
 	_ Match = (*OwnerMatch)(nil)
+	_ Match = (*FileDiffMatch)(nil)
 )
 
 // Match ranks are used for sorting the different match types.
@@ -46,6 +47,7 @@ const (
 	rankDiffMatch   = 2
 	rankRepoMatch   = 3
 	rankOwnerMatch  = 4
+	rankFileDiff    = 5
 )
 
 // Key is a sorting or deduplicating key for a Match. It contains all the
@@ -48,6 +48,13 @@ func TestFrontendClient(t *testing.T) {
 				Type:   CommitMatchType,
 				Detail: "test",
 			},
+			&EventContentDiffMatch{
+				Type:         ContentDiffMatchType,
+				Path:         "test",
+				BaseRevision: "main",
+				Added:        []ChunkMatch{{Content: "added"}},
+				Removed:      []ChunkMatch{{Content: "removed"}},
+			},
 		},
 	}, {
 		Name: "filters",
@@ -56,6 +56,27 @@ type EventPathMatch struct {
 
 func (e *EventPathMatch) eventMatch() {}
 
+// EventContentDiffMatch describes how the content matches of a file changed
+// between two revisions. It is streamed for search.diff: queries.
+type EventContentDiffMatch struct {
+	// Type is always ContentDiffMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	Path            string       `json:"path"`
+	RepositoryID    int32        `json:"repositoryID"`
+	Repository      string       `json:"repository"`
+	RepoStars       int          `json:"repoStars,omitempty"`
+	RepoLastFetched *time.Time   `json:"repoLastFetched,omitempty"`
+	Branches        []string     `json:"branches,omitempty"`
+	Commit          string       `json:"commit,omitempty"`
+	BaseRevision    string       `json:"baseRevision"`
+	BaseCommit      string       `json:"baseCommit,omitempty"`
+	Added           []ChunkMatch `json:"added"`
+	Removed         []ChunkMatch `json:"removed"`
+}
+
+func (e *EventContentDiffMatch) eventMatch() {}
+
 type DecoratedHunk struct {
 	Content   DecoratedContent `json:"content"`
 	LineStart int              `json:"lineStart"`
@@ -247,6 +268,7 @@ const (
 	PathMatchType
 	PersonMatchType
 	TeamMatchType
+	ContentDiffMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -265,6 +287,8 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"person"`), nil
 	case TeamMatchType:
 		return []byte(`"team"`), nil
+	case ContentDiffMatchType:
+		return []byte(`"contentDiff"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -285,6 +309,8 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = PersonMatchType
 	} else if bytes.Equal(b, []byte(`"team"`)) {
 		*t = TeamMatchType
+	} else if bytes.Equal(b, []byte(`"contentDiff"`)) {
+		*t = ContentDiffMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
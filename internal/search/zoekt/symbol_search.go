@@ -2,15 +2,23 @@ package zoekt
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	"github.com/RoaringBitmap/roaring"
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/zoekt"
 	zoektquery "github.com/sourcegraph/zoekt/query"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/trace/policy"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 type SymbolSearchJob struct {
@@ -135,3 +143,96 @@ func (s *GlobalSymbolSearchJob) Attributes(v job.Verbosity) (res []attribute.Key
 
 func (s *GlobalSymbolSearchJob) Children() []job.Describer       { return nil }
 func (s *GlobalSymbolSearchJob) MapChildren(job.MapFunc) job.Job { return s }
+
+// SymbolsAtCommitOptions restricts the symbols returned by SearchSymbolsAtCommit.
+type SymbolsAtCommitOptions struct {
+	// Name is a regular expression matched against symbol names. An empty
+	// Name matches all symbols.
+	Name string
+
+	// Paths, if non-empty, restricts the search to the files with these
+	// paths.
+	Paths []string
+
+	IsCaseSensitive bool
+
+	// Limit is the maximum number of symbols to return.
+	Limit int
+}
+
+// SearchSymbolsAtCommit searches the symbols Zoekt has indexed for repo at
+// commit. The returned bool is false if Zoekt has not indexed symbols for that
+// commit, in which case callers should fall back to the symbols service.
+func SearchSymbolsAtCommit(ctx context.Context, client zoekt.Streamer, repo types.MinimalRepo, commit api.CommitID, opts SymbolsAtCommitOptions) (_ []*result.SymbolMatch, indexed bool, err error) {
+	repos := roaring.BitmapOf(uint32(repo.ID))
+	list, err := client.List(ctx, &zoektquery.RepoIDs{Repos: repos}, &zoekt.ListOptions{Field: zoekt.RepoListFieldReposMap})
+	if err != nil {
+		return nil, false, err
+	}
+
+	entry, ok := list.ReposMap[uint32(repo.ID)]
+	if !ok || !entry.HasSymbols {
+		return nil, false, nil
+	}
+	var branch string
+	for _, b := range entry.Branches {
+		if b.Version == string(commit) {
+			branch = b.Name
+			break
+		}
+	}
+	if branch == "" {
+		return nil, false, nil
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = ".*"
+	}
+	nameQuery, err := parseRe(name, false, true, opts.IsCaseSensitive)
+	if err != nil {
+		return nil, true, err
+	}
+
+	ands := []zoektquery.Q{
+		&zoektquery.BranchesRepos{List: []zoektquery.BranchRepos{{Branch: branch, Repos: repos}}},
+		&zoektquery.Symbol{Expr: nameQuery},
+	}
+	if len(opts.Paths) > 0 {
+		pathQuery, err := FileRe(ExactPathsPattern(opts.Paths), true)
+		if err != nil {
+			return nil, true, err
+		}
+		ands = append(ands, pathQuery)
+	}
+
+	resp, err := client.Search(ctx, zoektquery.Simplify(zoektquery.NewAnd(ands...)), &zoekt.SearchOptions{
+		Trace:              policy.ShouldTrace(ctx),
+		ShardMaxMatchCount: opts.Limit,
+		TotalMaxMatchCount: opts.Limit,
+		MaxDocDisplayCount: opts.Limit,
+		ChunkMatches:       true,
+	})
+	if err != nil {
+		return nil, true, err
+	}
+
+	var symbols []*result.SymbolMatch
+	for i := range resp.Files {
+		symbols = append(symbols, zoektFileMatchToSymbolResults(repo, string(commit), &resp.Files[i])...)
+	}
+	return symbols, true, nil
+}
+
+// ExactPathsPattern returns a regular expression that matches exactly the
+// given paths.
+func ExactPathsPattern(paths []string) string {
+	quoted := make([]string, 0, len(paths))
+	for _, path := range paths {
+		quoted = append(quoted, regexp.QuoteMeta(path))
+	}
+	if len(quoted) == 1 {
+		return "^" + quoted[0] + "$"
+	}
+	return "^(?:" + strings.Join(quoted, "|") + ")$"
+}
@@ -607,6 +607,11 @@ type GitserverRepo struct {
 	// A log of the different types of corruption that was detected on this repo. The order of the log entries are
 	// stored from most recent to least recent and capped at 10 entries. See LogCorruption on Gitserverrepo store.
 	CorruptionLogs []RepoCorruptionLog
+	// The last time the integrity of the repo on disk was verified by gitserver.
+	IntegrityCheckedAt time.Time
+	// Whether the repo passed its last integrity check. Only meaningful if
+	// IntegrityCheckedAt is set.
+	IntegrityCheckPassed bool
 
 	// PoolRepoID is the repo_id of the parent repo of which this repo is a fork. This is referenced
 	// for deduplicated storage of the repo itself on disk.
@@ -1,6 +1,10 @@
 package outbound
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
+)
 
 type EventType struct {
 	Key         string
@@ -41,3 +45,12 @@ func RegisterEventType(eventType EventType) {
 
 	registeredEventTypes.types = append(registeredEventTypes.types, eventType)
 }
+
+func init() {
+	for _, t := range events.Types {
+		RegisterEventType(EventType{
+			Key:         t.Key,
+			Description: t.Description,
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// Package events defines the outbound webhook event types that are not owned
+// by a single product area, along with their payloads.
+//
+// Every payload carries a version. Fields may be added to a payload without
+// changing its version, but removing a field or changing its meaning requires
+// the version to be bumped so that receivers can tell the shapes apart.
+//
+// This package must not depend on internal/database, since the database
+// package itself enqueues some of these events.
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const (
+	RepoAdded       = "repo:added"
+	RepoCloned      = "repo:cloned"
+	RepoCloneFailed = "repo:clone_failed"
+	RepoDeleted     = "repo:deleted"
+
+	UserCreated          = "user:created"
+	UserDeleted          = "user:deleted"
+	UserSiteAdminGranted = "user:site_admin_granted"
+
+	PermissionsSyncComplete = "permissions_sync:complete"
+
+	PreciseIndexProcessed = "precise_index:processed"
+	PreciseIndexFailed    = "precise_index:failed"
+
+	InsightSeriesBackfillComplete = "insight_series:backfill_complete"
+)
+
+// EventType describes an event type defined in this package.
+type EventType struct {
+	Key         string
+	Description string
+}
+
+// Types are the event types defined in this package, in the order they are
+// shown in the webhook admin UI.
+var Types = []EventType{
+	{Key: RepoAdded, Description: "sent when a repository is added to Sourcegraph from a code host connection"},
+	{Key: RepoCloned, Description: "sent when a repository is cloned or re-cloned by gitserver"},
+	{Key: RepoCloneFailed, Description: "sent when an attempt to clone a repository fails"},
+	{Key: RepoDeleted, Description: "sent when a repository is removed because it is no longer synced by any code host connection"},
+	{Key: UserCreated, Description: "sent when a user account is created"},
+	{Key: UserDeleted, Description: "sent when a user account is deleted"},
+	{Key: UserSiteAdminGranted, Description: "sent when a user is promoted to site admin"},
+	{Key: PermissionsSyncComplete, Description: "sent when a permissions sync job for a user or repository finishes"},
+	{Key: PreciseIndexProcessed, Description: "sent when a precise code intelligence index upload is processed"},
+	{Key: PreciseIndexFailed, Description: "sent when processing a precise code intelligence index upload fails"},
+	{Key: InsightSeriesBackfillComplete, Description: "sent when the historical backfill of a code insight series completes"},
+}
+
+const (
+	repositoryVersion      = 1
+	userVersion            = 1
+	permissionsSyncVersion = 1
+	preciseIndexVersion    = 1
+	insightSeriesVersion   = 1
+)
+
+// Repository is the payload of the repo:* events.
+type Repository struct {
+	Version            int                 `json:"version"`
+	ID                 graphql.ID          `json:"id"`
+	Name               string              `json:"name"`
+	URL                string              `json:"url"`
+	Private            bool                `json:"private"`
+	ExternalRepository *ExternalRepository `json:"external_repository"`
+	// Error is the clone error. It is only set for repo:clone_failed.
+	Error *string `json:"error"`
+}
+
+// ExternalRepository identifies a repository on its code host.
+type ExternalRepository struct {
+	ServiceType string `json:"service_type"`
+	ServiceID   string `json:"service_id"`
+	ID          string `json:"id"`
+}
+
+// NewRepository returns the payload for repo. cloneErr is included for
+// repo:clone_failed events and should be nil otherwise.
+func NewRepository(repo *types.Repo, cloneErr error) *Repository {
+	p := &Repository{
+		Version: repositoryVersion,
+		ID:      relay.MarshalID("Repository", repo.ID),
+		Name:    string(repo.Name),
+		URL:     "/" + string(repo.Name),
+		Private: repo.Private,
+	}
+	if repo.ExternalRepo != (api.ExternalRepoSpec{}) {
+		p.ExternalRepository = &ExternalRepository{
+			ServiceType: repo.ExternalRepo.ServiceType,
+			ServiceID:   repo.ExternalRepo.ServiceID,
+			ID:          repo.ExternalRepo.ID,
+		}
+	}
+	if cloneErr != nil {
+		msg := cloneErr.Error()
+		p.Error = &msg
+	}
+	return p
+}
+
+// User is the payload of the user:* events.
+type User struct {
+	Version     int        `json:"version"`
+	ID          graphql.ID `json:"id"`
+	Username    string     `json:"username"`
+	DisplayName string     `json:"display_name"`
+	SiteAdmin   bool       `json:"site_admin"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// NewUser returns the payload for user.
+func NewUser(user *types.User) *User {
+	return &User{
+		Version:     userVersion,
+		ID:          relay.MarshalID("User", user.ID),
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		SiteAdmin:   user.SiteAdmin,
+		CreatedAt:   user.CreatedAt,
+	}
+}
+
+// PermissionsSync is the payload of the permissions_sync:complete event.
+// Exactly one of UserID and RepositoryID is set.
+type PermissionsSync struct {
+	Version            int         `json:"version"`
+	ID                 graphql.ID  `json:"id"`
+	UserID             *graphql.ID `json:"user_id"`
+	RepositoryID       *graphql.ID `json:"repository_id"`
+	Reason             string      `json:"reason"`
+	Success            bool        `json:"success"`
+	PartialSuccess     bool        `json:"partial_success"`
+	PermissionsAdded   int         `json:"permissions_added"`
+	PermissionsRemoved int         `json:"permissions_removed"`
+	PermissionsFound   int         `json:"permissions_found"`
+	Error              *string     `json:"error"`
+}
+
+// PermissionsSyncResult is the outcome of a permissions sync job.
+type PermissionsSyncResult struct {
+	JobID          int
+	UserID         int32
+	RepositoryID   api.RepoID
+	Reason         string
+	PartialSuccess bool
+	Added          int
+	Removed        int
+	Found          int
+	Err            error
+}
+
+// NewPermissionsSync returns the payload for a finished permissions sync job.
+func NewPermissionsSync(r PermissionsSyncResult) *PermissionsSync {
+	p := &PermissionsSync{
+		Version:            permissionsSyncVersion,
+		ID:                 relay.MarshalID("PermissionsSyncJob", r.JobID),
+		Reason:             r.Reason,
+		Success:            r.Err == nil,
+		PartialSuccess:     r.PartialSuccess,
+		PermissionsAdded:   r.Added,
+		PermissionsRemoved: r.Removed,
+		PermissionsFound:   r.Found,
+	}
+	if r.UserID != 0 {
+		id := relay.MarshalID("User", r.UserID)
+		p.UserID = &id
+	}
+	if r.RepositoryID != 0 {
+		id := relay.MarshalID("Repository", r.RepositoryID)
+		p.RepositoryID = &id
+	}
+	if r.Err != nil {
+		msg := r.Err.Error()
+		p.Error = &msg
+	}
+	return p
+}
+
+// PreciseIndex is the payload of the precise_index:* events.
+type PreciseIndex struct {
+	Version        int        `json:"version"`
+	ID             graphql.ID `json:"id"`
+	RepositoryID   graphql.ID `json:"repository_id"`
+	RepositoryName string     `json:"repository_name"`
+	Commit         string     `json:"commit"`
+	Root           string     `json:"root"`
+	Indexer        string     `json:"indexer"`
+	IndexerVersion string     `json:"indexer_version"`
+	UploadedAt     time.Time  `json:"uploaded_at"`
+	// Error is the processing error. It is only set for precise_index:failed.
+	Error *string `json:"error"`
+}
+
+// PreciseIndexUpload describes a precise index upload.
+type PreciseIndexUpload struct {
+	ID             int
+	RepositoryID   int
+	RepositoryName string
+	Commit         string
+	Root           string
+	Indexer        string
+	IndexerVersion string
+	UploadedAt     time.Time
+}
+
+// NewPreciseIndex returns the payload for upload. processErr is included for
+// precise_index:failed events and should be nil otherwise.
+func NewPreciseIndex(upload PreciseIndexUpload, processErr error) *PreciseIndex {
+	p := &PreciseIndex{
+		Version:        preciseIndexVersion,
+		ID:             relay.MarshalID("PreciseIndex", fmt.Sprintf("U:%d", upload.ID)),
+		RepositoryID:   relay.MarshalID("Repository", int32(upload.RepositoryID)),
+		RepositoryName: upload.RepositoryName,
+		Commit:         upload.Commit,
+		Root:           upload.Root,
+		Indexer:        upload.Indexer,
+		IndexerVersion: upload.IndexerVersion,
+		UploadedAt:     upload.UploadedAt,
+	}
+	if processErr != nil {
+		msg := processErr.Error()
+		p.Error = &msg
+	}
+	return p
+}
+
+// InsightSeries is the payload of the insight_series:backfill_complete event.
+type InsightSeries struct {
+	Version     int       `json:"version"`
+	SeriesID    string    `json:"series_id"`
+	Query       string    `json:"query"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// NewInsightSeries returns the payload for the series with the given ID and
+// query.
+func NewInsightSeries(seriesID, query string, completedAt time.Time) *InsightSeries {
+	return &InsightSeries{
+		Version:     insightSeriesVersion,
+		SeriesID:    seriesID,
+		Query:       query,
+		CompletedAt: completedAt,
+	}
+}
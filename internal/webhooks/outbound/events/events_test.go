@@ -0,0 +1,159 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestNewRepository(t *testing.T) {
+	repo := &types.Repo{
+		ID:      15,
+		Name:    "github.com/sourcegraph/sourcegraph",
+		Private: true,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "MDEwOlJlcG9zaXRvcnk0MTI4ODcwOA==",
+			ServiceType: "github",
+			ServiceID:   "https://github.com/",
+		},
+	}
+
+	t.Run("cloned", func(t *testing.T) {
+		assertJSON(t, NewRepository(repo, nil), `{
+			"version": 1,
+			"id": "UmVwb3NpdG9yeToxNQ==",
+			"name": "github.com/sourcegraph/sourcegraph",
+			"url": "/github.com/sourcegraph/sourcegraph",
+			"private": true,
+			"external_repository": {
+				"service_type": "github",
+				"service_id": "https://github.com/",
+				"id": "MDEwOlJlcG9zaXRvcnk0MTI4ODcwOA=="
+			},
+			"error": null
+		}`)
+	})
+
+	t.Run("clone failed", func(t *testing.T) {
+		assertJSON(t, NewRepository(&types.Repo{ID: 15, Name: "example.com/repo"}, errors.New("repository not found")), `{
+			"version": 1,
+			"id": "UmVwb3NpdG9yeToxNQ==",
+			"name": "example.com/repo",
+			"url": "/example.com/repo",
+			"private": false,
+			"external_repository": null,
+			"error": "repository not found"
+		}`)
+	})
+}
+
+func TestNewUser(t *testing.T) {
+	assertJSON(t, NewUser(&types.User{
+		ID:          1,
+		Username:    "alice",
+		DisplayName: "Alice",
+		SiteAdmin:   true,
+		CreatedAt:   time.Date(2023, 3, 19, 5, 41, 24, 0, time.UTC),
+	}), `{
+		"version": 1,
+		"id": "VXNlcjox",
+		"username": "alice",
+		"display_name": "Alice",
+		"site_admin": true,
+		"created_at": "2023-03-19T05:41:24Z"
+	}`)
+}
+
+func TestNewPermissionsSync(t *testing.T) {
+	t.Run("user", func(t *testing.T) {
+		assertJSON(t, NewPermissionsSync(PermissionsSyncResult{
+			JobID:          3,
+			UserID:         1,
+			Reason:         "REASON_USER_ADDED",
+			PartialSuccess: true,
+			Added:          2,
+			Found:          5,
+		}), `{
+			"version": 1,
+			"id": "UGVybWlzc2lvbnNTeW5jSm9iOjM=",
+			"user_id": "VXNlcjox",
+			"repository_id": null,
+			"reason": "REASON_USER_ADDED",
+			"success": true,
+			"partial_success": true,
+			"permissions_added": 2,
+			"permissions_removed": 0,
+			"permissions_found": 5,
+			"error": null
+		}`)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		assertJSON(t, NewPermissionsSync(PermissionsSyncResult{
+			JobID:        3,
+			RepositoryID: 15,
+			Reason:       "REASON_MANUAL_REPO_SYNC",
+			Err:          errors.New("All providers failed to sync permissions."),
+		}), `{
+			"version": 1,
+			"id": "UGVybWlzc2lvbnNTeW5jSm9iOjM=",
+			"user_id": null,
+			"repository_id": "UmVwb3NpdG9yeToxNQ==",
+			"reason": "REASON_MANUAL_REPO_SYNC",
+			"success": false,
+			"partial_success": false,
+			"permissions_added": 0,
+			"permissions_removed": 0,
+			"permissions_found": 0,
+			"error": "All providers failed to sync permissions."
+		}`)
+	})
+}
+
+func TestNewPreciseIndex(t *testing.T) {
+	assertJSON(t, NewPreciseIndex(PreciseIndexUpload{
+		ID:             42,
+		RepositoryID:   15,
+		RepositoryName: "github.com/sourcegraph/sourcegraph",
+		Commit:         "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		Root:           "lib/",
+		Indexer:        "scip-go",
+		IndexerVersion: "0.1.0",
+		UploadedAt:     time.Date(2023, 3, 19, 5, 41, 24, 0, time.UTC),
+	}, errors.New("unsupported SCIP version")), `{
+		"version": 1,
+		"id": "UHJlY2lzZUluZGV4OiJVOjQyIg==",
+		"repository_id": "UmVwb3NpdG9yeToxNQ==",
+		"repository_name": "github.com/sourcegraph/sourcegraph",
+		"commit": "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		"root": "lib/",
+		"indexer": "scip-go",
+		"indexer_version": "0.1.0",
+		"uploaded_at": "2023-03-19T05:41:24Z",
+		"error": "unsupported SCIP version"
+	}`)
+}
+
+func TestNewInsightSeries(t *testing.T) {
+	assertJSON(t, NewInsightSeries("s:1234", "TODO", time.Date(2023, 3, 19, 5, 41, 24, 0, time.UTC)), `{
+		"version": 1,
+		"series_id": "s:1234",
+		"query": "TODO",
+		"completed_at": "2023-03-19T05:41:24Z"
+	}`)
+}
+
+func assertJSON(t *testing.T, payload any, want string) {
+	t.Helper()
+
+	have, err := json.Marshal(payload)
+	require.NoError(t, err)
+	assert.JSONEq(t, want, string(have))
+}
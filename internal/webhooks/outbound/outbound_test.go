@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -39,6 +40,17 @@ func TestEnqueueWebhook(t *testing.T) {
 	})
 }
 
+func TestRegisteredEventTypes(t *testing.T) {
+	registered := map[string]bool{}
+	for _, eventType := range GetRegisteredEventTypes() {
+		registered[eventType.Key] = true
+	}
+
+	for _, eventType := range events.Types {
+		assert.True(t, registered[eventType.Key], "event type %q is not registered", eventType.Key)
+	}
+}
+
 func TestCheckAddress(t *testing.T) {
 	t.Run("Invalid Addresses", func(t *testing.T) {
 		badURLS := []string{
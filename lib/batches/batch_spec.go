@@ -37,6 +37,7 @@ type BatchSpec struct {
 	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	Schedule          *Schedule                `json:"schedule,omitempty" yaml:"schedule,omitempty"`
 }
 
 type ChangesetTemplate struct {
@@ -119,6 +120,34 @@ type Output struct {
 	Format string `json:"format,omitempty" yaml:"format,omitempty"`
 }
 
+// Schedule defines when a batch spec executed server-side is re-run.
+type Schedule struct {
+	Cron             string                  `json:"cron,omitempty" yaml:"cron"`
+	AutoApply        ScheduleAutoApplyPolicy `json:"autoApply,omitempty" yaml:"autoApply"`
+	// MaxNewChangesets caps the number of new changesets a run may create
+	// and still be applied automatically. Nil means unlimited; zero means
+	// only runs that create no new changesets are applied.
+	MaxNewChangesets *int `json:"maxNewChangesets,omitempty" yaml:"maxNewChangesets"`
+}
+
+// ScheduleAutoApplyPolicy defines whether the result of a scheduled run is
+// applied to the batch change without user interaction.
+type ScheduleAutoApplyPolicy string
+
+const (
+	ScheduleAutoApplyNever  ScheduleAutoApplyPolicy = "never"
+	ScheduleAutoApplyAlways ScheduleAutoApplyPolicy = "always"
+)
+
+// ShouldAutoApply returns whether a run that would create newChangesets new
+// changesets is applied automatically.
+func (s *Schedule) ShouldAutoApply(newChangesets int) bool {
+	if s.AutoApply != ScheduleAutoApplyAlways {
+		return false
+	}
+	return s.MaxNewChangesets == nil || newChangesets <= *s.MaxNewChangesets
+}
+
 type TransformChanges struct {
 	Group []Group `json:"group,omitempty" yaml:"group"`
 }
@@ -166,6 +195,10 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 		errs = errors.Append(errs, NewValidationError(errors.New("batch spec includes steps but no changesetTemplate")))
 	}
 
+	if spec.Schedule != nil && len(spec.Steps) == 0 {
+		errs = errors.Append(errs, NewValidationError(errors.New("batch spec includes a schedule but no steps")))
+	}
+
 	for i, step := range spec.Steps {
 		for _, mount := range step.Mount {
 			if strings.Contains(mount.Path, invalidMountCharacters) {
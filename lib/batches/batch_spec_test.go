@@ -8,6 +8,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
+
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
 func TestParseBatchSpec(t *testing.T) {
@@ -59,6 +61,83 @@ steps:
 		}
 	})
 
+	t.Run("schedule", func(t *testing.T) {
+		const spec = `
+name: hello-world
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+schedule:
+  cron: "@weekly"
+  autoApply: always
+  maxNewChangesets: 10
+`
+
+		have, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		want := &Schedule{Cron: "@weekly", AutoApply: ScheduleAutoApplyAlways, MaxNewChangesets: pointers.Ptr(10)}
+		if diff := cmp.Diff(want, have.Schedule); diff != "" {
+			t.Fatalf("unexpected schedule (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("schedule without steps", func(t *testing.T) {
+		const spec = `
+name: hello-world
+importChangesets:
+  - repository: github.com/foo/bar
+    externalIDs: [1]
+schedule:
+  cron: "@weekly"
+`
+
+		_, err := ParseBatchSpec([]byte(spec))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+
+		wantErr := `batch spec includes a schedule but no steps`
+		haveErr := err.Error()
+		if haveErr != wantErr {
+			t.Fatalf("wrong error. want=%q, have=%q", wantErr, haveErr)
+		}
+	})
+
+	t.Run("invalid schedule autoApply", func(t *testing.T) {
+		const spec = `
+name: hello-world
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Hello World
+schedule:
+  cron: "@weekly"
+  autoApply: sometimes
+`
+
+		if _, err := ParseBatchSpec([]byte(spec)); err == nil {
+			t.Fatal("no error returned")
+		}
+	})
+
 	t.Run("invalid batch change name", func(t *testing.T) {
 		const spec = `
 name: this name is invalid cause it contains whitespace
@@ -416,3 +495,51 @@ func TestBatchSpec_RequiredEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedule_ShouldAutoApply(t *testing.T) {
+	for name, tc := range map[string]struct {
+		schedule      Schedule
+		newChangesets int
+		want          bool
+	}{
+		"default policy": {
+			schedule: Schedule{Cron: "@weekly"},
+			want:     false,
+		},
+		"never": {
+			schedule: Schedule{Cron: "@weekly", AutoApply: ScheduleAutoApplyNever},
+			want:     false,
+		},
+		"always": {
+			schedule:      Schedule{Cron: "@weekly", AutoApply: ScheduleAutoApplyAlways},
+			newChangesets: 1000,
+			want:          true,
+		},
+		"below limit": {
+			schedule:      Schedule{Cron: "@weekly", AutoApply: ScheduleAutoApplyAlways, MaxNewChangesets: pointers.Ptr(5)},
+			newChangesets: 5,
+			want:          true,
+		},
+		"above limit": {
+			schedule:      Schedule{Cron: "@weekly", AutoApply: ScheduleAutoApplyAlways, MaxNewChangesets: pointers.Ptr(5)},
+			newChangesets: 6,
+			want:          false,
+		},
+		"zero limit, no new changesets": {
+			schedule:      Schedule{Cron: "@weekly", AutoApply: ScheduleAutoApplyAlways, MaxNewChangesets: pointers.Ptr(0)},
+			newChangesets: 0,
+			want:          true,
+		},
+		"zero limit, new changesets": {
+			schedule:      Schedule{Cron: "@weekly", AutoApply: ScheduleAutoApplyAlways, MaxNewChangesets: pointers.Ptr(0)},
+			newChangesets: 1,
+			want:          false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.schedule.ShouldAutoApply(tc.newChangesets); have != tc.want {
+				t.Fatalf("wrong result. want=%t, have=%t", tc.want, have)
+			}
+		})
+	}
+}
@@ -38,6 +38,11 @@ type MountMetadata struct {
 	Path     string
 	Size     int64
 	Modified time.Time
+
+	// Hash is the hex encoded SHA-256 of the file's content, if known. It is
+	// not part of Key, which relies on Size and Modified, but it is required
+	// for SharedKey.
+	Hash string `json:"-"`
 }
 
 func (key CacheKey) mountsMetadata() ([]MountMetadata, error) {
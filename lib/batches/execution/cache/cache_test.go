@@ -2,6 +2,7 @@ package cache
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -195,3 +196,94 @@ type testM struct {
 func (t testM) Get(steps []batches.Step) ([]MountMetadata, error) {
 	return t.m, t.err
 }
+
+func TestCacheKey_SharedKey(t *testing.T) {
+	pinned := "alpine@sha256:" + strings.Repeat("a", 64)
+	modDate := time.Date(2022, 1, 2, 3, 5, 6, 7, time.UTC)
+
+	sharedKey := func(t *testing.T, key CacheKey) (string, bool) {
+		t.Helper()
+		k, ok, err := key.SharedKey()
+		require.NoError(t, err)
+		return k, ok
+	}
+
+	base := CacheKey{
+		Repository: repo,
+		Steps: []batches.Step{
+			{Run: "foo", Container: pinned},
+			{Run: "bar", Container: "alpine:3"},
+		},
+		StepIndex: 0,
+	}
+
+	t.Run("pinned images", func(t *testing.T) {
+		key, ok := sharedKey(t, base)
+		assert.True(t, ok)
+		assert.True(t, strings.HasSuffix(key, "-step-0"))
+
+		again, _ := sharedKey(t, base)
+		assert.Equal(t, key, again)
+	})
+
+	t.Run("unpinned image", func(t *testing.T) {
+		key := base
+		key.StepIndex = 1
+		_, ok := sharedKey(t, key)
+		assert.False(t, ok)
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		var stepEnv env.Environment
+		require.NoError(t, json.Unmarshal([]byte(`["SOME_ENV"]`), &stepEnv))
+
+		key := base
+		key.Steps = []batches.Step{{Run: "foo", Container: pinned, Env: stepEnv}}
+		key.GlobalEnv = []string{"SOME_ENV=FOO"}
+		foo, ok := sharedKey(t, key)
+		assert.True(t, ok)
+
+		key.GlobalEnv = []string{"SOME_ENV=BAR"}
+		bar, ok := sharedKey(t, key)
+		assert.True(t, ok)
+		assert.NotEqual(t, foo, bar)
+	})
+
+	t.Run("mounts", func(t *testing.T) {
+		key := base
+		key.MetadataRetriever = testM{m: []MountMetadata{{Path: "/foo/bar", Size: 100, Modified: modDate, Hash: "abc"}}}
+		first, ok := sharedKey(t, key)
+		assert.True(t, ok)
+
+		// The modification time of a file differs between uploads by different
+		// users, but its content does not.
+		key.MetadataRetriever = testM{m: []MountMetadata{{Path: "/foo/bar", Size: 100, Modified: modDate.Add(time.Hour), Hash: "abc"}}}
+		second, ok := sharedKey(t, key)
+		assert.True(t, ok)
+		assert.Equal(t, first, second)
+
+		key.MetadataRetriever = testM{m: []MountMetadata{{Path: "/foo/bar", Size: 100, Modified: modDate, Hash: "def"}}}
+		third, ok := sharedKey(t, key)
+		assert.True(t, ok)
+		assert.NotEqual(t, first, third)
+
+		key.MetadataRetriever = testM{m: []MountMetadata{{Path: "/foo/bar", Size: 100, Modified: modDate}}}
+		_, ok = sharedKey(t, key)
+		assert.False(t, ok)
+	})
+}
+
+func TestIsPinnedImage(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("0", 64)
+	for image, want := range map[string]bool{
+		"alpine":                                false,
+		"alpine:3":                              false,
+		"alpine@" + digest:                      true,
+		"alpine:3@" + digest:                    true,
+		"registry.example.com/a/b@" + digest:    true,
+		"alpine@sha256:1234":                    false,
+		"alpine@md5:" + strings.Repeat("0", 64): false,
+	} {
+		assert.Equal(t, want, IsPinnedImage(image), image)
+	}
+}
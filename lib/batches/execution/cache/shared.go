@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SharedKeyer is implemented by Keyers whose results can also be shared between
+// users.
+type SharedKeyer interface {
+	Keyer
+	SharedKey() (key string, ok bool, err error)
+}
+
+var _ SharedKeyer = CacheKey{}
+
+// sharedKeyVersion is part of every shared key. Bump it to invalidate all
+// shared cache entries when the contents of the key change.
+const sharedKeyVersion = 1
+
+// SharedKey returns a content-addressed key for the result of executing the
+// steps up to and including StepIndex, which can be used to share the result
+// between users.
+//
+// Unlike Key, SharedKey only depends on inputs that uniquely identify the
+// content the steps operate on: the repository revision, the steps themselves
+// including their container images, their resolved environments and the
+// content of mounted files. Hence, ok is false if a step uses a container
+// image that is not pinned by digest, since a tag may resolve to a different
+// image for a different user, or if the content of a mounted file is unknown.
+func (key CacheKey) SharedKey() (_ string, ok bool, err error) {
+	steps := key.Steps[0 : key.StepIndex+1]
+	for _, step := range steps {
+		if !IsPinnedImage(step.Container) {
+			return "", false, nil
+		}
+	}
+
+	envs, err := resolveStepsEnvironment(key.GlobalEnv, steps)
+	if err != nil {
+		return "", false, err
+	}
+
+	metadata, err := key.mountsMetadata()
+	if err != nil {
+		return "", false, err
+	}
+	mounts := make([]sharedMount, 0, len(metadata))
+	for _, m := range metadata {
+		if m.Hash == "" {
+			return "", false, nil
+		}
+		mounts = append(mounts, sharedMount{Path: m.Path, Hash: m.Hash})
+	}
+
+	clone := key
+	clone.Steps = steps
+
+	raw, err := json.Marshal(struct {
+		Version      int
+		Key          *CacheKey
+		Environments []map[string]string
+		Mounts       []sharedMount
+	}{
+		Version:      sharedKeyVersion,
+		Key:          &clone,
+		Environments: envs,
+		Mounts:       mounts,
+	})
+	if err != nil {
+		return "", false, err
+	}
+
+	hash := sha256.Sum256(raw)
+	return fmt.Sprintf("%s-step-%d", hex.EncodeToString(hash[:]), key.StepIndex), true, nil
+}
+
+type sharedMount struct {
+	Path string
+	Hash string
+}
+
+// IsPinnedImage returns true if the given container image reference is pinned
+// to an image digest, such as alpine@sha256:<digest>.
+func IsPinnedImage(image string) bool {
+	_, digest, ok := strings.Cut(image, "@")
+	return ok && strings.HasPrefix(digest, "sha256:") && len(digest) == len("sha256:")+64
+}
@@ -2,7 +2,7 @@
 
 package schema
 
-// BatchSpecJSON is the content of the file "schema/batch_spec.schema.json".
+// BatchSpecJSON is the content of the file "../schema/batch_spec.schema.json".
 const BatchSpecJSON = `{
   "$id": "batch_spec.schema.json#",
   "$schema": "http://json-schema.org/draft-07/schema#",
@@ -401,6 +401,33 @@ const BatchSpecJSON = `{
           ]
         }
       }
+    },
+    "schedule": {
+      "type": "object",
+      "description": "Re-runs the batch spec on a recurring schedule when it is executed server-side. Each run resolves the workspaces again, so that repositories newly matching ` + "`" + `on` + "`" + ` are picked up, and executes the steps in a new batch spec.",
+      "additionalProperties": false,
+      "required": ["cron"],
+      "properties": {
+        "cron": {
+          "type": "string",
+          "description": "A cron expression that defines when the batch spec is re-run, in UTC.",
+          "examples": ["0 6 * * 1", "@weekly"]
+        },
+        "autoApply": {
+          "type": "string",
+          "description": "Whether the batch spec of a run is applied to the batch change automatically once it has been executed. With ` + "`" + `never` + "`" + `, the new batch spec has to be previewed and applied manually.",
+          "enum": ["never", "always"],
+          "default": "never"
+        },
+        "maxNewChangesets": {
+          "type": "integer",
+          "description": "The maximum number of new changesets a run may create when it is applied automatically. Runs that would create more changesets are not applied and wait for manual review instead. Unlimited if unset; 0 only applies runs that create no new changesets.",
+          "minimum": 0,
+          "!go": {
+            "pointer": true
+          }
+        }
+      }
     }
   }
 }
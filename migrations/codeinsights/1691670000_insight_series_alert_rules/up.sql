@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS insight_series_alert_rules (
+    id SERIAL PRIMARY KEY,
+    insight_view_id INTEGER NOT NULL REFERENCES insight_view(id) ON DELETE CASCADE,
+    insight_series_id INTEGER NOT NULL REFERENCES insight_series(id) ON DELETE CASCADE,
+    capture TEXT,
+    kind TEXT NOT NULL,
+    comparison TEXT NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    intervals INTEGER NOT NULL DEFAULT 1,
+    description TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    email BOOLEAN NOT NULL DEFAULT FALSE,
+    slack_webhook_url TEXT,
+    webhook_url TEXT,
+    created_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    firing BOOLEAN NOT NULL DEFAULT FALSE,
+    last_value DOUBLE PRECISION,
+    last_evaluated_at TIMESTAMP WITH TIME ZONE,
+    last_fired_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alert_rules_insight_series_id_idx ON insight_series_alert_rules USING btree (insight_series_id);
+CREATE INDEX IF NOT EXISTS insight_series_alert_rules_insight_view_id_idx ON insight_series_alert_rules USING btree (insight_view_id);
+
+COMMENT ON TABLE insight_series_alert_rules IS 'Threshold and trend rules on insight series, evaluated after each recording of the series.';
+COMMENT ON COLUMN insight_series_alert_rules.insight_view_id IS 'The insight view the rule was created on, which determines who can manage it.';
+COMMENT ON COLUMN insight_series_alert_rules.capture IS 'For series generated from capture groups, the captured value whose series is evaluated. All values are summed if null.';
+COMMENT ON COLUMN insight_series_alert_rules.kind IS 'VALUE compares the latest value of the series, PERCENT_CHANGE its percent change over the last intervals recordings.';
+COMMENT ON COLUMN insight_series_alert_rules.comparison IS 'ABOVE or BELOW the threshold.';
+COMMENT ON COLUMN insight_series_alert_rules.email IS 'Whether to email the creator of the rule when it starts firing.';
+COMMENT ON COLUMN insight_series_alert_rules.created_by IS 'The ID of the user in the frontend database that created the rule. The series is evaluated with their permissions.';
+COMMENT ON COLUMN insight_series_alert_rules.firing IS 'Whether the condition held at the last evaluation. Notifications are only sent when this changes to true.';
+COMMENT ON COLUMN insight_series_alert_rules.last_value IS 'The value compared at the last evaluation.';
@@ -174,6 +174,56 @@ COMMENT ON COLUMN insight_series.just_in_time IS 'Specifies if the series should
 
 COMMENT ON COLUMN insight_series.repository_criteria IS 'The search criteria used to determine the repositories that are included in this series.';
 
+CREATE TABLE insight_series_alert_rules (
+    id integer NOT NULL,
+    insight_view_id integer NOT NULL,
+    insight_series_id integer NOT NULL,
+    capture text,
+    kind text NOT NULL,
+    comparison text NOT NULL,
+    threshold double precision NOT NULL,
+    intervals integer DEFAULT 1 NOT NULL,
+    description text NOT NULL,
+    enabled boolean DEFAULT true NOT NULL,
+    email boolean DEFAULT false NOT NULL,
+    slack_webhook_url text,
+    webhook_url text,
+    created_by integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    firing boolean DEFAULT false NOT NULL,
+    last_value double precision,
+    last_evaluated_at timestamp with time zone,
+    last_fired_at timestamp with time zone
+);
+
+COMMENT ON TABLE insight_series_alert_rules IS 'Threshold and trend rules on insight series, evaluated after each recording of the series.';
+
+COMMENT ON COLUMN insight_series_alert_rules.insight_view_id IS 'The insight view the rule was created on, which determines who can manage it.';
+
+COMMENT ON COLUMN insight_series_alert_rules.capture IS 'For series generated from capture groups, the captured value whose series is evaluated. All values are summed if null.';
+
+COMMENT ON COLUMN insight_series_alert_rules.kind IS 'VALUE compares the latest value of the series, PERCENT_CHANGE its percent change over the last intervals recordings.';
+
+COMMENT ON COLUMN insight_series_alert_rules.comparison IS 'ABOVE or BELOW the threshold.';
+
+COMMENT ON COLUMN insight_series_alert_rules.email IS 'Whether to email the creator of the rule when it starts firing.';
+
+COMMENT ON COLUMN insight_series_alert_rules.created_by IS 'The ID of the user in the frontend database that created the rule. The series is evaluated with their permissions.';
+
+COMMENT ON COLUMN insight_series_alert_rules.firing IS 'Whether the condition held at the last evaluation. Notifications are only sent when this changes to true.';
+
+COMMENT ON COLUMN insight_series_alert_rules.last_value IS 'The value compared at the last evaluation.';
+
+CREATE SEQUENCE insight_series_alert_rules_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE insight_series_alert_rules_id_seq OWNED BY insight_series_alert_rules.id;
+
 CREATE TABLE insight_series_backfill (
     id integer NOT NULL,
     series_id integer NOT NULL,
@@ -541,6 +591,8 @@ ALTER TABLE ONLY dashboard_insight_view ALTER COLUMN id SET DEFAULT nextval('das
 
 ALTER TABLE ONLY insight_series ALTER COLUMN id SET DEFAULT nextval('insight_series_id_seq'::regclass);
 
+ALTER TABLE ONLY insight_series_alert_rules ALTER COLUMN id SET DEFAULT nextval('insight_series_alert_rules_id_seq'::regclass);
+
 ALTER TABLE ONLY insight_series_backfill ALTER COLUMN id SET DEFAULT nextval('insight_series_backfill_id_seq'::regclass);
 
 ALTER TABLE ONLY insight_series_incomplete_points ALTER COLUMN id SET DEFAULT nextval('insight_series_incomplete_points_id_seq'::regclass);
@@ -573,6 +625,9 @@ ALTER TABLE ONLY dashboard_insight_view
 ALTER TABLE ONLY dashboard
     ADD CONSTRAINT dashboard_pk PRIMARY KEY (id);
 
+ALTER TABLE ONLY insight_series_alert_rules
+    ADD CONSTRAINT insight_series_alert_rules_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY insight_series_backfill
     ADD CONSTRAINT insight_series_backfill_pk PRIMARY KEY (id);
 
@@ -627,6 +682,10 @@ CREATE INDEX dashboard_insight_view_dashboard_id_fk_idx ON dashboard_insight_vie
 
 CREATE INDEX dashboard_insight_view_insight_view_id_fk_idx ON dashboard_insight_view USING btree (insight_view_id);
 
+CREATE INDEX insight_series_alert_rules_insight_series_id_idx ON insight_series_alert_rules USING btree (insight_series_id);
+
+CREATE INDEX insight_series_alert_rules_insight_view_id_idx ON insight_series_alert_rules USING btree (insight_view_id);
+
 CREATE INDEX insight_series_deleted_at_idx ON insight_series USING btree (deleted_at);
 
 CREATE UNIQUE INDEX insight_series_incomplete_points_unique_idx ON insight_series_incomplete_points USING btree (series_id, reason, "time", repo_id);
@@ -686,6 +745,12 @@ ALTER TABLE ONLY dashboard_insight_view
 ALTER TABLE ONLY dashboard_insight_view
     ADD CONSTRAINT dashboard_insight_view_insight_view_id_fk FOREIGN KEY (insight_view_id) REFERENCES insight_view(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY insight_series_alert_rules
+    ADD CONSTRAINT insight_series_alert_rules_insight_series_id_fkey FOREIGN KEY (insight_series_id) REFERENCES insight_series(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY insight_series_alert_rules
+    ADD CONSTRAINT insight_series_alert_rules_insight_view_id_fkey FOREIGN KEY (insight_view_id) REFERENCES insight_view(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY insight_series_backfill
     ADD CONSTRAINT insight_series_backfill_series_id_fk FOREIGN KEY (series_id) REFERENCES insight_series(id) ON DELETE CASCADE;
 
@@ -0,0 +1,5 @@
+ALTER TABLE cm_trigger_jobs
+    DROP COLUMN IF EXISTS content_search_results;
+
+ALTER TABLE cm_last_searched
+    DROP COLUMN IF EXISTS match_fingerprints;
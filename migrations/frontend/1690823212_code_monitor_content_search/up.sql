@@ -0,0 +1,9 @@
+ALTER TABLE cm_last_searched
+    ADD COLUMN IF NOT EXISTS match_fingerprints jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN cm_last_searched.match_fingerprints IS 'For content search code monitors, a map from the path of each file matched on the previous run to a fingerprint of its matches';
+
+ALTER TABLE cm_trigger_jobs
+    ADD COLUMN IF NOT EXISTS content_search_results jsonb;
+
+COMMENT ON COLUMN cm_trigger_jobs.content_search_results IS 'For content search code monitors, the files that started or stopped matching since the previous run';
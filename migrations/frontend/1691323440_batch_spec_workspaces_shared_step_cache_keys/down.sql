@@ -0,0 +1 @@
+ALTER TABLE batch_spec_workspaces DROP COLUMN IF EXISTS shared_step_cache_keys;
@@ -0,0 +1,3 @@
+ALTER TABLE batch_spec_workspaces ADD COLUMN IF NOT EXISTS shared_step_cache_keys jsonb DEFAULT '{}'::jsonb NOT NULL;
+
+COMMENT ON COLUMN batch_spec_workspaces.shared_step_cache_keys IS 'Maps the per-user step cache keys of the workspace to their content-addressed keys in the shared step cache';
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS batch_change_scheduled_runs (
+    id bigserial PRIMARY KEY,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    batch_spec_id bigint REFERENCES batch_specs(id) ON DELETE SET NULL DEFERRABLE,
+    state text DEFAULT 'resolving'::text NOT NULL,
+    new_changesets integer DEFAULT 0 NOT NULL,
+    failure_message text,
+    scheduled_at timestamp with time zone NOT NULL,
+    finished_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS batch_change_scheduled_runs_batch_change_id ON batch_change_scheduled_runs USING btree (batch_change_id, scheduled_at);
+CREATE INDEX IF NOT EXISTS batch_change_scheduled_runs_state ON batch_change_scheduled_runs USING btree (state);
+
+COMMENT ON TABLE batch_change_scheduled_runs IS 'The runs of batch changes whose batch spec has a schedule';
+COMMENT ON COLUMN batch_change_scheduled_runs.batch_spec_id IS 'The batch spec created for the run';
+COMMENT ON COLUMN batch_change_scheduled_runs.new_changesets IS 'The number of changesets that applying the batch spec of the run creates';
+COMMENT ON COLUMN batch_change_scheduled_runs.scheduled_at IS 'The time the run was started by the schedule';
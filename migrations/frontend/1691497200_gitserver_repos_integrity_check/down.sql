@@ -0,0 +1,2 @@
+ALTER TABLE gitserver_repos DROP COLUMN IF EXISTS integrity_checked_at;
+ALTER TABLE gitserver_repos DROP COLUMN IF EXISTS integrity_check_passed;
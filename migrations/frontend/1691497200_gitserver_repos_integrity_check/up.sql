@@ -0,0 +1,5 @@
+ALTER TABLE gitserver_repos ADD COLUMN IF NOT EXISTS integrity_checked_at timestamp with time zone;
+ALTER TABLE gitserver_repos ADD COLUMN IF NOT EXISTS integrity_check_passed boolean;
+
+COMMENT ON COLUMN gitserver_repos.integrity_checked_at IS 'Timestamp of the last periodic integrity check of the repo on disk';
+COMMENT ON COLUMN gitserver_repos.integrity_check_passed IS 'Whether the last periodic integrity check of the repo on disk passed';
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS own_aggregate_blame_ownership;
+
+DELETE FROM own_background_jobs
+WHERE job_type IN (SELECT id FROM own_signal_configurations WHERE name = 'blame-ownership');
+
+DELETE FROM own_signal_configurations
+WHERE name = 'blame-ownership';
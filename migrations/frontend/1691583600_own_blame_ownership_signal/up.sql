@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS own_aggregate_blame_ownership
+(
+    id               SERIAL PRIMARY KEY,
+    commit_author_id INTEGER          NOT NULL REFERENCES commit_authors (id),
+    file_path_id     INTEGER          NOT NULL REFERENCES repo_paths (id),
+    lines_count      INTEGER          NOT NULL DEFAULT 0,
+    score            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS own_aggregate_blame_ownership_file_author
+    ON own_aggregate_blame_ownership
+        USING btree (file_path_id, commit_author_id);
+
+COMMENT ON TABLE own_aggregate_blame_ownership
+    IS 'One entry contains the share of the current lines of a file or directory tree that were last changed by a given commit author, according to git blame.';
+COMMENT ON COLUMN own_aggregate_blame_ownership.lines_count
+    IS 'Number of lines in the file or directory tree last changed by the author.';
+COMMENT ON COLUMN own_aggregate_blame_ownership.score
+    IS 'Share of the lines in the file or directory tree last changed by the author, between 0 and 1. Each line is weighted by the age of its last change, so that recent changes count more.';
+
+INSERT INTO own_signal_configurations (name, enabled, description)
+VALUES ('blame-ownership', FALSE, 'Indexes the authors of the current lines of each file using git blame, weighting recently changed lines higher.')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS user_totp
+(
+    user_id           INTEGER PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    secret            TEXT                     NOT NULL,
+    encryption_key_id TEXT                     NOT NULL DEFAULT '',
+    recovery_codes    TEXT[]                   NOT NULL DEFAULT '{}',
+    last_used_step    BIGINT                   NOT NULL DEFAULT 0,
+    enabled_at        TIMESTAMP WITH TIME ZONE,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE user_totp
+    IS 'The authenticator app (TOTP) second factor of users who sign in with the builtin auth provider.';
+COMMENT ON COLUMN user_totp.secret
+    IS 'The base32-encoded TOTP secret, encrypted with the userTOTPKey if encryption_key_id is set.';
+COMMENT ON COLUMN user_totp.recovery_codes
+    IS 'SHA-256 hashes of the recovery codes that were not used yet.';
+COMMENT ON COLUMN user_totp.last_used_step
+    IS 'The time step of the last accepted code, to reject replays of the same code.';
+COMMENT ON COLUMN user_totp.enabled_at
+    IS 'When the enrollment was confirmed with a code. NULL while the enrollment is pending.';
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS repo_update_schedules
+(
+    repo_id          INTEGER PRIMARY KEY REFERENCES repo (id) ON DELETE CASCADE,
+    interval_seconds INTEGER                  NOT NULL,
+    due_at           TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_fetched_at  TIMESTAMP WITH TIME ZONE,
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS repo_update_schedules_due_at ON repo_update_schedules USING btree (due_at);
+
+COMMENT ON TABLE repo_update_schedules
+    IS 'The update schedule of repositories in the repo-updater scheduler, persisted so that learned update intervals survive restarts.';
+COMMENT ON COLUMN repo_update_schedules.interval_seconds
+    IS 'How regularly the repository is fetched, learned from how often it changes.';
+COMMENT ON COLUMN repo_update_schedules.due_at
+    IS 'The next time the repository is due to be fetched.';
+COMMENT ON COLUMN repo_update_schedules.last_fetched_at
+    IS 'When the repository was last fetched by the scheduler, if known.';
@@ -964,6 +964,36 @@ CREATE SEQUENCE assigned_teams_id_seq
 
 ALTER SEQUENCE assigned_teams_id_seq OWNED BY assigned_teams.id;
 
+CREATE TABLE batch_change_scheduled_runs (
+    id bigint NOT NULL,
+    batch_change_id bigint NOT NULL,
+    batch_spec_id bigint,
+    state text DEFAULT 'resolving'::text NOT NULL,
+    new_changesets integer DEFAULT 0 NOT NULL,
+    failure_message text,
+    scheduled_at timestamp with time zone NOT NULL,
+    finished_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE batch_change_scheduled_runs IS 'The runs of batch changes whose batch spec has a schedule';
+
+COMMENT ON COLUMN batch_change_scheduled_runs.batch_spec_id IS 'The batch spec created for the run';
+
+COMMENT ON COLUMN batch_change_scheduled_runs.new_changesets IS 'The number of changesets that applying the batch spec of the run creates';
+
+COMMENT ON COLUMN batch_change_scheduled_runs.scheduled_at IS 'The time the run was started by the schedule';
+
+CREATE SEQUENCE batch_change_scheduled_runs_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE batch_change_scheduled_runs_id_seq OWNED BY batch_change_scheduled_runs.id;
+
 CREATE TABLE batch_changes (
     id bigint NOT NULL,
     name text NOT NULL,
@@ -1170,9 +1200,12 @@ CREATE TABLE batch_spec_workspaces (
     unsupported boolean DEFAULT false NOT NULL,
     skipped boolean DEFAULT false NOT NULL,
     cached_result_found boolean DEFAULT false NOT NULL,
-    step_cache_results jsonb DEFAULT '{}'::jsonb NOT NULL
+    step_cache_results jsonb DEFAULT '{}'::jsonb NOT NULL,
+    shared_step_cache_keys jsonb DEFAULT '{}'::jsonb NOT NULL
 );
 
+COMMENT ON COLUMN batch_spec_workspaces.shared_step_cache_keys IS 'Maps the per-user step cache keys of the workspace to their content-addressed keys in the shared step cache';
+
 CREATE SEQUENCE batch_spec_workspaces_id_seq
     START WITH 1
     INCREMENT BY 1
@@ -1440,7 +1473,9 @@ CREATE TABLE cm_action_jobs (
     slack_webhook bigint,
     queued_at timestamp with time zone DEFAULT now(),
     cancel boolean DEFAULT false NOT NULL,
-    CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((((
+    teams_webhook bigint,
+    mattermost_webhook bigint,
+    CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((((((
 CASE
     WHEN (email IS NULL) THEN 0
     ELSE 1
@@ -1452,6 +1487,14 @@ END) +
 CASE
     WHEN (slack_webhook IS NULL) THEN 0
     ELSE 1
+END) +
+CASE
+    WHEN (teams_webhook IS NULL) THEN 0
+    ELSE 1
+END) +
+CASE
+    WHEN (mattermost_webhook IS NULL) THEN 0
+    ELSE 1
 END) = 1))
 );
 
@@ -1461,6 +1504,10 @@ COMMENT ON COLUMN cm_action_jobs.webhook IS 'The ID of the cm_webhooks action to
 
 COMMENT ON COLUMN cm_action_jobs.slack_webhook IS 'The ID of the cm_slack_webhook action to execute if this is a slack webhook job. Mutually exclusive with email and webhook';
 
+COMMENT ON COLUMN cm_action_jobs.teams_webhook IS 'The ID of the cm_teams_webhooks action to execute if this is a Microsoft Teams webhook job. Mutually exclusive with the other action types';
+
+COMMENT ON COLUMN cm_action_jobs.mattermost_webhook IS 'The ID of the cm_mattermost_webhooks action to execute if this is a Mattermost webhook job. Mutually exclusive with the other action types';
+
 COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
 
 CREATE SEQUENCE cm_action_jobs_id_seq
@@ -1498,13 +1545,43 @@ ALTER SEQUENCE cm_emails_id_seq OWNED BY cm_emails.id;
 CREATE TABLE cm_last_searched (
     monitor_id bigint NOT NULL,
     commit_oids text[] NOT NULL,
-    repo_id integer NOT NULL
+    repo_id integer NOT NULL,
+    match_fingerprints jsonb DEFAULT '{}'::jsonb NOT NULL
 );
 
 COMMENT ON TABLE cm_last_searched IS 'The last searched commit hashes for the given code monitor and unique set of search arguments';
 
 COMMENT ON COLUMN cm_last_searched.commit_oids IS 'The set of commit OIDs that was previously successfully searched and should be excluded on the next run';
 
+COMMENT ON COLUMN cm_last_searched.match_fingerprints IS 'For content search code monitors, a map from the path of each file matched on the previous run to a fingerprint of its matches';
+
+CREATE TABLE cm_mattermost_webhooks (
+    id bigint NOT NULL,
+    monitor bigint NOT NULL,
+    url text NOT NULL,
+    enabled boolean NOT NULL,
+    include_results boolean DEFAULT false NOT NULL,
+    created_by integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    changed_by integer NOT NULL,
+    changed_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE cm_mattermost_webhooks IS 'Mattermost webhook actions configured on code monitors';
+
+COMMENT ON COLUMN cm_mattermost_webhooks.monitor IS 'The code monitor that the action is defined on';
+
+COMMENT ON COLUMN cm_mattermost_webhooks.url IS 'The Mattermost incoming webhook URL we send the code monitor event to';
+
+CREATE SEQUENCE cm_mattermost_webhooks_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE cm_mattermost_webhooks_id_seq OWNED BY cm_mattermost_webhooks.id;
+
 CREATE TABLE cm_monitors (
     id bigint NOT NULL,
     created_by integer NOT NULL,
@@ -1592,6 +1669,33 @@ CREATE SEQUENCE cm_slack_webhooks_id_seq
 
 ALTER SEQUENCE cm_slack_webhooks_id_seq OWNED BY cm_slack_webhooks.id;
 
+CREATE TABLE cm_teams_webhooks (
+    id bigint NOT NULL,
+    monitor bigint NOT NULL,
+    url text NOT NULL,
+    enabled boolean NOT NULL,
+    include_results boolean DEFAULT false NOT NULL,
+    created_by integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    changed_by integer NOT NULL,
+    changed_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE cm_teams_webhooks IS 'Microsoft Teams webhook actions configured on code monitors';
+
+COMMENT ON COLUMN cm_teams_webhooks.monitor IS 'The code monitor that the action is defined on';
+
+COMMENT ON COLUMN cm_teams_webhooks.url IS 'The Microsoft Teams incoming webhook URL we send the code monitor event to';
+
+CREATE SEQUENCE cm_teams_webhooks_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE cm_teams_webhooks_id_seq OWNED BY cm_teams_webhooks.id;
+
 CREATE TABLE cm_trigger_jobs (
     id integer NOT NULL,
     query bigint NOT NULL,
@@ -1610,9 +1714,12 @@ CREATE TABLE cm_trigger_jobs (
     search_results jsonb,
     queued_at timestamp with time zone DEFAULT now(),
     cancel boolean DEFAULT false NOT NULL,
+    content_search_results jsonb,
     CONSTRAINT search_results_is_array CHECK ((jsonb_typeof(search_results) = 'array'::text))
 );
 
+COMMENT ON COLUMN cm_trigger_jobs.content_search_results IS 'For content search code monitors, the files that started or stopped matching since the previous run';
+
 CREATE SEQUENCE cm_trigger_jobs_id_seq
     AS integer
     START WITH 1
@@ -2720,13 +2827,19 @@ CREATE TABLE gitserver_repos (
     corrupted_at timestamp with time zone,
     corruption_logs jsonb DEFAULT '[]'::jsonb NOT NULL,
     cloning_progress text DEFAULT ''::text,
-    pool_repo_id integer
+    pool_repo_id integer,
+    integrity_checked_at timestamp with time zone,
+    integrity_check_passed boolean
 );
 
 COMMENT ON COLUMN gitserver_repos.corrupted_at IS 'Timestamp of when repo corruption was detected';
 
 COMMENT ON COLUMN gitserver_repos.corruption_logs IS 'Log output of repo corruptions that have been detected - encoded as json';
 
+COMMENT ON COLUMN gitserver_repos.integrity_check_passed IS 'Whether the last periodic integrity check of the repo on disk passed';
+
+COMMENT ON COLUMN gitserver_repos.integrity_checked_at IS 'Timestamp of the last periodic integrity check of the repo on disk';
+
 COMMENT ON COLUMN gitserver_repos.pool_repo_id IS 'This is used to refer to the pool repository for deduplicated repos';
 
 CREATE TABLE gitserver_repos_statistics (
@@ -3770,6 +3883,31 @@ CREATE VIEW outbound_webhooks_with_event_types AS
           WHERE (outbound_webhook_event_types.outbound_webhook_id = outbound_webhooks.id))) AS event_types
    FROM outbound_webhooks;
 
+CREATE TABLE own_aggregate_blame_ownership (
+    id integer NOT NULL,
+    commit_author_id integer NOT NULL,
+    file_path_id integer NOT NULL,
+    lines_count integer DEFAULT 0 NOT NULL,
+    score double precision DEFAULT 0 NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE own_aggregate_blame_ownership IS 'One entry contains the share of the current lines of a file or directory tree that were last changed by a given commit author, according to git blame.';
+
+COMMENT ON COLUMN own_aggregate_blame_ownership.lines_count IS 'Number of lines in the file or directory tree last changed by the author.';
+
+COMMENT ON COLUMN own_aggregate_blame_ownership.score IS 'Share of the lines in the file or directory tree last changed by the author, between 0 and 1. Each line is weighted by the age of its last change, so that recent changes count more.';
+
+CREATE SEQUENCE own_aggregate_blame_ownership_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE own_aggregate_blame_ownership_id_seq OWNED BY own_aggregate_blame_ownership.id;
+
 CREATE TABLE own_aggregate_recent_contribution (
     id integer NOT NULL,
     commit_author_id integer NOT NULL,
@@ -4363,6 +4501,22 @@ COMMENT ON COLUMN repo_statistics.failed_fetch IS 'Number of repositories that a
 
 COMMENT ON COLUMN repo_statistics.corrupted IS 'Number of repositories that are NOT soft-deleted and not blocked and have corrupted_at set in gitserver_repos table';
 
+CREATE TABLE repo_update_schedules (
+    repo_id integer NOT NULL,
+    interval_seconds integer NOT NULL,
+    due_at timestamp with time zone NOT NULL,
+    last_fetched_at timestamp with time zone,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE repo_update_schedules IS 'The update schedule of repositories in the repo-updater scheduler, persisted so that learned update intervals survive restarts.';
+
+COMMENT ON COLUMN repo_update_schedules.interval_seconds IS 'How regularly the repository is fetched, learned from how often it changes.';
+
+COMMENT ON COLUMN repo_update_schedules.due_at IS 'The next time the repository is due to be fetched.';
+
+COMMENT ON COLUMN repo_update_schedules.last_fetched_at IS 'When the repository was last fetched by the scheduler, if known.';
+
 CREATE TABLE role_permissions (
     role_id integer NOT NULL,
     permission_id integer NOT NULL,
@@ -4755,6 +4909,27 @@ CREATE SEQUENCE users_id_seq
 
 ALTER SEQUENCE users_id_seq OWNED BY users.id;
 
+CREATE TABLE user_totp (
+    user_id integer NOT NULL,
+    secret text NOT NULL,
+    encryption_key_id text DEFAULT ''::text NOT NULL,
+    recovery_codes text[] DEFAULT '{}'::text[] NOT NULL,
+    last_used_step bigint DEFAULT 0 NOT NULL,
+    enabled_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE user_totp IS 'The authenticator app (TOTP) second factor of users who sign in with the builtin auth provider.';
+
+COMMENT ON COLUMN user_totp.secret IS 'The base32-encoded TOTP secret, encrypted with the userTOTPKey if encryption_key_id is set.';
+
+COMMENT ON COLUMN user_totp.recovery_codes IS 'SHA-256 hashes of the recovery codes that were not used yet.';
+
+COMMENT ON COLUMN user_totp.last_used_step IS 'The time step of the last accepted code, to reject replays of the same code.';
+
+COMMENT ON COLUMN user_totp.enabled_at IS 'When the enrollment was confirmed with a code. NULL while the enrollment is pending.';
+
 CREATE TABLE versions (
     service text NOT NULL,
     version text NOT NULL,
@@ -4921,6 +5096,8 @@ ALTER TABLE ONLY assigned_owners ALTER COLUMN id SET DEFAULT nextval('assigned_o
 
 ALTER TABLE ONLY assigned_teams ALTER COLUMN id SET DEFAULT nextval('assigned_teams_id_seq'::regclass);
 
+ALTER TABLE ONLY batch_change_scheduled_runs ALTER COLUMN id SET DEFAULT nextval('batch_change_scheduled_runs_id_seq'::regclass);
+
 ALTER TABLE ONLY batch_changes ALTER COLUMN id SET DEFAULT nextval('batch_changes_id_seq'::regclass);
 
 ALTER TABLE ONLY batch_changes_site_credentials ALTER COLUMN id SET DEFAULT nextval('batch_changes_site_credentials_id_seq'::regclass);
@@ -4951,6 +5128,8 @@ ALTER TABLE ONLY cm_action_jobs ALTER COLUMN id SET DEFAULT nextval('cm_action_j
 
 ALTER TABLE ONLY cm_emails ALTER COLUMN id SET DEFAULT nextval('cm_emails_id_seq'::regclass);
 
+ALTER TABLE ONLY cm_mattermost_webhooks ALTER COLUMN id SET DEFAULT nextval('cm_mattermost_webhooks_id_seq'::regclass);
+
 ALTER TABLE ONLY cm_monitors ALTER COLUMN id SET DEFAULT nextval('cm_monitors_id_seq'::regclass);
 
 ALTER TABLE ONLY cm_queries ALTER COLUMN id SET DEFAULT nextval('cm_queries_id_seq'::regclass);
@@ -4959,6 +5138,8 @@ ALTER TABLE ONLY cm_recipients ALTER COLUMN id SET DEFAULT nextval('cm_recipient
 
 ALTER TABLE ONLY cm_slack_webhooks ALTER COLUMN id SET DEFAULT nextval('cm_slack_webhooks_id_seq'::regclass);
 
+ALTER TABLE ONLY cm_teams_webhooks ALTER COLUMN id SET DEFAULT nextval('cm_teams_webhooks_id_seq'::regclass);
+
 ALTER TABLE ONLY cm_trigger_jobs ALTER COLUMN id SET DEFAULT nextval('cm_trigger_jobs_id_seq'::regclass);
 
 ALTER TABLE ONLY cm_webhooks ALTER COLUMN id SET DEFAULT nextval('cm_webhooks_id_seq'::regclass);
@@ -5087,6 +5268,8 @@ ALTER TABLE ONLY outbound_webhook_logs ALTER COLUMN id SET DEFAULT nextval('outb
 
 ALTER TABLE ONLY outbound_webhooks ALTER COLUMN id SET DEFAULT nextval('outbound_webhooks_id_seq'::regclass);
 
+ALTER TABLE ONLY own_aggregate_blame_ownership ALTER COLUMN id SET DEFAULT nextval('own_aggregate_blame_ownership_id_seq'::regclass);
+
 ALTER TABLE ONLY own_aggregate_recent_contribution ALTER COLUMN id SET DEFAULT nextval('own_aggregate_recent_contribution_id_seq'::regclass);
 
 ALTER TABLE ONLY own_aggregate_recent_view ALTER COLUMN id SET DEFAULT nextval('own_aggregate_recent_view_id_seq'::regclass);
@@ -5178,6 +5361,9 @@ ALTER TABLE ONLY assigned_owners
 ALTER TABLE ONLY assigned_teams
     ADD CONSTRAINT assigned_teams_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY batch_change_scheduled_runs
+    ADD CONSTRAINT batch_change_scheduled_runs_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY batch_changes
     ADD CONSTRAINT batch_changes_pkey PRIMARY KEY (id);
 
@@ -5241,6 +5427,9 @@ ALTER TABLE ONLY cm_emails
 ALTER TABLE ONLY cm_last_searched
     ADD CONSTRAINT cm_last_searched_pkey PRIMARY KEY (monitor_id, repo_id);
 
+ALTER TABLE ONLY cm_mattermost_webhooks
+    ADD CONSTRAINT cm_mattermost_webhooks_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY cm_monitors
     ADD CONSTRAINT cm_monitors_pkey PRIMARY KEY (id);
 
@@ -5253,6 +5442,9 @@ ALTER TABLE ONLY cm_recipients
 ALTER TABLE ONLY cm_slack_webhooks
     ADD CONSTRAINT cm_slack_webhooks_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY cm_teams_webhooks
+    ADD CONSTRAINT cm_teams_webhooks_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY cm_trigger_jobs
     ADD CONSTRAINT cm_trigger_jobs_pkey PRIMARY KEY (id);
 
@@ -5523,6 +5715,9 @@ ALTER TABLE ONLY outbound_webhook_logs
 ALTER TABLE ONLY outbound_webhooks
     ADD CONSTRAINT outbound_webhooks_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY own_aggregate_blame_ownership
+    ADD CONSTRAINT own_aggregate_blame_ownership_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY own_aggregate_recent_contribution
     ADD CONSTRAINT own_aggregate_recent_contribution_pkey PRIMARY KEY (id);
 
@@ -5601,6 +5796,9 @@ ALTER TABLE ONLY repo_permissions
 ALTER TABLE ONLY repo
     ADD CONSTRAINT repo_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY repo_update_schedules
+    ADD CONSTRAINT repo_update_schedules_pkey PRIMARY KEY (repo_id);
+
 ALTER TABLE ONLY role_permissions
     ADD CONSTRAINT role_permissions_pkey PRIMARY KEY (permission_id, role_id);
 
@@ -5676,6 +5874,9 @@ ALTER TABLE ONLY user_repo_permissions
 ALTER TABLE ONLY user_roles
     ADD CONSTRAINT user_roles_pkey PRIMARY KEY (user_id, role_id);
 
+ALTER TABLE ONLY user_totp
+    ADD CONSTRAINT user_totp_pkey PRIMARY KEY (user_id);
+
 ALTER TABLE ONLY users
     ADD CONSTRAINT users_pkey PRIMARY KEY (id);
 
@@ -5718,6 +5919,10 @@ CREATE UNIQUE INDEX assigned_owners_file_path_owner ON assigned_owners USING btr
 
 CREATE UNIQUE INDEX assigned_teams_file_path_owner ON assigned_teams USING btree (file_path_id, owner_team_id);
 
+CREATE INDEX batch_change_scheduled_runs_batch_change_id ON batch_change_scheduled_runs USING btree (batch_change_id, scheduled_at);
+
+CREATE INDEX batch_change_scheduled_runs_state ON batch_change_scheduled_runs USING btree (state);
+
 CREATE INDEX batch_changes_namespace_org_id ON batch_changes USING btree (namespace_org_id);
 
 CREATE INDEX batch_changes_namespace_user_id ON batch_changes USING btree (namespace_user_id);
@@ -5792,8 +5997,12 @@ CREATE INDEX cm_action_jobs_state_idx ON cm_action_jobs USING btree (state);
 
 CREATE INDEX cm_action_jobs_trigger_event ON cm_action_jobs USING btree (trigger_event);
 
+CREATE INDEX cm_mattermost_webhooks_monitor ON cm_mattermost_webhooks USING btree (monitor);
+
 CREATE INDEX cm_slack_webhooks_monitor ON cm_slack_webhooks USING btree (monitor);
 
+CREATE INDEX cm_teams_webhooks_monitor ON cm_teams_webhooks USING btree (monitor);
+
 CREATE INDEX cm_trigger_jobs_finished_at ON cm_trigger_jobs USING btree (finished_at);
 
 CREATE INDEX cm_trigger_jobs_state_idx ON cm_trigger_jobs USING btree (state);
@@ -6054,6 +6263,8 @@ CREATE INDEX outbound_webhook_payload_process_after_idx ON outbound_webhook_jobs
 
 CREATE INDEX outbound_webhooks_logs_status_code_idx ON outbound_webhook_logs USING btree (status_code);
 
+CREATE UNIQUE INDEX own_aggregate_blame_ownership_file_author ON own_aggregate_blame_ownership USING btree (file_path_id, commit_author_id);
+
 CREATE UNIQUE INDEX own_aggregate_recent_contribution_file_author ON own_aggregate_recent_contribution USING btree (changed_file_path_id, commit_author_id);
 
 CREATE UNIQUE INDEX own_aggregate_recent_view_viewer ON own_aggregate_recent_view USING btree (viewed_file_path_id, viewer_id);
@@ -6138,6 +6349,8 @@ CREATE INDEX repo_stars_desc_id_desc_idx ON repo USING btree (stars DESC NULLS L
 
 CREATE INDEX repo_stars_idx ON repo USING btree (stars DESC NULLS LAST);
 
+CREATE INDEX repo_update_schedules_due_at ON repo_update_schedules USING btree (due_at);
+
 CREATE INDEX repo_uri_idx ON repo USING btree (uri);
 
 CREATE UNIQUE INDEX search_contexts_name_namespace_org_id_unique ON search_contexts USING btree (name, namespace_org_id) WHERE (namespace_org_id IS NOT NULL);
@@ -6300,6 +6513,12 @@ ALTER TABLE ONLY assigned_teams
 ALTER TABLE ONLY assigned_teams
     ADD CONSTRAINT assigned_teams_who_assigned_team_id_fkey FOREIGN KEY (who_assigned_team_id) REFERENCES users(id) ON DELETE SET NULL DEFERRABLE;
 
+ALTER TABLE ONLY batch_change_scheduled_runs
+    ADD CONSTRAINT batch_change_scheduled_runs_batch_change_id_fkey FOREIGN KEY (batch_change_id) REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE;
+
+ALTER TABLE ONLY batch_change_scheduled_runs
+    ADD CONSTRAINT batch_change_scheduled_runs_batch_spec_id_fkey FOREIGN KEY (batch_spec_id) REFERENCES batch_specs(id) ON DELETE SET NULL DEFERRABLE;
+
 ALTER TABLE ONLY batch_changes
     ADD CONSTRAINT batch_changes_batch_spec_id_fkey FOREIGN KEY (batch_spec_id) REFERENCES batch_specs(id) DEFERRABLE;
 
@@ -6381,9 +6600,15 @@ ALTER TABLE ONLY changesets
 ALTER TABLE ONLY cm_action_jobs
     ADD CONSTRAINT cm_action_jobs_email_fk FOREIGN KEY (email) REFERENCES cm_emails(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY cm_action_jobs
+    ADD CONSTRAINT cm_action_jobs_mattermost_webhook_fkey FOREIGN KEY (mattermost_webhook) REFERENCES cm_mattermost_webhooks(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY cm_action_jobs
     ADD CONSTRAINT cm_action_jobs_slack_webhook_fkey FOREIGN KEY (slack_webhook) REFERENCES cm_slack_webhooks(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY cm_action_jobs
+    ADD CONSTRAINT cm_action_jobs_teams_webhook_fkey FOREIGN KEY (teams_webhook) REFERENCES cm_teams_webhooks(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY cm_action_jobs
     ADD CONSTRAINT cm_action_jobs_trigger_event_fk FOREIGN KEY (trigger_event) REFERENCES cm_trigger_jobs(id) ON DELETE CASCADE;
 
@@ -6405,6 +6630,15 @@ ALTER TABLE ONLY cm_last_searched
 ALTER TABLE ONLY cm_last_searched
     ADD CONSTRAINT cm_last_searched_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY cm_mattermost_webhooks
+    ADD CONSTRAINT cm_mattermost_webhooks_changed_by_fkey FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY cm_mattermost_webhooks
+    ADD CONSTRAINT cm_mattermost_webhooks_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY cm_mattermost_webhooks
+    ADD CONSTRAINT cm_mattermost_webhooks_monitor_fkey FOREIGN KEY (monitor) REFERENCES cm_monitors(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY cm_monitors
     ADD CONSTRAINT cm_monitors_changed_by_fk FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE CASCADE;
 
@@ -6435,6 +6669,15 @@ ALTER TABLE ONLY cm_slack_webhooks
 ALTER TABLE ONLY cm_slack_webhooks
     ADD CONSTRAINT cm_slack_webhooks_monitor_fkey FOREIGN KEY (monitor) REFERENCES cm_monitors(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY cm_teams_webhooks
+    ADD CONSTRAINT cm_teams_webhooks_changed_by_fkey FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY cm_teams_webhooks
+    ADD CONSTRAINT cm_teams_webhooks_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY cm_teams_webhooks
+    ADD CONSTRAINT cm_teams_webhooks_monitor_fkey FOREIGN KEY (monitor) REFERENCES cm_monitors(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY cm_trigger_jobs
     ADD CONSTRAINT cm_trigger_jobs_query_fk FOREIGN KEY (query) REFERENCES cm_queries(id) ON DELETE CASCADE;
 
@@ -6678,6 +6921,12 @@ ALTER TABLE ONLY outbound_webhooks
 ALTER TABLE ONLY outbound_webhooks
     ADD CONSTRAINT outbound_webhooks_updated_by_fkey FOREIGN KEY (updated_by) REFERENCES users(id) ON DELETE SET NULL;
 
+ALTER TABLE ONLY own_aggregate_blame_ownership
+    ADD CONSTRAINT own_aggregate_blame_ownership_commit_author_id_fkey FOREIGN KEY (commit_author_id) REFERENCES commit_authors(id);
+
+ALTER TABLE ONLY own_aggregate_blame_ownership
+    ADD CONSTRAINT own_aggregate_blame_ownership_file_path_id_fkey FOREIGN KEY (file_path_id) REFERENCES repo_paths(id);
+
 ALTER TABLE ONLY own_aggregate_recent_contribution
     ADD CONSTRAINT own_aggregate_recent_contribution_changed_file_path_id_fkey FOREIGN KEY (changed_file_path_id) REFERENCES repo_paths(id);
 
@@ -6744,6 +6993,9 @@ ALTER TABLE ONLY repo_paths
 ALTER TABLE ONLY repo_paths
     ADD CONSTRAINT repo_paths_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE;
 
+ALTER TABLE ONLY repo_update_schedules
+    ADD CONSTRAINT repo_update_schedules_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY role_permissions
     ADD CONSTRAINT role_permissions_permission_id_fkey FOREIGN KEY (permission_id) REFERENCES permissions(id) ON DELETE CASCADE DEFERRABLE;
 
@@ -6843,6 +7095,9 @@ ALTER TABLE ONLY user_roles
 ALTER TABLE ONLY user_roles
     ADD CONSTRAINT user_roles_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE DEFERRABLE;
 
+ALTER TABLE ONLY user_totp
+    ADD CONSTRAINT user_totp_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY webhook_logs
     ADD CONSTRAINT webhook_logs_external_service_id_fkey FOREIGN KEY (external_service_id) REFERENCES external_services(id) ON UPDATE CASCADE ON DELETE CASCADE;
 
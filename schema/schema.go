@@ -135,6 +135,7 @@ type AuthProviders struct {
 	Github         *GitHubAuthProvider
 	Gitlab         *GitLabAuthProvider
 	HttpHeader     *HTTPHeaderAuthProvider
+	Ldap           *LDAPAuthProvider
 	Openidconnect  *OpenIDConnectAuthProvider
 	Saml           *SAMLAuthProvider
 }
@@ -161,6 +162,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.HttpHeader != nil {
 		return json.Marshal(v.HttpHeader)
 	}
+	if v.Ldap != nil {
+		return json.Marshal(v.Ldap)
+	}
 	if v.Openidconnect != nil {
 		return json.Marshal(v.Openidconnect)
 	}
@@ -191,12 +195,22 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Gitlab)
 	case "http-header":
 		return json.Unmarshal(data, &v.HttpHeader)
+	case "ldap":
+		return json.Unmarshal(data, &v.Ldap)
 	case "openidconnect":
 		return json.Unmarshal(data, &v.Openidconnect)
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"azureDevOps", "bitbucketcloud", "builtin", "gerrit", "github", "gitlab", "http-header", "openidconnect", "saml"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"azureDevOps", "bitbucketcloud", "builtin", "gerrit", "github", "gitlab", "http-header", "ldap", "openidconnect", "saml"})
+}
+
+// AuthTOTP description: Configures TOTP-based two-factor authentication for users who sign in with the builtin username-password authentication provider. Users can enroll an authenticator app in their account security settings.
+type AuthTOTP struct {
+	// Enforcement description: Whether users must enroll a second factor. With "optional", users can choose to enroll. With "required", users without a second factor must enroll one while signing in before they can use Sourcegraph.
+	Enforcement string `json:"enforcement,omitempty"`
+	// Issuer description: The issuer name shown next to the account in authenticator apps.
+	Issuer string `json:"issuer,omitempty"`
 }
 
 // AzureDevOpsAuthProvider description: Azure auth provider for dev.azure.com
@@ -258,6 +272,8 @@ type BatchSpec struct {
 	Name string `json:"name"`
 	// On description: The set of repositories (and branches) to run the batch change on, specified as a list of search queries (that match repositories) and/or specific repositories.
 	On []any `json:"on,omitempty"`
+	// Schedule description: Re-runs the batch spec on a recurring schedule when it is executed server-side. Each run resolves the workspaces again, so that repositories newly matching `on` are picked up, and executes the steps in a new batch spec.
+	Schedule *Schedule `json:"schedule,omitempty"`
 	// Steps description: The sequence of commands to run (for each repository branch matched in the `on` property) to produce the workspace changes that will be included in the batch change.
 	Steps []*Step `json:"steps,omitempty"`
 	// TransformChanges description: Optional transformations to apply to the changes produced in each repository.
@@ -365,6 +381,8 @@ type BitbucketServerConnection struct {
 	Exclude []*ExcludedBitbucketServerRepo `json:"exclude,omitempty"`
 	// ExcludePersonalRepositories description: Whether or not personal repositories should be excluded or not. When true, Sourcegraph will ignore personal repositories it may have access to. See https://docs.sourcegraph.com/integration/bitbucket_server#excluding-personal-repositories for more information.
 	ExcludePersonalRepositories bool `json:"excludePersonalRepositories,omitempty"`
+	// GitLFS description: Fetch Git LFS objects of the default branch when cloning and fetching repositories from this Bitbucket Server instance, so that files stored in Git LFS show their content instead of the LFS pointer in search and the file view. Requires git-lfs on gitserver. See https://docs.sourcegraph.com/admin/repo/git_lfs.
+	GitLFS *BitbucketServerGitLFS `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Bitbucket Server / Bitbucket Data Center instance.
 	//
 	// If "http", Sourcegraph will access Bitbucket Server / Bitbucket Data Center repositories using Git URLs of the form http(s)://bitbucket.example.com/scm/myproject/myrepo.git (using https: if the Bitbucket Server / Bitbucket Data Center instance uses HTTPS).
@@ -411,6 +429,14 @@ type BitbucketServerConnection struct {
 	Webhooks *Webhooks `json:"webhooks,omitempty"`
 }
 
+// BitbucketServerGitLFS description: Fetch Git LFS objects of the default branch when cloning and fetching repositories from this Bitbucket Server instance, so that files stored in Git LFS show their content instead of the LFS pointer in search and the file view. Requires git-lfs on gitserver. See https://docs.sourcegraph.com/admin/repo/git_lfs.
+type BitbucketServerGitLFS struct {
+	// Enabled description: Whether to fetch Git LFS objects.
+	Enabled bool `json:"enabled"`
+	// MaxSizeMegabytes description: The maximum disk space the Git LFS objects of a single repository may use. The LFS objects of repositories exceeding it are removed, and those files show the LFS pointer again.
+	MaxSizeMegabytes int `json:"maxSizeMegabytes,omitempty"`
+}
+
 // BitbucketServerIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Server / Bitbucket Data Center identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Server / Bitbucket Data Center accounts and `auth.enableUsernameChanges` must be set to false for security reasons.
 type BitbucketServerIdentityProvider struct {
 	Username *BitbucketServerUsernameIdentity
@@ -582,7 +608,7 @@ type Completions struct {
 	CompletionModelMaxTokens int `json:"completionModelMaxTokens,omitempty"`
 	// Enabled description: DEPRECATED. Use cody.enabled instead to turn Cody on/off.
 	Enabled *bool `json:"enabled,omitempty"`
-	// Endpoint description: The endpoint under which to reach the provider. Currently only used for provider types "sourcegraph", "openai" and "anthropic". The default values are "https://cody-gateway.sourcegraph.com", "https://api.openai.com/v1/chat/completions", and "https://api.anthropic.com/v1/complete" for Sourcegraph, OpenAI, and Anthropic, respectively.
+	// Endpoint description: The endpoint under which to reach the provider. Currently only used for provider types "sourcegraph", "openai" and "anthropic". The default values are "https://cody-gateway.sourcegraph.com", "https://api.openai.com/v1/chat/completions", and "https://api.anthropic.com/v1/complete" for Sourcegraph, OpenAI, and Anthropic, respectively. For provider "openai-compatible", this is the required base URL of the server, such as "http://localhost:8000/v1".
 	Endpoint string `json:"endpoint,omitempty"`
 	// FastChatModel description: The model used for fast chat completions.
 	FastChatModel string `json:"fastChatModel,omitempty"`
@@ -590,6 +616,8 @@ type Completions struct {
 	FastChatModelMaxTokens int `json:"fastChatModelMaxTokens,omitempty"`
 	// Model description: DEPRECATED. Use chatModel instead.
 	Model string `json:"model,omitempty"`
+	// OpenAICompatible description: Settings for the "openai-compatible" provider, which talks to a self-hosted server that implements the OpenAI chat completions API.
+	OpenAICompatible *OpenAICompatibleProvider `json:"openAICompatible,omitempty"`
 	// PerUserCodeCompletionsDailyLimit description: If > 0, enables the maximum number of code completions requests allowed to be made by a single user account in a day. On instances that allow anonymous requests, the rate limit is enforced by IP.
 	PerUserCodeCompletionsDailyLimit int `json:"perUserCodeCompletionsDailyLimit,omitempty"`
 	// PerUserDailyLimit description: If > 0, enables the maximum number of completions requests allowed to be made by a single user account in a day. On instances that allow anonymous requests, the rate limit is enforced by IP.
@@ -654,11 +682,13 @@ type EmailTemplates struct {
 type Embeddings struct {
 	// AccessToken description: The access token used to authenticate with the external embedding API service. For provider sourcegraph, this is optional.
 	AccessToken string `json:"accessToken,omitempty"`
+	// ApproximateIndex description: Configures approximate nearest neighbor indexes, which make searching large repository embedding indexes faster at the cost of some recall. Repositories must be reindexed for changes to the index to take effect.
+	ApproximateIndex *EmbeddingsApproximateIndex `json:"approximateIndex,omitempty"`
 	// Dimensions description: The dimensionality of the embedding vectors. Required field if not using the sourcegraph provider.
 	Dimensions int `json:"dimensions,omitempty"`
 	// Enabled description: Toggles whether embedding service is enabled.
 	Enabled *bool `json:"enabled,omitempty"`
-	// Endpoint description: The endpoint under which to reach the provider. Sensible default will be used for each provider.
+	// Endpoint description: The endpoint under which to reach the provider. Sensible default will be used for each provider. For provider "openai-compatible", this is the base URL of the server, such as "http://localhost:8000/v1".
 	Endpoint string `json:"endpoint,omitempty"`
 	// ExcludeChunkOnError description: Whether to cancel indexing a repo if embedding a single file fails. If true, the chunk that cannot generate embeddings is not indexed and the remainder of the repository proceeds with indexing.
 	ExcludeChunkOnError *bool `json:"excludeChunkOnError,omitempty"`
@@ -676,6 +706,8 @@ type Embeddings struct {
 	MinimumInterval string `json:"minimumInterval,omitempty"`
 	// Model description: The model used for embedding. A default model will be used for each provider, if not set.
 	Model string `json:"model,omitempty"`
+	// OpenAICompatible description: Settings for the "openai-compatible" provider, which talks to a self-hosted server that implements the OpenAI embeddings API.
+	OpenAICompatible *OpenAICompatibleProvider `json:"openAICompatible,omitempty"`
 	// PolicyRepositoryMatchLimit description: The maximum number of repositories that can be matched by a global embeddings policy
 	PolicyRepositoryMatchLimit *int `json:"policyRepositoryMatchLimit,omitempty"`
 	// Provider description: The provider to use for generating embeddings. Defaults to sourcegraph.
@@ -684,6 +716,16 @@ type Embeddings struct {
 	Url string `json:"url,omitempty"`
 }
 
+// EmbeddingsApproximateIndex description: Configures approximate nearest neighbor indexes, which make searching large repository embedding indexes faster at the cost of some recall. Repositories must be reindexed for changes to the index to take effect.
+type EmbeddingsApproximateIndex struct {
+	// Enabled description: Whether to build approximate indexes when embedding repositories, and to use them when searching embeddings.
+	Enabled bool `json:"enabled,omitempty"`
+	// MinRows description: The minimum number of code or text embeddings of a repository for which an approximate index is built. Smaller embedding indexes are always searched exactly.
+	MinRows int `json:"minRows,omitempty"`
+	// Probes description: The number of clusters of an approximate index that are searched for each query. Higher values increase recall and latency. An approximate index has about as many clusters as the square root of its number of embeddings.
+	Probes int `json:"probes,omitempty"`
+}
+
 // EncryptionKey description: Config for a key
 type EncryptionKey struct {
 	Cloudkms *CloudKMSEncryptionKey
@@ -739,6 +781,7 @@ type EncryptionKeys struct {
 	GitHubAppKey           *EncryptionKey `json:"gitHubAppKey,omitempty"`
 	OutboundWebhookKey     *EncryptionKey `json:"outboundWebhookKey,omitempty"`
 	UserExternalAccountKey *EncryptionKey `json:"userExternalAccountKey,omitempty"`
+	UserTOTPKey            *EncryptionKey `json:"userTOTPKey,omitempty"`
 	WebhookKey             *EncryptionKey `json:"webhookKey,omitempty"`
 	WebhookLogKey          *EncryptionKey `json:"webhookLogKey,omitempty"`
 }
@@ -792,6 +835,18 @@ type ExcludedGitLabProject struct {
 	// Pattern description: Regular expression which matches against the name of a GitLab project ("group/name").
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedGiteaRepo struct {
+	// Archived description: If set to true, archived repositories will be excluded.
+	Archived bool `json:"archived,omitempty"`
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Id description: The ID of a Gitea repository (as returned by the Gitea API) to exclude from mirroring. Use this to exclude the repository, even if renamed.
+	Id int `json:"id,omitempty"`
+	// Name description: The name of a Gitea repository ("owner/name") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gitea repository ("owner/name").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitoliteRepo struct {
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -859,6 +914,8 @@ type ExperimentalFeatures struct {
 	JvmPackages string `json:"jvmPackages,omitempty"`
 	// NpmPackages description: Allow adding npm package code host connections
 	NpmPackages string `json:"npmPackages,omitempty"`
+	// NugetPackages description: Allow adding NuGet package host connections
+	NugetPackages string `json:"nugetPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
 	Pagure string `json:"pagure,omitempty"`
 	// PasswordPolicy description: DEPRECATED: this is now a standard feature see: auth.passwordPolicy
@@ -867,6 +924,8 @@ type ExperimentalFeatures struct {
 	Perforce string `json:"perforce,omitempty"`
 	// PerforceChangelistMapping description: Allow mapping of Perforce changelists to their commit SHAs in the DB
 	PerforceChangelistMapping string `json:"perforceChangelistMapping,omitempty"`
+	// PhpPackages description: Allow adding PHP package host connections
+	PhpPackages string `json:"phpPackages,omitempty"`
 	// PythonPackages description: Allow adding Python package code host connections
 	PythonPackages string `json:"pythonPackages,omitempty"`
 	// Ranking description: Experimental search result ranking options.
@@ -940,10 +999,12 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "insightsDataRetention")
 	delete(m, "jvmPackages")
 	delete(m, "npmPackages")
+	delete(m, "nugetPackages")
 	delete(m, "pagure")
 	delete(m, "passwordPolicy")
 	delete(m, "perforce")
 	delete(m, "perforceChangelistMapping")
+	delete(m, "phpPackages")
 	delete(m, "pythonPackages")
 	delete(m, "ranking")
 	delete(m, "rateLimitAnonymous")
@@ -1146,6 +1207,8 @@ type GitHubConnection struct {
 	Exclude []*ExcludedGitHubRepo `json:"exclude,omitempty"`
 	// GitHubAppDetails description: If non-null, this is a GitHub App connection with some additional properties.
 	GitHubAppDetails *GitHubAppDetails `json:"gitHubAppDetails,omitempty"`
+	// GitLFS description: Fetch Git LFS objects of the default branch when cloning and fetching repositories from this GitHub instance, so that files stored in Git LFS show their content instead of the LFS pointer in search and the file view. Requires git-lfs on gitserver. See https://docs.sourcegraph.com/admin/repo/git_lfs.
+	GitLFS *GitHubGitLFS `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this GitHub instance.
 	//
 	// If "http", Sourcegraph will access GitHub repositories using Git URLs of the form http(s)://github.com/myteam/myproject.git (using https: if the GitHub instance uses HTTPS).
@@ -1195,6 +1258,14 @@ type GitHubConnection struct {
 	Webhooks []*GitHubWebhook `json:"webhooks,omitempty"`
 }
 
+// GitHubGitLFS description: Fetch Git LFS objects of the default branch when cloning and fetching repositories from this GitHub instance, so that files stored in Git LFS show their content instead of the LFS pointer in search and the file view. Requires git-lfs on gitserver. See https://docs.sourcegraph.com/admin/repo/git_lfs.
+type GitHubGitLFS struct {
+	// Enabled description: Whether to fetch Git LFS objects.
+	Enabled bool `json:"enabled"`
+	// MaxSizeMegabytes description: The maximum disk space the Git LFS objects of a single repository may use. The LFS objects of repositories exceeding it are removed, and those files show the LFS pointer again.
+	MaxSizeMegabytes int `json:"maxSizeMegabytes,omitempty"`
+}
+
 // GitHubRateLimit description: Rate limit applied when making background API requests to GitHub.
 type GitHubRateLimit struct {
 	// Enabled description: true if rate limiting is enabled.
@@ -1252,6 +1323,8 @@ type GitLabConnection struct {
 	CloudGlobal bool `json:"cloudGlobal,omitempty"`
 	// Exclude description: A list of projects to never mirror from this GitLab instance. Takes precedence over "projects" and "projectQuery" configuration. Supports excluding by name ({"name": "group/name"}) or by ID ({"id": 42}).
 	Exclude []*ExcludedGitLabProject `json:"exclude,omitempty"`
+	// GitLFS description: Fetch Git LFS objects of the default branch when cloning and fetching repositories from this GitLab instance, so that files stored in Git LFS show their content instead of the LFS pointer in search and the file view. Requires git-lfs on gitserver. See https://docs.sourcegraph.com/admin/repo/git_lfs.
+	GitLFS *GitLabGitLFS `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this GitLab instance.
 	//
 	// If "http", Sourcegraph will access GitLab repositories using Git URLs of the form http(s)://gitlab.example.com/myteam/myproject.git (using https: if the GitLab instance uses HTTPS).
@@ -1289,6 +1362,14 @@ type GitLabConnection struct {
 	// Webhooks description: An array of webhook configurations
 	Webhooks []*GitLabWebhook `json:"webhooks,omitempty"`
 }
+
+// GitLabGitLFS description: Fetch Git LFS objects of the default branch when cloning and fetching repositories from this GitLab instance, so that files stored in Git LFS show their content instead of the LFS pointer in search and the file view. Requires git-lfs on gitserver. See https://docs.sourcegraph.com/admin/repo/git_lfs.
+type GitLabGitLFS struct {
+	// Enabled description: Whether to fetch Git LFS objects.
+	Enabled bool `json:"enabled"`
+	// MaxSizeMegabytes description: The maximum disk space the Git LFS objects of a single repository may use. The LFS objects of repositories exceeding it are removed, and those files show the LFS pointer again.
+	MaxSizeMegabytes int `json:"maxSizeMegabytes,omitempty"`
+}
 type GitLabNameTransformation struct {
 	// Regex description: The regex to match for the occurrences of its replacement.
 	Regex string `json:"regex,omitempty"`
@@ -1324,6 +1405,60 @@ type GitRecorder struct {
 	Size int `json:"size,omitempty"`
 }
 
+// GiteaAuthorization description: If non-null, enforces Gitea repository permissions. Sourcegraph users are matched to the Gitea users they signed in with through an OpenID Connect auth provider whose issuer is this Gitea instance, and the repositories each user can access are fetched by impersonating them with the `Sudo` header, so the token must belong to a Gitea site administrator.
+type GiteaAuthorization struct {
+}
+
+// GiteaConnection description: Configuration for a connection to Gitea or Forgejo.
+type GiteaConnection struct {
+	// Authorization description: If non-null, enforces Gitea repository permissions. Sourcegraph users are matched to the Gitea users they signed in with through an OpenID Connect auth provider whose issuer is this Gitea instance, and the repositories each user can access are fetched by impersonating them with the `Sudo` header, so the token must belong to a Gitea site administrator.
+	Authorization *GiteaAuthorization `json:"authorization,omitempty"`
+	// Certificate description: TLS certificate of the Gitea instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
+	Certificate string `json:"certificate,omitempty"`
+	// Exclude description: A list of repositories to never mirror from this Gitea instance. Takes precedence over "orgs", "repos", and "repositoryQuery" configuration.
+	Exclude []*ExcludedGiteaRepo `json:"exclude,omitempty"`
+	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Gitea instance.
+	//
+	// If "http", Sourcegraph will access Gitea repositories using Git URLs of the form https://gitea.example.com/myorg/myrepo.git.
+	//
+	// If "ssh", Sourcegraph will access Gitea repositories using Git URLs of the form git@gitea.example.com:myorg/myrepo.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
+	GitURLType string `json:"gitURLType,omitempty"`
+	// Orgs description: An array of organization names identifying Gitea organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// RateLimit description: Rate limit applied when making API requests to Gitea.
+	RateLimit *GiteaRateLimit `json:"rateLimit,omitempty"`
+	// Repos description: An array of repository "owner/name" strings specifying which Gitea repositories to mirror on Sourcegraph.
+	Repos []string `json:"repos,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Gitea repository. In the pattern, the variable "{host}" is replaced with the Gitea URL's host (such as gitea.example.com), and "{nameWithOwner}" is replaced with the Gitea repository's "owner/name" (such as "myorg/myrepo").
+	//
+	// For example, if your Gitea is https://gitea.example.com and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{nameWithOwner}" would mean that a Gitea repository at https://gitea.example.com/myorg/myrepo is available on Sourcegraph at https://src.example.com/gitea.example.com/myorg/myrepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// RepositoryQuery description: An array of strings specifying which Gitea repositories to mirror on Sourcegraph. The valid values are:
+	//
+	// - `all` mirrors all repositories visible to the token.
+	//
+	// - `affiliated` mirrors all repositories the token's user owns or is a collaborator or organization member of.
+	//
+	// - `none` mirrors no repositories (except those specified in the `orgs` or `repos` configuration property).
+	//
+	// If the value is any other string, it is used as a keyword to search for repositories.
+	RepositoryQuery []string `json:"repositoryQuery,omitempty"`
+	// Token description: An access token for the Gitea instance. It needs the `read:repository` and `read:organization` scopes to mirror repositories, and the `read:user` and `read:admin` scopes when `authorization` is enabled.
+	Token string `json:"token"`
+	// Url description: URL of a Gitea or Forgejo instance, such as https://gitea.example.com.
+	Url string `json:"url"`
+}
+
+// GiteaRateLimit description: Rate limit applied when making API requests to Gitea.
+type GiteaRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // Github description: GitHub configuration, both for queries and receiving release webhooks.
 type Github struct {
 	// Repository description: The repository to get the latest version of.
@@ -1436,6 +1571,65 @@ type JVMPackagesConnection struct {
 	Maven Maven `json:"maven"`
 }
 
+// LDAPAttributes description: The attributes of user entries that Sourcegraph user properties are read from.
+type LDAPAttributes struct {
+	// DisplayName description: The attribute holding the display name of users.
+	DisplayName string `json:"displayName,omitempty"`
+	// Email description: The attribute holding the email of users.
+	Email string `json:"email,omitempty"`
+	// Username description: The attribute that users sign in with, and their Sourcegraph username is derived from. Use sAMAccountName for Active Directory.
+	Username string `json:"username,omitempty"`
+}
+
+// LDAPAuthProvider description: Configures the LDAP authentication provider, which authenticates users with the username and password of their entry in an LDAP directory such as OpenLDAP or Active Directory.
+type LDAPAuthProvider struct {
+	// AllowSignup description: Allows new visitors to sign up for accounts via LDAP authentication. If false, users signing in via LDAP must have an existing Sourcegraph account with the same verified email, which will be linked to their LDAP identity after sign-in. This requires trustEmailAttribute.
+	AllowSignup *bool `json:"allowSignup,omitempty"`
+	// Attributes description: The attributes of user entries that Sourcegraph user properties are read from.
+	Attributes *LDAPAttributes `json:"attributes,omitempty"`
+	// BindDN description: The DN of the service account used to search for users and groups. Searches are anonymous if it is not set.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPassword description: The password of the service account.
+	BindPassword  string  `json:"bindPassword,omitempty"`
+	DisplayName   string  `json:"displayName,omitempty"`
+	DisplayPrefix *string `json:"displayPrefix,omitempty"`
+	// GroupSync description: Synchronizes the membership of LDAP groups into Sourcegraph organizations and teams when users sign in. Users are added to the organizations and teams of their groups, and removed from the organizations and teams that are mapped to groups they are no longer a member of. Organizations and teams must already exist.
+	GroupSync *LDAPGroupSync `json:"groupSync,omitempty"`
+	Hidden    bool           `json:"hidden,omitempty"`
+	Order     int            `json:"order,omitempty"`
+	// StartTLS description: Upgrades connections to ldap:// URLs to TLS with the StartTLS operation before sending credentials.
+	StartTLS bool `json:"startTLS,omitempty"`
+	// TlsCACertificate description: The PEM-encoded certificate of the certificate authority that issued the certificate of the LDAP server, when it is not trusted by the system.
+	TlsCACertificate string `json:"tlsCACertificate,omitempty"`
+	// TlsInsecureSkipVerify description: Skips the verification of the certificate of the LDAP server. Only use this for testing.
+	TlsInsecureSkipVerify bool `json:"tlsInsecureSkipVerify,omitempty"`
+	// TrustEmailAttribute description: Treats the emails read from the email attribute as verified. Users signing in via LDAP for the first time are then linked to the existing Sourcegraph account with the same verified email. Only enable this if users cannot change the email attribute of their own entry.
+	TrustEmailAttribute bool   `json:"trustEmailAttribute,omitempty"`
+	Type                string `json:"type"`
+	// Url description: The URL of the LDAP server. Use the ldaps scheme to connect with TLS.
+	Url string `json:"url"`
+	// UserFilter description: The LDAP filter that entries must match to be users that can sign in. It is combined with a match of the username attribute with the username that signs in.
+	UserFilter string `json:"userFilter,omitempty"`
+	// UserSearchBase description: The DN of the subtree users are searched in.
+	UserSearchBase string `json:"userSearchBase"`
+}
+
+// LDAPGroupSync description: Synchronizes the membership of LDAP groups into Sourcegraph organizations and teams when users sign in. Users are added to the organizations and teams of their groups, and removed from the organizations and teams that are mapped to groups they are no longer a member of. Organizations and teams must already exist.
+type LDAPGroupSync struct {
+	// GroupFilter description: The LDAP filter that entries must match to be groups. It is combined with a match of the member attribute with the DN of the user.
+	GroupFilter string `json:"groupFilter,omitempty"`
+	// GroupSearchBase description: The DN of the subtree groups are searched in.
+	GroupSearchBase string `json:"groupSearchBase"`
+	// MemberAttribute description: The attribute of group entries that holds the DNs of their members.
+	MemberAttribute string `json:"memberAttribute,omitempty"`
+	// NameAttribute description: The attribute of group entries that holds their name.
+	NameAttribute string `json:"nameAttribute,omitempty"`
+	// Orgs description: Maps the names of LDAP groups to the names of the Sourcegraph organizations their members belong to.
+	Orgs map[string]string `json:"orgs,omitempty"`
+	// Teams description: Maps the names of LDAP groups to the names of the Sourcegraph teams their members belong to.
+	Teams map[string]string `json:"teams,omitempty"`
+}
+
 // LocalGitExternalService description: Configuration for integration local Git repositories.
 type LocalGitExternalService struct {
 	Repos []*LocalGitRepoPattern `json:"repos,omitempty"`
@@ -1629,6 +1823,24 @@ type NpmRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// NuGetPackagesConnection description: Configuration for a connection to NuGet packages
+type NuGetPackagesConnection struct {
+	// Dependencies description: An array of strings specifying NuGet packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the configured NuGet feed.
+	RateLimit *NuGetRateLimit `json:"rateLimit,omitempty"`
+	// ServiceIndex description: The URL of the NuGet V3 service index of the feed to download packages from.
+	ServiceIndex string `json:"serviceIndex,omitempty"`
+}
+
+// NuGetRateLimit description: Rate limit applied when making background API requests to the configured NuGet feed.
+type NuGetRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type OAuthIdentity struct {
 	Type string `json:"type"`
 }
@@ -1676,6 +1888,16 @@ type OnRepository struct {
 	Repository string `json:"repository"`
 }
 
+// OpenAICompatibleProvider description: Settings for servers that implement the OpenAI API, such as self-hosted model servers.
+type OpenAICompatibleProvider struct {
+	// AuthHeader description: The HTTP header in which the access token is sent.
+	AuthHeader string `json:"authHeader,omitempty"`
+	// AuthHeaderPrefix description: The prefix of the access token in the auth header. Set it to an empty string to send the access token as is.
+	AuthHeaderPrefix *string `json:"authHeaderPrefix,omitempty"`
+	// ModelMapping description: Maps the names of the configured models to the model names that are sent to the server. Models that are not mapped are sent as configured.
+	ModelMapping map[string]string `json:"modelMapping,omitempty"`
+}
+
 // OpenIDConnectAuthProvider description: Configures the OpenID Connect authentication provider for SSO.
 type OpenIDConnectAuthProvider struct {
 	// AllowSignup description: Allows new visitors to sign up for accounts via OpenID Connect authentication. If false, users signing in via OpenID Connect must have an existing Sourcegraph account, which will be linked to their OpenID Connect identity after sign-in.
@@ -1726,7 +1948,9 @@ type OrganizationInvitations struct {
 type OtherExternalServiceConnection struct {
 	// Exclude description: A list of repositories to never mirror by name after applying repositoryPathPattern. Supports excluding by exact name ({"name": "myrepo"}) or regular expression ({"pattern": ".*secret.*"}).
 	Exclude []*ExcludedOtherRepo `json:"exclude,omitempty"`
-	Repos   []string             `json:"repos"`
+	// GitLFS description: Fetch Git LFS objects of the default branch when cloning and fetching repositories from this code host, so that files stored in Git LFS show their content instead of the LFS pointer in search and the file view. Requires git-lfs on gitserver. See https://docs.sourcegraph.com/admin/repo/git_lfs.
+	GitLFS *OtherGitLFS `json:"gitLFS,omitempty"`
+	Repos  []string     `json:"repos"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Git clone base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
 	//
 	// For example, if your Git clone base URL is https://git.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://git.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/git.example.com/repos/my/repo.
@@ -1739,6 +1963,14 @@ type OtherExternalServiceConnection struct {
 	Root string `json:"root,omitempty"`
 	Url  string `json:"url,omitempty"`
 }
+
+// OtherGitLFS description: Fetch Git LFS objects of the default branch when cloning and fetching repositories from this code host, so that files stored in Git LFS show their content instead of the LFS pointer in search and the file view. Requires git-lfs on gitserver. See https://docs.sourcegraph.com/admin/repo/git_lfs.
+type OtherGitLFS struct {
+	// Enabled description: Whether to fetch Git LFS objects.
+	Enabled bool `json:"enabled"`
+	// MaxSizeMegabytes description: The maximum disk space the Git LFS objects of a single repository may use. The LFS objects of repositories exceeding it are removed, and those files show the LFS pointer again.
+	MaxSizeMegabytes int `json:"maxSizeMegabytes,omitempty"`
+}
 type OutputVariable struct {
 	// Format description: The expected format of the output. If set, the output is being parsed in that format before being stored in the var. If not set, 'text' is assumed to the format.
 	Format string `json:"format,omitempty"`
@@ -1746,6 +1978,24 @@ type OutputVariable struct {
 	Value string `json:"value"`
 }
 
+// PHPPackagesConnection description: Configuration for a connection to PHP packages
+type PHPPackagesConnection struct {
+	// Dependencies description: An array of strings specifying PHP packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the configured Composer repository.
+	RateLimit *PHPRateLimit `json:"rateLimit,omitempty"`
+	// Repository description: The URL of the Composer repository to download packages from. It must serve the metadata of packages at p2/<vendor>/<package>.json, like Packagist.
+	Repository string `json:"repository,omitempty"`
+}
+
+// PHPRateLimit description: Rate limit applied when making background API requests to the configured Composer repository.
+type PHPRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // PagureConnection description: Configuration for a connection to Pagure.
 type PagureConnection struct {
 	// Forks description: If true, it includes forks in the returned projects.
@@ -2030,6 +2280,16 @@ type SMTPServerConfig struct {
 	// Username description: The username to use when communicating with the SMTP server.
 	Username string `json:"username,omitempty"`
 }
+
+// Schedule description: Re-runs the batch spec on a recurring schedule when it is executed server-side. Each run resolves the workspaces again, so that repositories newly matching `on` are picked up, and executes the steps in a new batch spec.
+type Schedule struct {
+	// AutoApply description: Whether the batch spec of a run is applied to the batch change automatically once it has been executed. With `never`, the new batch spec has to be previewed and applied manually.
+	AutoApply string `json:"autoApply,omitempty"`
+	// Cron description: A cron expression that defines when the batch spec is re-run, in UTC.
+	Cron string `json:"cron"`
+	// MaxNewChangesets description: The maximum number of new changesets a run may create when it is applied automatically. Runs that would create more changesets are not applied and wait for manual review instead. Unlimited if unset; 0 only applies runs that create no new changesets.
+	MaxNewChangesets *int `json:"maxNewChangesets,omitempty"`
+}
 type SearchIndexRevisionsRule struct {
 	// Name description: Regular expression which matches against the name of a repository (e.g. "^github\.com/owner/name$").
 	Name string `json:"name,omitempty"`
@@ -2431,6 +2691,8 @@ type SiteConfiguration struct {
 	//   ```
 	//
 	AuthSessionExpiry string `json:"auth.sessionExpiry,omitempty"`
+	// AuthTotp description: Configures TOTP-based two-factor authentication for users who sign in with the builtin username-password authentication provider. Users can enroll an authenticator app in their account security settings.
+	AuthTotp *AuthTOTP `json:"auth.totp,omitempty"`
 	// AuthUnlockAccountLinkExpiry description: Validity expressed in minutes of the unlock account token
 	AuthUnlockAccountLinkExpiry int `json:"auth.unlockAccountLinkExpiry,omitempty"`
 	// AuthUnlockAccountLinkSigningKey description: Base64-encoded HMAC signing key to sign the JWT token for account unlock URLs
@@ -2455,6 +2717,8 @@ type SiteConfiguration struct {
 	BatchChangesRestrictToAdmins *bool `json:"batchChanges.restrictToAdmins,omitempty"`
 	// BatchChangesRolloutWindows description: Specifies specific windows, which can have associated rate limits, to be used when reconciling published changesets (creating or updating). All days and times are handled in UTC.
 	BatchChangesRolloutWindows *[]*BatchChangeRolloutWindow `json:"batchChanges.rolloutWindows,omitempty"`
+	// BatchChangesSharedStepCache description: Share the results of server-side batch spec execution steps between users through the blobstore. A step's cached result is reused by any user whose execution has the same repository revision, steps, container image digests, step environment and mounted files. Only steps whose container images are pinned by digest (e.g. alpine@sha256:...) are shared.
+	BatchChangesSharedStepCache bool `json:"batchChanges.sharedStepCache,omitempty"`
 	// Branding description: Customize Sourcegraph homepage logo and search icon.
 	//
 	// Only available in Sourcegraph Enterprise.
@@ -2656,6 +2920,8 @@ type SiteConfiguration struct {
 	ScimAuthToken string `json:"scim.authToken,omitempty"`
 	// ScimIdentityProvider description: Identity provider used for SCIM support.  "STANDARD" should be used unless a more specific value is available
 	ScimIdentityProvider string `json:"scim.identityProvider,omitempty"`
+	// SearchArchiveExtensions description: A list of file extensions of archives (such as .jar, .zip and .tar.gz files) committed to repositories whose members searcher should expand and search. Matching members are reported with paths like lib/foo.jar!/META-INF/MANIFEST.MF. Archives nested inside expanded archives are expanded as well, subject to size and depth limits. Archives are not expanded by default.
+	SearchArchiveExtensions []string `json:"search.archiveExtensions,omitempty"`
 	// SearchIndexSymbolsEnabled description: Whether indexed symbol search is enabled. This is contingent on the indexed search configuration, and is true by default for instances with indexed search enabled. Enabling this will cause every repository to re-index, which is a time consuming (several hours) operation. Additionally, it requires more storage and ram to accommodate the added symbols information in the search index.
 	SearchIndexSymbolsEnabled *bool `json:"search.index.symbols.enabled,omitempty"`
 	// SearchLargeFiles description: A list of file glob patterns where matching files will be indexed and searched regardless of their size. Files still need to be valid utf-8 to be indexed. The glob pattern syntax can be found here: https://github.com/bmatcuk/doublestar#patterns.
@@ -2714,6 +2980,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "auth.providers")
 	delete(m, "auth.public")
 	delete(m, "auth.sessionExpiry")
+	delete(m, "auth.totp")
 	delete(m, "auth.unlockAccountLinkExpiry")
 	delete(m, "auth.unlockAccountLinkSigningKey")
 	delete(m, "auth.userOrgMap")
@@ -2726,6 +2993,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "batchChanges.enforceForks")
 	delete(m, "batchChanges.restrictToAdmins")
 	delete(m, "batchChanges.rolloutWindows")
+	delete(m, "batchChanges.sharedStepCache")
 	delete(m, "branding")
 	delete(m, "cloneProgress.log")
 	delete(m, "codeIntelAutoIndexing.allowGlobalPolicies")
@@ -2825,6 +3093,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "repositories")
 	delete(m, "scim.authToken")
 	delete(m, "scim.identityProvider")
+	delete(m, "search.archiveExtensions")
 	delete(m, "search.index.symbols.enabled")
 	delete(m, "search.largeFiles")
 	delete(m, "search.limits")